@@ -34,6 +34,15 @@ func NewAccountFromSigner(signer crypto.Signer, accountAddress ...AccountAddress
 	return types.NewAccountFromSigner(signer, accountAddress...)
 }
 
+// NewAccountFromPrivateKeyString creates an account from an AIP-80 private key string, e.g. as exported by the Aptos
+// CLI or TypeScript SDK.  Ed25519 keys create legacy Ed25519 accounts, and Secp256k1 keys create single key accounts.
+// If strict is false, plain hex Ed25519 keys are accepted too.
+//
+//	account, err := NewAccountFromPrivateKeyString("ed25519-priv-0x...", true)
+func NewAccountFromPrivateKeyString(privateKey string, strict bool, accountAddress ...AccountAddress) (*Account, error) {
+	return types.NewAccountFromPrivateKeyString(privateKey, strict, accountAddress...)
+}
+
 // NewEd25519Account creates a legacy Ed25519 account, this is most commonly used in wallets
 func NewEd25519Account() (*Account, error) {
 	return types.NewEd25519Account()
@@ -0,0 +1,184 @@
+package aptos
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// accountCoinsDataPageSize is the number of balances fetched per indexer request by [IndexerClient.GetAccountCoinsData]
+const accountCoinsDataPageSize = 100
+
+// AccountCoinData is the balance of a coin or fungible asset held by an account, with the asset's metadata
+type AccountCoinData struct {
+	AssetType                string    // AssetType is the coin type e.g. 0x1::aptos_coin::AptosCoin, or the fungible asset metadata address
+	Amount                   uint64    // Amount held, in the smallest unit of the asset
+	TokenStandard            string    // TokenStandard is "v1" for coins, and "v2" for fungible assets
+	IsPrimary                bool      // IsPrimary is true if the balance is in the account's primary store
+	IsFrozen                 bool      // IsFrozen is true if the store is frozen
+	LastTransactionVersion   uint64    // LastTransactionVersion is the version the balance last changed
+	LastTransactionTimestamp time.Time // LastTransactionTimestamp is the time the balance last changed
+
+	Name     string // Name of the asset e.g. Aptos Coin
+	Symbol   string // Symbol of the asset e.g. APT
+	Decimals uint8  // Decimals of the asset e.g. 8
+	IconUri  string // IconUri is the asset's icon, may be empty
+}
+
+// Asset parses the [AccountCoinData.AssetType] into an [Asset]
+func (data *AccountCoinData) Asset() (Asset, error) {
+	return ParseAsset(data.AssetType)
+}
+
+// BalanceAmount is the balance as an [Amount], labeled with the asset's decimals
+func (data *AccountCoinData) BalanceAmount() (Amount, error) {
+	asset, err := data.Asset()
+	if err != nil {
+		return Amount{}, err
+	}
+	return NewAmount(asset, data.Amount, data.Decimals), nil
+}
+
+// GetAccountCoinsData gets every non-zero coin and fungible asset balance of owner, with the name, symbol, and decimals
+// of each asset, in one call.  Balances are ordered by asset type.
+//
+//	balances, err := client.GetAccountCoinsData(owner)
+//	for _, balance := range balances {
+//		amount, _ := balance.BalanceAmount()
+//		fmt.Printf("%s %s\n", amount.String(), balance.Symbol)
+//	}
+func (ic *IndexerClient) GetAccountCoinsData(owner AccountAddress) ([]AccountCoinData, error) {
+	var out []AccountCoinData
+	for offset := 0; ; offset += accountCoinsDataPageSize {
+		var q struct {
+			CurrentFungibleAssetBalances []struct {
+				AssetType                string `graphql:"asset_type"`
+				Amount                   uint64 `graphql:"amount"`
+				TokenStandard            string `graphql:"token_standard"`
+				IsPrimary                bool   `graphql:"is_primary"`
+				IsFrozen                 bool   `graphql:"is_frozen"`
+				LastTransactionVersion   uint64 `graphql:"last_transaction_version"`
+				LastTransactionTimestamp string `graphql:"last_transaction_timestamp"`
+				Metadata                 *struct {
+					Name     string  `graphql:"name"`
+					Symbol   string  `graphql:"symbol"`
+					Decimals uint8   `graphql:"decimals"`
+					IconUri  *string `graphql:"icon_uri"`
+				} `graphql:"metadata"`
+			} `graphql:"current_fungible_asset_balances(where: {owner_address: {_eq: $address}, amount: {_gt: 0}}, order_by: [{asset_type: asc}, {storage_id: asc}], limit: $limit, offset: $offset)"`
+		}
+		variables := map[string]any{
+			"address": owner.StringLong(),
+			"limit":   accountCoinsDataPageSize,
+			"offset":  offset,
+		}
+		err := ic.Query(&q, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, balance := range q.CurrentFungibleAssetBalances {
+			data := AccountCoinData{
+				AssetType:              balance.AssetType,
+				Amount:                 balance.Amount,
+				TokenStandard:          balance.TokenStandard,
+				IsPrimary:              balance.IsPrimary,
+				IsFrozen:               balance.IsFrozen,
+				LastTransactionVersion: balance.LastTransactionVersion,
+			}
+			data.LastTransactionTimestamp, err = time.Parse(indexerTimeLayout, balance.LastTransactionTimestamp)
+			if err != nil {
+				return nil, fmt.Errorf("bad timestamp %s for %s: %w", balance.LastTransactionTimestamp, balance.AssetType, err)
+			}
+			if metadata := balance.Metadata; metadata != nil {
+				data.Name = metadata.Name
+				data.Symbol = metadata.Symbol
+				data.Decimals = metadata.Decimals
+				if metadata.IconUri != nil {
+					data.IconUri = *metadata.IconUri
+				}
+			}
+			out = append(out, data)
+		}
+		if len(q.CurrentFungibleAssetBalances) < accountCoinsDataPageSize {
+			return out, nil
+		}
+	}
+}
+
+// GetAccountCoinsData gets every non-zero coin and fungible asset balance of owner, with the metadata of each asset.
+//
+// Without an indexer, balances are read from the node instead, which is slower.  The node can't list the fungible
+// stores of an account, so only coins and APT in the primary store are found, and LastTransactionVersion and
+// LastTransactionTimestamp aren't set.
+func (client *Client) GetAccountCoinsData(owner AccountAddress) ([]AccountCoinData, error) {
+	if client.indexerClient == nil {
+		warnIndexerFallback("GetAccountCoinsData")
+		return client.accountCoinsDataFromNode(owner)
+	}
+	return client.indexerClient.GetAccountCoinsData(owner)
+}
+
+// accountCoinsDataFromNode gets the coin balances of owner, and its APT primary store balance, from the node
+func (client *Client) accountCoinsDataFromNode(owner AccountAddress) ([]AccountCoinData, error) {
+	balances, err := client.nodeClient.coinBalancesFromNode(owner)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]AccountCoinData, 0, len(balances)+1)
+	for _, balance := range balances {
+		if balance.Amount == 0 {
+			continue
+		}
+		// The coin's metadata is in its CoinInfo, at the address that published it
+		coinAddress := AccountAddress{}
+		err = coinAddress.ParseStringRelaxed(strings.SplitN(balance.CoinType, "::", 2)[0])
+		if err != nil {
+			return nil, fmt.Errorf("bad coin type %s: %w", balance.CoinType, err)
+		}
+		info, err := client.nodeClient.AccountResource(coinAddress, "0x1::coin::CoinInfo<"+balance.CoinType+">")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get coin info of %s: %w", balance.CoinType, err)
+		}
+		data := AccountCoinData{AssetType: balance.CoinType, Amount: balance.Amount, TokenStandard: "v1", IsPrimary: true}
+		data.Name, data.Symbol, data.Decimals, _ = assetMetadataFromResource(info)
+		out = append(out, data)
+	}
+
+	aptMetadata := AccountAddress{31: 0xa}
+	amount, err := client.Balance(owner, FungibleAsset(aptMetadata))
+	if err != nil {
+		return nil, err
+	}
+	if amount.Value > 0 {
+		metadata, err := client.nodeClient.AccountResource(aptMetadata, "0x1::fungible_asset::Metadata")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metadata of %s: %w", aptMetadata.String(), err)
+		}
+		data := AccountCoinData{AssetType: aptMetadata.StringLong(), Amount: amount.Value, TokenStandard: "v2", IsPrimary: true}
+		data.Name, data.Symbol, data.Decimals, data.IconUri = assetMetadataFromResource(metadata)
+		out = append(out, data)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].AssetType < out[j].AssetType
+	})
+	return out, nil
+}
+
+// assetMetadataFromResource reads the name, symbol, decimals, and icon of a CoinInfo or fungible asset Metadata
+// resource
+func assetMetadataFromResource(resource map[string]any) (name string, symbol string, decimals uint8, iconUri string) {
+	data, ok := resource["data"].(map[string]any)
+	if !ok {
+		data = resource
+	}
+	name, _ = data["name"].(string)
+	symbol, _ = data["symbol"].(string)
+	if value, ok := data["decimals"].(float64); ok {
+		decimals = uint8(value)
+	}
+	iconUri, _ = data["icon_uri"].(string)
+	return name, symbol, decimals, iconUri
+}
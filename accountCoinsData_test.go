@@ -0,0 +1,111 @@
+package aptos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetAccountCoinsData(t *testing.T) {
+	owner := AccountAddress{}
+	assert.NoError(t, owner.ParseStringRelaxed("0xa11ce"))
+
+	requests := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string
+			Variables map[string]any
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Contains(t, request.Query, "metadata{name,symbol,decimals,icon_uri}")
+		assert.Equal(t, owner.StringLong(), request.Variables["address"])
+		assert.Equal(t, float64(accountCoinsDataPageSize), request.Variables["limit"])
+		requests++
+
+		switch request.Variables["offset"] {
+		case float64(0):
+			// A full page, so the next page is fetched
+			balances := []string{`{
+				"asset_type": "0x1::aptos_coin::AptosCoin",
+				"amount": 150000000,
+				"token_standard": "v1",
+				"is_primary": true,
+				"is_frozen": false,
+				"last_transaction_version": 1234,
+				"last_transaction_timestamp": "2024-05-06T07:08:09.5",
+				"metadata": {"name": "Aptos Coin", "symbol": "APT", "decimals": 8, "icon_uri": null}
+			}`}
+			for i := 1; i < accountCoinsDataPageSize; i++ {
+				balances = append(balances, fmt.Sprintf(`{
+					"asset_type": "0x%x",
+					"amount": %d,
+					"token_standard": "v2",
+					"is_primary": true,
+					"is_frozen": false,
+					"last_transaction_version": 1,
+					"last_transaction_timestamp": "2024-05-06T07:08:09",
+					"metadata": {"name": "Token", "symbol": "TOK", "decimals": 6, "icon_uri": "https://example.com/tok.png"}
+				}`, 0x1000+i, i))
+			}
+			_, _ = w.Write([]byte(`{"data": {"current_fungible_asset_balances": [` + strings.Join(balances, ",") + `]}}`))
+		case float64(accountCoinsDataPageSize):
+			_, _ = w.Write([]byte(`{"data": {"current_fungible_asset_balances": [{
+				"asset_type": "0xbad",
+				"amount": 5,
+				"token_standard": "v2",
+				"is_primary": false,
+				"is_frozen": true,
+				"last_transaction_version": 2,
+				"last_transaction_timestamp": "2024-05-06T07:08:09",
+				"metadata": null
+			}]}}`))
+		default:
+			t.Errorf("unexpected offset %v", request.Variables["offset"])
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1", IndexerUrl: mockServer.URL + "/graphql"})
+	assert.NoError(t, err)
+	balances, err := client.GetAccountCoinsData(owner)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Len(t, balances, accountCoinsDataPageSize+1)
+
+	apt := balances[0]
+	assert.Equal(t, "0x1::aptos_coin::AptosCoin", apt.AssetType)
+	assert.Equal(t, uint64(150000000), apt.Amount)
+	assert.Equal(t, "v1", apt.TokenStandard)
+	assert.True(t, apt.IsPrimary)
+	assert.Equal(t, uint64(1234), apt.LastTransactionVersion)
+	assert.Equal(t, time.Date(2024, 5, 6, 7, 8, 9, 500000000, time.UTC), apt.LastTransactionTimestamp)
+	assert.Equal(t, "Aptos Coin", apt.Name)
+	assert.Equal(t, "APT", apt.Symbol)
+	assert.Equal(t, uint8(8), apt.Decimals)
+	assert.Equal(t, "", apt.IconUri)
+	amount, err := apt.BalanceAmount()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", amount.String())
+	assert.True(t, AptAsset.Equal(amount.Asset))
+
+	token := balances[1]
+	assert.Equal(t, "TOK", token.Symbol)
+	assert.Equal(t, "https://example.com/tok.png", token.IconUri)
+	asset, err := token.Asset()
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", token.TokenStandard)
+	assert.False(t, asset.Equal(AptAsset))
+
+	// Missing metadata is left empty
+	last := balances[accountCoinsDataPageSize]
+	assert.Equal(t, uint64(5), last.Amount)
+	assert.True(t, last.IsFrozen)
+	assert.Equal(t, "", last.Symbol)
+	assert.Equal(t, uint8(0), last.Decimals)
+}
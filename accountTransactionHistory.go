@@ -0,0 +1,306 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// DefaultAccountTransactionsPageSize is the number of transactions returned by [IndexerClient.GetAccountTransactions]
+// if no limit is given
+const DefaultAccountTransactionsPageSize = 25
+
+// accountTransactionsNodePageSize is the number of transactions fetched per node request without an indexer
+const accountTransactionsNodePageSize = 100
+
+// AccountTransactionKind is the kind of transaction in an account's history, as far as the indexer can tell
+type AccountTransactionKind string
+
+const (
+	AccountTransactionKindUser   AccountTransactionKind = "user"   // AccountTransactionKindUser is a transaction submitted by a user
+	AccountTransactionKindSystem AccountTransactionKind = "system" // AccountTransactionKindSystem is a transaction written by the chain e.g. block metadata
+)
+
+// AccountTransactionsOptions filters and pages [IndexerClient.GetAccountTransactions]
+type AccountTransactionsOptions struct {
+	Cursor        *uint64                // Cursor is the version of the last transaction of the previous page, only transactions past it are returned
+	Ascending     bool                   // Ascending returns the oldest transactions first, the default is newest first
+	Kind          AccountTransactionKind // Kind only returns transactions of the kind, if set
+	EntryFunction string                 // EntryFunction only returns transactions calling the function e.g. 0x1::aptos_account::transfer, if set
+	SentOnly      bool                   // SentOnly only returns transactions sent by the account, rather than all that touched it
+	Limit         int                    // Limit is the page size, defaults to [DefaultAccountTransactionsPageSize]
+}
+
+// AccountTransactionSummary is a transaction in an account's history.  The fields other than Version and Kind are
+// only set for [AccountTransactionKindUser].
+type AccountTransactionSummary struct {
+	Version                    uint64                 // Version of the transaction
+	Kind                       AccountTransactionKind // Kind of the transaction
+	Sender                     AccountAddress         // Sender of the transaction, which may not be the account
+	SequenceNumber             uint64                 // SequenceNumber of the sender
+	EntryFunction              string                 // EntryFunction called e.g. 0x1::aptos_account::transfer, empty for scripts
+	GasUnitPrice               uint64                 // GasUnitPrice the transaction paid
+	MaxGasAmount               uint64                 // MaxGasAmount of the transaction
+	BlockHeight                uint64                 // BlockHeight the transaction committed in
+	Timestamp                  time.Time              // Timestamp the transaction committed at
+	ExpirationTimestampSeconds uint64                 // ExpirationTimestampSeconds is seconds since Unix epoch
+}
+
+// indexerAccountTransactionsFilter is the where clause for account_transactions
+type indexerAccountTransactionsFilter map[string]any
+
+func (indexerAccountTransactionsFilter) GetGraphQLType() string {
+	return "account_transactions_bool_exp"
+}
+
+// indexerAccountTransactionsOrder is the order_by clause for account_transactions
+type indexerAccountTransactionsOrder []map[string]any
+
+func (indexerAccountTransactionsOrder) GetGraphQLType() string {
+	return "[account_transactions_order_by!]"
+}
+
+// GetAccountTransactions gets a page of the transactions that touched address, from the indexer, so the whole history
+// is available rather than only what the fullnode keeps.  Pass the version of the last transaction as the cursor to
+// get the next page.
+//
+//	options := AccountTransactionsOptions{EntryFunction: "0x1::aptos_account::transfer"}
+//	page, err := client.GetAccountTransactions(address, options)
+//	for len(page) > 0 && err == nil {
+//		options.Cursor = &page[len(page)-1].Version
+//		page, err = client.GetAccountTransactions(address, options)
+//	}
+func (ic *IndexerClient) GetAccountTransactions(address AccountAddress, options AccountTransactionsOptions) ([]AccountTransactionSummary, error) {
+	where := indexerAccountTransactionsFilter{
+		"account_address": map[string]any{"_eq": address.StringLong()},
+	}
+	direction := "desc"
+	if options.Ascending {
+		direction = "asc"
+	}
+	if options.Cursor != nil {
+		if options.Ascending {
+			where["transaction_version"] = map[string]any{"_gt": *options.Cursor}
+		} else {
+			where["transaction_version"] = map[string]any{"_lt": *options.Cursor}
+		}
+	}
+
+	// A user transaction always has a version, so this checks the user transaction exists
+	userTransaction := map[string]any{"version": map[string]any{"_is_null": false}}
+	if options.EntryFunction != "" {
+		userTransaction["entry_function_id_str"] = map[string]any{"_eq": options.EntryFunction}
+	}
+	if options.SentOnly {
+		userTransaction["sender"] = map[string]any{"_eq": address.StringLong()}
+	}
+	switch options.Kind {
+	case "":
+		if options.EntryFunction != "" || options.SentOnly {
+			where["user_transaction"] = userTransaction
+		}
+	case AccountTransactionKindUser:
+		where["user_transaction"] = userTransaction
+	case AccountTransactionKindSystem:
+		if options.EntryFunction != "" || options.SentOnly {
+			return nil, errors.New("system transactions have no entry function or sender")
+		}
+		where["_not"] = map[string]any{"user_transaction": userTransaction}
+	default:
+		return nil, fmt.Errorf("unknown account transaction kind %q", options.Kind)
+	}
+
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultAccountTransactionsPageSize
+	}
+
+	var q struct {
+		AccountTransactions []struct {
+			TransactionVersion uint64 `graphql:"transaction_version"`
+			UserTransaction    *struct {
+				Sender                  string `graphql:"sender"`
+				SequenceNumber          uint64 `graphql:"sequence_number"`
+				EntryFunctionIdStr      string `graphql:"entry_function_id_str"`
+				GasUnitPrice            uint64 `graphql:"gas_unit_price"`
+				MaxGasAmount            uint64 `graphql:"max_gas_amount"`
+				BlockHeight             uint64 `graphql:"block_height"`
+				Timestamp               string `graphql:"timestamp"`
+				ExpirationTimestampSecs string `graphql:"expiration_timestamp_secs"`
+			} `graphql:"user_transaction"`
+		} `graphql:"account_transactions(where: $where, order_by: $order_by, limit: $limit)"`
+	}
+	variables := map[string]any{
+		"where":    where,
+		"order_by": indexerAccountTransactionsOrder{{"transaction_version": direction}},
+		"limit":    limit,
+	}
+	err := ic.Query(&q, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]AccountTransactionSummary, 0, len(q.AccountTransactions))
+	for _, txn := range q.AccountTransactions {
+		summary := AccountTransactionSummary{
+			Version: txn.TransactionVersion,
+			Kind:    AccountTransactionKindSystem,
+		}
+		if user := txn.UserTransaction; user != nil {
+			summary.Kind = AccountTransactionKindUser
+			summary.SequenceNumber = user.SequenceNumber
+			summary.EntryFunction = user.EntryFunctionIdStr
+			summary.GasUnitPrice = user.GasUnitPrice
+			summary.MaxGasAmount = user.MaxGasAmount
+			summary.BlockHeight = user.BlockHeight
+			err = summary.Sender.ParseStringRelaxed(user.Sender)
+			if err != nil {
+				return nil, fmt.Errorf("bad sender %s for transaction %d: %w", user.Sender, txn.TransactionVersion, err)
+			}
+			summary.Timestamp, err = time.Parse(indexerTimeLayout, user.Timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("bad timestamp %s for transaction %d: %w", user.Timestamp, txn.TransactionVersion, err)
+			}
+			expiration, err := time.Parse(indexerTimeLayout, user.ExpirationTimestampSecs)
+			if err != nil {
+				return nil, fmt.Errorf("bad expiration %s for transaction %d: %w", user.ExpirationTimestampSecs, txn.TransactionVersion, err)
+			}
+			summary.ExpirationTimestampSeconds = uint64(expiration.Unix())
+		}
+		out = append(out, summary)
+	}
+	return out, nil
+}
+
+// GetAccountTransactions gets a page of the transactions that touched address, from the indexer.
+//
+// Without an indexer, [AccountTransactionsOptions.SentOnly] pages are read from the node instead, which is slower and
+// only has what the fullnode keeps, and BlockHeight isn't set.  The node can't find transactions that only touched the
+// account, so other options return [ErrNoIndexer].
+func (client *Client) GetAccountTransactions(address AccountAddress, options AccountTransactionsOptions) ([]AccountTransactionSummary, error) {
+	if client.indexerClient == nil {
+		if !options.SentOnly {
+			return nil, ErrNoIndexer
+		}
+		warnIndexerFallback("GetAccountTransactions")
+		return client.nodeClient.sentTransactionsFromNode(address, options)
+	}
+	return client.indexerClient.GetAccountTransactions(address, options)
+}
+
+// sentTransactionsFromNode gets a page of the transactions sent by address from the node, used when there is no
+// indexer.  The node lists them by sequence number, so the cursor is found by binary search on the versions.
+func (rc *NodeClient) sentTransactionsFromNode(address AccountAddress, options AccountTransactionsOptions) ([]AccountTransactionSummary, error) {
+	switch options.Kind {
+	case "", AccountTransactionKindUser:
+	case AccountTransactionKindSystem:
+		return nil, errors.New("system transactions have no entry function or sender")
+	default:
+		return nil, fmt.Errorf("unknown account transaction kind %q", options.Kind)
+	}
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultAccountTransactionsPageSize
+	}
+
+	info, err := rc.Account(address)
+	if err != nil {
+		return nil, err
+	}
+	count, err := info.SequenceNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	// firstPast finds the first sequence number whose transaction is past the cursor, or count if none are
+	firstPast := func(past func(version uint64) bool) (uint64, error) {
+		low, high := uint64(0), count
+		for low < high {
+			mid := low + (high-low)/2
+			one := uint64(1)
+			txns, err := rc.accountTransactionsInner(address, &mid, &one)
+			if err != nil {
+				return 0, err
+			}
+			if len(txns) == 0 {
+				return 0, fmt.Errorf("transaction %d of %s not found", mid, address.String())
+			}
+			if past(txns[0].Version()) {
+				high = mid
+			} else {
+				low = mid + 1
+			}
+		}
+		return low, nil
+	}
+
+	// Sequence numbers from start to end are left to page through
+	start, end := uint64(0), count
+	if cursor := options.Cursor; cursor != nil {
+		if options.Ascending {
+			start, err = firstPast(func(version uint64) bool { return version > *cursor })
+		} else {
+			end, err = firstPast(func(version uint64) bool { return version >= *cursor })
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]AccountTransactionSummary, 0, limit)
+	for start < end && len(out) < limit {
+		pageSize := min(end-start, uint64(accountTransactionsNodePageSize))
+		pageStart := start
+		if !options.Ascending {
+			pageStart = end - pageSize
+		}
+		txns, err := rc.accountTransactionsInner(address, &pageStart, &pageSize)
+		if err != nil {
+			return nil, err
+		}
+		if !options.Ascending {
+			slices.Reverse(txns)
+		}
+		for _, txn := range txns {
+			userTxn, err := txn.UserTransaction()
+			if err != nil || len(out) == limit {
+				continue
+			}
+			summary := nodeTransactionSummary(userTxn)
+			if options.EntryFunction == "" || summary.EntryFunction == options.EntryFunction {
+				out = append(out, summary)
+			}
+		}
+		if options.Ascending {
+			start += pageSize
+		} else {
+			end -= pageSize
+		}
+	}
+	return out, nil
+}
+
+// nodeTransactionSummary converts a user transaction from the node, which doesn't have the block height
+func nodeTransactionSummary(txn *api.UserTransaction) AccountTransactionSummary {
+	summary := AccountTransactionSummary{
+		Version:                    txn.Version,
+		Kind:                       AccountTransactionKindUser,
+		SequenceNumber:             txn.SequenceNumber,
+		GasUnitPrice:               txn.GasUnitPrice,
+		MaxGasAmount:               txn.MaxGasAmount,
+		Timestamp:                  time.UnixMicro(int64(txn.Timestamp)).UTC(),
+		ExpirationTimestampSeconds: txn.ExpirationTimestampSecs,
+	}
+	if txn.Sender != nil {
+		summary.Sender = *txn.Sender
+	}
+	if txn.Payload != nil {
+		if entryFunction, ok := txn.Payload.Inner.(*api.TransactionPayloadEntryFunction); ok {
+			summary.EntryFunction = entryFunction.Function
+		}
+	}
+	return summary
+}
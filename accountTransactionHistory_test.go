@@ -0,0 +1,122 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetAccountTransactions(t *testing.T) {
+	address := AccountAddress{}
+	assert.NoError(t, address.ParseStringRelaxed("0xa11ce"))
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string
+			Variables map[string]any
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Contains(t, request.Query, "$where:account_transactions_bool_exp!")
+		assert.Contains(t, request.Query, "$order_by:[account_transactions_order_by!]!")
+		where := request.Variables["where"].(map[string]any)
+		assert.Equal(t, map[string]any{"_eq": address.StringLong()}, where["account_address"])
+		assert.Equal(t, map[string]any{"_lt": float64(500)}, where["transaction_version"])
+		assert.Equal(t, map[string]any{
+			"version":               map[string]any{"_is_null": false},
+			"entry_function_id_str": map[string]any{"_eq": "0x1::aptos_account::transfer"},
+			"sender":                map[string]any{"_eq": address.StringLong()},
+		}, where["user_transaction"])
+		assert.Equal(t, []any{map[string]any{"transaction_version": "desc"}}, request.Variables["order_by"])
+		assert.Equal(t, float64(DefaultAccountTransactionsPageSize), request.Variables["limit"])
+
+		_, _ = w.Write([]byte(`{"data": {"account_transactions": [{
+			"transaction_version": 450,
+			"user_transaction": {
+				"sender": "0xa11ce",
+				"sequence_number": 7,
+				"entry_function_id_str": "0x1::aptos_account::transfer",
+				"gas_unit_price": 100,
+				"max_gas_amount": 2000,
+				"block_height": 40,
+				"timestamp": "2024-05-06T07:08:09.5",
+				"expiration_timestamp_secs": "2024-05-06T07:08:39"
+			}
+		}, {
+			"transaction_version": 400,
+			"user_transaction": null
+		}]}}`))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1", IndexerUrl: mockServer.URL + "/graphql"})
+	assert.NoError(t, err)
+	cursor := uint64(500)
+	transactions, err := client.GetAccountTransactions(address, AccountTransactionsOptions{
+		Cursor:        &cursor,
+		EntryFunction: "0x1::aptos_account::transfer",
+		SentOnly:      true,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 2)
+
+	transfer := transactions[0]
+	assert.Equal(t, uint64(450), transfer.Version)
+	assert.Equal(t, AccountTransactionKindUser, transfer.Kind)
+	assert.Equal(t, address, transfer.Sender)
+	assert.Equal(t, uint64(7), transfer.SequenceNumber)
+	assert.Equal(t, "0x1::aptos_account::transfer", transfer.EntryFunction)
+	assert.Equal(t, uint64(100), transfer.GasUnitPrice)
+	assert.Equal(t, uint64(2000), transfer.MaxGasAmount)
+	assert.Equal(t, uint64(40), transfer.BlockHeight)
+	assert.Equal(t, time.Date(2024, 5, 6, 7, 8, 9, 500000000, time.UTC), transfer.Timestamp)
+	assert.Equal(t, uint64(time.Date(2024, 5, 6, 7, 8, 39, 0, time.UTC).Unix()), transfer.ExpirationTimestampSeconds)
+
+	assert.Equal(t, uint64(400), transactions[1].Version)
+	assert.Equal(t, AccountTransactionKindSystem, transactions[1].Kind)
+}
+
+func TestClient_GetAccountTransactionsAscendingSystem(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string
+			Variables map[string]any
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		where := request.Variables["where"].(map[string]any)
+		assert.Equal(t, map[string]any{"_gt": float64(10)}, where["transaction_version"])
+		assert.Equal(t, map[string]any{"user_transaction": map[string]any{"version": map[string]any{"_is_null": false}}}, where["_not"])
+		assert.Equal(t, []any{map[string]any{"transaction_version": "asc"}}, request.Variables["order_by"])
+		assert.Equal(t, float64(5), request.Variables["limit"])
+		_, _ = w.Write([]byte(`{"data": {"account_transactions": []}}`))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1", IndexerUrl: mockServer.URL + "/graphql"})
+	assert.NoError(t, err)
+	cursor := uint64(10)
+	transactions, err := client.GetAccountTransactions(AccountOne, AccountTransactionsOptions{
+		Cursor:    &cursor,
+		Ascending: true,
+		Kind:      AccountTransactionKindSystem,
+		Limit:     5,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, transactions)
+
+	// Filters that only apply to user transactions are rejected before querying
+	_, err = client.GetAccountTransactions(AccountOne, AccountTransactionsOptions{Kind: AccountTransactionKindSystem, SentOnly: true})
+	assert.Error(t, err)
+	_, err = client.GetAccountTransactions(AccountOne, AccountTransactionsOptions{Kind: "genesis"})
+	assert.Error(t, err)
+}
+
+func TestClient_GetAccountTransactionsNoIndexer(t *testing.T) {
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: "http://localhost:8080/v1"})
+	assert.NoError(t, err)
+	_, err = client.GetAccountTransactions(AccountOne, AccountTransactionsOptions{})
+	assert.ErrorIs(t, err, ErrNoIndexer)
+}
@@ -0,0 +1,345 @@
+package aptos
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FungibleAssetProcessor is the indexer processor that indexes fungible asset and coin activities
+const FungibleAssetProcessor = "fungible_asset_processor"
+
+// accountingPageSize is the number of activities fetched per indexer query
+const accountingPageSize = 100
+
+// AccountingEntryKind tells how an [AccountingEntry] changed the balance
+type AccountingEntryKind string
+
+const (
+	AccountingIncome        AccountingEntryKind = "income"         // AccountingIncome is a deposit into the account
+	AccountingOutflow       AccountingEntryKind = "outflow"        // AccountingOutflow is a withdrawal from the account
+	AccountingFee           AccountingEntryKind = "fee"            // AccountingFee is gas paid by the account
+	AccountingStorageRefund AccountingEntryKind = "storage_refund" // AccountingStorageRefund is storage fee refunded with the gas
+)
+
+// AccountingEntry is one balance change of an account, derived from a withdraw, deposit, or gas fee event
+type AccountingEntry struct {
+	Version   uint64              `json:"version,string"` // Version is the ledger version of the transaction
+	Timestamp time.Time           `json:"timestamp"`      // Timestamp of the transaction
+	Asset     string              `json:"asset"`          // Asset is the coin type, or the fungible asset metadata address
+	Kind      AccountingEntryKind `json:"kind"`           // Kind of balance change
+	Amount    uint64              `json:"amount,string"`  // Amount in the smallest unit of the asset
+}
+
+// AssetStatement is the income and outflow of one asset over the period of an [AccountingReport].  The opening and
+// closing balances are read from the node at the snapshot versions, so any difference the entries don't explain shows
+// up in Discrepancy, e.g. activity the indexer doesn't track.
+type AssetStatement struct {
+	Asset          string   `json:"asset"`                  // Asset is the coin type, or the fungible asset metadata address
+	Decimals       uint8    `json:"decimals"`               // Decimals of the asset, amounts are in the smallest unit
+	Opening        uint64   `json:"opening,string"`         // Opening balance at [AccountingReport.StartVersion]
+	Income         uint64   `json:"income,string"`          // Income is the sum of deposits
+	Outflow        uint64   `json:"outflow,string"`         // Outflow is the sum of withdrawals
+	Fees           uint64   `json:"fees,string"`            // Fees is the gas paid, only for the gas asset
+	StorageRefunds uint64   `json:"storage_refunds,string"` // StorageRefunds is the storage fee refunded with the gas
+	Closing        uint64   `json:"closing,string"`         // Closing balance at [AccountingReport.EndVersion]
+	Discrepancy    *big.Int `json:"discrepancy"`            // Discrepancy is the closing balance minus the balance the entries explain, 0 when reconciled
+}
+
+// Reconciled tells if the entries explain the difference between the opening and closing balances
+func (statement *AssetStatement) Reconciled() bool {
+	return statement.Discrepancy == nil || statement.Discrepancy.Sign() == 0
+}
+
+// reconcile computes the discrepancy from the balances and totals
+func (statement *AssetStatement) reconcile() {
+	explained := new(big.Int).SetUint64(statement.Opening)
+	explained.Add(explained, new(big.Int).SetUint64(statement.Income))
+	explained.Add(explained, new(big.Int).SetUint64(statement.StorageRefunds))
+	explained.Sub(explained, new(big.Int).SetUint64(statement.Outflow))
+	explained.Sub(explained, new(big.Int).SetUint64(statement.Fees))
+	statement.Discrepancy = new(big.Int).Sub(new(big.Int).SetUint64(statement.Closing), explained)
+}
+
+// AccountingReport is an income and outflow statement per asset for an account over a period, see
+// [Client.AccountingReport].  It can be exported with [AccountingReport.WriteCSV] or as JSON with
+// [AccountingReport.WriteJSON] or [json.Marshal].
+type AccountingReport struct {
+	Address      AccountAddress    `json:"address"`
+	Start        time.Time         `json:"start"`                // Start of the period, inclusive
+	End          time.Time         `json:"end"`                  // End of the period, exclusive
+	StartVersion uint64            `json:"start_version,string"` // StartVersion is the last ledger version before Start, the opening snapshot
+	EndVersion   uint64            `json:"end_version,string"`   // EndVersion is the last ledger version before End, or the last one indexed if earlier
+	Statements   []AssetStatement  `json:"statements"`           // Statements per asset with any entries, sorted by asset
+	Entries      []AccountingEntry `json:"entries"`              // Entries in the period, in ledger order
+}
+
+// Reconciled tells if every statement is reconciled
+func (report *AccountingReport) Reconciled() bool {
+	for i := range report.Statements {
+		if !report.Statements[i].Reconciled() {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteJSON writes the report as indented JSON
+func (report *AccountingReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// WriteCSV writes the statements as CSV, one row per asset with amounts in the smallest unit of the asset
+func (report *AccountingReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"asset", "decimals", "opening", "income", "outflow", "fees", "storage_refunds", "closing", "discrepancy"})
+	for _, statement := range report.Statements {
+		discrepancy := "0"
+		if statement.Discrepancy != nil {
+			discrepancy = statement.Discrepancy.String()
+		}
+		_ = writer.Write([]string{
+			statement.Asset,
+			strconv.FormatUint(uint64(statement.Decimals), 10),
+			strconv.FormatUint(statement.Opening, 10),
+			strconv.FormatUint(statement.Income, 10),
+			strconv.FormatUint(statement.Outflow, 10),
+			strconv.FormatUint(statement.Fees, 10),
+			strconv.FormatUint(statement.StorageRefunds, 10),
+			strconv.FormatUint(statement.Closing, 10),
+			discrepancy,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteEntriesCSV writes the entries as CSV, one row per balance change, for auditing a statement
+func (report *AccountingReport) WriteEntriesCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"version", "timestamp", "asset", "kind", "amount"})
+	for _, entry := range report.Entries {
+		_ = writer.Write([]string{
+			strconv.FormatUint(entry.Version, 10),
+			entry.Timestamp.UTC().Format(time.RFC3339Nano),
+			entry.Asset,
+			string(entry.Kind),
+			strconv.FormatUint(entry.Amount, 10),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// AccountingReport builds an income and outflow statement per asset for address over [start, end).
+//
+// Entries come from the withdraw, deposit, and gas fee activities in the indexer.  The period is mapped to ledger
+// versions by block, and the opening and closing balances are read from the node at those versions, so the node must
+// be an archival node that hasn't pruned the start of the period.  Each statement reconciles the balance difference
+// with the entries, see [AssetStatement.Discrepancy].
+//
+// If the indexer hasn't processed up to end, the report ends at the last indexed version, see
+// [AccountingReport.EndVersion].  Requires an indexer, or returns [ErrNoIndexer].
+//
+//	report, err := client.AccountingReport(treasury, monthStart, monthStart.AddDate(0, 1, 0))
+//	if err != nil {
+//		return err
+//	}
+//	err = report.WriteCSV(file)
+func (client *Client) AccountingReport(address AccountAddress, start time.Time, end time.Time) (*AccountingReport, error) {
+	if client.indexerClient == nil {
+		return nil, ErrNoIndexer
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("accounting report start %s must be before end %s", start, end)
+	}
+
+	indexed, err := client.indexerClient.GetProcessorStatus(FungibleAssetProcessor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get indexer status: %w", err)
+	}
+	startVersion, indexedStart, err := client.snapshotVersion(start, indexed)
+	if err != nil {
+		return nil, err
+	}
+	if !indexedStart {
+		return nil, fmt.Errorf("indexer has only processed up to version %d, before the start of the report", indexed)
+	}
+	endVersion, _, err := client.snapshotVersion(end, indexed)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := client.accountingEntries(address, startVersion, endVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AccountingReport{
+		Address:      address,
+		Start:        start,
+		End:          end,
+		StartVersion: startVersion,
+		EndVersion:   endVersion,
+		Entries:      entries,
+	}
+	statements := make(map[string]*AssetStatement)
+	for _, entry := range entries {
+		statement, ok := statements[entry.Asset]
+		if !ok {
+			statement = &AssetStatement{Asset: entry.Asset}
+			statements[entry.Asset] = statement
+		}
+		switch entry.Kind {
+		case AccountingIncome:
+			statement.Income += entry.Amount
+		case AccountingOutflow:
+			statement.Outflow += entry.Amount
+		case AccountingFee:
+			statement.Fees += entry.Amount
+		case AccountingStorageRefund:
+			statement.StorageRefunds += entry.Amount
+		}
+	}
+
+	for _, statement := range statements {
+		asset, err := ParseAsset(statement.Asset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse asset %s: %w", statement.Asset, err)
+		}
+		opening, err := client.Balance(address, asset, startVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get opening balance of %s at version %d: %w", statement.Asset, startVersion, err)
+		}
+		closing, err := client.Balance(address, asset, endVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get closing balance of %s at version %d: %w", statement.Asset, endVersion, err)
+		}
+		statement.Decimals = closing.Decimals
+		statement.Opening = opening.Value
+		statement.Closing = closing.Value
+		statement.reconcile()
+		report.Statements = append(report.Statements, *statement)
+	}
+	slices.SortFunc(report.Statements, func(a, b AssetStatement) int {
+		return strings.Compare(a.Asset, b.Asset)
+	})
+	return report, nil
+}
+
+// indexerTimestamp is a timestamp variable for indexer queries
+type indexerTimestamp string
+
+func (indexerTimestamp) GetGraphQLType() string {
+	return "timestamp"
+}
+
+// indexerBigint is a bigint variable for indexer queries e.g. a version
+type indexerBigint uint64
+
+func (indexerBigint) GetGraphQLType() string {
+	return "bigint"
+}
+
+// indexerTimeLayout is the layout of timestamps in the indexer, which are in UTC without a zone
+const indexerTimeLayout = "2006-01-02T15:04:05.999999999"
+
+// snapshotVersion finds the last ledger version before t, which is the version before the first block at or after t.
+// If the indexer has no block at or after t, or hasn't processed it yet, the last indexed version is used, and ok is false.
+func (client *Client) snapshotVersion(t time.Time, indexed uint64) (version uint64, ok bool, err error) {
+	var q struct {
+		BlockMetadataTransactions []struct {
+			Version uint64
+		} `graphql:"block_metadata_transactions(where: {timestamp: {_gte: $time}}, order_by: {version: asc}, limit: 1)"`
+	}
+	variables := map[string]any{
+		"time": indexerTimestamp(t.UTC().Format(indexerTimeLayout)),
+	}
+	err = client.queryIndexer(&q, variables)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to find the ledger version at %s: %w", t, err)
+	}
+	if len(q.BlockMetadataTransactions) == 0 {
+		return indexed, false, nil
+	}
+	version = q.BlockMetadataTransactions[0].Version
+	if version > 0 {
+		version--
+	}
+	if version > indexed {
+		return indexed, false, nil
+	}
+	return version, true, nil
+}
+
+// accountingEntries fetches the balance changes of address in the versions (from, to]
+func (client *Client) accountingEntries(address AccountAddress, from uint64, to uint64) ([]AccountingEntry, error) {
+	var entries []AccountingEntry
+	for offset := 0; ; offset += accountingPageSize {
+		var q struct {
+			FungibleAssetActivities []struct {
+				TransactionVersion   uint64 `graphql:"transaction_version"`
+				TransactionTimestamp string `graphql:"transaction_timestamp"`
+				Type                 string
+				AssetType            string  `graphql:"asset_type"`
+				Amount               *uint64 `graphql:"amount"`
+				IsGasFee             bool    `graphql:"is_gas_fee"`
+				StorageRefundAmount  *uint64 `graphql:"storage_refund_amount"`
+			} `graphql:"fungible_asset_activities(where: {owner_address: {_eq: $address}, transaction_version: {_gt: $from, _lte: $to}, is_transaction_success: {_eq: true}}, order_by: [{transaction_version: asc}, {event_index: asc}], limit: $limit, offset: $offset)"`
+		}
+		variables := map[string]any{
+			"address": address.StringLong(),
+			"from":    indexerBigint(from),
+			"to":      indexerBigint(to),
+			"limit":   accountingPageSize,
+			"offset":  offset,
+		}
+		err := client.queryIndexer(&q, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch activities: %w", err)
+		}
+
+		for _, activity := range q.FungibleAssetActivities {
+			if activity.Amount == nil {
+				continue
+			}
+			timestamp, err := time.Parse(indexerTimeLayout, activity.TransactionTimestamp)
+			if err != nil {
+				return nil, fmt.Errorf("bad timestamp %s at version %d: %w", activity.TransactionTimestamp, activity.TransactionVersion, err)
+			}
+			entry := AccountingEntry{
+				Version:   activity.TransactionVersion,
+				Timestamp: timestamp,
+				Asset:     activity.AssetType,
+				Amount:    *activity.Amount,
+			}
+			switch {
+			case activity.IsGasFee:
+				entry.Kind = AccountingFee
+				entries = append(entries, entry)
+				if activity.StorageRefundAmount != nil && *activity.StorageRefundAmount > 0 {
+					entry.Kind = AccountingStorageRefund
+					entry.Amount = *activity.StorageRefundAmount
+					entries = append(entries, entry)
+				}
+			case strings.HasSuffix(activity.Type, "Withdraw") || strings.HasSuffix(activity.Type, "WithdrawEvent"):
+				entry.Kind = AccountingOutflow
+				entries = append(entries, entry)
+			case strings.HasSuffix(activity.Type, "Deposit") || strings.HasSuffix(activity.Type, "DepositEvent"):
+				entry.Kind = AccountingIncome
+				entries = append(entries, entry)
+			}
+		}
+		if len(q.FungibleAssetActivities) < accountingPageSize {
+			return entries, nil
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_AccountingReport(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	address := AccountAddress{}
+	assert.NoError(t, address.ParseStringRelaxed("0x1234"))
+
+	activities := []map[string]any{
+		{"transaction_version": 150, "transaction_timestamp": "2024-01-02T03:04:05.123456", "type": "0x1::coin::DepositEvent", "asset_type": "0x1::aptos_coin::AptosCoin", "amount": 1000, "is_gas_fee": false},
+		{"transaction_version": 200, "transaction_timestamp": "2024-01-03T00:00:00", "type": "0x1::aptos_coin::GasFeeEvent", "asset_type": "0x1::aptos_coin::AptosCoin", "amount": 50, "is_gas_fee": true, "storage_refund_amount": 10},
+		{"transaction_version": 200, "transaction_timestamp": "2024-01-03T00:00:00", "type": "0x1::coin::WithdrawEvent", "asset_type": "0x1::aptos_coin::AptosCoin", "amount": 300, "is_gas_fee": false},
+		{"transaction_version": 300, "transaction_timestamp": "2024-01-04T00:00:00", "type": "0x1::fungible_asset::Deposit", "asset_type": "0xabc", "amount": 7, "is_gas_fee": false},
+		{"transaction_version": 300, "transaction_timestamp": "2024-01-04T00:00:00", "type": "0x1::fungible_asset::Frozen", "asset_type": "0xabc", "amount": nil, "is_gas_fee": false},
+	}
+	balances := map[string]string{
+		"coin 100":                   "5000",
+		"coin 500":                   "5660",
+		"primary_fungible_store 100": "0",
+		"primary_fungible_store 500": "8",
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/v1/view":
+			if bytes.Contains(body, []byte("decimals")) {
+				w.Write([]byte("[8]"))
+				return
+			}
+			module := "coin"
+			if bytes.Contains(body, []byte("primary_fungible_store")) {
+				module = "primary_fungible_store"
+			}
+			json.NewEncoder(w).Encode([]string{balances[module+" "+r.URL.Query().Get("ledger_version")]})
+		case "/graphql":
+			var request struct {
+				Query     string
+				Variables map[string]any
+			}
+			assert.NoError(t, json.Unmarshal(body, &request))
+			var data any
+			switch {
+			case strings.Contains(request.Query, "processor_status"):
+				data = map[string]any{"processor_status": []map[string]any{{"last_success_version": 1000}}}
+			case strings.Contains(request.Query, "block_metadata_transactions"):
+				assert.Contains(t, request.Query, "$time:timestamp!")
+				version := 501
+				if request.Variables["time"] == "2024-01-01T00:00:00" {
+					version = 101
+				}
+				data = map[string]any{"block_metadata_transactions": []map[string]any{{"version": version}}}
+			case strings.Contains(request.Query, "fungible_asset_activities"):
+				assert.Contains(t, request.Query, "$from:bigint!")
+				assert.Equal(t, float64(100), request.Variables["from"])
+				assert.Equal(t, float64(500), request.Variables["to"])
+				assert.Equal(t, address.StringLong(), request.Variables["address"])
+				data = map[string]any{"fungible_asset_activities": activities}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": data})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1", IndexerUrl: mockServer.URL + "/graphql"})
+	assert.NoError(t, err)
+
+	report, err := client.AccountingReport(address, start, end)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), report.StartVersion)
+	assert.Equal(t, uint64(500), report.EndVersion)
+	assert.Len(t, report.Entries, 5)
+	assert.Equal(t, AccountingStorageRefund, report.Entries[2].Kind)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC), report.Entries[0].Timestamp)
+
+	assert.Len(t, report.Statements, 2)
+	apt := report.Statements[0]
+	assert.Equal(t, "0x1::aptos_coin::AptosCoin", apt.Asset)
+	assert.Equal(t, uint8(8), apt.Decimals)
+	assert.Equal(t, uint64(5000), apt.Opening)
+	assert.Equal(t, uint64(1000), apt.Income)
+	assert.Equal(t, uint64(300), apt.Outflow)
+	assert.Equal(t, uint64(50), apt.Fees)
+	assert.Equal(t, uint64(10), apt.StorageRefunds)
+	assert.Equal(t, uint64(5660), apt.Closing)
+	assert.True(t, apt.Reconciled())
+
+	// One unit isn't explained by the entries
+	fa := report.Statements[1]
+	assert.Equal(t, "0xabc", fa.Asset)
+	assert.Equal(t, int64(1), fa.Discrepancy.Int64())
+	assert.False(t, report.Reconciled())
+
+	csv := &strings.Builder{}
+	assert.NoError(t, report.WriteCSV(csv))
+	assert.Equal(t, "asset,decimals,opening,income,outflow,fees,storage_refunds,closing,discrepancy\n"+
+		"0x1::aptos_coin::AptosCoin,8,5000,1000,300,50,10,5660,0\n"+
+		"0xabc,8,0,7,0,0,0,8,1\n", csv.String())
+
+	entriesCsv := &strings.Builder{}
+	assert.NoError(t, report.WriteEntriesCSV(entriesCsv))
+	assert.Contains(t, entriesCsv.String(), "150,2024-01-02T03:04:05.123456Z,0x1::aptos_coin::AptosCoin,income,1000\n")
+
+	jsonOut := &bytes.Buffer{}
+	assert.NoError(t, report.WriteJSON(jsonOut))
+	decoded := AccountingReport{}
+	assert.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	assert.Len(t, decoded.Statements, 2)
+	assert.Equal(t, apt.Closing, decoded.Statements[0].Closing)
+	assert.Equal(t, "1", decoded.Statements[1].Discrepancy.String())
+	assert.Len(t, decoded.Entries, 5)
+
+	_, err = client.AccountingReport(address, end, start)
+	assert.Error(t, err)
+}
+
+func TestClient_AccountingReportNoIndexer(t *testing.T) {
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: "http://localhost:8080/v1"})
+	assert.NoError(t, err)
+	_, err = client.AccountingReport(AccountOne, time.Now().Add(-time.Hour), time.Now())
+	assert.ErrorIs(t, err, ErrNoIndexer)
+}
@@ -0,0 +1,86 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// ErrAmountGuard is returned (wrapped) when an [AmountGuard] check fails, check with errors.Is
+var ErrAmountGuard = errors.New("amount guard")
+
+// AmountGuard is an optional safeguard for automated senders, to catch fat-finger amounts and gas settings before
+// they are signed.  Any zero valued limit is disabled.
+//
+//	guard := &aptos.AmountGuard{MaxBalanceFraction: 0.25, MaxGasPriceDeviation: 10}
+//	err := guard.CheckTransfer(client, sender.Address, amount)
+type AmountGuard struct {
+	MaxBalanceFraction   float64 // MaxBalanceFraction is the largest fraction of the sender's APT balance a single transfer may move e.g. 0.5
+	MaxGasPriceDeviation float64 // MaxGasPriceDeviation is how many times above or below the network estimate the gas unit price may be e.g. 10
+	MaxGasFee            uint64  // MaxGasFee is the largest MaxGasAmount * GasUnitPrice allowed, in octas
+	WarnOnly             bool    // WarnOnly logs violations with slog.Warn instead of returning an error
+}
+
+// AmountGuardClient is the subset of the client used by [AmountGuard], satisfied by both [Client] and [NodeClient]
+type AmountGuardClient interface {
+	AccountAPTBalance(address AccountAddress, ledgerVersion ...uint64) (uint64, error)
+	EstimateGasPrice() (info EstimateGasInfo, err error)
+}
+
+// CheckTransfer checks that amount doesn't exceed [AmountGuard.MaxBalanceFraction] of the sender's APT balance
+func (guard *AmountGuard) CheckTransfer(client AmountGuardClient, sender AccountAddress, amount uint64) error {
+	if guard.MaxBalanceFraction <= 0 {
+		return nil
+	}
+	balance, err := client.AccountAPTBalance(sender)
+	if err != nil {
+		return fmt.Errorf("amount guard failed to fetch balance: %w", err)
+	}
+	limit := float64(balance) * guard.MaxBalanceFraction
+	if float64(amount) > limit {
+		return guard.violation("transfer amount %d exceeds %.4f of sender %s balance %d", amount, guard.MaxBalanceFraction, sender.String(), balance)
+	}
+	return nil
+}
+
+// CheckGas checks the gas parameters of rawTxn against [AmountGuard.MaxGasFee] and the network's gas estimate
+func (guard *AmountGuard) CheckGas(client AmountGuardClient, rawTxn *RawTransaction) error {
+	if guard.MaxGasFee > 0 {
+		// Division avoids overflow of MaxGasAmount * GasUnitPrice
+		if rawTxn.GasUnitPrice != 0 && rawTxn.MaxGasAmount > guard.MaxGasFee/rawTxn.GasUnitPrice {
+			return guard.violation("max gas fee %d * %d exceeds limit %d", rawTxn.MaxGasAmount, rawTxn.GasUnitPrice, guard.MaxGasFee)
+		}
+	}
+	if guard.MaxGasPriceDeviation <= 0 {
+		return nil
+	}
+	estimate, err := client.EstimateGasPrice()
+	if err != nil {
+		return fmt.Errorf("amount guard failed to estimate gas price: %w", err)
+	}
+	high := float64(estimate.Price(GasPriorityPrioritized)) * guard.MaxGasPriceDeviation
+	low := float64(estimate.Price(GasPriorityDeprioritized)) / guard.MaxGasPriceDeviation
+	price := float64(rawTxn.GasUnitPrice)
+	if price > high || price < low {
+		return guard.violation("gas unit price %d deviates from estimate %d by more than %.2fx", rawTxn.GasUnitPrice, estimate.GasEstimate, guard.MaxGasPriceDeviation)
+	}
+	return nil
+}
+
+// CheckTransaction runs both [AmountGuard.CheckTransfer] for amount and [AmountGuard.CheckGas] on rawTxn
+func (guard *AmountGuard) CheckTransaction(client AmountGuardClient, rawTxn *RawTransaction, amount uint64) error {
+	if err := guard.CheckTransfer(client, rawTxn.Sender, amount); err != nil {
+		return err
+	}
+	return guard.CheckGas(client, rawTxn)
+}
+
+// violation either logs or returns the error depending on [AmountGuard.WarnOnly]
+func (guard *AmountGuard) violation(format string, args ...any) error {
+	err := fmt.Errorf("%w: "+format, append([]any{ErrAmountGuard}, args...)...)
+	if guard.WarnOnly {
+		slog.Warn("amount guard violation", "err", err)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockGuardClient struct {
+	balance  uint64
+	estimate EstimateGasInfo
+}
+
+func (m *mockGuardClient) AccountAPTBalance(_ AccountAddress, _ ...uint64) (uint64, error) {
+	return m.balance, nil
+}
+
+func (m *mockGuardClient) EstimateGasPrice() (EstimateGasInfo, error) {
+	return m.estimate, nil
+}
+
+func TestAmountGuard(t *testing.T) {
+	client := &mockGuardClient{
+		balance:  1000,
+		estimate: EstimateGasInfo{DeprioritizedGasEstimate: 100, GasEstimate: 150, PrioritizedGasEstimate: 200},
+	}
+	guard := &AmountGuard{MaxBalanceFraction: 0.5, MaxGasPriceDeviation: 10, MaxGasFee: 1_000_000}
+
+	assert.NoError(t, guard.CheckTransfer(client, AccountOne, 500))
+	assert.ErrorIs(t, guard.CheckTransfer(client, AccountOne, 501), ErrAmountGuard)
+
+	rawTxn := &RawTransaction{Sender: AccountOne, MaxGasAmount: 1000, GasUnitPrice: 150}
+	assert.NoError(t, guard.CheckTransaction(client, rawTxn, 100))
+
+	// Too expensive
+	rawTxn.GasUnitPrice = 2001
+	assert.ErrorIs(t, guard.CheckGas(client, rawTxn), ErrAmountGuard)
+
+	// Too cheap
+	rawTxn.GasUnitPrice = 9
+	assert.ErrorIs(t, guard.CheckGas(client, rawTxn), ErrAmountGuard)
+
+	// Total fee too high
+	rawTxn.GasUnitPrice = 150
+	rawTxn.MaxGasAmount = 1_000_000
+	assert.ErrorIs(t, guard.CheckGas(client, rawTxn), ErrAmountGuard)
+
+	// Warn only never errors
+	guard.WarnOnly = true
+	assert.NoError(t, guard.CheckTransfer(client, AccountOne, 10_000))
+	assert.NoError(t, guard.CheckGas(client, rawTxn))
+
+	// Disabled guard does nothing
+	assert.NoError(t, (&AmountGuard{}).CheckTransaction(client, rawTxn, 10_000))
+}
@@ -0,0 +1,320 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// AnsSuffix is the top level domain of Aptos Names
+const AnsSuffix = ".apt"
+
+// ErrNameNotFound is returned when an Aptos Name isn't registered, has expired, or an address has no primary name
+var ErrNameNotFound = errors.New("aptos name not found")
+
+// AnsRouterAddresses are the addresses of the Aptos Names router contract, by chain id
+var AnsRouterAddresses = map[uint8]string{
+	1: "0x867ed1f6bf916171b1de3ee92849b8978b7d1b9e0a8cc982a3d19d535dfd9c0c", // mainnet
+	2: "0x5f8fd2347449685cf41d4db97926ec3a096eaf381332be4f1318ad4d16a8497c", // testnet
+}
+
+// AnsSource is an option to [Client.ResolveName] and [Client.PrimaryName], choosing where names are looked up
+type AnsSource uint8
+
+const (
+	AnsSourceAuto    AnsSource = iota // AnsSourceAuto uses the router if its address is known for the chain, otherwise the indexer
+	AnsSourceRouter                   // AnsSourceRouter calls the view functions of the router contract, so it is always up to date
+	AnsSourceIndexer                  // AnsSourceIndexer queries the indexer, which may lag the chain
+)
+
+// AnsRouter is an option to [Client.ResolveName] and [Client.PrimaryName], overriding the address of the router
+// contract e.g. for a localnet deployment
+type AnsRouter AccountAddress
+
+// AnsName is an Aptos Name, e.g. alice.apt, or bob.alice.apt with a subdomain
+type AnsName struct {
+	Domain    string // Domain e.g. alice
+	Subdomain string // Subdomain e.g. bob, empty if none
+}
+
+// ParseAnsName parses a name with or without the .apt suffix, e.g. "alice.apt", "alice", or "bob.alice.apt".  Names are
+// case-insensitive.  Each part must be 3 to 63 characters of a-z, 0-9, and -, not starting or ending with -.
+func ParseAnsName(name string) (AnsName, error) {
+	trimmed := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), AnsSuffix)
+	parts := strings.Split(trimmed, ".")
+	out := AnsName{}
+	switch len(parts) {
+	case 1:
+		out.Domain = parts[0]
+	case 2:
+		out.Subdomain = parts[0]
+		out.Domain = parts[1]
+	default:
+		return AnsName{}, fmt.Errorf("invalid aptos name %q, it has too many parts", name)
+	}
+	for _, part := range parts {
+		if !validAnsLabel(part) {
+			return AnsName{}, fmt.Errorf("invalid aptos name %q, parts must be 3 to 63 characters of a-z, 0-9, and -", name)
+		}
+	}
+	return out, nil
+}
+
+// String formats the name with the .apt suffix e.g. "bob.alice.apt"
+func (name AnsName) String() string {
+	if name.Subdomain == "" {
+		return name.Domain + AnsSuffix
+	}
+	return name.Subdomain + "." + name.Domain + AnsSuffix
+}
+
+// validAnsLabel checks a domain or subdomain follows the rules of the router
+func validAnsLabel(label string) bool {
+	if len(label) < 3 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, c := range label {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// ResolveName resolves an Aptos Name e.g. "alice.apt" to the address it targets, returning [ErrNameNotFound] if the
+// name isn't registered, has expired, or has no target.
+//
+//	address, err := client.ResolveName("alice.apt")
+//
+// Optional arguments:
+//   - AnsSource: where to look up the name. Default [AnsSourceAuto].
+//   - AnsRouter: address of the router contract. Default from [AnsRouterAddresses].
+func (client *Client) ResolveName(name string, options ...any) (AccountAddress, error) {
+	parsed, err := ParseAnsName(name)
+	if err != nil {
+		return AccountAddress{}, err
+	}
+	router, useRouter, err := client.ansSource(options)
+	if err != nil {
+		return AccountAddress{}, err
+	}
+	if useRouter {
+		return client.resolveNameFromRouter(router, parsed)
+	}
+	return client.resolveNameFromIndexer(parsed)
+}
+
+// ResolveAddressOrName parses input as an address if it is one, otherwise resolves it as an Aptos Name, so users can
+// paste either
+//
+//	recipient, err := client.ResolveAddressOrName(input)
+func (client *Client) ResolveAddressOrName(input string, options ...any) (AccountAddress, error) {
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, "0x") || strings.HasPrefix(input, "0X") {
+		address := AccountAddress{}
+		err := address.ParseStringRelaxed(input)
+		return address, err
+	}
+	return client.ResolveName(input, options...)
+}
+
+// PrimaryName looks up the primary Aptos Name of an address e.g. "alice.apt", returning [ErrNameNotFound] if it has
+// none.  It accepts the same options as [Client.ResolveName].
+//
+//	name, err := client.PrimaryName(address)
+func (client *Client) PrimaryName(address AccountAddress, options ...any) (string, error) {
+	router, useRouter, err := client.ansSource(options)
+	if err != nil {
+		return "", err
+	}
+	var name AnsName
+	if useRouter {
+		name, err = client.primaryNameFromRouter(router, address)
+	} else {
+		name, err = client.primaryNameFromIndexer(address)
+	}
+	if err != nil {
+		return "", err
+	}
+	return name.String(), nil
+}
+
+// ansSource chooses between the router and the indexer, returning the router address if it is used
+func (client *Client) ansSource(options []any) (router AccountAddress, useRouter bool, err error) {
+	source := AnsSourceAuto
+	var routerOverride *AccountAddress
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case AnsSource:
+			source = value
+		case AnsRouter:
+			address := AccountAddress(value)
+			routerOverride = &address
+		default:
+			return router, false, fmt.Errorf("aptos names arg %d bad type %T", i+1, arg)
+		}
+	}
+	if source == AnsSourceIndexer {
+		return router, false, nil
+	}
+
+	if routerOverride != nil {
+		return *routerOverride, true, nil
+	}
+	chainId, err := client.GetChainId()
+	if err != nil {
+		return router, false, err
+	}
+	routerStr, known := AnsRouterAddresses[chainId]
+	switch {
+	case known:
+		err = router.ParseStringRelaxed(routerStr)
+		return router, err == nil, err
+	case source == AnsSourceRouter:
+		return router, false, fmt.Errorf("no aptos names router known for chain %d, pass an AnsRouter", chainId)
+	default:
+		return router, false, nil
+	}
+}
+
+// resolveNameFromRouter calls router::get_target_addr
+func (client *Client) resolveNameFromRouter(router AccountAddress, name AnsName) (AccountAddress, error) {
+	domain, err := bcs.SerializeSingle(func(ser *bcs.Serializer) {
+		ser.WriteString(name.Domain)
+	})
+	if err != nil {
+		return AccountAddress{}, err
+	}
+	subdomain, err := bcs.SerializeSingle(func(ser *bcs.Serializer) {
+		var subdomain *string
+		if name.Subdomain != "" {
+			subdomain = &name.Subdomain
+		}
+		bcs.SerializeOption(ser, subdomain, func(ser *bcs.Serializer, item string) { ser.WriteString(item) })
+	})
+	if err != nil {
+		return AccountAddress{}, err
+	}
+	values, err := client.View(&ViewPayload{
+		Module:   ModuleId{Address: router, Name: "router"},
+		Function: "get_target_addr",
+		ArgTypes: []TypeTag{},
+		Args:     [][]byte{domain, subdomain},
+	})
+	if err != nil {
+		return AccountAddress{}, err
+	}
+	if len(values) != 1 {
+		return AccountAddress{}, fmt.Errorf("get_target_addr returned %d values", len(values))
+	}
+	target, ok, err := unwrapViewOptionString(values[0])
+	if err != nil {
+		return AccountAddress{}, fmt.Errorf("get_target_addr returned %w", err)
+	}
+	if !ok {
+		return AccountAddress{}, fmt.Errorf("%w: %s", ErrNameNotFound, name.String())
+	}
+	address := AccountAddress{}
+	err = address.ParseStringRelaxed(target)
+	return address, err
+}
+
+// primaryNameFromRouter calls router::get_primary_name
+func (client *Client) primaryNameFromRouter(router AccountAddress, address AccountAddress) (AnsName, error) {
+	addressBytes, err := bcs.Serialize(&address)
+	if err != nil {
+		return AnsName{}, err
+	}
+	values, err := client.View(&ViewPayload{
+		Module:   ModuleId{Address: router, Name: "router"},
+		Function: "get_primary_name",
+		ArgTypes: []TypeTag{},
+		Args:     [][]byte{addressBytes},
+	})
+	if err != nil {
+		return AnsName{}, err
+	}
+	if len(values) != 2 {
+		return AnsName{}, fmt.Errorf("get_primary_name returned %d values", len(values))
+	}
+	name := AnsName{}
+	name.Subdomain, _, err = unwrapViewOptionString(values[0])
+	if err != nil {
+		return AnsName{}, fmt.Errorf("get_primary_name returned %w", err)
+	}
+	domain, ok, err := unwrapViewOptionString(values[1])
+	if err != nil {
+		return AnsName{}, fmt.Errorf("get_primary_name returned %w", err)
+	}
+	if !ok {
+		return AnsName{}, fmt.Errorf("%w: no primary name for %s", ErrNameNotFound, address.String())
+	}
+	name.Domain = domain
+	return name, nil
+}
+
+// resolveNameFromIndexer looks up the target of an active name in current_aptos_names
+func (client *Client) resolveNameFromIndexer(name AnsName) (AccountAddress, error) {
+	var q struct {
+		CurrentAptosNames []struct {
+			RegisteredAddress *string `graphql:"registered_address"`
+		} `graphql:"current_aptos_names(where: {domain: {_eq: $domain}, subdomain: {_eq: $subdomain}, is_active: {_eq: true}}, limit: 1)"`
+	}
+	variables := map[string]any{
+		"domain":    name.Domain,
+		"subdomain": name.Subdomain,
+	}
+	err := client.queryIndexer(&q, variables)
+	if err != nil {
+		return AccountAddress{}, err
+	}
+	if len(q.CurrentAptosNames) == 0 || q.CurrentAptosNames[0].RegisteredAddress == nil {
+		return AccountAddress{}, fmt.Errorf("%w: %s", ErrNameNotFound, name.String())
+	}
+	address := AccountAddress{}
+	err = address.ParseStringRelaxed(*q.CurrentAptosNames[0].RegisteredAddress)
+	return address, err
+}
+
+// primaryNameFromIndexer looks up the active primary name owned by address in current_aptos_names
+func (client *Client) primaryNameFromIndexer(address AccountAddress) (AnsName, error) {
+	var q struct {
+		CurrentAptosNames []struct {
+			Domain    string `graphql:"domain"`
+			Subdomain string `graphql:"subdomain"`
+		} `graphql:"current_aptos_names(where: {owner_address: {_eq: $address}, is_primary: {_eq: true}, is_active: {_eq: true}}, limit: 1)"`
+	}
+	variables := map[string]any{
+		"address": address.StringLong(),
+	}
+	err := client.queryIndexer(&q, variables)
+	if err != nil {
+		return AnsName{}, err
+	}
+	if len(q.CurrentAptosNames) == 0 {
+		return AnsName{}, fmt.Errorf("%w: no primary name for %s", ErrNameNotFound, address.String())
+	}
+	return AnsName{Domain: q.CurrentAptosNames[0].Domain, Subdomain: q.CurrentAptosNames[0].Subdomain}, nil
+}
+
+// unwrapViewOptionString unwraps the API's JSON form of an Option<String> or Option<address> e.g. {"vec": ["alice"]}
+func unwrapViewOptionString(value any) (string, bool, error) {
+	option, isMap := value.(map[string]any)
+	if !isMap {
+		return "", false, fmt.Errorf("bad option %v", value)
+	}
+	vec, isVec := option["vec"].([]any)
+	if !isVec {
+		return "", false, fmt.Errorf("bad option %v", value)
+	}
+	if len(vec) == 0 {
+		return "", false, nil
+	}
+	str, isStr := vec[0].(string)
+	if !isStr {
+		return "", false, fmt.Errorf("bad option value %v", vec[0])
+	}
+	return str, true, nil
+}
@@ -0,0 +1,150 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAnsName(t *testing.T) {
+	name, err := ParseAnsName(" Alice.APT ")
+	assert.NoError(t, err)
+	assert.Equal(t, AnsName{Domain: "alice"}, name)
+	assert.Equal(t, "alice.apt", name.String())
+
+	name, err = ParseAnsName("bob.alice")
+	assert.NoError(t, err)
+	assert.Equal(t, AnsName{Domain: "alice", Subdomain: "bob"}, name)
+	assert.Equal(t, "bob.alice.apt", name.String())
+
+	for _, bad := range []string{"", "al.apt", "-alice.apt", "alice-.apt", "al_ice.apt", "a.b.c.apt", "carol.bob.alice.apt"} {
+		_, err = ParseAnsName(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func ansRouterServer(t *testing.T, router string) *httptest.Server {
+	routerAddress := AccountAddress{}
+	assert.NoError(t, routerAddress.ParseStringRelaxed(router))
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/view" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		assert.True(t, bytes.HasPrefix(body, routerAddress[:]))
+		switch {
+		case bytes.Contains(body, []byte("get_target_addr")) && bytes.Contains(body, []byte("alice")):
+			// The subdomain is passed as an option
+			assert.True(t, bytes.HasSuffix(body, []byte{1, 3, 'b', 'o', 'b'}))
+			_, _ = w.Write([]byte(`[{"vec": ["0xa11ce"]}]`))
+		case bytes.Contains(body, []byte("get_target_addr")):
+			_, _ = w.Write([]byte(`[{"vec": []}]`))
+		case bytes.Contains(body, []byte("get_primary_name")) && bytes.HasSuffix(body, AccountOne[:]):
+			_, _ = w.Write([]byte(`[{"vec": []}, {"vec": []}]`))
+		case bytes.Contains(body, []byte("get_primary_name")):
+			_, _ = w.Write([]byte(`[{"vec": ["bob"]}, {"vec": ["alice"]}]`))
+		default:
+			t.Errorf("unexpected view %x", body)
+		}
+	}))
+}
+
+func TestClient_ResolveNameRouter(t *testing.T) {
+	mockServer := ansRouterServer(t, AnsRouterAddresses[2])
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 2, NodeUrl: mockServer.URL + "/v1"})
+	assert.NoError(t, err)
+
+	expected := AccountAddress{}
+	assert.NoError(t, expected.ParseStringRelaxed("0xa11ce"))
+	address, err := client.ResolveName("bob.alice.apt")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, address)
+
+	_, err = client.ResolveName("bob.carol.apt")
+	assert.ErrorIs(t, err, ErrNameNotFound)
+
+	name, err := client.PrimaryName(expected)
+	assert.NoError(t, err)
+	assert.Equal(t, "bob.alice.apt", name)
+	_, err = client.PrimaryName(AccountOne)
+	assert.ErrorIs(t, err, ErrNameNotFound)
+
+	// Addresses are passed through, names are resolved
+	address, err = client.ResolveAddressOrName("0x1")
+	assert.NoError(t, err)
+	assert.Equal(t, AccountOne, address)
+	address, err = client.ResolveAddressOrName("bob.alice")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, address)
+}
+
+func TestClient_ResolveNameRouterOverride(t *testing.T) {
+	mockServer := ansRouterServer(t, "0xcafe")
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1"})
+	assert.NoError(t, err)
+
+	// No router is known for localnet
+	_, err = client.ResolveName("bob.alice.apt", AnsSourceRouter)
+	assert.Error(t, err)
+
+	router := AccountAddress{}
+	assert.NoError(t, router.ParseStringRelaxed("0xcafe"))
+	_, err = client.ResolveName("bob.alice.apt", AnsRouter(router))
+	assert.NoError(t, err)
+
+	_, err = client.ResolveName("alice.apt", "router")
+	assert.Error(t, err)
+}
+
+func TestClient_ResolveNameIndexer(t *testing.T) {
+	owner := AccountAddress{}
+	assert.NoError(t, owner.ParseStringRelaxed("0xa11ce"))
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string
+			Variables map[string]any
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		switch {
+		case request.Variables["domain"] == "alice":
+			assert.Equal(t, "", request.Variables["subdomain"])
+			_, _ = w.Write([]byte(`{"data": {"current_aptos_names": [{"registered_address": "0xa11ce"}]}}`))
+		case request.Variables["domain"] != nil:
+			_, _ = w.Write([]byte(`{"data": {"current_aptos_names": []}}`))
+		case request.Variables["address"] == owner.StringLong():
+			assert.Contains(t, request.Query, "is_primary: {_eq: true}")
+			_, _ = w.Write([]byte(`{"data": {"current_aptos_names": [{"domain": "alice", "subdomain": ""}]}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data": {"current_aptos_names": []}}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	// Localnet has no known router, so the indexer is used
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1", IndexerUrl: mockServer.URL + "/graphql"})
+	assert.NoError(t, err)
+
+	address, err := client.ResolveName("alice.apt")
+	assert.NoError(t, err)
+	assert.Equal(t, owner, address)
+	_, err = client.ResolveName("carol.apt", AnsSourceIndexer)
+	assert.ErrorIs(t, err, ErrNameNotFound)
+
+	name, err := client.PrimaryName(owner)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice.apt", name)
+	_, err = client.PrimaryName(AccountOne)
+	assert.ErrorIs(t, err, ErrNameNotFound)
+}
@@ -6,3 +6,29 @@ type Error struct {
 	ErrorCode   string `json:"error_code"`    // ErrorCode is the string name of the error
 	VmErrorCode uint64 `json:"vm_error_code"` // VmErrorCode is the number of the failure, optional 0 if not set
 }
+
+// Error codes returned by the node in [Error.ErrorCode]
+const (
+	ErrorCodeAccountNotFound          = "account_not_found"          // ErrorCodeAccountNotFound is returned when the account doesn't exist at the version
+	ErrorCodeResourceNotFound         = "resource_not_found"         // ErrorCodeResourceNotFound is returned when the resource doesn't exist at the version
+	ErrorCodeModuleNotFound           = "module_not_found"           // ErrorCodeModuleNotFound is returned when the module doesn't exist at the version
+	ErrorCodeStructFieldNotFound      = "struct_field_not_found"     // ErrorCodeStructFieldNotFound is returned when a struct field doesn't exist
+	ErrorCodeVersionNotFound          = "version_not_found"          // ErrorCodeVersionNotFound is returned when the ledger version is in the future
+	ErrorCodeTransactionNotFound      = "transaction_not_found"      // ErrorCodeTransactionNotFound is returned when the transaction isn't known to the node
+	ErrorCodeTableItemNotFound        = "table_item_not_found"       // ErrorCodeTableItemNotFound is returned when the table item doesn't exist
+	ErrorCodeBlockNotFound            = "block_not_found"            // ErrorCodeBlockNotFound is returned when the block doesn't exist
+	ErrorCodeStateValueNotFound       = "state_value_not_found"      // ErrorCodeStateValueNotFound is returned when the state value doesn't exist
+	ErrorCodeVersionPruned            = "version_pruned"             // ErrorCodeVersionPruned is returned when the ledger version has been pruned by the node
+	ErrorCodeBlockPruned              = "block_pruned"               // ErrorCodeBlockPruned is returned when the block has been pruned by the node
+	ErrorCodeInvalidInput             = "invalid_input"              // ErrorCodeInvalidInput is returned when the request is malformed
+	ErrorCodeInvalidTransactionUpdate = "invalid_transaction_update" // ErrorCodeInvalidTransactionUpdate is returned when a pending transaction is replaced
+	ErrorCodeSequenceNumberTooOld     = "sequence_number_too_old"    // ErrorCodeSequenceNumberTooOld is returned when the sequence number was already used
+	ErrorCodeVmError                  = "vm_error"                   // ErrorCodeVmError is returned when the VM rejects a transaction, see [Error.VmErrorCode]
+	ErrorCodeRejectedByFilter         = "rejected_by_filter"         // ErrorCodeRejectedByFilter is returned when the node filters out the transaction
+	ErrorCodeHealthCheckFailed        = "health_check_failed"        // ErrorCodeHealthCheckFailed is returned when the node is unhealthy
+	ErrorCodeMempoolIsFull            = "mempool_is_full"            // ErrorCodeMempoolIsFull is returned when the mempool can't take more transactions
+	ErrorCodeInternalError            = "internal_error"             // ErrorCodeInternalError is returned for an internal node error
+	ErrorCodeWebFrameworkError        = "web_framework_error"        // ErrorCodeWebFrameworkError is returned for an error in the node's web framework
+	ErrorCodeBcsNotSupported          = "bcs_not_supported"          // ErrorCodeBcsNotSupported is returned when BCS isn't supported for the endpoint
+	ErrorCodeApiDisabled              = "api_disabled"               // ErrorCodeApiDisabled is returned when the endpoint is disabled on the node
+)
@@ -38,9 +38,28 @@ type Event struct {
 	Type           string         // Type is the fully qualified name of the event e.g. 0x1::coin::WithdrawEvent
 	Guid           *GUID          // GUID is the unique identifier of the event, only present in V1 events
 	SequenceNumber uint64         // SequenceNumber is the sequence number of the event, only present in V1 events
+	Version        uint64         // Version of the transaction that emitted the event, only present when fetched by event handle
 	Data           map[string]any // Data is the event data, a map of field name to value, this should match it's on-chain struct representation
 }
 
+// DataInto decodes the event data into out, e.g. a struct matching the event's fields
+//
+//	var deposit struct {
+//		Amount string `json:"amount"`
+//	}
+//	err := event.DataInto(&deposit)
+func (o *Event) DataInto(out any) error {
+	var data any = o.Data
+	if value, ok := o.Data[AnyDataName]; ok && len(o.Data) == 1 {
+		data = value
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
 // region Event JSON
 const (
 	AnyDataName = "__any_data__"
@@ -53,6 +72,7 @@ func (o *Event) UnmarshalJSON(b []byte) error {
 		Type           string         `json:"type"`
 		Guid           *GUID          `json:"guid"`
 		SequenceNumber U64            `json:"sequence_number"`
+		Version        U64            `json:"version"`
 		Data           map[string]any `json:"data"`
 	}
 	data := &innerStandard{}
@@ -62,6 +82,7 @@ func (o *Event) UnmarshalJSON(b []byte) error {
 		o.Type = data.Type
 		o.Guid = data.Guid
 		o.SequenceNumber = data.SequenceNumber.ToUint64()
+		o.Version = data.Version.ToUint64()
 		o.Data = data.Data
 		return nil
 	}
@@ -71,6 +92,7 @@ func (o *Event) UnmarshalJSON(b []byte) error {
 		Type           string `json:"type"`
 		Guid           *GUID  `json:"guid"`
 		SequenceNumber U64    `json:"sequence_number"`
+		Version        U64    `json:"version"`
 		Data           any    `json:"data"`
 	}
 
@@ -85,6 +107,7 @@ func (o *Event) UnmarshalJSON(b []byte) error {
 	o.Type = data.Type
 	o.Guid = data.Guid
 	o.SequenceNumber = data.SequenceNumber.ToUint64()
+	o.Version = dataAny.Version.ToUint64()
 	o.Data = dataMap
 
 	return err
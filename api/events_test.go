@@ -33,6 +33,26 @@ func TestEvent_V1(t *testing.T) {
 	assert.Equal(t, addr, data.Guid.AccountAddress)
 }
 
+func TestEvent_Versioned(t *testing.T) {
+	testJson := `{
+		"version": "1234",
+		"type": "0x1::coin::WithdrawEvent",
+		"guid": {
+			"account_address": "0x1",
+			"creation_number": "3"
+		},
+		"sequence_number": "5",
+		"data": {
+			"amount": "1000"
+		}
+	}`
+	data := &Event{}
+	err := json.Unmarshal([]byte(testJson), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1234), data.Version)
+	assert.Equal(t, uint64(5), data.SequenceNumber)
+}
+
 func TestEvent_V2(t *testing.T) {
 	testJson := `	{
 		"type": "0x1::fungible_asset::Withdraw",
@@ -78,3 +98,21 @@ func TestEvent_V2_Other(t *testing.T) {
 	assert.Equal(t, uint64(0), data.Guid.CreationNumber)
 	assert.Equal(t, &types.AccountZero, data.Guid.AccountAddress)
 }
+
+func TestEvent_DataInto(t *testing.T) {
+	data := &Event{}
+	err := json.Unmarshal([]byte(`{"type": "0x1::coin::DepositEvent", "sequence_number": "0", "data": {"amount": "1000"}}`), &data)
+	assert.NoError(t, err)
+	deposit := struct {
+		Amount U64 `json:"amount"`
+	}{}
+	assert.NoError(t, data.DataInto(&deposit))
+	assert.Equal(t, uint64(1000), deposit.Amount.ToUint64())
+
+	// Non-map data is decoded directly
+	err = json.Unmarshal([]byte(`{"type": "0x1::m::Id", "sequence_number": "0", "data": "5"}`), &data)
+	assert.NoError(t, err)
+	var value string
+	assert.NoError(t, data.DataInto(&value))
+	assert.Equal(t, "5", value)
+}
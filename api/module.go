@@ -76,6 +76,26 @@ type MoveModule struct {
 	Structs          []*MoveStruct         `json:"structs"`           // Structs are the structs defined in the module.
 }
 
+// Function finds an exposed function by name, nil if the module has none
+func (o *MoveModule) Function(name string) *MoveFunction {
+	for _, function := range o.ExposedFunctions {
+		if function != nil && function.Name == name {
+			return function
+		}
+	}
+	return nil
+}
+
+// Struct finds a struct by name, nil if the module has none
+func (o *MoveModule) Struct(name string) *MoveStruct {
+	for _, moveStruct := range o.Structs {
+		if moveStruct != nil && moveStruct.Name == name {
+			return moveStruct
+		}
+	}
+	return nil
+}
+
 // MoveScript is the representation of a compiled script.  The API may not fill in the ABI field.
 //
 // Example:
@@ -167,6 +187,15 @@ type MoveFunction struct {
 	Return            []string            `json:"return"`              // Return is the return type for the function in string format for the TypeTag
 }
 
+// ArgParams are the params passed as transaction arguments, without the leading signers filled in by the transaction
+func (o *MoveFunction) ArgParams() []string {
+	params := o.Params
+	for len(params) > 0 && (params[0] == "signer" || params[0] == "&signer") {
+		params = params[1:]
+	}
+	return params
+}
+
 // GenericTypeParam is a set of requirements for a generic.  These can be applied via different
 // [MoveAbility] constraints required on the type.
 //
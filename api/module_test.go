@@ -31,3 +31,72 @@ func TestModule_MoveScript(t *testing.T) {
 	expectedRes, _ := util.ParseHex("0xa11ceb0b060000000901000202020403060f0515")
 	assert.Equal(t, HexBytes(expectedRes), data.Bytecode)
 }
+
+func TestModule_HexBytesJSON(t *testing.T) {
+	var data HexBytes
+	assert.NoError(t, json.Unmarshal([]byte(`"0xa11ceb0b"`), &data))
+	assert.Equal(t, HexBytes{0xa1, 0x1c, 0xeb, 0x0b}, data)
+	assert.NoError(t, json.Unmarshal([]byte(`"a11ceb0b"`), &data))
+	assert.Equal(t, HexBytes{0xa1, 0x1c, 0xeb, 0x0b}, data)
+	assert.NoError(t, json.Unmarshal([]byte(`"0x"`), &data))
+	assert.Equal(t, HexBytes{}, data)
+	// Escaped strings still go through the slow path
+	assert.NoError(t, json.Unmarshal([]byte(`"\u0030x01"`), &data))
+	assert.Equal(t, HexBytes{0x01}, data)
+	assert.Error(t, json.Unmarshal([]byte(`"0xzz"`), &data))
+	assert.Error(t, json.Unmarshal([]byte(`12`), &data))
+
+	out, err := json.Marshal(HexBytes{0xa1, 0x1c, 0xeb, 0x0b})
+	assert.NoError(t, err)
+	assert.Equal(t, `"0xa11ceb0b"`, string(out))
+	out, err = json.Marshal(HexBytes{})
+	assert.NoError(t, err)
+	assert.Equal(t, `"0x"`, string(out))
+}
+
+// TestModule_MoveModuleLookup tests finding functions and structs in a module ABI
+func TestModule_MoveModuleLookup(t *testing.T) {
+	testJson := `{
+		"bytecode": "0xa11ceb0b",
+		"abi": {
+			"address": "0x1",
+			"name": "coin",
+			"friends": [],
+			"exposed_functions": [
+				{
+					"name": "transfer",
+					"visibility": "public",
+					"is_entry": true,
+					"is_view": false,
+					"generic_type_params": [{"constraints": []}],
+					"params": ["&signer", "address", "u64"],
+					"return": []
+				}
+			],
+			"structs": [
+				{
+					"name": "Coin",
+					"is_native": false,
+					"abilities": ["store"],
+					"generic_type_params": [{"constraints": []}],
+					"fields": [{"name": "value", "type": "u64"}]
+				}
+			]
+		}
+	}`
+	data := &MoveBytecode{}
+	err := json.Unmarshal([]byte(testJson), &data)
+	assert.NoError(t, err)
+
+	transfer := data.Abi.Function("transfer")
+	assert.NotNil(t, transfer)
+	assert.True(t, transfer.IsEntry)
+	assert.Equal(t, []string{"address", "u64"}, transfer.ArgParams())
+	assert.Nil(t, data.Abi.Function("balance"))
+
+	coin := data.Abi.Struct("Coin")
+	assert.NotNil(t, coin)
+	assert.Equal(t, []MoveAbility{MoveAbilityStore}, coin.Abilities)
+	assert.Equal(t, "value", coin.Fields[0].Name)
+	assert.Nil(t, data.Abi.Struct("CoinStore"))
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/aptos-labs/aptos-go-sdk/internal/types"
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
@@ -65,19 +66,42 @@ type HexBytes []byte
 //
 //	"0x123456" -> []byte{0x12, 0x34, 0x56}
 func (u *HexBytes) UnmarshalJSON(b []byte) error {
+	// Hex never needs escaping, so decode straight from the JSON rather than copying through a string, these can be
+	// large e.g. module bytecode
+	if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' && bytes.IndexByte(b, '\\') < 0 {
+		decoded, err := util.DecodeHexBytes(b[1 : len(b)-1])
+		if err != nil {
+			return err
+		}
+		*u = decoded
+		return nil
+	}
+
 	var str string
 	err := json.Unmarshal(b, &str)
 	if err != nil {
 		return err
 	}
-	bytes, err := util.ParseHex(str)
+	decoded, err := util.ParseHex(str)
 	if err != nil {
 		return err
 	}
-	*u = bytes
+	*u = decoded
 	return nil
 }
 
+// MarshalJSON serializes a [HexBytes] to a 0x prefixed hex JSON string
+//
+// Example:
+//
+//	[]byte{0x12, 0x34, 0x56} -> "0x123456"
+func (u HexBytes) MarshalJSON() ([]byte, error) {
+	out := make([]byte, 1, len(u)*2+4)
+	out[0] = '"'
+	out = util.AppendHex(out, u)
+	return append(out, '"'), nil
+}
+
 // Hash is a representation of a hash as Hex in JSON
 //
 // # This is always represented as a 32-byte hash in hexadecimal format
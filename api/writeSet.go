@@ -2,6 +2,8 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
+
 	"github.com/aptos-labs/aptos-go-sdk/internal/types"
 )
 
@@ -119,6 +121,54 @@ func (o *WriteSetChange) UnmarshalJSON(b []byte) error {
 	return json.Unmarshal(b, o.Inner)
 }
 
+// WriteResource changes the change to a [WriteSetChangeWriteResource]; however, it will fail if it's not one.
+func (o *WriteSetChange) WriteResource() (*WriteSetChangeWriteResource, error) {
+	if o.Type == WriteSetChangeVariantWriteResource {
+		return o.Inner.(*WriteSetChangeWriteResource), nil
+	}
+	return nil, fmt.Errorf("write set change type is not write_resource: %s", o.Type)
+}
+
+// DeleteResource changes the change to a [WriteSetChangeDeleteResource]; however, it will fail if it's not one.
+func (o *WriteSetChange) DeleteResource() (*WriteSetChangeDeleteResource, error) {
+	if o.Type == WriteSetChangeVariantDeleteResource {
+		return o.Inner.(*WriteSetChangeDeleteResource), nil
+	}
+	return nil, fmt.Errorf("write set change type is not delete_resource: %s", o.Type)
+}
+
+// WriteModule changes the change to a [WriteSetChangeWriteModule]; however, it will fail if it's not one.
+func (o *WriteSetChange) WriteModule() (*WriteSetChangeWriteModule, error) {
+	if o.Type == WriteSetChangeVariantWriteModule {
+		return o.Inner.(*WriteSetChangeWriteModule), nil
+	}
+	return nil, fmt.Errorf("write set change type is not write_module: %s", o.Type)
+}
+
+// DeleteModule changes the change to a [WriteSetChangeDeleteModule]; however, it will fail if it's not one.
+func (o *WriteSetChange) DeleteModule() (*WriteSetChangeDeleteModule, error) {
+	if o.Type == WriteSetChangeVariantDeleteModule {
+		return o.Inner.(*WriteSetChangeDeleteModule), nil
+	}
+	return nil, fmt.Errorf("write set change type is not delete_module: %s", o.Type)
+}
+
+// WriteTableItem changes the change to a [WriteSetChangeWriteTableItem]; however, it will fail if it's not one.
+func (o *WriteSetChange) WriteTableItem() (*WriteSetChangeWriteTableItem, error) {
+	if o.Type == WriteSetChangeVariantWriteTableItem {
+		return o.Inner.(*WriteSetChangeWriteTableItem), nil
+	}
+	return nil, fmt.Errorf("write set change type is not write_table_item: %s", o.Type)
+}
+
+// DeleteTableItem changes the change to a [WriteSetChangeDeleteTableItem]; however, it will fail if it's not one.
+func (o *WriteSetChange) DeleteTableItem() (*WriteSetChangeDeleteTableItem, error) {
+	if o.Type == WriteSetChangeVariantDeleteTableItem {
+		return o.Inner.(*WriteSetChangeDeleteTableItem), nil
+	}
+	return nil, fmt.Errorf("write set change type is not delete_table_item: %s", o.Type)
+}
+
 // WriteSetChangeImpl is an interface for all write set changes
 type WriteSetChangeImpl interface {
 }
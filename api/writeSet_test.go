@@ -91,7 +91,10 @@ func TestWriteSet_WriteModule(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, WriteSetChangeVariantWriteModule, data.Type)
-	inner := data.Inner.(*WriteSetChangeWriteModule) // TODO: probably make this a bit cleaner with a function
+	inner, err := data.WriteModule()
+	assert.NoError(t, err)
+	_, err = data.WriteResource()
+	assert.Error(t, err)
 	expectedAddress := &types.AccountAddress{}
 	err = expectedAddress.ParseStringRelaxed("0xe42895bdea9ffef448368a95f51b4c883a8e025be3f8e7d08df39f46861a0dc5")
 	assert.NoError(t, err)
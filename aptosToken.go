@@ -0,0 +1,434 @@
+package aptos
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// TokenResourceType is the resource of a digital asset in the 0x4::token standard
+const TokenResourceType = "0x4::token::Token"
+
+// Property types of a [TokenProperty], as named by 0x4::property_map
+const (
+	TokenPropertyTypeBool    = "bool"
+	TokenPropertyTypeU8      = "u8"
+	TokenPropertyTypeU16     = "u16"
+	TokenPropertyTypeU32     = "u32"
+	TokenPropertyTypeU64     = "u64"
+	TokenPropertyTypeU128    = "u128"
+	TokenPropertyTypeU256    = "u256"
+	TokenPropertyTypeAddress = "address"
+	TokenPropertyTypeBytes   = "vector<u8>"
+	TokenPropertyTypeString  = "0x1::string::String"
+)
+
+// TokenProperty is an entry of the property map of a token, with the value BCS encoded as its type
+type TokenProperty struct {
+	Key   string // Key is the name of the property
+	Type  string // Type is the Move type of the value e.g. [TokenPropertyTypeU64]
+	Value []byte // Value is the BCS encoded value
+}
+
+// NewTokenProperty encodes a property from a Go value.  Accepts bool, uint8, uint16, uint32, uint64, [AccountAddress],
+// string, and []byte.  Use [NewTokenPropertyU128] and [NewTokenPropertyU256] for larger numbers.
+//
+//	property, err := NewTokenProperty("level", uint64(3))
+func NewTokenProperty(key string, value any) (TokenProperty, error) {
+	var propertyType string
+	var marshal func(ser *bcs.Serializer)
+	switch v := value.(type) {
+	case bool:
+		propertyType, marshal = TokenPropertyTypeBool, func(ser *bcs.Serializer) { ser.Bool(v) }
+	case uint8:
+		propertyType, marshal = TokenPropertyTypeU8, func(ser *bcs.Serializer) { ser.U8(v) }
+	case uint16:
+		propertyType, marshal = TokenPropertyTypeU16, func(ser *bcs.Serializer) { ser.U16(v) }
+	case uint32:
+		propertyType, marshal = TokenPropertyTypeU32, func(ser *bcs.Serializer) { ser.U32(v) }
+	case uint64:
+		propertyType, marshal = TokenPropertyTypeU64, func(ser *bcs.Serializer) { ser.U64(v) }
+	case AccountAddress:
+		propertyType, marshal = TokenPropertyTypeAddress, func(ser *bcs.Serializer) { v.MarshalBCS(ser) }
+	case string:
+		propertyType, marshal = TokenPropertyTypeString, func(ser *bcs.Serializer) { ser.WriteString(v) }
+	case []byte:
+		propertyType, marshal = TokenPropertyTypeBytes, func(ser *bcs.Serializer) { ser.WriteBytes(v) }
+	default:
+		return TokenProperty{}, fmt.Errorf("token property %s has unsupported type %T", key, value)
+	}
+	return newTokenProperty(key, propertyType, marshal)
+}
+
+// NewTokenPropertyU128 encodes a u128 property
+func NewTokenPropertyU128(key string, value *big.Int) (TokenProperty, error) {
+	return newTokenProperty(key, TokenPropertyTypeU128, func(ser *bcs.Serializer) { ser.U128(*value) })
+}
+
+// NewTokenPropertyU256 encodes a u256 property
+func NewTokenPropertyU256(key string, value *big.Int) (TokenProperty, error) {
+	return newTokenProperty(key, TokenPropertyTypeU256, func(ser *bcs.Serializer) { ser.U256(*value) })
+}
+
+func newTokenProperty(key string, propertyType string, marshal func(ser *bcs.Serializer)) (TokenProperty, error) {
+	value, err := bcs.SerializeSingle(marshal)
+	if err != nil {
+		return TokenProperty{}, fmt.Errorf("failed to encode token property %s: %w", key, err)
+	}
+	return TokenProperty{Key: key, Type: propertyType, Value: value}, nil
+}
+
+// encodeTokenProperties encodes properties as the property_keys, property_types, and property_values arguments of
+// 0x4::aptos_token::mint
+func encodeTokenProperties(properties []TokenProperty) (keys []byte, types []byte, values []byte, err error) {
+	keys, err = bcs.SerializeSingle(func(ser *bcs.Serializer) {
+		bcs.SerializeSequenceWithFunction(properties, ser, func(ser *bcs.Serializer, property TokenProperty) {
+			ser.WriteString(property.Key)
+		})
+	})
+	if err != nil {
+		return
+	}
+	types, err = bcs.SerializeSingle(func(ser *bcs.Serializer) {
+		bcs.SerializeSequenceWithFunction(properties, ser, func(ser *bcs.Serializer, property TokenProperty) {
+			ser.WriteString(property.Type)
+		})
+	})
+	if err != nil {
+		return
+	}
+	values, err = bcs.SerializeSingle(func(ser *bcs.Serializer) {
+		bcs.SerializeSequenceWithFunction(properties, ser, func(ser *bcs.Serializer, property TokenProperty) {
+			ser.WriteBytes(property.Value)
+		})
+	})
+	return
+}
+
+// AptosCollectionConfig configures a collection created by [AptosTokenCreateCollectionPayload]
+type AptosCollectionConfig struct {
+	Name        string // Name is unique across the collections of the creator
+	Description string // Description of the collection
+	Uri         string // Uri of the collection metadata
+	MaxSupply   uint64 // MaxSupply is the most tokens that can be minted, use math.MaxUint64 for no practical limit
+
+	MutableDescription       bool // MutableDescription allows the creator to change the description of the collection
+	MutableRoyalty           bool // MutableRoyalty allows the creator to change the royalty of the collection
+	MutableUri               bool // MutableUri allows the creator to change the uri of the collection
+	MutableTokenDescription  bool // MutableTokenDescription allows the creator to change the description of tokens
+	MutableTokenName         bool // MutableTokenName allows the creator to change the name of tokens
+	MutableTokenProperties   bool // MutableTokenProperties allows the creator to add, update, and remove token properties
+	MutableTokenUri          bool // MutableTokenUri allows the creator to change the uri of tokens
+	TokensBurnableByCreator  bool // TokensBurnableByCreator allows the creator to burn tokens
+	TokensFreezableByCreator bool // TokensFreezableByCreator allows the creator to freeze transfers of tokens
+
+	RoyaltyNumerator   uint64 // RoyaltyNumerator of the royalty fraction paid to the creator, 0 for none
+	RoyaltyDenominator uint64 // RoyaltyDenominator of the royalty fraction, must not be 0
+}
+
+// AptosTokenMint describes a token minted by [AptosTokenMintPayload]
+type AptosTokenMint struct {
+	Collection  string          // Collection is the name of a collection of the creator
+	Name        string          // Name of the token
+	Description string          // Description of the token
+	Uri         string          // Uri of the token metadata
+	Properties  []TokenProperty // Properties are the initial property map of the token
+}
+
+// AptosTokenCreateCollectionPayload builds an EntryFunction payload for 0x4::aptos_token::create_collection
+func AptosTokenCreateCollectionPayload(config AptosCollectionConfig) *EntryFunction {
+	args := make([][]byte, 0, 15)
+	ser := &bcs.Serializer{}
+	// Each argument is serialized on its own, none of them can fail
+	next := func() {
+		args = append(args, ser.ToBytes())
+		ser = &bcs.Serializer{}
+	}
+	ser.WriteString(config.Description)
+	next()
+	ser.U64(config.MaxSupply)
+	next()
+	ser.WriteString(config.Name)
+	next()
+	ser.WriteString(config.Uri)
+	next()
+	for _, flag := range []bool{
+		config.MutableDescription,
+		config.MutableRoyalty,
+		config.MutableUri,
+		config.MutableTokenDescription,
+		config.MutableTokenName,
+		config.MutableTokenProperties,
+		config.MutableTokenUri,
+		config.TokensBurnableByCreator,
+		config.TokensFreezableByCreator,
+	} {
+		ser.Bool(flag)
+		next()
+	}
+	ser.U64(config.RoyaltyNumerator)
+	next()
+	ser.U64(config.RoyaltyDenominator)
+	next()
+	return aptosTokenEntryFunction("create_collection", []TypeTag{}, args)
+}
+
+// AptosTokenMintPayload builds an EntryFunction payload for 0x4::aptos_token::mint, or for
+// 0x4::aptos_token::mint_soul_bound if soulBoundTo is set, minting a token that can never be transferred away from it
+func AptosTokenMintPayload(mint AptosTokenMint, soulBoundTo *AccountAddress) (*EntryFunction, error) {
+	args := make([][]byte, 0, 9)
+	for _, s := range []string{mint.Collection, mint.Description, mint.Name, mint.Uri} {
+		arg, err := bcs.SerializeSingle(func(ser *bcs.Serializer) { ser.WriteString(s) })
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	keys, types, values, err := encodeTokenProperties(mint.Properties)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, keys, types, values)
+	if soulBoundTo == nil {
+		return aptosTokenEntryFunction("mint", []TypeTag{}, args), nil
+	}
+	args = append(args, soulBoundTo[:])
+	return aptosTokenEntryFunction("mint_soul_bound", []TypeTag{}, args), nil
+}
+
+// ObjectTransferPayload builds an EntryFunction payload for 0x1::object::transfer, which transfers any transferable
+// object e.g. a token to the receiver
+func ObjectTransferPayload(object AccountAddress, objectType TypeTag, receiver AccountAddress) *EntryFunction {
+	return &EntryFunction{
+		Module: ModuleId{
+			Address: AccountOne,
+			Name:    "object",
+		},
+		Function: "transfer",
+		ArgTypes: []TypeTag{objectType},
+		Args: [][]byte{
+			object[:],
+			receiver[:],
+		},
+	}
+}
+
+// AptosTokenBurnPayload builds an EntryFunction payload for 0x4::aptos_token::burn
+func AptosTokenBurnPayload(token AccountAddress) *EntryFunction {
+	return aptosTokenEntryFunction("burn", []TypeTag{tokenTypeTag()}, [][]byte{token[:]})
+}
+
+// AptosTokenFreezeTransferPayload builds an EntryFunction payload for 0x4::aptos_token::freeze_transfer, or
+// 0x4::aptos_token::unfreeze_transfer if frozen is false
+func AptosTokenFreezeTransferPayload(token AccountAddress, frozen bool) *EntryFunction {
+	function := "freeze_transfer"
+	if !frozen {
+		function = "unfreeze_transfer"
+	}
+	return aptosTokenEntryFunction(function, []TypeTag{tokenTypeTag()}, [][]byte{token[:]})
+}
+
+// AptosTokenSetPayload builds an EntryFunction payload for 0x4::aptos_token::set_description, set_name, or set_uri,
+// depending on field, which must be "description", "name", or "uri"
+func AptosTokenSetPayload(token AccountAddress, field string, value string) (*EntryFunction, error) {
+	switch field {
+	case "description", "name", "uri":
+	default:
+		return nil, fmt.Errorf("token field %s can't be set, use description, name, or uri", field)
+	}
+	valueBytes, err := bcs.SerializeSingle(func(ser *bcs.Serializer) { ser.WriteString(value) })
+	if err != nil {
+		return nil, err
+	}
+	return aptosTokenEntryFunction("set_"+field, []TypeTag{tokenTypeTag()}, [][]byte{token[:], valueBytes}), nil
+}
+
+// AptosTokenAddPropertyPayload builds an EntryFunction payload for 0x4::aptos_token::add_property, or
+// 0x4::aptos_token::update_property if update is true
+func AptosTokenAddPropertyPayload(token AccountAddress, property TokenProperty, update bool) (*EntryFunction, error) {
+	key, err := bcs.SerializeSingle(func(ser *bcs.Serializer) { ser.WriteString(property.Key) })
+	if err != nil {
+		return nil, err
+	}
+	propertyType, err := bcs.SerializeSingle(func(ser *bcs.Serializer) { ser.WriteString(property.Type) })
+	if err != nil {
+		return nil, err
+	}
+	value, err := bcs.SerializeBytes(property.Value)
+	if err != nil {
+		return nil, err
+	}
+	function := "add_property"
+	if update {
+		function = "update_property"
+	}
+	return aptosTokenEntryFunction(function, []TypeTag{tokenTypeTag()}, [][]byte{token[:], key, propertyType, value}), nil
+}
+
+// AptosTokenRemovePropertyPayload builds an EntryFunction payload for 0x4::aptos_token::remove_property
+func AptosTokenRemovePropertyPayload(token AccountAddress, key string) (*EntryFunction, error) {
+	keyBytes, err := bcs.SerializeSingle(func(ser *bcs.Serializer) { ser.WriteString(key) })
+	if err != nil {
+		return nil, err
+	}
+	return aptosTokenEntryFunction("remove_property", []TypeTag{tokenTypeTag()}, [][]byte{token[:], keyBytes}), nil
+}
+
+func aptosTokenEntryFunction(function string, argTypes []TypeTag, args [][]byte) *EntryFunction {
+	return &EntryFunction{
+		Module: ModuleId{
+			Address: AccountFour,
+			Name:    "aptos_token",
+		},
+		Function: function,
+		ArgTypes: argTypes,
+		Args:     args,
+	}
+}
+
+func tokenTypeTag() TypeTag {
+	return TypeTag{Value: &StructTag{Address: AccountFour, Module: "token", Name: "Token"}}
+}
+
+// MintedTokens returns the addresses of the tokens minted by a committed transaction, from its 0x4::collection::Mint
+// and 0x4::collection::MintEvent events
+func MintedTokens(txn *api.UserTransaction) ([]AccountAddress, error) {
+	tokens := make([]AccountAddress, 0)
+	for _, event := range txn.Events {
+		if event.Type != "0x4::collection::Mint" && event.Type != "0x4::collection::MintEvent" {
+			continue
+		}
+		token, ok := event.Data["token"].(string)
+		if !ok {
+			return nil, fmt.Errorf("bad token in %s event: %v", event.Type, event.Data["token"])
+		}
+		address := AccountAddress{}
+		err := address.ParseStringRelaxed(token)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, address)
+	}
+	return tokens, nil
+}
+
+// AptosTokenClient builds and signs transactions for digital assets of the 0x4::aptos_token standard.  Each method
+// accepts the options of [Client.BuildTransaction], e.g. [MaxGasAmount].
+//
+//	tokenClient := NewAptosTokenClient(client)
+//	signedTxn, err := tokenClient.Mint(creator, AptosTokenMint{Collection: "Heroes", Name: "Alice"})
+//	// Submit, wait, then find the address of the new token
+//	tokens, err := MintedTokens(txn)
+type AptosTokenClient struct {
+	aptosClient *Client // Aptos client
+}
+
+// NewAptosTokenClient creates a client for the 0x4::aptos_token standard
+func NewAptosTokenClient(client *Client) *AptosTokenClient {
+	return &AptosTokenClient{client}
+}
+
+// CreateCollection creates a collection owned by the creator, which tokens are minted into
+func (client *AptosTokenClient) CreateCollection(creator TransactionSigner, config AptosCollectionConfig, options ...any) (*SignedTransaction, error) {
+	return client.sign(creator, AptosTokenCreateCollectionPayload(config), options)
+}
+
+// Mint mints a token into a collection of the creator, owned by the creator
+func (client *AptosTokenClient) Mint(creator TransactionSigner, mint AptosTokenMint, options ...any) (*SignedTransaction, error) {
+	payload, err := AptosTokenMintPayload(mint, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(creator, payload, options)
+}
+
+// MintSoulBound mints a token into a collection of the creator, which is owned by soulBoundTo and can never be
+// transferred
+func (client *AptosTokenClient) MintSoulBound(creator TransactionSigner, mint AptosTokenMint, soulBoundTo AccountAddress, options ...any) (*SignedTransaction, error) {
+	payload, err := AptosTokenMintPayload(mint, &soulBoundTo)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(creator, payload, options)
+}
+
+// Transfer transfers a token from its owner to the receiver
+func (client *AptosTokenClient) Transfer(owner TransactionSigner, token AccountAddress, receiver AccountAddress, options ...any) (*SignedTransaction, error) {
+	return client.sign(owner, ObjectTransferPayload(token, tokenTypeTag(), receiver), options)
+}
+
+// Burn burns a token, if the collection allows the creator to
+func (client *AptosTokenClient) Burn(creator TransactionSigner, token AccountAddress, options ...any) (*SignedTransaction, error) {
+	return client.sign(creator, AptosTokenBurnPayload(token), options)
+}
+
+// Freeze stops the owner transferring a token, if the collection allows the creator to
+func (client *AptosTokenClient) Freeze(creator TransactionSigner, token AccountAddress, options ...any) (*SignedTransaction, error) {
+	return client.sign(creator, AptosTokenFreezeTransferPayload(token, true), options)
+}
+
+// Unfreeze allows the owner to transfer a token again
+func (client *AptosTokenClient) Unfreeze(creator TransactionSigner, token AccountAddress, options ...any) (*SignedTransaction, error) {
+	return client.sign(creator, AptosTokenFreezeTransferPayload(token, false), options)
+}
+
+// SetDescription changes the description of a token, if the collection allows the creator to
+func (client *AptosTokenClient) SetDescription(creator TransactionSigner, token AccountAddress, description string, options ...any) (*SignedTransaction, error) {
+	return client.set(creator, token, "description", description, options)
+}
+
+// SetName changes the name of a token, if the collection allows the creator to
+func (client *AptosTokenClient) SetName(creator TransactionSigner, token AccountAddress, name string, options ...any) (*SignedTransaction, error) {
+	return client.set(creator, token, "name", name, options)
+}
+
+// SetUri changes the uri of a token, if the collection allows the creator to
+func (client *AptosTokenClient) SetUri(creator TransactionSigner, token AccountAddress, uri string, options ...any) (*SignedTransaction, error) {
+	return client.set(creator, token, "uri", uri, options)
+}
+
+// AddProperty adds a property to a token, if the collection allows the creator to mutate properties
+func (client *AptosTokenClient) AddProperty(creator TransactionSigner, token AccountAddress, property TokenProperty, options ...any) (*SignedTransaction, error) {
+	payload, err := AptosTokenAddPropertyPayload(token, property, false)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(creator, payload, options)
+}
+
+// UpdateProperty replaces an existing property of a token, if the collection allows the creator to mutate properties
+func (client *AptosTokenClient) UpdateProperty(creator TransactionSigner, token AccountAddress, property TokenProperty, options ...any) (*SignedTransaction, error) {
+	payload, err := AptosTokenAddPropertyPayload(token, property, true)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(creator, payload, options)
+}
+
+// RemoveProperty removes a property of a token, if the collection allows the creator to mutate properties
+func (client *AptosTokenClient) RemoveProperty(creator TransactionSigner, token AccountAddress, key string, options ...any) (*SignedTransaction, error) {
+	payload, err := AptosTokenRemovePropertyPayload(token, key)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(creator, payload, options)
+}
+
+func (client *AptosTokenClient) set(creator TransactionSigner, token AccountAddress, field string, value string, options []any) (*SignedTransaction, error) {
+	payload, err := AptosTokenSetPayload(token, field, value)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(creator, payload, options)
+}
+
+// sign builds and signs a transaction for the payload
+func (client *AptosTokenClient) sign(sender TransactionSigner, payload *EntryFunction, options []any) (*SignedTransaction, error) {
+	rawTxn, err := client.aptosClient.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return rawTxn.SignedTransaction(sender)
+}
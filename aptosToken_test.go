@@ -0,0 +1,110 @@
+package aptos
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTokenProperty(t *testing.T) {
+	property, err := NewTokenProperty("level", uint64(3))
+	assert.NoError(t, err)
+	assert.Equal(t, TokenProperty{Key: "level", Type: TokenPropertyTypeU64, Value: []byte{3, 0, 0, 0, 0, 0, 0, 0}}, property)
+
+	property, err = NewTokenProperty("class", "mage")
+	assert.NoError(t, err)
+	assert.Equal(t, TokenPropertyTypeString, property.Type)
+	assert.Equal(t, []byte{4, 'm', 'a', 'g', 'e'}, property.Value)
+
+	property, err = NewTokenProperty("owner", AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, TokenPropertyTypeAddress, property.Type)
+	assert.Equal(t, AccountOne[:], property.Value)
+
+	property, err = NewTokenPropertyU128("power", big.NewInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, TokenPropertyTypeU128, property.Type)
+	assert.Len(t, property.Value, 16)
+
+	_, err = NewTokenProperty("level", 3)
+	assert.Error(t, err)
+}
+
+func TestAptosTokenPayloads(t *testing.T) {
+	collection := AptosTokenCreateCollectionPayload(AptosCollectionConfig{
+		Name:                    "Heroes",
+		MaxSupply:               10,
+		MutableTokenProperties:  true,
+		TokensBurnableByCreator: true,
+		RoyaltyDenominator:      100,
+	})
+	assert.Equal(t, "create_collection", collection.Function)
+	assert.Equal(t, AccountFour, collection.Module.Address)
+	assert.Len(t, collection.Args, 15)
+	assert.Equal(t, []byte{6, 'H', 'e', 'r', 'o', 'e', 's'}, collection.Args[2])
+	assert.Equal(t, []byte{1}, collection.Args[9])
+	assert.Equal(t, []byte{1}, collection.Args[11])
+	assert.Equal(t, []byte{0}, collection.Args[12])
+
+	level, err := NewTokenProperty("level", uint8(3))
+	assert.NoError(t, err)
+	mint, err := AptosTokenMintPayload(AptosTokenMint{Collection: "Heroes", Name: "Alice", Properties: []TokenProperty{level}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "mint", mint.Function)
+	assert.Len(t, mint.Args, 7)
+	assert.Equal(t, []byte{1, 5, 'l', 'e', 'v', 'e', 'l'}, mint.Args[4])
+	assert.Equal(t, []byte{1, 2, 'u', '8'}, mint.Args[5])
+	assert.Equal(t, []byte{1, 1, 3}, mint.Args[6])
+
+	soulBound, err := AptosTokenMintPayload(AptosTokenMint{Collection: "Heroes", Name: "Bob"}, &AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, "mint_soul_bound", soulBound.Function)
+	assert.Len(t, soulBound.Args, 8)
+	assert.Equal(t, AccountOne[:], soulBound.Args[7])
+
+	transfer := ObjectTransferPayload(AccountTwo, tokenTypeTag(), AccountThree)
+	assert.Equal(t, "object", transfer.Module.Name)
+	assert.Equal(t, TokenResourceType, transfer.ArgTypes[0].String())
+	assert.Equal(t, [][]byte{AccountTwo[:], AccountThree[:]}, transfer.Args)
+
+	assert.Equal(t, "unfreeze_transfer", AptosTokenFreezeTransferPayload(AccountTwo, false).Function)
+	set, err := AptosTokenSetPayload(AccountTwo, "uri", "https://example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "set_uri", set.Function)
+	_, err = AptosTokenSetPayload(AccountTwo, "royalty", "1")
+	assert.Error(t, err)
+
+	update, err := AptosTokenAddPropertyPayload(AccountTwo, level, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "update_property", update.Function)
+	assert.Equal(t, []byte{1, 3}, update.Args[3])
+}
+
+func TestAptosTokenClient(t *testing.T) {
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: "http://localhost:8080/v1"})
+	assert.NoError(t, err)
+	creator, err := NewEd25519Account()
+	assert.NoError(t, err)
+
+	// No requests are needed when the options are given
+	tokenClient := NewAptosTokenClient(client)
+	signedTxn, err := tokenClient.Transfer(creator, AccountTwo, AccountThree, SequenceNumber(1), GasUnitPrice(100), MaxGasAmount(1000))
+	assert.NoError(t, err)
+	payload := signedTxn.Transaction.Payload.Payload.(*EntryFunction)
+	assert.Equal(t, "transfer", payload.Function)
+	assert.Equal(t, uint64(1), signedTxn.Transaction.SequenceNumber)
+}
+
+func TestMintedTokens(t *testing.T) {
+	txn := &api.UserTransaction{Events: []*api.Event{
+		{Type: "0x1::fungible_asset::Withdraw", Data: map[string]any{"store": "0x1"}},
+		{Type: "0x4::collection::Mint", Data: map[string]any{"collection": "0x5", "token": "0xa"}},
+	}}
+	tokens, err := MintedTokens(txn)
+	assert.NoError(t, err)
+	expected := AccountAddress{}
+	assert.NoError(t, expected.ParseStringRelaxed("0xa"))
+	assert.Equal(t, []AccountAddress{expected}, tokens)
+}
@@ -0,0 +1,237 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// AptDecimals is the number of decimals for APT, 1 APT = 10^8 octas
+const AptDecimals = uint8(8)
+
+// AssetKind tells if an [Asset] is a legacy coin or a fungible asset
+type AssetKind uint8
+
+const (
+	AssetKindCoin          AssetKind = iota // AssetKindCoin is a legacy coin, identified by its coin type e.g. 0x1::aptos_coin::AptosCoin
+	AssetKindFungibleAsset                  // AssetKindFungibleAsset is a fungible asset, identified by its metadata object address
+)
+
+// Asset identifies a coin by its type, or a fungible asset by its metadata address
+//
+// Use [CoinAsset], [FungibleAsset], or [ParseAsset] to create one
+type Asset struct {
+	Kind     AssetKind      // Kind of the asset
+	CoinType *TypeTag       // CoinType is set for [AssetKindCoin]
+	Metadata AccountAddress // Metadata is set for [AssetKindFungibleAsset]
+}
+
+// AptAsset is the APT coin
+var AptAsset = CoinAsset(AptosCoinTypeTag)
+
+// CoinAsset creates an [Asset] for a legacy coin type
+func CoinAsset(coinType TypeTag) Asset {
+	return Asset{Kind: AssetKindCoin, CoinType: &coinType}
+}
+
+// FungibleAsset creates an [Asset] for a fungible asset metadata address
+func FungibleAsset(metadata AccountAddress) Asset {
+	return Asset{Kind: AssetKindFungibleAsset, Metadata: metadata}
+}
+
+// ParseAsset parses either a coin type e.g. 0x1::aptos_coin::AptosCoin, or a fungible asset metadata address e.g. 0xa
+func ParseAsset(input string) (Asset, error) {
+	if strings.Contains(input, "::") {
+		coinType, err := ParseTypeTag(input)
+		if err != nil {
+			return Asset{}, err
+		}
+		return CoinAsset(*coinType), nil
+	}
+	metadata := AccountAddress{}
+	err := metadata.ParseStringRelaxed(input)
+	if err != nil {
+		return Asset{}, err
+	}
+	return FungibleAsset(metadata), nil
+}
+
+// String returns the coin type or the metadata address
+func (asset Asset) String() string {
+	switch asset.Kind {
+	case AssetKindCoin:
+		if asset.CoinType == nil {
+			return ""
+		}
+		return asset.CoinType.String()
+	default:
+		return asset.Metadata.String()
+	}
+}
+
+// Equal tells if two assets identify the same coin or fungible asset
+func (asset Asset) Equal(other Asset) bool {
+	return asset.Kind == other.Kind && asset.String() == other.String()
+}
+
+// TransferPayload builds a transfer of value of the asset to dest.  Coins use 0x1::aptos_account::transfer_coins, and
+// fungible assets use the primary store transfer.
+func (asset Asset) TransferPayload(dest AccountAddress, value uint64) (*EntryFunction, error) {
+	switch asset.Kind {
+	case AssetKindCoin:
+		return CoinTransferPayload(asset.CoinType, dest, value)
+	case AssetKindFungibleAsset:
+		return FungibleAssetPrimaryStoreTransferPayload(&asset.Metadata, dest, value)
+	default:
+		return nil, fmt.Errorf("unknown asset kind %d", asset.Kind)
+	}
+}
+
+// Amount is a value of an [Asset] in its smallest unit e.g. octas, labeled with the asset's decimals
+type Amount struct {
+	Asset    Asset  // Asset the amount is denominated in
+	Value    uint64 // Value in the smallest unit of the asset
+	Decimals uint8  // Decimals of the asset, used for formatting
+}
+
+// NewAmount creates an [Amount] from a value in the smallest unit of the asset
+func NewAmount(asset Asset, value uint64, decimals uint8) Amount {
+	return Amount{Asset: asset, Value: value, Decimals: decimals}
+}
+
+// AptAmount creates an [Amount] of APT from octas
+func AptAmount(octas uint64) Amount {
+	return NewAmount(AptAsset, octas, AptDecimals)
+}
+
+// ParseAmount parses a decimal string e.g. "1.5" into an [Amount] with the given decimals
+//
+// Returns an error if the string has more fractional digits than decimals, or overflows a u64
+func ParseAmount(asset Asset, input string, decimals uint8) (Amount, error) {
+	value, err := ParseDecimalValue(input, decimals)
+	if err != nil {
+		return Amount{}, err
+	}
+	return NewAmount(asset, value, decimals), nil
+}
+
+// String formats the amount as a decimal without trailing zeros e.g. "1.5"
+func (amount Amount) String() string {
+	return FormatDecimalValue(amount.Value, amount.Decimals)
+}
+
+// Add adds two amounts of the same asset, returning an error on mismatch or overflow
+func (amount Amount) Add(other Amount) (Amount, error) {
+	if !amount.Asset.Equal(other.Asset) {
+		return Amount{}, fmt.Errorf("cannot add %s to %s", other.Asset.String(), amount.Asset.String())
+	}
+	sum := amount.Value + other.Value
+	if sum < amount.Value {
+		return Amount{}, errors.New("amount overflow")
+	}
+	return NewAmount(amount.Asset, sum, amount.Decimals), nil
+}
+
+// FormatDecimalValue formats a value in the smallest unit as a decimal string e.g. 150000000 with 8 decimals is "1.5"
+func FormatDecimalValue(value uint64, decimals uint8) string {
+	if decimals == 0 {
+		return fmt.Sprintf("%d", value)
+	}
+	digits := fmt.Sprintf("%0*d", int(decimals)+1, value)
+	whole := digits[:len(digits)-int(decimals)]
+	fraction := strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+	if fraction == "" {
+		return whole
+	}
+	return whole + "." + fraction
+}
+
+// ParseDecimalValue parses a decimal string e.g. "1.5" into the smallest unit, with 8 decimals it is 150000000
+func ParseDecimalValue(input string, decimals uint8) (uint64, error) {
+	whole, fraction, hasFraction := strings.Cut(strings.TrimSpace(input), ".")
+	if whole == "" && (!hasFraction || fraction == "") {
+		return 0, fmt.Errorf("invalid amount %#v", input)
+	}
+	if len(fraction) > int(decimals) {
+		return 0, fmt.Errorf("amount %s has more than %d decimals", input, decimals)
+	}
+	digits := whole + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid amount %#v", input)
+		}
+	}
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok || !value.IsUint64() {
+		return 0, fmt.Errorf("amount %s overflows u64", input)
+	}
+	return value.Uint64(), nil
+}
+
+// Balance fetches the balance of an [Asset] for the owner, with its decimals.  Fungible assets are read from the
+// owner's primary store.
+func (client *Client) Balance(owner AccountAddress, asset Asset, ledgerVersion ...uint64) (Amount, error) {
+	ownerBytes, err := bcs.Serialize(&owner)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	var balancePayload, decimalsPayload *ViewPayload
+	switch asset.Kind {
+	case AssetKindCoin:
+		if asset.CoinType == nil {
+			return Amount{}, errors.New("coin asset missing coin type")
+		}
+		coinModule := ModuleId{Address: AccountOne, Name: "coin"}
+		balancePayload = &ViewPayload{Module: coinModule, Function: "balance", ArgTypes: []TypeTag{*asset.CoinType}, Args: [][]byte{ownerBytes}}
+		decimalsPayload = &ViewPayload{Module: coinModule, Function: "decimals", ArgTypes: []TypeTag{*asset.CoinType}, Args: [][]byte{}}
+	case AssetKindFungibleAsset:
+		balancePayload = &ViewPayload{
+			Module:   ModuleId{Address: AccountOne, Name: "primary_fungible_store"},
+			Function: "balance",
+			ArgTypes: []TypeTag{metadataStructTag()},
+			Args:     [][]byte{ownerBytes, asset.Metadata[:]},
+		}
+		decimalsPayload = &ViewPayload{
+			Module:   ModuleId{Address: AccountOne, Name: "fungible_asset"},
+			Function: "decimals",
+			ArgTypes: []TypeTag{metadataStructTag()},
+			Args:     [][]byte{asset.Metadata[:]},
+		}
+	default:
+		return Amount{}, fmt.Errorf("unknown asset kind %d", asset.Kind)
+	}
+
+	balanceVals, err := client.View(balancePayload, ledgerVersion...)
+	if err != nil {
+		return Amount{}, err
+	}
+	if len(balanceVals) != 1 {
+		return Amount{}, fmt.Errorf("bad view return from node, balance returned %d values, expected 1", len(balanceVals))
+	}
+	balanceStr, ok := balanceVals[0].(string)
+	if !ok {
+		return Amount{}, fmt.Errorf("bad balance view return %v", balanceVals[0])
+	}
+	value, err := StrToUint64(balanceStr)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	decimalsVals, err := client.View(decimalsPayload, ledgerVersion...)
+	if err != nil {
+		return Amount{}, err
+	}
+	if len(decimalsVals) != 1 {
+		return Amount{}, fmt.Errorf("bad view return from node, decimals returned %d values, expected 1", len(decimalsVals))
+	}
+	decimals, ok := decimalsVals[0].(float64)
+	if !ok {
+		return Amount{}, fmt.Errorf("bad decimals view return %v", decimalsVals[0])
+	}
+
+	return NewAmount(asset, value, uint8(decimals)), nil
+}
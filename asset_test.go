@@ -0,0 +1,124 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimalValue(t *testing.T) {
+	formats := map[uint64]string{
+		0:           "0",
+		1:           "0.00000001",
+		150_000_000: "1.5",
+		100_000_000: "1",
+		123_456_789: "1.23456789",
+	}
+	for value, expected := range formats {
+		assert.Equal(t, expected, FormatDecimalValue(value, AptDecimals))
+		parsed, err := ParseDecimalValue(expected, AptDecimals)
+		assert.NoError(t, err)
+		assert.Equal(t, value, parsed)
+	}
+	assert.Equal(t, "42", FormatDecimalValue(42, 0))
+
+	parsed, err := ParseDecimalValue(".5", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(50), parsed)
+
+	for _, bad := range []string{"", ".", "1.2.3", "abc", "-1", "1.123456789", "184467440737.09551616"} {
+		_, err = ParseDecimalValue(bad, AptDecimals)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestParseAsset(t *testing.T) {
+	asset, err := ParseAsset("0x1::aptos_coin::AptosCoin")
+	assert.NoError(t, err)
+	assert.Equal(t, AssetKindCoin, asset.Kind)
+	assert.True(t, asset.Equal(AptAsset))
+
+	asset, err = ParseAsset("0xa")
+	assert.NoError(t, err)
+	assert.Equal(t, AssetKindFungibleAsset, asset.Kind)
+	assert.Equal(t, "0xa", asset.String())
+	assert.False(t, asset.Equal(AptAsset))
+
+	_, err = ParseAsset("not an asset")
+	assert.Error(t, err)
+}
+
+func TestAmount(t *testing.T) {
+	amount, err := ParseAmount(AptAsset, "1.5", AptDecimals)
+	assert.NoError(t, err)
+	assert.Equal(t, AptAmount(150_000_000), amount)
+	assert.Equal(t, "1.5", amount.String())
+
+	sum, err := amount.Add(AptAmount(50_000_000))
+	assert.NoError(t, err)
+	assert.Equal(t, "2", sum.String())
+
+	_, err = amount.Add(NewAmount(FungibleAsset(AccountOne), 1, 8))
+	assert.Error(t, err)
+
+	_, err = AptAmount(^uint64(0)).Add(AptAmount(1))
+	assert.Error(t, err)
+}
+
+func TestClientBalance(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/view", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		// The function name is in the BCS body, so check it for which view this is
+		if bytes.Contains(body, []byte("decimals")) {
+			json.NewEncoder(w).Encode([]any{6})
+		} else {
+			json.NewEncoder(w).Encode([]any{"1234567"})
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{Name: "mocknet", ChainId: 4, NodeUrl: mockServer.URL})
+	assert.NoError(t, err)
+
+	for _, asset := range []Asset{AptAsset, FungibleAsset(AccountTwo)} {
+		amount, err := client.Balance(AccountOne, asset)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1234567), amount.Value)
+		assert.Equal(t, uint8(6), amount.Decimals)
+		assert.Equal(t, "1.234567", amount.String())
+	}
+}
+
+func TestClientBalanceBadView(t *testing.T) {
+	for _, name := range []string{"balance", "decimals"} {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				isDecimals := bytes.Contains(body, []byte("decimals"))
+				switch {
+				case isDecimals == (name == "decimals"):
+					json.NewEncoder(w).Encode([]any{})
+				case isDecimals:
+					json.NewEncoder(w).Encode([]any{6})
+				default:
+					json.NewEncoder(w).Encode([]any{"1234567"})
+				}
+			}))
+			defer mockServer.Close()
+
+			client, err := NewClient(NetworkConfig{Name: "mocknet", ChainId: 4, NodeUrl: mockServer.URL})
+			assert.NoError(t, err)
+
+			_, err = client.Balance(AccountOne, AptAsset)
+			assert.ErrorContains(t, err, name+" returned 0 values")
+		})
+	}
+}
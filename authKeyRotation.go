@@ -0,0 +1,288 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+)
+
+// RotationProofChallenge is the 0x1::account::RotationProofChallenge struct, it must be signed by both the current and
+// the new key to rotate the authentication key of an account.
+//
+// Implements:
+//   - [bcs.Marshaler]
+//   - [bcs.Unmarshaler]
+//   - [bcs.Struct]
+type RotationProofChallenge struct {
+	SequenceNumber uint64         // SequenceNumber is the current sequence number of the account
+	Originator     AccountAddress // Originator is the address of the account being rotated
+	CurrentAuthKey AccountAddress // CurrentAuthKey is the on-chain authentication key before rotation
+	NewPublicKey   []byte         // NewPublicKey is the bytes of the public key being rotated to
+}
+
+// MarshalBCS serializes the [RotationProofChallenge] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (challenge *RotationProofChallenge) MarshalBCS(ser *bcs.Serializer) {
+	ser.U64(challenge.SequenceNumber)
+	ser.Struct(&challenge.Originator)
+	ser.Struct(&challenge.CurrentAuthKey)
+	ser.WriteBytes(challenge.NewPublicKey)
+}
+
+// UnmarshalBCS deserializes the [RotationProofChallenge] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (challenge *RotationProofChallenge) UnmarshalBCS(des *bcs.Deserializer) {
+	challenge.SequenceNumber = des.U64()
+	des.Struct(&challenge.Originator)
+	des.Struct(&challenge.CurrentAuthKey)
+	challenge.NewPublicKey = des.ReadBytes()
+}
+
+// SigningMessage is the message both keys sign.  Move checks signatures over a SignedMessage, which prefixes the BCS of
+// the challenge with its 0x1::type_info::TypeInfo.
+func (challenge *RotationProofChallenge) SigningMessage() ([]byte, error) {
+	return bcs.SerializeSingle(func(ser *bcs.Serializer) {
+		ser.Struct(&AccountOne)
+		ser.WriteBytes([]byte("account"))
+		ser.WriteBytes([]byte("RotationProofChallenge"))
+		challenge.MarshalBCS(ser)
+	})
+}
+
+// rotationScheme returns the scheme accepted by 0x1::account::rotate_authentication_key for a signer
+func rotationScheme(signer crypto.Signer) (scheme uint8, err error) {
+	switch signer.PubKey().(type) {
+	case *crypto.Ed25519PublicKey:
+		return crypto.Ed25519Scheme, nil
+	case *crypto.MultiEd25519PublicKey:
+		return crypto.MultiEd25519Scheme, nil
+	default:
+		return 0, fmt.Errorf("auth key rotation only supports ed25519 and multi ed25519 keys, got %T", signer.PubKey())
+	}
+}
+
+// RotateAuthKeyPayload builds an EntryFunction payload for 0x1::account::rotate_authentication_key
+//
+// Args:
+//   - fromScheme and fromPublicKey are the scheme and bytes of the current public key
+//   - toScheme and toPublicKey are the scheme and bytes of the new public key
+//   - capRotateKey is the signature of the [RotationProofChallenge] by the current key
+//   - capUpdateTable is the signature of the [RotationProofChallenge] by the new key
+func RotateAuthKeyPayload(fromScheme uint8, fromPublicKey []byte, toScheme uint8, toPublicKey []byte, capRotateKey []byte, capUpdateTable []byte) (payload *EntryFunction, err error) {
+	fromPublicKeyBytes, err := bcs.SerializeBytes(fromPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	toPublicKeyBytes, err := bcs.SerializeBytes(toPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	capRotateKeyBytes, err := bcs.SerializeBytes(capRotateKey)
+	if err != nil {
+		return nil, err
+	}
+	capUpdateTableBytes, err := bcs.SerializeBytes(capUpdateTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EntryFunction{
+		Module: ModuleId{
+			Address: AccountOne,
+			Name:    "account",
+		},
+		Function: "rotate_authentication_key",
+		ArgTypes: []TypeTag{},
+		Args: [][]byte{
+			{fromScheme},
+			fromPublicKeyBytes,
+			{toScheme},
+			toPublicKeyBytes,
+			capRotateKeyBytes,
+			capUpdateTableBytes,
+		},
+	}, nil
+}
+
+// NewRotateAuthKeyPayload signs a [RotationProofChallenge] with both the current and new signers, and builds the
+// rotation payload from the signatures.  currentAuthKey is the on-chain authentication key of the account, which is
+// only different from the address once the account has been rotated before.
+func NewRotateAuthKeyPayload(address AccountAddress, sequenceNumber uint64, currentAuthKey AccountAddress, currentSigner crypto.Signer, newSigner crypto.Signer) (payload *EntryFunction, err error) {
+	fromScheme, err := rotationScheme(currentSigner)
+	if err != nil {
+		return nil, err
+	}
+	toScheme, err := rotationScheme(newSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &RotationProofChallenge{
+		SequenceNumber: sequenceNumber,
+		Originator:     address,
+		CurrentAuthKey: currentAuthKey,
+		NewPublicKey:   newSigner.PubKey().Bytes(),
+	}
+	message, err := challenge.SigningMessage()
+	if err != nil {
+		return nil, err
+	}
+	capRotateKey, err := currentSigner.SignMessage(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign rotation proof with current key: %w", err)
+	}
+	capUpdateTable, err := newSigner.SignMessage(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign rotation proof with new key: %w", err)
+	}
+
+	return RotateAuthKeyPayload(fromScheme, currentSigner.PubKey().Bytes(), toScheme, challenge.NewPublicKey, capRotateKey.Bytes(), capUpdateTable.Bytes())
+}
+
+// RotateAuthKey rotates the authentication key of account to newSigner with 0x1::account::rotate_authentication_key,
+// and waits for the transaction to commit.  On success, account.Signer is replaced by newSigner, and account.Address
+// is kept, as the address no longer matches the authentication key.  Use [Client.OriginatingAddress] to find the
+// address of a rotated account from its new key.
+//
+// Accepts options:
+//   - [MaxGasAmount]
+//   - [GasUnitPrice]
+//   - [GasPriority]
+//   - [ExpirationSeconds]
+//   - [ChainIdOption]
+//   - [PollPeriod]
+//   - [PollTimeout]
+//   - [PollBackoff]
+//   - [MaxPollPeriod]
+//   - [LongPoll]
+//   - [ErrorOnFailure]
+func (client *Client) RotateAuthKey(account *Account, newSigner crypto.Signer, options ...any) (data *api.UserTransaction, err error) {
+	return client.nodeClient.RotateAuthKey(account, newSigner, options...)
+}
+
+// RotateAuthKey rotates the authentication key of account to newSigner with 0x1::account::rotate_authentication_key,
+// and waits for the transaction to commit.  On success, account.Signer is replaced by newSigner, and account.Address
+// is kept, as the address no longer matches the authentication key.
+//
+// Accepts options:
+//   - [MaxGasAmount]
+//   - [GasUnitPrice]
+//   - [GasPriority]
+//   - [ExpirationSeconds]
+//   - [ChainIdOption]
+//   - [PollPeriod]
+//   - [PollTimeout]
+//   - [PollBackoff]
+//   - [MaxPollPeriod]
+//   - [LongPoll]
+//   - [ErrorOnFailure]
+func (rc *NodeClient) RotateAuthKey(account *Account, newSigner crypto.Signer, options ...any) (data *api.UserTransaction, err error) {
+	buildOptions := make([]any, 0, len(options)+1)
+	pollOptions := make([]any, 0, 2)
+	for _, option := range options {
+		switch option.(type) {
+		case PollPeriod, PollTimeout, PollBackoff, MaxPollPeriod, LongPoll, ErrorOnFailure:
+			pollOptions = append(pollOptions, option)
+		case SequenceNumber:
+			return nil, errors.New("RotateAuthKey uses the on-chain sequence number, SequenceNumber is not allowed")
+		default:
+			buildOptions = append(buildOptions, option)
+		}
+	}
+
+	info, err := rc.Account(account.Address)
+	if err != nil {
+		return nil, err
+	}
+	sequenceNumber, err := info.SequenceNumber()
+	if err != nil {
+		return nil, err
+	}
+	authKeyBytes, err := info.AuthenticationKey()
+	if err != nil {
+		return nil, err
+	}
+	currentAuthKey := AccountAddress{}
+	if len(authKeyBytes) != len(currentAuthKey) {
+		return nil, fmt.Errorf("invalid on-chain authentication key length %d", len(authKeyBytes))
+	}
+	copy(currentAuthKey[:], authKeyBytes)
+	if *account.Signer.AuthKey() != crypto.AuthenticationKey(currentAuthKey) {
+		return nil, fmt.Errorf("account signer does not match on-chain authentication key %s", currentAuthKey.String())
+	}
+
+	payload, err := NewRotateAuthKeyPayload(account.Address, sequenceNumber, currentAuthKey, account.Signer, newSigner)
+	if err != nil {
+		return nil, err
+	}
+	buildOptions = append(buildOptions, SequenceNumber(sequenceNumber))
+	submitResponse, err := rc.BuildSignAndSubmitTransaction(account, TransactionPayload{Payload: payload}, buildOptions...)
+	if err != nil {
+		return nil, err
+	}
+	data, err = rc.WaitForTransaction(submitResponse.Hash, pollOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if !data.Success {
+		return data, fmt.Errorf("auth key rotation failed: %s", data.VmStatus)
+	}
+
+	account.Signer = newSigner
+	return data, nil
+}
+
+// OriginatingAddress looks up the address of an account from its authentication key, with
+// 0x1::account::originating_address.  ok is false if no rotated account uses the authentication key.
+//
+// Accounts that have never been rotated aren't tracked, their address is their authentication key.
+func (client *Client) OriginatingAddress(authKey crypto.AuthenticationKey, ledgerVersion ...uint64) (address AccountAddress, ok bool, err error) {
+	return client.nodeClient.OriginatingAddress(authKey, ledgerVersion...)
+}
+
+// OriginatingAddress looks up the address of an account from its authentication key, with
+// 0x1::account::originating_address.  ok is false if no rotated account uses the authentication key.
+func (rc *NodeClient) OriginatingAddress(authKey crypto.AuthenticationKey, ledgerVersion ...uint64) (address AccountAddress, ok bool, err error) {
+	values, err := rc.View(&ViewPayload{
+		Module: ModuleId{
+			Address: AccountOne,
+			Name:    "account",
+		},
+		Function: "originating_address",
+		ArgTypes: []TypeTag{},
+		Args:     [][]byte{authKey[:]},
+	}, ledgerVersion...)
+	if err != nil {
+		return address, false, err
+	}
+	if len(values) != 1 {
+		return address, false, fmt.Errorf("originating_address returned %d values", len(values))
+	}
+	option, isMap := values[0].(map[string]any)
+	if !isMap {
+		return address, false, fmt.Errorf("originating_address returned bad option %v", values[0])
+	}
+	vec, isVec := option["vec"].([]any)
+	if !isVec {
+		return address, false, fmt.Errorf("originating_address returned bad option %v", values[0])
+	}
+	if len(vec) == 0 {
+		return address, false, nil
+	}
+	addressStr, isStr := vec[0].(string)
+	if !isStr {
+		return address, false, fmt.Errorf("originating_address returned bad address %v", vec[0])
+	}
+	err = address.ParseStringRelaxed(addressStr)
+	if err != nil {
+		return address, false, err
+	}
+	return address, true, nil
+}
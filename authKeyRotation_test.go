@@ -0,0 +1,163 @@
+package aptos
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotationProofChallenge(t *testing.T) {
+	challenge := &RotationProofChallenge{
+		SequenceNumber: 5,
+		Originator:     AccountTwo,
+		CurrentAuthKey: AccountThree,
+		NewPublicKey:   []byte{1, 2, 3},
+	}
+	challengeBytes, err := bcs.Serialize(challenge)
+	assert.NoError(t, err)
+
+	challenge2 := &RotationProofChallenge{}
+	err = bcs.Deserialize(challenge2, challengeBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, challenge, challenge2)
+
+	// The signing message is the type info of 0x1::account::RotationProofChallenge followed by the challenge
+	message, err := challenge.SigningMessage()
+	assert.NoError(t, err)
+	typeInfo := append(AccountOne[:], append([]byte("\x07account"), []byte("\x16RotationProofChallenge")...)...)
+	assert.Equal(t, append(typeInfo, challengeBytes...), message)
+}
+
+func TestNewRotateAuthKeyPayload(t *testing.T) {
+	currentKey, err := crypto.GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	newKey, err := crypto.GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	address := AccountAddress(*currentKey.AuthKey())
+
+	payload, err := NewRotateAuthKeyPayload(address, 3, address, currentKey, newKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "rotate_authentication_key", payload.Function)
+	assert.Len(t, payload.Args, 6)
+	assert.Equal(t, []byte{crypto.Ed25519Scheme}, payload.Args[0])
+	assert.Equal(t, []byte{crypto.Ed25519Scheme}, payload.Args[2])
+
+	challenge := &RotationProofChallenge{
+		SequenceNumber: 3,
+		Originator:     address,
+		CurrentAuthKey: address,
+		NewPublicKey:   newKey.PubKey().Bytes(),
+	}
+	message, err := challenge.SigningMessage()
+	assert.NoError(t, err)
+	capRotateKey := &crypto.Ed25519Signature{}
+	assert.NoError(t, capRotateKey.FromBytes(bcs.NewDeserializer(payload.Args[4]).ReadBytes()))
+	assert.True(t, currentKey.PubKey().Verify(message, capRotateKey))
+	capUpdateTable := &crypto.Ed25519Signature{}
+	assert.NoError(t, capUpdateTable.FromBytes(bcs.NewDeserializer(payload.Args[5]).ReadBytes()))
+	assert.True(t, newKey.PubKey().Verify(message, capUpdateTable))
+
+	// Single key accounts can't be rotated with rotate_authentication_key
+	secp256k1Key, err := crypto.GenerateSecp256k1Key()
+	assert.NoError(t, err)
+	_, err = NewRotateAuthKeyPayload(address, 3, address, currentKey, crypto.NewSingleSigner(secp256k1Key))
+	assert.Error(t, err)
+}
+
+func TestRotateAuthKey(t *testing.T) {
+	account, err := NewEd25519Account()
+	assert.NoError(t, err)
+	originalAuthKey := *account.AuthKey()
+	newKey, err := crypto.GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+
+	success := true
+	var submitted *SignedTransaction
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/estimate_gas_price":
+			json.NewEncoder(w).Encode(map[string]any{"gas_estimate": 100})
+		case r.URL.Path == "/accounts/"+account.Address.String():
+			json.NewEncoder(w).Encode(map[string]any{"sequence_number": "9", "authentication_key": originalAuthKey.ToHex()})
+		case r.URL.Path == "/transactions" && r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			submitted = &SignedTransaction{}
+			assert.NoError(t, bcs.Deserialize(submitted, body))
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(map[string]any{"hash": "0x1234", "sender": account.Address.String(), "sequence_number": "9"})
+		case strings.HasPrefix(r.URL.Path, "/transactions/wait_by_hash/"):
+			fmt.Fprintf(w, `{"type": "user_transaction", "version": "10", "hash": "0x1234", "sender": "%s", "sequence_number": "9", "max_gas_amount": "100", "gas_unit_price": "100", "expiration_timestamp_secs": "1", "gas_used": "10", "success": %t, "vm_status": "Executed successfully", "changes": [], "events": [], "timestamp": "1"}`, account.Address.String(), success)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	// The sequence number has to be the on-chain one, to match the proof
+	_, err = client.RotateAuthKey(account, newKey, SequenceNumber(1))
+	assert.Error(t, err)
+
+	success = false
+	_, err = client.RotateAuthKey(account, newKey)
+	assert.Error(t, err)
+	assert.Equal(t, originalAuthKey, *account.AuthKey())
+
+	success = true
+	originalAddress := account.Address
+	txn, err := client.RotateAuthKey(account, newKey, MaxGasAmount(2000))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), txn.Version)
+	assert.Equal(t, uint64(9), submitted.Transaction.SequenceNumber)
+	assert.Equal(t, uint64(2000), submitted.Transaction.MaxGasAmount)
+	signingMessage, err := submitted.Transaction.SigningMessage()
+	assert.NoError(t, err)
+	assert.True(t, submitted.Authenticator.Verify(signingMessage))
+
+	// The account keeps its address, but now signs with the new key
+	assert.Equal(t, originalAddress, account.Address)
+	assert.Equal(t, *newKey.AuthKey(), *account.AuthKey())
+
+	// The old key no longer matches the on-chain authentication key
+	_, err = client.RotateAuthKey(account, newKey)
+	assert.Error(t, err)
+}
+
+func TestOriginatingAddress(t *testing.T) {
+	found := true
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/view" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if found {
+			w.Write([]byte(`[{"vec": ["0x2"]}]`))
+		} else {
+			w.Write([]byte(`[{"vec": []}]`))
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	address, ok, err := client.OriginatingAddress(crypto.AuthenticationKey{})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, AccountTwo, address)
+
+	found = false
+	_, ok, err = client.OriginatingAddress(crypto.AuthenticationKey{})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
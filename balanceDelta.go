@@ -0,0 +1,182 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// BalanceDelta is the net change of an account's balance of one asset in a transaction
+type BalanceDelta struct {
+	Account AccountAddress // Account whose balance changed, or the fungible store if its owner isn't in the write set
+	Asset   string         // Asset is the coin type, or the fungible asset metadata address.  Empty if it couldn't be determined
+	Amount  *big.Int       // Amount is the net change in the smallest unit of the asset, negative for a decrease
+}
+
+// BalanceDeltasFromTransaction computes the net balance change per account and asset of a committed transaction, e.g.
+// to credit deposits and debit withdrawals on an exchange.  Accounts and assets are sorted, and zero changes are left
+// out.
+//
+// Changes come from the coin and fungible asset withdraw and deposit events, and the gas paid by the fee payer, or the
+// sender if not sponsored, less any storage refund.  APT is always reported as 0x1::aptos_coin::AptosCoin, whether it
+// moved as a coin or as the 0xa fungible asset.  Coin types of 0x1::coin::DepositEvent and WithdrawEvent, and the
+// owners and metadata of fungible stores, are resolved from the transaction's write set.
+//
+//	for _, delta := range aptos.BalanceDeltasFromTransaction(txn) {
+//		if delta.Account == hotWallet && delta.Asset == aptos.AptosCoinTypeTag.String() {
+//			// credit or debit delta.Amount
+//		}
+//	}
+func BalanceDeltasFromTransaction(txn *api.UserTransaction) []BalanceDelta {
+	if txn == nil {
+		return nil
+	}
+	type key struct {
+		account AccountAddress
+		asset   string
+	}
+	totals := make(map[key]*big.Int)
+	add := func(account AccountAddress, asset string, amount uint64, negative bool) {
+		k := key{account, canonicalAsset(asset)}
+		if totals[k] == nil {
+			totals[k] = new(big.Int)
+		}
+		change := new(big.Int).SetUint64(amount)
+		if negative {
+			change.Neg(change)
+		}
+		totals[k].Add(totals[k], change)
+	}
+
+	// Failed transactions have no withdrawals or deposits, only gas
+	if txn.Success {
+		stores := fungibleStoresFromChanges(txn.Changes)
+		coinHandles := coinHandlesFromChanges(txn.Changes)
+		for _, event := range txn.Events {
+			if event == nil {
+				continue
+			}
+			switch event.Type {
+			case "0x1::coin::DepositEvent", "0x1::coin::WithdrawEvent":
+				data, err := DecodeEvent[CoinDepositEvent](event)
+				if err != nil || event.Guid == nil || event.Guid.AccountAddress == nil {
+					continue
+				}
+				handle := EventHandleId{Account: *event.Guid.AccountAddress, CreationNumber: event.Guid.CreationNumber}
+				add(handle.Account, coinHandles[handle], data.Amount, event.Type == "0x1::coin::WithdrawEvent")
+			case "0x1::coin::CoinDeposit", "0x1::coin::CoinWithdraw":
+				data, err := DecodeEvent[CoinDeposit](event)
+				if err != nil {
+					continue
+				}
+				add(data.Account, normalizeCoinType(data.CoinType), data.Amount, event.Type == "0x1::coin::CoinWithdraw")
+			case "0x1::fungible_asset::Deposit", "0x1::fungible_asset::Withdraw":
+				data, err := DecodeEvent[FungibleAssetDeposit](event)
+				if err != nil {
+					continue
+				}
+				account, asset := data.Store, ""
+				if info, found := stores[data.Store]; found {
+					asset = info.metadata
+					if info.owner != nil {
+						account = *info.owner
+					}
+				}
+				add(account, asset, data.Amount, event.Type == "0x1::fungible_asset::Withdraw")
+			}
+		}
+	}
+
+	if txn.Sender != nil {
+		payer := *txn.Sender
+		if txn.Signature != nil {
+			if feePayerSig, ok := txn.Signature.Inner.(*api.FeePayerSignature); ok && feePayerSig.FeePayerAddress != nil {
+				payer = *feePayerSig.FeePayerAddress
+			}
+		}
+		add(payer, AptosCoinTypeTag.String(), txn.GasUsed*txn.GasUnitPrice, true)
+		for _, event := range txn.Events {
+			if event == nil || event.Type != FeeStatementEventType {
+				continue
+			}
+			if refund, found := event.Data["storage_fee_refund_octas"].(string); found {
+				if amount, err := StrToUint64(refund); err == nil {
+					add(payer, AptosCoinTypeTag.String(), amount, false)
+				}
+			}
+		}
+	}
+
+	deltas := make([]BalanceDelta, 0, len(totals))
+	for k, amount := range totals {
+		if amount.Sign() != 0 {
+			deltas = append(deltas, BalanceDelta{Account: k.account, Asset: k.asset, Amount: amount})
+		}
+	}
+	slices.SortFunc(deltas, func(a, b BalanceDelta) int {
+		if c := bytes.Compare(a.Account[:], b.Account[:]); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Asset, b.Asset)
+	})
+	return deltas
+}
+
+// aptFungibleAssetMetadata is the metadata address of APT as a fungible asset, as formatted by fungibleStoresFromChanges
+var aptFungibleAssetMetadata = func() string {
+	metadata := AccountAddress{}
+	_ = metadata.ParseStringRelaxed("0xa")
+	return metadata.String()
+}()
+
+// canonicalAsset reports APT as 0x1::aptos_coin::AptosCoin, whether it moved as a coin or as the 0xa fungible asset
+func canonicalAsset(asset string) string {
+	if asset == aptFungibleAssetMetadata {
+		return AptosCoinTypeTag.String()
+	}
+	return asset
+}
+
+// coinHandlesFromChanges maps the deposit and withdraw event handles of the coin stores in a write set to their coin types
+func coinHandlesFromChanges(changes []*api.WriteSetChange) map[EventHandleId]string {
+	handles := make(map[EventHandleId]string)
+	for _, change := range changes {
+		if change == nil {
+			continue
+		}
+		write, err := change.WriteResource()
+		if err != nil || write.Data == nil {
+			continue
+		}
+		coinType, found := strings.CutPrefix(write.Data.Type, "0x1::coin::CoinStore<")
+		if !found || !strings.HasSuffix(coinType, ">") {
+			continue
+		}
+		b, err := json.Marshal(write.Data.Data)
+		if err != nil {
+			continue
+		}
+		store := CoinStoreResource{}
+		if json.Unmarshal(b, &store) != nil {
+			continue
+		}
+		coinType = normalizeCoinType(strings.TrimSuffix(coinType, ">"))
+		for _, handle := range []EventHandle{store.DepositEvents, store.WithdrawEvents} {
+			handles[EventHandleId{Account: handle.Address, CreationNumber: handle.CreationNumber}] = coinType
+		}
+	}
+	return handles
+}
+
+// normalizeCoinType formats a coin type the same as [TypeTag.String], or leaves it as is if it can't be parsed
+func normalizeCoinType(coinType string) string {
+	typeTag, err := ParseTypeTag(coinType)
+	if err != nil {
+		return coinType
+	}
+	return typeTag.String()
+}
@@ -0,0 +1,81 @@
+package aptos
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceDeltasFromTransaction(t *testing.T) {
+	txn := &api.UserTransaction{}
+	err := json.Unmarshal([]byte(`{
+		"version": "1",
+		"hash": "0x1",
+		"state_change_hash": "0x1",
+		"event_root_hash": "0x1",
+		"accumulator_root_hash": "0x1",
+		"gas_used": "10",
+		"success": true,
+		"vm_status": "Executed successfully",
+		"sender": "0xb",
+		"sequence_number": "0",
+		"max_gas_amount": "100",
+		"gas_unit_price": "100",
+		"expiration_timestamp_secs": "1",
+		"payload": {"type": "entry_function_payload", "function": "0x1::aptos_account::transfer", "type_arguments": [], "arguments": []},
+		"timestamp": "1",
+		"changes": [
+			{"type": "write_resource", "address": "0xc", "state_key_hash": "0x1", "data": {"type": "0x1::coin::CoinStore<0xcafe::token::Token>", "data": {
+				"coin": {"value": "50"},
+				"frozen": false,
+				"deposit_events": {"counter": "1", "guid": {"id": {"addr": "0xc", "creation_num": "2"}}},
+				"withdraw_events": {"counter": "0", "guid": {"id": {"addr": "0xc", "creation_num": "3"}}}
+			}}},
+			{"type": "write_resource", "address": "0xd", "state_key_hash": "0x1", "data": {"type": "0x1::object::ObjectCore", "data": {
+				"allow_ungated_transfer": false, "guid_creation_num": "0", "owner": "0xb", "transfer_events": {"counter": "0", "guid": {"id": {"addr": "0xd", "creation_num": "0"}}}
+			}}},
+			{"type": "write_resource", "address": "0xd", "state_key_hash": "0x1", "data": {"type": "0x1::fungible_asset::FungibleStore", "data": {
+				"balance": "0", "frozen": false, "metadata": {"inner": "0xa"}
+			}}}
+		],
+		"events": [
+			{"type": "0x1::fungible_asset::Withdraw", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {"store": "0xd", "amount": "700"}},
+			{"type": "0x1::fungible_asset::Deposit", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {"store": "0xe", "amount": "200"}},
+			{"type": "0x1::coin::CoinDeposit", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {"coin_type": "0x1::aptos_coin::AptosCoin", "account": "0xc", "amount": "500"}},
+			{"type": "0x1::coin::DepositEvent", "guid": {"account_address": "0xc", "creation_number": "2"}, "sequence_number": "0", "data": {"amount": "50"}},
+			{"type": "0x1::transaction_fee::FeeStatement", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {
+				"execution_gas_units": "5", "io_gas_units": "5", "storage_fee_octas": "0", "storage_fee_refund_octas": "300", "total_charge_gas_units": "10"
+			}}
+		],
+		"signature": {"type": "ed25519_signature", "public_key": "0x`+"0000000000000000000000000000000000000000000000000000000000000000"+`", "signature": "0x`+"00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"+`"}
+	}`), txn)
+	assert.NoError(t, err)
+
+	address := func(str string) AccountAddress {
+		addr := AccountAddress{}
+		assert.NoError(t, addr.ParseStringRelaxed(str))
+		return addr
+	}
+	apt := AptosCoinTypeTag.String()
+	token, err := ParseTypeTag("0xcafe::token::Token")
+	assert.NoError(t, err)
+
+	// The sender withdrew 700 APT from their primary store and paid 1000 gas, less 300 refunded
+	assert.Equal(t, []BalanceDelta{
+		{Account: address("0xb"), Asset: apt, Amount: big.NewInt(-1400)},
+		{Account: address("0xc"), Asset: token.String(), Amount: big.NewInt(50)},
+		{Account: address("0xc"), Asset: apt, Amount: big.NewInt(500)},
+		{Account: address("0xe"), Asset: "", Amount: big.NewInt(200)},
+	}, BalanceDeltasFromTransaction(txn))
+
+	// A failed transaction only pays gas
+	txn.Success = false
+	assert.Equal(t, []BalanceDelta{
+		{Account: address("0xb"), Asset: apt, Amount: big.NewInt(-700)},
+	}, BalanceDeltasFromTransaction(txn))
+
+	assert.Nil(t, BalanceDeltasFromTransaction(nil))
+}
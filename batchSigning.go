@@ -0,0 +1,169 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+)
+
+// BatchSender is the sender of a transaction in an [UnsignedBatch].  Only the public key is needed, the private key can
+// stay in an HSM or other external signer.
+type BatchSender struct {
+	Address   AccountAddress   // Address of the sender account
+	PublicKey crypto.PublicKey // PublicKey is the public key the signature will be checked against, e.g. [crypto.Ed25519PublicKey]
+}
+
+// UnsignedBatch is a set of transactions built ahead of signing.  The signing messages can be sent to an HSM in a
+// single round trip, and the signatures added back with [AttachSignatures].
+type UnsignedBatch struct {
+	Senders      []BatchSender     // Senders of each transaction, in the same order as Transactions
+	Transactions []*RawTransaction // Transactions to be signed
+	Messages     [][]byte          // Messages are the signing messages for each transaction, in the same order as Transactions
+}
+
+// SigningMessages returns the messages to be signed, in the same order as the transactions
+//
+// These are the full signing messages, schemes that hash before signing (e.g. secp256k1 uses SHA3-256) must hash them
+// the same way [crypto.Signer.Sign] would.
+func (batch *UnsignedBatch) SigningMessages() [][]byte {
+	return batch.Messages
+}
+
+// BuildUnsignedBatch builds one single signer transaction per payload, without signing them
+//
+// senders must be the same length as payloads.  A sender may appear more than once, and its transactions will get
+// consecutive sequence numbers starting at the current on-chain sequence number.  The gas price and chain id are only
+// looked up once for the whole batch.
+//
+// Accepts options:
+//   - [MaxGasAmount]
+//   - [GasUnitPrice]
+//   - [GasPriority]
+//   - [ExpirationSeconds]
+//   - [ChainIdOption]
+func (client *Client) BuildUnsignedBatch(payloads []TransactionPayload, senders []BatchSender, options ...any) (*UnsignedBatch, error) {
+	return client.nodeClient.BuildUnsignedBatch(payloads, senders, options...)
+}
+
+// BuildUnsignedBatch builds one single signer transaction per payload, without signing them
+//
+// senders must be the same length as payloads.  A sender may appear more than once, and its transactions will get
+// consecutive sequence numbers starting at the current on-chain sequence number.  The gas price and chain id are only
+// looked up once for the whole batch.
+//
+// Accepts options:
+//   - [MaxGasAmount]
+//   - [GasUnitPrice]
+//   - [GasPriority]
+//   - [ExpirationSeconds]
+//   - [ChainIdOption]
+func (rc *NodeClient) BuildUnsignedBatch(payloads []TransactionPayload, senders []BatchSender, options ...any) (*UnsignedBatch, error) {
+	if len(payloads) != len(senders) {
+		return nil, fmt.Errorf("BuildUnsignedBatch payloads and senders must be the same length: %d != %d", len(payloads), len(senders))
+	}
+
+	haveGasUnitPrice := false
+	haveChainId := false
+	gasPriority := GasPriorityNormal
+	for opti, option := range options {
+		switch ovalue := option.(type) {
+		case GasUnitPrice:
+			haveGasUnitPrice = true
+		case ChainIdOption:
+			haveChainId = true
+		case GasPriority:
+			gasPriority = ovalue
+		case MaxGasAmount, ExpirationSeconds:
+		case SequenceNumber:
+			return nil, fmt.Errorf("BuildUnsignedBatch arg [%d] SequenceNumber is not allowed, sequence numbers are assigned per sender", opti+3)
+		default:
+			return nil, fmt.Errorf("BuildUnsignedBatch arg [%d] unknown option type %T", opti+3, option)
+		}
+	}
+	// Copy the options, so the per transaction sequence number doesn't leak to the caller
+	options = append(options[:len(options):len(options)], SequenceNumber(0))
+	sequenceNumberIndex := len(options) - 1
+
+	// Look up the shared values once, rather than per transaction
+	if !haveGasUnitPrice {
+		info, err := rc.EstimateGasPrice()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, GasUnitPrice(info.Price(gasPriority)))
+	}
+	if !haveChainId {
+		chainId, err := rc.GetChainId()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, ChainIdOption(chainId))
+	}
+
+	sequenceNumbers := make(map[AccountAddress]uint64)
+	batch := &UnsignedBatch{
+		Senders:      senders,
+		Transactions: make([]*RawTransaction, len(payloads)),
+		Messages:     make([][]byte, len(payloads)),
+	}
+	for i, payload := range payloads {
+		sender := senders[i].Address
+		sequenceNumber, ok := sequenceNumbers[sender]
+		if !ok {
+			account, err := rc.Account(sender)
+			if err != nil {
+				return nil, err
+			}
+			sequenceNumber, err = account.SequenceNumber()
+			if err != nil {
+				return nil, err
+			}
+		}
+		sequenceNumbers[sender] = sequenceNumber + 1
+
+		options[sequenceNumberIndex] = SequenceNumber(sequenceNumber)
+		rawTxn, err := rc.BuildTransaction(sender, payload, options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build transaction %d: %w", i, err)
+		}
+		message, err := rawTxn.SigningMessage()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build signing message %d: %w", i, err)
+		}
+		batch.Transactions[i] = rawTxn
+		batch.Messages[i] = message
+	}
+	return batch, nil
+}
+
+// AttachSignatures combines an [UnsignedBatch] with the signatures over its signing messages, in the same order, to
+// make [SignedTransaction]s ready for submission
+//
+// Every signature is verified against the sender's public key, so a signature returned out of order fails here rather
+// than on submission.
+func AttachSignatures(batch *UnsignedBatch, signatures []crypto.Signature) ([]*SignedTransaction, error) {
+	if batch == nil {
+		return nil, errors.New("AttachSignatures batch is nil")
+	}
+	if len(signatures) != len(batch.Transactions) {
+		return nil, fmt.Errorf("AttachSignatures expected %d signatures, got %d", len(batch.Transactions), len(signatures))
+	}
+
+	signedTxns := make([]*SignedTransaction, len(batch.Transactions))
+	for i, rawTxn := range batch.Transactions {
+		auth := &crypto.AccountAuthenticator{}
+		err := auth.FromKeyAndSignature(batch.Senders[i].PublicKey, signatures[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to attach signature %d: %w", i, err)
+		}
+		if !auth.Verify(batch.Messages[i]) {
+			return nil, fmt.Errorf("signature %d does not verify for sender %s", i, batch.Senders[i].Address.String())
+		}
+		signedTxns[i], err = rawTxn.SignedTransactionWithAuthenticator(auth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to attach signature %d: %w", i, err)
+		}
+	}
+	return signedTxns, nil
+}
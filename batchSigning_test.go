@@ -0,0 +1,86 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsignedBatch(t *testing.T) {
+	alice, err := NewEd25519Account()
+	assert.NoError(t, err)
+	bob, err := NewEd25519Account()
+	assert.NoError(t, err)
+
+	accountRequests := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/estimate_gas_price":
+			json.NewEncoder(w).Encode(map[string]any{"gas_estimate": 150})
+		case strings.HasPrefix(r.URL.Path, "/accounts/"):
+			accountRequests++
+			sequenceNumber := "7"
+			if strings.HasSuffix(r.URL.Path, bob.Address.String()) {
+				sequenceNumber = "2"
+			}
+			json.NewEncoder(w).Encode(map[string]any{"sequence_number": sequenceNumber, "authentication_key": "0x0"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewNodeClient(mockServer.URL, 4)
+	assert.NoError(t, err)
+
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	payloads := []TransactionPayload{{Payload: payload}, {Payload: payload}, {Payload: payload}}
+	senders := []BatchSender{
+		{Address: alice.Address, PublicKey: alice.PubKey()},
+		{Address: bob.Address, PublicKey: bob.PubKey()},
+		{Address: alice.Address, PublicKey: alice.PubKey()},
+	}
+
+	_, err = client.BuildUnsignedBatch(payloads, senders[:2])
+	assert.Error(t, err)
+	_, err = client.BuildUnsignedBatch(payloads, senders, SequenceNumber(1))
+	assert.Error(t, err)
+
+	batch, err := client.BuildUnsignedBatch(payloads, senders)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, accountRequests)
+	assert.Equal(t, uint64(7), batch.Transactions[0].SequenceNumber)
+	assert.Equal(t, uint64(2), batch.Transactions[1].SequenceNumber)
+	assert.Equal(t, uint64(8), batch.Transactions[2].SequenceNumber)
+	for _, txn := range batch.Transactions {
+		assert.Equal(t, uint64(150), txn.GasUnitPrice)
+		assert.Equal(t, uint8(4), txn.ChainId)
+	}
+
+	// Sign externally, as an HSM would
+	signers := []*Account{alice, bob, alice}
+	signatures := make([]crypto.Signature, len(batch.SigningMessages()))
+	for i, message := range batch.SigningMessages() {
+		signatures[i], err = signers[i].SignMessage(message)
+		assert.NoError(t, err)
+	}
+
+	// Out of order signatures are caught before submission
+	_, err = AttachSignatures(batch, []crypto.Signature{signatures[1], signatures[0], signatures[2]})
+	assert.Error(t, err)
+	_, err = AttachSignatures(batch, signatures[:2])
+	assert.Error(t, err)
+
+	signedTxns, err := AttachSignatures(batch, signatures)
+	assert.NoError(t, err)
+	assert.Len(t, signedTxns, 3)
+	for _, signedTxn := range signedTxns {
+		assert.NoError(t, signedTxn.Verify())
+	}
+}
@@ -0,0 +1,163 @@
+package bcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Uint128 is a Move u128 backed by [big.Int].  It serializes to BCS as 16 little-endian bytes, and to JSON as a decimal
+// string, which is how the node API represents u128 values.  The zero value is 0.
+//
+//	amount, err := ParseUint128("340282366920938463463374607431768211455")
+//	bytes, err := Serialize(&amount)
+type Uint128 struct {
+	value big.Int
+}
+
+// Uint256 is a Move u256 backed by [big.Int].  It serializes to BCS as 32 little-endian bytes, and to JSON as a decimal
+// string, which is how the node API represents u256 values.  The zero value is 0.
+type Uint256 struct {
+	value big.Int
+}
+
+// NewUint128 creates a [Uint128], erroring if the value is negative or doesn't fit in 128 bits
+func NewUint128(v *big.Int) (Uint128, error) {
+	out := Uint128{}
+	err := setBigUint(&out.value, v, 128, "u128")
+	return out, err
+}
+
+// NewUint128FromUint64 creates a [Uint128] from a uint64, which always fits
+func NewUint128FromUint64(v uint64) Uint128 {
+	out := Uint128{}
+	out.value.SetUint64(v)
+	return out
+}
+
+// ParseUint128 parses a decimal string into a [Uint128]
+func ParseUint128(s string) (Uint128, error) {
+	out := Uint128{}
+	err := parseBigUint(&out.value, s, 128, "u128")
+	return out, err
+}
+
+// BigInt returns a copy of the value
+func (u Uint128) BigInt() *big.Int {
+	return new(big.Int).Set(&u.value)
+}
+
+// String returns the value as a decimal string
+func (u Uint128) String() string {
+	return u.value.String()
+}
+
+// MarshalBCS serializes the value as 16 little-endian bytes
+func (u *Uint128) MarshalBCS(ser *Serializer) {
+	ser.U128(u.value)
+}
+
+// UnmarshalBCS deserializes 16 little-endian bytes
+func (u *Uint128) UnmarshalBCS(des *Deserializer) {
+	u.value = des.U128()
+}
+
+// MarshalJSON marshals the value as a decimal string
+func (u Uint128) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.value.String())
+}
+
+// UnmarshalJSON unmarshals a decimal string, or a JSON number
+func (u *Uint128) UnmarshalJSON(data []byte) error {
+	return unmarshalBigUintJSON(&u.value, data, 128, "u128")
+}
+
+// NewUint256 creates a [Uint256], erroring if the value is negative or doesn't fit in 256 bits
+func NewUint256(v *big.Int) (Uint256, error) {
+	out := Uint256{}
+	err := setBigUint(&out.value, v, 256, "u256")
+	return out, err
+}
+
+// NewUint256FromUint64 creates a [Uint256] from a uint64, which always fits
+func NewUint256FromUint64(v uint64) Uint256 {
+	out := Uint256{}
+	out.value.SetUint64(v)
+	return out
+}
+
+// ParseUint256 parses a decimal string into a [Uint256]
+func ParseUint256(s string) (Uint256, error) {
+	out := Uint256{}
+	err := parseBigUint(&out.value, s, 256, "u256")
+	return out, err
+}
+
+// BigInt returns a copy of the value
+func (u Uint256) BigInt() *big.Int {
+	return new(big.Int).Set(&u.value)
+}
+
+// String returns the value as a decimal string
+func (u Uint256) String() string {
+	return u.value.String()
+}
+
+// MarshalBCS serializes the value as 32 little-endian bytes
+func (u *Uint256) MarshalBCS(ser *Serializer) {
+	ser.U256(u.value)
+}
+
+// UnmarshalBCS deserializes 32 little-endian bytes
+func (u *Uint256) UnmarshalBCS(des *Deserializer) {
+	u.value = des.U256()
+}
+
+// MarshalJSON marshals the value as a decimal string
+func (u Uint256) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.value.String())
+}
+
+// UnmarshalJSON unmarshals a decimal string, or a JSON number
+func (u *Uint256) UnmarshalJSON(data []byte) error {
+	return unmarshalBigUintJSON(&u.value, data, 256, "u256")
+}
+
+// setBigUint sets out to v, checking it fits in an unsigned integer of the given bits
+func setBigUint(out *big.Int, v *big.Int, bits int, typeName string) error {
+	if v == nil {
+		return fmt.Errorf("cannot create %s from nil", typeName)
+	}
+	if v.Sign() < 0 {
+		return fmt.Errorf("cannot create %s from negative %s", typeName, v.String())
+	}
+	if v.BitLen() > bits {
+		return fmt.Errorf("cannot create %s from %s, it overflows", typeName, v.String())
+	}
+	out.Set(v)
+	return nil
+}
+
+// parseBigUint parses a decimal string into out, checking it fits in an unsigned integer of the given bits
+func parseBigUint(out *big.Int, s string, bits int, typeName string) error {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return fmt.Errorf("cannot parse %s from %q", typeName, s)
+	}
+	return setBigUint(out, v, bits, typeName)
+}
+
+// unmarshalBigUintJSON unmarshals a decimal string or number into out
+func unmarshalBigUintJSON(out *big.Int, data []byte, bits int, typeName string) error {
+	var s string
+	if strings.HasPrefix(string(data), `"`) {
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return err
+		}
+	} else {
+		s = string(data)
+	}
+	return parseBigUint(out, s, bits, typeName)
+}
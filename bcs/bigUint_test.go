@@ -0,0 +1,71 @@
+package bcs
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUint128(t *testing.T) {
+	maxU128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	value, err := NewUint128(maxU128)
+	assert.NoError(t, err)
+	assert.Equal(t, maxU128.String(), value.String())
+
+	bytes, err := Serialize(&value)
+	assert.NoError(t, err)
+	assert.Len(t, bytes, 16)
+	output := Uint128{}
+	assert.NoError(t, Deserialize(&output, bytes))
+	assert.Equal(t, 0, maxU128.Cmp(output.BigInt()))
+
+	// JSON is a decimal string, and numbers are accepted too
+	jsonBytes, err := json.Marshal(struct{ Amount Uint128 }{Amount: NewUint128FromUint64(5)})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Amount":"5"}`, string(jsonBytes))
+	assert.NoError(t, json.Unmarshal([]byte(`"7"`), &output))
+	assert.Equal(t, "7", output.String())
+	assert.NoError(t, json.Unmarshal([]byte(`8`), &output))
+	assert.Equal(t, "8", output.String())
+	assert.Error(t, json.Unmarshal([]byte(`"-1"`), &output))
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &output))
+
+	_, err = NewUint128(new(big.Int).Add(maxU128, big.NewInt(1)))
+	assert.Error(t, err)
+	_, err = NewUint128(big.NewInt(-1))
+	assert.Error(t, err)
+	_, err = NewUint128(nil)
+	assert.Error(t, err)
+
+	// The bigger value fits in a u256
+	_, err = ParseUint256(new(big.Int).Add(maxU128, big.NewInt(1)).String())
+	assert.NoError(t, err)
+}
+
+func TestUint256(t *testing.T) {
+	value, err := ParseUint256("1000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	bytes, err := Serialize(&value)
+	assert.NoError(t, err)
+	assert.Len(t, bytes, 32)
+	output := Uint256{}
+	assert.NoError(t, Deserialize(&output, bytes))
+	assert.Equal(t, value.String(), output.String())
+
+	jsonBytes, err := json.Marshal(NewUint256FromUint64(1))
+	assert.NoError(t, err)
+	assert.Equal(t, `"1"`, string(jsonBytes))
+
+	_, err = ParseUint256(new(big.Int).Lsh(big.NewInt(1), 256).String())
+	assert.Error(t, err)
+}
+
+func TestSerializer_BigUintOutOfRange(t *testing.T) {
+	// Out of range values are an error rather than a panic, or dropping the sign
+	_, err := SerializeU128(*new(big.Int).Lsh(big.NewInt(1), 128))
+	assert.Error(t, err)
+	_, err = SerializeU256(*big.NewInt(-1))
+	assert.Error(t, err)
+}
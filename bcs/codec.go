@@ -0,0 +1,454 @@
+package bcs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Marshal serializes any value into BCS with reflection, so structs don't need a hand-written [Marshaler].
+//
+// Types are serialized as:
+//   - [Marshaler] implementations, by value or by reference, with their own MarshalBCS
+//   - bool, uint8, uint16, uint32, uint64 as themselves, including named types e.g. `type Variant uint8`
+//   - string and []byte with a Uleb128 length first
+//   - [N]byte and other arrays as fixed length, without a length
+//   - slices as sequences, with a Uleb128 length first
+//   - maps as sequences of key value pairs, sorted by the serialized keys
+//   - pointers as the value they point to, a nil pointer is an error unless the field is optional
+//   - structs as their exported fields in order, without any framing
+//
+// Struct fields are configured with the `bcs` tag, with an optional order first, then options:
+//
+//	type Transfer struct {
+//		Receiver [32]byte
+//		Amount   big.Int `bcs:",u128"`     // big.Int must be u128 or u256
+//		Memo     *string `bcs:",optional"` // optional fields are serialized as a Move Option, nil is None
+//		Cached   []byte  `bcs:"-"`         // skipped
+//	}
+//
+// Fields are serialized in the order they are declared, unless every field has an order e.g. `bcs:"2"`, which allows
+// matching an on-chain struct without reordering Go fields.  Signed integers, floats, and interfaces have no BCS
+// representation and are an error.
+func Marshal(v any) ([]byte, error) {
+	if v == nil {
+		return nil, errors.New("cannot marshal nil")
+	}
+	return SerializeSingle(func(ser *Serializer) {
+		encodeValue(ser, reflect.ValueOf(v), fieldOptions{})
+	})
+}
+
+// Unmarshal deserializes BCS into the value v points to, with the same rules as [Marshal].  [Unmarshaler]
+// implementations are used when the type implements it by reference.  It errors if there are remaining bytes.
+//
+//	transfer := Transfer{}
+//	err := Unmarshal(bytes, &transfer)
+func Unmarshal(data []byte, v any) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("cannot unmarshal into non-pointer or nil %T", v)
+	}
+	des := NewDeserializer(data)
+	decodeValue(des, value.Elem(), fieldOptions{})
+	if des.Error() != nil {
+		return des.Error()
+	}
+	if des.Remaining() > 0 {
+		return fmt.Errorf("deserialize failed: remaining %d byte(s)", des.Remaining())
+	}
+	return nil
+}
+
+var (
+	marshalerType   = reflect.TypeFor[Marshaler]()
+	unmarshalerType = reflect.TypeFor[Unmarshaler]()
+	bigIntType      = reflect.TypeFor[big.Int]()
+	byteType        = reflect.TypeFor[byte]()
+)
+
+// fieldOptions are the options of a `bcs` struct tag
+type fieldOptions struct {
+	optional bool // optional serializes a pointer as a Move Option
+	bigSize  uint // bigSize is 128 or 256 for a big.Int, 0 otherwise
+}
+
+// structField is a serialized field of a struct
+type structField struct {
+	index   int
+	name    string
+	order   int
+	options fieldOptions
+}
+
+// structInfo caches the fields of a struct type, or why it can't be serialized
+type structInfo struct {
+	fields []structField
+	err    error
+}
+
+// structInfos caches structInfo by reflect.Type
+var structInfos sync.Map
+
+// structFields parses the `bcs` tags of a struct type
+func structFields(t reflect.Type) ([]structField, error) {
+	if cached, ok := structInfos.Load(t); ok {
+		info := cached.(*structInfo)
+		return info.fields, info.err
+	}
+	fields, err := parseStructFields(t)
+	structInfos.Store(t, &structInfo{fields: fields, err: err})
+	return fields, err
+}
+
+func parseStructFields(t reflect.Type) ([]structField, error) {
+	fields := make([]structField, 0, t.NumField())
+	ordered := 0
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("bcs")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		field := structField{index: i, name: sf.Name}
+		if hasTag {
+			parts := strings.Split(tag, ",")
+			if parts[0] != "" {
+				order, err := strconv.Atoi(parts[0])
+				if err != nil {
+					return nil, fmt.Errorf("field %s.%s has invalid order %s", t.Name(), sf.Name, parts[0])
+				}
+				field.order = order
+				ordered++
+			}
+			for _, option := range parts[1:] {
+				switch option {
+				case "optional":
+					if sf.Type.Kind() != reflect.Pointer {
+						return nil, fmt.Errorf("optional field %s.%s must be a pointer", t.Name(), sf.Name)
+					}
+					field.options.optional = true
+				case "u128":
+					field.options.bigSize = 128
+				case "u256":
+					field.options.bigSize = 256
+				default:
+					return nil, fmt.Errorf("field %s.%s has unknown bcs option %s", t.Name(), sf.Name, option)
+				}
+			}
+		}
+		// Options apply to the elements of pointers, slices, arrays, and map values
+		fieldType := sf.Type
+		for fieldType.Kind() == reflect.Pointer || fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array || fieldType.Kind() == reflect.Map {
+			fieldType = fieldType.Elem()
+		}
+		if field.options.bigSize != 0 && fieldType != bigIntType {
+			return nil, fmt.Errorf("field %s.%s must be a big.Int to be u%d", t.Name(), sf.Name, field.options.bigSize)
+		}
+		fields = append(fields, field)
+	}
+
+	if ordered == 0 {
+		return fields, nil
+	}
+	if ordered != len(fields) {
+		return nil, fmt.Errorf("either all or none of the fields of %s must have an order", t.Name())
+	}
+	slices.SortStableFunc(fields, func(a, b structField) int {
+		return a.order - b.order
+	})
+	for i := 1; i < len(fields); i++ {
+		if fields[i].order == fields[i-1].order {
+			return nil, fmt.Errorf("fields %s and %s of %s have the same order %d", fields[i-1].name, fields[i].name, t.Name(), fields[i].order)
+		}
+	}
+	return fields, nil
+}
+
+// marshalerOf returns the [Marshaler] of a value, by value or by reference
+func marshalerOf(v reflect.Value) (Marshaler, bool) {
+	if v.Type().Implements(marshalerType) {
+		return v.Interface().(Marshaler), true
+	}
+	if reflect.PointerTo(v.Type()).Implements(marshalerType) {
+		if v.CanAddr() {
+			return v.Addr().Interface().(Marshaler), true
+		}
+		// Copy it, so it can be marshaled by reference
+		ref := reflect.New(v.Type())
+		ref.Elem().Set(v)
+		return ref.Interface().(Marshaler), true
+	}
+	return nil, false
+}
+
+// encodeValue serializes a value with reflection, see [Marshal]
+func encodeValue(ser *Serializer, v reflect.Value, options fieldOptions) {
+	if ser.Error() != nil {
+		return
+	}
+	if options.optional {
+		if v.IsNil() {
+			ser.Uleb128(0)
+			return
+		}
+		ser.Uleb128(1)
+		encodeValue(ser, v.Elem(), fieldOptions{bigSize: options.bigSize})
+		return
+	}
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		ser.SetError(fmt.Errorf("cannot marshal nil %s, use an optional field", v.Type()))
+		return
+	}
+	if marshaler, ok := marshalerOf(v); ok {
+		marshaler.MarshalBCS(ser)
+		return
+	}
+	if v.Type() == bigIntType {
+		value := v.Interface().(big.Int)
+		switch options.bigSize {
+		case 128:
+			ser.U128(value)
+		case 256:
+			ser.U256(value)
+		default:
+			ser.SetError(errors.New("cannot marshal big.Int without a u128 or u256 option"))
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		ser.Bool(v.Bool())
+	case reflect.Uint8:
+		ser.U8(uint8(v.Uint()))
+	case reflect.Uint16:
+		ser.U16(uint16(v.Uint()))
+	case reflect.Uint32:
+		ser.U32(uint32(v.Uint()))
+	case reflect.Uint64:
+		ser.U64(v.Uint())
+	case reflect.String:
+		ser.WriteString(v.String())
+	case reflect.Pointer, reflect.Interface:
+		encodeValue(ser, v.Elem(), options)
+	case reflect.Slice:
+		if v.Type().Elem() == byteType {
+			ser.WriteBytes(v.Bytes())
+			return
+		}
+		ser.Uleb128(uint32(v.Len()))
+		encodeElements(ser, v, options)
+	case reflect.Array:
+		encodeElements(ser, v, options)
+	case reflect.Map:
+		encodeMap(ser, v, options)
+	case reflect.Struct:
+		fields, err := structFields(v.Type())
+		if err != nil {
+			ser.SetError(err)
+			return
+		}
+		for _, field := range fields {
+			encodeValue(ser, v.Field(field.index), field.options)
+			if ser.Error() != nil {
+				ser.SetError(fmt.Errorf("could not serialize field %s.%s: %w", v.Type().Name(), field.name, ser.Error()))
+				return
+			}
+		}
+	default:
+		ser.SetError(fmt.Errorf("cannot marshal %s, it has no BCS representation", v.Type()))
+	}
+}
+
+// encodeElements serializes each element of a slice or array, without a length
+func encodeElements(ser *Serializer, v reflect.Value, options fieldOptions) {
+	for i := 0; i < v.Len(); i++ {
+		encodeValue(ser, v.Index(i), options)
+		if ser.Error() != nil {
+			ser.SetError(fmt.Errorf("could not serialize sequence[%d] member of %s %w", i, v.Type(), ser.Error()))
+			return
+		}
+	}
+}
+
+// encodeMap serializes a map as a sequence of key value pairs, sorted by the serialized keys so it is canonical
+func encodeMap(ser *Serializer, v reflect.Value, options fieldOptions) {
+	type entry struct {
+		key   []byte
+		value reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := SerializeSingle(func(ser *Serializer) {
+			encodeValue(ser, iter.Key(), fieldOptions{})
+		})
+		if err != nil {
+			ser.SetError(fmt.Errorf("could not serialize map key of %s %w", v.Type(), err))
+			return
+		}
+		entries = append(entries, entry{key: key, value: iter.Value()})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		return bytes.Compare(a.key, b.key)
+	})
+
+	ser.Uleb128(uint32(len(entries)))
+	for _, entry := range entries {
+		ser.FixedBytes(entry.key)
+		encodeValue(ser, entry.value, options)
+		if ser.Error() != nil {
+			ser.SetError(fmt.Errorf("could not serialize map value of %s %w", v.Type(), ser.Error()))
+			return
+		}
+	}
+}
+
+// decodeValue deserializes into a settable value with reflection, see [Unmarshal]
+func decodeValue(des *Deserializer, v reflect.Value, options fieldOptions) {
+	if des.Error() != nil {
+		return
+	}
+	if options.optional {
+		switch des.Uleb128() {
+		case 0:
+			v.SetZero()
+		case 1:
+			v.Set(reflect.New(v.Type().Elem()))
+			decodeValue(des, v.Elem(), fieldOptions{bigSize: options.bigSize})
+		default:
+			des.setError("expected 0 or 1 element as an option")
+		}
+		return
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		decodeValue(des, v.Elem(), options)
+		return
+	}
+	if reflect.PointerTo(v.Type()).Implements(unmarshalerType) {
+		v.Addr().Interface().(Unmarshaler).UnmarshalBCS(des)
+		return
+	}
+	if v.Type() == bigIntType {
+		var value big.Int
+		switch options.bigSize {
+		case 128:
+			value = des.U128()
+		case 256:
+			value = des.U256()
+		default:
+			des.setError("cannot unmarshal big.Int without a u128 or u256 option")
+			return
+		}
+		v.Set(reflect.ValueOf(value))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(des.Bool())
+	case reflect.Uint8:
+		v.SetUint(uint64(des.U8()))
+	case reflect.Uint16:
+		v.SetUint(uint64(des.U16()))
+	case reflect.Uint32:
+		v.SetUint(uint64(des.U32()))
+	case reflect.Uint64:
+		v.SetUint(des.U64())
+	case reflect.String:
+		v.SetString(des.ReadString())
+	case reflect.Slice:
+		if v.Type().Elem() == byteType {
+			v.SetBytes(des.ReadBytes())
+			return
+		}
+		length := int(des.Uleb128())
+		if des.Error() != nil {
+			return
+		}
+		// Don't trust the length to allocate, each element takes at least a byte in practice
+		slice := reflect.MakeSlice(v.Type(), 0, min(length, des.Remaining()))
+		for i := 0; i < length; i++ {
+			slice = reflect.Append(slice, reflect.Zero(v.Type().Elem()))
+			decodeValue(des, slice.Index(i), options)
+			if des.Error() != nil {
+				des.err = fmt.Errorf("could not deserialize sequence[%d] member of %s %w", i, v.Type(), des.Error())
+				return
+			}
+		}
+		v.Set(slice)
+	case reflect.Array:
+		if v.Type().Elem() == byteType {
+			des.ReadFixedBytesInto(v.Slice(0, v.Len()).Bytes())
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			decodeValue(des, v.Index(i), options)
+			if des.Error() != nil {
+				des.err = fmt.Errorf("could not deserialize sequence[%d] member of %s %w", i, v.Type(), des.Error())
+				return
+			}
+		}
+	case reflect.Map:
+		decodeMap(des, v, options)
+	case reflect.Struct:
+		fields, err := structFields(v.Type())
+		if err != nil {
+			des.SetError(err)
+			return
+		}
+		for _, field := range fields {
+			decodeValue(des, v.Field(field.index), field.options)
+			if des.Error() != nil {
+				des.err = fmt.Errorf("could not deserialize field %s.%s: %w", v.Type().Name(), field.name, des.Error())
+				return
+			}
+		}
+	default:
+		des.setError("cannot unmarshal %s, it has no BCS representation", v.Type())
+	}
+}
+
+// decodeMap deserializes a sequence of key value pairs, the keys must be sorted as [Marshal] sorts them
+func decodeMap(des *Deserializer, v reflect.Value, options fieldOptions) {
+	length := int(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	out := reflect.MakeMapWithSize(v.Type(), min(length, des.Remaining()))
+	var previousKey []byte
+	for i := 0; i < length; i++ {
+		start := des.pos
+		key := reflect.New(v.Type().Key()).Elem()
+		decodeValue(des, key, fieldOptions{})
+		if des.Error() != nil {
+			des.err = fmt.Errorf("could not deserialize map key of %s %w", v.Type(), des.Error())
+			return
+		}
+		keyBytes := des.source[start:des.pos]
+		if i > 0 && bytes.Compare(previousKey, keyBytes) >= 0 {
+			des.setError("map keys of %s are not in canonical order", v.Type())
+			return
+		}
+		previousKey = keyBytes
+
+		value := reflect.New(v.Type().Elem()).Elem()
+		decodeValue(des, value, options)
+		if des.Error() != nil {
+			des.err = fmt.Errorf("could not deserialize map value of %s %w", v.Type(), des.Error())
+			return
+		}
+		out.SetMapIndex(key, value)
+	}
+	v.Set(out)
+}
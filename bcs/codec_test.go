@@ -0,0 +1,145 @@
+package bcs
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type codecVariant uint8
+
+type codecInner struct {
+	Flag bool
+	Name string
+}
+
+type codecStruct struct {
+	Num      uint64
+	Variant  codecVariant
+	Address  [4]byte
+	Data     []byte
+	Inners   []codecInner
+	Amount   big.Int  `bcs:",u128"`
+	Big      *big.Int `bcs:",u256"`
+	Memo     *string  `bcs:",optional"`
+	Missing  *uint16  `bcs:",optional"`
+	Custom   TestStruct
+	Balances map[string]uint32
+	Cached   string `bcs:"-"`
+	private  uint8
+}
+
+func TestMarshal(t *testing.T) {
+	memo := "hi"
+	input := codecStruct{
+		Num:      1,
+		Variant:  2,
+		Address:  [4]byte{1, 2, 3, 4},
+		Data:     []byte{5},
+		Inners:   []codecInner{{Flag: true, Name: "a"}},
+		Amount:   *big.NewInt(3),
+		Big:      big.NewInt(4),
+		Memo:     &memo,
+		Custom:   TestStruct{num: 6, b: true},
+		Balances: map[string]uint32{"b": 2, "a": 1},
+		Cached:   "skipped",
+		private:  7,
+	}
+
+	// Matches the hand-written serialization
+	expected, err := SerializeSingle(func(ser *Serializer) {
+		ser.U64(1)
+		ser.U8(2)
+		ser.FixedBytes([]byte{1, 2, 3, 4})
+		ser.WriteBytes([]byte{5})
+		ser.Uleb128(1)
+		ser.Bool(true)
+		ser.WriteString("a")
+		ser.U128(*big.NewInt(3))
+		ser.U256(*big.NewInt(4))
+		SerializeOption(ser, &memo, func(ser *Serializer, item string) { ser.WriteString(item) })
+		SerializeOption(ser, nil, func(ser *Serializer, item uint16) { ser.U16(item) })
+		ser.Struct(&TestStruct{num: 6, b: true})
+		ser.Uleb128(2)
+		ser.WriteString("a")
+		ser.U32(1)
+		ser.WriteString("b")
+		ser.U32(2)
+	})
+	assert.NoError(t, err)
+
+	bytes, err := Marshal(input)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, bytes)
+
+	// By reference is the same
+	bytes, err = Marshal(&input)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, bytes)
+
+	output := codecStruct{}
+	assert.NoError(t, Unmarshal(bytes, &output))
+	input.Cached = ""
+	input.private = 0
+	assert.Equal(t, input, output)
+
+	// Trailing bytes are an error
+	assert.Error(t, Unmarshal(append(bytes, 0), &output))
+	assert.Error(t, Unmarshal(bytes, output))
+}
+
+type codecOrdered struct {
+	Second uint8 `bcs:"2"`
+	First  uint8 `bcs:"1"`
+}
+
+func TestMarshal_Ordered(t *testing.T) {
+	bytes, err := Marshal(codecOrdered{Second: 2, First: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, bytes)
+
+	output := codecOrdered{}
+	assert.NoError(t, Unmarshal([]byte{3, 4}, &output))
+	assert.Equal(t, codecOrdered{First: 3, Second: 4}, output)
+}
+
+func TestMarshal_Errors(t *testing.T) {
+	_, err := Marshal(nil)
+	assert.Error(t, err)
+
+	_, err = Marshal(struct{ Num int }{Num: 1})
+	assert.ErrorContains(t, err, "no BCS representation")
+
+	_, err = Marshal(struct{ Ptr *uint8 }{})
+	assert.ErrorContains(t, err, "optional")
+
+	_, err = Marshal(struct{ Amount big.Int }{})
+	assert.ErrorContains(t, err, "u128 or u256")
+
+	_, err = Marshal(struct {
+		Amount uint64 `bcs:",u128"`
+	}{})
+	assert.ErrorContains(t, err, "big.Int")
+
+	_, err = Marshal(struct {
+		Value uint8 `bcs:",optional"`
+	}{})
+	assert.ErrorContains(t, err, "pointer")
+
+	_, err = Marshal(struct {
+		A uint8 `bcs:"1"`
+		B uint8
+	}{})
+	assert.ErrorContains(t, err, "order")
+
+	// Map keys must be canonical
+	output := map[uint8]uint8{}
+	assert.NoError(t, Unmarshal([]byte{2, 1, 10, 2, 20}, &output))
+	assert.Equal(t, map[uint8]uint8{1: 10, 2: 20}, output)
+	assert.ErrorContains(t, Unmarshal([]byte{2, 2, 20, 1, 10}, &output), "canonical")
+
+	// Lengths aren't trusted
+	var slice []uint64
+	assert.Error(t, Unmarshal([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F}, &slice))
+}
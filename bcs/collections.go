@@ -0,0 +1,115 @@
+package bcs
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+)
+
+// MapEntry is one key value pair of a map, in the order it is serialized.  A Move 0x1::simple_map::SimpleMap is a
+// sequence of entries in insertion order.
+type MapEntry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// SerializeMap serializes a map as a sequence of key value pairs, prefixed with the length.  Entries are sorted by their
+// serialized keys, so the output is canonical even though Go maps are unordered.
+//
+//	SerializeMap(ser, balances, func(ser *Serializer, key string) {
+//		ser.WriteString(key)
+//	}, func(ser *Serializer, value uint64) {
+//		ser.U64(value)
+//	})
+func SerializeMap[K comparable, V any](ser *Serializer, input map[K]V, serializeKey func(ser *Serializer, key K), serializeValue func(ser *Serializer, value V)) {
+	type keyed struct {
+		keyBytes []byte
+		key      K
+	}
+	keys := make([]keyed, 0, len(input))
+	for key := range input {
+		keyBytes, err := SerializeSingle(func(ser *Serializer) {
+			serializeKey(ser, key)
+		})
+		if err != nil {
+			ser.SetError(fmt.Errorf("could not serialize map key %v %w", key, err))
+			return
+		}
+		keys = append(keys, keyed{keyBytes: keyBytes, key: key})
+	}
+	slices.SortFunc(keys, func(a, b keyed) int {
+		return bytes.Compare(a.keyBytes, b.keyBytes)
+	})
+
+	ser.Uleb128(uint32(len(keys)))
+	for _, k := range keys {
+		ser.FixedBytes(k.keyBytes)
+		serializeValue(ser, input[k.key])
+		if ser.Error() != nil {
+			ser.SetError(fmt.Errorf("could not serialize map value for key %v %w", k.key, ser.Error()))
+			return
+		}
+	}
+}
+
+// DeserializeMap deserializes a sequence of key value pairs into a map.  Duplicate keys are an error, but the order of
+// keys isn't checked, so maps serialized in insertion order like a SimpleMap are accepted.
+func DeserializeMap[K comparable, V any](des *Deserializer, deserializeKey func(des *Deserializer, out *K), deserializeValue func(des *Deserializer, out *V)) map[K]V {
+	entries := DeserializeMapEntries(des, deserializeKey, deserializeValue)
+	if des.Error() != nil {
+		return nil
+	}
+	out := make(map[K]V, len(entries))
+	for _, entry := range entries {
+		if _, ok := out[entry.Key]; ok {
+			des.setError("duplicate map key %v", entry.Key)
+			return nil
+		}
+		out[entry.Key] = entry.Value
+	}
+	return out
+}
+
+// SerializeMapEntries serializes key value pairs in the given order, prefixed with the length, e.g. for a SimpleMap
+func SerializeMapEntries[K any, V any](ser *Serializer, entries []MapEntry[K, V], serializeKey func(ser *Serializer, key K), serializeValue func(ser *Serializer, value V)) {
+	SerializeSequenceWithFunction(entries, ser, func(ser *Serializer, entry MapEntry[K, V]) {
+		serializeKey(ser, entry.Key)
+		serializeValue(ser, entry.Value)
+	})
+}
+
+// DeserializeMapEntries deserializes key value pairs in order, e.g. for a SimpleMap
+func DeserializeMapEntries[K any, V any](des *Deserializer, deserializeKey func(des *Deserializer, out *K), deserializeValue func(des *Deserializer, out *V)) []MapEntry[K, V] {
+	return DeserializeSequenceWithFunction(des, func(des *Deserializer, out *MapEntry[K, V]) {
+		deserializeKey(des, &out.Key)
+		deserializeValue(des, &out.Value)
+	})
+}
+
+// SerializeStructOption serializes an optional [Marshaler], by value or by reference, like [SerializeSequence]
+//
+//	SerializeStructOption(ser, &address) // Some(address)
+//	SerializeStructOption[AccountAddress](ser, nil) // None
+func SerializeStructOption[T any](ser *Serializer, input *T) {
+	if input == nil {
+		SerializeSequence([]T{}, ser)
+	} else {
+		SerializeSequence([]T{*input}, ser)
+	}
+}
+
+// DeserializeStructOption deserializes an optional [Unmarshaler], like [DeserializeSequence]
+func DeserializeStructOption[T any](des *Deserializer) *T {
+	array := DeserializeSequence[T](des)
+	switch len(array) {
+	case 0:
+		// None
+		return nil
+	case 1:
+		// Some
+		return &array[0]
+	default:
+		des.setError("expected 0 or 1 element as an option, got %d", len(array))
+	}
+	return nil
+}
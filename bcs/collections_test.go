@@ -0,0 +1,82 @@
+package bcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serializeStringKey(ser *Serializer, key string) {
+	ser.WriteString(key)
+}
+
+func deserializeStringKey(des *Deserializer, out *string) {
+	*out = des.ReadString()
+}
+
+func serializeU64Value(ser *Serializer, value uint64) {
+	ser.U64(value)
+}
+
+func deserializeU64Value(des *Deserializer, out *uint64) {
+	*out = des.U64()
+}
+
+func TestSerializeMap(t *testing.T) {
+	input := map[string]uint64{"b": 2, "a": 1, "c": 3}
+	bytes, err := SerializeSingle(func(ser *Serializer) {
+		SerializeMap(ser, input, serializeStringKey, serializeU64Value)
+	})
+	assert.NoError(t, err)
+
+	// Sorted by key, the same as the entries in order
+	expected, err := SerializeSingle(func(ser *Serializer) {
+		SerializeMapEntries(ser, []MapEntry[string, uint64]{{"a", 1}, {"b", 2}, {"c", 3}}, serializeStringKey, serializeU64Value)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, bytes)
+
+	des := NewDeserializer(bytes)
+	output := DeserializeMap(des, deserializeStringKey, deserializeU64Value)
+	assert.NoError(t, des.Error())
+	assert.Equal(t, input, output)
+
+	// SimpleMap order is kept by entries
+	bytes, err = SerializeSingle(func(ser *Serializer) {
+		SerializeMapEntries(ser, []MapEntry[string, uint64]{{"z", 1}, {"a", 2}}, serializeStringKey, serializeU64Value)
+	})
+	assert.NoError(t, err)
+	des = NewDeserializer(bytes)
+	entries := DeserializeMapEntries(des, deserializeStringKey, deserializeU64Value)
+	assert.NoError(t, des.Error())
+	assert.Equal(t, []MapEntry[string, uint64]{{"z", 1}, {"a", 2}}, entries)
+
+	// Duplicate keys are an error
+	bytes, err = SerializeSingle(func(ser *Serializer) {
+		SerializeMapEntries(ser, []MapEntry[string, uint64]{{"a", 1}, {"a", 2}}, serializeStringKey, serializeU64Value)
+	})
+	assert.NoError(t, err)
+	des = NewDeserializer(bytes)
+	DeserializeMap(des, deserializeStringKey, deserializeU64Value)
+	assert.Error(t, des.Error())
+}
+
+func TestSerializeStructOption(t *testing.T) {
+	bytes, err := SerializeSingle(func(ser *Serializer) {
+		SerializeStructOption(ser, &TestStruct{num: 5, b: true})
+		SerializeStructOption[TestStruct](ser, nil)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 5, 1, 0}, bytes)
+
+	des := NewDeserializer(bytes)
+	some := DeserializeStructOption[TestStruct](des)
+	none := DeserializeStructOption[TestStruct](des)
+	assert.NoError(t, des.Error())
+	assert.Equal(t, &TestStruct{num: 5, b: true}, some)
+	assert.Nil(t, none)
+
+	des = NewDeserializer([]byte{2, 5, 1, 6, 0})
+	DeserializeStructOption[TestStruct](des)
+	assert.Error(t, des.Error())
+}
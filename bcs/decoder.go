@@ -0,0 +1,103 @@
+package bcs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+const (
+	decoderMinRead = 4096    // decoderMinRead is the smallest read from the stream of a [Decoder]
+	decoderMaxRead = 1 << 20 // decoderMaxRead is the largest read from the stream of a [Decoder]
+)
+
+// Decoder deserializes a stream of BCS values from an [io.Reader] incrementally, without the whole stream in memory.
+// Only the value being decoded is buffered, so memory is bounded by the largest value rather than the stream.
+//
+// Values are concatenated with no framing, as BCS is not self-describing.  A large sequence can be decoded one element
+// at a time, by reading its length with [Decoder.DecodeSequenceLength] first.
+//
+//	decoder := NewDecoder(reader)
+//	length, err := decoder.DecodeSequenceLength()
+//	for i := uint32(0); i < length; i++ {
+//		txn := &SignedTransaction{}
+//		err = decoder.Decode(txn)
+//	}
+//
+// Once decoding a value fails, the position in the stream is unknown, so every later call returns the same error.
+type Decoder struct {
+	des Deserializer
+}
+
+// NewDecoder creates a [Decoder] reading from r.  The reader is read in chunks, so it doesn't need to be buffered.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{des: Deserializer{reader: r}}
+}
+
+// Decode deserializes the next value from the stream with its [Unmarshaler].  It returns [io.EOF] if the stream ended
+// cleanly before the value, and wraps [io.ErrUnexpectedEOF] if it ended partway through.
+func (dec *Decoder) Decode(v Unmarshaler) error {
+	return dec.decode(func(des *Deserializer) {
+		des.Struct(v)
+	})
+}
+
+// DecodeValue deserializes the next value from the stream into the value v points to with reflection, like
+// [Unmarshal].  It returns [io.EOF] if the stream ended cleanly before the value.
+func (dec *Decoder) DecodeValue(v any) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("cannot unmarshal into non-pointer or nil %T", v)
+	}
+	return dec.decode(func(des *Deserializer) {
+		decodeValue(des, value.Elem(), fieldOptions{})
+	})
+}
+
+// DecodeSequenceLength reads the Uleb128 length of a sequence, so its elements can be decoded one at a time
+func (dec *Decoder) DecodeSequenceLength() (length uint32, err error) {
+	err = dec.decode(func(des *Deserializer) {
+		length = des.Uleb128()
+	})
+	return length, err
+}
+
+// More tells if there is anything left in the stream to decode, reading from it if needed
+func (dec *Decoder) More() bool {
+	return dec.des.err == nil && dec.des.ensure(1)
+}
+
+// decode runs one top level deserialization on the stream
+func (dec *Decoder) decode(deserialize func(des *Deserializer)) error {
+	des := &dec.des
+	if des.err != nil {
+		return des.err
+	}
+
+	// Drop the bytes of earlier values, nothing can refer to them anymore
+	remaining := copy(des.source, des.source[des.pos:])
+	des.source = des.source[:remaining]
+	des.pos = 0
+
+	if !des.ensure(1) {
+		if des.readErr == nil || errors.Is(des.readErr, io.EOF) {
+			return io.EOF
+		}
+		des.err = des.readErr
+		return des.err
+	}
+
+	deserialize(des)
+	if des.err == nil {
+		return nil
+	}
+	switch {
+	case des.readErr == nil:
+	case errors.Is(des.readErr, io.EOF):
+		des.err = fmt.Errorf("%w: %w", io.ErrUnexpectedEOF, des.err)
+	default:
+		des.err = fmt.Errorf("%w: %w", des.err, des.readErr)
+	}
+	return des.err
+}
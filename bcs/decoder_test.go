@@ -0,0 +1,66 @@
+package bcs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoder(t *testing.T) {
+	// A sequence of structs followed by a string
+	ser := &Serializer{}
+	SerializeSequence([]TestStruct{{num: 1, b: true}, {num: 2, b: false}, {num: 3, b: true}}, ser)
+	ser.WriteString("end")
+	data := ser.ToBytes()
+
+	// Reading a byte at a time checks values can span reads
+	decoder := NewDecoder(iotest.OneByteReader(bytes.NewReader(data)))
+	length, err := decoder.DecodeSequenceLength()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), length)
+	for i := uint32(0); i < length; i++ {
+		item := &TestStruct{}
+		assert.NoError(t, decoder.Decode(item))
+		assert.Equal(t, uint8(i+1), item.num)
+	}
+	assert.True(t, decoder.More())
+	str := ""
+	assert.NoError(t, decoder.DecodeValue(&str))
+	assert.Equal(t, "end", str)
+
+	// The stream ended cleanly
+	assert.False(t, decoder.More())
+	assert.ErrorIs(t, decoder.Decode(&TestStruct{}), io.EOF)
+}
+
+func TestDecoder_Truncated(t *testing.T) {
+	decoder := NewDecoder(bytes.NewReader([]byte{0x01}))
+	err := decoder.Decode(&TestStruct{})
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.NotErrorIs(t, err, io.EOF)
+
+	// Errors are sticky, the stream position is unknown
+	assert.Equal(t, err, decoder.Decode(&TestStruct{}))
+
+	// A length longer than the stream doesn't allocate it
+	decoder = NewDecoder(bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F, 0x01}))
+	_, err = decoder.DecodeSequenceLength()
+	assert.NoError(t, err)
+	decoder = NewDecoder(bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F, 0x01}))
+	str := ""
+	assert.ErrorIs(t, decoder.DecodeValue(&str), io.ErrUnexpectedEOF)
+}
+
+func TestDecoder_ReadError(t *testing.T) {
+	readErr := errors.New("connection reset")
+	decoder := NewDecoder(io.MultiReader(bytes.NewReader([]byte{0x01}), iotest.ErrReader(readErr)))
+	err := decoder.Decode(&TestStruct{})
+	assert.ErrorIs(t, err, readErr)
+
+	decoder = NewDecoder(iotest.ErrReader(readErr))
+	assert.ErrorIs(t, decoder.Decode(&TestStruct{}), readErr)
+}
@@ -3,6 +3,7 @@ package bcs
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"math/big"
 	"slices"
 )
@@ -19,9 +20,11 @@ import (
 //		return deserializer.Error()
 //	}
 type Deserializer struct {
-	source []byte // Underlying data to parse
-	pos    int    // Current position in the buffer
-	err    error  // Any error that has happened so far
+	source  []byte    // Underlying data to parse
+	pos     int       // Current position in the buffer
+	err     error     // Any error that has happened so far
+	reader  io.Reader // reader to fill source from as it's needed, only set by a [Decoder]
+	readErr error     // readErr is the error that stopped reading from reader, e.g. io.EOF
 }
 
 // NewDeserializer creates a new Deserializer from a byte array.
@@ -52,6 +55,27 @@ func Deserialize(dest Unmarshaler, bytes []byte) error {
 	return nil
 }
 
+// ensure checks that n more bytes are available, reading them from the reader of a [Decoder] if needed
+func (des *Deserializer) ensure(n int) bool {
+	if n < 0 {
+		return false
+	}
+	for len(des.source)-des.pos < n {
+		if des.reader == nil || des.readErr != nil {
+			return false
+		}
+		// Read in bounded chunks, so a bad length can't allocate more than the stream actually has
+		size := min(max(n-(len(des.source)-des.pos), decoderMinRead), decoderMaxRead)
+		des.source = slices.Grow(des.source, size)
+		read, err := des.reader.Read(des.source[len(des.source) : len(des.source)+size])
+		des.source = des.source[:len(des.source)+read]
+		if err != nil {
+			des.readErr = err
+		}
+	}
+	return true
+}
+
 // Error If there has been any error, return it
 func (des *Deserializer) Error() error {
 	return des.err
@@ -68,13 +92,15 @@ func (des *Deserializer) SetError(err error) {
 //	deserializer := NewDeserializer(bytes)
 //	num := deserializer.U8()
 //	deserializer.Remaining == 1
+//
+// For a [Decoder], only the bytes already read from the stream are counted.
 func (des *Deserializer) Remaining() int {
 	return len(des.source) - des.pos
 }
 
 // Bool deserializes a single byte as a bool
 func (des *Deserializer) Bool() bool {
-	if des.pos >= len(des.source) {
+	if !des.ensure(1) {
 		des.setError("not enough bytes remaining to deserialize bool")
 		return false
 	}
@@ -92,22 +118,22 @@ func (des *Deserializer) Bool() bool {
 }
 
 func deserializeUint[T uint8 | uint16 | uint32 | uint64](des *Deserializer, typeName string, size int, decode func(slice []byte) T) T {
-	end := des.pos + size
-	if end > len(des.source) {
+	if !des.ensure(size) {
 		des.setError("not enough bytes remaining to deserialize %s", typeName)
 		return T(0)
 	}
+	end := des.pos + size
 	out := decode(des.source[des.pos:end])
 	des.pos = end
 	return out
 }
 
 func (des *Deserializer) deserializeUBigint(typeName string, size int) big.Int {
-	end := des.pos + size
-	if end > len(des.source) {
+	if !des.ensure(size) {
 		des.setError("not enough bytes remaining to deserialize %s", typeName)
 		return *big.NewInt(-1)
 	}
+	end := des.pos + size
 	bytesBigEndian := make([]byte, size)
 	copy(bytesBigEndian[:], des.source[des.pos:end])
 	des.pos = end
@@ -160,7 +186,7 @@ func (des *Deserializer) Uleb128() uint32 {
 
 	for out < maxU32 {
 		// Ensure we still have bytes to process
-		if des.pos >= len(des.source) {
+		if !des.ensure(1) {
 			des.setError("not enough bytes remaining to deserialize uleb128")
 			return 0
 		}
@@ -193,6 +219,11 @@ func (des *Deserializer) ReadBytes() []byte {
 	if des.err != nil {
 		return nil
 	}
+	// Check the bytes are there before trusting the length to allocate
+	if !des.ensure(int(length)) {
+		des.setError("not enough bytes remaining to deserialize bytes")
+		return nil
+	}
 
 	dest := make([]byte, length)
 	des.readBytes("bytes", int(length), dest)
@@ -218,11 +249,11 @@ func (des *Deserializer) ReadFixedBytesInto(dest []byte) {
 }
 
 func (des *Deserializer) readBytes(typeName string, length int, dest []byte) {
-	end := des.pos + length
-	if end > len(des.source) {
+	if !des.ensure(length) {
 		des.setError("not enough bytes remaining to deserialize %s", typeName)
 		return
 	}
+	end := des.pos + length
 	copy(dest, des.source[des.pos:end])
 	des.pos = end
 }
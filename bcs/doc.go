@@ -2,5 +2,10 @@
 //
 // The bcs package can be used to serialize and deserialize complex types into a binary canonical format that is non-self describing.  Meaning that you will need to know the format ahead of time in order to serialize and deserialize. Check out [Serializer] for serialization and [Deserializer] for deserialization.
 //
+// For structs, [Marshal] and [Unmarshal] serialize with reflection and `bcs` struct tags, like encoding/json, instead of
+// hand-writing [Marshaler] and [Unmarshaler].
+//
+// For hot paths, [AcquireSerializer] and [SerializeInto] reuse buffers, so serialization doesn't allocate.
+//
 // [BCS]: https://github.com/diem/bcs
 package bcs
@@ -0,0 +1,78 @@
+package bcs
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledSerializerSize is the largest buffer kept in the pool, so that one huge payload e.g. a module publish,
+// doesn't pin its memory for the life of the process
+const maxPooledSerializerSize = 64 * 1024
+
+var serializerPool = sync.Pool{
+	New: func() any {
+		return &Serializer{}
+	},
+}
+
+// NewSerializer creates a [Serializer] that writes into the given buffer, reusing its capacity.  Any bytes already
+// in buf are kept, and serialized bytes are appended after them.
+//
+//	buf := make([]byte, 0, 1024)
+//	ser := NewSerializer(buf)
+//	ser.U64(10)
+//	bytes := ser.ToBytes()
+func NewSerializer(buf []byte) *Serializer {
+	return &Serializer{out: *bytes.NewBuffer(buf)}
+}
+
+// AcquireSerializer gets an empty [Serializer] from a shared pool.  Call [ReleaseSerializer] when done with it, and
+// don't use the output of [Serializer.ToBytes] after releasing, as the buffer will be reused.
+//
+//	ser := AcquireSerializer()
+//	defer ReleaseSerializer(ser)
+//	txn.MarshalBCS(ser)
+//	if ser.Error() != nil {
+//		return ser.Error()
+//	}
+//	_, err = writer.Write(ser.ToBytes())
+func AcquireSerializer() *Serializer {
+	return serializerPool.Get().(*Serializer)
+}
+
+// ReleaseSerializer resets the [Serializer] and returns it to the pool.  Serializers with very large buffers are
+// dropped instead.
+func ReleaseSerializer(ser *Serializer) {
+	if ser == nil || ser.out.Cap() > maxPooledSerializerSize {
+		return
+	}
+	ser.Reset()
+	serializerPool.Put(ser)
+}
+
+// SerializeInto appends the serialized value to buf, and returns the extended buffer.  If buf has enough capacity no
+// allocation is made, so a buffer can be reused across many values:
+//
+//	buf := make([]byte, 0, 1024)
+//	for _, txn := range txns {
+//		buf, err = SerializeInto(buf[:0], txn)
+//		if err != nil {
+//			return err
+//		}
+//		send(buf)
+//	}
+func SerializeInto(buf []byte, value Marshaler) ([]byte, error) {
+	// A pooled serializer is borrowed, as a fresh one would escape to the heap through the interface call.  Its own
+	// buffer is swapped back before it's released.
+	ser := AcquireSerializer()
+	pooled := ser.out
+	ser.out = *bytes.NewBuffer(buf)
+	value.MarshalBCS(ser)
+	out, err := ser.out.Bytes(), ser.err
+	ser.out = pooled
+	ReleaseSerializer(ser)
+	if err != nil {
+		return buf, err
+	}
+	return out, nil
+}
@@ -0,0 +1,127 @@
+package bcs
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type poolTestTxn struct {
+	sender   [32]byte
+	sequence uint64
+	payload  []byte
+	function string
+	gas      uint64
+}
+
+func (txn *poolTestTxn) MarshalBCS(ser *Serializer) {
+	ser.FixedBytes(txn.sender[:])
+	ser.U64(txn.sequence)
+	ser.WriteString(txn.function)
+	ser.WriteBytes(txn.payload)
+	ser.U64(txn.gas)
+	ser.U32(uint32(txn.gas))
+	ser.U16(uint16(txn.gas))
+}
+
+var poolTestValue = &poolTestTxn{
+	sender:   [32]byte{1, 2, 3},
+	sequence: 42,
+	payload:  bytes.Repeat([]byte{0xAB}, 200),
+	function: "0x1::aptos_account::transfer",
+	gas:      1000,
+}
+
+func TestSerializeInto(t *testing.T) {
+	expected, err := Serialize(poolTestValue)
+	assert.NoError(t, err)
+
+	// Appends after the existing bytes, in place when there's capacity
+	buf := make([]byte, 1, 1024)
+	buf[0] = 0xFF
+	out, err := SerializeInto(buf, poolTestValue)
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte{0xFF}, expected...), out)
+	assert.Equal(t, &buf[:1][0], &out[0])
+
+	// Grows when there isn't
+	out, err = SerializeInto(nil, poolTestValue)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	// The buffer is returned unchanged on error
+	out, err = SerializeInto(buf, &TestStruct3{num: 256})
+	assert.Error(t, err)
+	assert.Equal(t, buf, out)
+
+	ser := NewSerializer(make([]byte, 0, 8))
+	ser.U8(1)
+	assert.Equal(t, []byte{1}, ser.ToBytes())
+}
+
+func TestAcquireSerializer(t *testing.T) {
+	ser := AcquireSerializer()
+	ser.U8(1)
+	ser.SetError(errors.New("failed"))
+	ReleaseSerializer(ser)
+
+	// Released serializers are always reset
+	ser = AcquireSerializer()
+	assert.NoError(t, ser.Error())
+	assert.Empty(t, ser.ToBytes())
+	ReleaseSerializer(ser)
+	ReleaseSerializer(nil)
+
+	// Serialize copies out of the pooled buffer
+	first, err := SerializeU64(1)
+	assert.NoError(t, err)
+	second, err := SerializeU64(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0}, first)
+	assert.Equal(t, []byte{2, 0, 0, 0, 0, 0, 0, 0}, second)
+}
+
+func TestSerializeInto_NoAllocations(t *testing.T) {
+	buf := make([]byte, 0, 1024)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = SerializeInto(buf[:0], poolTestValue)
+	})
+	assert.Zero(t, allocs)
+}
+
+// BenchmarkSerializer_New is how serialization was done before pooling, a new serializer per value
+func BenchmarkSerializer_New(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ser := &Serializer{}
+		poolTestValue.MarshalBCS(ser)
+		_ = ser.ToBytes()
+	}
+}
+
+func BenchmarkSerializer_Pooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ser := AcquireSerializer()
+		poolTestValue.MarshalBCS(ser)
+		_ = ser.ToBytes()
+		ReleaseSerializer(ser)
+	}
+}
+
+func BenchmarkSerialize(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = Serialize(poolTestValue)
+	}
+}
+
+func BenchmarkSerializeInto(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 1024)
+	for i := 0; i < b.N; i++ {
+		buf, _ = SerializeInto(buf[:0], poolTestValue)
+	}
+}
@@ -55,18 +55,22 @@ func (ser *Serializer) Bool(v bool) {
 	}
 }
 
-func serializeUInt[T uint16 | uint32 | uint64](ser *Serializer, size uint, v T, serialize func(slice []byte, num T)) {
-	ub := make([]byte, size)
-	serialize(ub[:], v)
-	ser.out.Write(ub[:])
+func serializeUInt[T uint16 | uint32 | uint64](ser *Serializer, v T, serialize func(slice []byte, num T) []byte) {
+	// Appending to the buffer's spare capacity means integers don't allocate
+	ser.out.Write(serialize(ser.out.AvailableBuffer(), v))
 }
 
 func (ser *Serializer) serializeUBigInt(size uint, v *big.Int) {
-	ub := make([]byte, size)
-	v.FillBytes(ub[:])
+	// FillBytes would panic on overflow, and drop the sign of negative numbers
+	if v.Sign() < 0 || uint(v.BitLen()) > size*8 {
+		ser.SetError(fmt.Errorf("cannot serialize %s as u%d", v.String(), size*8))
+		return
+	}
+	var ub [32]byte
+	v.FillBytes(ub[:size])
 	// Reverse, since big.Int outputs bytes in BigEndian
-	slices.Reverse(ub[:])
-	ser.out.Write(ub[:])
+	slices.Reverse(ub[:size])
+	ser.out.Write(ub[:size])
 }
 
 // U8 serialize a byte
@@ -76,17 +80,17 @@ func (ser *Serializer) U8(v uint8) {
 
 // U16 serialize an unsigned 16-bit integer in little-endian format
 func (ser *Serializer) U16(v uint16) {
-	serializeUInt(ser, 2, v, binary.LittleEndian.PutUint16)
+	serializeUInt(ser, v, binary.LittleEndian.AppendUint16)
 }
 
 // U32 serialize an unsigned 32-bit integer in little-endian format
 func (ser *Serializer) U32(v uint32) {
-	serializeUInt(ser, 4, v, binary.LittleEndian.PutUint32)
+	serializeUInt(ser, v, binary.LittleEndian.AppendUint32)
 }
 
 // U64 serialize an unsigned 64-bit integer in little-endian format
 func (ser *Serializer) U64(v uint64) {
-	serializeUInt(ser, 8, v, binary.LittleEndian.PutUint64)
+	serializeUInt(ser, v, binary.LittleEndian.AppendUint64)
 }
 
 // U128 serialize an unsigned 128-bit integer in little-endian format
@@ -116,7 +120,8 @@ func (ser *Serializer) WriteBytes(v []byte) {
 
 // WriteString similar to [Serializer.WriteBytes] using the UTF-8 byte representation of the string
 func (ser *Serializer) WriteString(v string) {
-	ser.WriteBytes([]byte(v))
+	ser.Uleb128(uint32(len(v)))
+	ser.out.WriteString(v)
 }
 
 // FixedBytes similar to [Serializer.WriteBytes], but it forgoes the length header.
@@ -322,13 +327,15 @@ func SerializeBytes(input []byte) ([]byte, error) {
 //		}
 //	})
 func SerializeSingle(marshal func(ser *Serializer)) (bytes []byte, err error) {
-	ser := &Serializer{}
+	ser := AcquireSerializer()
+	defer ReleaseSerializer(ser)
 	marshal(ser)
 	err = ser.Error()
 	if err != nil {
 		return nil, err
 	}
-	bytes = ser.ToBytes()
+	// The buffer goes back to the pool, so the output is copied out
+	bytes = slices.Clone(ser.ToBytes())
 	return bytes, nil
 }
 
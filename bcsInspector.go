@@ -0,0 +1,331 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+)
+
+// ModuleAbiResolver looks up the ABI of a module, [Client.ModuleAbi] fetches and caches them from the node
+type ModuleAbiResolver interface {
+	ModuleAbi(module ModuleId) (abi *api.MoveModule, err error)
+}
+
+// BcsNode is one value in a BCS blob decoded by [BcsInspector]
+type BcsNode struct {
+	Name     string     // Name is the field name, [i] for vector elements, or empty for the root
+	Type     string     // Type is the Move type of the value e.g. 0x1::string::String
+	Offset   int        // Offset is the byte offset of the value in the blob
+	Length   int        // Length is the number of bytes of the value, including any length prefix
+	Value    string     // Value is the formatted value, empty for structs and vectors
+	Children []*BcsNode // Children are the fields of a struct, or the elements of a vector
+	Err      error      // Err is set on the node that failed to decode
+}
+
+// String formats the node as an annotated tree, one value per line with its byte range
+func (node *BcsNode) String() string {
+	out := strings.Builder{}
+	node.write(&out, 0)
+	return out.String()
+}
+
+func (node *BcsNode) write(out *strings.Builder, depth int) {
+	out.WriteString(fmt.Sprintf("[%4d..%4d) %s", node.Offset, node.Offset+node.Length, strings.Repeat("  ", depth)))
+	if node.Name != "" {
+		out.WriteString(node.Name)
+		out.WriteString(": ")
+	}
+	out.WriteString(node.Type)
+	if node.Value != "" {
+		out.WriteString(" = ")
+		out.WriteString(node.Value)
+	}
+	if node.Err != nil {
+		out.WriteString(" !! ")
+		out.WriteString(node.Err.Error())
+	}
+	out.WriteByte('\n')
+	for _, child := range node.Children {
+		child.write(out, depth+1)
+	}
+}
+
+// BcsInspector decodes BCS blobs of any Move type into an annotated tree of [BcsNode]s, using struct layouts from
+// module ABIs.  It's meant for debugging e.g. "deserialization error at byte N" failures, as the tree shows how far
+// decoding got, and what each byte was decoded as.
+//
+//	inspector := NewBcsInspector(client)
+//	inspector.Debug("0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", blob)
+//
+// 0x1::string::String, 0x1::option::Option and 0x1::object::Object are decoded without needing their ABIs.
+type BcsInspector struct {
+	resolver ModuleAbiResolver
+	modules  map[ModuleId]*api.MoveModule
+}
+
+// NewBcsInspector creates a [BcsInspector] with the given modules.  Modules not given are looked up with the resolver,
+// which may be nil.
+func NewBcsInspector(resolver ModuleAbiResolver, modules ...*api.MoveModule) *BcsInspector {
+	inspector := &BcsInspector{
+		resolver: resolver,
+		modules:  make(map[ModuleId]*api.MoveModule),
+	}
+	for _, module := range modules {
+		if module != nil && module.Address != nil {
+			inspector.modules[ModuleId{Address: *module.Address, Name: module.Name}] = module
+		}
+	}
+	return inspector
+}
+
+// Inspect decodes the blob as the Move type e.g. 0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>
+//
+// On failure, the partially decoded tree is returned along with the error, with [BcsNode.Err] set on the failing value.
+func (inspector *BcsInspector) Inspect(moveType string, blob []byte) (*BcsNode, error) {
+	typeTag, err := ParseTypeTag(moveType)
+	if err != nil {
+		return nil, err
+	}
+	return inspector.InspectTypeTag(*typeTag, blob)
+}
+
+// InspectTypeTag decodes the blob as the [TypeTag], see [BcsInspector.Inspect]
+func (inspector *BcsInspector) InspectTypeTag(typeTag TypeTag, blob []byte) (*BcsNode, error) {
+	decoder := &bcsInspectorDecoder{inspector: inspector, des: bcs.NewDeserializer(blob), size: len(blob)}
+	root := &BcsNode{}
+	decoder.decode(root, typeTag)
+	if decoder.err != nil {
+		return root, decoder.err
+	}
+	if remaining := decoder.des.Remaining(); remaining != 0 {
+		root.Err = fmt.Errorf("%d trailing bytes", remaining)
+		return root, fmt.Errorf("bcs inspect %s: %w at byte %d", typeTag.String(), root.Err, decoder.offset())
+	}
+	return root, nil
+}
+
+// Print decodes the blob and writes the tree to w, including the partial tree on failure
+func (inspector *BcsInspector) Print(w io.Writer, moveType string, blob []byte) error {
+	root, err := inspector.Inspect(moveType, blob)
+	if root != nil {
+		if _, writeErr := io.WriteString(w, root.String()); writeErr != nil {
+			return writeErr
+		}
+	}
+	return err
+}
+
+// Debug prints the tree to stderr, along with any error
+func (inspector *BcsInspector) Debug(moveType string, blob []byte) {
+	err := inspector.Print(os.Stderr, moveType, blob)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+type bcsInspectorDecoder struct {
+	inspector *BcsInspector
+	des       *bcs.Deserializer
+	size      int
+	err       error
+}
+
+func (decoder *bcsInspectorDecoder) offset() int {
+	return decoder.size - decoder.des.Remaining()
+}
+
+// fail records the first error on the node, returning false so callers can stop decoding
+func (decoder *bcsInspectorDecoder) fail(node *BcsNode, err error) bool {
+	node.Value = ""
+	node.Err = err
+	if decoder.err == nil {
+		decoder.err = fmt.Errorf("bcs inspect %s: %w at byte %d", node.Type, err, decoder.offset())
+	}
+	return false
+}
+
+// check records any deserializer error on the node
+func (decoder *bcsInspectorDecoder) check(node *BcsNode) bool {
+	if err := decoder.des.Error(); err != nil {
+		return decoder.fail(node, err)
+	}
+	return true
+}
+
+func (decoder *bcsInspectorDecoder) decode(node *BcsNode, typeTag TypeTag) bool {
+	node.Type = typeTag.String()
+	node.Offset = decoder.offset()
+	ok := decoder.decodeValue(node, typeTag)
+	node.Length = decoder.offset() - node.Offset
+	return ok
+}
+
+func (decoder *bcsInspectorDecoder) decodeValue(node *BcsNode, typeTag TypeTag) bool {
+	des := decoder.des
+	switch inner := typeTag.Value.(type) {
+	case *BoolTag:
+		node.Value = strconv.FormatBool(des.Bool())
+	case *U8Tag:
+		node.Value = strconv.FormatUint(uint64(des.U8()), 10)
+	case *U16Tag:
+		node.Value = strconv.FormatUint(uint64(des.U16()), 10)
+	case *U32Tag:
+		node.Value = strconv.FormatUint(uint64(des.U32()), 10)
+	case *U64Tag:
+		node.Value = strconv.FormatUint(des.U64(), 10)
+	case *U128Tag:
+		value := des.U128()
+		node.Value = value.String()
+	case *U256Tag:
+		value := des.U256()
+		node.Value = value.String()
+	case *AddressTag:
+		address := AccountAddress{}
+		address.UnmarshalBCS(des)
+		node.Value = address.String()
+	case *VectorTag:
+		return decoder.decodeVector(node, inner.TypeParam)
+	case *StructTag:
+		return decoder.decodeStruct(node, inner)
+	default:
+		return decoder.fail(node, fmt.Errorf("type %s can't be stored in BCS", typeTag.String()))
+	}
+	return decoder.check(node)
+}
+
+func (decoder *bcsInspectorDecoder) decodeVector(node *BcsNode, elementType TypeTag) bool {
+	length := decoder.des.Uleb128()
+	if !decoder.check(node) {
+		return false
+	}
+	// Every element takes at least a byte, so this catches garbage lengths before allocating
+	if int(length) > decoder.des.Remaining() {
+		return decoder.fail(node, fmt.Errorf("vector length %d is more than the %d remaining bytes", length, decoder.des.Remaining()))
+	}
+	if _, ok := elementType.Value.(*U8Tag); ok {
+		bytes := decoder.des.ReadFixedBytes(int(length))
+		node.Value = util.BytesToHex(bytes)
+		return decoder.check(node)
+	}
+	node.Value = fmt.Sprintf("(%d elements)", length)
+	for i := uint32(0); i < length; i++ {
+		child := &BcsNode{Name: fmt.Sprintf("[%d]", i)}
+		node.Children = append(node.Children, child)
+		if !decoder.decode(child, elementType) {
+			return false
+		}
+	}
+	return true
+}
+
+func (decoder *bcsInspectorDecoder) decodeStruct(node *BcsNode, structTag *StructTag) bool {
+	if structTag.Address == AccountOne {
+		switch structTag.Module + "::" + structTag.Name {
+		case "string::String":
+			bytes := decoder.des.ReadBytes()
+			if !decoder.check(node) {
+				return false
+			}
+			if !utf8.Valid(bytes) {
+				return decoder.fail(node, errors.New("string is not valid UTF-8"))
+			}
+			node.Value = strconv.Quote(string(bytes))
+			return true
+		case "option::Option":
+			if len(structTag.TypeParams) == 1 {
+				return decoder.decodeOption(node, structTag.TypeParams[0])
+			}
+		case "object::Object":
+			address := AccountAddress{}
+			address.UnmarshalBCS(decoder.des)
+			node.Value = address.String()
+			return decoder.check(node)
+		}
+	}
+
+	layout, err := decoder.inspector.structLayout(structTag)
+	if err != nil {
+		return decoder.fail(node, err)
+	}
+	if len(layout.GenericTypeParams) != len(structTag.TypeParams) {
+		return decoder.fail(node, fmt.Errorf("struct takes %d type arguments, got %d", len(layout.GenericTypeParams), len(structTag.TypeParams)))
+	}
+	for _, field := range layout.Fields {
+		child := &BcsNode{Name: field.Name, Type: field.Type, Offset: decoder.offset()}
+		node.Children = append(node.Children, child)
+		fieldType, err := ParseTypeTag(field.Type)
+		if err != nil {
+			return decoder.fail(child, err)
+		}
+		if !decoder.decode(child, substituteTypeParams(*fieldType, structTag.TypeParams)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (decoder *bcsInspectorDecoder) decodeOption(node *BcsNode, valueType TypeTag) bool {
+	length := decoder.des.Uleb128()
+	if !decoder.check(node) {
+		return false
+	}
+	switch length {
+	case 0:
+		node.Value = "none"
+		return true
+	case 1:
+		child := &BcsNode{Name: "some"}
+		node.Children = append(node.Children, child)
+		return decoder.decode(child, valueType)
+	default:
+		return decoder.fail(node, fmt.Errorf("option has %d values", length))
+	}
+}
+
+// structLayout finds the ABI of the struct, looking up its module with the resolver if needed
+func (inspector *BcsInspector) structLayout(structTag *StructTag) (*api.MoveStruct, error) {
+	moduleId := ModuleId{Address: structTag.Address, Name: structTag.Module}
+	module, ok := inspector.modules[moduleId]
+	if !ok {
+		if inspector.resolver == nil {
+			return nil, fmt.Errorf("no ABI for module %s::%s", structTag.Address.String(), structTag.Module)
+		}
+		var err error
+		module, err = inspector.resolver.ModuleAbi(moduleId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ABI for module %s::%s: %w", structTag.Address.String(), structTag.Module, err)
+		}
+		inspector.modules[moduleId] = module
+	}
+	if layout := module.Struct(structTag.Name); layout != nil {
+		return layout, nil
+	}
+	return nil, fmt.Errorf("struct %s not found in module ABI", structTag.Name)
+}
+
+// substituteTypeParams replaces generic type parameters e.g. T0 with the given type arguments
+func substituteTypeParams(typeTag TypeTag, typeArgs []TypeTag) TypeTag {
+	switch inner := typeTag.Value.(type) {
+	case *GenericTag:
+		if inner.Num < uint64(len(typeArgs)) {
+			return typeArgs[inner.Num]
+		}
+	case *VectorTag:
+		return NewTypeTag(&VectorTag{TypeParam: substituteTypeParams(inner.TypeParam, typeArgs)})
+	case *StructTag:
+		typeParams := make([]TypeTag, len(inner.TypeParams))
+		for i, typeParam := range inner.TypeParams {
+			typeParams[i] = substituteTypeParams(typeParam, typeArgs)
+		}
+		return NewTypeTag(&StructTag{Address: inner.Address, Module: inner.Module, Name: inner.Name, TypeParams: typeParams})
+	}
+	return typeTag
+}
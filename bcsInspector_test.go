@@ -0,0 +1,130 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+const testInspectorModule = `{
+	"address": "0xcafe",
+	"name": "game",
+	"friends": [],
+	"exposed_functions": [],
+	"structs": [
+		{"name": "Player", "is_native": false, "abilities": ["key"], "generic_type_params": [{"constraints": []}], "fields": [
+			{"name": "name", "type": "0x1::string::String"},
+			{"name": "level", "type": "u64"},
+			{"name": "items", "type": "vector<0xcafe::game::Item>"},
+			{"name": "pet", "type": "0x1::option::Option<T0>"},
+			{"name": "guild", "type": "0x1::option::Option<address>"}
+		]},
+		{"name": "Item", "is_native": false, "abilities": ["store"], "generic_type_params": [], "fields": [
+			{"name": "id", "type": "u8"},
+			{"name": "tag", "type": "vector<u8>"}
+		]}
+	]
+}`
+
+type testModuleAbiResolver struct {
+	modules map[ModuleId]*api.MoveModule
+	calls   int
+}
+
+func (r *testModuleAbiResolver) ModuleAbi(module ModuleId) (*api.MoveModule, error) {
+	r.calls++
+	abi, ok := r.modules[module]
+	if !ok {
+		return nil, errors.New("module not found")
+	}
+	return abi, nil
+}
+
+func testPlayerBytes(t *testing.T) []byte {
+	t.Helper()
+	blob, err := bcs.SerializeSingle(func(ser *bcs.Serializer) {
+		ser.WriteString("alice")
+		ser.U64(7)
+		ser.Uleb128(2)
+		ser.U8(1)
+		ser.WriteBytes([]byte{0xca, 0xfe})
+		ser.U8(2)
+		ser.WriteBytes(nil)
+		// Some(42u16)
+		ser.Uleb128(1)
+		ser.U16(42)
+		// None
+		ser.Uleb128(0)
+	})
+	assert.NoError(t, err)
+	return blob
+}
+
+func TestBcsInspector(t *testing.T) {
+	module := &api.MoveModule{}
+	assert.NoError(t, json.Unmarshal([]byte(testInspectorModule), module))
+	inspector := NewBcsInspector(nil, module)
+
+	blob := testPlayerBytes(t)
+	root, err := inspector.Inspect("0xcafe::game::Player<u16>", blob)
+	assert.NoError(t, err)
+	assert.Equal(t, len(blob), root.Length)
+	assert.Len(t, root.Children, 5)
+	assert.Equal(t, `"alice"`, root.Children[0].Value)
+	assert.Equal(t, "7", root.Children[1].Value)
+	assert.Equal(t, 6, root.Children[1].Offset)
+
+	items := root.Children[2]
+	assert.Equal(t, "vector<0x000000000000000000000000000000000000000000000000000000000000cafe::game::Item>", items.Type)
+	assert.Len(t, items.Children, 2)
+	assert.Equal(t, "[0]", items.Children[0].Name)
+	assert.Equal(t, "0xcafe", items.Children[0].Children[1].Value)
+	assert.Equal(t, "0x", items.Children[1].Children[1].Value)
+
+	pet := root.Children[3]
+	assert.Equal(t, "0x1::option::Option<u16>", pet.Type)
+	assert.Equal(t, "42", pet.Children[0].Value)
+	assert.Equal(t, "none", root.Children[4].Value)
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, inspector.Print(&buf, "0xcafe::game::Player<u16>", blob))
+	assert.Contains(t, buf.String(), "[   6..  14)   level: u64 = 7\n")
+	assert.Contains(t, buf.String(), "      id: u8 = 1\n")
+}
+
+func TestBcsInspectorErrors(t *testing.T) {
+	module := &api.MoveModule{}
+	assert.NoError(t, json.Unmarshal([]byte(testInspectorModule), module))
+	resolver := &testModuleAbiResolver{modules: map[ModuleId]*api.MoveModule{{Address: *module.Address, Name: module.Name}: module}}
+	inspector := NewBcsInspector(resolver)
+
+	// Truncated blobs return the partial tree, annotated with where decoding failed
+	blob := testPlayerBytes(t)
+	root, err := inspector.Inspect("0xcafe::game::Player<u16>", blob[:10])
+	assert.ErrorContains(t, err, "at byte 6")
+	assert.Len(t, root.Children, 2)
+	assert.Equal(t, `"alice"`, root.Children[0].Value)
+	assert.Error(t, root.Children[1].Err)
+	assert.Contains(t, root.String(), "level: u64 !! ")
+	assert.Equal(t, 1, resolver.calls)
+
+	// Trailing bytes are reported
+	_, err = inspector.Inspect("0xcafe::game::Player<u16>", append(blob, 0))
+	assert.ErrorContains(t, err, "1 trailing bytes")
+	assert.Equal(t, 1, resolver.calls)
+
+	// Missing layouts
+	_, err = inspector.Inspect("0xbeef::shop::Item", blob)
+	assert.ErrorContains(t, err, "module not found")
+	_, err = inspector.Inspect("0xcafe::game::Missing", blob)
+	assert.ErrorContains(t, err, "struct Missing not found")
+
+	// Garbage lengths
+	_, err = inspector.Inspect("vector<u64>", []byte{0xff, 0x01, 0x00})
+	assert.ErrorContains(t, err, "vector length 255 is more than the 1 remaining bytes")
+}
@@ -0,0 +1,140 @@
+package aptos
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// The BCS reads below ask the node for application/x-bcs and decode the response with the SDK's deserializer.  They
+// skip the JSON conversion on both ends, and keep the exact on-chain bytes, which matters for high-volume readers e.g.
+// indexers.
+
+// InfoBCS gets general information about the blockchain, as [NodeClient.Info] but read as BCS
+func (rc *NodeClient) InfoBCS() (info NodeInfo, err error) {
+	blob, err := rc.GetBCS(rc.baseUrl.String())
+	if err != nil {
+		return info, fmt.Errorf("get node info api err: %w", err)
+	}
+	err = bcs.Deserialize(&info, blob)
+	if err != nil {
+		return info, fmt.Errorf("failed to decode node info: %w", err)
+	}
+
+	// Cache the ChainId for later calls, because performance
+	rc.chainId = info.ChainId
+	return info, nil
+}
+
+// UnmarshalBCS deserializes the BCS node info of the index endpoint
+func (info *NodeInfo) UnmarshalBCS(des *bcs.Deserializer) {
+	info.ChainId = des.U8()
+	info.EpochStr = strconv.FormatUint(des.U64(), 10)
+	info.LedgerVersionStr = strconv.FormatUint(des.U64(), 10)
+	info.OldestLedgerVersionStr = strconv.FormatUint(des.U64(), 10)
+	info.LedgerTimestampStr = strconv.FormatUint(des.U64(), 10)
+	switch role := des.Uleb128(); role {
+	case 0:
+		info.NodeRole = "validator"
+	case 1:
+		info.NodeRole = "full_node"
+	default:
+		des.SetError(fmt.Errorf("unknown node role %d", role))
+		return
+	}
+	info.OldestBlockHeightStr = strconv.FormatUint(des.U64(), 10)
+	info.BlockHeightStr = strconv.FormatUint(des.U64(), 10)
+	info.GitHash = ""
+	if des.Bool() {
+		info.GitHash = des.ReadString()
+	}
+}
+
+// AccountBCS gets the 0x1::account::Account resource of an account as BCS
+//
+// Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+func (rc *NodeClient) AccountBCS(address AccountAddress, ledgerVersion ...uint64) (account AccountResource, err error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String())
+	rc.setLedgerVersion(au, ledgerVersion)
+	blob, err := rc.GetBCS(au.String())
+	if err != nil {
+		return account, fmt.Errorf("get account info api err: %w", err)
+	}
+	err = bcs.Deserialize(&account, blob)
+	if err != nil {
+		return account, fmt.Errorf("failed to decode account %s: %w", address.String(), err)
+	}
+	return account, nil
+}
+
+// AccountResourceBCSInto fetches a resource for an account as BCS, and decodes the Move struct into out
+//
+// Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+//
+//	account := &AccountResource{}
+//	err := client.AccountResourceBCSInto(address, AccountResourceType, account)
+func (rc *NodeClient) AccountResourceBCSInto(address AccountAddress, resourceType string, out bcs.Unmarshaler, ledgerVersion ...uint64) error {
+	blob, err := rc.AccountResourceBCS(address, resourceType, ledgerVersion...)
+	if err != nil {
+		return err
+	}
+	err = bcs.Deserialize(out, blob)
+	if err != nil {
+		return fmt.Errorf("failed to decode resource %s: %w", resourceType, err)
+	}
+	return nil
+}
+
+// TransactionByVersionBCS gets a committed transaction by version as BCS, with its events and state changes.
+// Genesis and validator transactions can't be decoded, and return [ErrUnsupportedTransactionData].
+func (rc *NodeClient) TransactionByVersionBCS(version uint64) (data *TransactionOnChainData, err error) {
+	restUrl := rc.baseUrl.JoinPath("transactions/by_version", strconv.FormatUint(version, 10))
+	blob, err := rc.GetBCS(restUrl.String())
+	if err != nil {
+		return nil, fmt.Errorf("get transaction api err: %w", err)
+	}
+	data = &TransactionOnChainData{}
+	err = bcs.Deserialize(data, blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction %d: %w", version, err)
+	}
+	return data, nil
+}
+
+// TransactionsBCS gets a page of committed transactions as BCS, with their events and state changes.  Unlike
+// [NodeClient.Transactions], it's a single request, so the limit is capped by the node's page size.
+//
+// Arguments:
+//   - start is a version number. Nil for most recent transactions.
+//   - limit is a number of transactions to return. 'about a hundred' by default.
+func (rc *NodeClient) TransactionsBCS(start *uint64, limit *uint64) (data []*TransactionOnChainData, err error) {
+	au := rc.baseUrl.JoinPath("transactions")
+	params := url.Values{}
+	if start != nil {
+		params.Set("start", strconv.FormatUint(*start, 10))
+	}
+	if limit != nil {
+		params.Set("limit", strconv.FormatUint(*limit, 10))
+	}
+	if len(params) != 0 {
+		au.RawQuery = params.Encode()
+	}
+	blob, err := rc.GetBCS(au.String())
+	if err != nil {
+		return nil, fmt.Errorf("get transactions api err: %w", err)
+	}
+	des := bcs.NewDeserializer(blob)
+	data = bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, out **TransactionOnChainData) {
+		*out = &TransactionOnChainData{}
+		(*out).UnmarshalBCS(des)
+	})
+	if des.Error() == nil && des.Remaining() > 0 {
+		des.SetError(fmt.Errorf("%d bytes left over", des.Remaining()))
+	}
+	if des.Error() != nil {
+		return nil, fmt.Errorf("failed to decode transactions: %w", des.Error())
+	}
+	return data, nil
+}
@@ -0,0 +1,252 @@
+package aptos
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+// serializeTransactionInfo writes a successful TransactionInfo::V0
+func serializeTransactionInfo(ser *bcs.Serializer, gasUsed uint64, hash []byte) {
+	ser.Uleb128(0)
+	ser.U64(gasUsed)
+	ser.Uleb128(uint32(ExecutionStatusSuccess))
+	ser.WriteBytes(hash)
+	ser.WriteBytes(bytes.Repeat([]byte{0x02}, 32))
+	ser.WriteBytes(bytes.Repeat([]byte{0x03}, 32))
+	ser.Bool(false)
+	ser.Bool(false)
+}
+
+func TestTransactionOnChainData_UnmarshalBCS(t *testing.T) {
+	account, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{Sender: account.Address, SequenceNumber: 3, Payload: TransactionPayload{Payload: payload}, MaxGasAmount: 1000, GasUnitPrice: 100, ChainId: 4}
+	signedTxn, err := rawTxn.SignedTransaction(account)
+	assert.NoError(t, err)
+	coinType := AptosCoinTypeTag
+
+	ser := &bcs.Serializer{}
+	ser.U64(42)
+	ser.Uleb128(uint32(TransactionDataUser))
+	signedTxn.MarshalBCS(ser)
+	serializeTransactionInfo(ser, 9, bytes.Repeat([]byte{0x01}, 32))
+	// A handle event and a module event
+	ser.Uleb128(2)
+	ser.Uleb128(0)
+	ser.U64(5)
+	AccountOne.MarshalBCS(ser)
+	ser.U64(11)
+	coinType.MarshalBCS(ser)
+	ser.WriteBytes(AccountTwo[:])
+	ser.Uleb128(1)
+	coinType.MarshalBCS(ser)
+	ser.WriteBytes([]byte{0x01})
+	ser.WriteBytes(bytes.Repeat([]byte{0x04}, 32))
+	// A write set modifying a resource, with metadata, and deleting a table item
+	ser.Uleb128(0)
+	ser.Uleb128(2)
+	ser.Uleb128(uint32(StateKeyAccessPath))
+	account.Address.MarshalBCS(ser)
+	ser.WriteBytes([]byte{0x01, 0x02})
+	ser.Uleb128(4)
+	ser.WriteBytes([]byte{0x05})
+	ser.Uleb128(1)
+	ser.U64(40)
+	ser.U64(50)
+	ser.U64(60)
+	ser.Uleb128(uint32(StateKeyTableItem))
+	AccountTwo.MarshalBCS(ser)
+	ser.WriteBytes([]byte{0x03})
+	ser.Uleb128(2)
+	assert.NoError(t, ser.Error())
+
+	data := &TransactionOnChainData{}
+	assert.NoError(t, bcs.Deserialize(data, ser.ToBytes()))
+	assert.Equal(t, uint64(42), data.Version)
+	assert.Equal(t, TransactionDataUser, data.Variant)
+	assert.Equal(t, uint64(3), data.UserTransaction.Transaction.SequenceNumber)
+	assert.Equal(t, BytesToHex(bytes.Repeat([]byte{0x01}, 32)), data.Hash())
+	assert.Equal(t, uint64(9), data.Info.GasUsed)
+	assert.True(t, data.Info.Status.Success())
+	assert.Nil(t, data.Info.StateCheckpointHash)
+
+	assert.Len(t, data.Events, 2)
+	assert.Equal(t, &EventHandleId{Account: AccountOne, CreationNumber: 5}, data.Events[0].Handle)
+	assert.Equal(t, uint64(11), data.Events[0].SequenceNumber)
+	assert.Equal(t, coinType.String(), data.Events[0].Type.String())
+	eventAddress := &AccountAddress{}
+	assert.NoError(t, data.Events[0].DataInto(eventAddress))
+	assert.Equal(t, AccountTwo, *eventAddress)
+	assert.Nil(t, data.Events[1].Handle)
+
+	assert.Len(t, data.Changes, 2)
+	assert.Equal(t, StateKey{Kind: StateKeyAccessPath, Address: account.Address, Path: []byte{0x01, 0x02}}, data.Changes[0].Key)
+	assert.Equal(t, WriteOpModification, data.Changes[0].Kind)
+	assert.Equal(t, []byte{0x05}, data.Changes[0].Data)
+	assert.Equal(t, uint64(40), data.Changes[0].SlotDeposit)
+	assert.Equal(t, uint64(50), data.Changes[0].BytesDeposit)
+	assert.Equal(t, uint64(60), data.Changes[0].CreationTimeUsecs)
+	assert.Equal(t, StateKeyTableItem, data.Changes[1].Key.Kind)
+	assert.Equal(t, WriteOpDeletion, data.Changes[1].Kind)
+	assert.Nil(t, data.Changes[1].Data)
+
+	// Genesis can only be read as JSON
+	ser = &bcs.Serializer{}
+	ser.U64(0)
+	ser.Uleb128(uint32(TransactionDataGenesis))
+	assert.ErrorIs(t, bcs.Deserialize(&TransactionOnChainData{}, ser.ToBytes()), ErrUnsupportedTransactionData)
+}
+
+func TestNodeClient_BCSResponses(t *testing.T) {
+	// A page of block metadata, a checkpoint with an abort status, and a block epilogue
+	page := &bcs.Serializer{}
+	page.Uleb128(3)
+	page.U64(100)
+	page.Uleb128(uint32(TransactionDataBlockMetadata))
+	page.WriteBytes(bytes.Repeat([]byte{0x09}, 32))
+	page.U64(7)
+	page.U64(8)
+	AccountTwo.MarshalBCS(page)
+	page.WriteBytes([]byte{0xff})
+	page.Uleb128(1)
+	page.U32(2)
+	page.U64(1234)
+	serializeTransactionInfo(page, 0, bytes.Repeat([]byte{0x0a}, 32))
+	page.Uleb128(0)
+	page.WriteBytes(bytes.Repeat([]byte{0x04}, 32))
+	page.Uleb128(0)
+	page.Uleb128(0)
+
+	page.U64(101)
+	page.Uleb128(uint32(TransactionDataStateCheckpoint))
+	page.WriteBytes(bytes.Repeat([]byte{0x0b}, 32))
+	page.Uleb128(0)
+	page.U64(0)
+	page.Uleb128(uint32(ExecutionStatusMoveAbort))
+	page.Uleb128(0)
+	AccountOne.MarshalBCS(page)
+	page.WriteString("coin")
+	page.U64(0x10006)
+	page.Bool(true)
+	page.WriteString("EINSUFFICIENT_BALANCE")
+	page.WriteString("Not enough coins")
+	page.WriteBytes(bytes.Repeat([]byte{0x0c}, 32))
+	page.WriteBytes(bytes.Repeat([]byte{0x02}, 32))
+	page.WriteBytes(bytes.Repeat([]byte{0x03}, 32))
+	page.Bool(true)
+	page.WriteBytes(bytes.Repeat([]byte{0x0d}, 32))
+	page.Bool(false)
+	page.Uleb128(0)
+	page.WriteBytes(bytes.Repeat([]byte{0x04}, 32))
+	page.Uleb128(0)
+	page.Uleb128(0)
+
+	page.U64(102)
+	page.Uleb128(uint32(TransactionDataBlockEpilogue))
+	page.Uleb128(1)
+	page.WriteBytes(bytes.Repeat([]byte{0x09}, 32))
+	page.Uleb128(0)
+	page.Bool(false)
+	page.Bool(true)
+	page.U64(500)
+	page.U64(600)
+	page.Uleb128(0)
+	page.Uleb128(1)
+	page.U64(2)
+	page.U64(70)
+	serializeTransactionInfo(page, 0, bytes.Repeat([]byte{0x0e}, 32))
+	page.Uleb128(0)
+	page.WriteBytes(bytes.Repeat([]byte{0x04}, 32))
+	page.Uleb128(0)
+	page.Uleb128(0)
+	assert.NoError(t, page.Error())
+
+	info := &bcs.Serializer{}
+	info.U8(4)
+	info.U64(10)
+	info.U64(2000)
+	info.U64(5)
+	info.U64(1700000000000000)
+	info.Uleb128(1)
+	info.U64(1)
+	info.U64(300)
+	info.Bool(true)
+	info.WriteString("abcdef")
+
+	account := &bcs.Serializer{}
+	account.WriteBytes(AccountOne[:])
+	account.U64(7)
+	account.U64(4)
+	account.U64(0)
+	account.U64(0)
+	AccountOne.MarshalBCS(account)
+	account.U64(0)
+	account.U64(1)
+	AccountOne.MarshalBCS(account)
+	account.Uleb128(0)
+	account.Uleb128(1)
+	AccountTwo.MarshalBCS(account)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-bcs", r.Header.Get("Accept"))
+		switch r.URL.Path {
+		case "/transactions":
+			assert.Equal(t, "100", r.URL.Query().Get("start"))
+			_, _ = w.Write(page.ToBytes())
+		case "/":
+			_, _ = w.Write(info.ToBytes())
+		case "/accounts/" + AccountOne.String():
+			assert.Equal(t, "55", r.URL.Query().Get("ledger_version"))
+			_, _ = w.Write(account.ToBytes())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	start := uint64(100)
+	txns, err := client.TransactionsBCS(&start, nil)
+	assert.NoError(t, err)
+	assert.Len(t, txns, 3)
+	assert.Equal(t, uint64(1234), txns[0].BlockMetadata.TimestampUsecs)
+	assert.Equal(t, AccountTwo, txns[0].BlockMetadata.Proposer)
+	assert.Equal(t, []uint32{2}, txns[0].BlockMetadata.FailedProposerIndices)
+	status := txns[1].Info.Status
+	assert.False(t, status.Success())
+	assert.Equal(t, ExecutionStatusMoveAbort, status.Kind)
+	assert.Equal(t, &ModuleId{Address: AccountOne, Name: "coin"}, status.Location)
+	assert.Equal(t, uint64(0x10006), status.Code)
+	assert.Equal(t, "EINSUFFICIENT_BALANCE", status.ReasonName)
+	assert.Len(t, txns[1].Info.StateCheckpointHash, 32)
+	assert.Equal(t, TransactionDataBlockEpilogue, txns[2].Variant)
+	assert.Equal(t, uint64(102), txns[2].Version)
+
+	nodeInfo, err := client.InfoBCS()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(4), nodeInfo.ChainId)
+	assert.Equal(t, uint64(2000), nodeInfo.LedgerVersion())
+	assert.Equal(t, uint64(300), nodeInfo.BlockHeight())
+	assert.Equal(t, "full_node", nodeInfo.NodeRole)
+	assert.Equal(t, "abcdef", nodeInfo.GitHash)
+
+	accountResource, err := client.AccountBCS(AccountOne, 55)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), accountResource.SequenceNumber)
+	assert.Equal(t, AccountOne[:], accountResource.AuthenticationKey)
+	assert.Equal(t, uint64(1), accountResource.KeyRotationEvents.CreationNumber)
+	assert.Nil(t, accountResource.RotationCapabilityOffer)
+	assert.Equal(t, &AccountTwo, accountResource.SignerCapabilityOffer)
+
+	_, err = client.TransactionByVersionBCS(1)
+	assert.Error(t, err)
+}
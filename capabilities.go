@@ -0,0 +1,78 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+// ErrNoIndexer is returned by indexer-only APIs when the [NetworkConfig] has no IndexerUrl
+var ErrNoIndexer = errors.New("no indexer configured for this network")
+
+// ErrNoFaucet is returned by faucet APIs when the [NetworkConfig] has no FaucetUrl
+var ErrNoFaucet = errors.New("no faucet configured for this network")
+
+// coinStorePrefix is the resource type prefix for legacy coin balances
+const coinStorePrefix = "0x1::coin::CoinStore<"
+
+// HasIndexer tells if the client was configured with an indexer.  When it's missing, [Client.GetCoinBalances],
+// [Client.GetAccountCoinsData], and [Client.GetAccountTransactions] of sent transactions fall back to the node REST
+// API, which is much slower and may find less, see each of them.  Other indexer APIs return [ErrNoIndexer].
+func (client *Client) HasIndexer() bool {
+	return client.indexerClient != nil
+}
+
+// HasFaucet tells if the client was configured with a faucet
+func (client *Client) HasFaucet() bool {
+	return client.faucetClient != nil
+}
+
+// queryIndexer runs a query against the indexer, or returns [ErrNoIndexer]
+func (client *Client) queryIndexer(query any, variables map[string]any, options ...graphql.Option) error {
+	if client.indexerClient == nil {
+		return ErrNoIndexer
+	}
+	return client.indexerClient.Query(query, variables, options...)
+}
+
+// warnIndexerFallback logs that an API is falling back to the node
+func warnIndexerFallback(api string) {
+	slog.Warn("no indexer configured, falling back to node REST scan, this may be slow", "api", api)
+}
+
+// coinBalancesFromNode scans all resources of the account for coin stores, used when there is no indexer
+//
+// Note that this only covers legacy coins held in a CoinStore, and not fungible asset stores
+func (rc *NodeClient) coinBalancesFromNode(address AccountAddress) ([]CoinBalance, error) {
+	resources, err := rc.AccountResources(address)
+	if err != nil {
+		return nil, err
+	}
+	var out []CoinBalance
+	for _, resource := range resources {
+		if !strings.HasPrefix(resource.Type, coinStorePrefix) || !strings.HasSuffix(resource.Type, ">") {
+			continue
+		}
+		coinType := resource.Type[len(coinStorePrefix) : len(resource.Type)-1]
+		coin, ok := resource.Data["coin"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("malformed coin store %s", resource.Type)
+		}
+		value, ok := coin["value"].(string)
+		if !ok {
+			return nil, fmt.Errorf("malformed coin store value %s", resource.Type)
+		}
+		amount, err := StrToUint64(value)
+		if err != nil {
+			return nil, fmt.Errorf("malformed coin store value %s: %w", resource.Type, err)
+		}
+		out = append(out, CoinBalance{
+			CoinType: coinType,
+			Amount:   amount,
+		})
+	}
+	return out, nil
+}
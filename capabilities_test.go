@@ -0,0 +1,158 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientWithoutIndexer(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/0x1/resources", r.URL.Path)
+		json.NewEncoder(w).Encode([]map[string]any{
+			{
+				"type": "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+				"data": map[string]any{"coin": map[string]any{"value": "1000"}},
+			},
+			{
+				"type": "0x1::account::Account",
+				"data": map[string]any{"sequence_number": "1"},
+			},
+			{
+				"type": "0x1::coin::CoinStore<0x1234::my_coin::MyCoin<u8>>",
+				"data": map[string]any{"coin": map[string]any{"value": "5"}},
+			},
+		})
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{
+		Name:    "mocknet",
+		ChainId: 4,
+		NodeUrl: mockServer.URL,
+	})
+	assert.NoError(t, err)
+	assert.False(t, client.HasIndexer())
+	assert.False(t, client.HasFaucet())
+
+	balances, err := client.GetCoinBalances(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, []CoinBalance{
+		{CoinType: "0x1::aptos_coin::AptosCoin", Amount: 1000},
+		{CoinType: "0x1234::my_coin::MyCoin<u8>", Amount: 5},
+	}, balances)
+
+	_, err = client.GetProcessorStatus("default_processor")
+	assert.ErrorIs(t, err, ErrNoIndexer)
+	assert.ErrorIs(t, client.QueryIndexer(nil, nil), ErrNoIndexer)
+	assert.ErrorIs(t, client.Fund(AccountOne, 100), ErrNoFaucet)
+}
+
+func TestClientWithoutIndexer_Fallbacks(t *testing.T) {
+	// Transactions of 0x1, with sequence number i at version 10*i
+	userTxn := func(sequenceNumber uint64) map[string]any {
+		function := "0x1::aptos_account::transfer"
+		if sequenceNumber%2 == 1 {
+			function = "0x1::code::publish_package_txn"
+		}
+		return map[string]any{
+			"type":                      "user_transaction",
+			"version":                   strconv.FormatUint(10*sequenceNumber, 10),
+			"hash":                      "0x" + strconv.FormatUint(sequenceNumber, 16),
+			"sender":                    "0x1",
+			"sequence_number":           strconv.FormatUint(sequenceNumber, 10),
+			"max_gas_amount":            "1000",
+			"gas_unit_price":            "100",
+			"expiration_timestamp_secs": "1700000600",
+			"timestamp":                 "1700000000000000",
+			"success":                   true,
+			"payload":                   map[string]any{"type": "entry_function_payload", "function": function, "type_arguments": []string{}, "arguments": []any{}},
+			"events":                    []any{},
+			"changes":                   []any{},
+		}
+	}
+	const sent = 250
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/0x1":
+			json.NewEncoder(w).Encode(map[string]any{"sequence_number": strconv.Itoa(sent), "authentication_key": "0x01"})
+		case "/accounts/0x1/transactions":
+			start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+			limit, _ := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+			txns := make([]map[string]any, 0)
+			for i := start; i < min(start+limit, sent); i++ {
+				txns = append(txns, userTxn(i))
+			}
+			json.NewEncoder(w).Encode(txns)
+		case "/accounts/0x1/resources":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"type": "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", "data": map[string]any{"coin": map[string]any{"value": "1000"}}},
+				{"type": "0x1::coin::CoinStore<0xcafe::token::Token>", "data": map[string]any{"coin": map[string]any{"value": "0"}}},
+			})
+		case "/accounts/0x1/resource/0x1::coin::CoinInfo<0x1::aptos_coin::AptosCoin>":
+			json.NewEncoder(w).Encode(map[string]any{"type": "0x1::coin::CoinInfo<0x1::aptos_coin::AptosCoin>", "data": map[string]any{"name": "Aptos Coin", "symbol": "APT", "decimals": 8}})
+		case "/accounts/0xa/resource/0x1::fungible_asset::Metadata":
+			json.NewEncoder(w).Encode(map[string]any{"type": "0x1::fungible_asset::Metadata", "data": map[string]any{"name": "Aptos Coin", "symbol": "APT", "decimals": 8, "icon_uri": ""}})
+		case "/view":
+			body, _ := io.ReadAll(r.Body)
+			if bytes.Contains(body, []byte("decimals")) {
+				json.NewEncoder(w).Encode([]any{8})
+			} else {
+				json.NewEncoder(w).Encode([]any{"500"})
+			}
+		default:
+			t.Errorf("unexpected request %s", r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{Name: "mocknet", ChainId: 4, NodeUrl: mockServer.URL})
+	assert.NoError(t, err)
+
+	// Coins and APT in the primary store are found on the node
+	coins, err := client.GetAccountCoinsData(AccountOne)
+	assert.NoError(t, err)
+	assert.Len(t, coins, 2)
+	assert.Equal(t, "0x000000000000000000000000000000000000000000000000000000000000000a", coins[0].AssetType)
+	assert.Equal(t, uint64(500), coins[0].Amount)
+	assert.Equal(t, "v2", coins[0].TokenStandard)
+	assert.Equal(t, "0x1::aptos_coin::AptosCoin", coins[1].AssetType)
+	assert.Equal(t, uint64(1000), coins[1].Amount)
+	assert.Equal(t, "APT", coins[1].Symbol)
+	assert.Equal(t, uint8(8), coins[1].Decimals)
+
+	// Only sent transactions can be found on the node
+	_, err = client.GetAccountTransactions(AccountOne, AccountTransactionsOptions{})
+	assert.ErrorIs(t, err, ErrNoIndexer)
+
+	versions := func(page []AccountTransactionSummary) []uint64 {
+		out := make([]uint64, len(page))
+		for i, txn := range page {
+			out[i] = txn.Version
+		}
+		return out
+	}
+	page, err := client.GetAccountTransactions(AccountOne, AccountTransactionsOptions{SentOnly: true, Limit: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2490, 2480, 2470}, versions(page))
+	assert.Equal(t, "0x1::code::publish_package_txn", page[0].EntryFunction)
+	assert.Equal(t, int64(1700000000), page[0].Timestamp.Unix())
+
+	cursor := uint64(1005)
+	page, err = client.GetAccountTransactions(AccountOne, AccountTransactionsOptions{SentOnly: true, Cursor: &cursor, Limit: 2, EntryFunction: "0x1::aptos_account::transfer"})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1000, 980}, versions(page))
+
+	page, err = client.GetAccountTransactions(AccountOne, AccountTransactionsOptions{SentOnly: true, Cursor: &cursor, Ascending: true, Limit: 200})
+	assert.NoError(t, err)
+	assert.Len(t, page, 149)
+	assert.Equal(t, uint64(1010), page[0].Version)
+	assert.Equal(t, uint64(2490), page[148].Version)
+}
@@ -0,0 +1,606 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+const (
+	DefaultScannerCheckpointInterval = uint64(1000)           // DefaultScannerCheckpointInterval is the number of versions a [ChainScanner] scans without deposits before saving its checkpoint
+	DefaultScannerRetryBackoff       = 200 * time.Millisecond // DefaultScannerRetryBackoff is the wait before a [ChainScanner] retries after a temporary node error, it doubles after each
+	DefaultScannerMaxRetryBackoff    = 30 * time.Second       // DefaultScannerMaxRetryBackoff caps the wait between retries of a [ChainScanner]
+)
+
+// Deposit is a deposit of a coin or fungible asset into a watched account, found by a [ChainScanner].  A deposit is
+// identified by its Version and EventIndex, so it can be credited once even if delivered again after a restart.
+type Deposit struct {
+	Version         uint64          // Version of the transaction
+	TransactionHash string          // TransactionHash of the transaction
+	Timestamp       uint64          // Timestamp of the transaction in microseconds since the Unix epoch
+	Sender          AccountAddress  // Sender of the transaction, which may be the watched account itself
+	Account         AccountAddress  // Account is the watched account deposited to
+	Store           *AccountAddress // Store is the fungible store deposited to, nil for a coin deposit
+	Asset           string          // Asset is the coin type, or the fungible asset metadata address.  APT is always 0x1::aptos_coin::AptosCoin
+	Amount          uint64          // Amount deposited in the smallest unit of the asset
+	EventIndex      int             // EventIndex is the index of the deposit event within the transaction
+}
+
+// ScannerCheckpoint stores the next version of a [ChainScanner], so it resumes where it stopped after a restart.
+//
+// Implementations are [MemoryScannerCheckpoint] and [FileScannerCheckpoint].
+type ScannerCheckpoint interface {
+	// Load returns the saved version, and false if none has been saved yet
+	Load() (version uint64, ok bool, err error)
+	// Save saves the version
+	Save(version uint64) error
+}
+
+// MemoryScannerCheckpoint is a [ScannerCheckpoint] held in memory, e.g. for tests or to resume a scanner within a
+// process
+type MemoryScannerCheckpoint struct {
+	mutex   sync.Mutex
+	version *uint64
+}
+
+// Load returns the saved version
+//
+// Implements:
+//   - [ScannerCheckpoint]
+func (c *MemoryScannerCheckpoint) Load() (uint64, bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.version == nil {
+		return 0, false, nil
+	}
+	return *c.version, true, nil
+}
+
+// Save saves the version
+//
+// Implements:
+//   - [ScannerCheckpoint]
+func (c *MemoryScannerCheckpoint) Save(version uint64) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.version = &version
+	return nil
+}
+
+// FileScannerCheckpoint is a [ScannerCheckpoint] saved as a decimal version in a file.  The file is replaced
+// atomically, so a crash while saving leaves the previous checkpoint.
+//
+//	scanner := client.ChainScanner().Checkpoint(aptos.FileScannerCheckpoint("deposits.checkpoint"))
+type FileScannerCheckpoint string
+
+// Load reads the version from the file, returning false if the file doesn't exist
+//
+// Implements:
+//   - [ScannerCheckpoint]
+func (c FileScannerCheckpoint) Load() (uint64, bool, error) {
+	contents, err := os.ReadFile(string(c))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	version, err := strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid scanner checkpoint %s: %w", string(c), err)
+	}
+	return version, true, nil
+}
+
+// Save writes the version to a temporary file, and renames it over the file
+//
+// Implements:
+//   - [ScannerCheckpoint]
+func (c FileScannerCheckpoint) Save(version uint64) error {
+	tmp := string(c) + ".tmp"
+	err := os.WriteFile(tmp, []byte(strconv.FormatUint(version, 10)+"\n"), 0o600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, string(c))
+}
+
+// ChainScanner walks committed transactions in version order, and reports the coin and fungible asset deposits into a
+// watch-list of accounts, e.g. the deposit addresses of an exchange.  It runs on an [EventSubscriptionBackend], polling
+// by default.
+//
+//	scanner := client.ChainScanner().
+//		Watch(depositAddresses...).
+//		Checkpoint(aptos.FileScannerCheckpoint("deposits.checkpoint"))
+//	deposits, errs := scanner.Channel(ctx, 100)
+//	for deposit := range deposits {
+//		// credit deposit.Amount of deposit.Asset to deposit.Account, once per deposit.Version and deposit.EventIndex
+//		scanner.Ack(deposit)
+//	}
+//	err := <-errs
+//
+// In a long-running service, give it a [ChainScanner.Handler] and Start it instead, and Close saves the checkpoint after
+// the deposit being handled.
+//
+// Committed transactions are final, so there are no reorgs to roll back, and the checkpoint only moves forward.  It is
+// saved once the deposits of a transaction have been handled, and every [ChainScanner.CheckpointInterval] versions
+// otherwise.  Deposits handled after the last save are delivered again on resume, so they must be credited
+// idempotently.
+//
+// Deposits are found from withdraw and deposit events, resolved to accounts and assets from the transaction's write
+// set.  Deposits into the primary fungible store of a watched account are found even if the store's owner isn't in the
+// write set.  Only successful transactions have deposits.
+type ChainScanner struct {
+	backend            EventSubscriptionBackend
+	checkpoint         ScannerCheckpoint
+	fromVersion        *uint64
+	checkpointInterval uint64
+	retryBackoff       time.Duration
+	maxRetryBackoff    time.Duration
+	nextVersion        uint64
+	acks               *depositAcks // acks tracks deposits delivered on a channel until they're acknowledged, nil for a handler
+	handler            func(deposit Deposit) error
+
+	life    lifecycle
+	cancel  context.CancelFunc
+	closing bool // closing is set by Close, so the cancellation isn't reported as an error
+
+	mutex         sync.RWMutex
+	watched       map[AccountAddress]bool
+	primaryStores map[string]map[AccountAddress]AccountAddress // primaryStores is the primary store of each watched account by metadata, filled as metadata is seen
+}
+
+// NewChainScanner creates a [ChainScanner] on the given backend, watching no accounts
+func NewChainScanner(backend EventSubscriptionBackend) *ChainScanner {
+	return &ChainScanner{
+		backend:            backend,
+		checkpointInterval: DefaultScannerCheckpointInterval,
+		retryBackoff:       DefaultScannerRetryBackoff,
+		maxRetryBackoff:    DefaultScannerMaxRetryBackoff,
+		watched:            make(map[AccountAddress]bool),
+		primaryStores:      make(map[string]map[AccountAddress]AccountAddress),
+	}
+}
+
+// Watch reports deposits into the accounts.  It can be called while the scanner is running, e.g. as users are given
+// new deposit addresses, and applies from the next transaction scanned.
+func (s *ChainScanner) Watch(addresses ...AccountAddress) *ChainScanner {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, address := range addresses {
+		s.watched[address] = true
+	}
+	clear(s.primaryStores)
+	return s
+}
+
+// Unwatch stops reporting deposits into the accounts
+func (s *ChainScanner) Unwatch(addresses ...AccountAddress) *ChainScanner {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, address := range addresses {
+		delete(s.watched, address)
+	}
+	clear(s.primaryStores)
+	return s
+}
+
+// Watching tells if deposits into the account are reported
+func (s *ChainScanner) Watching(address AccountAddress) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.watched[address]
+}
+
+// Backend changes the transport of the scanner
+func (s *ChainScanner) Backend(backend EventSubscriptionBackend) *ChainScanner {
+	s.backend = backend
+	return s
+}
+
+// Checkpoint saves progress to the checkpoint, and resumes from it if it has been saved before
+func (s *ChainScanner) Checkpoint(checkpoint ScannerCheckpoint) *ChainScanner {
+	s.checkpoint = checkpoint
+	return s
+}
+
+// FromVersion starts scanning at the ledger version, inclusive, if there is no saved checkpoint.  By default, only new
+// transactions are scanned.
+func (s *ChainScanner) FromVersion(version uint64) *ChainScanner {
+	s.fromVersion = &version
+	return s
+}
+
+// CheckpointInterval is the number of versions scanned without deposits before the checkpoint is saved, defaults to
+// [DefaultScannerCheckpointInterval]
+func (s *ChainScanner) CheckpointInterval(versions uint64) *ChainScanner {
+	s.checkpointInterval = versions
+	return s
+}
+
+// RetryBackoff sets the wait before retrying after a temporary node error, doubling after each retry up to
+// maxBackoff.  Defaults to [DefaultScannerRetryBackoff] and [DefaultScannerMaxRetryBackoff].
+func (s *ChainScanner) RetryBackoff(backoff time.Duration, maxBackoff time.Duration) *ChainScanner {
+	s.retryBackoff = backoff
+	s.maxRetryBackoff = maxBackoff
+	return s
+}
+
+// Handler is called for each deposit in order by [ChainScanner.Start].  Returning an error stops the scanner.
+func (s *ChainScanner) Handler(handler func(deposit Deposit) error) *ChainScanner {
+	s.handler = handler
+	return s
+}
+
+// NextVersion is the first version not yet fully scanned
+func (s *ChainScanner) NextVersion() uint64 {
+	return s.nextVersion
+}
+
+// Start runs the scanner in the background, calling the handler for each deposit, see [ChainScanner.Run]
+//
+// Implements:
+//   - [Lifecycle]
+func (s *ChainScanner) Start(ctx context.Context) error {
+	if s.handler == nil {
+		return errors.New("chain scanner has no handler")
+	}
+	return s.life.start(func() {
+		ctx, s.cancel = context.WithCancel(ctx)
+		go func() {
+			err := s.Run(ctx, s.handler)
+			s.life.mutex.RLock()
+			if s.closing {
+				err = withoutCanceled(err)
+			}
+			s.life.mutex.RUnlock()
+			s.life.finish(err)
+		}()
+	})
+}
+
+// Close stops the scanner once the deposit being handled is done, and saves the checkpoint.  It returns the error the
+// scanner stopped with, including failing to save the checkpoint.
+//
+// Implements:
+//   - [Lifecycle]
+func (s *ChainScanner) Close() error {
+	s.life.close(func() {
+		s.closing = true
+		s.cancel()
+	})
+	return s.life.wait()
+}
+
+// Done is closed once the scanner has stopped, see [ChainScanner.Err] for why
+//
+// Implements:
+//   - [Lifecycle]
+func (s *ChainScanner) Done() <-chan struct{} {
+	return s.life.doneChan()
+}
+
+// Err is the error a started scanner stopped with, nil while it is running or if it was closed
+func (s *ChainScanner) Err() error {
+	select {
+	case <-s.Done():
+		return s.life.err
+	default:
+		return nil
+	}
+}
+
+// Run calls the handler for each deposit in order, until ctx is done or the handler returns an error.  It returns
+// ctx.Err() when cancelled, after saving the checkpoint.
+//
+// Temporary node errors, i.e. network errors, 429, and 5xx responses, are retried with backoff from the first version
+// not yet scanned, see [ChainScanner.RetryBackoff].  Other errors stop the scanner.
+func (s *ChainScanner) Run(ctx context.Context, handler func(deposit Deposit) error) error {
+	if s.backend == nil {
+		return errors.New("chain scanner has no backend")
+	}
+	if handler == nil {
+		return errors.New("chain scanner has no handler")
+	}
+	resumed, err := s.resume(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A scanner not resumed from the checkpoint saves its start, even if it makes no progress
+	saved, haveSaved := s.nextVersion, resumed
+	save := func() error {
+		version := s.committedVersion()
+		if s.checkpoint == nil || (haveSaved && saved == version) {
+			return nil
+		}
+		err := s.checkpoint.Save(version)
+		if err != nil {
+			return fmt.Errorf("failed to save chain scanner checkpoint at %d: %w", version, err)
+		}
+		saved, haveSaved = version, true
+		return nil
+	}
+
+	attempt := 0
+	for {
+		err = s.backend.Run(ctx, s.nextVersion, func(txn *api.UserTransaction) error {
+			if txn.Version < s.nextVersion {
+				return nil
+			}
+			attempt = 0
+			deposits := s.Deposits(txn)
+			for _, deposit := range deposits {
+				err := handler(deposit)
+				if err != nil {
+					return &chainScannerStop{err}
+				}
+			}
+			s.nextVersion = txn.Version + 1
+			if len(deposits) > 0 || !haveSaved || s.nextVersion-saved >= max(s.checkpointInterval, 1) {
+				if err := save(); err != nil {
+					return &chainScannerStop{err}
+				}
+			}
+			return nil
+		})
+		stop := &chainScannerStop{}
+		if errors.As(err, &stop) {
+			return errors.Join(stop.err, save())
+		}
+		if err == nil || ctx.Err() != nil || !transactionRangeRetryable(err) {
+			return errors.Join(err, save())
+		}
+		if err = s.wait(ctx, attempt); err != nil {
+			return errors.Join(err, save())
+		}
+		attempt++
+	}
+}
+
+// resume sets the first version to scan, from the checkpoint, the start version, or the latest version.  It returns
+// true if it resumed from the checkpoint.
+func (s *ChainScanner) resume(ctx context.Context) (bool, error) {
+	if s.checkpoint != nil {
+		version, ok, err := s.checkpoint.Load()
+		if err != nil {
+			return false, fmt.Errorf("failed to load chain scanner checkpoint: %w", err)
+		}
+		if ok {
+			s.nextVersion = version
+			return true, nil
+		}
+	}
+	if s.fromVersion != nil {
+		s.nextVersion = *s.fromVersion
+		return false, nil
+	}
+	for attempt := 0; ; attempt++ {
+		latest, err := s.backend.LatestVersion()
+		if err == nil {
+			s.nextVersion = latest + 1
+			return false, nil
+		}
+		if !transactionRangeRetryable(err) {
+			return false, fmt.Errorf("failed to get latest version for chain scanner: %w", err)
+		}
+		if err = s.wait(ctx, attempt); err != nil {
+			return false, err
+		}
+	}
+}
+
+// wait backs off before a retry, returning ctx.Err() if ctx is done first
+func (s *ChainScanner) wait(ctx context.Context, attempt int) error {
+	backoff := max(s.retryBackoff, time.Millisecond) << min(attempt, 30)
+	if s.maxRetryBackoff > 0 && (backoff > s.maxRetryBackoff || backoff <= 0) {
+		backoff = s.maxRetryBackoff
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(backoff):
+		return nil
+	}
+}
+
+// committedVersion is the version the checkpoint can be saved at, the oldest deposit not yet acknowledged on a channel,
+// or otherwise the first version not yet scanned
+func (s *ChainScanner) committedVersion() uint64 {
+	if s.acks != nil {
+		if version, ok := s.acks.oldest(); ok {
+			return version
+		}
+	}
+	return s.nextVersion
+}
+
+// chainScannerStop wraps errors from the handler or checkpoint, which stop the scanner rather than being retried
+type chainScannerStop struct {
+	err error
+}
+
+func (e *chainScannerStop) Error() string {
+	return e.err.Error()
+}
+
+func (e *chainScannerStop) Unwrap() error {
+	return e.err
+}
+
+// Channel runs the scanner in the background, delivering deposits on the returned channel instead of to a handler.  The
+// channel holds up to buffer deposits, and scanning pauses while it's full.
+//
+// Call [ChainScanner.Ack] once a deposit has been credited.  The checkpoint is only saved up to the oldest deposit not
+// yet acknowledged, so deposits still in the channel, or being credited, when the scanner stops are delivered again on
+// resume.
+//
+// Both channels are closed once the scanner stops.  It stops when ctx is done, without an error, or on the first
+// error, which is sent on the error channel.
+//
+//	deposits, errs := scanner.Channel(ctx, 100)
+//	for deposit := range deposits {
+//		// credit the deposit
+//		scanner.Ack(deposit)
+//	}
+func (s *ChainScanner) Channel(ctx context.Context, buffer int) (<-chan Deposit, <-chan error) {
+	deposits := make(chan Deposit, buffer)
+	errs := make(chan error, 1)
+	s.acks = &depositAcks{pending: make(map[depositKey]bool)}
+	go func() {
+		defer close(errs)
+		defer close(deposits)
+		err := s.Run(ctx, func(deposit Deposit) error {
+			s.acks.push(deposit)
+			select {
+			case deposits <- deposit:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+	return deposits, errs
+}
+
+// Ack acknowledges a deposit received from [ChainScanner.Channel] has been credited, so the checkpoint can move past
+// it.  Deposits can be acknowledged in any order, and acknowledging a deposit not waiting for it does nothing.
+func (s *ChainScanner) Ack(deposit Deposit) {
+	if s.acks != nil {
+		s.acks.ack(deposit)
+	}
+}
+
+// depositKey identifies a deposit, see [Deposit]
+type depositKey struct {
+	version    uint64
+	eventIndex int
+}
+
+// depositAcks tracks the deposits delivered on a channel, in delivery order, until they're acknowledged
+type depositAcks struct {
+	mutex   sync.Mutex
+	order   []depositKey
+	pending map[depositKey]bool // pending is true once acknowledged, waiting for older deposits to be acknowledged
+}
+
+func (a *depositAcks) push(deposit Deposit) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	key := depositKey{deposit.Version, deposit.EventIndex}
+	a.order = append(a.order, key)
+	a.pending[key] = false
+}
+
+func (a *depositAcks) ack(deposit Deposit) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	key := depositKey{deposit.Version, deposit.EventIndex}
+	if _, ok := a.pending[key]; ok {
+		a.pending[key] = true
+	}
+}
+
+// oldest is the version of the oldest deposit not yet acknowledged, false if all are
+func (a *depositAcks) oldest() (uint64, bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	for len(a.order) > 0 && a.pending[a.order[0]] {
+		delete(a.pending, a.order[0])
+		a.order = a.order[1:]
+	}
+	if len(a.order) == 0 {
+		return 0, false
+	}
+	return a.order[0].version, true
+}
+
+// Deposits returns the deposits into watched accounts in the transaction, in event order
+func (s *ChainScanner) Deposits(txn *api.UserTransaction) []Deposit {
+	if txn == nil || !txn.Success {
+		return nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.watched) == 0 {
+		return nil
+	}
+
+	sender := AccountAddress{}
+	if txn.Sender != nil {
+		sender = *txn.Sender
+	}
+	stores := fungibleStoresFromChanges(txn.Changes)
+	var coinHandles map[EventHandleId]string
+	var deposits []Deposit
+	for i, event := range txn.Events {
+		leg, isWithdraw, ok := transferLegFromEvent(event, stores)
+		if !ok || isWithdraw {
+			continue
+		}
+		deposit := Deposit{
+			Version:         txn.Version,
+			TransactionHash: txn.Hash,
+			Timestamp:       txn.Timestamp,
+			Sender:          sender,
+			Account:         leg.account,
+			Asset:           leg.asset,
+			Amount:          leg.amount,
+			EventIndex:      i,
+		}
+		switch event.Type {
+		case "0x1::coin::DepositEvent":
+			if coinHandles == nil {
+				coinHandles = coinHandlesFromChanges(txn.Changes)
+			}
+			deposit.Asset = coinHandles[EventHandleId{Account: leg.account, CreationNumber: event.Guid.CreationNumber}]
+		case "0x1::coin::CoinDeposit":
+			deposit.Asset = normalizeCoinType(deposit.Asset)
+		case "0x1::fungible_asset::Deposit":
+			store := AccountAddress{}
+			if store.ParseStringRelaxed(event.Data["store"].(string)) != nil {
+				continue
+			}
+			deposit.Store = &store
+			if deposit.Account == store && deposit.Asset != "" {
+				if owner, ok := s.primaryStoreOwner(store, deposit.Asset); ok {
+					deposit.Account = owner
+				}
+			}
+		}
+		if !s.watched[deposit.Account] {
+			continue
+		}
+		deposit.Asset = canonicalAsset(deposit.Asset)
+		deposits = append(deposits, deposit)
+	}
+	return deposits
+}
+
+// primaryStoreOwner finds the watched account whose primary store of the metadata is the store.  The mutex must be
+// held for writing.
+func (s *ChainScanner) primaryStoreOwner(store AccountAddress, metadata string) (AccountAddress, bool) {
+	owners, ok := s.primaryStores[metadata]
+	if !ok {
+		metadataAddress := AccountAddress{}
+		if metadataAddress.ParseStringRelaxed(metadata) != nil {
+			return AccountAddress{}, false
+		}
+		owners = make(map[AccountAddress]AccountAddress, len(s.watched))
+		for account := range s.watched {
+			owners[account.ObjectAddressFromObject(&metadataAddress)] = account
+		}
+		s.primaryStores[metadata] = owners
+	}
+	owner, ok := owners[store]
+	return owner, ok
+}
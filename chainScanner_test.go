@@ -0,0 +1,209 @@
+package aptos
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainScanner(t *testing.T) {
+	alice, bob, carol, aptMetadata := AccountAddress{}, AccountAddress{}, AccountAddress{}, AccountAddress{}
+	assert.NoError(t, alice.ParseStringRelaxed("0xa1"))
+	assert.NoError(t, bob.ParseStringRelaxed("0xb1"))
+	assert.NoError(t, carol.ParseStringRelaxed("0xc1"))
+	assert.NoError(t, aptMetadata.ParseStringRelaxed("0xa"))
+	bobStore := bob.ObjectAddressFromObject(&aptMetadata)
+
+	client := &mockPollingEventClient{}
+	add := func(events []map[string]any, changes []map[string]any) {
+		userTxn := testTransferTransaction(t, uint64(len(client.txns)), "0x1", events, changes)
+		userTxn.Sender = &carol
+		client.txns = append(client.txns, &api.CommittedTransaction{Type: api.TransactionVariantUser, Inner: userTxn})
+	}
+	// A legacy coin deposit to alice, the coin type comes from her CoinStore
+	add([]map[string]any{
+		{"type": "0x1::coin::DepositEvent", "guid": map[string]any{"creation_number": "2", "account_address": "0xa1"}, "sequence_number": "0", "data": map[string]any{"amount": "5"}},
+	}, []map[string]any{
+		{"type": "write_resource", "address": "0xa1", "state_key_hash": "0x0", "data": map[string]any{"type": "0x1::coin::CoinStore<0xcafe::token::Token>", "data": map[string]any{
+			"coin":            map[string]any{"value": "5"},
+			"frozen":          false,
+			"deposit_events":  map[string]any{"counter": "1", "guid": map[string]any{"id": map[string]any{"addr": "0xa1", "creation_num": "2"}}},
+			"withdraw_events": map[string]any{"counter": "0", "guid": map[string]any{"id": map[string]any{"addr": "0xa1", "creation_num": "3"}}},
+		}}},
+	})
+	// Nothing to watched accounts
+	add([]map[string]any{
+		testCategoryEvent("0x1::coin::CoinDeposit", map[string]any{"coin_type": "0x1::aptos_coin::AptosCoin", "account": "0xc1", "amount": "1"}),
+	}, nil)
+	// APT into bob's existing primary store, whose owner isn't in the write set
+	add([]map[string]any{
+		testCategoryEvent("0x1::fungible_asset::Withdraw", map[string]any{"store": "0xc2", "amount": "7"}),
+		testCategoryEvent("0x1::fungible_asset::Deposit", map[string]any{"store": bobStore.String(), "amount": "7"}),
+	}, []map[string]any{
+		{"type": "write_resource", "address": bobStore.String(), "state_key_hash": "0x0", "data": map[string]any{"type": "0x1::fungible_asset::FungibleStore", "data": map[string]any{"metadata": map[string]any{"inner": "0xa"}}}},
+	})
+
+	checkpoint := &MemoryScannerCheckpoint{}
+	backend := &PollingEventBackend{Client: client, PollInterval: time.Millisecond}
+	scanner := NewChainScanner(backend).Watch(alice, bob).Checkpoint(checkpoint).FromVersion(0)
+	assert.True(t, scanner.Watching(alice))
+	assert.False(t, scanner.Watching(carol))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	deposits, errs := scanner.Channel(ctx, 0)
+	token, err := ParseTypeTag("0xcafe::token::Token")
+	assert.NoError(t, err)
+
+	deposit := <-deposits
+	assert.Equal(t, Deposit{Version: 0, TransactionHash: "0x1", Sender: carol, Account: alice, Asset: token.String(), Amount: 5}, deposit)
+	aliceDeposit := deposit
+	deposit = <-deposits
+	assert.Equal(t, Deposit{Version: 2, TransactionHash: "0x1", Sender: carol, Account: bob, Store: &bobStore, Asset: AptosCoinTypeTag.String(), Amount: 7, EventIndex: 1}, deposit)
+	scanner.Ack(deposit)
+	cancel()
+	for range deposits {
+	}
+	assert.NoError(t, <-errs)
+
+	// Alice's deposit wasn't acknowledged, so the checkpoint stays at it, and it's delivered again on resume
+	version, ok, err := checkpoint.Load()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), version)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	scanner = NewChainScanner(backend).Watch(alice, bob).Checkpoint(checkpoint)
+	deposits, errs = scanner.Channel(ctx, 10)
+	assert.Equal(t, aliceDeposit, <-deposits)
+	scanner.Ack(<-deposits)
+	scanner.Ack(aliceDeposit)
+	cancel()
+	for range deposits {
+	}
+	assert.NoError(t, <-errs)
+
+	// Everything was acknowledged, so the checkpoint is after the last transaction, and resuming doesn't deliver the
+	// deposits again
+	version, ok, err = checkpoint.Load()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), version)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	resumed := NewChainScanner(backend).Watch(alice, bob).Checkpoint(checkpoint).FromVersion(0)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	err = resumed.Run(ctx, func(deposit Deposit) error {
+		t.Errorf("unexpected deposit %v", deposit)
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, uint64(3), resumed.NextVersion())
+
+	resumed.Unwatch(alice)
+	txn, err := client.txns[0].UserTransaction()
+	assert.NoError(t, err)
+	assert.Empty(t, resumed.Deposits(txn))
+}
+
+func TestFileScannerCheckpoint(t *testing.T) {
+	checkpoint := FileScannerCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+	_, ok, err := checkpoint.Load()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, checkpoint.Save(42))
+	assert.NoError(t, checkpoint.Save(43))
+	version, ok, err := checkpoint.Load()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(43), version)
+}
+
+type flakyPollingEventClient struct {
+	*mockPollingEventClient
+	failures atomic.Int32
+}
+
+func (m *flakyPollingEventClient) Transactions(start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	if m.failures.Add(-1) >= 0 {
+		return nil, &HttpError{Status: "503 Service Unavailable", StatusCode: http.StatusServiceUnavailable}
+	}
+	return m.mockPollingEventClient.Transactions(start, limit)
+}
+
+func TestChainScanner_Retry(t *testing.T) {
+	mock := &mockPollingEventClient{}
+	mock.add(t, "0xc1", []map[string]any{
+		testCategoryEvent("0x1::coin::CoinDeposit", map[string]any{"coin_type": "0x1::aptos_coin::AptosCoin", "account": "0xa1", "amount": "1"}),
+	})
+	alice := AccountAddress{}
+	assert.NoError(t, alice.ParseStringRelaxed("0xa1"))
+
+	// Temporary errors are retried
+	client := &flakyPollingEventClient{mockPollingEventClient: mock}
+	client.failures.Store(3)
+	backend := &PollingEventBackend{Client: client, PollInterval: time.Millisecond}
+	scanner := NewChainScanner(backend).Watch(alice).FromVersion(0).RetryBackoff(time.Millisecond, 5*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := scanner.Run(ctx, func(deposit Deposit) error {
+		assert.Equal(t, uint64(1), deposit.Amount)
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(-1), client.failures.Load())
+
+	// Handler errors aren't, even if they look temporary
+	handlerErr := &HttpError{StatusCode: http.StatusServiceUnavailable}
+	err = NewChainScanner(backend).Watch(alice).FromVersion(0).Run(context.Background(), func(deposit Deposit) error {
+		return handlerErr
+	})
+	assert.ErrorIs(t, err, handlerErr)
+}
+
+func TestChainScanner_Lifecycle(t *testing.T) {
+	client := &mockPollingEventClient{}
+	client.add(t, "0xc1", []map[string]any{
+		testCategoryEvent("0x1::coin::CoinDeposit", map[string]any{"coin_type": "0x1::aptos_coin::AptosCoin", "account": "0xa1", "amount": "1"}),
+	})
+	alice := AccountAddress{}
+	assert.NoError(t, alice.ParseStringRelaxed("0xa1"))
+
+	received := make(chan Deposit, 1)
+	checkpoint := &MemoryScannerCheckpoint{}
+	scanner := NewChainScanner(&PollingEventBackend{Client: client, PollInterval: time.Millisecond}).
+		Watch(alice).
+		Checkpoint(checkpoint).
+		FromVersion(0).
+		Handler(func(deposit Deposit) error {
+			received <- deposit
+			return nil
+		})
+	var _ Lifecycle = scanner
+
+	assert.NoError(t, scanner.Start(context.Background()))
+	assert.ErrorIs(t, scanner.Start(context.Background()), ErrAlreadyStarted)
+	assert.Equal(t, uint64(1), (<-received).Amount)
+
+	// Closing isn't an error, and saves the checkpoint past the deposit
+	assert.NoError(t, scanner.Close())
+	<-scanner.Done()
+	assert.NoError(t, scanner.Err())
+	version, ok, err := checkpoint.Load()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), version)
+	assert.ErrorIs(t, scanner.Start(context.Background()), ErrClosed)
+}
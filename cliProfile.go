@@ -0,0 +1,216 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CliConfigFile is the path of the Aptos CLI config, relative to the workspace or home directory
+var CliConfigFile = filepath.Join(".aptos", "config.yaml")
+
+// DefaultCliProfile is the profile the Aptos CLI uses when none is named
+const DefaultCliProfile = "default"
+
+// ErrCliProfileNotFound is returned when a profile doesn't exist in the Aptos CLI config
+var ErrCliProfileNotFound = errors.New("aptos cli profile not found")
+
+// CliProfile is a single profile from the Aptos CLI config, as created by `aptos init --profile <name>`
+type CliProfile struct {
+	Network    string `yaml:"network,omitempty"`     // Network is one of Mainnet, Testnet, Devnet, Local, or Custom
+	PrivateKey string `yaml:"private_key,omitempty"` // PrivateKey is the AIP-80 or hex encoded private key
+	PublicKey  string `yaml:"public_key,omitempty"`  // PublicKey is the encoded public key
+	Account    string `yaml:"account,omitempty"`     // Account is the account address, which may not have a leading 0x
+	RestUrl    string `yaml:"rest_url,omitempty"`    // RestUrl is the node url, usually without the trailing /v1
+	FaucetUrl  string `yaml:"faucet_url,omitempty"`  // FaucetUrl is the faucet url, if the network has one
+
+	Extra map[string]any `yaml:",inline"` // Extra keeps fields this SDK doesn't know about, so saving doesn't drop them
+}
+
+// CliConfig is the Aptos CLI config file, usually at ~/.aptos/config.yaml or .aptos/config.yaml in a workspace
+type CliConfig struct {
+	Profiles       map[string]*CliProfile `yaml:"profiles"`
+	DefaultProfile string                 `yaml:"default_profile,omitempty"` // DefaultProfile is used when none is named, the Aptos CLI ignores it and uses [DefaultCliProfile]
+
+	Extra map[string]any `yaml:",inline"` // Extra keeps fields this SDK doesn't know about, so saving doesn't drop them
+}
+
+// DefaultCliConfigPath returns the path of the Aptos CLI config.  Like the CLI, a config in the current directory is
+// preferred over the global config in the home directory.
+func DefaultCliConfigPath() (string, error) {
+	if _, err := os.Stat(CliConfigFile); err == nil {
+		return CliConfigFile, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, CliConfigFile), nil
+}
+
+// LoadCliConfig reads an Aptos CLI config file.  A missing file is an empty config, so profiles can be added to it.
+func LoadCliConfig(path string) (*CliConfig, error) {
+	config := &CliConfig{}
+	contents, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		err = yaml.Unmarshal(contents, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse aptos cli config %s: %w", path, err)
+		}
+	}
+	if config.Profiles == nil {
+		config.Profiles = make(map[string]*CliProfile)
+	}
+	return config, nil
+}
+
+// Save writes the config to path, creating the directory if needed.  The file is only readable by the owner, as it
+// contains private keys.
+func (config *CliConfig) Save(path string) error {
+	contents, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte("---\n"), contents...), 0o600)
+}
+
+// ProfileNames returns the names of all profiles, sorted
+func (config *CliConfig) ProfileNames() []string {
+	names := make([]string, 0, len(config.Profiles))
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns the profile with the given name, or [ErrCliProfileNotFound]
+func (config *CliConfig) Profile(name string) (*CliProfile, error) {
+	profile, ok := config.Profiles[name]
+	if !ok || profile == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCliProfileNotFound, name)
+	}
+	return profile, nil
+}
+
+// DefaultProfileName returns the profile used when none is named, see [CliConfig.UseProfile]
+func (config *CliConfig) DefaultProfileName() string {
+	if config.DefaultProfile != "" {
+		return config.DefaultProfile
+	}
+	return DefaultCliProfile
+}
+
+// UseProfile makes the profile the default, or [ErrCliProfileNotFound] if it doesn't exist
+func (config *CliConfig) UseProfile(name string) error {
+	if _, err := config.Profile(name); err != nil {
+		return err
+	}
+	if name == DefaultCliProfile {
+		name = ""
+	}
+	config.DefaultProfile = name
+	return nil
+}
+
+// AddProfile adds or replaces a profile for the account on the given network
+func (config *CliConfig) AddProfile(name string, network NetworkConfig, account *Account) error {
+	privateKey, err := account.PrivateKeyString()
+	if err != nil {
+		return err
+	}
+	profile := &CliProfile{
+		Network:    cliNetworkName(network),
+		PrivateKey: privateKey,
+		PublicKey:  account.PubKey().ToHex(),
+		Account:    strings.TrimPrefix(account.Address.StringLong(), "0x"),
+		RestUrl:    strings.TrimSuffix(strings.TrimSuffix(network.NodeUrl, "/"), "/v1"),
+		FaucetUrl:  network.FaucetUrl,
+	}
+	if config.Profiles == nil {
+		config.Profiles = make(map[string]*CliProfile)
+	}
+	config.Profiles[name] = profile
+	return nil
+}
+
+// NetworkConfig returns the [NetworkConfig] of the profile.  Named networks use the SDK's config, with the profile's
+// urls taking precedence if set.
+func (profile *CliProfile) NetworkConfig() (NetworkConfig, error) {
+	network, ok := NamedNetworks[cliNetworkToNamedNetwork(profile.Network)]
+	if !ok {
+		if profile.RestUrl == "" {
+			return NetworkConfig{}, fmt.Errorf("aptos cli profile for network %s has no rest_url", profile.Network)
+		}
+		network = NetworkConfig{Name: strings.ToLower(profile.Network)}
+	}
+	if profile.RestUrl != "" {
+		network.NodeUrl = strings.TrimSuffix(profile.RestUrl, "/")
+		if !strings.HasSuffix(network.NodeUrl, "/v1") {
+			network.NodeUrl += "/v1"
+		}
+	}
+	if profile.FaucetUrl != "" {
+		network.FaucetUrl = profile.FaucetUrl
+	}
+	return network, nil
+}
+
+// AccountAddress returns the account address of the profile
+func (profile *CliProfile) AccountAddress() (AccountAddress, error) {
+	address := AccountAddress{}
+	err := address.ParseStringRelaxed(profile.Account)
+	return address, err
+}
+
+// Signer returns an [Account] that can sign for the profile, keeping the profile's address in case the key was rotated
+func (profile *CliProfile) Signer() (*Account, error) {
+	if profile.PrivateKey == "" {
+		return nil, errors.New("aptos cli profile has no private key")
+	}
+	address, err := profile.AccountAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	// The CLI writes AIP-80 keys, older configs have plain hex Ed25519 keys
+	return NewAccountFromPrivateKeyString(profile.PrivateKey, false, address)
+}
+
+// cliNetworkName converts a [NetworkConfig] to the network name used by the Aptos CLI
+func cliNetworkName(network NetworkConfig) string {
+	switch network.Name {
+	case MainnetConfig.Name:
+		return "Mainnet"
+	case TestnetConfig.Name:
+		return "Testnet"
+	case DevnetConfig.Name:
+		return "Devnet"
+	case LocalnetConfig.Name:
+		return "Local"
+	default:
+		return "Custom"
+	}
+}
+
+// cliNetworkToNamedNetwork converts the Aptos CLI network name to the key in [NamedNetworks]
+func cliNetworkToNamedNetwork(network string) string {
+	switch strings.ToLower(network) {
+	case "local":
+		return LocalnetConfig.Name
+	default:
+		return strings.ToLower(network)
+	}
+}
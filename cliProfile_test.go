@@ -0,0 +1,97 @@
+package aptos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCliConfig = `---
+profiles:
+  default:
+    network: Devnet
+    private_key: "ed25519-priv-0xc5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5"
+    public_key: "ed25519-pub-0x3b6a3e93f67e2d1b1bd8bd8b9bee9d0ba5e0c8a2b8e0a5b3f2d7ac0f1ac0b0a1"
+    account: 00000000000000000000000000000000000000000000000000000000000000ab
+    rest_url: "https://fullnode.devnet.aptoslabs.com"
+    faucet_url: "https://faucet.devnet.aptoslabs.com"
+    derivation_path: "m/44'/637'/0'/0'/0'"
+  custom:
+    network: Custom
+    account: 0xcd
+    rest_url: "http://127.0.0.1:9000/"
+extra_key: kept
+`
+
+func TestCliConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".aptos", "config.yaml")
+
+	// Missing files are empty
+	config, err := LoadCliConfig(path)
+	assert.NoError(t, err)
+	assert.Empty(t, config.ProfileNames())
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	assert.NoError(t, os.WriteFile(path, []byte(testCliConfig), 0o600))
+	config, err = LoadCliConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"custom", "default"}, config.ProfileNames())
+
+	profile, err := config.Profile("default")
+	assert.NoError(t, err)
+	network, err := profile.NetworkConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, DevnetConfig.ChainId, network.ChainId)
+	assert.Equal(t, "https://fullnode.devnet.aptoslabs.com/v1", network.NodeUrl)
+
+	// The profile's address is kept, even though it doesn't match the key
+	signer, err := profile.Signer()
+	assert.NoError(t, err)
+	assert.Equal(t, AccountAddress{31: 0xab}, signer.Address)
+
+	custom, err := config.Profile("custom")
+	assert.NoError(t, err)
+	network, err = custom.NetworkConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:9000/v1", network.NodeUrl)
+	_, err = custom.Signer()
+	assert.Error(t, err)
+
+	_, err = config.Profile("missing")
+	assert.ErrorIs(t, err, ErrCliProfileNotFound)
+
+	// Adding a profile keeps the unknown fields when saved
+	account, err := NewSecp256k1Account()
+	assert.NoError(t, err)
+	assert.NoError(t, config.AddProfile("secp", TestnetConfig, account))
+	assert.NoError(t, config.Save(path))
+
+	config, err = LoadCliConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"custom", "default", "secp"}, config.ProfileNames())
+	assert.Equal(t, "kept", config.Extra["extra_key"])
+	assert.Equal(t, "m/44'/637'/0'/0'/0'", config.Profiles["default"].Extra["derivation_path"])
+
+	secp := config.Profiles["secp"]
+	assert.Equal(t, "Testnet", secp.Network)
+	network, err = secp.NetworkConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, TestnetConfig.NodeUrl, network.NodeUrl)
+	signer, err = secp.Signer()
+	assert.NoError(t, err)
+	assert.Equal(t, account.Address, signer.Address)
+	assert.Equal(t, account.AuthKey(), signer.AuthKey())
+
+	// The default profile is saved, and "default" unless set
+	assert.Equal(t, DefaultCliProfile, config.DefaultProfileName())
+	assert.ErrorIs(t, config.UseProfile("missing"), ErrCliProfileNotFound)
+	assert.NoError(t, config.UseProfile("secp"))
+	assert.NoError(t, config.Save(path))
+	config, err = LoadCliConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "secp", config.DefaultProfileName())
+	assert.NoError(t, config.UseProfile(DefaultCliProfile))
+	assert.Empty(t, config.DefaultProfile)
+}
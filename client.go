@@ -1,26 +1,31 @@
 package aptos
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/hasura/go-graphql-client"
 )
 
 // NetworkConfig a configuration for the Client and which network to use.  Use one of the preconfigured [LocalnetConfig], [DevnetConfig], [TestnetConfig], or [MainnetConfig] unless you have your own full node.
 //
-// Name, ChainId, IndexerUrl, FaucetUrl are not required.
+// Name, ChainId, IndexerUrl, FaucetUrl, PepperUrl, ProverUrl are not required.
 //
 // If ChainId is 0, the ChainId wil be fetched on-chain
 // If IndexerUrl or FaucetUrl are an empty string "", clients will not be made for them.
+// PepperUrl and ProverUrl are the keyless services used by [NewKeylessClient].
 type NetworkConfig struct {
 	Name       string
 	ChainId    uint8
 	NodeUrl    string
 	IndexerUrl string
 	FaucetUrl  string
+	PepperUrl  string
+	ProverUrl  string
 }
 
 // LocalnetConfig is for use with a localnet, created by the [Aptos CLI](https://aptos.dev/tools/aptos-cli)
@@ -42,6 +47,8 @@ var DevnetConfig = NetworkConfig{
 	Name:       "devnet",
 	NodeUrl:    "https://api.devnet.aptoslabs.com/v1",
 	IndexerUrl: "https://api.devnet.aptoslabs.com/v1/graphql",
+	PepperUrl:  "https://api.devnet.aptoslabs.com/keyless/pepper/v0",
+	ProverUrl:  "https://api.devnet.aptoslabs.com/keyless/prover/v0",
 	FaucetUrl:  "https://faucet.devnet.aptoslabs.com/",
 }
 
@@ -51,6 +58,8 @@ var TestnetConfig = NetworkConfig{
 	ChainId:    2,
 	NodeUrl:    "https://api.testnet.aptoslabs.com/v1",
 	IndexerUrl: "https://api.testnet.aptoslabs.com/v1/graphql",
+	PepperUrl:  "https://api.testnet.aptoslabs.com/keyless/pepper/v0",
+	ProverUrl:  "https://api.testnet.aptoslabs.com/keyless/prover/v0",
 	FaucetUrl:  "https://faucet.testnet.aptoslabs.com/",
 }
 
@@ -60,6 +69,8 @@ var MainnetConfig = NetworkConfig{
 	ChainId:    1,
 	NodeUrl:    "https://api.mainnet.aptoslabs.com/v1",
 	IndexerUrl: "https://api.mainnet.aptoslabs.com/v1/graphql",
+	PepperUrl:  "https://api.mainnet.aptoslabs.com/keyless/pepper/v0",
+	ProverUrl:  "https://api.mainnet.aptoslabs.com/keyless/prover/v0",
 	FaucetUrl:  "",
 }
 
@@ -133,9 +144,29 @@ type AptosRpcClient interface {
 	//	dataMap, _ := client.AccountResource(address, 1)
 	AccountResources(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error)
 
-	// AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
+	// AccountResourceBCS fetches a resource for an account as the raw Move struct BCS blob, looking inside resource
+	// groups if needed
+	AccountResourceBCS(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data []byte, err error)
+
+	// AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte,
+	// with resource groups flattened into their member resources
 	AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error)
 
+	// InfoBCS gets general information about the blockchain, read as BCS
+	InfoBCS() (info NodeInfo, err error)
+
+	// AccountBCS gets the 0x1::account::Account resource of an account, read as BCS
+	AccountBCS(address AccountAddress, ledgerVersion ...uint64) (account AccountResource, err error)
+
+	// AccountResourceBCSInto fetches a resource for an account as BCS, and decodes the Move struct into out
+	AccountResourceBCSInto(address AccountAddress, resourceType string, out bcs.Unmarshaler, ledgerVersion ...uint64) error
+
+	// TransactionByVersionBCS gets a committed transaction by version as BCS, with its events and state changes
+	TransactionByVersionBCS(version uint64) (data *TransactionOnChainData, err error)
+
+	// TransactionsBCS gets a page of committed transactions as BCS, with their events and state changes
+	TransactionsBCS(start *uint64, limit *uint64) (data []*TransactionOnChainData, err error)
+
 	// BlockByHeight fetches a block by height
 	//
 	//	block, _ := client.BlockByHeight(1, false)
@@ -158,13 +189,9 @@ type AptosRpcClient interface {
 	// The transaction may be pending or recently committed.
 	//
 	//	data, err := client.TransactionByHash("0xabcd")
-	//	if err != nil {
-	//		if httpErr, ok := err.(aptos.HttpError) {
-	//			if httpErr.StatusCode == 404 {
-	//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-	//			}
-	//		}
-	//	} else {
+	//	if aptos.HasErrorCode(err, api.ErrorCodeTransactionNotFound) {
+	//		// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
+	//	} else if err == nil {
 	//		if data["type"] == "pending_transaction" {
 	//			// known to local mempool, but not committed yet
 	//		}
@@ -175,12 +202,8 @@ type AptosRpcClient interface {
 	// committed to have a ledger version
 	//
 	//	data, err := client.TransactionByVersion("0xabcd")
-	//	if err != nil {
-	//		if httpErr, ok := err.(aptos.HttpError) {
-	//			if httpErr.StatusCode == 404 {
-	//				// if we're sure this has been submitted, the full node might not be caught up to this version yet
-	//			}
-	//		}
+	//	if aptos.HasErrorCode(err, api.ErrorCodeTransactionNotFound) {
+	//		// if we're sure this has been submitted, the full node might not be caught up to this version yet
 	//	}
 	TransactionByVersion(version uint64) (data *api.CommittedTransaction, err error)
 
@@ -233,6 +256,20 @@ type AptosRpcClient interface {
 		limit *uint64,
 	) ([]*api.Event, error)
 
+	// EventsByCreationNumber retrieves events by the creation number of an event handle's GUID for a given account.
+	//
+	// Arguments:
+	//   - account - The account address to get events for
+	//   - creationNumber - The creation number of the event handle's GUID
+	//   - start - The starting sequence number. nil for the first events
+	//   - limit - The number of events to return, 100 by default
+	EventsByCreationNumber(
+		account AccountAddress,
+		creationNumber uint64,
+		start *uint64,
+		limit *uint64,
+	) ([]*api.Event, error)
+
 	// SubmitTransaction Submits an already signed transaction to the blockchain
 	//
 	//	sender := NewEd25519Account()
@@ -437,8 +474,26 @@ type AptosIndexerClient interface {
 	// GetProcessorStatus returns the ledger version up to which the processor has processed
 	GetProcessorStatus(processorName string) (uint64, error)
 
+	// GetProcessorStatuses gets the status of every processor of the indexer, ordered by name
+	GetProcessorStatuses() ([]ProcessorStatus, error)
+
+	// WaitForIndexerOnVersion waits for the indexer to process the ledger version
+	WaitForIndexerOnVersion(version uint64, options ...any) error
+
 	// GetCoinBalances gets the balances of all coins associated with a given address
 	GetCoinBalances(address AccountAddress) ([]CoinBalance, error)
+
+	// GetOwnedDigitalAssets gets a page of the digital assets (NFTs) currently held by owner
+	GetOwnedDigitalAssets(owner AccountAddress, options OwnedDigitalAssetsOptions) ([]OwnedDigitalAsset, error)
+
+	// GetAccountCoinsData gets every non-zero coin and fungible asset balance of owner, with the metadata of each asset
+	GetAccountCoinsData(owner AccountAddress) ([]AccountCoinData, error)
+
+	// GetAccountTransactions gets a page of the transactions that touched address, with filters
+	GetAccountTransactions(address AccountAddress, options AccountTransactionsOptions) ([]AccountTransactionSummary, error)
+
+	// ResourceHistory gets every value a resource had between fromVersion and toVersion inclusive, oldest first
+	ResourceHistory(address AccountAddress, resourceType string, fromVersion uint64, toVersion uint64) ([]ResourceVersion, error)
 }
 
 // Client is a facade over the multiple types of underlying clients, as the user doesn't actually care where the data
@@ -456,8 +511,30 @@ type Client struct {
 }
 
 // NewClient Creates a new client with a specific network config that can be extended in the future
+//
+// Accepts options:
+//   - *http.Client: the HTTP client for every request
+//   - [http.RoundTripper]: the transport for every request e.g. for a proxy, custom TLS, or a test double, it replaces
+//     the transport of the HTTP client
+//   - [RequireSuccessfulSimulation]: simulate every transaction before submitting it
+//   - [ClientGasConfig]: the max gas, gas unit price, and expiration of every built transaction, unless overridden by
+//     the build options
+//   - [RetryPolicy]: retry failed requests, wrapping the transport of the HTTP client
+//   - [RateLimit]: limit the rate of requests, wrapping the transport of the HTTP client
+//   - [Middleware]: intercept every request, the first given is the outermost
+//   - [ApiKey]: the node provider API key, sent as a bearer token on every request
+//   - [ClientHeaders]: static headers sent on every request, including to the indexer
+//   - [Failover]: spread node requests over several fullnodes, routing around failed or lagging ones
 func NewClient(config NetworkConfig, options ...any) (client *Client, err error) {
 	var httpClient *http.Client = nil
+	var transport http.RoundTripper = nil
+	var simulationGate *RequireSuccessfulSimulation = nil
+	var gasDefaults []any = nil
+	var retryPolicy *RetryPolicy = nil
+	var rateLimit *RateLimit = nil
+	var middlewares []Middleware = nil
+	var headers map[string]string = nil
+	var failover *Failover = nil
 	for i, arg := range options {
 		switch value := arg.(type) {
 		case *http.Client:
@@ -466,6 +543,39 @@ func NewClient(config NetworkConfig, options ...any) (client *Client, err error)
 				return
 			}
 			httpClient = value
+		case http.RoundTripper:
+			if transport != nil {
+				err = fmt.Errorf("NewClient only accepts one http.RoundTripper")
+				return
+			}
+			transport = value
+		case RequireSuccessfulSimulation:
+			simulationGate = &value
+		case ClientGasConfig:
+			gasDefaults, err = value.options()
+			if err != nil {
+				return
+			}
+		case RetryPolicy:
+			retryPolicy = &value
+		case RateLimit:
+			rateLimit = &value
+		case Middleware:
+			middlewares = append(middlewares, value)
+		case ApiKey:
+			if headers == nil {
+				headers = make(map[string]string)
+			}
+			headers["Authorization"] = "Bearer " + string(value)
+		case Failover:
+			failover = &value
+		case ClientHeaders:
+			if headers == nil {
+				headers = make(map[string]string)
+			}
+			for key, header := range value {
+				headers[key] = header
+			}
 		default:
 			err = fmt.Errorf("NewClient arg %d bad type %T", i+1, arg)
 			return
@@ -480,6 +590,41 @@ func NewClient(config NetworkConfig, options ...any) (client *Client, err error)
 	if err != nil {
 		return nil, err
 	}
+	nodeClient.simulationGate = simulationGate
+	nodeClient.gasDefaults = gasDefaults
+	if transport != nil || retryPolicy != nil || rateLimit != nil || len(middlewares) > 0 || len(headers) > 0 || failover != nil {
+		// The caller's HTTP client isn't changed, and each retry waits for the rate limit and goes through the middleware
+		wrapped := *nodeClient.client
+		if transport != nil {
+			wrapped.Transport = transport
+		}
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			wrapped.Transport = middlewares[i].Transport(wrapped.Transport)
+		}
+		if failover != nil {
+			// Middleware sees the node each request is sent to
+			wrapped.Transport, err = failover.transport(wrapped.Transport, nodeClient.baseUrl)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(headers) > 0 {
+			// Middleware sees the headers e.g. to sign them
+			inner := wrapped.Transport
+			if inner == nil {
+				inner = http.DefaultTransport
+			}
+			wrapped.Transport = &headerTransport{inner: inner, headers: headers}
+		}
+		if rateLimit != nil {
+			wrapped.Transport = rateLimit.Transport(wrapped.Transport)
+		}
+		if retryPolicy != nil {
+			wrapped.Transport = retryPolicy.Transport(wrapped.Transport)
+		}
+		nodeClient.client = &wrapped
+	}
+
 	// Indexer may not be present
 	var indexerClient *IndexerClient = nil
 	if config.IndexerUrl != "" {
@@ -515,7 +660,7 @@ func (client *Client) SetTimeout(timeout time.Duration) {
 	client.nodeClient.SetTimeout(timeout)
 }
 
-// SetHeader sets the header for all future requests
+// SetHeader sets the header for all future node and faucet requests, see [ClientHeaders] to include the indexer
 //
 //	client.SetHeader("Authorization", "Bearer abcde")
 func (client *Client) SetHeader(key string, value string) {
@@ -529,6 +674,35 @@ func (client *Client) RemoveHeader(key string) {
 	client.nodeClient.RemoveHeader(key)
 }
 
+// WithContext returns a client sharing the connection and settings of this one, which sends every node request with
+// ctx, so requests are cancelled when ctx is done.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+//	defer cancel()
+//	info, err := client.WithContext(ctx).Account(address)
+func (client *Client) WithContext(ctx context.Context) *Client {
+	return &Client{
+		nodeClient:    client.nodeClient.WithContext(ctx),
+		faucetClient:  client.faucetClient,
+		indexerClient: client.indexerClient,
+	}
+}
+
+// AtLedgerVersion returns a client sharing the connection and settings of this one, which reads state at version
+// unless a call is given its own ledger version.  This covers accounts, resources, modules, tables, and view functions,
+// including the balance and module clients built on them, so several reads see the same state.
+//
+//	pinned := client.AtLedgerVersion(version)
+//	balance, err := pinned.AccountAPTBalance(address)
+//	account, err := pinned.Account(address)
+func (client *Client) AtLedgerVersion(version uint64) *Client {
+	return &Client{
+		nodeClient:    client.nodeClient.AtLedgerVersion(version),
+		faucetClient:  client.faucetClient,
+		indexerClient: client.indexerClient,
+	}
+}
+
 // Info Retrieves the node info about the network and it's current state
 func (client *Client) Info() (info NodeInfo, err error) {
 	return client.nodeClient.Info()
@@ -539,6 +713,14 @@ func (client *Client) Account(address AccountAddress, ledgerVersion ...uint64) (
 	return client.nodeClient.Account(address, ledgerVersion...)
 }
 
+// AccountWithLedgerInfo is [Client.Account], which also returns the ledger state the account was read at
+//
+//	account, ledger, err := client.AccountWithLedgerInfo(address)
+//	// account.SequenceNumber() is as of ledger.LedgerVersion
+func (client *Client) AccountWithLedgerInfo(address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, ledger LedgerInfo, err error) {
+	return client.nodeClient.AccountWithLedgerInfo(address, ledgerVersion...)
+}
+
 // AccountResource Retrieves a single resource given its struct name.
 //
 //	address := AccountOne
@@ -552,6 +734,20 @@ func (client *Client) AccountResource(address AccountAddress, resourceType strin
 	return client.nodeClient.AccountResource(address, resourceType, ledgerVersion...)
 }
 
+// AccountResourceWithLedgerInfo is [Client.AccountResource], which also returns the ledger state the resource was read
+// at
+func (client *Client) AccountResourceWithLedgerInfo(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, ledger LedgerInfo, err error) {
+	return client.nodeClient.AccountResourceWithLedgerInfo(address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceInto fetches a resource and decodes its data into out, e.g. one of the framework resource structs
+//
+//	store := &CoinStoreResource{}
+//	err := client.AccountResourceInto(address, AptosCoinStoreResourceType, store)
+func (client *Client) AccountResourceInto(address AccountAddress, resourceType string, out any, ledgerVersion ...uint64) (err error) {
+	return client.nodeClient.AccountResourceInto(address, resourceType, out, ledgerVersion...)
+}
+
 // AccountResources fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
 // For fetching raw Move structs as BCS, See #AccountResourcesBCS
 //
@@ -566,7 +762,22 @@ func (client *Client) AccountResources(address AccountAddress, ledgerVersion ...
 	return client.nodeClient.AccountResources(address, ledgerVersion...)
 }
 
-// AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
+// AccountResourcesWithLedgerInfo is [Client.AccountResources], which also returns the ledger state the resources were
+// read at
+func (client *Client) AccountResourcesWithLedgerInfo(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, ledger LedgerInfo, err error) {
+	return client.nodeClient.AccountResourcesWithLedgerInfo(address, ledgerVersion...)
+}
+
+// AccountResourceBCS fetches a resource for an account as the raw Move struct BCS blob, looking inside resource groups
+// if needed
+//
+//	data, _ := client.AccountResourceBCS(objectAddress, FungibleAssetMetadataResourceType)
+func (client *Client) AccountResourceBCS(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data []byte, err error) {
+	return client.nodeClient.AccountResourceBCS(address, resourceType, ledgerVersion...)
+}
+
+// AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte,
+// with resource groups flattened into their member resources
 func (client *Client) AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error) {
 	return client.nodeClient.AccountResourcesBCS(address, ledgerVersion...)
 }
@@ -597,13 +808,9 @@ func (client *Client) BlockByVersion(ledgerVersion uint64, withTransactions bool
 // The transaction may be pending or recently committed.
 //
 //	data, err := client.TransactionByHash("0xabcd")
-//	if err != nil {
-//		if httpErr, ok := err.(aptos.HttpError) {
-//			if httpErr.StatusCode == 404 {
-//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-//			}
-//		}
-//	} else {
+//	if aptos.HasErrorCode(err, api.ErrorCodeTransactionNotFound) {
+//		// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
+//	} else if err == nil {
 //		if data["type"] == "pending_transaction" {
 //			// known to local mempool, but not committed yet
 //		}
@@ -612,21 +819,67 @@ func (client *Client) TransactionByHash(txnHash string) (data *api.Transaction,
 	return client.nodeClient.TransactionByHash(txnHash)
 }
 
+// TransactionByHashWithLedgerInfo is [Client.TransactionByHash], which also returns the ledger state the transaction was
+// looked up at
+func (client *Client) TransactionByHashWithLedgerInfo(txnHash string) (data *api.Transaction, ledger LedgerInfo, err error) {
+	return client.nodeClient.TransactionByHashWithLedgerInfo(txnHash)
+}
+
 // TransactionByVersion gets info on a transaction from its LedgerVersion.  It must have been
 // committed to have a ledger version
 //
 //	data, err := client.TransactionByVersion("0xabcd")
-//	if err != nil {
-//		if httpErr, ok := err.(aptos.HttpError) {
-//			if httpErr.StatusCode == 404 {
-//				// if we're sure this has been submitted, the full node might not be caught up to this version yet
-//			}
-//		}
+//	if aptos.HasErrorCode(err, api.ErrorCodeTransactionNotFound) {
+//		// if we're sure this has been submitted, the full node might not be caught up to this version yet
 //	}
 func (client *Client) TransactionByVersion(version uint64) (data *api.CommittedTransaction, err error) {
 	return client.nodeClient.TransactionByVersion(version)
 }
 
+// TransactionByVersionWithLedgerInfo is [Client.TransactionByVersion], which also returns the ledger state the
+// transaction was looked up at
+func (client *Client) TransactionByVersionWithLedgerInfo(version uint64) (data *api.CommittedTransaction, ledger LedgerInfo, err error) {
+	return client.nodeClient.TransactionByVersionWithLedgerInfo(version)
+}
+
+// InfoBCS gets general information about the blockchain, as [Client.Info] but read as BCS
+func (client *Client) InfoBCS() (info NodeInfo, err error) {
+	return client.nodeClient.InfoBCS()
+}
+
+// AccountBCS gets the 0x1::account::Account resource of an account as BCS
+//
+//	account, err := client.AccountBCS(address)
+//	sequenceNumber := account.SequenceNumber
+func (client *Client) AccountBCS(address AccountAddress, ledgerVersion ...uint64) (account AccountResource, err error) {
+	return client.nodeClient.AccountBCS(address, ledgerVersion...)
+}
+
+// AccountResourceBCSInto fetches a resource for an account as BCS, and decodes the Move struct into out
+//
+//	account := &AccountResource{}
+//	err := client.AccountResourceBCSInto(address, AccountResourceType, account)
+func (client *Client) AccountResourceBCSInto(address AccountAddress, resourceType string, out bcs.Unmarshaler, ledgerVersion ...uint64) error {
+	return client.nodeClient.AccountResourceBCSInto(address, resourceType, out, ledgerVersion...)
+}
+
+// TransactionByVersionBCS gets a committed transaction by version as BCS, with its events and state changes.
+// Genesis and validator transactions can't be decoded, and return [ErrUnsupportedTransactionData].
+//
+//	txn, err := client.TransactionByVersionBCS(version)
+//	if err == nil && txn.UserTransaction != nil && !txn.Info.Status.Success() {
+//		// the user transaction failed
+//	}
+func (client *Client) TransactionByVersionBCS(version uint64) (data *TransactionOnChainData, err error) {
+	return client.nodeClient.TransactionByVersionBCS(version)
+}
+
+// TransactionsBCS gets a page of committed transactions as BCS, with their events and state changes, see
+// [NodeClient.TransactionsBCS]
+func (client *Client) TransactionsBCS(start *uint64, limit *uint64) (data []*TransactionOnChainData, err error) {
+	return client.nodeClient.TransactionsBCS(start, limit)
+}
+
 // PollForTransactions Waits up to 10 seconds for transactions to be done, polling at 10Hz
 // Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
 //
@@ -644,10 +897,29 @@ func (client *Client) PollForTransactions(txnHashes []string, options ...any) er
 // WaitForTransaction Do a long-GET for one transaction and wait for it to complete
 //
 //	data, err := client.WaitForTransaction("0x1234")
+//
+// Accepts the options of [NodeClient.WaitForTransaction] e.g. to back off and fail on execution errors
+//
+//	data, err := client.WaitForTransaction("0x1234", PollTimeout(time.Minute), PollBackoff(2), MaxPollPeriod(5*time.Second), ErrorOnFailure(true))
+//	if errors.Is(err, ErrTransactionFailed) {
+//		// data.VmStatus is why
+//	}
 func (client *Client) WaitForTransaction(txnHash string, options ...any) (data *api.UserTransaction, err error) {
 	return client.nodeClient.WaitForTransaction(txnHash, options...)
 }
 
+// WaitForTransactions waits on many transactions in parallel, see [NodeClient.WaitForTransactions]
+//
+//	results, err := client.WaitForTransactions(hashes, WaitConcurrency(16), ErrorOnFailure(true))
+//	for _, result := range results {
+//		if result.Err != nil {
+//			// result.Hash didn't commit successfully
+//		}
+//	}
+func (client *Client) WaitForTransactions(hashes []string, options ...any) ([]TransactionWaitResult, error) {
+	return client.nodeClient.WaitForTransactions(hashes, options...)
+}
+
 // Transactions Get recent transactions.
 // Start is a version number. Nil for most recent transactions.
 // Limit is a number of transactions to return. 'about a hundred' by default.
@@ -658,6 +930,76 @@ func (client *Client) Transactions(start *uint64, limit *uint64) (data []*api.Co
 	return client.nodeClient.Transactions(start, limit)
 }
 
+// TransactionRange creates an iterator over the committed transactions from version start up to, but not including,
+// version end, see [TransactionRangeIterator]
+//
+//	it := client.TransactionRange(1000, 2000)
+//	for it.Next() {
+//		txn := it.Transaction()
+//	}
+//	err := it.Err()
+func (client *Client) TransactionRange(start uint64, end uint64) *TransactionRangeIterator {
+	return client.nodeClient.TransactionRange(start, end)
+}
+
+// TransactionsByVersionRange fetches the committed transactions from version start up to, but not including, version
+// end, paging and retrying as needed
+func (client *Client) TransactionsByVersionRange(start uint64, end uint64) ([]*api.CommittedTransaction, error) {
+	return client.nodeClient.TransactionsByVersionRange(start, end)
+}
+
+// Subscribe starts building an [EventSubscription], which polls the node for transactions by default
+//
+//	err := client.Subscribe().
+//		EventType("0x4::collection::Mint").
+//		Handler(func(event SubscribedEvent) error {
+//			return nil
+//		}).
+//		Run(ctx)
+func (client *Client) Subscribe() *EventSubscription {
+	return NewEventSubscription(&PollingEventBackend{Client: client.nodeClient})
+}
+
+// ChainScanner starts building a [ChainScanner] for deposits into watched accounts, which polls the node for
+// transactions by default
+//
+//	scanner := client.ChainScanner().Watch(depositAddress)
+//	deposits, errs := scanner.Channel(ctx, 100)
+func (client *Client) ChainScanner() *ChainScanner {
+	return NewChainScanner(&PollingEventBackend{Client: client.nodeClient})
+}
+
+// SubscribeEvents polls the node for new events matching the filter, and delivers them in order on the returned
+// channel, see [EventSubscription.Channel].  Each event is delivered once, even across polls.
+//
+// If the filter names event handles, only those handles' events are fetched, by sequence number, and
+// [SubscribedEvent.TransactionHash], [SubscribedEvent.Sender] and [SubscribedEvent.Index] aren't set.  Otherwise, every
+// transaction is scanned.
+//
+//	events, errs := client.SubscribeEvents(ctx, EventFilter{EventTypes: []string{"0x4::collection::Mint"}})
+//	for event := range events {
+//		mint := MintEvent{}
+//		err := event.Decode(&mint)
+//	}
+//	err := <-errs
+func (client *Client) SubscribeEvents(ctx context.Context, filter EventFilter) (<-chan SubscribedEvent, <-chan error) {
+	subscription := client.Subscribe()
+	for _, address := range filter.Addresses {
+		subscription.Address(address)
+	}
+	for _, eventType := range filter.EventTypes {
+		subscription.EventType(eventType)
+	}
+	for _, handle := range filter.EventHandles {
+		subscription.EventHandle(handle)
+	}
+	if filter.FromVersion != nil {
+		subscription.FromVersion(*filter.FromVersion)
+	}
+	subscription.handlePoller = &eventHandlePoller{client: client.nodeClient}
+	return subscription.Channel(ctx, filter.Buffer)
+}
+
 // AccountTransactions Get transactions associated with an account.
 // Start is a version number. Nil for most recent transactions.
 // Limit is a number of transactions to return. 'about a hundred' by default.
@@ -678,6 +1020,16 @@ func (client *Client) EventsByHandle(account AccountAddress, eventHandle string,
 	return client.nodeClient.EventsByHandle(account, eventHandle, fieldName, start, limit)
 }
 
+// EventsByCreationNumber Get events by the creation number of an event handle's GUID for an account.
+// Start is a sequence number. Nil for the first events.
+// Limit is a number of events to return, 100 by default.
+//
+//	client.EventsByCreationNumber(AccountOne, 2, 0, 2)   // Returns the first 2 events of the handle
+//	client.EventsByCreationNumber(AccountOne, 2, 1, 100) // Returns 100 events
+func (client *Client) EventsByCreationNumber(account AccountAddress, creationNumber uint64, start *uint64, limit *uint64) ([]*api.Event, error) {
+	return client.nodeClient.EventsByCreationNumber(account, creationNumber, start, limit)
+}
+
 // SubmitTransaction Submits an already signed transaction to the blockchain
 //
 //	sender := NewEd25519Account()
@@ -764,7 +1116,12 @@ func (client *Client) GetChainId() (chainId uint8, err error) {
 }
 
 // Fund Uses the faucet to fund an address, only applies to non-production networks
+//
+// Returns [ErrNoFaucet] if the network has no faucet configured
 func (client *Client) Fund(address AccountAddress, amount uint64) error {
+	if client.faucetClient == nil {
+		return ErrNoFaucet
+	}
 	return client.faucetClient.Fund(address, amount)
 }
 
@@ -812,9 +1169,19 @@ func (client *Client) BuildTransactionMultiAgent(sender AccountAddress, payload
 	return client.nodeClient.BuildTransactionMultiAgent(sender, payload, options...)
 }
 
+// TransactionBuilder starts building a raw transaction with chained options, see [TransactionBuilder]
+//
+//	rawTxn, err := client.TransactionBuilder().Sender(sender.AccountAddress()).Payload(payload).MaxGas(20_000).Build()
+func (client *Client) TransactionBuilder() *TransactionBuilder {
+	return NewTransactionBuilder(client)
+}
+
 // BuildSignAndSubmitTransaction Convenience function to do all three in one
 // for more configuration, please use them separately
 //
+// The max gas, gas unit price, and expiration default to the client's [ClientGasConfig], and can be overridden with the
+// same options as [Client.BuildTransaction].
+//
 //	sender := NewEd25519Account()
 //	txnPayload := TransactionPayload{
 //		Payload: &EntryFunction{
@@ -853,6 +1220,11 @@ func (client *Client) View(payload *ViewPayload, ledgerVersion ...uint64) (vals
 	return client.nodeClient.View(payload, ledgerVersion...)
 }
 
+// ViewWithLedgerInfo is [Client.View], which also returns the ledger state the view function ran at
+func (client *Client) ViewWithLedgerInfo(payload *ViewPayload, ledgerVersion ...uint64) (vals []any, ledger LedgerInfo, err error) {
+	return client.nodeClient.ViewWithLedgerInfo(payload, ledgerVersion...)
+}
+
 // EstimateGasPrice Retrieves the gas estimate from the network.
 func (client *Client) EstimateGasPrice() (info EstimateGasInfo, err error) {
 	return client.nodeClient.EstimateGasPrice()
@@ -863,6 +1235,15 @@ func (client *Client) AccountAPTBalance(address AccountAddress, ledgerVersion ..
 	return client.nodeClient.AccountAPTBalance(address, ledgerVersion...)
 }
 
+// AccountAPTBalanceWithLedgerInfo is [Client.AccountAPTBalance], which also returns the ledger state the balance was
+// read at
+//
+//	balance, ledger, err := client.AccountAPTBalanceWithLedgerInfo(address)
+//	// balance is as of ledger.LedgerVersion
+func (client *Client) AccountAPTBalanceWithLedgerInfo(address AccountAddress, ledgerVersion ...uint64) (uint64, LedgerInfo, error) {
+	return client.nodeClient.AccountAPTBalanceWithLedgerInfo(address, ledgerVersion...)
+}
+
 // QueryIndexer queries the indexer using GraphQL to fill the `query` struct with data.  See examples in the indexer client on how to make queries
 //
 //	var out []CoinBalance
@@ -889,17 +1270,50 @@ func (client *Client) AccountAPTBalance(address AccountAddress, ledgerVersion ..
 //	}
 //
 //	return out, nil
+//
+// Returns [ErrNoIndexer] if the network has no indexer configured
 func (client *Client) QueryIndexer(query any, variables map[string]any, options ...graphql.Option) error {
-	return client.indexerClient.Query(query, variables, options...)
+	return client.queryIndexer(query, variables, options...)
 }
 
 // GetProcessorStatus returns the ledger version up to which the processor has processed
+//
+// Returns [ErrNoIndexer] if the network has no indexer configured
 func (client *Client) GetProcessorStatus(processorName string) (uint64, error) {
+	if client.indexerClient == nil {
+		return 0, ErrNoIndexer
+	}
 	return client.indexerClient.GetProcessorStatus(processorName)
 }
 
+// GetProcessorStatuses gets the status of every processor of the indexer, ordered by name
+//
+// Returns [ErrNoIndexer] if the network has no indexer configured
+func (client *Client) GetProcessorStatuses() ([]ProcessorStatus, error) {
+	if client.indexerClient == nil {
+		return nil, ErrNoIndexer
+	}
+	return client.indexerClient.GetProcessorStatuses()
+}
+
+// WaitForIndexerOnVersion waits for the indexer to process the ledger version, see [IndexerClient.WaitForIndexerOnVersion]
+//
+// Returns [ErrNoIndexer] if the network has no indexer configured
+func (client *Client) WaitForIndexerOnVersion(version uint64, options ...any) error {
+	if client.indexerClient == nil {
+		return ErrNoIndexer
+	}
+	return client.indexerClient.WaitForIndexerOnVersion(version, options...)
+}
+
 // GetCoinBalances gets the balances of all coins associated with a given address
+//
+// If the network has no indexer configured, this falls back to scanning the account's resources on the node
 func (client *Client) GetCoinBalances(address AccountAddress) ([]CoinBalance, error) {
+	if client.indexerClient == nil {
+		warnIndexerFallback("GetCoinBalances")
+		return client.nodeClient.coinBalancesFromNode(address)
+	}
 	return client.indexerClient.GetCoinBalances(address)
 }
 
@@ -908,10 +1322,54 @@ func (client *Client) NodeAPIHealthCheck(durationSecs ...uint64) (api.HealthChec
 	return client.nodeClient.NodeAPIHealthCheck(durationSecs...)
 }
 
+// NodeHealth checks if the node's ledger is within durationSecs of the current time, the node default if not given.
+// A stale node isn't an error, see [NodeHealth.Healthy].
+//
+//	health, err := client.NodeHealth(10)
+//	if err != nil || !health.Healthy {
+//		// take the node out of rotation
+//	}
+func (client *Client) NodeHealth(durationSecs ...uint64) (NodeHealth, error) {
+	return client.nodeClient.NodeHealth(durationSecs...)
+}
+
+// AccountModule fetches a module published at an address, with its bytecode and ABI e.g. for its functions and structs
+//
+//	module, err := client.AccountModule(AccountOne, "coin")
+//	transfer := module.Abi.Function("transfer")
 func (client *Client) AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (data *api.MoveBytecode, err error) {
 	return client.nodeClient.AccountModule(address, moduleName, ledgerVersion...)
 }
 
+// AccountModuleWithLedgerInfo is [Client.AccountModule], which also returns the ledger state the module was read at
+func (client *Client) AccountModuleWithLedgerInfo(address AccountAddress, moduleName string, ledgerVersion ...uint64) (data *api.MoveBytecode, ledger LedgerInfo, err error) {
+	return client.nodeClient.AccountModuleWithLedgerInfo(address, moduleName, ledgerVersion...)
+}
+
+// AccountModules fetches all modules published at an address, with their ABIs
+func (client *Client) AccountModules(address AccountAddress, ledgerVersion ...uint64) (data []*api.MoveBytecode, err error) {
+	return client.nodeClient.AccountModules(address, ledgerVersion...)
+}
+
+// EntryFunctionWithArgs builds an [EntryFunction] using the on-chain ABI to convert args, see [EntryFunctionFromAbi]
 func (client *Client) EntryFunctionWithArgs(address AccountAddress, moduleName string, functionName string, typeArgs []any, args []any) (entry *EntryFunction, err error) {
 	return client.nodeClient.EntryFunctionWithArgs(address, moduleName, functionName, typeArgs, args)
 }
+
+// EntryFunctionFromABI builds an [EntryFunction] from arguments as they would appear in JSON, using the on-chain ABI
+// to check the number and types of arguments and to encode them.
+//
+// Args may be decoded JSON values (string, float64, [json.Number], bool, []any, nil) or [json.RawMessage], along with
+// anything [EntryFunctionFromAbi] accepts.  Numbers are best passed as strings or [json.Number], as float64 loses
+// precision above 2^53.  Options may be given as nil, the inner value, or the API's {"vec": [...]} form.
+//
+//	entry, err := client.EntryFunctionFromABI(ModuleId{Address: AccountOne, Name: "aptos_account"}, "transfer_coins",
+//		[]any{"0x1::aptos_coin::AptosCoin"}, []any{"0xb0b", "100"})
+func (client *Client) EntryFunctionFromABI(module ModuleId, function string, typeArgs []any, jsonArgs []any) (entry *EntryFunction, err error) {
+	return client.nodeClient.EntryFunctionFromABI(module, function, typeArgs, jsonArgs)
+}
+
+// ModuleAbi fetches the ABI of a module, caching it for later calls
+func (client *Client) ModuleAbi(module ModuleId) (abi *api.MoveModule, err error) {
+	return client.nodeClient.ModuleAbi(module)
+}
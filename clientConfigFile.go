@@ -0,0 +1,311 @@
+package aptos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultClientTimeout is the HTTP timeout of a client built from a [ClientConfig] with no timeout
+const DefaultClientTimeout = 60 * time.Second
+
+// ConfigDuration is a duration in a config file, written as a Go duration string e.g. "1.5s" or "250ms"
+type ConfigDuration time.Duration
+
+// UnmarshalJSON parses a duration string
+func (d *ConfigDuration) UnmarshalJSON(b []byte) error {
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return fmt.Errorf("duration must be a string e.g. \"1.5s\": %w", err)
+	}
+	return d.parse(str)
+}
+
+// MarshalJSON writes the duration as a string
+func (d ConfigDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalYAML parses a duration string
+func (d *ConfigDuration) UnmarshalYAML(value *yaml.Node) error {
+	return d.parse(value.Value)
+}
+
+// MarshalYAML writes the duration as a string
+func (d ConfigDuration) MarshalYAML() (any, error) {
+	return time.Duration(d).String(), nil
+}
+
+func (d *ConfigDuration) parse(str string) error {
+	duration, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*d = ConfigDuration(duration)
+	return nil
+}
+
+// ClientRetryConfig configures retries of requests by a client built from a [ClientConfig], see [RetryPolicy].  Network
+// errors, timeouts, 429 and 5xx responses are retried, and only for requests whose body can be sent again.  Transaction
+// submissions and faucet funding aren't retried unless non_idempotent is set.
+type ClientRetryConfig struct {
+	Attempts   int            `yaml:"attempts,omitempty" json:"attempts,omitempty"`       // Attempts is the total number of tries, including the first
+	Backoff    ConfigDuration `yaml:"backoff,omitempty" json:"backoff,omitempty"`         // Backoff is the wait before the first retry, it doubles after each retry
+	MaxBackoff ConfigDuration `yaml:"max_backoff,omitempty" json:"max_backoff,omitempty"` // MaxBackoff caps the wait between retries, if set
+	Jitter     float64        `yaml:"jitter,omitempty" json:"jitter,omitempty"`           // Jitter is the fraction of each wait that's randomized, from 0 to 1
+
+	NonIdempotent bool `yaml:"non_idempotent,omitempty" json:"non_idempotent,omitempty"` // NonIdempotent retries requests which may have taken effect, see [RetryPolicy.RetryNonIdempotent]
+}
+
+// policy converts the config to a [RetryPolicy]
+func (config *ClientRetryConfig) policy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: config.Attempts,
+		BaseBackoff: time.Duration(config.Backoff),
+		MaxBackoff:  time.Duration(config.MaxBackoff),
+		Jitter:      config.Jitter,
+
+		RetryNonIdempotent: config.NonIdempotent,
+	}
+}
+
+// ClientConfig is the declarative configuration of a [Client], loaded from a YAML or JSON file by [LoadClientConfig]:
+//
+//	network: testnet
+//	api_key: ${APTOS_API_KEY}
+//	timeout: 10s
+//	retry:
+//	  attempts: 3
+//	  backoff: 200ms
+//	rate_limit:
+//	  requests_per_second: 10
+//	proxy: http://proxy.internal:3128
+//	gas:
+//	  max_gas_amount: 20000
+//	  priority: prioritized
+//
+// Endpoints default to those of the named network, and any set in the file override them.  The API key and header
+// values may reference environment variables as $NAME or ${NAME}, so secrets don't have to be in the file.
+type ClientConfig struct {
+	Network    string `yaml:"network,omitempty" json:"network,omitempty"`         // Network is a name in [NamedNetworks] to take defaults from e.g. testnet
+	ChainId    uint8  `yaml:"chain_id,omitempty" json:"chain_id,omitempty"`       // ChainId of the network, fetched from the node if not set
+	NodeUrl    string `yaml:"node_url,omitempty" json:"node_url,omitempty"`       // NodeUrl is the fullnode API e.g. https://api.testnet.aptoslabs.com/v1
+	IndexerUrl string `yaml:"indexer_url,omitempty" json:"indexer_url,omitempty"` // IndexerUrl is the indexer GraphQL API
+	FaucetUrl  string `yaml:"faucet_url,omitempty" json:"faucet_url,omitempty"`   // FaucetUrl is the faucet API
+	PepperUrl  string `yaml:"pepper_url,omitempty" json:"pepper_url,omitempty"`   // PepperUrl is the keyless pepper service
+	ProverUrl  string `yaml:"prover_url,omitempty" json:"prover_url,omitempty"`   // ProverUrl is the keyless prover service
+
+	ApiKey  string            `yaml:"api_key,omitempty" json:"api_key,omitempty"` // ApiKey is sent as a bearer token on every request
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"` // Headers are sent on every request
+
+	Timeout   ConfigDuration     `yaml:"timeout,omitempty" json:"timeout,omitempty"`       // Timeout of each request, defaults to [DefaultClientTimeout]
+	Retry     *ClientRetryConfig `yaml:"retry,omitempty" json:"retry,omitempty"`           // Retry failed requests, no retries if not set
+	RateLimit *RateLimit         `yaml:"rate_limit,omitempty" json:"rate_limit,omitempty"` // RateLimit of requests, no limit if not set
+	Proxy     string             `yaml:"proxy,omitempty" json:"proxy,omitempty"`           // Proxy URL for every request, the HTTP_PROXY environment variables are used if not set
+
+	Gas *ClientGasConfig `yaml:"gas,omitempty" json:"gas,omitempty"` // Gas of built transactions, the build defaults if not set
+}
+
+// ClientGasConfig is the gas and expiration policy of a client, for every transaction it builds, including with
+// [Client.BuildSignAndSubmitTransaction].  Options passed to [Client.BuildTransaction] override it, and a [GasPriority]
+// passed there estimates the price even if GasUnitPrice is set here.
+//
+//	client, err := NewClient(MainnetConfig, ClientGasConfig{
+//		MaxGasAmount: 20_000,
+//		Priority:     "prioritized",
+//		Expiration:   ConfigDuration(time.Minute),
+//	})
+type ClientGasConfig struct {
+	MaxGasAmount uint64 `yaml:"max_gas_amount,omitempty" json:"max_gas_amount,omitempty"` // MaxGasAmount of each transaction, defaults to [DefaultMaxGasAmount]
+	GasUnitPrice uint64 `yaml:"gas_unit_price,omitempty" json:"gas_unit_price,omitempty"` // GasUnitPrice of each transaction, estimated if not set
+	Priority     string `yaml:"priority,omitempty" json:"priority,omitempty"`             // Priority of the estimate used without a GasUnitPrice, one of normal, prioritized, or deprioritized
+
+	Expiration ConfigDuration `yaml:"expiration,omitempty" json:"expiration,omitempty"` // Expiration of each transaction from when it's built, in whole seconds, defaults to [DefaultExpirationSeconds]
+}
+
+// options converts the gas policy to [Client.BuildTransaction] options
+func (gas ClientGasConfig) options() ([]any, error) {
+	options := make([]any, 0, 4)
+	if gas.MaxGasAmount != 0 {
+		options = append(options, MaxGasAmount(gas.MaxGasAmount))
+	}
+	if gas.GasUnitPrice != 0 {
+		options = append(options, GasUnitPrice(gas.GasUnitPrice))
+	}
+	switch strings.ToLower(gas.Priority) {
+	case "", "normal":
+	case "prioritized":
+		options = append(options, GasPriorityPrioritized)
+	case "deprioritized":
+		options = append(options, GasPriorityDeprioritized)
+	default:
+		return nil, fmt.Errorf("unknown gas priority %q", gas.Priority)
+	}
+	if gas.Expiration != 0 {
+		expiration := time.Duration(gas.Expiration)
+		if expiration < time.Second {
+			return nil, fmt.Errorf("gas expiration %s must be at least 1s", expiration)
+		}
+		options = append(options, ExpirationSeconds(expiration/time.Second))
+	}
+	return options, nil
+}
+
+// LoadClientConfig reads a [ClientConfig] from a file.  Files ending in .json are read as JSON, anything else as YAML.
+func LoadClientConfig(path string) (*ClientConfig, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &ClientConfig{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(strings.NewReader(string(contents)))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(config)
+	} else {
+		decoder := yaml.NewDecoder(strings.NewReader(string(contents)))
+		decoder.KnownFields(true)
+		err = decoder.Decode(config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// NewClientFromConfigFile creates a [Client] configured by a YAML or JSON file, see [ClientConfig]
+//
+//	client, err := aptos.NewClientFromConfigFile("aptos.yaml")
+func NewClientFromConfigFile(path string) (*Client, error) {
+	config, err := LoadClientConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return config.NewClient()
+}
+
+// NewClient creates a [Client] from the config, options are passed to [NewClient].  An [http.RoundTripper] option is
+// the base transport, under the config's retries, rate limit, and headers.
+func (config *ClientConfig) NewClient(options ...any) (*Client, error) {
+	network, err := config.NetworkConfig()
+	if err != nil {
+		return nil, err
+	}
+	var base http.RoundTripper
+	remaining := make([]any, 0, len(options))
+	for _, option := range options {
+		if transport, ok := option.(http.RoundTripper); ok && base == nil {
+			base = transport
+		} else {
+			remaining = append(remaining, option)
+		}
+	}
+	options = remaining
+	httpClient, err := config.httpClient(base)
+	if err != nil {
+		return nil, err
+	}
+	options = append([]any{httpClient}, options...)
+	if config.Gas != nil {
+		options = append(options, *config.Gas)
+	}
+	return NewClient(network, options...)
+}
+
+// NetworkConfig resolves the endpoints of the config, starting from the named network if set
+func (config *ClientConfig) NetworkConfig() (NetworkConfig, error) {
+	network := NetworkConfig{}
+	if config.Network != "" {
+		named, ok := NamedNetworks[strings.ToLower(config.Network)]
+		if !ok {
+			return NetworkConfig{}, fmt.Errorf("unknown network %q", config.Network)
+		}
+		network = named
+	}
+	override := func(field *string, value string) {
+		if value != "" {
+			*field = value
+		}
+	}
+	if config.ChainId != 0 {
+		network.ChainId = config.ChainId
+	}
+	override(&network.NodeUrl, config.NodeUrl)
+	override(&network.IndexerUrl, config.IndexerUrl)
+	override(&network.FaucetUrl, config.FaucetUrl)
+	override(&network.PepperUrl, config.PepperUrl)
+	override(&network.ProverUrl, config.ProverUrl)
+	if network.NodeUrl == "" {
+		return NetworkConfig{}, errors.New("client config needs a network or a node_url")
+	}
+	return network, nil
+}
+
+// HttpClient builds the HTTP client for the config's timeout, retries, rate limit, proxy, and headers.  It is shared by
+// the node, indexer, and faucet, so they all get the same settings.
+func (config *ClientConfig) HttpClient() (*http.Client, error) {
+	return config.httpClient(nil)
+}
+
+// httpClient builds the HTTP client on the base transport, a clone of [http.DefaultTransport] if nil
+func (config *ClientConfig) httpClient(base http.RoundTripper) (*http.Client, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if config.Proxy != "" {
+		proxy, err := url.Parse(config.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy url '%s': %w", config.Proxy, err)
+		}
+		transport, ok := base.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("client config proxy can't be set on a %T transport", base)
+		}
+		transport = transport.Clone()
+		transport.Proxy = http.ProxyURL(proxy)
+		base = transport
+	}
+
+	roundTripper := base
+	if config.RateLimit != nil {
+		roundTripper = config.RateLimit.Transport(roundTripper)
+	}
+	if config.Retry != nil {
+		roundTripper = config.Retry.policy().Transport(roundTripper)
+	}
+
+	headers := make(map[string]string, len(config.Headers)+1)
+	for key, value := range config.Headers {
+		headers[key] = os.ExpandEnv(value)
+	}
+	if config.ApiKey != "" {
+		headers["Authorization"] = "Bearer " + os.ExpandEnv(config.ApiKey)
+	}
+	if len(headers) > 0 {
+		roundTripper = &headerTransport{inner: roundTripper, headers: headers}
+	}
+
+	timeout := time.Duration(config.Timeout)
+	if timeout <= 0 {
+		timeout = DefaultClientTimeout
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Jar:       jar,
+		Timeout:   timeout,
+		Transport: roundTripper,
+	}, nil
+}
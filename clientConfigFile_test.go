@@ -0,0 +1,189 @@
+package aptos
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientFromConfigFile(t *testing.T) {
+	t.Setenv("TEST_APTOS_API_KEY", "secret")
+
+	requests := atomic.Int32{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "sdk-test", r.Header.Get("X-Client"))
+		// The first request fails, and is retried
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"chain_id": 4, "ledger_version": "100", "ledger_timestamp": "1700000000000000"})
+	}))
+	defer mockServer.Close()
+
+	path := filepath.Join(t.TempDir(), "aptos.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+network: localnet
+node_url: `+mockServer.URL+`/v1
+api_key: ${TEST_APTOS_API_KEY}
+headers:
+  X-Client: sdk-test
+timeout: 5s
+retry:
+  attempts: 3
+  backoff: 1ms
+rate_limit:
+  requests_per_second: 1000
+  burst: 10
+`), 0o600))
+
+	config, err := LoadClientConfig(path)
+	assert.NoError(t, err)
+	network, err := config.NetworkConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, mockServer.URL+"/v1", network.NodeUrl)
+	assert.Equal(t, LocalnetConfig.IndexerUrl, network.IndexerUrl)
+	assert.Equal(t, LocalnetConfig.ChainId, network.ChainId)
+	assert.Equal(t, &RateLimit{RequestsPerSecond: 1000, Burst: 10}, config.RateLimit)
+
+	client, err := NewClientFromConfigFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, client.nodeClient.client.Timeout)
+	info, err := client.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), info.LedgerVersion())
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestNewClientFromConfigFileJsonProxy(t *testing.T) {
+	// Requests to the node go through the proxy
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "node.invalid", r.URL.Host)
+		_ = json.NewEncoder(w).Encode(map[string]any{"chain_id": 2, "ledger_version": "7", "ledger_timestamp": "1700000000000000"})
+	}))
+	defer proxy.Close()
+
+	path := filepath.Join(t.TempDir(), "aptos.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{
+	"chain_id": 2,
+	"node_url": "http://node.invalid/v1",
+	"timeout": "250ms",
+	"proxy": "`+proxy.URL+`"
+}`), 0o600))
+
+	client, err := NewClientFromConfigFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, client.nodeClient.client.Timeout)
+	info, err := client.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), info.LedgerVersion())
+}
+
+func TestLoadClientConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, contents string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+		return path
+	}
+
+	// Typos are caught rather than silently ignored
+	_, err := LoadClientConfig(write("typo.yaml", "node_uri: http://localhost:8080/v1\n"))
+	assert.Error(t, err)
+	_, err = LoadClientConfig(write("typo.json", `{"node_uri": "http://localhost:8080/v1"}`))
+	assert.Error(t, err)
+	_, err = LoadClientConfig(write("duration.yaml", "timeout: soon\n"))
+	assert.Error(t, err)
+
+	_, err = NewClientFromConfigFile(write("unknown.yaml", "network: moonnet\n"))
+	assert.Error(t, err)
+	_, err = NewClientFromConfigFile(write("empty.yaml", "timeout: 1s\n"))
+	assert.Error(t, err)
+	_, err = NewClientFromConfigFile(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRetryTransportRewindsBody(t *testing.T) {
+	requests := atomic.Int32{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body))
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer mockServer.Close()
+
+	config := &ClientConfig{Retry: &ClientRetryConfig{Attempts: 3, Backoff: ConfigDuration(time.Millisecond)}}
+	httpClient, err := config.HttpClient()
+	assert.NoError(t, err)
+	response, err := httpClient.Post(mockServer.URL+"/v1/view", "text/plain", strings.NewReader("payload"))
+	assert.NoError(t, err)
+	_ = response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// A body that can't be rewound is only sent once
+	requests.Store(0)
+	response, err = httpClient.Post(mockServer.URL+"/v1/view", "text/plain", io.MultiReader(strings.NewReader("payload")))
+	assert.NoError(t, err)
+	_ = response.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestClientGasConfig(t *testing.T) {
+	client, err := NewClient(LocalnetConfig, ClientGasConfig{MaxGasAmount: 5000, GasUnitPrice: 150, Expiration: ConfigDuration(90 * time.Second)})
+	assert.NoError(t, err)
+
+	build := func(options ...any) *RawTransaction {
+		options = append(options, SequenceNumber(1), ChainIdOption(4))
+		rawTxn, err := client.BuildTransaction(AccountOne, TransactionPayload{Payload: ObjectTransferCallPayload(AccountTwo, AccountThree)}, options...)
+		assert.NoError(t, err)
+		return rawTxn
+	}
+	now := uint64(time.Now().Unix())
+	rawTxn := build()
+	assert.Equal(t, uint64(5000), rawTxn.MaxGasAmount)
+	assert.Equal(t, uint64(150), rawTxn.GasUnitPrice)
+	assert.InDelta(t, now+90, rawTxn.ExpirationTimestampSeconds, 2)
+
+	// Build options override the defaults
+	rawTxn = build(ExpirationSeconds(10), GasUnitPrice(200))
+	assert.Equal(t, uint64(200), rawTxn.GasUnitPrice)
+	assert.InDelta(t, now+10, rawTxn.ExpirationTimestampSeconds, 2)
+
+	// A priority passed to the build estimates the price, rather than using the default price
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/estimate_gas_price", r.URL.Path)
+		json.NewEncoder(w).Encode(map[string]any{
+			"deprioritized_gas_estimate": 100,
+			"gas_estimate":               150,
+			"prioritized_gas_estimate":   300,
+		})
+	}))
+	defer mockServer.Close()
+	client, err = NewClient(NetworkConfig{Name: "mocknet", ChainId: 4, NodeUrl: mockServer.URL}, ClientGasConfig{GasUnitPrice: 120})
+	assert.NoError(t, err)
+	rawTxn = build(GasPriorityPrioritized)
+	assert.Equal(t, uint64(300), rawTxn.GasUnitPrice)
+	rawTxn = build()
+	assert.Equal(t, uint64(120), rawTxn.GasUnitPrice)
+
+	_, err = NewClient(LocalnetConfig, ClientGasConfig{Expiration: ConfigDuration(time.Millisecond)})
+	assert.ErrorContains(t, err, "at least 1s")
+
+	config := ClientConfig{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"network": "localnet", "gas": {"expiration": "2m"}}`), &config))
+	assert.Equal(t, ConfigDuration(2*time.Minute), config.Gas.Expiration)
+}
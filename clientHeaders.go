@@ -0,0 +1,33 @@
+package aptos
+
+import (
+	"net/http"
+)
+
+// ApiKey is a [NewClient] option for the API key of a node provider e.g. from the Aptos developer portal.  It is sent as
+// a bearer token on every node, indexer, and faucet request.
+//
+//	client, err := NewClient(MainnetConfig, ApiKey(os.Getenv("APTOS_API_KEY")))
+type ApiKey string
+
+// ClientHeaders is a [NewClient] option for static headers sent on every node, indexer, and faucet request.  Unlike
+// [Client.SetHeader], which only covers node and faucet requests, the headers are set by the HTTP transport.
+//
+//	client, err := NewClient(MainnetConfig, ClientHeaders{"X-Gateway-Key": key})
+type ClientHeaders map[string]string
+
+// headerTransport sets headers on every request, unless the request already has them
+type headerTransport struct {
+	inner   http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	for key, value := range t.headers {
+		if request.Header.Get(key) == "" {
+			request.Header.Set(key, value)
+		}
+	}
+	return t.inner.RoundTrip(request)
+}
@@ -0,0 +1,36 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient_Headers(t *testing.T) {
+	paths := make([]string, 0)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		assert.Equal(t, "gateway", r.Header.Get("X-Gateway"))
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/v1/graphql" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"processor_status": []any{map[string]any{"last_success_version": 10}}}})
+			return
+		}
+		_, _ = w.Write([]byte(`{"sequence_number": "7", "authentication_key": "0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+	}))
+	defer mockServer.Close()
+
+	config := NetworkConfig{NodeUrl: mockServer.URL + "/v1", IndexerUrl: mockServer.URL + "/v1/graphql", ChainId: 4}
+	client, err := NewClient(config, ApiKey("key"), ClientHeaders{"X-Gateway": "gateway"})
+	assert.NoError(t, err)
+
+	_, err = client.Account(AccountOne)
+	assert.NoError(t, err)
+	version, err := client.GetProcessorStatus("default_processor")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), version)
+	assert.Equal(t, []string{"/v1/accounts/0x1", "/v1/graphql"}, paths)
+}
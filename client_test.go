@@ -1,7 +1,10 @@
 package aptos
 
 import (
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+	"io"
+	"net/http"
 	"strings"
 	"sync"
 	"testing"
@@ -52,12 +55,17 @@ func initSigners() {
 		signer, err := NewMultiKeyTestSigner(32, 5)
 		return any(signer).(TransactionSigner), err
 	}
-	/* TODO: MultiEd25519 is not supported ATM
-	TestSigners["MultiEd25519"] = func() (TransactionSigner, error) {
-		signer, err := NewMultiEd25519Signer(3, 2)
-		return any(signer).(TransactionSigner), err
+	TestSigners["2-of-3 MultiEd25519"] = func() (TransactionSigner, error) {
+		testSigner, err := NewMultiEd25519Signer(3, 2)
+		if err != nil {
+			return nil, err
+		}
+		signer, err := crypto.NewMultiEd25519Signer(testSigner.PubKey().(*crypto.MultiEd25519PublicKey), testSigner.Keys...)
+		if err != nil {
+			return nil, err
+		}
+		return NewAccountFromSigner(signer)
 	}
-	*/
 }
 
 func initSingleSignerPayloads() {
@@ -78,6 +86,56 @@ func TestAptosClientHeaderValue(t *testing.T) {
 	assert.NotEqual(t, "aptos-go-sdk/unk", ClientHeaderValue)
 }
 
+// roundTripFunc is a test double transport
+type roundTripFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func TestNewClient_Transport(t *testing.T) {
+	requests := 0
+	transport := roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		requests++
+		assert.Equal(t, "/v1/accounts/0x1", request.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"sequence_number": "7", "authentication_key": "0x0000000000000000000000000000000000000000000000000000000000000001"}`)),
+			Request:    request,
+		}, nil
+	})
+
+	httpClient := &http.Client{Timeout: time.Second}
+	client, err := NewClient(NetworkConfig{NodeUrl: "http://node.invalid/v1", ChainId: 4}, httpClient, http.RoundTripper(transport))
+	assert.NoError(t, err)
+	account, err := client.Account(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", account.SequenceNumberStr)
+	assert.Equal(t, 1, requests)
+	// The caller's client keeps its transport, and its other settings are used
+	assert.Nil(t, httpClient.Transport)
+	assert.Equal(t, time.Second, client.nodeClient.client.Timeout)
+
+	_, err = NewClient(NetworkConfig{NodeUrl: "http://node.invalid/v1", ChainId: 4}, http.RoundTripper(transport), http.RoundTripper(transport))
+	assert.Error(t, err)
+
+	// A config's headers are set on top of the transport
+	config := &ClientConfig{NodeUrl: "http://node.invalid/v1", ChainId: 4, ApiKey: "key"}
+	client, err = config.NewClient(roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, "Bearer key", request.Header.Get("Authorization"))
+		return transport(request)
+	}))
+	assert.NoError(t, err)
+	_, err = client.Account(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	config.Proxy = "http://proxy.invalid:3128"
+	_, err = config.NewClient(transport)
+	assert.Error(t, err)
+}
+
 func Test_SingleSignerFlows(t *testing.T) {
 	for name, signer := range TestSigners {
 		for payloadName, buildSingleSignerPayload := range TestSingleSignerPayloads {
@@ -234,6 +292,27 @@ func TestAPTTransferTransaction(t *testing.T) {
 	assert.NotNil(t, signedTxn)
 }
 
+func TestCoinTransferTransaction(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	client, err := NewClient(LocalnetConfig)
+	assert.NoError(t, err)
+	coinType, err := ParseTypeTag("0x1::test_coin::TestCoin")
+	assert.NoError(t, err)
+
+	rawTxn, err := CoinTransferTransaction(client, *coinType, sender, AccountTwo, 1337, MaxGasAmount(1000), GasUnitPrice(100), ChainIdOption(4), SequenceNumber(1))
+	assert.NoError(t, err)
+	payload := rawTxn.Payload.Payload.(*EntryFunction)
+	assert.Equal(t, "transfer_coins", payload.Function)
+	assert.Equal(t, []TypeTag{*coinType}, payload.ArgTypes)
+	assert.Equal(t, AccountTwo[:], payload.Args[0])
+
+	// APT keeps using 0x1::aptos_account::transfer
+	rawTxn, err = APTTransferTransaction(client, sender, AccountTwo, 1337, MaxGasAmount(1000), GasUnitPrice(100), ChainIdOption(4), SequenceNumber(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "transfer", rawTxn.Payload.Payload.(*EntryFunction).Function)
+}
+
 func Test_Indexer(t *testing.T) {
 	client, err := createTestClient()
 	assert.NoError(t, err)
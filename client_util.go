@@ -54,7 +54,18 @@ func init() {
 // options may be: MaxGasAmount, GasUnitPrice, ExpirationSeconds, ValidUntil, SequenceNumber, ChainIdOption
 // deprecated, please use the EntryFunction APIs
 func APTTransferTransaction(client *Client, sender TransactionSigner, dest AccountAddress, amount uint64, options ...any) (rawTxn *RawTransaction, err error) {
-	entryFunction, err := CoinTransferPayload(nil, dest, amount)
+	return CoinTransferTransaction(client, AptosCoinTypeTag, sender, dest, amount, options...)
+}
+
+// CoinTransferTransaction Move some of a coin from sender to dest with 0x1::aptos_account::transfer_coins, only for
+// single signer.  The amount is in the coin's smallest unit.  The receiver doesn't need to have registered the coin.
+//
+//	usdc, err := ParseTypeTag("0xcafe::usdc::USDC")
+//	rawTxn, err := CoinTransferTransaction(client, *usdc, sender, dest, 1_000_000)
+//
+// options may be: MaxGasAmount, GasUnitPrice, ExpirationSeconds, ValidUntil, SequenceNumber, ChainIdOption
+func CoinTransferTransaction(client *Client, coinType TypeTag, sender TransactionSigner, dest AccountAddress, amount uint64, options ...any) (rawTxn *RawTransaction, err error) {
+	entryFunction, err := CoinTransferPayload(&coinType, dest, amount)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,75 @@
+// fixtures generates Go test scaffolding for the Move modules published at an address, see [aptos.GenerateFixtures]
+//
+//	go run ./cmd/fixtures -network testnet -address 0xcafe -package counter -out counter/fixtures.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+)
+
+func main() {
+	network := flag.String("network", "devnet", "network to fetch the modules from, one of "+strings.Join(networkNames(), ", "))
+	nodeUrl := flag.String("node", "", "full node URL, overrides -network")
+	address := flag.String("address", "", "address the modules are published at")
+	modules := flag.String("modules", "", "comma separated modules to generate, defaults to all")
+	pkg := flag.String("package", "fixtures", "Go package name of the generated file")
+	out := flag.String("out", "", "file to write, defaults to stdout")
+	flag.Parse()
+
+	err := run(*network, *nodeUrl, *address, *modules, *pkg, *out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run fetches the ABIs and writes the fixtures
+func run(network string, nodeUrl string, address string, modules string, pkg string, out string) error {
+	config, ok := aptos.NamedNetworks[network]
+	if !ok && nodeUrl == "" {
+		return fmt.Errorf("unknown network %s", network)
+	}
+	if nodeUrl != "" {
+		config = aptos.NetworkConfig{NodeUrl: nodeUrl}
+	}
+	moduleAddress := aptos.AccountAddress{}
+	err := moduleAddress.ParseStringRelaxed(address)
+	if err != nil {
+		return fmt.Errorf("invalid -address: %w", err)
+	}
+
+	client, err := aptos.NewClient(config)
+	if err != nil {
+		return err
+	}
+	options := aptos.FixtureOptions{Package: pkg}
+	if modules != "" {
+		options.Modules = strings.Split(modules, ",")
+	}
+	source, err := client.GenerateFixtures(moduleAddress, options)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	return os.WriteFile(out, source, 0644)
+}
+
+// networkNames lists the preconfigured networks
+func networkNames() []string {
+	names := make([]string, 0, len(aptos.NamedNetworks))
+	for name := range aptos.NamedNetworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
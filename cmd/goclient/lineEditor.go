@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode/utf8"
+)
+
+// Control keys handled by the line editor
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyBackspace = 8
+	keyTab       = '\t'
+	keyNewline   = '\n'
+	keyReturn    = '\r'
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// completer returns the word being completed at the end of the line, and its candidates
+type completer func(line string) (word string, candidates []string)
+
+// lineEditor reads lines from a terminal with tab completion.  It puts the terminal in non-canonical mode with stty, so
+// it is only available on terminals of unix-like systems.
+type lineEditor struct {
+	in       *os.File
+	out      io.Writer
+	complete completer
+	saved    string // saved is the terminal state to restore on close
+}
+
+// newLineEditor switches the terminal to non-canonical mode, erroring if in isn't a terminal
+func newLineEditor(in *os.File, out io.Writer, complete completer) (*lineEditor, error) {
+	info, err := in.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode()&os.ModeCharDevice == 0 {
+		return nil, errors.New("input is not a terminal")
+	}
+	saved, err := stty(in, "-g")
+	if err != nil {
+		return nil, err
+	}
+	_, err = stty(in, "-icanon", "-echo", "-isig", "min", "1")
+	if err != nil {
+		return nil, err
+	}
+	return &lineEditor{in: in, out: out, complete: complete, saved: strings.TrimSpace(saved)}, nil
+}
+
+// close restores the terminal
+func (editor *lineEditor) close() {
+	_, _ = stty(editor.in, editor.saved)
+}
+
+// readLine reads a line, returning [io.EOF] on Ctrl-D at an empty line
+func (editor *lineEditor) readLine(prompt string) (string, error) {
+	fmt.Fprint(editor.out, prompt)
+	line := make([]byte, 0, 128)
+	buf := make([]byte, 1)
+	for {
+		_, err := editor.in.Read(buf)
+		if err != nil {
+			return "", err
+		}
+		switch key := buf[0]; key {
+		case keyReturn, keyNewline:
+			fmt.Fprintln(editor.out)
+			return string(line), nil
+		case keyCtrlC:
+			// Abandon the line
+			fmt.Fprintln(editor.out, "^C")
+			fmt.Fprint(editor.out, prompt)
+			line = line[:0]
+		case keyCtrlD:
+			if len(line) == 0 {
+				fmt.Fprintln(editor.out)
+				return "", io.EOF
+			}
+		case keyBackspace, keyDelete:
+			if len(line) > 0 {
+				_, size := utf8.DecodeLastRune(line)
+				line = line[:len(line)-size]
+				fmt.Fprint(editor.out, "\b \b")
+			}
+		case keyTab:
+			line = editor.completeLine(prompt, line)
+		case keyEscape:
+			// Cursor movement and history aren't supported, drop the rest of the escape sequence
+			editor.skipEscape()
+		default:
+			if key >= ' ' {
+				line = append(line, key)
+				_, _ = editor.out.Write(buf)
+			}
+		}
+	}
+}
+
+// completeLine completes the last word of the line, listing the candidates if there's more than one
+func (editor *lineEditor) completeLine(prompt string, line []byte) []byte {
+	word, candidates := editor.complete(string(line))
+	if len(candidates) == 0 {
+		return line
+	}
+
+	completion := commonPrefix(candidates)
+	if len(candidates) == 1 && !strings.HasSuffix(completion, "::") {
+		completion += " "
+	}
+	if len(completion) > len(word) {
+		suffix := completion[len(word):]
+		fmt.Fprint(editor.out, suffix)
+		return append(line, suffix...)
+	}
+
+	// Nothing more in common, show the choices
+	fmt.Fprintln(editor.out)
+	fmt.Fprintln(editor.out, strings.Join(candidates, "  "))
+	fmt.Fprint(editor.out, prompt, string(line))
+	return line
+}
+
+// skipEscape reads the rest of an ANSI escape sequence e.g. an arrow key
+func (editor *lineEditor) skipEscape() {
+	buf := make([]byte, 1)
+	_, err := editor.in.Read(buf)
+	if err != nil || buf[0] != '[' {
+		return
+	}
+	for {
+		_, err = editor.in.Read(buf)
+		if err != nil || (buf[0] >= 0x40 && buf[0] <= 0x7e) {
+			return
+		}
+	}
+}
+
+// commonPrefix returns the longest prefix shared by all the candidates
+func commonPrefix(candidates []string) string {
+	prefix := candidates[0]
+	for _, candidate := range candidates[1:] {
+		for !strings.HasPrefix(candidate, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// stty runs stty on the terminal
+func stty(terminal *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = terminal
+	out, err := cmd.Output()
+	return string(out), err
+}
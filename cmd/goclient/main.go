@@ -0,0 +1,124 @@
+// goclient is a command line client for the Aptos blockchain built on the SDK
+//
+//	go run ./cmd/goclient repl -network testnet -profile default
+//
+// Profiles are shared with the Aptos CLI in ~/.aptos/config.yaml, and the default profile is used if -profile isn't
+// given
+//
+//	go run ./cmd/goclient profile add -network testnet alice
+//	go run ./cmd/goclient profile use alice
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+)
+
+const usage = `usage: goclient <command> [flags]
+
+commands:
+  repl     interactive prompt for querying accounts, calling view functions, and submitting transactions
+  profile  list, add, or choose the default of the Aptos CLI profiles`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "repl":
+		err = runRepl(os.Args[2:])
+	case "profile":
+		err = runProfile(os.Args[2:], os.Stdout)
+	case "help", "-h", "-help", "--help":
+		fmt.Println(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %s\n%s\n", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// runRepl parses the repl flags and runs the prompt until exit
+func runRepl(args []string) error {
+	flags := flag.NewFlagSet("repl", flag.ExitOnError)
+	network := flags.String("network", "", "network to connect to, one of "+strings.Join(networkNames(), ", ")+", defaults to the profile's network or devnet")
+	nodeUrl := flags.String("node", "", "full node URL, overrides -network")
+	profileName := flags.String("profile", "", "Aptos CLI profile to sign transactions with, defaults to the default profile if there is one")
+	configPath := flags.String("config", "", "Aptos CLI config file, defaults to ~/.aptos/config.yaml")
+	_ = flags.Parse(args)
+
+	config, signer, err := replConfig(*network, *nodeUrl, *profileName, *configPath)
+	if err != nil {
+		return err
+	}
+	client, err := aptos.NewClient(config)
+	if err != nil {
+		return err
+	}
+	return newSession(client, signer, os.Stdout).run(os.Stdin)
+}
+
+// replConfig resolves the network and signer from the flags
+func replConfig(network string, nodeUrl string, profileName string, configPath string) (aptos.NetworkConfig, *aptos.Account, error) {
+	config := aptos.DevnetConfig
+	var signer *aptos.Account
+	named := profileName != ""
+	profile, profileName, err := loadProfile(profileName, configPath)
+	if err != nil {
+		return config, nil, err
+	}
+	if profile != nil {
+		config, err = profile.NetworkConfig()
+		if err != nil {
+			return config, nil, err
+		}
+		// A default profile without a key only picks the network, a named one is for signing
+		if named || profile.PrivateKey != "" {
+			signer, err = profile.Signer()
+			if err != nil {
+				return config, nil, fmt.Errorf("profile %s: %w", profileName, err)
+			}
+		}
+	}
+
+	if network != "" {
+		named, ok := aptos.NamedNetworks[network]
+		if !ok {
+			return config, nil, fmt.Errorf("unknown network %s", network)
+		}
+		config = named
+	}
+	if nodeUrl != "" {
+		if network == "" && profile == nil {
+			config = aptos.NetworkConfig{}
+		}
+		config.NodeUrl = nodeUrl
+	}
+	if config.NodeUrl == "" {
+		return config, nil, errors.New("no node URL")
+	}
+	return config, signer, nil
+}
+
+// networkNames lists the preconfigured networks
+func networkNames() []string {
+	names := make([]string, 0, len(aptos.NamedNetworks))
+	for name := range aptos.NamedNetworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+)
+
+const profileUsage = `usage: goclient profile <command> [flags]
+
+commands:
+  list [-config path]                list the profiles, marking the default with *
+  add [flags] <name>                 add a profile, with a new Ed25519 key unless -private-key is given
+  use [-config path] <name>          make the profile the default when -profile isn't given`
+
+// runProfile runs a profile subcommand, managing the Aptos CLI config the same way as `aptos init`
+func runProfile(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(profileUsage)
+	}
+	switch args[0] {
+	case "list":
+		return runProfileList(args[1:], out)
+	case "add":
+		return runProfileAdd(args[1:], out)
+	case "use":
+		return runProfileUse(args[1:], out)
+	default:
+		return fmt.Errorf("unknown profile command %s\n%s", args[0], profileUsage)
+	}
+}
+
+// runProfileList prints each profile's name, network, and account
+func runProfileList(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("profile list", flag.ContinueOnError)
+	configPath := flags.String("config", "", "Aptos CLI config file, defaults to ~/.aptos/config.yaml")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cliConfig, _, err := loadCliConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, name := range cliConfig.ProfileNames() {
+		profile := cliConfig.Profiles[name]
+		marker := " "
+		if name == cliConfig.DefaultProfileName() {
+			marker = "*"
+		}
+		account := profile.Account
+		if address, err := profile.AccountAddress(); err == nil {
+			account = address.String()
+		}
+		_, _ = fmt.Fprintf(writer, "%s %s\t%s\t%s\n", marker, name, profile.Network, account)
+	}
+	return writer.Flush()
+}
+
+// runProfileAdd adds a profile for a new or given key, and saves the config
+func runProfileAdd(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("profile add", flag.ContinueOnError)
+	configPath := flags.String("config", "", "Aptos CLI config file, defaults to ~/.aptos/config.yaml")
+	network := flags.String("network", "", "network of the profile, one of "+strings.Join(networkNames(), ", ")+", defaults to devnet")
+	nodeUrl := flags.String("node", "", "full node URL, for a custom network")
+	faucetUrl := flags.String("faucet", "", "faucet URL, for a custom network")
+	privateKey := flags.String("private-key", "", "AIP-80 or hex Ed25519 private key, a new Ed25519 key if not set")
+	force := flags.Bool("force", false, "replace an existing profile")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: goclient profile add [flags] <name>")
+	}
+	name := flags.Arg(0)
+
+	cliConfig, path, err := loadCliConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	if _, ok := cliConfig.Profiles[name]; ok && !*force {
+		return fmt.Errorf("profile %s already exists, use -force to replace it", name)
+	}
+
+	config := aptos.DevnetConfig
+	if *network != "" {
+		named, ok := aptos.NamedNetworks[*network]
+		if !ok {
+			return fmt.Errorf("unknown network %s", *network)
+		}
+		config = named
+	}
+	if *nodeUrl != "" {
+		if *network == "" {
+			config = aptos.NetworkConfig{}
+		}
+		config.NodeUrl = *nodeUrl
+	}
+	if *faucetUrl != "" {
+		config.FaucetUrl = *faucetUrl
+	}
+
+	var account *aptos.Account
+	if *privateKey != "" {
+		account, err = aptos.NewAccountFromPrivateKeyString(*privateKey, false)
+	} else {
+		account, err = aptos.NewEd25519Account()
+	}
+	if err != nil {
+		return err
+	}
+
+	err = cliConfig.AddProfile(name, config, account)
+	if err != nil {
+		return err
+	}
+	err = cliConfig.Save(path)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "added profile %s for account %s on %s\n", name, account.Address.String(), cliConfig.Profiles[name].Network)
+	return nil
+}
+
+// runProfileUse makes a profile the default, and saves the config
+func runProfileUse(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("profile use", flag.ContinueOnError)
+	configPath := flags.String("config", "", "Aptos CLI config file, defaults to ~/.aptos/config.yaml")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: goclient profile use [-config path] <name>")
+	}
+	name := flags.Arg(0)
+
+	cliConfig, path, err := loadCliConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	err = cliConfig.UseProfile(name)
+	if err != nil {
+		return err
+	}
+	err = cliConfig.Save(path)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "default profile is now %s\n", name)
+	return nil
+}
+
+// loadCliConfig loads the Aptos CLI config at path, or at the default path if empty, and returns the path used
+func loadCliConfig(path string) (*aptos.CliConfig, string, error) {
+	if path == "" {
+		defaultPath, err := aptos.DefaultCliConfigPath()
+		if err != nil {
+			return nil, "", err
+		}
+		path = defaultPath
+	}
+	cliConfig, err := aptos.LoadCliConfig(path)
+	return cliConfig, path, err
+}
+
+// loadProfile loads the named profile, or the default profile if name is empty.  A missing default profile isn't an
+// error, as goclient works without one.
+func loadProfile(name string, configPath string) (*aptos.CliProfile, string, error) {
+	cliConfig, _, err := loadCliConfig(configPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if name != "" {
+		profile, err := cliConfig.Profile(name)
+		return profile, name, err
+	}
+	name = cliConfig.DefaultProfileName()
+	profile, err := cliConfig.Profile(name)
+	if errors.Is(err, aptos.ErrCliProfileNotFound) {
+		return nil, "", nil
+	}
+	return profile, name, err
+}
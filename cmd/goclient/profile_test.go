@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileCommands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".aptos", "config.yaml")
+	run := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		err := runProfile(args, out)
+		return out.String(), err
+	}
+
+	// Without a default profile, the repl falls back to devnet without a signer
+	config, signer, err := replConfig("", "", "", path)
+	assert.NoError(t, err)
+	assert.Equal(t, aptos.DevnetConfig.NodeUrl, config.NodeUrl)
+	assert.Nil(t, signer)
+
+	out, err := run("add", "-config", path, "-network", "testnet", "-private-key", "ed25519-priv-0xc5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5", "alice")
+	assert.NoError(t, err)
+	alice, err := aptos.NewAccountFromPrivateKeyString("ed25519-priv-0xc5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "added profile alice for account "+alice.Address.String()+" on Testnet\n", out)
+
+	_, err = run("add", "-config", path, "-node", "http://127.0.0.1:9000", "local")
+	assert.NoError(t, err)
+	_, err = run("add", "-config", path, "alice")
+	assert.ErrorContains(t, err, "already exists")
+
+	out, err = run("list", "-config", path)
+	assert.NoError(t, err)
+	assert.Contains(t, out, "  alice  Testnet  "+alice.Address.String()+"\n")
+	assert.Contains(t, out, "  local  Custom")
+
+	// The default profile picks the repl's network and signer
+	out, err = run("use", "-config", path, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "default profile is now alice\n", out)
+	out, err = run("list", "-config", path)
+	assert.NoError(t, err)
+	assert.Contains(t, out, "* alice")
+
+	config, signer, err = replConfig("", "", "", path)
+	assert.NoError(t, err)
+	assert.Equal(t, aptos.TestnetConfig.NodeUrl, config.NodeUrl)
+	assert.Equal(t, alice.Address, signer.Address)
+
+	config, signer, err = replConfig("", "", "local", path)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:9000/v1", config.NodeUrl)
+	assert.NotNil(t, signer)
+
+	_, err = run("use", "-config", path, "missing")
+	assert.ErrorIs(t, err, aptos.ErrCliProfileNotFound)
+	_, err = run("remove")
+	assert.ErrorContains(t, err, "unknown profile command")
+}
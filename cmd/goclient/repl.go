@@ -0,0 +1,490 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+const replHelp = `commands:
+  account [ADDRESS]                   sequence number and APT balance, defaults to $me
+  view FUNCTION[<TYPES>] [ARGS...]    call a view function e.g. view 0x1::coin::balance<0x1::aptos_coin::AptosCoin> $me
+  submit FUNCTION[<TYPES>] [ARGS...]  sign, submit, and wait for an entry function, the hash is saved as $last
+  txn [HASH]                          show a transaction, defaults to $last
+  modules ADDRESS                     list the modules at an address
+  set NAME VALUE                      set a session variable, used as $NAME
+  unset NAME                          remove a session variable
+  vars                                list the session variables
+  help                                show this help
+  exit                                leave the repl
+
+Arguments are decoded against the function's ABI.  Vectors are JSON arrays e.g. [1,2,3], and strings with spaces are
+quoted.  Press tab to complete commands, variables, modules, and functions.`
+
+// replCommands are the commands of the repl, in the order they are completed
+var replCommands = []string{"account", "exit", "help", "modules", "set", "submit", "txn", "unset", "vars", "view"}
+
+// errExit ends the repl
+var errExit = errors.New("exit")
+
+// session is the state of a repl, kept between commands
+type session struct {
+	client *aptos.Client
+	signer *aptos.Account               // signer is the account transactions are submitted from, nil for read only sessions
+	vars   map[string]string            // vars are the session variables, substituted for $name in arguments
+	abis   map[string][]*api.MoveModule // abis are the fetched module ABIs by address, for completion and argument encoding
+	out    io.Writer
+}
+
+// newSession creates a session, with $me set to the signer's address
+func newSession(client *aptos.Client, signer *aptos.Account, out io.Writer) *session {
+	s := &session{
+		client: client,
+		signer: signer,
+		vars:   make(map[string]string),
+		abis:   make(map[string][]*api.MoveModule),
+		out:    out,
+	}
+	if signer != nil {
+		s.vars["me"] = signer.Address.String()
+	}
+	return s
+}
+
+// run reads and executes commands until exit or the end of the input
+func (s *session) run(in *os.File) error {
+	fmt.Fprintln(s.out, `type "help" for commands`)
+	editor, err := newLineEditor(in, s.out, s.complete)
+	if err != nil {
+		// Not a terminal, read plain lines without completion
+		return s.runLines(in)
+	}
+	defer editor.close()
+	for {
+		line, err := editor.readLine("> ")
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if s.execute(line) == errExit {
+			return nil
+		}
+	}
+}
+
+// runLines executes each line of the input
+func (s *session) runLines(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if s.execute(scanner.Text()) == errExit {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// execute runs a line, printing any error
+func (s *session) execute(line string) error {
+	err := s.dispatch(line)
+	if err != nil && err != errExit {
+		fmt.Fprintf(s.out, "error: %v\n", err)
+	}
+	return err
+}
+
+// dispatch runs a line
+func (s *session) dispatch(line string) error {
+	words, err := splitWords(line)
+	if err != nil || len(words) == 0 {
+		return err
+	}
+	command := words[0]
+	args, err := s.substitute(words[1:])
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "help":
+		fmt.Fprintln(s.out, replHelp)
+		return nil
+	case "exit", "quit":
+		return errExit
+	case "set":
+		if len(args) != 2 {
+			return errors.New("usage: set NAME VALUE")
+		}
+		s.vars[strings.TrimPrefix(args[0], "$")] = args[1]
+		return nil
+	case "unset":
+		if len(args) != 1 {
+			return errors.New("usage: unset NAME")
+		}
+		delete(s.vars, strings.TrimPrefix(args[0], "$"))
+		return nil
+	case "vars":
+		for _, name := range s.varNames() {
+			fmt.Fprintf(s.out, "$%s = %s\n", name, s.vars[name])
+		}
+		return nil
+	case "account":
+		return s.account(args)
+	case "modules":
+		return s.modules(args)
+	case "view":
+		return s.view(args)
+	case "submit":
+		return s.submit(args)
+	case "txn":
+		return s.txn(args)
+	default:
+		return fmt.Errorf("unknown command %s, type \"help\" for commands", command)
+	}
+}
+
+// account prints the sequence number and APT balance of an account
+func (s *session) account(args []string) error {
+	if len(args) == 0 && s.signer != nil {
+		args = []string{s.vars["me"]}
+	}
+	if len(args) != 1 {
+		return errors.New("usage: account ADDRESS")
+	}
+	address, err := parseAddress(args[0])
+	if err != nil {
+		return err
+	}
+	info, err := s.client.Account(address)
+	if err != nil {
+		return err
+	}
+	sequenceNumber, err := info.SequenceNumber()
+	if err != nil {
+		return err
+	}
+	balance, err := s.client.AccountAPTBalance(address)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "address:            %s\nsequence number:    %d\nauthentication key: %s\nAPT balance:        %d octas\n",
+		address.StringLong(), sequenceNumber, info.AuthenticationKeyHex, balance)
+	return nil
+}
+
+// modules lists the modules at an address, fetching their ABIs for completion
+func (s *session) modules(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: modules ADDRESS")
+	}
+	address, err := parseAddress(args[0])
+	if err != nil {
+		return err
+	}
+	abis, err := s.moduleAbis(address)
+	if err != nil {
+		return err
+	}
+	for _, abi := range abis {
+		fmt.Fprintf(s.out, "%s::%s\n", args[0], abi.Name)
+	}
+	return nil
+}
+
+// view calls a view function and prints the result as JSON
+func (s *session) view(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: view FUNCTION[<TYPES>] [ARGS...]")
+	}
+	entry, err := s.encodeCall(args[0], args[1:], func(function *api.MoveFunction) bool { return function.IsView })
+	if err != nil {
+		return err
+	}
+	result, err := s.client.View(&aptos.ViewPayload{
+		Module:   entry.Module,
+		Function: entry.Function,
+		ArgTypes: entry.ArgTypes,
+		Args:     entry.Args,
+	})
+	if err != nil {
+		return err
+	}
+	return s.printJson(result)
+}
+
+// submit signs and submits an entry function, waits for it, and saves the hash as $last
+func (s *session) submit(args []string) error {
+	if s.signer == nil {
+		return errors.New("no signer, start the repl with -profile to submit transactions")
+	}
+	if len(args) == 0 {
+		return errors.New("usage: submit FUNCTION[<TYPES>] [ARGS...]")
+	}
+	entry, err := s.encodeCall(args[0], args[1:], func(function *api.MoveFunction) bool { return function.IsEntry })
+	if err != nil {
+		return err
+	}
+	response, err := s.client.BuildSignAndSubmitTransaction(s.signer, aptos.TransactionPayload{Payload: entry})
+	if err != nil {
+		return err
+	}
+	s.vars["last"] = response.Hash
+	fmt.Fprintf(s.out, "submitted %s\n", response.Hash)
+
+	txn, err := s.client.WaitForTransaction(response.Hash)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "version %d: %s, gas used %d\n", txn.Version, txn.VmStatus, txn.GasUsed)
+	return nil
+}
+
+// txn prints a transaction as JSON
+func (s *session) txn(args []string) error {
+	if len(args) == 0 && s.vars["last"] != "" {
+		args = []string{s.vars["last"]}
+	}
+	if len(args) != 1 {
+		return errors.New("usage: txn HASH")
+	}
+	txn, err := s.client.TransactionByHash(args[0])
+	if err != nil {
+		return err
+	}
+	return s.printJson(txn.Inner)
+}
+
+// encodeCall encodes the arguments of a function call against the function's ABI
+func (s *session) encodeCall(function string, args []string, filter func(*api.MoveFunction) bool) (*aptos.EntryFunction, error) {
+	tag, err := aptos.ParseTypeTag(function)
+	if err != nil {
+		return nil, fmt.Errorf("invalid function %s: %w", function, err)
+	}
+	id, ok := tag.Value.(*aptos.StructTag)
+	if !ok {
+		return nil, fmt.Errorf("invalid function %s, expected ADDRESS::MODULE::FUNCTION", function)
+	}
+	abi, err := s.client.ModuleAbi(aptos.ModuleId{Address: id.Address, Name: id.Module})
+	if err != nil {
+		return nil, err
+	}
+	var moveFunction *api.MoveFunction
+	for _, candidate := range abi.ExposedFunctions {
+		if candidate.Name == id.Name {
+			moveFunction = candidate
+			break
+		}
+	}
+	if moveFunction == nil || !filter(moveFunction) {
+		return nil, fmt.Errorf("no callable function %s in module %s", id.Name, abi.Name)
+	}
+
+	typeArgs := make([]any, len(id.TypeParams))
+	for i := range id.TypeParams {
+		typeArgs[i] = id.TypeParams[i]
+	}
+	decodedArgs := make([]any, len(args))
+	for i, arg := range args {
+		decodedArgs[i], err = decodeArg(arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i+1, err)
+		}
+	}
+	return aptos.EntryFunctionFromAbi(moveFunction, id.Address, id.Module, id.Name, typeArgs, decodedArgs)
+}
+
+// moduleAbis fetches and caches the module ABIs at an address
+func (s *session) moduleAbis(address aptos.AccountAddress) ([]*api.MoveModule, error) {
+	key := address.String()
+	if abis, ok := s.abis[key]; ok {
+		return abis, nil
+	}
+	modules, err := s.client.AccountModules(address)
+	if err != nil {
+		return nil, err
+	}
+	abis := make([]*api.MoveModule, 0, len(modules))
+	for _, module := range modules {
+		if module.Abi != nil {
+			abis = append(abis, module.Abi)
+		}
+	}
+	sort.Slice(abis, func(i, j int) bool { return abis[i].Name < abis[j].Name })
+	s.abis[key] = abis
+	return abis, nil
+}
+
+// substitute replaces $name arguments with the session variables
+func (s *session) substitute(args []string) ([]string, error) {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "$") {
+			out[i] = arg
+			continue
+		}
+		value, ok := s.vars[arg[1:]]
+		if !ok {
+			return nil, fmt.Errorf("unknown variable %s", arg)
+		}
+		out[i] = value
+	}
+	return out, nil
+}
+
+// varNames lists the session variables in order
+func (s *session) varNames() []string {
+	names := make([]string, 0, len(s.vars))
+	for name := range s.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// printJson prints a value as indented JSON
+func (s *session) printJson(value any) error {
+	out, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(s.out, string(out))
+	return err
+}
+
+// complete returns the candidates for the last word of the line
+func (s *session) complete(line string) (word string, candidates []string) {
+	start := strings.LastIndexAny(line, " \t") + 1
+	word = line[start:]
+	if start == 0 {
+		return word, withPrefix(replCommands, word)
+	}
+	if strings.HasPrefix(word, "$") {
+		names := s.varNames()
+		for i := range names {
+			names[i] = "$" + names[i]
+		}
+		return word, withPrefix(names, word)
+	}
+
+	parts := strings.Split(word, "::")
+	if len(parts) < 2 || len(parts) > 3 {
+		return word, nil
+	}
+	address, err := parseAddress(parts[0])
+	if err != nil {
+		return word, nil
+	}
+	abis, err := s.moduleAbis(address)
+	if err != nil {
+		return word, nil
+	}
+	command := strings.Fields(line)[0]
+	options := make([]string, 0)
+	for _, abi := range abis {
+		if len(parts) == 2 {
+			options = append(options, parts[0]+"::"+abi.Name+"::")
+			continue
+		}
+		if abi.Name != parts[1] {
+			continue
+		}
+		for _, function := range abi.ExposedFunctions {
+			if (command == "view" && function.IsView) || (command == "submit" && function.IsEntry) {
+				options = append(options, parts[0]+"::"+abi.Name+"::"+function.Name)
+			}
+		}
+	}
+	return word, withPrefix(options, word)
+}
+
+// withPrefix filters the options to those starting with prefix
+func withPrefix(options []string, prefix string) []string {
+	out := make([]string, 0)
+	for _, option := range options {
+		if strings.HasPrefix(option, prefix) {
+			out = append(out, option)
+		}
+	}
+	return out
+}
+
+// parseAddress parses an address, allowing short addresses e.g. 0x1
+func parseAddress(input string) (aptos.AccountAddress, error) {
+	address := aptos.AccountAddress{}
+	err := address.ParseStringRelaxed(input)
+	if err != nil {
+		return address, fmt.Errorf("invalid address %s: %w", input, err)
+	}
+	return address, nil
+}
+
+// decodeArg decodes a JSON array or object argument, keeping numbers exact.  Anything else is passed as a string, and
+// converted by the function's parameter type.
+func decodeArg(arg string) (any, error) {
+	if !strings.HasPrefix(arg, "[") && !strings.HasPrefix(arg, "{") {
+		return arg, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(arg)))
+	decoder.UseNumber()
+	var out any
+	err := decoder.Decode(&out)
+	return out, err
+}
+
+// splitWords splits a line on whitespace, keeping quoted strings, JSON arrays and objects, and type arguments together
+func splitWords(line string) ([]string, error) {
+	words := make([]string, 0)
+	var word strings.Builder
+	inWord := false
+	depth := 0
+	quoted := false
+	for _, r := range line {
+		switch {
+		case quoted:
+			if r == '"' && depth == 0 {
+				quoted = false
+				continue
+			} else if r == '"' {
+				quoted = false
+			}
+		case r == '"':
+			quoted = true
+			inWord = true
+			if depth == 0 {
+				continue
+			}
+		case r == '[' || r == '{' || r == '<':
+			depth++
+		case r == ']' || r == '}' || r == '>':
+			depth--
+		case (r == ' ' || r == '\t') && depth == 0:
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+			continue
+		}
+		inWord = true
+		word.WriteRune(r)
+	}
+	if quoted {
+		return nil, errors.New("unterminated quote")
+	}
+	if depth != 0 {
+		return nil, errors.New("unbalanced brackets")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitWords(t *testing.T) {
+	words, err := splitWords(`view  0x1::coin::balance<0x1::aptos_coin::AptosCoin, u8> "hello world" [1, 2] $me`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"view", "0x1::coin::balance<0x1::aptos_coin::AptosCoin, u8>", "hello world", "[1, 2]", "$me"}, words)
+
+	words, err = splitWords(`submit ["a b", "c"]`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"submit", `["a b", "c"]`}, words)
+
+	_, err = splitWords(`set name "unterminated`)
+	assert.Error(t, err)
+	_, err = splitWords(`view [1, 2`)
+	assert.Error(t, err)
+}
+
+func TestSession_Vars(t *testing.T) {
+	client, err := aptos.NewClient(aptos.LocalnetConfig)
+	assert.NoError(t, err)
+	out := &bytes.Buffer{}
+	s := newSession(client, nil, out)
+
+	err = s.runLines(strings.NewReader("set owner 0x1\nset copy $owner\nvars\nunset owner\naccount $owner\nexit\nvars\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "$copy = 0x1\n$owner = 0x1\nerror: unknown variable $owner\n", out.String())
+	assert.Equal(t, map[string]string{"copy": "0x1"}, s.vars)
+}
+
+func TestSession_Complete(t *testing.T) {
+	client, err := aptos.NewClient(aptos.LocalnetConfig)
+	assert.NoError(t, err)
+	s := newSession(client, nil, &bytes.Buffer{})
+	s.vars["me"] = "0x1"
+	s.abis[aptos.AccountOne.String()] = []*api.MoveModule{
+		{Name: "aptos_account", ExposedFunctions: []*api.MoveFunction{{Name: "transfer", IsEntry: true}}},
+		{Name: "coin", ExposedFunctions: []*api.MoveFunction{
+			{Name: "balance", IsView: true},
+			{Name: "transfer", IsEntry: true},
+			{Name: "value"},
+		}},
+	}
+
+	word, candidates := s.complete("su")
+	assert.Equal(t, "su", word)
+	assert.Equal(t, []string{"submit"}, candidates)
+
+	_, candidates = s.complete("account $m")
+	assert.Equal(t, []string{"$me"}, candidates)
+
+	word, candidates = s.complete("view 0x1::")
+	assert.Equal(t, "0x1::", word)
+	assert.Equal(t, []string{"0x1::aptos_account::", "0x1::coin::"}, candidates)
+
+	_, candidates = s.complete("view 0x1::coin::")
+	assert.Equal(t, []string{"0x1::coin::balance"}, candidates)
+	_, candidates = s.complete("submit 0x1::coin::t")
+	assert.Equal(t, []string{"0x1::coin::transfer"}, candidates)
+
+	assert.Equal(t, "0x1::coin::", commonPrefix([]string{"0x1::coin::balance", "0x1::coin::transfer"}))
+}
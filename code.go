@@ -1,6 +1,11 @@
 package aptos
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 )
 
@@ -12,14 +17,7 @@ import (
 // metadata must be the BCS encoded metadata from the compiler, and bytecode must be the BCS encoded bytecode from the compiler.
 // bytecode must be ordered in the same order out of the compiler, or it will fail on publishing.
 func PublishPackagePayloadFromJsonFile(metadata []byte, bytecode [][]byte) (*TransactionPayload, error) {
-	metadataBytes, err := bcs.SerializeBytes(metadata)
-	if err != nil {
-		return nil, err
-	}
-
-	bytecodeBytes, err := bcs.SerializeSingle(func(ser *bcs.Serializer) {
-		bcs.SerializeSequenceWithFunction(bytecode, ser, (*bcs.Serializer).WriteBytes)
-	})
+	args, err := publishPackageArgs(metadata, bytecode)
 	if err != nil {
 		return nil, err
 	}
@@ -31,6 +29,66 @@ func PublishPackagePayloadFromJsonFile(metadata []byte, bytecode [][]byte) (*Tra
 		},
 		Function: "publish_package_txn",
 		ArgTypes: []TypeTag{},
-		Args:     [][]byte{metadataBytes, bytecodeBytes},
+		Args:     args,
 	}}, nil
 }
+
+// publishPackageArgs serializes the metadata and bytecode arguments of a package publish
+func publishPackageArgs(metadata []byte, bytecode [][]byte) ([][]byte, error) {
+	metadataBytes, err := bcs.SerializeBytes(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	bytecodeBytes, err := bcs.SerializeSingle(func(ser *bcs.Serializer) {
+		bcs.SerializeSequenceWithFunction(bytecode, ser, (*bcs.Serializer).WriteBytes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{metadataBytes, bytecodeBytes}, nil
+}
+
+// PublishPackagePayloadFromCliJson reads the JSON file generated by the Aptos CLI, and converts it to a payload
+//
+//	aptos move build-publish-payload --json-output-file publish.json
+//
+// The hex encoded metadata and bytecode are decoded straight from the JSON, without an intermediate string copy, as
+// packages can be large.
+func PublishPackagePayloadFromCliJson(r io.Reader) (*TransactionPayload, error) {
+	type cliArg struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	type cliPayload struct {
+		FunctionId string   `json:"function_id"`
+		Args       []cliArg `json:"args"`
+	}
+	payload := &cliPayload{}
+	err := json.NewDecoder(r).Decode(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse publish payload: %w", err)
+	}
+	if payload.FunctionId != "0x1::code::publish_package_txn" {
+		return nil, fmt.Errorf("unexpected publish payload function %s", payload.FunctionId)
+	}
+	if len(payload.Args) != 2 || payload.Args[0].Type != "hex" || payload.Args[1].Type != "hex" {
+		return nil, fmt.Errorf("publish payload must have two hex arguments, metadata and bytecode")
+	}
+
+	var metadata api.HexBytes
+	err = json.Unmarshal(payload.Args[0].Value, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse publish payload metadata: %w", err)
+	}
+	var modules []api.HexBytes
+	err = json.Unmarshal(payload.Args[1].Value, &modules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse publish payload bytecode: %w", err)
+	}
+	bytecode := make([][]byte, len(modules))
+	for i, module := range modules {
+		bytecode[i] = module
+	}
+	return PublishPackagePayloadFromJsonFile(metadata, bytecode)
+}
@@ -1 +1,29 @@
 package aptos
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishPackagePayloadFromCliJson(t *testing.T) {
+	cliJson := `{
+		"function_id": "0x1::code::publish_package_txn",
+		"type_args": [],
+		"args": [
+			{"type": "hex", "value": "0x0102"},
+			{"type": "hex", "value": ["0xa11ceb0b01", "0xa11ceb0b02"]}
+		]
+	}`
+	payload, err := PublishPackagePayloadFromCliJson(strings.NewReader(cliJson))
+	assert.NoError(t, err)
+	expected, err := PublishPackagePayloadFromJsonFile([]byte{0x01, 0x02}, [][]byte{{0xa1, 0x1c, 0xeb, 0x0b, 0x01}, {0xa1, 0x1c, 0xeb, 0x0b, 0x02}})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, payload)
+
+	_, err = PublishPackagePayloadFromCliJson(strings.NewReader(`{"function_id": "0x1::coin::transfer", "args": []}`))
+	assert.Error(t, err)
+	_, err = PublishPackagePayloadFromCliJson(strings.NewReader(`{"function_id": "0x1::code::publish_package_txn", "args": [{"type": "hex", "value": "0xzz"}, {"type": "hex", "value": []}]}`))
+	assert.Error(t, err)
+}
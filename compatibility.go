@@ -0,0 +1,182 @@
+package aptos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+)
+
+// CompatibilityVectorType is what a [CompatibilityVector] checks, and which inputs it has
+type CompatibilityVectorType string
+
+const (
+	CompatibilityAddress         CompatibilityVectorType = "address"          // CompatibilityAddress formats the "address" input, parsed relaxed, as its AIP-40 string
+	CompatibilityAuthKey         CompatibilityVectorType = "auth_key"         // CompatibilityAuthKey derives the authentication key of the "public_key" input with the "scheme" input, one of ed25519, single_key_ed25519, or secp256k1
+	CompatibilitySigningMessage  CompatibilityVectorType = "signing_message"  // CompatibilitySigningMessage builds the signing message of the BCS "raw_transaction" input
+	CompatibilityTransactionHash CompatibilityVectorType = "transaction_hash" // CompatibilityTransactionHash hashes the BCS "signed_transaction" input
+	CompatibilitySignature       CompatibilityVectorType = "signature"        // CompatibilitySignature signs the hex "message" input with the "private_key" input
+)
+
+// CompatibilityVector is a shared test vector, checking that the SDK produces the same bytes as other SDKs.  Inputs
+// and the expected output are strings, hex for bytes.
+//
+//	{"name": "ed25519 auth key", "type": "auth_key", "input": {"public_key": "0xde19...", "scheme": "ed25519"}, "expected": "0x978c..."}
+type CompatibilityVector struct {
+	Name     string                  `json:"name"`     // Name of the vector, for reporting
+	Type     CompatibilityVectorType `json:"type"`     // Type of check
+	Input    map[string]string       `json:"input"`    // Input by name, depending on the type
+	Expected string                  `json:"expected"` // Expected output
+}
+
+// CompatibilityResult is the result of running a [CompatibilityVector]
+type CompatibilityResult struct {
+	Vector CompatibilityVector // Vector that was run
+	Actual string              // Actual output, empty if Err is set
+	Err    error               // Err is set if the vector couldn't be run
+}
+
+// Passed tells if the actual output matched the expected output.  Hex is compared case-insensitively.
+func (result CompatibilityResult) Passed() bool {
+	return result.Err == nil && strings.EqualFold(result.Actual, result.Vector.Expected)
+}
+
+// LoadCompatibilityVectors reads a JSON array of [CompatibilityVector]s
+func LoadCompatibilityVectors(r io.Reader) ([]CompatibilityVector, error) {
+	vectors := make([]CompatibilityVector, 0)
+	err := json.NewDecoder(r).Decode(&vectors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compatibility vectors: %w", err)
+	}
+	return vectors, nil
+}
+
+// CompatibilityRunner runs [CompatibilityVector]s to check byte for byte compatibility with other SDKs.  Forks and
+// custom signers can run the same vectors against their own implementations.
+//
+//	vectors, err := LoadCompatibilityVectors(file)
+//	runner := &CompatibilityRunner{Signer: myHsmSigner}
+//	results, err := runner.Run(vectors)
+type CompatibilityRunner struct {
+	// Signer creates the signer for the private key of a [CompatibilitySignature] vector, defaults to parsing it with
+	// [crypto.ParseAIP80PrivateKey]
+	Signer func(privateKey string) (crypto.MessageSigner, error)
+}
+
+// Run runs every vector, returning the results in order and an error joining the failures
+func (runner *CompatibilityRunner) Run(vectors []CompatibilityVector) ([]CompatibilityResult, error) {
+	results := make([]CompatibilityResult, len(vectors))
+	failures := make([]error, 0)
+	for i, vector := range vectors {
+		actual, err := runner.run(vector)
+		results[i] = CompatibilityResult{Vector: vector, Actual: actual, Err: err}
+		switch {
+		case err != nil:
+			failures = append(failures, fmt.Errorf("vector %s: %w", vector.Name, err))
+		case !results[i].Passed():
+			failures = append(failures, fmt.Errorf("vector %s: expected %s, got %s", vector.Name, vector.Expected, actual))
+		}
+	}
+	return results, errors.Join(failures...)
+}
+
+// run computes the output of a vector
+func (runner *CompatibilityRunner) run(vector CompatibilityVector) (string, error) {
+	switch vector.Type {
+	case CompatibilityAddress:
+		address := AccountAddress{}
+		err := address.ParseStringRelaxed(vector.Input["address"])
+		if err != nil {
+			return "", err
+		}
+		return address.String(), nil
+	case CompatibilityAuthKey:
+		publicKey, err := compatibilityPublicKey(vector.Input["scheme"], vector.Input["public_key"])
+		if err != nil {
+			return "", err
+		}
+		return publicKey.AuthKey().ToHex(), nil
+	case CompatibilitySigningMessage:
+		rawTxn := &RawTransaction{}
+		err := compatibilityDeserialize(rawTxn, vector.Input["raw_transaction"])
+		if err != nil {
+			return "", err
+		}
+		message, err := rawTxn.SigningMessage()
+		if err != nil {
+			return "", err
+		}
+		return BytesToHex(message), nil
+	case CompatibilityTransactionHash:
+		signedTxn := &SignedTransaction{}
+		err := compatibilityDeserialize(signedTxn, vector.Input["signed_transaction"])
+		if err != nil {
+			return "", err
+		}
+		return signedTxn.Hash()
+	case CompatibilitySignature:
+		newSigner := runner.Signer
+		if newSigner == nil {
+			newSigner = func(privateKey string) (crypto.MessageSigner, error) {
+				return crypto.ParseAIP80PrivateKey(privateKey)
+			}
+		}
+		signer, err := newSigner(vector.Input["private_key"])
+		if err != nil {
+			return "", err
+		}
+		message, err := ParseHex(vector.Input["message"])
+		if err != nil {
+			return "", err
+		}
+		signature, err := signer.SignMessage(message)
+		if err != nil {
+			return "", err
+		}
+		return signature.ToHex(), nil
+	default:
+		return "", fmt.Errorf("unsupported vector type %s", vector.Type)
+	}
+}
+
+// compatibilityPublicKey parses a public key for an authentication key scheme
+func compatibilityPublicKey(scheme string, publicKeyHex string) (crypto.PublicKey, error) {
+	switch scheme {
+	case "ed25519":
+		publicKey := &crypto.Ed25519PublicKey{}
+		err := publicKey.FromHex(publicKeyHex)
+		if err != nil {
+			return nil, err
+		}
+		return publicKey, nil
+	case "single_key_ed25519":
+		publicKey := &crypto.Ed25519PublicKey{}
+		err := publicKey.FromHex(publicKeyHex)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.ToAnyPublicKey(publicKey)
+	case "secp256k1":
+		publicKey := &crypto.Secp256k1PublicKey{}
+		err := publicKey.FromHex(publicKeyHex)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.ToAnyPublicKey(publicKey)
+	default:
+		return nil, fmt.Errorf("unsupported auth key scheme %s", scheme)
+	}
+}
+
+// compatibilityDeserialize deserializes hex BCS input
+func compatibilityDeserialize(out bcs.Unmarshaler, input string) error {
+	bytes, err := ParseHex(input)
+	if err != nil {
+		return err
+	}
+	return bcs.Deserialize(out, bytes)
+}
@@ -0,0 +1,56 @@
+package aptos
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompatibilityVectors(t *testing.T) {
+	file, err := os.Open("testdata/compatibility_vectors.json")
+	assert.NoError(t, err)
+	defer file.Close()
+	vectors, err := LoadCompatibilityVectors(file)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, vectors)
+
+	results, err := (&CompatibilityRunner{}).Run(vectors)
+	assert.NoError(t, err)
+	for _, result := range results {
+		assert.True(t, result.Passed(), result.Vector.Name)
+	}
+}
+
+func TestCompatibilityRunner_Failures(t *testing.T) {
+	vectors := []CompatibilityVector{
+		{Name: "wrong", Type: CompatibilityAddress, Input: map[string]string{"address": "0x2"}, Expected: "0x3"},
+		{Name: "unsupported", Type: "multi_key", Expected: "0x1"},
+		{Name: "uppercase hex", Type: CompatibilityAddress, Input: map[string]string{"address": "0xb"}, Expected: "0xB"},
+	}
+	results, err := (&CompatibilityRunner{}).Run(vectors)
+	assert.ErrorContains(t, err, "vector wrong: expected 0x3, got 0x2")
+	assert.ErrorContains(t, err, "vector unsupported: unsupported vector type multi_key")
+	assert.False(t, results[0].Passed())
+	assert.False(t, results[1].Passed())
+	assert.True(t, results[2].Passed())
+
+	// Custom signers are checked against the same vectors
+	signed := make([]string, 0)
+	runner := &CompatibilityRunner{Signer: func(privateKey string) (crypto.MessageSigner, error) {
+		signed = append(signed, privateKey)
+		return crypto.ParseAIP80PrivateKey(privateKey)
+	}}
+	results, err = runner.Run([]CompatibilityVector{{
+		Name:     "ed25519 signature",
+		Type:     CompatibilitySignature,
+		Input:    map[string]string{"private_key": "ed25519-priv-0xc5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5", "message": "0x68656c6c6f20776f726c64"},
+		Expected: "0x9e653d56a09247570bb174a389e85b9226abd5c403ea6c504b386626a145158cd4efd66fc5e071c0e19538a96a05ddbda24d3c51e1e6a9dacc6bb1ce775cce07",
+	}})
+	assert.NoError(t, err)
+	assert.True(t, results[0].Passed())
+	assert.Len(t, signed, 1)
+	assert.True(t, strings.HasPrefix(signed[0], "ed25519-priv-"))
+}
@@ -0,0 +1,156 @@
+package crypto
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// DefaultRemoteSignerTimeout is the timeout for each request to a remote key e.g. [AwsKmsSigner] or [VaultTransitSigner]
+const DefaultRemoteSignerTimeout = 10 * time.Second
+
+// AwsKmsClient is the subset of the AWS KMS API used by [AwsKmsSigner].  It keeps the AWS SDK out of this module's
+// dependencies, adapting the aws-sdk-go-v2 client is a few lines:
+//
+//	type kmsAdapter struct{ client *kms.Client }
+//
+//	func (a kmsAdapter) GetPublicKey(ctx context.Context, keyId string) ([]byte, error) {
+//		out, err := a.client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: &keyId})
+//		if err != nil {
+//			return nil, err
+//		}
+//		return out.PublicKey, nil
+//	}
+//
+//	func (a kmsAdapter) Sign(ctx context.Context, keyId string, digest []byte) ([]byte, error) {
+//		out, err := a.client.Sign(ctx, &kms.SignInput{
+//			KeyId:            &keyId,
+//			Message:          digest,
+//			MessageType:      types.MessageTypeDigest,
+//			SigningAlgorithm: types.SigningAlgorithmSpecEcdsaSha256,
+//		})
+//		if err != nil {
+//			return nil, err
+//		}
+//		return out.Signature, nil
+//	}
+type AwsKmsClient interface {
+	// GetPublicKey returns the DER encoded SubjectPublicKeyInfo of the key
+	GetPublicKey(ctx context.Context, keyId string) (derPublicKey []byte, err error)
+	// Sign signs the 32 byte digest with ECDSA, returning the DER encoded signature
+	Sign(ctx context.Context, keyId string, digest []byte) (derSignature []byte, err error)
+}
+
+// AwsKmsSigner is a [RemoteSigner] for an ECC_SECG_P256K1 key in AWS KMS, for Secp256k1 SingleKey accounts.  The
+// private key never leaves KMS.
+//
+//	kmsSigner, err := crypto.NewAwsKmsSigner(kmsAdapter{client}, "alias/treasury")
+//	signer, err := crypto.NewRemoteSigner(kmsSigner)
+//
+// Implements:
+//   - [RemoteSigner]
+type AwsKmsSigner struct {
+	Client  AwsKmsClient  // Client is the KMS client
+	KeyId   string        // KeyId is the key ID, ARN, or alias of the key
+	Timeout time.Duration // Timeout is the timeout for each KMS request, defaults to [DefaultRemoteSignerTimeout]
+
+	publicKey *Secp256k1PublicKey
+}
+
+// NewAwsKmsSigner creates an [AwsKmsSigner], fetching the public key of the key from KMS
+func NewAwsKmsSigner(client AwsKmsClient, keyId string) (*AwsKmsSigner, error) {
+	signer := &AwsKmsSigner{Client: client, KeyId: keyId}
+	ctx, cancel := signer.context()
+	defer cancel()
+	der, err := client.GetPublicKey(ctx, keyId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of kms key %s: %w", keyId, err)
+	}
+	signer.publicKey, err = parseSecp256k1PublicKeyDer(der)
+	if err != nil {
+		return nil, fmt.Errorf("kms key %s: %w", keyId, err)
+	}
+	return signer, nil
+}
+
+// PublicKey returns the [Secp256k1PublicKey] of the KMS key
+//
+// Implements:
+//   - [RemoteSigner]
+func (s *AwsKmsSigner) PublicKey() VerifyingKey {
+	return s.publicKey
+}
+
+// SignMessage signs the SHA3-256 hash of the message in KMS, returning a low s [Secp256k1Signature]
+//
+// Implements:
+//   - [RemoteSigner]
+func (s *AwsKmsSigner) SignMessage(msg []byte) (Signature, error) {
+	ctx, cancel := s.context()
+	defer cancel()
+	der, err := s.Client.Sign(ctx, s.KeyId, util.Sha3256Hash([][]byte{msg}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with kms key %s: %w", s.KeyId, err)
+	}
+	var ecdsaSignature struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &ecdsaSignature)
+	if err != nil || len(rest) != 0 {
+		return nil, errors.New("invalid DER signature from kms")
+	}
+	if ecdsaSignature.R.Sign() <= 0 || ecdsaSignature.S.Sign() <= 0 || ecdsaSignature.R.BitLen() > 256 || ecdsaSignature.S.BitLen() > 256 {
+		return nil, errors.New("invalid DER signature from kms")
+	}
+	bytes := make([]byte, Secp256k1SignatureLength)
+	ecdsaSignature.R.FillBytes(bytes[:32])
+	ecdsaSignature.S.FillBytes(bytes[32:])
+	// KMS doesn't normalize s, which the Move VM requires
+	signature, _, err := NormalizeSecp256k1Signature(bytes)
+	return signature, err
+}
+
+func (s *AwsKmsSigner) context() (context.Context, context.CancelFunc) {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRemoteSignerTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// parseSecp256k1PublicKeyDer parses a DER SubjectPublicKeyInfo, which [x509.ParsePKIXPublicKey] doesn't support for
+// secp256k1
+func parseSecp256k1PublicKeyDer(der []byte) (*Secp256k1PublicKey, error) {
+	var info struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil || len(rest) != 0 {
+		return nil, errors.New("invalid DER public key")
+	}
+	var curve asn1.ObjectIdentifier
+	if !info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, errors.New("public key is not an ECDSA key")
+	}
+	if _, err = asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curve); err != nil || !curve.Equal(oidCurveSecp256k1) {
+		return nil, errors.New("public key is not a secp256k1 key")
+	}
+	publicKey := &Secp256k1PublicKey{}
+	err = publicKey.FromBytes(info.PublicKey.RightAlign())
+	if err != nil {
+		return nil, err
+	}
+	return publicKey, nil
+}
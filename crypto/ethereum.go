@@ -0,0 +1,85 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// EthereumAddressLength is the length of an Ethereum address in bytes
+const EthereumAddressLength = 20
+
+// NewSecp256k1PrivateKeyFromEthereum parses a raw hex secp256k1 private key as exported by EVM wallets e.g. 0xabc...
+//
+// EVM keys are never AIP-80 formatted, so no AIP-80 warning is printed.
+func NewSecp256k1PrivateKeyFromEthereum(hexStr string) (*Secp256k1PrivateKey, error) {
+	bytes, err := ParsePrivateKey(hexStr, PrivateKeyVariantSecp256k1, false)
+	if err != nil {
+		return nil, err
+	}
+	key := &Secp256k1PrivateKey{}
+	err = key.FromBytes(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// EthereumAddressBytes derives the 20 byte Ethereum address of a [Secp256k1PublicKey]
+//
+// This is the last 20 bytes of the Keccak-256 hash of the uncompressed public key, without the 0x04 prefix
+func (key *Secp256k1PublicKey) EthereumAddressBytes() []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(key.Bytes()[1:])
+	hash := hasher.Sum(nil)
+	return hash[len(hash)-EthereumAddressLength:]
+}
+
+// EthereumAddress derives the EIP-55 checksummed Ethereum address of a [Secp256k1PublicKey] e.g. 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed
+func (key *Secp256k1PublicKey) EthereumAddress() string {
+	return ToChecksumEthereumAddress(key.EthereumAddressBytes())
+}
+
+// ToChecksumEthereumAddress formats a 20 byte Ethereum address with the EIP-55 mixed case checksum
+func ToChecksumEthereumAddress(address []byte) string {
+	lower := hex.EncodeToString(address)
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(lower))
+	hash := hasher.Sum(nil)
+
+	out := []byte(lower)
+	for i, c := range out {
+		if c < 'a' {
+			continue
+		}
+		// Uppercase if the matching nibble of the hash is >= 8
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if nibble&0x0f >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// ParseEthereumAddress parses a hex Ethereum address, validating the EIP-55 checksum if the address is mixed case
+func ParseEthereumAddress(address string) ([]byte, error) {
+	trimmed := strings.TrimPrefix(address, "0x")
+	if len(trimmed) != EthereumAddressLength*2 {
+		return nil, fmt.Errorf("invalid ethereum address length %d", len(trimmed))
+	}
+	bytes, err := hex.DecodeString(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ethereum address: %w", err)
+	}
+	if trimmed != strings.ToLower(trimmed) && trimmed != strings.ToUpper(trimmed) {
+		if ToChecksumEthereumAddress(bytes) != "0x"+trimmed {
+			return nil, fmt.Errorf("invalid ethereum address checksum %s", address)
+		}
+	}
+	return bytes, nil
+}
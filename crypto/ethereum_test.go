@@ -0,0 +1,39 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// Hardhat / Anvil default account 0, a well known test key
+	testEthereumPrivateKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	testEthereumAddress    = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+)
+
+func TestEthereumAddress(t *testing.T) {
+	key, err := NewSecp256k1PrivateKeyFromEthereum(testEthereumPrivateKey)
+	assert.NoError(t, err)
+
+	pubKey := key.VerifyingKey().(*Secp256k1PublicKey)
+	assert.Equal(t, testEthereumAddress, pubKey.EthereumAddress())
+}
+
+func TestParseEthereumAddress(t *testing.T) {
+	bytes, err := ParseEthereumAddress(testEthereumAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, testEthereumAddress, ToChecksumEthereumAddress(bytes))
+
+	// All lower case skips the checksum
+	_, err = ParseEthereumAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	assert.NoError(t, err)
+
+	// Bad checksum
+	_, err = ParseEthereumAddress("0xF39fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	assert.Error(t, err)
+
+	// Bad length
+	_, err = ParseEthereumAddress("0xf39fd6")
+	assert.Error(t, err)
+}
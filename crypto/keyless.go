@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"errors"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+)
+
+//region KeylessPublicKey
+
+// KeylessPublicKey is the public key of a keyless account, which is tied to an OpenID Connect provider account rather
+// than a private key.  It is always wrapped in an [AnyPublicKey] on-chain.
+//
+// Keyless signatures are verified against zero knowledge proofs and the provider's JWKs on-chain, so they can't be
+// verified here.
+//
+// Implements:
+//   - [VerifyingKey]
+//   - [CryptoMaterial]
+//   - [bcs.Marshaler]
+//   - [bcs.Unmarshaler]
+//   - [bcs.Struct]
+type KeylessPublicKey struct {
+	IssVal string // IssVal is the issuer of the OpenID provider e.g. https://accounts.google.com
+	Idc    []byte // Idc is the identity commitment, which hides the user and application identifiers
+}
+
+//region KeylessPublicKey VerifyingKey implementation
+
+// Verify always returns false, keyless signatures can only be verified on-chain
+//
+// Implements:
+//   - [VerifyingKey]
+func (key *KeylessPublicKey) Verify([]byte, Signature) bool {
+	return false
+}
+
+//endregion
+
+//region KeylessPublicKey CryptoMaterial implementation
+
+// Bytes returns the BCS bytes of the [KeylessPublicKey]
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *KeylessPublicKey) Bytes() []byte {
+	val, _ := bcs.Serialize(key)
+	return val
+}
+
+// FromBytes sets the [KeylessPublicKey] from its BCS bytes
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *KeylessPublicKey) FromBytes(bytes []byte) (err error) {
+	return bcs.Deserialize(key, bytes)
+}
+
+// ToHex returns the hex string representation of the [KeylessPublicKey], with a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *KeylessPublicKey) ToHex() string {
+	return util.BytesToHex(key.Bytes())
+}
+
+// FromHex sets the [KeylessPublicKey] to the bytes represented by the hex string, with or without a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *KeylessPublicKey) FromHex(hexStr string) (err error) {
+	bytes, err := util.ParseHex(hexStr)
+	if err != nil {
+		return err
+	}
+	return key.FromBytes(bytes)
+}
+
+//endregion
+
+//region KeylessPublicKey bcs.Struct implementation
+
+// MarshalBCS serializes the [KeylessPublicKey] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (key *KeylessPublicKey) MarshalBCS(ser *bcs.Serializer) {
+	ser.WriteString(key.IssVal)
+	ser.WriteBytes(key.Idc)
+}
+
+// UnmarshalBCS deserializes the [KeylessPublicKey] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (key *KeylessPublicKey) UnmarshalBCS(des *bcs.Deserializer) {
+	key.IssVal = des.ReadString()
+	key.Idc = des.ReadBytes()
+	if des.Error() == nil && len(key.Idc) == 0 {
+		des.SetError(errors.New("keyless public key is missing its identity commitment"))
+	}
+}
+
+//endregion
+//endregion
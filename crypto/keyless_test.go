@@ -0,0 +1,34 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestKeylessPublicKey(t *testing.T) {
+	keyless := &KeylessPublicKey{IssVal: "https://accounts.google.com", Idc: []byte{1, 2, 3}}
+	publicKey, err := ToAnyPublicKey(keyless)
+	assert.NoError(t, err)
+	assert.Equal(t, AnyPublicKeyVariantKeyless, publicKey.Variant)
+
+	// Variant, issuer as a string, then the identity commitment as bytes
+	expected := append([]byte{3, 27}, []byte("https://accounts.google.com")...)
+	expected = append(expected, 3, 1, 2, 3)
+	assert.Equal(t, expected, publicKey.Bytes())
+
+	deserialized := &AnyPublicKey{}
+	assert.NoError(t, bcs.Deserialize(deserialized, expected))
+	assert.Equal(t, publicKey, deserialized)
+
+	// Keyless accounts derive their address like any other single key
+	hash := sha3.Sum256(append(expected, SingleKeyScheme))
+	assert.Equal(t, hash[:], publicKey.AuthKey()[:])
+
+	// Keyless signatures can't be verified off-chain
+	assert.False(t, publicKey.Verify([]byte("hello"), &AnySignature{}))
+
+	assert.Error(t, bcs.Deserialize(&KeylessPublicKey{}, []byte{0, 0}))
+}
@@ -0,0 +1,381 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// APDU instructions and parameters of the Aptos Ledger app
+const (
+	LedgerCla          = byte(0x5b) // LedgerCla is the APDU class of the Aptos app
+	LedgerInsVersion   = byte(0x03) // LedgerInsVersion gets the app version
+	LedgerInsAppName   = byte(0x04) // LedgerInsAppName gets the app name
+	LedgerInsPublicKey = byte(0x05) // LedgerInsPublicKey gets the public key for a derivation path
+	LedgerInsSign      = byte(0x06) // LedgerInsSign signs a message for a derivation path
+
+	ledgerP1NonConfirm = byte(0x00)
+	ledgerP1Confirm    = byte(0x01)
+	ledgerP1Start      = byte(0x00)
+	ledgerP2More       = byte(0x80)
+	ledgerP2Last       = byte(0x00)
+	ledgerMaxChunkSize = 255
+)
+
+// LedgerStatusOk is the APDU status word for success
+const LedgerStatusOk = uint16(0x9000)
+
+// DefaultLedgerDerivationPath is the derivation path of the first Aptos account on a Ledger, as used by the Aptos CLI and
+// wallets
+const DefaultLedgerDerivationPath = "m/44'/637'/0'/0'/0'"
+
+// ErrLedgerRejected is returned when the user rejects the request on the Ledger device
+var ErrLedgerRejected = errors.New("request rejected on the ledger device")
+
+// LedgerError is a non-success APDU status word returned by the Ledger device
+type LedgerError struct {
+	StatusWord uint16
+}
+
+// Error describes the status word
+func (e *LedgerError) Error() string {
+	switch e.StatusWord {
+	case 0x6985:
+		return ErrLedgerRejected.Error()
+	case 0x6d00, 0x6e00:
+		return fmt.Sprintf("ledger status 0x%04x: the Aptos app isn't open on the ledger device", e.StatusWord)
+	case 0x5515:
+		return fmt.Sprintf("ledger status 0x%04x: the ledger device is locked", e.StatusWord)
+	case 0x6a80, 0x6b00:
+		return fmt.Sprintf("ledger status 0x%04x: invalid request data, blind signing may need to be enabled", e.StatusWord)
+	default:
+		return fmt.Sprintf("ledger status 0x%04x", e.StatusWord)
+	}
+}
+
+// Is makes a rejection by the user match [ErrLedgerRejected] with errors.Is
+func (e *LedgerError) Is(target error) bool {
+	return target == ErrLedgerRejected && e.StatusWord == 0x6985
+}
+
+//region LedgerTransport
+
+// LedgerTransport exchanges APDUs with a Ledger device.  The response includes the two byte status word.
+type LedgerTransport interface {
+	Exchange(apdu []byte) (response []byte, err error)
+}
+
+// LedgerHIDTransport is a [LedgerTransport] over a USB HID device, which frames APDUs into 64 byte HID reports
+//
+// Opening the device is left to a HID library e.g. github.com/karalabe/hid, with vendor ID 0x2c97.  Some HID libraries
+// need a leading 0x00 report ID on each written report, which the Device should add.
+type LedgerHIDTransport struct {
+	Device  io.ReadWriter // Device is the opened HID device
+	Channel uint16        // Channel is the HID channel, defaults to 0x0101
+}
+
+const (
+	ledgerHIDPacketSize = 64
+	ledgerHIDTag        = byte(0x05)
+	ledgerHIDChannel    = uint16(0x0101)
+)
+
+// NewLedgerHIDTransport creates a [LedgerHIDTransport] over an opened HID device
+func NewLedgerHIDTransport(device io.ReadWriter) *LedgerHIDTransport {
+	return &LedgerHIDTransport{Device: device, Channel: ledgerHIDChannel}
+}
+
+// Exchange writes the APDU as HID reports, and reads the response
+//
+// Implements:
+//   - [LedgerTransport]
+func (t *LedgerHIDTransport) Exchange(apdu []byte) ([]byte, error) {
+	channel := t.Channel
+	if channel == 0 {
+		channel = ledgerHIDChannel
+	}
+
+	// The first report has the length of the APDU before its data
+	data := binary.BigEndian.AppendUint16(nil, uint16(len(apdu)))
+	data = append(data, apdu...)
+	for sequence := uint16(0); len(data) > 0; sequence++ {
+		packet := make([]byte, ledgerHIDPacketSize)
+		binary.BigEndian.PutUint16(packet[0:2], channel)
+		packet[2] = ledgerHIDTag
+		binary.BigEndian.PutUint16(packet[3:5], sequence)
+		n := copy(packet[5:], data)
+		data = data[n:]
+		if _, err := t.Device.Write(packet); err != nil {
+			return nil, fmt.Errorf("failed to write to ledger: %w", err)
+		}
+	}
+
+	var response []byte
+	length := -1
+	for sequence := uint16(0); length < 0 || len(response) < length; sequence++ {
+		packet := make([]byte, ledgerHIDPacketSize)
+		n, err := io.ReadFull(t.Device, packet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from ledger after %d bytes: %w", n, err)
+		}
+		if binary.BigEndian.Uint16(packet[0:2]) != channel || packet[2] != ledgerHIDTag {
+			return nil, errors.New("unexpected ledger HID channel or tag")
+		}
+		if binary.BigEndian.Uint16(packet[3:5]) != sequence {
+			return nil, fmt.Errorf("unexpected ledger HID sequence %d, expected %d", binary.BigEndian.Uint16(packet[3:5]), sequence)
+		}
+		payload := packet[5:]
+		if sequence == 0 {
+			length = int(binary.BigEndian.Uint16(payload[0:2]))
+			payload = payload[2:]
+		}
+		response = append(response, payload[:min(len(payload), length-len(response))]...)
+	}
+	return response, nil
+}
+
+//endregion
+
+//region LedgerSigner
+
+// LedgerSigner signs with an [Ed25519PrivateKey] held in the Aptos app on a Ledger hardware wallet.  Every signature
+// needs to be approved on the device, which displays the transaction.
+//
+//	transport := crypto.NewLedgerHIDTransport(device)
+//	signer, err := crypto.NewLedgerSigner(transport, crypto.DefaultLedgerDerivationPath)
+//	account, err := aptos.NewAccountFromSigner(signer)
+//
+// Implements:
+//   - [Signer]
+//   - [MessageSigner]
+type LedgerSigner struct {
+	Transport      LedgerTransport   // Transport is the connection to the device
+	DerivationPath []uint32          // DerivationPath is the hardened BIP-44 path of the key
+	PublicKey      *Ed25519PublicKey // PublicKey is the public key of the key on the device
+}
+
+// NewLedgerSigner creates a [LedgerSigner] for the derivation path e.g. [DefaultLedgerDerivationPath], fetching its
+// public key from the device
+func NewLedgerSigner(transport LedgerTransport, derivationPath string) (*LedgerSigner, error) {
+	path, err := ParseLedgerDerivationPath(derivationPath)
+	if err != nil {
+		return nil, err
+	}
+	signer := &LedgerSigner{Transport: transport, DerivationPath: path}
+	signer.PublicKey, err = signer.fetchPublicKey(false)
+	if err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// ParseLedgerDerivationPath parses a BIP-44 path e.g. m/44'/637'/0'/0'/0'.  Every index must be hardened, as
+// Ed25519 keys only support hardened derivation.
+func ParseLedgerDerivationPath(derivationPath string) ([]uint32, error) {
+	parts := strings.Split(derivationPath, "/")
+	if len(parts) < 2 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q, expected e.g. %s", derivationPath, DefaultLedgerDerivationPath)
+	}
+	path := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		index, found := strings.CutSuffix(part, "'")
+		if !found {
+			return nil, fmt.Errorf("invalid derivation path %q, every index must be hardened", derivationPath)
+		}
+		value, err := strconv.ParseUint(index, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: %w", derivationPath, err)
+		}
+		path = append(path, uint32(value)|0x80000000)
+	}
+	return path, nil
+}
+
+// VerifyAddress displays the address of the key on the device for the user to confirm, returning [ErrLedgerRejected]
+// if it doesn't match
+func (s *LedgerSigner) VerifyAddress() error {
+	publicKey, err := s.fetchPublicKey(true)
+	if err != nil {
+		return err
+	}
+	if s.PublicKey != nil && publicKey.ToHex() != s.PublicKey.ToHex() {
+		return errors.New("ledger public key changed, the wrong device may be connected")
+	}
+	return nil
+}
+
+// AppVersion returns the version of the Aptos app on the device e.g. 0.6.9
+func (s *LedgerSigner) AppVersion() (string, error) {
+	response, err := s.send(LedgerInsVersion, 0, 0, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(response) < 3 {
+		return "", errors.New("invalid ledger app version response")
+	}
+	return fmt.Sprintf("%d.%d.%d", response[0], response[1], response[2]), nil
+}
+
+// Sign signs a transaction signing message on the device and returns an associated [AccountAuthenticator]
+//
+// Implements:
+//   - [Signer]
+func (s *LedgerSigner) Sign(msg []byte) (authenticator *AccountAuthenticator, err error) {
+	signature, err := s.SignMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorEd25519,
+		Auth: &Ed25519Authenticator{
+			PubKey: s.PublicKey,
+			Sig:    signature.(*Ed25519Signature),
+		},
+	}, nil
+}
+
+// SignMessage sends the message to the device to sign.  Transactions are displayed for approval, other messages need
+// blind signing to be enabled in the app.
+//
+// Implements:
+//   - [Signer]
+//   - [MessageSigner]
+func (s *LedgerSigner) SignMessage(msg []byte) (signature Signature, err error) {
+	// Chunks are numbered in P1, starting at 1
+	if len(msg) > 255*ledgerMaxChunkSize {
+		return nil, fmt.Errorf("message too long for ledger %d > %d", len(msg), 255*ledgerMaxChunkSize)
+	}
+	_, err = s.send(LedgerInsSign, ledgerP1Start, ledgerP2More, s.pathBytes())
+	if err != nil {
+		return nil, err
+	}
+	var response []byte
+	for i := 0; i == 0 || i*ledgerMaxChunkSize < len(msg); i++ {
+		chunk := msg[i*ledgerMaxChunkSize : min((i+1)*ledgerMaxChunkSize, len(msg))]
+		p2 := ledgerP2More
+		if (i+1)*ledgerMaxChunkSize >= len(msg) {
+			p2 = ledgerP2Last
+		}
+		response, err = s.send(LedgerInsSign, byte(i+1), p2, chunk)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(response) < 1 || int(response[0]) != ed25519.SignatureSize || len(response) < 1+ed25519.SignatureSize {
+		return nil, errors.New("invalid ledger signature response")
+	}
+	sig := &Ed25519Signature{}
+	copy(sig.Inner[:], response[1:1+ed25519.SignatureSize])
+	if !s.PublicKey.Verify(msg, sig) {
+		return nil, errors.New("ledger signature doesn't match its public key")
+	}
+	return sig, nil
+}
+
+// EmptySignature creates an empty signature for use in simulation
+//
+// Implements:
+//   - [MessageSigner]
+func (s *LedgerSigner) EmptySignature() Signature {
+	return &Ed25519Signature{}
+}
+
+// VerifyingKey returns the [Ed25519PublicKey] of the key on the device
+//
+// Implements:
+//   - [MessageSigner]
+func (s *LedgerSigner) VerifyingKey() VerifyingKey {
+	return s.PublicKey
+}
+
+// SimulationAuthenticator creates a new [AccountAuthenticator] for simulation purposes, without using the device
+//
+// Implements:
+//   - [Signer]
+func (s *LedgerSigner) SimulationAuthenticator() *AccountAuthenticator {
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorEd25519,
+		Auth: &Ed25519Authenticator{
+			PubKey: s.PublicKey,
+			Sig:    &Ed25519Signature{},
+		},
+	}
+}
+
+// AuthKey gives the [AuthenticationKey] of the key on the device
+//
+// Implements:
+//   - [Signer]
+func (s *LedgerSigner) AuthKey() *AuthenticationKey {
+	out := &AuthenticationKey{}
+	out.FromPublicKey(s.PublicKey)
+	return out
+}
+
+// PubKey returns the [Ed25519PublicKey] of the key on the device
+//
+// Implements:
+//   - [Signer]
+func (s *LedgerSigner) PubKey() PublicKey {
+	return s.PublicKey
+}
+
+func (s *LedgerSigner) fetchPublicKey(display bool) (*Ed25519PublicKey, error) {
+	p1 := ledgerP1NonConfirm
+	if display {
+		p1 = ledgerP1Confirm
+	}
+	response, err := s.send(LedgerInsPublicKey, p1, ledgerP2Last, s.pathBytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(response) < 1 || len(response) < 1+int(response[0]) {
+		return nil, errors.New("invalid ledger public key response")
+	}
+	keyBytes := response[1 : 1+int(response[0])]
+	// Some app versions prefix the key with a format byte
+	if len(keyBytes) == ed25519.PublicKeySize+1 {
+		keyBytes = keyBytes[1:]
+	}
+	publicKey := &Ed25519PublicKey{}
+	err = publicKey.FromBytes(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ledger public key: %w", err)
+	}
+	return publicKey, nil
+}
+
+func (s *LedgerSigner) pathBytes() []byte {
+	out := []byte{byte(len(s.DerivationPath))}
+	for _, index := range s.DerivationPath {
+		out = binary.BigEndian.AppendUint32(out, index)
+	}
+	return out
+}
+
+// send sends a single APDU, returning the response without its status word
+func (s *LedgerSigner) send(ins byte, p1 byte, p2 byte, data []byte) ([]byte, error) {
+	if len(data) > ledgerMaxChunkSize {
+		return nil, fmt.Errorf("ledger APDU data too long %d > %d", len(data), ledgerMaxChunkSize)
+	}
+	apdu := append([]byte{LedgerCla, ins, p1, p2, byte(len(data))}, data...)
+	response, err := s.Transport.Exchange(apdu)
+	if err != nil {
+		return nil, err
+	}
+	if len(response) < 2 {
+		return nil, errors.New("ledger response is missing its status word")
+	}
+	statusWord := binary.BigEndian.Uint16(response[len(response)-2:])
+	if statusWord != LedgerStatusOk {
+		return nil, &LedgerError{StatusWord: statusWord}
+	}
+	return response[:len(response)-2], nil
+}
+
+//endregion
@@ -0,0 +1,160 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockLedgerApp simulates the Aptos app on a Ledger device
+type mockLedgerApp struct {
+	t       *testing.T
+	key     *Ed25519PrivateKey
+	reject  bool
+	pending []byte
+	path    []byte
+	chunks  int
+}
+
+func (m *mockLedgerApp) Exchange(apdu []byte) ([]byte, error) {
+	assert.Equal(m.t, LedgerCla, apdu[0])
+	assert.Equal(m.t, int(apdu[4]), len(apdu)-5)
+	ins, p1, p2, data := apdu[1], apdu[2], apdu[3], apdu[5:]
+	ok := []byte{0x90, 0x00}
+	switch ins {
+	case LedgerInsVersion:
+		return append([]byte{0, 6, 9}, ok...), nil
+	case LedgerInsPublicKey:
+		assert.Equal(m.t, []byte{5, 0x80, 0, 0, 44, 0x80, 0, 0x02, 0x7d, 0x80, 0, 0, 0, 0x80, 0, 0, 0, 0x80, 0, 0, 0}, data)
+		if p1 == 1 && m.reject {
+			return []byte{0x69, 0x85}, nil
+		}
+		publicKey := m.key.PubKey().Bytes()
+		response := append([]byte{byte(len(publicKey))}, publicKey...)
+		response = append(response, 32)
+		response = append(response, make([]byte, 32)...)
+		return append(response, ok...), nil
+	case LedgerInsSign:
+		if p1 == 0 {
+			m.path = data
+			m.pending = nil
+			m.chunks = 0
+			return ok, nil
+		}
+		m.chunks++
+		assert.Equal(m.t, byte(m.chunks), p1)
+		m.pending = append(m.pending, data...)
+		if p2 == 0x80 {
+			return ok, nil
+		}
+		if m.reject {
+			return []byte{0x69, 0x85}, nil
+		}
+		signature, _ := m.key.SignMessage(m.pending)
+		return append(append([]byte{64}, signature.Bytes()...), ok...), nil
+	}
+	return []byte{0x6d, 0x00}, nil
+}
+
+func TestParseLedgerDerivationPath(t *testing.T) {
+	path, err := ParseLedgerDerivationPath(DefaultLedgerDerivationPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{0x8000002c, 0x8000027d, 0x80000000, 0x80000000, 0x80000000}, path)
+
+	for _, invalid := range []string{"", "m", "44'/637'", "m/44'/637'/0", "m/44'/x'", "m/2147483648'"} {
+		_, err = ParseLedgerDerivationPath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestLedgerSigner(t *testing.T) {
+	key, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	app := &mockLedgerApp{t: t, key: key}
+
+	signer, err := NewLedgerSigner(app, DefaultLedgerDerivationPath)
+	assert.NoError(t, err)
+	assert.Equal(t, key.PubKey().ToHex(), signer.PubKey().ToHex())
+	assert.Equal(t, key.AuthKey(), signer.AuthKey())
+	assert.NoError(t, signer.VerifyAddress())
+	version, err := signer.AppVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.6.9", version)
+
+	// Messages are sent in chunks
+	for _, size := range []int{0, 10, 255, 256, 1000} {
+		msg := bytes.Repeat([]byte{byte(size)}, size)
+		auth, err := signer.Sign(msg)
+		assert.NoError(t, err)
+		assert.True(t, auth.Verify(msg))
+		assert.Equal(t, signer.pathBytes(), app.path)
+		assert.Equal(t, max(1, (size+254)/255), app.chunks)
+	}
+	assert.Equal(t, AccountAuthenticatorEd25519, signer.SimulationAuthenticator().Variant)
+
+	app.reject = true
+	_, err = signer.SignMessage([]byte("hello"))
+	assert.ErrorIs(t, err, ErrLedgerRejected)
+	assert.ErrorIs(t, signer.VerifyAddress(), ErrLedgerRejected)
+
+	var ledgerErr *LedgerError
+	_, err = signer.send(0x7f, 0, 0, nil)
+	assert.True(t, errors.As(err, &ledgerErr))
+	assert.Equal(t, uint16(0x6d00), ledgerErr.StatusWord)
+}
+
+// mockHIDDevice answers each APDU written as HID reports with the response of the app
+type mockHIDDevice struct {
+	app     LedgerTransport
+	written []byte
+	read    bytes.Buffer
+}
+
+func (d *mockHIDDevice) Write(packet []byte) (int, error) {
+	if binary.BigEndian.Uint16(packet[3:5]) == 0 {
+		d.written = nil
+	}
+	d.written = append(d.written, packet[5:]...)
+	length := int(binary.BigEndian.Uint16(d.written[0:2]))
+	if len(d.written)-2 < length {
+		return len(packet), nil
+	}
+	response, err := d.app.Exchange(d.written[2 : 2+length])
+	if err != nil {
+		return 0, err
+	}
+	data := binary.BigEndian.AppendUint16(nil, uint16(len(response)))
+	data = append(data, response...)
+	for sequence := uint16(0); len(data) > 0; sequence++ {
+		out := make([]byte, 64)
+		binary.BigEndian.PutUint16(out[0:2], 0x0101)
+		out[2] = 0x05
+		binary.BigEndian.PutUint16(out[3:5], sequence)
+		data = data[copy(out[5:], data):]
+		d.read.Write(out)
+	}
+	return len(packet), nil
+}
+
+func (d *mockHIDDevice) Read(p []byte) (int, error) {
+	return d.read.Read(p)
+}
+
+func TestLedgerHIDTransport(t *testing.T) {
+	key, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	transport := NewLedgerHIDTransport(&mockHIDDevice{app: &mockLedgerApp{t: t, key: key}})
+
+	signer, err := NewLedgerSigner(transport, DefaultLedgerDerivationPath)
+	assert.NoError(t, err)
+	assert.Equal(t, key.PubKey().ToHex(), signer.PubKey().ToHex())
+
+	// Signatures span multiple HID reports
+	msg := bytes.Repeat([]byte{0xab}, 300)
+	signature, err := signer.SignMessage(msg)
+	assert.NoError(t, err)
+	assert.True(t, key.PubKey().Verify(msg, signature))
+}
@@ -1,12 +1,19 @@
 package crypto
 
 import (
+	"bytes"
 	"crypto/ed25519"
+	"errors"
 	"fmt"
+	"sort"
+
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
 )
 
+// MaxMultiEd25519Keys is the maximum number of keys in a [MultiEd25519PublicKey]
+const MaxMultiEd25519Keys = 32
+
 //region MultiEd25519PublicKey
 
 // MultiEd25519PublicKey is the public key for off-chain multi-sig on Aptos with Ed25519 keys
@@ -25,30 +32,64 @@ type MultiEd25519PublicKey struct {
 	SignaturesRequired uint8
 }
 
+// NewMultiEd25519PublicKey creates a K-of-N [MultiEd25519PublicKey], the order of the keys determines the signature
+// indices and the [AuthenticationKey]
+func NewMultiEd25519PublicKey(pubKeys []*Ed25519PublicKey, signaturesRequired uint8) (*MultiEd25519PublicKey, error) {
+	err := validateMultiEd25519Threshold(len(pubKeys), signaturesRequired)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiEd25519PublicKey{
+		PubKeys:            pubKeys,
+		SignaturesRequired: signaturesRequired,
+	}, nil
+}
+
+// validateMultiEd25519Threshold checks the number of keys and signatures required are allowed on-chain
+func validateMultiEd25519Threshold(numKeys int, signaturesRequired uint8) error {
+	if numKeys == 0 || numKeys > MaxMultiEd25519Keys {
+		return fmt.Errorf("multi ed25519 public key must have between 1 and %d keys, got %d", MaxMultiEd25519Keys, numKeys)
+	}
+	if signaturesRequired == 0 || int(signaturesRequired) > numKeys {
+		return fmt.Errorf("multi ed25519 signatures required must be between 1 and %d, got %d", numKeys, signaturesRequired)
+	}
+	return nil
+}
+
 //region MultiEd25519PublicKey VerifyingKey implementation
 
 // Verify verifies the signature against the message
 //
-// # This function will return true if the number of verified signatures is greater than or equal to the number of required signatures
+// The signature must have one valid signature for each key in its bitmap, and at least [MultiEd25519PublicKey.SignaturesRequired]
+// signatures.
 //
 // Implements:
 //   - [VerifyingKey]
 func (key *MultiEd25519PublicKey) Verify(msg []byte, signature Signature) bool {
-	switch sig := signature.(type) {
-	case *MultiEd25519Signature:
-		verified := uint8(0)
-		// TODO: Verify with bitmap, and check that this works properly
-		for i, pubKey := range key.PubKeys {
-			if pubKey.Verify(msg, sig.Signatures[i]) {
-				verified++
-			}
-		}
-
-		return verified >= key.SignaturesRequired
-	default:
+	sig, ok := signature.(*MultiEd25519Signature)
+	if !ok || key.SignaturesRequired == 0 {
+		return false
+	}
+	indices := sig.Indices()
+	if len(indices) != len(sig.Signatures) || len(indices) < int(key.SignaturesRequired) {
 		return false
 	}
+	for i, index := range indices {
+		if int(index) >= len(key.PubKeys) || !key.PubKeys[index].Verify(msg, sig.Signatures[i]) {
+			return false
+		}
+	}
+	return true
+}
 
+// KeyIndex returns the index of the public key in the [MultiEd25519PublicKey], used for the signature bitmap
+func (key *MultiEd25519PublicKey) KeyIndex(pubKey *Ed25519PublicKey) (index uint8, ok bool) {
+	for i, candidate := range key.PubKeys {
+		if bytes.Equal(candidate.Bytes(), pubKey.Bytes()) {
+			return uint8(i), true
+		}
+	}
+	return 0, false
 }
 
 //endregion
@@ -101,8 +142,15 @@ func (key *MultiEd25519PublicKey) Bytes() []byte {
 //   - [CryptoMaterial]
 func (key *MultiEd25519PublicKey) FromBytes(bytes []byte) (err error) {
 	keyBytesLength := len(bytes)
+	if keyBytesLength == 0 || (keyBytesLength-1)%ed25519.PublicKeySize != 0 {
+		return fmt.Errorf("invalid multi ed25519 public key length %d", keyBytesLength)
+	}
 	numKeys := keyBytesLength / ed25519.PublicKeySize
 	signaturesRequired := bytes[keyBytesLength-1]
+	err = validateMultiEd25519Threshold(numKeys, signaturesRequired)
+	if err != nil {
+		return err
+	}
 
 	pubKeys := make([]*Ed25519PublicKey, numKeys)
 	for i := 0; i < numKeys; i++ {
@@ -261,8 +309,73 @@ const MultiEd25519BitmapLen = 4
 //   - [bcs.Unmarshaler]
 //   - [bcs.Struct]
 type MultiEd25519Signature struct {
-	Signatures []*Ed25519Signature
-	Bitmap     [MultiEd25519BitmapLen]byte
+	Signatures []*Ed25519Signature         // Signatures of the signing keys, in the order of their indices
+	Bitmap     [MultiEd25519BitmapLen]byte // Bitmap of the indices of the signing keys, starting from the leftmost bit
+}
+
+// IndexedEd25519Signature is a signature from the key at Index in a [MultiEd25519PublicKey]
+type IndexedEd25519Signature struct {
+	Index     uint8
+	Signature *Ed25519Signature
+}
+
+// NewMultiEd25519Signature assembles a threshold signature from individual signatures, in any order
+//
+//	sig, err := NewMultiEd25519Signature([]IndexedEd25519Signature{
+//		{Index: 2, Signature: aliceSig},
+//		{Index: 0, Signature: bobSig},
+//	})
+func NewMultiEd25519Signature(signatures []IndexedEd25519Signature) (*MultiEd25519Signature, error) {
+	sorted := make([]IndexedEd25519Signature, len(signatures))
+	copy(sorted, signatures)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Index < sorted[j].Index
+	})
+
+	multiSig := &MultiEd25519Signature{Signatures: make([]*Ed25519Signature, len(sorted))}
+	for i, sig := range sorted {
+		if sig.Signature == nil {
+			return nil, fmt.Errorf("missing signature for index %d", sig.Index)
+		}
+		err := multiSig.addIndex(sig.Index)
+		if err != nil {
+			return nil, err
+		}
+		multiSig.Signatures[i] = sig.Signature
+	}
+	return multiSig, nil
+}
+
+// ContainsKey tells if the key at index signed
+func (e *MultiEd25519Signature) ContainsKey(index uint8) bool {
+	if index >= MaxMultiEd25519Keys {
+		return false
+	}
+	numByte, numBit := KeyIndices(index)
+	return e.Bitmap[numByte]&(128>>numBit) != 0
+}
+
+// Indices returns the indices of the keys that signed, in increasing order
+func (e *MultiEd25519Signature) Indices() []uint8 {
+	indices := make([]uint8, 0, len(e.Signatures))
+	for i := uint8(0); i < MaxMultiEd25519Keys; i++ {
+		if e.ContainsKey(i) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+func (e *MultiEd25519Signature) addIndex(index uint8) error {
+	if index >= MaxMultiEd25519Keys {
+		return fmt.Errorf("index %d is greater than the maximum number of keys %d", index, MaxMultiEd25519Keys)
+	}
+	if e.ContainsKey(index) {
+		return fmt.Errorf("index %d already in bitmap", index)
+	}
+	numByte, numBit := KeyIndices(index)
+	e.Bitmap[numByte] |= 128 >> numBit
+	return nil
 }
 
 //region MultiEd25519Signature CryptoMaterial implementation
@@ -290,8 +403,11 @@ func (e *MultiEd25519Signature) Bytes() []byte {
 // Implements:
 //   - [CryptoMaterial]
 func (e *MultiEd25519Signature) FromBytes(bytes []byte) (err error) {
+	if len(bytes) < MultiEd25519BitmapLen || (len(bytes)-MultiEd25519BitmapLen)%ed25519.SignatureSize != 0 {
+		return fmt.Errorf("invalid multi ed25519 signature length %d", len(bytes))
+	}
 	signatures := make([]*Ed25519Signature, len(bytes)/ed25519.SignatureSize)
-	for i := 0; (i+1)*ed25519.SignatureSize < len(bytes); i++ {
+	for i := range signatures {
 		start := i * ed25519.SignatureSize
 		end := start + ed25519.SignatureSize
 		signatures[i] = &Ed25519Signature{}
@@ -347,6 +463,9 @@ func (e *MultiEd25519Signature) MarshalBCS(ser *bcs.Serializer) {
 //   - [bcs.Unmarshaler]
 func (e *MultiEd25519Signature) UnmarshalBCS(des *bcs.Deserializer) {
 	bytes := des.ReadBytes()
+	if des.Error() != nil {
+		return
+	}
 	err := e.FromBytes(bytes)
 	if err != nil {
 		des.SetError(err)
@@ -355,3 +474,123 @@ func (e *MultiEd25519Signature) UnmarshalBCS(des *bcs.Deserializer) {
 
 //endregion
 //endregion
+
+//region MultiEd25519Signer
+
+// MultiEd25519Signer signs for a K-of-N [MultiEd25519PublicKey] account with the private keys held locally.  At least
+// [MultiEd25519PublicKey.SignaturesRequired] keys are needed.
+//
+// When keys are held by different parties, sign with each key and combine the signatures with [NewMultiEd25519Signature]
+// instead.
+//
+// Implements:
+//   - [Signer]
+type MultiEd25519Signer struct {
+	PublicKey *MultiEd25519PublicKey // PublicKey is the public key of the account
+	Keys      []*Ed25519PrivateKey   // Keys are the private keys held, in the order of their index in PublicKey
+
+	indices []uint8
+}
+
+// NewMultiEd25519Signer creates a [MultiEd25519Signer], every private key must be part of the public key
+func NewMultiEd25519Signer(publicKey *MultiEd25519PublicKey, privateKeys ...*Ed25519PrivateKey) (*MultiEd25519Signer, error) {
+	if len(privateKeys) < int(publicKey.SignaturesRequired) {
+		return nil, fmt.Errorf("multi ed25519 signer needs %d keys, got %d", publicKey.SignaturesRequired, len(privateKeys))
+	}
+	indexed := make([]IndexedEd25519Signature, 0, len(privateKeys))
+	keysByIndex := make(map[uint8]*Ed25519PrivateKey, len(privateKeys))
+	for _, privateKey := range privateKeys {
+		index, ok := publicKey.KeyIndex(privateKey.PubKey().(*Ed25519PublicKey))
+		if !ok {
+			return nil, errors.New("private key is not part of the multi ed25519 public key")
+		}
+		if _, ok = keysByIndex[index]; ok {
+			return nil, fmt.Errorf("duplicate private key for index %d", index)
+		}
+		keysByIndex[index] = privateKey
+		indexed = append(indexed, IndexedEd25519Signature{Index: index})
+	}
+	sort.Slice(indexed, func(i, j int) bool {
+		return indexed[i].Index < indexed[j].Index
+	})
+
+	signer := &MultiEd25519Signer{PublicKey: publicKey}
+	for _, index := range indexed[:publicKey.SignaturesRequired] {
+		signer.indices = append(signer.indices, index.Index)
+		signer.Keys = append(signer.Keys, keysByIndex[index.Index])
+	}
+	return signer, nil
+}
+
+// Sign signs a transaction and returns an associated [AccountAuthenticator]
+//
+// Implements:
+//   - [Signer]
+func (s *MultiEd25519Signer) Sign(msg []byte) (authenticator *AccountAuthenticator, err error) {
+	signature, err := s.SignMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorMultiEd25519,
+		Auth: &MultiEd25519Authenticator{
+			PubKey: s.PublicKey,
+			Sig:    signature.(*MultiEd25519Signature),
+		},
+	}, nil
+}
+
+// SignMessage signs a message with exactly [MultiEd25519PublicKey.SignaturesRequired] keys
+//
+// Implements:
+//   - [Signer]
+func (s *MultiEd25519Signer) SignMessage(msg []byte) (Signature, error) {
+	signatures := make([]IndexedEd25519Signature, len(s.Keys))
+	for i, key := range s.Keys {
+		signature, err := key.SignMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		signatures[i] = IndexedEd25519Signature{Index: s.indices[i], Signature: signature.(*Ed25519Signature)}
+	}
+	return NewMultiEd25519Signature(signatures)
+}
+
+// SimulationAuthenticator creates a new [AccountAuthenticator] for simulation purposes, with empty signatures for the
+// keys that would sign
+//
+// Implements:
+//   - [Signer]
+func (s *MultiEd25519Signer) SimulationAuthenticator() *AccountAuthenticator {
+	signatures := make([]IndexedEd25519Signature, len(s.indices))
+	for i, index := range s.indices {
+		signatures[i] = IndexedEd25519Signature{Index: index, Signature: &Ed25519Signature{}}
+	}
+	// Indices are unique and in range, so this can't fail
+	signature, _ := NewMultiEd25519Signature(signatures)
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorMultiEd25519,
+		Auth: &MultiEd25519Authenticator{
+			PubKey: s.PublicKey,
+			Sig:    signature,
+		},
+	}
+}
+
+// AuthKey gives the [AuthenticationKey] of the [MultiEd25519PublicKey]
+//
+// Implements:
+//   - [Signer]
+func (s *MultiEd25519Signer) AuthKey() *AuthenticationKey {
+	return s.PublicKey.AuthKey()
+}
+
+// PubKey returns the [MultiEd25519PublicKey]
+//
+// Implements:
+//   - [Signer]
+func (s *MultiEd25519Signer) PubKey() PublicKey {
+	return s.PublicKey
+}
+
+//endregion
@@ -90,12 +90,109 @@ func createMultiEd25519Signature(t *testing.T, key1 *Ed25519PrivateKey, key2 *Ed
 	sig2, err := key2.SignMessage(message)
 	assert.NoError(t, err)
 
-	// TODO: This signature should be built easier, ergonomics to fix this late
-	return &MultiEd25519Signature{
-		Signatures: []*Ed25519Signature{
-			sig1.(*Ed25519Signature),
-			sig2.(*Ed25519Signature),
-		},
-		Bitmap: [4]byte([]byte("c0000000")),
+	signature, err := NewMultiEd25519Signature([]IndexedEd25519Signature{
+		{Index: 1, Signature: sig2.(*Ed25519Signature)},
+		{Index: 0, Signature: sig1.(*Ed25519Signature)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [4]byte{0xc0, 0, 0, 0}, signature.Bitmap)
+	return signature
+}
+
+func TestMultiEd25519Bitmap(t *testing.T) {
+	key1, key2, _, _, publicKey := createMultiEd25519Key(t)
+	message := []byte("hello world")
+	sig1, err := key1.SignMessage(message)
+	assert.NoError(t, err)
+	sig2, err := key2.SignMessage(message)
+	assert.NoError(t, err)
+
+	// Signatures in the wrong slot don't verify
+	swapped := &MultiEd25519Signature{
+		Signatures: []*Ed25519Signature{sig2.(*Ed25519Signature), sig1.(*Ed25519Signature)},
+		Bitmap:     [4]byte{0xc0, 0, 0, 0},
 	}
+	assert.False(t, publicKey.Verify(message, swapped))
+
+	// The bitmap must match the number of signatures, and meet the threshold
+	oneSig, err := NewMultiEd25519Signature([]IndexedEd25519Signature{{Index: 1, Signature: sig2.(*Ed25519Signature)}})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint8{1}, oneSig.Indices())
+	assert.False(t, publicKey.Verify(message, oneSig))
+	publicKey.SignaturesRequired = 1
+	assert.True(t, publicKey.Verify(message, oneSig))
+	oneSig.Bitmap = [4]byte{0xc0, 0, 0, 0}
+	assert.False(t, publicKey.Verify(message, oneSig))
+
+	// Indices out of range and duplicates are rejected
+	_, err = NewMultiEd25519Signature([]IndexedEd25519Signature{{Index: 32, Signature: sig1.(*Ed25519Signature)}})
+	assert.Error(t, err)
+	_, err = NewMultiEd25519Signature([]IndexedEd25519Signature{
+		{Index: 3, Signature: sig1.(*Ed25519Signature)},
+		{Index: 3, Signature: sig2.(*Ed25519Signature)},
+	})
+	assert.Error(t, err)
+
+	lastSig, err := NewMultiEd25519Signature([]IndexedEd25519Signature{{Index: 31, Signature: sig1.(*Ed25519Signature)}})
+	assert.NoError(t, err)
+	assert.Equal(t, [4]byte{0, 0, 0, 0x01}, lastSig.Bitmap)
+}
+
+func TestMultiEd25519Validation(t *testing.T) {
+	_, _, pubKey1, pubKey2, _ := createMultiEd25519Key(t)
+
+	_, err := NewMultiEd25519PublicKey(nil, 1)
+	assert.Error(t, err)
+	_, err = NewMultiEd25519PublicKey([]*Ed25519PublicKey{pubKey1, pubKey2}, 0)
+	assert.Error(t, err)
+	_, err = NewMultiEd25519PublicKey([]*Ed25519PublicKey{pubKey1, pubKey2}, 3)
+	assert.Error(t, err)
+	publicKey, err := NewMultiEd25519PublicKey([]*Ed25519PublicKey{pubKey1, pubKey2}, 1)
+	assert.NoError(t, err)
+
+	// Bad lengths and thresholds fail to deserialize instead of panicking
+	assert.Error(t, (&MultiEd25519PublicKey{}).FromBytes([]byte{}))
+	assert.Error(t, (&MultiEd25519PublicKey{}).FromBytes(publicKey.Bytes()[1:]))
+	badThreshold := publicKey.Bytes()
+	badThreshold[len(badThreshold)-1] = 3
+	assert.Error(t, (&MultiEd25519PublicKey{}).FromBytes(badThreshold))
+	assert.Error(t, (&MultiEd25519Signature{}).FromBytes([]byte{1, 2}))
+	assert.Error(t, (&MultiEd25519Signature{}).FromBytes(make([]byte, 65)))
+}
+
+func TestMultiEd25519Signer(t *testing.T) {
+	key1, key2, _, _, _ := createMultiEd25519Key(t)
+	key3, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	publicKey, err := NewMultiEd25519PublicKey([]*Ed25519PublicKey{
+		key1.PubKey().(*Ed25519PublicKey),
+		key2.PubKey().(*Ed25519PublicKey),
+		key3.PubKey().(*Ed25519PublicKey),
+	}, 2)
+	assert.NoError(t, err)
+
+	// Not enough keys, or keys not in the public key
+	_, err = NewMultiEd25519Signer(publicKey, key3)
+	assert.Error(t, err)
+	other, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	_, err = NewMultiEd25519Signer(publicKey, key3, other)
+	assert.Error(t, err)
+	_, err = NewMultiEd25519Signer(publicKey, key3, key3)
+	assert.Error(t, err)
+
+	signer, err := NewMultiEd25519Signer(publicKey, key3, key1)
+	assert.NoError(t, err)
+	assert.Equal(t, publicKey.AuthKey(), signer.AuthKey())
+
+	message := []byte("hello world")
+	auth, err := signer.Sign(message)
+	assert.NoError(t, err)
+	assert.Equal(t, AccountAuthenticatorMultiEd25519, auth.Variant)
+	assert.True(t, auth.Verify(message))
+	assert.Equal(t, []uint8{0, 2}, auth.Signature().(*MultiEd25519Signature).Indices())
+
+	simulation := signer.SimulationAuthenticator()
+	assert.False(t, simulation.Verify(message))
+	assert.Equal(t, []uint8{0, 2}, simulation.Signature().(*MultiEd25519Signature).Indices())
 }
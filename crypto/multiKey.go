@@ -1,6 +1,8 @@
 package crypto
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
@@ -23,6 +25,40 @@ type MultiKey struct {
 	SignaturesRequired uint8           // The number of signatures required to pass verification
 }
 
+// NewMultiKey creates a K-of-N [MultiKey] from any supported keys e.g. [Ed25519PublicKey], [Secp256k1PublicKey], or
+// [AnyPublicKey].  The order of the keys determines the signature indices and the [AuthenticationKey].
+func NewMultiKey(pubKeys []VerifyingKey, signaturesRequired uint8) (*MultiKey, error) {
+	if len(pubKeys) == 0 || len(pubKeys) > int(MaxMultiKeySignatures) {
+		return nil, fmt.Errorf("multi key must have between 1 and %d keys, got %d", MaxMultiKeySignatures, len(pubKeys))
+	}
+	if signaturesRequired == 0 || int(signaturesRequired) > len(pubKeys) {
+		return nil, fmt.Errorf("multi key signatures required must be between 1 and %d, got %d", len(pubKeys), signaturesRequired)
+	}
+	anyPubKeys := make([]*AnyPublicKey, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		anyPubKey, err := ToAnyPublicKey(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("multi key sub key %d: %w", i, err)
+		}
+		anyPubKeys[i] = anyPubKey
+	}
+	return &MultiKey{
+		PubKeys:            anyPubKeys,
+		SignaturesRequired: signaturesRequired,
+	}, nil
+}
+
+// KeyIndex returns the index of the public key in the [MultiKey], used for the signature bitmap
+func (key *MultiKey) KeyIndex(pubKey *AnyPublicKey) (index uint8, ok bool) {
+	target := pubKey.Bytes()
+	for i, candidate := range key.PubKeys {
+		if bytes.Equal(candidate.Bytes(), target) {
+			return uint8(i), true
+		}
+	}
+	return 0, false
+}
+
 //region MultiKey VerifyingKey implementation
 
 // Verify verifies the signature against the message
@@ -33,12 +69,16 @@ type MultiKey struct {
 func (key *MultiKey) Verify(msg []byte, signature Signature) bool {
 	switch sig := signature.(type) {
 	case *MultiKeySignature:
-		if key.SignaturesRequired > uint8(len(sig.Signatures)) {
+		indices := sig.Bitmap.Indices()
+		if key.SignaturesRequired == 0 || int(key.SignaturesRequired) > len(sig.Signatures) || len(indices) != len(sig.Signatures) {
 			return false
 		}
 
 		// Convert to individual authenticators, and verify
-		for sigIndex, keyIndex := range sig.Bitmap.Indices() {
+		for sigIndex, keyIndex := range indices {
+			if int(keyIndex) >= len(key.PubKeys) {
+				return false
+			}
 			authenticator := AccountAuthenticator{}
 			err := authenticator.FromKeyAndSignature(key.PubKeys[keyIndex], sig.Signatures[sigIndex])
 			if err != nil {
@@ -371,7 +411,7 @@ func (bm *MultiKeyBitmap) ContainsKey(index uint8) bool {
 	if int(numByte) >= len(bm.inner) {
 		return false
 	}
-	return (bm.inner[numByte] & (128 >> numBit)) == 1
+	return (bm.inner[numByte] & (128 >> numBit)) != 0
 }
 
 // AddKey adds the value to the map, returning an error if it is already added
@@ -438,3 +478,123 @@ func (bm *MultiKeyBitmap) UnmarshalBCS(des *bcs.Deserializer) {
 
 //endregion
 //endregion
+
+//region MultiKeySigner
+
+// MultiKeySigner signs for a K-of-N [MultiKey] account with the signers held locally.  At least
+// [MultiKey.SignaturesRequired] signers are needed.
+//
+// When keys are held by different parties, sign with each [SingleSigner] and combine the signatures with
+// [NewMultiKeySignature] instead.
+//
+// Implements:
+//   - [Signer]
+type MultiKeySigner struct {
+	PublicKey *MultiKey       // PublicKey is the public key of the account
+	Signers   []*SingleSigner // Signers are the signers used, in the order of their index in PublicKey
+
+	indices []uint8
+}
+
+// NewMultiKeySigner creates a [MultiKeySigner], every signer's key must be part of the public key
+func NewMultiKeySigner(publicKey *MultiKey, signers ...*SingleSigner) (*MultiKeySigner, error) {
+	if len(signers) < int(publicKey.SignaturesRequired) {
+		return nil, fmt.Errorf("multi key signer needs %d signers, got %d", publicKey.SignaturesRequired, len(signers))
+	}
+	signersByIndex := make(map[uint8]*SingleSigner, len(signers))
+	indices := make([]uint8, 0, len(signers))
+	for _, signer := range signers {
+		index, ok := publicKey.KeyIndex(signer.PubKey().(*AnyPublicKey))
+		if !ok {
+			return nil, errors.New("signer is not part of the multi key")
+		}
+		if _, ok = signersByIndex[index]; ok {
+			return nil, fmt.Errorf("duplicate signer for index %d", index)
+		}
+		signersByIndex[index] = signer
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	multiSigner := &MultiKeySigner{PublicKey: publicKey}
+	for _, index := range indices[:publicKey.SignaturesRequired] {
+		multiSigner.indices = append(multiSigner.indices, index)
+		multiSigner.Signers = append(multiSigner.Signers, signersByIndex[index])
+	}
+	return multiSigner, nil
+}
+
+// Sign signs a transaction and returns an associated [AccountAuthenticator]
+//
+// Implements:
+//   - [Signer]
+func (s *MultiKeySigner) Sign(msg []byte) (authenticator *AccountAuthenticator, err error) {
+	signature, err := s.SignMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorMultiKey,
+		Auth: &MultiKeyAuthenticator{
+			PubKey: s.PublicKey,
+			Sig:    signature.(*MultiKeySignature),
+		},
+	}, nil
+}
+
+// SignMessage signs a message with exactly [MultiKey.SignaturesRequired] signers
+//
+// Implements:
+//   - [Signer]
+func (s *MultiKeySigner) SignMessage(msg []byte) (Signature, error) {
+	signatures := make([]IndexedAnySignature, len(s.Signers))
+	for i, signer := range s.Signers {
+		signature, err := signer.SignMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		signatures[i] = IndexedAnySignature{Index: s.indices[i], Signature: signature.(*AnySignature)}
+	}
+	return NewMultiKeySignature(signatures)
+}
+
+// SimulationAuthenticator creates a new [AccountAuthenticator] for simulation purposes, with empty signatures for the
+// signers that would sign
+//
+// Implements:
+//   - [Signer]
+func (s *MultiKeySigner) SimulationAuthenticator() *AccountAuthenticator {
+	signatures := make([]IndexedAnySignature, len(s.Signers))
+	for i, signer := range s.Signers {
+		signatures[i] = IndexedAnySignature{Index: s.indices[i], Signature: signer.EmptySignature()}
+	}
+	// Indices are unique and in range, so this can't fail
+	signature, _ := NewMultiKeySignature(signatures)
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorMultiKey,
+		Auth: &MultiKeyAuthenticator{
+			PubKey: s.PublicKey,
+			Sig:    signature,
+		},
+	}
+}
+
+// AuthKey gives the [AuthenticationKey] of the [MultiKey]
+//
+// Implements:
+//   - [Signer]
+func (s *MultiKeySigner) AuthKey() *AuthenticationKey {
+	return s.PublicKey.AuthKey()
+}
+
+// PubKey returns the [MultiKey]
+//
+// Implements:
+//   - [Signer]
+func (s *MultiKeySigner) PubKey() PublicKey {
+	return s.PublicKey
+}
+
+//endregion
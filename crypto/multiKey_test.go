@@ -148,3 +148,92 @@ func createMultiKeySignature(t *testing.T, index1 uint8, key1 *SingleSigner, ind
 	assert.NoError(t, err)
 	return sig
 }
+
+func TestMultiKeyBitmap(t *testing.T) {
+	bitmap := MultiKeyBitmap{}
+	assert.NoError(t, bitmap.AddKey(0))
+	assert.NoError(t, bitmap.AddKey(9))
+	assert.True(t, bitmap.ContainsKey(0))
+	assert.False(t, bitmap.ContainsKey(1))
+	assert.True(t, bitmap.ContainsKey(9))
+	assert.Equal(t, []uint8{0, 9}, bitmap.Indices())
+	assert.Error(t, bitmap.AddKey(9))
+	assert.Error(t, bitmap.AddKey(MaxMultiKeySignatures))
+
+	bytes, err := bcs.Serialize(&bitmap)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2, 0x80, 0x40}, bytes)
+}
+
+func TestMultiKeyVerifyBitmap(t *testing.T) {
+	key1, key2, _, _, _, _, publicKey := createMultiKey(t)
+	message := []byte("hello world")
+
+	// Signatures must be in the slots of their keys
+	signature := createMultiKeySignature(t, 0, key1, 1, key2, message)
+	signature.Signatures[0], signature.Signatures[1] = signature.Signatures[1], signature.Signatures[0]
+	assert.False(t, publicKey.Verify(message, signature))
+
+	// The bitmap must match the number of signatures
+	signature = createMultiKeySignature(t, 0, key1, 1, key2, message)
+	assert.NoError(t, signature.Bitmap.AddKey(2))
+	assert.False(t, publicKey.Verify(message, signature))
+
+	// Keys must exist
+	signature, err := NewMultiKeySignature([]IndexedAnySignature{
+		{Index: 0, Signature: createMultiKeySignature(t, 0, key1, 1, key2, message).Signatures[0]},
+		{Index: 5, Signature: createMultiKeySignature(t, 0, key1, 1, key2, message).Signatures[1]},
+	})
+	assert.NoError(t, err)
+	assert.False(t, publicKey.Verify(message, signature))
+}
+
+func TestNewMultiKey(t *testing.T) {
+	key1, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	key2, err := GenerateSecp256k1Key()
+	assert.NoError(t, err)
+
+	_, err = NewMultiKey(nil, 1)
+	assert.Error(t, err)
+	_, err = NewMultiKey([]VerifyingKey{key1.PubKey(), key2.VerifyingKey()}, 3)
+	assert.Error(t, err)
+
+	publicKey, err := NewMultiKey([]VerifyingKey{key1.PubKey(), key2.VerifyingKey()}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, AnyPublicKeyVariantEd25519, publicKey.PubKeys[0].Variant)
+	assert.Equal(t, AnyPublicKeyVariantSecp256k1, publicKey.PubKeys[1].Variant)
+	assert.Equal(t, MultiKeyScheme, publicKey.Scheme())
+
+	index, ok := publicKey.KeyIndex(NewSingleSigner(key2).PubKey().(*AnyPublicKey))
+	assert.True(t, ok)
+	assert.Equal(t, uint8(1), index)
+}
+
+func TestMultiKeySigner(t *testing.T) {
+	key1, key2, key3, _, _, _, publicKey := createMultiKey(t)
+
+	_, err := NewMultiKeySigner(publicKey, key1)
+	assert.Error(t, err)
+	_, err = NewMultiKeySigner(publicKey, key1, key1)
+	assert.Error(t, err)
+	other, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	_, err = NewMultiKeySigner(publicKey, key1, NewSingleSigner(other))
+	assert.Error(t, err)
+
+	signer, err := NewMultiKeySigner(publicKey, key3, key2, key1)
+	assert.NoError(t, err)
+	assert.Equal(t, publicKey.AuthKey(), signer.AuthKey())
+
+	message := []byte("hello world")
+	auth, err := signer.Sign(message)
+	assert.NoError(t, err)
+	assert.Equal(t, AccountAuthenticatorMultiKey, auth.Variant)
+	assert.True(t, auth.Verify(message))
+	assert.Equal(t, []uint8{0, 1}, auth.Signature().(*MultiKeySignature).Bitmap.Indices())
+
+	simulation := signer.SimulationAuthenticator()
+	assert.False(t, simulation.Verify(message))
+	assert.Equal(t, []uint8{0, 1}, simulation.Signature().(*MultiKeySignature).Bitmap.Indices())
+}
@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
@@ -51,9 +52,11 @@ func FormatPrivateKey(privateKey any, keyType PrivateKeyVariant) (formattedStrin
 	return fmt.Sprintf("%s%s", aip80Prefix, hexStr), nil
 }
 
-// ParseHexInput parses a hex input that may be bytes, hex string, or an AIP-80 compliant string to bytes.
+// ParsePrivateKey parses a hex input that may be bytes, hex string, or an AIP-80 compliant string to bytes.
 //
-// You may optionally pass in a boolean to strictly enforce AIP-80 compliance.
+// You may optionally pass in a boolean to strictly enforce AIP-80 compliance.  In strict mode, only AIP-80 strings with
+// a 0x prefixed hex key are accepted.  In loose mode (false), plain hex strings are accepted silently.  If not given,
+// plain hex strings are accepted with a warning.  AIP-80 strings for another key type are always rejected.
 func ParsePrivateKey(value any, keyType PrivateKeyVariant, strict ...bool) (bytes []byte, err error) {
 	aip80Prefix := AIP80Prefixes[keyType]
 
@@ -67,6 +70,9 @@ func ParsePrivateKey(value any, keyType PrivateKeyVariant, strict ...bool) (byte
 
 	switch v := value.(type) {
 	case string:
+		if otherType, ok := aip80Variant(v); ok && otherType != keyType {
+			return nil, fmt.Errorf("private key is AIP-80 formatted as %s, expected %s", otherType, keyType)
+		}
 		if (strictness == nil || !*strictness) && !strings.HasPrefix(v, aip80Prefix) {
 			bytes, err := util.ParseHex(v)
 			if err != nil {
@@ -75,14 +81,17 @@ func ParsePrivateKey(value any, keyType PrivateKeyVariant, strict ...bool) (byte
 
 			// If strictness is not explicitly false, warn about non-AIP-80 compliance
 			if strictness == nil {
-				fmt.Printf("[Aptos SDK] It is recommended that private keys are AIP-80 compliant (https://github.com/aptos-foundation/AIPs/blob/main/aips/aip-80.md). You can fix the private key by formatting it with crypto.FormatPrivateKey")
+				slog.Warn("private key is not AIP-80 compliant, format it with crypto.FormatPrivateKey", "key_type", keyType, "aip", "https://github.com/aptos-foundation/AIPs/blob/main/aips/aip-80.md")
 			}
 
 			return bytes, nil
 		} else if strings.HasPrefix(v, aip80Prefix) {
 			// Parse for AIP-80 compliant String input
-			parts := strings.Split(v, "-")
-			return util.ParseHex(parts[2])
+			hexStr := strings.TrimPrefix(v, aip80Prefix)
+			if strictness != nil && *strictness && !strings.HasPrefix(hexStr, "0x") {
+				return nil, fmt.Errorf("invalid AIP-80 private key, key must be 0x prefixed hex")
+			}
+			return util.ParseHex(hexStr)
 		}
 		return nil, fmt.Errorf("invalid hex string input while parsing private key. Must be AIP-80 compliant string")
 	case []byte:
@@ -91,3 +100,56 @@ func ParsePrivateKey(value any, keyType PrivateKeyVariant, strict ...bool) (byte
 		return nil, fmt.Errorf("unsupported private key type: must be string or []byte")
 	}
 }
+
+// aip80Variant returns the key type of an AIP-80 string, ok is false if it has no known prefix
+func aip80Variant(value string) (keyType PrivateKeyVariant, ok bool) {
+	for variant, prefix := range AIP80Prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return variant, true
+		}
+	}
+	return "", false
+}
+
+// ParseAIP80PrivateKey parses an AIP-80 string of any key type, e.g. as exported by the Aptos CLI or TypeScript SDK
+//
+//	privateKey, err := ParseAIP80PrivateKey("ed25519-priv-0x...")
+//	// privateKey is an *Ed25519PrivateKey
+//
+// A plain hex string has no key type, and is only accepted in loose mode (strict false) as an [Ed25519PrivateKey],
+// the default key type of the Aptos CLI.  Strict mode is the default.
+func ParseAIP80PrivateKey(value string, strict ...bool) (privateKey MessageSigner, err error) {
+	if len(strict) > 1 {
+		return nil, fmt.Errorf("strictness must be a single boolean")
+	}
+	isStrict := len(strict) == 0 || strict[0]
+
+	keyType, ok := aip80Variant(value)
+	if !ok {
+		if isStrict {
+			return nil, fmt.Errorf("private key is not AIP-80 compliant, must start with one of %s or %s", AIP80Prefixes[PrivateKeyVariantEd25519], AIP80Prefixes[PrivateKeyVariantSecp256k1])
+		}
+		keyType = PrivateKeyVariantEd25519
+	}
+	bytes, err := ParsePrivateKey(value, keyType, isStrict)
+	if err != nil {
+		return nil, err
+	}
+
+	switch keyType {
+	case PrivateKeyVariantSecp256k1:
+		key := &Secp256k1PrivateKey{}
+		err = key.FromBytes(bytes)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	default:
+		key := &Ed25519PrivateKey{}
+		err = key.FromBytes(bytes)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+}
@@ -0,0 +1,61 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	// AIP-80 strings are accepted in every mode
+	for _, strict := range [][]bool{{}, {false}, {true}} {
+		bytes, err := ParsePrivateKey(testEd25519PrivateKey, PrivateKeyVariantEd25519, strict...)
+		assert.NoError(t, err)
+		assert.Len(t, bytes, 32)
+	}
+
+	// Plain hex is only accepted in loose mode
+	_, err := ParsePrivateKey(testEd25519PrivateKeyHex, PrivateKeyVariantEd25519, false)
+	assert.NoError(t, err)
+	_, err = ParsePrivateKey(testEd25519PrivateKeyHex, PrivateKeyVariantEd25519, true)
+	assert.Error(t, err)
+
+	// Strict mode requires the 0x after the prefix
+	unprefixed := "ed25519-priv-" + testEd25519PrivateKeyHex[2:]
+	_, err = ParsePrivateKey(unprefixed, PrivateKeyVariantEd25519, false)
+	assert.NoError(t, err)
+	_, err = ParsePrivateKey(unprefixed, PrivateKeyVariantEd25519, true)
+	assert.Error(t, err)
+
+	// A key of another type is never accepted
+	_, err = ParsePrivateKey(testSecp256k1PrivateKey, PrivateKeyVariantEd25519, false)
+	assert.ErrorContains(t, err, "secp256k1")
+
+	_, err = ParsePrivateKey(testEd25519PrivateKey, PrivateKeyVariantEd25519, true, true)
+	assert.Error(t, err)
+}
+
+func TestParseAIP80PrivateKey(t *testing.T) {
+	privateKey, err := ParseAIP80PrivateKey(testEd25519PrivateKey)
+	assert.NoError(t, err)
+	assert.IsType(t, &Ed25519PrivateKey{}, privateKey)
+	assert.Equal(t, testEd25519PublicKey, privateKey.VerifyingKey().ToHex())
+
+	privateKey, err = ParseAIP80PrivateKey(testSecp256k1PrivateKey)
+	assert.NoError(t, err)
+	assert.IsType(t, &Secp256k1PrivateKey{}, privateKey)
+	formatted, err := privateKey.(*Secp256k1PrivateKey).ToAIP80()
+	assert.NoError(t, err)
+	assert.Equal(t, testSecp256k1PrivateKey, formatted)
+
+	// Plain hex is treated as Ed25519 in loose mode only
+	_, err = ParseAIP80PrivateKey(testEd25519PrivateKeyHex)
+	assert.Error(t, err)
+	privateKey, err = ParseAIP80PrivateKey(testEd25519PrivateKeyHex, false)
+	assert.NoError(t, err)
+	assert.IsType(t, &Ed25519PrivateKey{}, privateKey)
+
+	// Bad lengths are caught
+	_, err = ParseAIP80PrivateKey("ed25519-priv-0x1234")
+	assert.Error(t, err)
+}
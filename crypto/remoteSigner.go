@@ -0,0 +1,164 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+)
+
+//region RemoteSigner
+
+// RemoteSigner is a key held outside the process e.g. in a KMS or HSM, which only exposes its public key and signs
+// messages.  Adapt it to a [Signer] with [NewRemoteSigner] to sign transactions.
+//
+// The public key must be an [Ed25519PublicKey] or [Secp256k1PublicKey].  Secp256k1 signers sign the SHA3-256 hash of
+// the message, the same as [Secp256k1PrivateKey].
+type RemoteSigner interface {
+	// PublicKey returns the public key of the remote key
+	PublicKey() VerifyingKey
+	// SignMessage signs a message with the remote key
+	SignMessage(msg []byte) (Signature, error)
+}
+
+// NewRemoteSigner adapts a [RemoteSigner] to a [Signer].  Ed25519 keys sign for legacy Ed25519 accounts, and
+// Secp256k1 keys sign for SingleKey accounts.  Use [NewSingleSigner] with a [RemoteMessageSigner] for Ed25519
+// SingleKey accounts.
+//
+//	signer, err := crypto.NewRemoteSigner(kmsSigner)
+//	account, err := aptos.NewAccountFromSigner(signer)
+func NewRemoteSigner(remote RemoteSigner) (Signer, error) {
+	switch remote.PublicKey().(type) {
+	case *Ed25519PublicKey:
+		return &RemoteEd25519Signer{Remote: remote}, nil
+	case *Secp256k1PublicKey:
+		return NewSingleSigner(&RemoteMessageSigner{Remote: remote}), nil
+	default:
+		return nil, fmt.Errorf("unsupported remote signer public key %T", remote.PublicKey())
+	}
+}
+
+// RemoteMessageSigner adapts a [RemoteSigner] to a [MessageSigner], so it can be used in a [SingleSigner].  Signatures
+// are checked against the public key, and high s Secp256k1 signatures are normalized, as the Move VM rejects them.
+//
+// Implements:
+//   - [MessageSigner]
+type RemoteMessageSigner struct {
+	Remote RemoteSigner // Remote is the remote key
+}
+
+// SignMessage signs a message with the remote key
+//
+// Implements:
+//   - [MessageSigner]
+func (s *RemoteMessageSigner) SignMessage(msg []byte) (Signature, error) {
+	signature, err := s.Remote.SignMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	if secp256k1Signature, ok := signature.(*Secp256k1Signature); ok && !secp256k1Signature.IsLowS() {
+		signature, _, err = NormalizeSecp256k1Signature(secp256k1Signature.Bytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !s.Remote.PublicKey().Verify(msg, signature) {
+		return nil, errors.New("remote signature doesn't match its public key")
+	}
+	return signature, nil
+}
+
+// EmptySignature creates an empty signature of the remote key's type for use in simulation
+//
+// Implements:
+//   - [MessageSigner]
+func (s *RemoteMessageSigner) EmptySignature() Signature {
+	switch s.Remote.PublicKey().(type) {
+	case *Secp256k1PublicKey:
+		return (&Secp256k1PrivateKey{}).EmptySignature()
+	default:
+		return &Ed25519Signature{}
+	}
+}
+
+// VerifyingKey returns the public key of the remote key
+//
+// Implements:
+//   - [MessageSigner]
+func (s *RemoteMessageSigner) VerifyingKey() VerifyingKey {
+	return s.Remote.PublicKey()
+}
+
+//endregion
+
+//region RemoteEd25519Signer
+
+// RemoteEd25519Signer signs for a legacy Ed25519 account with a remote [Ed25519PublicKey], see [NewRemoteSigner]
+//
+// Implements:
+//   - [Signer]
+type RemoteEd25519Signer struct {
+	Remote RemoteSigner // Remote is the remote key, with an [Ed25519PublicKey]
+}
+
+// Sign signs a transaction and returns an associated [AccountAuthenticator]
+//
+// Implements:
+//   - [Signer]
+func (s *RemoteEd25519Signer) Sign(msg []byte) (authenticator *AccountAuthenticator, err error) {
+	signature, err := s.SignMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	ed25519Signature, ok := signature.(*Ed25519Signature)
+	if !ok {
+		return nil, fmt.Errorf("remote signer returned %T, expected an ed25519 signature", signature)
+	}
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorEd25519,
+		Auth: &Ed25519Authenticator{
+			PubKey: s.Remote.PublicKey().(*Ed25519PublicKey),
+			Sig:    ed25519Signature,
+		},
+	}, nil
+}
+
+// SignMessage signs a message with the remote key, checking the signature against the public key
+//
+// Implements:
+//   - [Signer]
+func (s *RemoteEd25519Signer) SignMessage(msg []byte) (Signature, error) {
+	return (&RemoteMessageSigner{Remote: s.Remote}).SignMessage(msg)
+}
+
+// SimulationAuthenticator creates a new [AccountAuthenticator] for simulation purposes, without using the remote key
+//
+// Implements:
+//   - [Signer]
+func (s *RemoteEd25519Signer) SimulationAuthenticator() *AccountAuthenticator {
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorEd25519,
+		Auth: &Ed25519Authenticator{
+			PubKey: s.Remote.PublicKey().(*Ed25519PublicKey),
+			Sig:    &Ed25519Signature{},
+		},
+	}
+}
+
+// AuthKey gives the [AuthenticationKey] of the remote key
+//
+// Implements:
+//   - [Signer]
+func (s *RemoteEd25519Signer) AuthKey() *AuthenticationKey {
+	out := &AuthenticationKey{}
+	out.FromPublicKey(s.PubKey())
+	return out
+}
+
+// PubKey returns the [Ed25519PublicKey] of the remote key
+//
+// Implements:
+//   - [Signer]
+func (s *RemoteEd25519Signer) PubKey() PublicKey {
+	return s.Remote.PublicKey().(*Ed25519PublicKey)
+}
+
+//endregion
@@ -0,0 +1,220 @@
+package crypto
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+)
+
+// localRemoteSigner is a [RemoteSigner] backed by a local private key
+type localRemoteSigner struct {
+	key interface {
+		VerifyingKey() VerifyingKey
+		SignMessage(msg []byte) (Signature, error)
+	}
+}
+
+func (s *localRemoteSigner) PublicKey() VerifyingKey {
+	return s.key.VerifyingKey()
+}
+
+func (s *localRemoteSigner) SignMessage(msg []byte) (Signature, error) {
+	return s.key.SignMessage(msg)
+}
+
+// mockKms simulates AWS KMS with a secp256k1 key, which doesn't normalize s
+type mockKms struct {
+	t     *testing.T
+	key   *secp256k1.PrivateKey
+	highS bool
+}
+
+func (m *mockKms) GetPublicKey(_ context.Context, keyId string) ([]byte, error) {
+	assert.Equal(m.t, "alias/test", keyId)
+	curve, err := asn1.Marshal(oidCurveSecp256k1)
+	assert.NoError(m.t, err)
+	publicKey := m.key.PubKey().SerializeUncompressed()
+	return asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: curve}},
+		PublicKey: asn1.BitString{Bytes: publicKey, BitLength: len(publicKey) * 8},
+	})
+}
+
+func (m *mockKms) Sign(_ context.Context, _ string, digest []byte) ([]byte, error) {
+	assert.Len(m.t, digest, 32)
+	signature := ecdsa.Sign(m.key, digest)
+	if !m.highS {
+		return signature.Serialize(), nil
+	}
+	r := signature.R()
+	s := signature.S()
+	rBytes := r.Bytes()
+	sBytes := s.Bytes()
+	highS := new(big.Int).Sub(secp256k1.S256().N, new(big.Int).SetBytes(sBytes[:]))
+	return asn1.Marshal(struct{ R, S *big.Int }{new(big.Int).SetBytes(rBytes[:]), highS})
+}
+
+func TestNewRemoteSigner_Ed25519(t *testing.T) {
+	key, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+
+	signer, err := NewRemoteSigner(&localRemoteSigner{key: key})
+	assert.NoError(t, err)
+	assert.Equal(t, key.PubKey(), signer.PubKey())
+	assert.Equal(t, key.AuthKey(), signer.AuthKey())
+
+	msg := []byte("hello")
+	auth, err := signer.Sign(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, AccountAuthenticatorEd25519, auth.Variant)
+	assert.True(t, auth.Verify(msg))
+	assert.Equal(t, AccountAuthenticatorEd25519, signer.SimulationAuthenticator().Variant)
+}
+
+func TestNewRemoteSigner_Secp256k1(t *testing.T) {
+	key, err := GenerateSecp256k1Key()
+	assert.NoError(t, err)
+
+	signer, err := NewRemoteSigner(&localRemoteSigner{key: key})
+	assert.NoError(t, err)
+	localSigner := NewSingleSigner(key)
+	assert.Equal(t, localSigner.AuthKey(), signer.AuthKey())
+
+	msg := []byte("hello")
+	auth, err := signer.Sign(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, AccountAuthenticatorSingleSender, auth.Variant)
+	assert.True(t, auth.Verify(msg))
+}
+
+func TestNewRemoteSigner_WrongKey(t *testing.T) {
+	key, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	otherKey, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+
+	// A remote that signs with a different key than it claims is rejected
+	signer := &RemoteEd25519Signer{Remote: &mismatchedRemoteSigner{publicKey: key.VerifyingKey(), key: otherKey}}
+	_, err = signer.Sign([]byte("hello"))
+	assert.Error(t, err)
+}
+
+type mismatchedRemoteSigner struct {
+	publicKey VerifyingKey
+	key       *Ed25519PrivateKey
+}
+
+func (s *mismatchedRemoteSigner) PublicKey() VerifyingKey {
+	return s.publicKey
+}
+
+func (s *mismatchedRemoteSigner) SignMessage(msg []byte) (Signature, error) {
+	return s.key.SignMessage(msg)
+}
+
+func TestAwsKmsSigner(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey()
+	assert.NoError(t, err)
+	for _, highS := range []bool{false, true} {
+		kms := &mockKms{t: t, key: key, highS: highS}
+		kmsSigner, err := NewAwsKmsSigner(kms, "alias/test")
+		assert.NoError(t, err)
+		assert.Equal(t, (&Secp256k1PrivateKey{Inner: key}).VerifyingKey(), kmsSigner.PublicKey())
+
+		msg := []byte("hello")
+		signature, err := kmsSigner.SignMessage(msg)
+		assert.NoError(t, err)
+		assert.True(t, signature.(*Secp256k1Signature).IsLowS())
+		assert.True(t, kmsSigner.PublicKey().Verify(msg, signature))
+
+		signer, err := NewRemoteSigner(kmsSigner)
+		assert.NoError(t, err)
+		auth, err := signer.Sign(msg)
+		assert.NoError(t, err)
+		assert.True(t, auth.Verify(msg))
+	}
+}
+
+func TestAwsKmsSigner_NotSecp256k1(t *testing.T) {
+	// A P-256 key, which KMS also supports
+	p256, err := asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+	assert.NoError(t, err)
+	der, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: p256}},
+		PublicKey: asn1.BitString{Bytes: make([]byte, 65), BitLength: 65 * 8},
+	})
+	assert.NoError(t, err)
+	_, err = parseSecp256k1PublicKeyDer(der)
+	assert.Error(t, err)
+}
+
+func TestVaultTransitSigner(t *testing.T) {
+	key, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Vault-Token"))
+		assert.Equal(t, "ns", r.Header.Get("X-Vault-Namespace"))
+		switch r.URL.Path {
+		case "/v1/transit/keys/test":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+				"type":           "ed25519",
+				"latest_version": 2,
+				"keys": map[string]any{
+					"1": map[string]any{"public_key": base64.StdEncoding.EncodeToString(make([]byte, 32))},
+					"2": map[string]any{"public_key": base64.StdEncoding.EncodeToString(key.PubKey().Bytes())},
+				},
+			}})
+		case "/v1/transit/sign/test":
+			var request struct {
+				Input      string `json:"input"`
+				KeyVersion int    `json:"key_version"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, 2, request.KeyVersion)
+			msg, err := base64.StdEncoding.DecodeString(request.Input)
+			assert.NoError(t, err)
+			signature, err := key.SignMessage(msg)
+			assert.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+				"signature": "vault:v2:" + base64.StdEncoding.EncodeToString(signature.Bytes()),
+			}})
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	vaultSigner := &VaultTransitSigner{Address: mockServer.URL, Token: "token", Namespace: "ns", KeyName: "test"}
+	assert.NoError(t, vaultSigner.Refresh())
+	assert.Equal(t, 2, vaultSigner.KeyVersion)
+	assert.Equal(t, key.PubKey(), vaultSigner.PublicKey())
+
+	signer, err := NewRemoteSigner(vaultSigner)
+	assert.NoError(t, err)
+	msg := util.Sha3256Hash([][]byte{[]byte("hello")})
+	auth, err := signer.Sign(msg)
+	assert.NoError(t, err)
+	assert.True(t, auth.Verify(msg))
+
+	vaultSigner.KeyName = "missing"
+	_, err = vaultSigner.SignMessage(msg)
+	assert.ErrorContains(t, err, "403")
+}
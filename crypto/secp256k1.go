@@ -72,6 +72,20 @@ func (key *Secp256k1PrivateKey) SignMessage(msg []byte) (sig Signature, err erro
 	return &Secp256k1Signature{signature}, nil
 }
 
+// SignMessageWithRecoveryId signs a message the same as [Secp256k1PrivateKey.SignMessage], and also returns the
+// recovery id (0-3) needed by [Secp256k1Signature.RecoverPublicKey].  The signature always has a low s.
+func (key *Secp256k1PrivateKey) SignMessageWithRecoveryId(msg []byte) (sig *Secp256k1Signature, recoveryId byte, err error) {
+	hash := util.Sha3256Hash([][]byte{msg})
+	// The compact format is [27 + recovery id][r][s], for an uncompressed public key
+	compact := ecdsa.SignCompact(key.Inner, hash, false)
+	sig = &Secp256k1Signature{}
+	err = sig.FromBytes(compact[1:])
+	if err != nil {
+		return nil, 0, err
+	}
+	return sig, compact[0] - 27, nil
+}
+
 //endregion
 
 //region Secp256k1PrivateKey CryptoMaterial
@@ -160,6 +174,10 @@ type Secp256k1PublicKey struct {
 func (key *Secp256k1PublicKey) Verify(msg []byte, sig Signature) bool {
 	switch sig := sig.(type) {
 	case *Secp256k1Signature:
+		// The Move VM rejects malleable signatures, so they must be rejected here as well
+		if sig.Inner == nil || !sig.IsLowS() {
+			return false
+		}
 		// Verification requires to pass the SHA-256 hash of the message
 		hash := util.Sha3256Hash([][]byte{msg})
 		return sig.Inner.Verify(hash, key.Inner)
@@ -330,10 +348,13 @@ type Secp256k1Signature struct {
 
 // RecoverPublicKey recovers the public key from the signature and message
 //
-// If you know the recovery bit (0-4), please provide it, otherwise, use [RecoverSecp256k1PublicKeyWithAuthenticationKey]
+// If you know the recovery bit (0-3), please provide it, otherwise, use [RecoverSecp256k1PublicKeyWithAuthenticationKey]
 //
 // Note that this only applies to an [Secp256k1Signature], all other signatures are not recoverable
 func (e *Secp256k1Signature) RecoverPublicKey(message []byte, recoveryBit byte) (pubKey *Secp256k1PublicKey, err error) {
+	if recoveryBit > 3 {
+		return nil, fmt.Errorf("invalid secp256k1 recovery id %d, expected 0-3", recoveryBit)
+	}
 	hash := util.Sha3256Hash([][]byte{message})
 	return e.recoverSecp256k1PublicKey(hash, recoveryBit)
 }
@@ -402,25 +423,61 @@ func (e *Secp256k1Signature) FromBytes(bytes []byte) (err error) {
 	if len(bytes) != Secp256k1SignatureLength {
 		return fmt.Errorf("invalid secp256k1 signature size %d, expected %d", len(bytes), Secp256k1SignatureLength)
 	}
+	r, s, err := parseSecp256k1Scalars(bytes)
+	if err != nil {
+		return err
+	}
+
+	// Checks order of s to be low, as the Move VM rejects malleable signatures
+	if s.IsOverHalfOrder() {
+		return fmt.Errorf("invalid secp256k1 signature: s is over half order")
+	}
+	e.Inner = ecdsa.NewSignature(r, s)
+	return nil
+}
+
+// parseSecp256k1Scalars parses r and s, rejecting values that are zero or not less than the curve order, the same as
+// the on-chain parser
+func parseSecp256k1Scalars(bytes []byte) (r *secp256k1.ModNScalar, s *secp256k1.ModNScalar, err error) {
 	var rBytes [32]byte
 	copy(rBytes[:], bytes[0:32])
 	var sBytes [32]byte
 	copy(sBytes[:], bytes[32:64])
 
-	r := &secp256k1.ModNScalar{}
-	r.SetBytes(&rBytes)
-	s := &secp256k1.ModNScalar{}
-	s.SetBytes(&sBytes)
-
-	signature := ecdsa.NewSignature(r, s)
+	r = &secp256k1.ModNScalar{}
+	if r.SetBytes(&rBytes) != 0 || r.IsZero() {
+		return nil, nil, fmt.Errorf("invalid secp256k1 signature: r is out of range")
+	}
+	s = &secp256k1.ModNScalar{}
+	if s.SetBytes(&sBytes) != 0 || s.IsZero() {
+		return nil, nil, fmt.Errorf("invalid secp256k1 signature: s is out of range")
+	}
+	return r, s, nil
+}
 
-	// Checks order of s to be low
-	sTyped := signature.S()
-	if sTyped.IsOverHalfOrder() {
-		return fmt.Errorf("invalid secp256k1 signature: s is over half order")
+// NormalizeSecp256k1Signature parses a 64 byte r || s signature, replacing a high s with its low equivalent (n - s).
+//
+// Signatures from other signers e.g. an HSM or KMS may have a high s, which the Move VM rejects as malleable.  If s was
+// flipped, the recovery id of the signature flips as well (recoveryId ^ 1).
+func NormalizeSecp256k1Signature(bytes []byte) (sig *Secp256k1Signature, flipped bool, err error) {
+	if len(bytes) != Secp256k1SignatureLength {
+		return nil, false, fmt.Errorf("invalid secp256k1 signature size %d, expected %d", len(bytes), Secp256k1SignatureLength)
 	}
-	e.Inner = signature
-	return nil
+	r, s, err := parseSecp256k1Scalars(bytes)
+	if err != nil {
+		return nil, false, err
+	}
+	if s.IsOverHalfOrder() {
+		s.Negate()
+		flipped = true
+	}
+	return &Secp256k1Signature{Inner: ecdsa.NewSignature(r, s)}, flipped, nil
+}
+
+// IsLowS tells if s is at most half the curve order, the only form accepted by the Move VM
+func (e *Secp256k1Signature) IsLowS() bool {
+	s := e.Inner.S()
+	return !s.IsOverHalfOrder()
 }
 
 // ToHex returns the hex string representation of the [Secp256k1Signature], with a leading 0x
@@ -1,10 +1,12 @@
 package crypto
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -204,3 +206,62 @@ func TestSecp256k1Signature_RecoverPublicKeyFromSignatureWithRecoveryBit(t *test
 	assert.True(t, recoveredKey.Verify(message, signature))
 	assert.Equal(t, publicKey.ToHex(), recoveredKey.ToHex())
 }
+
+func TestSecp256k1SignMessageWithRecoveryId(t *testing.T) {
+	privateKey := &Secp256k1PrivateKey{}
+	assert.NoError(t, privateKey.FromHex(testSecp256k1PrivateKey))
+	message := []byte("hello world")
+
+	sig, recoveryId, err := privateKey.SignMessageWithRecoveryId(message)
+	assert.NoError(t, err)
+	assert.True(t, sig.IsLowS())
+	assert.LessOrEqual(t, recoveryId, byte(3))
+	assert.True(t, privateKey.VerifyingKey().Verify(message, sig))
+
+	recovered, err := sig.RecoverPublicKey(message, recoveryId)
+	assert.NoError(t, err)
+	assert.Equal(t, privateKey.VerifyingKey().ToHex(), recovered.ToHex())
+
+	_, err = sig.RecoverPublicKey(message, 4)
+	assert.Error(t, err)
+}
+
+func TestSecp256k1SignatureMalleability(t *testing.T) {
+	privateKey := &Secp256k1PrivateKey{}
+	assert.NoError(t, privateKey.FromHex(testSecp256k1PrivateKey))
+	message := []byte("hello world")
+	sig, recoveryId, err := privateKey.SignMessageWithRecoveryId(message)
+	assert.NoError(t, err)
+
+	// Build the malleable high s version of the same signature, n - s
+	r, s := sig.Inner.R(), sig.Inner.S()
+	s.Negate()
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	highS := append(rBytes[:], sBytes[:]...)
+
+	// Strict parsing rejects it, as does verification
+	assert.Error(t, (&Secp256k1Signature{}).FromBytes(highS))
+	assert.False(t, privateKey.VerifyingKey().Verify(message, &Secp256k1Signature{Inner: ecdsa.NewSignature(&r, &s)}))
+
+	// Normalizing gives back the low s signature, with a flipped recovery id
+	normalized, flipped, err := NormalizeSecp256k1Signature(highS)
+	assert.NoError(t, err)
+	assert.True(t, flipped)
+	assert.Equal(t, sig.Bytes(), normalized.Bytes())
+	recovered, err := normalized.RecoverPublicKey(message, recoveryId)
+	assert.NoError(t, err)
+	assert.Equal(t, privateKey.VerifyingKey().ToHex(), recovered.ToHex())
+
+	normalized, flipped, err = NormalizeSecp256k1Signature(sig.Bytes())
+	assert.NoError(t, err)
+	assert.False(t, flipped)
+	assert.Equal(t, sig.Bytes(), normalized.Bytes())
+
+	// r and s must be non-zero and less than the curve order
+	zero := make([]byte, Secp256k1SignatureLength)
+	assert.Error(t, (&Secp256k1Signature{}).FromBytes(zero))
+	overflow := append(bytes.Repeat([]byte{0xff}, 32), sBytes[:]...)
+	assert.Error(t, (&Secp256k1Signature{}).FromBytes(overflow))
+	_, _, err = NormalizeSecp256k1Signature(overflow)
+	assert.Error(t, err)
+}
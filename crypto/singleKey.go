@@ -36,11 +36,12 @@ func (key *SingleSigner) SignMessage(msg []byte) (Signature, error) {
 }
 
 func (key *SingleSigner) SignatureVariant() AnySignatureVariant {
+	// Signers are identified by their key, so signers other than private keys e.g. a [RemoteMessageSigner] work too
 	sigType := AnySignatureVariantEd25519
-	switch key.Signer.(type) {
-	case *Ed25519PrivateKey:
+	switch key.Signer.VerifyingKey().(type) {
+	case *Ed25519PublicKey:
 		sigType = AnySignatureVariantEd25519
-	case *Secp256k1PrivateKey:
+	case *Secp256k1PublicKey:
 		sigType = AnySignatureVariantSecp256k1
 	}
 	return sigType
@@ -102,10 +103,10 @@ func (key *SingleSigner) AuthKey() *AuthenticationKey {
 func (key *SingleSigner) PubKey() PublicKey {
 	innerPubKey := key.Signer.VerifyingKey()
 	keyType := AnyPublicKeyVariantEd25519
-	switch key.Signer.(type) {
-	case *Ed25519PrivateKey:
+	switch innerPubKey.(type) {
+	case *Ed25519PublicKey:
 		keyType = AnyPublicKeyVariantEd25519
-	case *Secp256k1PrivateKey:
+	case *Secp256k1PublicKey:
 		keyType = AnyPublicKeyVariantSecp256k1
 	}
 	return &AnyPublicKey{
@@ -125,6 +126,7 @@ type AnyPublicKeyVariant uint32
 const (
 	AnyPublicKeyVariantEd25519   AnyPublicKeyVariant = 0 // AnyPublicKeyVariantEd25519 is the variant for [Ed25519PublicKey]
 	AnyPublicKeyVariantSecp256k1 AnyPublicKeyVariant = 1 // AnyPublicKeyVariantSecp256k1 is the variant for [Secp256k1PublicKey]
+	AnyPublicKeyVariantKeyless   AnyPublicKeyVariant = 3 // AnyPublicKeyVariantKeyless is the variant for [KeylessPublicKey]
 )
 
 // AnyPublicKey is used by SingleSigner and MultiKey to allow for using different keys with the same structs
@@ -142,6 +144,8 @@ func ToAnyPublicKey(key VerifyingKey) (*AnyPublicKey, error) {
 		out.Variant = AnyPublicKeyVariantEd25519
 	case *Secp256k1PublicKey:
 		out.Variant = AnyPublicKeyVariantSecp256k1
+	case *KeylessPublicKey:
+		out.Variant = AnyPublicKeyVariantKeyless
 	case *AnyPublicKey:
 		// Passthrough for conversion
 		return key.(*AnyPublicKey), nil
@@ -254,6 +258,8 @@ func (key *AnyPublicKey) UnmarshalBCS(des *bcs.Deserializer) {
 		key.PubKey = &Ed25519PublicKey{}
 	case AnyPublicKeyVariantSecp256k1:
 		key.PubKey = &Secp256k1PublicKey{}
+	case AnyPublicKeyVariantKeyless:
+		key.PubKey = &KeylessPublicKey{}
 	default:
 		des.SetError(fmt.Errorf("unknown public key variant: %d", key.Variant))
 		return
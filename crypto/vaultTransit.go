@@ -0,0 +1,188 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultVaultTransitMount is the default mount path of the Vault transit secrets engine
+const DefaultVaultTransitMount = "transit"
+
+// VaultTransitSigner is a [RemoteSigner] for an ed25519 key in the HashiCorp Vault transit secrets engine, for Ed25519
+// accounts.  The private key never leaves Vault.
+//
+//	vaultSigner, err := crypto.NewVaultTransitSigner("https://vault.example.com:8200", token, "treasury")
+//	signer, err := crypto.NewRemoteSigner(vaultSigner)
+//
+// Implements:
+//   - [RemoteSigner]
+type VaultTransitSigner struct {
+	Address    string        // Address is the Vault server address e.g. https://vault.example.com:8200
+	Token      string        // Token is the Vault token, it needs the sign capability on the key
+	Namespace  string        // Namespace is the Vault Enterprise namespace, if any
+	Mount      string        // Mount is the mount path of the transit engine, defaults to [DefaultVaultTransitMount]
+	KeyName    string        // KeyName is the name of the transit key
+	KeyVersion int           // KeyVersion is the version of the key to sign with, the latest version when the signer was created
+	HttpClient *http.Client  // HttpClient is the client for requests, defaults to [http.DefaultClient]
+	Timeout    time.Duration // Timeout is the timeout for each Vault request, defaults to [DefaultRemoteSignerTimeout]
+
+	publicKey *Ed25519PublicKey
+}
+
+// NewVaultTransitSigner creates a [VaultTransitSigner] for the latest version of the key, fetching its public key from
+// Vault
+func NewVaultTransitSigner(address string, token string, keyName string) (*VaultTransitSigner, error) {
+	signer := &VaultTransitSigner{Address: address, Token: token, KeyName: keyName}
+	err := signer.Refresh()
+	if err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// Refresh fetches the latest version of the key and its public key, e.g. after the key is rotated in Vault.  Note that
+// rotating the key changes the public key, which needs to be rotated on-chain as well.
+func (s *VaultTransitSigner) Refresh() error {
+	var response struct {
+		Data struct {
+			Type          string `json:"type"`
+			LatestVersion int    `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+	err := s.request(http.MethodGet, "keys", nil, &response)
+	if err != nil {
+		return err
+	}
+	if response.Data.Type != "ed25519" {
+		return fmt.Errorf("vault transit key %s is %s, expected ed25519", s.KeyName, response.Data.Type)
+	}
+	key, ok := response.Data.Keys[strconv.Itoa(response.Data.LatestVersion)]
+	if !ok {
+		return fmt.Errorf("vault transit key %s is missing version %d", s.KeyName, response.Data.LatestVersion)
+	}
+	keyBytes, err := base64.StdEncoding.DecodeString(key.PublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key for vault transit key %s: %w", s.KeyName, err)
+	}
+	publicKey := &Ed25519PublicKey{}
+	err = publicKey.FromBytes(keyBytes)
+	if err != nil {
+		return fmt.Errorf("invalid public key for vault transit key %s: %w", s.KeyName, err)
+	}
+	s.publicKey = publicKey
+	s.KeyVersion = response.Data.LatestVersion
+	return nil
+}
+
+// PublicKey returns the [Ed25519PublicKey] of the transit key
+//
+// Implements:
+//   - [RemoteSigner]
+func (s *VaultTransitSigner) PublicKey() VerifyingKey {
+	return s.publicKey
+}
+
+// SignMessage signs the message with the transit key
+//
+// Implements:
+//   - [RemoteSigner]
+func (s *VaultTransitSigner) SignMessage(msg []byte) (Signature, error) {
+	request := map[string]any{
+		"input":       base64.StdEncoding.EncodeToString(msg),
+		"key_version": s.KeyVersion,
+	}
+	var response struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	err := s.request(http.MethodPost, "sign", request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signatures are of the form vault:v1:base64
+	parts := strings.Split(response.Data.Signature, ":")
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, errors.New("invalid vault transit signature")
+	}
+	sigBytes, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault transit signature: %w", err)
+	}
+	signature := &Ed25519Signature{}
+	err = signature.FromBytes(sigBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault transit signature: %w", err)
+	}
+	return signature, nil
+}
+
+// request makes a request to the transit engine for the key e.g. POST /v1/transit/sign/:name
+func (s *VaultTransitSigner) request(method string, action string, body any, out any) error {
+	mount := s.Mount
+	if mount == "" {
+		mount = DefaultVaultTransitMount
+	}
+	requestUrl, err := url.JoinPath(s.Address, "v1", mount, action, s.KeyName)
+	if err != nil {
+		return err
+	}
+	var reader io.Reader
+	if body != nil {
+		blob, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(blob)
+	}
+
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRemoteSignerTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", s.Token)
+	if s.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := s.HttpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault transit %s %s: %w", action, s.KeyName, err)
+	}
+	defer response.Body.Close()
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("vault transit %s %s: %w", action, s.KeyName, err)
+	}
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("vault transit %s %s: %d %s", action, s.KeyName, response.StatusCode, string(blob))
+	}
+	return json.Unmarshal(blob, out)
+}
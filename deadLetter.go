@@ -0,0 +1,185 @@
+package aptos
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// DeadLetterReason is why a payload was given up on by a [TransactionWorker]
+type DeadLetterReason string
+
+const (
+	DeadLetterRejected        DeadLetterReason = "rejected"         // DeadLetterRejected is a payload that couldn't be built, signed, or submitted in [TransactionWorker.MaxAttempts] attempts
+	DeadLetterExpired         DeadLetterReason = "expired"          // DeadLetterExpired is a submitted transaction that wasn't seen committing, check its hash before re-enqueuing as it may still commit
+	DeadLetterExecutionFailed DeadLetterReason = "execution_failed" // DeadLetterExecutionFailed is a committed transaction that failed to execute
+	DeadLetterCancelled       DeadLetterReason = "cancelled"        // DeadLetterCancelled is a payload that wasn't submitted before the worker was cancelled
+)
+
+// DeadLetter is a payload a [TransactionWorker] gave up on, with enough detail to reconcile it and re-enqueue it
+//
+//	worker.DeadLetters = DeadLetterFunc(func(letter DeadLetter) error {
+//		if letter.Reason == DeadLetterRejected {
+//			return retryLater(letter.Payload)
+//		}
+//		return alert(letter)
+//	})
+type DeadLetter struct {
+	Payload        TransactionBuildPayload // Payload is the payload as it was pushed to the worker
+	Reason         DeadLetterReason        // Reason the payload was given up on
+	Attempts       int                     // Attempts is the number of times the payload was built and submitted
+	SequenceNumber uint64                  // SequenceNumber of the last attempt, 0 if none was assigned
+	Hash           string                  // Hash of the submitted transaction, empty if it wasn't submitted
+	Transaction    *api.UserTransaction    // Transaction is the committed transaction, only for [DeadLetterExecutionFailed]
+	Err            error                   // Err is the last error
+}
+
+// DeadLetterSink receives the payloads a [TransactionWorker] gives up on.  Errors are logged, as the payload is
+// already reported as failed by an event.
+//
+// Implementations are [DeadLetterFunc], [DeadLetterChannel], and [DeadLetterJournal].
+type DeadLetterSink interface {
+	DeadLetter(letter DeadLetter) error
+}
+
+// DeadLetterFunc is a [DeadLetterSink] calling the function for each dead letter, it is called concurrently
+type DeadLetterFunc func(letter DeadLetter) error
+
+// DeadLetter calls the function
+//
+// Implements:
+//   - [DeadLetterSink]
+func (f DeadLetterFunc) DeadLetter(letter DeadLetter) error {
+	return f(letter)
+}
+
+// DeadLetterChannel is a [DeadLetterSink] sending each dead letter on the channel, blocking the worker until it is
+// received
+type DeadLetterChannel chan<- DeadLetter
+
+// DeadLetter sends the letter on the channel
+//
+// Implements:
+//   - [DeadLetterSink]
+func (c DeadLetterChannel) DeadLetter(letter DeadLetter) error {
+	c <- letter
+	return nil
+}
+
+// DeadLetterJournal is a [DeadLetterSink] appending each dead letter as a line of JSON, so payloads can be reconciled
+// after a restart with [ReadDeadLetterJournal].  [TransactionBuildPayload.Options] aren't recorded.
+//
+//	file, err := os.OpenFile("dead-letters.jsonl", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+//	worker.DeadLetters = NewDeadLetterJournal(file)
+type DeadLetterJournal struct {
+	mutex sync.Mutex
+	w     io.Writer
+}
+
+// NewDeadLetterJournal creates a [DeadLetterJournal] writing to w
+func NewDeadLetterJournal(w io.Writer) *DeadLetterJournal {
+	return &DeadLetterJournal{w: w}
+}
+
+// deadLetterJournalEntry is a line of a [DeadLetterJournal]
+type deadLetterJournalEntry struct {
+	Id             uint64                    `json:"id"`
+	Type           TransactionSubmissionType `json:"type"`
+	Payload        string                    `json:"payload"` // Payload is the BCS of the TransactionPayload as hex
+	Reason         DeadLetterReason          `json:"reason"`
+	Attempts       int                       `json:"attempts"`
+	SequenceNumber uint64                    `json:"sequence_number"`
+	Hash           string                    `json:"hash,omitempty"`
+	Version        uint64                    `json:"version,omitempty"`
+	VmStatus       string                    `json:"vm_status,omitempty"`
+	Error          string                    `json:"error,omitempty"`
+}
+
+// DeadLetter appends the letter to the journal
+//
+// Implements:
+//   - [DeadLetterSink]
+func (j *DeadLetterJournal) DeadLetter(letter DeadLetter) error {
+	payload, err := bcs.Serialize(&letter.Payload.Inner)
+	if err != nil {
+		return fmt.Errorf("failed to serialize dead letter %d: %w", letter.Payload.Id, err)
+	}
+	entry := deadLetterJournalEntry{
+		Id:             letter.Payload.Id,
+		Type:           letter.Payload.Type,
+		Payload:        BytesToHex(payload),
+		Reason:         letter.Reason,
+		Attempts:       letter.Attempts,
+		SequenceNumber: letter.SequenceNumber,
+		Hash:           letter.Hash,
+	}
+	if letter.Transaction != nil {
+		entry.Version = letter.Transaction.Version
+		entry.VmStatus = letter.Transaction.VmStatus
+	}
+	if letter.Err != nil {
+		entry.Error = letter.Err.Error()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	_, err = j.w.Write(append(line, '\n'))
+	return err
+}
+
+// ReadDeadLetterJournal reads the dead letters written by a [DeadLetterJournal].  [DeadLetter.Err] only keeps the
+// message of the original error, and [DeadLetter.Transaction] isn't restored.
+//
+//	letters, err := ReadDeadLetterJournal(file)
+//	for _, letter := range letters {
+//		err = worker.Submit(letter.Payload)
+//	}
+func ReadDeadLetterJournal(r io.Reader) ([]DeadLetter, error) {
+	letters := make([]DeadLetter, 0)
+	scanner := bufio.NewScanner(r)
+	// Payloads can be large e.g. publishing a package
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := deadLetterJournalEntry{}
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("dead letter journal line %d: %w", line, err)
+		}
+		payloadBytes, err := ParseHex(entry.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("dead letter journal line %d: %w", line, err)
+		}
+		letter := DeadLetter{
+			Payload:        TransactionBuildPayload{Id: entry.Id, Type: entry.Type},
+			Reason:         entry.Reason,
+			Attempts:       entry.Attempts,
+			SequenceNumber: entry.SequenceNumber,
+			Hash:           entry.Hash,
+		}
+		err = bcs.Deserialize(&letter.Payload.Inner, payloadBytes)
+		if err != nil {
+			return nil, fmt.Errorf("dead letter journal line %d: %w", line, err)
+		}
+		if entry.Error != "" {
+			letter.Err = errors.New(entry.Error)
+		}
+		letters = append(letters, letter)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
@@ -0,0 +1,206 @@
+package aptos
+
+import (
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// delegationPoolModule is the 0x1::delegation_pool module
+var delegationPoolModule = ModuleId{Address: AccountOne, Name: "delegation_pool"}
+
+// DelegationPoolAddStakePayload builds a 0x1::delegation_pool::add_stake of amount octas to the pool.  The pool charges
+// an add stake fee while it has pending active stake, which is refunded at the end of the epoch.
+func DelegationPoolAddStakePayload(pool AccountAddress, amount uint64) (*EntryFunction, error) {
+	return delegationPoolPayload("add_stake", pool, amount)
+}
+
+// DelegationPoolUnlockPayload builds a 0x1::delegation_pool::unlock of amount octas of active stake, making it
+// pending inactive until the end of the pool's lockup
+func DelegationPoolUnlockPayload(pool AccountAddress, amount uint64) (*EntryFunction, error) {
+	return delegationPoolPayload("unlock", pool, amount)
+}
+
+// DelegationPoolReactivateStakePayload builds a 0x1::delegation_pool::reactivate_stake of amount octas of pending
+// inactive stake, making it active again
+func DelegationPoolReactivateStakePayload(pool AccountAddress, amount uint64) (*EntryFunction, error) {
+	return delegationPoolPayload("reactivate_stake", pool, amount)
+}
+
+// DelegationPoolWithdrawPayload builds a 0x1::delegation_pool::withdraw of amount octas of inactive stake back to the
+// delegator
+func DelegationPoolWithdrawPayload(pool AccountAddress, amount uint64) (*EntryFunction, error) {
+	return delegationPoolPayload("withdraw", pool, amount)
+}
+
+// delegationPoolPayload builds a delegation pool entry function taking the pool and an amount
+func delegationPoolPayload(function string, pool AccountAddress, amount uint64) (*EntryFunction, error) {
+	amountBytes, err := bcs.SerializeU64(amount)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryFunction{
+		Module:   delegationPoolModule,
+		Function: function,
+		ArgTypes: []TypeTag{},
+		Args:     [][]byte{pool[:], amountBytes},
+	}, nil
+}
+
+// DelegatorStake is the stake of a delegator in a delegation pool, in octas
+type DelegatorStake struct {
+	Active          uint64 // Active stake is earning rewards
+	Inactive        uint64 // Inactive stake can be withdrawn
+	PendingInactive uint64 // PendingInactive stake is unlocking at the end of the lockup, and can be reactivated
+}
+
+// DelegationPoolClient builds and signs transactions for delegators of 0x1::delegation_pool pools, and queries their
+// stake.  Each transaction method takes the same options as [Client.BuildTransaction].
+//
+//	poolClient := NewDelegationPoolClient(client)
+//	signedTxn, err := poolClient.AddStake(delegator, pool, 11_00000000)
+//	stake, err := poolClient.DelegatorStake(pool, delegator.Address)
+type DelegationPoolClient struct {
+	aptosClient *Client
+}
+
+// NewDelegationPoolClient creates a client for 0x1::delegation_pool pools
+func NewDelegationPoolClient(client *Client) *DelegationPoolClient {
+	return &DelegationPoolClient{client}
+}
+
+// AddStake stakes amount octas in the pool, the minimum is 10 APT for a delegator's first stake
+func (client *DelegationPoolClient) AddStake(delegator TransactionSigner, pool AccountAddress, amount uint64, options ...any) (*SignedTransaction, error) {
+	payload, err := DelegationPoolAddStakePayload(pool, amount)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(delegator, payload, options)
+}
+
+// Unlock starts unlocking amount octas of active stake
+func (client *DelegationPoolClient) Unlock(delegator TransactionSigner, pool AccountAddress, amount uint64, options ...any) (*SignedTransaction, error) {
+	payload, err := DelegationPoolUnlockPayload(pool, amount)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(delegator, payload, options)
+}
+
+// ReactivateStake reactivates amount octas of pending inactive stake
+func (client *DelegationPoolClient) ReactivateStake(delegator TransactionSigner, pool AccountAddress, amount uint64, options ...any) (*SignedTransaction, error) {
+	payload, err := DelegationPoolReactivateStakePayload(pool, amount)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(delegator, payload, options)
+}
+
+// Withdraw withdraws amount octas of inactive stake
+func (client *DelegationPoolClient) Withdraw(delegator TransactionSigner, pool AccountAddress, amount uint64, options ...any) (*SignedTransaction, error) {
+	payload, err := DelegationPoolWithdrawPayload(pool, amount)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(delegator, payload, options)
+}
+
+// PoolExists tells if there is a delegation pool at the address
+func (client *DelegationPoolClient) PoolExists(pool AccountAddress, ledgerVersion ...uint64) (bool, error) {
+	vals, err := client.view("delegation_pool_exists", [][]byte{pool[:]}, 1, ledgerVersion)
+	if err != nil {
+		return false, err
+	}
+	exists, ok := vals[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("bad view return from node, delegation_pool_exists is %T", vals[0])
+	}
+	return exists, nil
+}
+
+// DelegatorStake returns the stake of the delegator in the pool
+func (client *DelegationPoolClient) DelegatorStake(pool AccountAddress, delegator AccountAddress, ledgerVersion ...uint64) (stake DelegatorStake, err error) {
+	vals, err := client.view("get_stake", [][]byte{pool[:], delegator[:]}, 3, ledgerVersion)
+	if err != nil {
+		return
+	}
+	amounts, err := viewU64s(vals)
+	if err != nil {
+		return
+	}
+	return DelegatorStake{Active: amounts[0], Inactive: amounts[1], PendingInactive: amounts[2]}, nil
+}
+
+// OperatorCommission returns the operator's commission of the pool's rewards in hundredths of a percent, e.g. 1000
+// is 10%
+func (client *DelegationPoolClient) OperatorCommission(pool AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	vals, err := client.view("operator_commission_percentage", [][]byte{pool[:]}, 1, ledgerVersion)
+	if err != nil {
+		return 0, err
+	}
+	amounts, err := viewU64s(vals)
+	if err != nil {
+		return 0, err
+	}
+	return amounts[0], nil
+}
+
+// AddStakeFee returns the fee in octas charged to add amount octas of stake to the pool
+func (client *DelegationPoolClient) AddStakeFee(pool AccountAddress, amount uint64, ledgerVersion ...uint64) (uint64, error) {
+	amountBytes, err := bcs.SerializeU64(amount)
+	if err != nil {
+		return 0, err
+	}
+	vals, err := client.view("get_add_stake_fee", [][]byte{pool[:], amountBytes}, 1, ledgerVersion)
+	if err != nil {
+		return 0, err
+	}
+	amounts, err := viewU64s(vals)
+	if err != nil {
+		return 0, err
+	}
+	return amounts[0], nil
+}
+
+// view calls a delegation pool view function, checking the number of return values
+func (client *DelegationPoolClient) view(function string, args [][]byte, returns int, ledgerVersion []uint64) ([]any, error) {
+	vals, err := client.aptosClient.View(&ViewPayload{
+		Module:   delegationPoolModule,
+		Function: function,
+		ArgTypes: []TypeTag{},
+		Args:     args,
+	}, ledgerVersion...)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) != returns {
+		return nil, fmt.Errorf("bad view return from node, %s returned %d values, expected %d", function, len(vals), returns)
+	}
+	return vals, nil
+}
+
+// sign builds and signs a transaction of the payload
+func (client *DelegationPoolClient) sign(sender TransactionSigner, payload *EntryFunction, options []any) (*SignedTransaction, error) {
+	rawTxn, err := client.aptosClient.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return rawTxn.SignedTransaction(sender)
+}
+
+// viewU64s converts the u64 strings returned by a view function
+func viewU64s(vals []any) ([]uint64, error) {
+	amounts := make([]uint64, len(vals))
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("bad view return from node, expected a u64 string, got %T", val)
+		}
+		amount, err := StrToUint64(str)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = amount
+	}
+	return amounts, nil
+}
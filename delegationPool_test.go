@@ -0,0 +1,79 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelegationPoolPayloads(t *testing.T) {
+	payload, err := DelegationPoolAddStakePayload(AccountTwo, 11_00000000)
+	assert.NoError(t, err)
+	assert.Equal(t, ModuleId{Address: AccountOne, Name: "delegation_pool"}, payload.Module)
+	assert.Equal(t, "add_stake", payload.Function)
+	assert.Equal(t, [][]byte{AccountTwo[:], {0x00, 0xab, 0x90, 0x41, 0, 0, 0, 0}}, payload.Args)
+
+	for function, build := range map[string]func(AccountAddress, uint64) (*EntryFunction, error){
+		"unlock":           DelegationPoolUnlockPayload,
+		"reactivate_stake": DelegationPoolReactivateStakePayload,
+		"withdraw":         DelegationPoolWithdrawPayload,
+	} {
+		payload, err = build(AccountTwo, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, function, payload.Function)
+		assert.Len(t, payload.Args, 2)
+	}
+
+	client, err := NewClient(LocalnetConfig)
+	assert.NoError(t, err)
+	delegator, err := NewEd25519Account()
+	assert.NoError(t, err)
+	signedTxn, err := NewDelegationPoolClient(client).Unlock(delegator, AccountTwo, 5, SequenceNumber(1), GasUnitPrice(100), MaxGasAmount(1000), ChainIdOption(4))
+	assert.NoError(t, err)
+	assert.Equal(t, "unlock", signedTxn.Transaction.Payload.Payload.(*EntryFunction).Function)
+}
+
+func TestDelegationPoolClient_Views(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/view", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		// The function name is in the BCS body
+		switch {
+		case bytes.Contains(body, []byte("get_stake")):
+			_ = json.NewEncoder(w).Encode([]any{"100", "20", "3"})
+		case bytes.Contains(body, []byte("operator_commission_percentage")):
+			_ = json.NewEncoder(w).Encode([]any{"1000"})
+		case bytes.Contains(body, []byte("delegation_pool_exists")):
+			_ = json.NewEncoder(w).Encode([]any{true})
+		default:
+			_ = json.NewEncoder(w).Encode([]any{})
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL})
+	assert.NoError(t, err)
+	poolClient := NewDelegationPoolClient(client)
+
+	stake, err := poolClient.DelegatorStake(AccountTwo, AccountThree)
+	assert.NoError(t, err)
+	assert.Equal(t, DelegatorStake{Active: 100, Inactive: 20, PendingInactive: 3}, stake)
+
+	commission, err := poolClient.OperatorCommission(AccountTwo)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), commission)
+
+	exists, err := poolClient.PoolExists(AccountTwo)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	// Wrong number of return values
+	_, err = poolClient.AddStakeFee(AccountTwo, 1)
+	assert.Error(t, err)
+}
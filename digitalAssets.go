@@ -0,0 +1,158 @@
+package aptos
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultDigitalAssetsPageSize is the number of digital assets returned by [IndexerClient.GetOwnedDigitalAssets] if no
+// limit is given
+const DefaultDigitalAssetsPageSize = 100
+
+// DigitalAssetStandard is the token standard of a digital asset, as reported by the indexer
+type DigitalAssetStandard string
+
+const (
+	DigitalAssetStandardV1 DigitalAssetStandard = "v1" // DigitalAssetStandardV1 is the legacy 0x3::token standard
+	DigitalAssetStandardV2 DigitalAssetStandard = "v2" // DigitalAssetStandardV2 is the object based 0x4::token standard
+)
+
+// OwnedDigitalAssetsOptions filters and pages [IndexerClient.GetOwnedDigitalAssets]
+type OwnedDigitalAssetsOptions struct {
+	CollectionId  *AccountAddress      // CollectionId only returns tokens in the collection, if set
+	TokenStandard DigitalAssetStandard // TokenStandard only returns tokens of the standard, if set
+	Limit         int                  // Limit is the page size, defaults to [DefaultDigitalAssetsPageSize]
+	Offset        int                  // Offset is the number of tokens to skip, for paging
+}
+
+// OwnedDigitalAsset is a digital asset (NFT) held by an account, with its token and collection data
+type OwnedDigitalAsset struct {
+	TokenDataId              AccountAddress       // TokenDataId identifies the token, it is the object address for v2 tokens
+	TokenStandard            DigitalAssetStandard // TokenStandard of the token
+	Amount                   uint64               // Amount held, 1 for non-fungible tokens
+	PropertyVersion          uint64               // PropertyVersion of a v1 token, 0 for v2 tokens
+	IsFungible               bool                 // IsFungible is true for fungible v2 tokens
+	IsSoulbound              bool                 // IsSoulbound is true if the token can't be transferred
+	LastTransactionVersion   uint64               // LastTransactionVersion is the version the ownership last changed
+	LastTransactionTimestamp time.Time            // LastTransactionTimestamp is the time the ownership last changed
+
+	TokenName   string // TokenName is the name of the token
+	TokenUri    string // TokenUri points to the token's metadata
+	Description string // Description of the token
+
+	CollectionId   AccountAddress // CollectionId identifies the collection, it is the object address for v2 collections
+	CollectionName string         // CollectionName is the name of the collection
+	CollectionUri  string         // CollectionUri points to the collection's metadata
+	CreatorAddress AccountAddress // CreatorAddress is the creator of the collection
+}
+
+// indexerTokenOwnershipFilter is the where clause for current_token_ownerships_v2
+type indexerTokenOwnershipFilter map[string]any
+
+func (indexerTokenOwnershipFilter) GetGraphQLType() string {
+	return "current_token_ownerships_v2_bool_exp"
+}
+
+// GetOwnedDigitalAssets gets a page of the digital assets (NFTs) currently held by owner, newest first
+//
+//	assets, err := client.GetOwnedDigitalAssets(owner, OwnedDigitalAssetsOptions{TokenStandard: DigitalAssetStandardV2})
+func (ic *IndexerClient) GetOwnedDigitalAssets(owner AccountAddress, options OwnedDigitalAssetsOptions) ([]OwnedDigitalAsset, error) {
+	where := indexerTokenOwnershipFilter{
+		"owner_address": map[string]any{"_eq": owner.StringLong()},
+		"amount":        map[string]any{"_gt": 0},
+	}
+	if options.CollectionId != nil {
+		where["current_token_data"] = map[string]any{"collection_id": map[string]any{"_eq": options.CollectionId.StringLong()}}
+	}
+	if options.TokenStandard != "" {
+		where["token_standard"] = map[string]any{"_eq": string(options.TokenStandard)}
+	}
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultDigitalAssetsPageSize
+	}
+
+	var q struct {
+		CurrentTokenOwnershipsV2 []struct {
+			TokenDataId              string  `graphql:"token_data_id"`
+			TokenStandard            string  `graphql:"token_standard"`
+			Amount                   uint64  `graphql:"amount"`
+			PropertyVersionV1        *uint64 `graphql:"property_version_v1"`
+			IsFungibleV2             *bool   `graphql:"is_fungible_v2"`
+			IsSoulboundV2            *bool   `graphql:"is_soulbound_v2"`
+			LastTransactionVersion   uint64  `graphql:"last_transaction_version"`
+			LastTransactionTimestamp string  `graphql:"last_transaction_timestamp"`
+			CurrentTokenData         *struct {
+				TokenName         string `graphql:"token_name"`
+				TokenUri          string `graphql:"token_uri"`
+				Description       string `graphql:"description"`
+				CollectionId      string `graphql:"collection_id"`
+				CurrentCollection *struct {
+					CollectionName string `graphql:"collection_name"`
+					Uri            string `graphql:"uri"`
+					CreatorAddress string `graphql:"creator_address"`
+				} `graphql:"current_collection"`
+			} `graphql:"current_token_data"`
+		} `graphql:"current_token_ownerships_v2(where: $where, order_by: [{last_transaction_version: desc}, {token_data_id: asc}], limit: $limit, offset: $offset)"`
+	}
+	variables := map[string]any{
+		"where":  where,
+		"limit":  limit,
+		"offset": options.Offset,
+	}
+	err := ic.Query(&q, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]OwnedDigitalAsset, 0, len(q.CurrentTokenOwnershipsV2))
+	for _, ownership := range q.CurrentTokenOwnershipsV2 {
+		asset := OwnedDigitalAsset{
+			TokenStandard:          DigitalAssetStandard(ownership.TokenStandard),
+			Amount:                 ownership.Amount,
+			LastTransactionVersion: ownership.LastTransactionVersion,
+		}
+		err = asset.TokenDataId.ParseStringRelaxed(ownership.TokenDataId)
+		if err != nil {
+			return nil, fmt.Errorf("bad token data id %s: %w", ownership.TokenDataId, err)
+		}
+		if ownership.PropertyVersionV1 != nil {
+			asset.PropertyVersion = *ownership.PropertyVersionV1
+		}
+		asset.IsFungible = ownership.IsFungibleV2 != nil && *ownership.IsFungibleV2
+		asset.IsSoulbound = ownership.IsSoulboundV2 != nil && *ownership.IsSoulboundV2
+		asset.LastTransactionTimestamp, err = time.Parse(indexerTimeLayout, ownership.LastTransactionTimestamp)
+		if err != nil {
+			return nil, fmt.Errorf("bad timestamp %s for token %s: %w", ownership.LastTransactionTimestamp, ownership.TokenDataId, err)
+		}
+
+		if data := ownership.CurrentTokenData; data != nil {
+			asset.TokenName = data.TokenName
+			asset.TokenUri = data.TokenUri
+			asset.Description = data.Description
+			err = asset.CollectionId.ParseStringRelaxed(data.CollectionId)
+			if err != nil {
+				return nil, fmt.Errorf("bad collection id %s: %w", data.CollectionId, err)
+			}
+			if collection := data.CurrentCollection; collection != nil {
+				asset.CollectionName = collection.CollectionName
+				asset.CollectionUri = collection.Uri
+				err = asset.CreatorAddress.ParseStringRelaxed(collection.CreatorAddress)
+				if err != nil {
+					return nil, fmt.Errorf("bad creator address %s: %w", collection.CreatorAddress, err)
+				}
+			}
+		}
+		out = append(out, asset)
+	}
+	return out, nil
+}
+
+// GetOwnedDigitalAssets gets a page of the digital assets (NFTs) currently held by owner, newest first.  Requires an
+// indexer, or returns [ErrNoIndexer].
+func (client *Client) GetOwnedDigitalAssets(owner AccountAddress, options OwnedDigitalAssetsOptions) ([]OwnedDigitalAsset, error) {
+	if client.indexerClient == nil {
+		return nil, ErrNoIndexer
+	}
+	return client.indexerClient.GetOwnedDigitalAssets(owner, options)
+}
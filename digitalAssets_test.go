@@ -0,0 +1,100 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetOwnedDigitalAssets(t *testing.T) {
+	owner := AccountAddress{}
+	assert.NoError(t, owner.ParseStringRelaxed("0xa11ce"))
+	collection := AccountAddress{}
+	assert.NoError(t, collection.ParseStringRelaxed("0xc011"))
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string
+			Variables map[string]any
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Contains(t, request.Query, "$where:current_token_ownerships_v2_bool_exp!")
+		assert.Contains(t, request.Query, "current_token_data{token_name,token_uri,description,collection_id,current_collection{collection_name,uri,creator_address}}")
+		where := request.Variables["where"].(map[string]any)
+		assert.Equal(t, map[string]any{"_eq": owner.StringLong()}, where["owner_address"])
+		assert.Equal(t, map[string]any{"_eq": "v2"}, where["token_standard"])
+		assert.Equal(t, map[string]any{"collection_id": map[string]any{"_eq": collection.StringLong()}}, where["current_token_data"])
+		assert.Equal(t, float64(10), request.Variables["limit"])
+		assert.Equal(t, float64(20), request.Variables["offset"])
+
+		_, _ = w.Write([]byte(`{"data": {"current_token_ownerships_v2": [{
+			"token_data_id": "0x7",
+			"token_standard": "v2",
+			"amount": 1,
+			"property_version_v1": 0,
+			"is_fungible_v2": false,
+			"is_soulbound_v2": true,
+			"last_transaction_version": 1234,
+			"last_transaction_timestamp": "2024-05-06T07:08:09.5",
+			"current_token_data": {
+				"token_name": "Sword #1",
+				"token_uri": "https://example.com/1.json",
+				"description": "A sword",
+				"collection_id": "0xc011",
+				"current_collection": {"collection_name": "Swords", "uri": "https://example.com", "creator_address": "0xc4"}
+			}
+		}, {
+			"token_data_id": "0x8",
+			"token_standard": "v2",
+			"amount": 1,
+			"property_version_v1": null,
+			"is_fungible_v2": null,
+			"is_soulbound_v2": null,
+			"last_transaction_version": 1000,
+			"last_transaction_timestamp": "2024-05-06T07:08:09",
+			"current_token_data": null
+		}]}}`))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1", IndexerUrl: mockServer.URL + "/graphql"})
+	assert.NoError(t, err)
+	assets, err := client.GetOwnedDigitalAssets(owner, OwnedDigitalAssetsOptions{
+		CollectionId:  &collection,
+		TokenStandard: DigitalAssetStandardV2,
+		Limit:         10,
+		Offset:        20,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, assets, 2)
+
+	sword := assets[0]
+	assert.Equal(t, "0x7", sword.TokenDataId.String())
+	assert.Equal(t, DigitalAssetStandardV2, sword.TokenStandard)
+	assert.Equal(t, uint64(1), sword.Amount)
+	assert.True(t, sword.IsSoulbound)
+	assert.False(t, sword.IsFungible)
+	assert.Equal(t, uint64(1234), sword.LastTransactionVersion)
+	assert.Equal(t, time.Date(2024, 5, 6, 7, 8, 9, 500000000, time.UTC), sword.LastTransactionTimestamp)
+	assert.Equal(t, "Sword #1", sword.TokenName)
+	assert.Equal(t, collection, sword.CollectionId)
+	assert.Equal(t, "Swords", sword.CollectionName)
+	creator := AccountAddress{}
+	assert.NoError(t, creator.ParseStringRelaxed("0xc4"))
+	assert.Equal(t, creator, sword.CreatorAddress)
+
+	// Missing token data is left empty
+	assert.Equal(t, "", assets[1].TokenName)
+	assert.False(t, assets[1].IsSoulbound)
+}
+
+func TestClient_GetOwnedDigitalAssetsNoIndexer(t *testing.T) {
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: "http://localhost:8080/v1"})
+	assert.NoError(t, err)
+	_, err = client.GetOwnedDigitalAssets(AccountOne, OwnedDigitalAssetsOptions{})
+	assert.ErrorIs(t, err, ErrNoIndexer)
+}
@@ -6,3 +6,31 @@ type EstimateGasInfo struct {
 	GasEstimate              uint64 `json:"gas_estimate"`               // GasEstimate is the gas estimate for a transaction that is willing to pay close to the median gas price
 	PrioritizedGasEstimate   uint64 `json:"prioritized_gas_estimate"`   // PrioritizedGasEstimate is the gas estimate for a transaction that is willing to pay more to be prioritized
 }
+
+// GasPriority selects which estimate from [EstimateGasInfo] is used when building a transaction without a [GasUnitPrice]
+type GasPriority uint8
+
+const (
+	GasPriorityNormal        GasPriority = iota // GasPriorityNormal uses [EstimateGasInfo.GasEstimate], the default
+	GasPriorityDeprioritized                    // GasPriorityDeprioritized uses [EstimateGasInfo.DeprioritizedGasEstimate]
+	GasPriorityPrioritized                      // GasPriorityPrioritized uses [EstimateGasInfo.PrioritizedGasEstimate]
+)
+
+// Price returns the gas unit price for the given priority
+//
+// If the node omits the deprioritized or prioritized estimate, it falls back to [EstimateGasInfo.GasEstimate]
+func (info *EstimateGasInfo) Price(priority GasPriority) uint64 {
+	switch priority {
+	case GasPriorityDeprioritized:
+		if info.DeprioritizedGasEstimate != 0 {
+			return info.DeprioritizedGasEstimate
+		}
+	case GasPriorityPrioritized:
+		if info.PrioritizedGasEstimate != 0 {
+			return info.PrioritizedGasEstimate
+		}
+	default:
+		// Fall through to the regular estimate
+	}
+	return info.GasEstimate
+}
@@ -0,0 +1,107 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+)
+
+// EthereumOwnershipProofPrefix is the first line of every [EthereumOwnershipProof] message
+const EthereumOwnershipProofPrefix = "APTOS::EthereumOwnershipProof"
+
+// NewSecp256k1AccountFromEthereumKey creates a SingleKey Secp256k1 account from a raw hex private key used on EVM chains
+//
+// The same key controls the Ethereum address and the Aptos address, so users can be ported across chains.
+func NewSecp256k1AccountFromEthereumKey(hexKey string) (*Account, error) {
+	privateKey, err := crypto.NewSecp256k1PrivateKeyFromEthereum(hexKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountFromSigner(crypto.NewSingleSigner(privateKey))
+}
+
+// EthereumKeyAddress derives the Aptos SingleKey address for a secp256k1 public key used on EVM chains
+//
+// This is the address of a new account, if the account has rotated its key, the on-chain address will differ.
+func EthereumKeyAddress(publicKey *crypto.Secp256k1PublicKey) (AccountAddress, error) {
+	anyPublicKey, err := crypto.ToAnyPublicKey(publicKey)
+	if err != nil {
+		return AccountAddress{}, err
+	}
+	return AccountAddress(*anyPublicKey.AuthKey()), nil
+}
+
+// EthereumOwnershipProof proves that the holder of an Ethereum key also controls the matching Aptos address
+type EthereumOwnershipProof struct {
+	EthereumAddress string               // EthereumAddress is the EIP-55 checksummed Ethereum address
+	AptosAddress    AccountAddress       // AptosAddress is the derived Aptos SingleKey address
+	Nonce           string               // Nonce is provided by the verifier to prevent replays
+	PublicKey       *crypto.AnyPublicKey // PublicKey is the secp256k1 public key, wrapped as a SingleKey
+	Signature       *crypto.AnySignature // Signature is the signature over [EthereumOwnershipProof.Message]
+}
+
+// EthereumOwnershipProofMessage builds the human-readable message signed for an [EthereumOwnershipProof]
+func EthereumOwnershipProofMessage(ethereumAddress string, aptosAddress AccountAddress, nonce string) []byte {
+	return []byte(fmt.Sprintf("%s\nethereum_address: %s\naptos_address: %s\nnonce: %s",
+		EthereumOwnershipProofPrefix, ethereumAddress, aptosAddress.StringLong(), nonce))
+}
+
+// SignEthereumOwnershipProof signs an [EthereumOwnershipProof] for the key, with a nonce from the verifier
+func SignEthereumOwnershipProof(privateKey *crypto.Secp256k1PrivateKey, nonce string) (*EthereumOwnershipProof, error) {
+	publicKey := privateKey.VerifyingKey().(*crypto.Secp256k1PublicKey)
+	aptosAddress, err := EthereumKeyAddress(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	ethereumAddress := publicKey.EthereumAddress()
+
+	signer := crypto.NewSingleSigner(privateKey)
+	signature, err := signer.SignMessage(EthereumOwnershipProofMessage(ethereumAddress, aptosAddress, nonce))
+	if err != nil {
+		return nil, err
+	}
+	anyPublicKey, err := crypto.ToAnyPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EthereumOwnershipProof{
+		EthereumAddress: ethereumAddress,
+		AptosAddress:    aptosAddress,
+		Nonce:           nonce,
+		PublicKey:       anyPublicKey,
+		Signature:       signature.(*crypto.AnySignature),
+	}, nil
+}
+
+// Message returns the signed message for the proof
+func (proof *EthereumOwnershipProof) Message() []byte {
+	return EthereumOwnershipProofMessage(proof.EthereumAddress, proof.AptosAddress, proof.Nonce)
+}
+
+// Verify checks that the public key matches both addresses, and that the signature is valid
+func (proof *EthereumOwnershipProof) Verify() error {
+	if proof.PublicKey == nil || proof.Signature == nil {
+		return errors.New("ethereum ownership proof missing public key or signature")
+	}
+	publicKey, ok := proof.PublicKey.PubKey.(*crypto.Secp256k1PublicKey)
+	if !ok {
+		return errors.New("ethereum ownership proof public key is not secp256k1")
+	}
+	if !strings.EqualFold(publicKey.EthereumAddress(), proof.EthereumAddress) {
+		return fmt.Errorf("public key does not match ethereum address %s", proof.EthereumAddress)
+	}
+	aptosAddress, err := EthereumKeyAddress(publicKey)
+	if err != nil {
+		return err
+	}
+	if aptosAddress != proof.AptosAddress {
+		return fmt.Errorf("public key does not match aptos address %s", proof.AptosAddress.String())
+	}
+	if !proof.PublicKey.Verify(proof.Message(), proof.Signature) {
+		return errors.New("ethereum ownership proof signature is invalid")
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEthereumOwnershipProof(t *testing.T) {
+	hexKey := "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	account, err := NewSecp256k1AccountFromEthereumKey(hexKey)
+	assert.NoError(t, err)
+
+	privateKey, err := crypto.NewSecp256k1PrivateKeyFromEthereum(hexKey)
+	assert.NoError(t, err)
+
+	proof, err := SignEthereumOwnershipProof(privateKey, "nonce-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", proof.EthereumAddress)
+	assert.Equal(t, account.Address, proof.AptosAddress)
+	assert.NoError(t, proof.Verify())
+
+	// Tampering with any field breaks the proof
+	proof.Nonce = "nonce-2"
+	assert.Error(t, proof.Verify())
+	proof.Nonce = "nonce-1"
+	proof.AptosAddress = AccountOne
+	assert.Error(t, proof.Verify())
+}
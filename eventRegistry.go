@@ -0,0 +1,346 @@
+package aptos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// ErrEventNotRegistered is returned decoding an event whose type has no struct in the [EventRegistry]
+var ErrEventNotRegistered = errors.New("event type not registered")
+
+// CoinDepositEvent is a 0x1::coin::DepositEvent, emitted on the deposit handle of a CoinStore
+type CoinDepositEvent struct {
+	Amount uint64 // Amount deposited
+}
+
+// CoinWithdrawEvent is a 0x1::coin::WithdrawEvent, emitted on the withdraw handle of a CoinStore
+type CoinWithdrawEvent struct {
+	Amount uint64 // Amount withdrawn
+}
+
+// CoinDeposit is a 0x1::coin::CoinDeposit module event
+type CoinDeposit struct {
+	CoinType string         // CoinType is the coin deposited e.g. 0x1::aptos_coin::AptosCoin
+	Account  AccountAddress // Account deposited to
+	Amount   uint64         // Amount deposited
+}
+
+// CoinWithdraw is a 0x1::coin::CoinWithdraw module event
+type CoinWithdraw struct {
+	CoinType string         // CoinType is the coin withdrawn e.g. 0x1::aptos_coin::AptosCoin
+	Account  AccountAddress // Account withdrawn from
+	Amount   uint64         // Amount withdrawn
+}
+
+// FungibleAssetDeposit is a 0x1::fungible_asset::Deposit module event
+type FungibleAssetDeposit struct {
+	Store  AccountAddress // Store is the fungible store deposited to
+	Amount uint64         // Amount deposited
+}
+
+// FungibleAssetWithdraw is a 0x1::fungible_asset::Withdraw module event
+type FungibleAssetWithdraw struct {
+	Store  AccountAddress // Store is the fungible store withdrawn from
+	Amount uint64         // Amount withdrawn
+}
+
+// DefaultEventTypes are the framework events registered by [NewEventRegistry]
+func DefaultEventTypes() map[string]any {
+	return map[string]any{
+		"0x1::coin::DepositEvent":       CoinDepositEvent{},
+		"0x1::coin::WithdrawEvent":      CoinWithdrawEvent{},
+		"0x1::coin::CoinDeposit":        CoinDeposit{},
+		"0x1::coin::CoinWithdraw":       CoinWithdraw{},
+		"0x1::fungible_asset::Deposit":  FungibleAssetDeposit{},
+		"0x1::fungible_asset::Withdraw": FungibleAssetWithdraw{},
+	}
+}
+
+// DecodedEvent is an event with its data decoded by an [EventRegistry]
+type DecodedEvent struct {
+	Event *api.Event // Event as returned by the node
+	Data  any        // Data is a pointer to the registered struct, nil if the type isn't registered
+}
+
+// EventRegistry decodes event data into the Go structs registered for event types, so events don't need to be read
+// field by field out of maps.  It is safe for concurrent use.
+//
+// Fields are matched by their json tag, or else the snake case of their name e.g. CreationNum is creation_num.  Integers
+// may be decimal strings as the node sends u64 and larger, addresses are parsed in any form, a Move Option is a pointer,
+// and a vector<u8> may be a []byte.
+//
+//	type Swap struct {
+//		Pool      aptos.AccountAddress
+//		AmountIn  uint64
+//		AmountOut uint64
+//	}
+//	registry := aptos.NewEventRegistry()
+//	registry.Register("0xcafe::pool::SwapEvent", Swap{})
+//	events, err := registry.DecodeTransaction(txn)
+//	for _, event := range events {
+//		if swap, ok := event.Data.(*Swap); ok {
+//			// use swap
+//		}
+//	}
+type EventRegistry struct {
+	mutex sync.RWMutex
+	types map[string]reflect.Type
+}
+
+// NewEventRegistry creates an [EventRegistry] with [DefaultEventTypes]
+func NewEventRegistry() *EventRegistry {
+	registry := &EventRegistry{types: make(map[string]reflect.Type)}
+	for eventType, prototype := range DefaultEventTypes() {
+		registry.Register(eventType, prototype)
+	}
+	return registry
+}
+
+// Register decodes events of the type e.g. 0x1::coin::DepositEvent into new values of the prototype's struct, replacing
+// any struct already registered.  Type arguments of the event type are ignored.
+func (r *EventRegistry) Register(eventType string, prototype any) {
+	t := reflect.TypeOf(prototype)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.types[normalizeMoveId(eventType)] = t
+}
+
+// Unregister removes the struct of the event type, returning false if there is none
+func (r *EventRegistry) Unregister(eventType string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	eventType = normalizeMoveId(eventType)
+	_, ok := r.types[eventType]
+	delete(r.types, eventType)
+	return ok
+}
+
+// Decode decodes the event's data into a new value of its registered struct, and returns a pointer to it, or
+// [ErrEventNotRegistered]
+func (r *EventRegistry) Decode(event *api.Event) (any, error) {
+	r.mutex.RLock()
+	t, ok := r.types[normalizeMoveId(event.Type)]
+	r.mutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotRegistered, event.Type)
+	}
+	out := reflect.New(t)
+	if err := decodeEventData(event, out); err != nil {
+		return nil, err
+	}
+	return out.Interface(), nil
+}
+
+// DecodeEvents decodes each event, leaving Data nil for unregistered types
+func (r *EventRegistry) DecodeEvents(events []*api.Event) ([]DecodedEvent, error) {
+	decoded := make([]DecodedEvent, 0, len(events))
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		data, err := r.Decode(event)
+		if err != nil && !errors.Is(err, ErrEventNotRegistered) {
+			return nil, err
+		}
+		decoded = append(decoded, DecodedEvent{Event: event, Data: data})
+	}
+	return decoded, nil
+}
+
+// DecodeTransaction decodes the events of a user transaction, or returns none for other transactions
+func (r *EventRegistry) DecodeTransaction(txn *api.CommittedTransaction) ([]DecodedEvent, error) {
+	userTxn, err := txn.UserTransaction()
+	if err != nil {
+		return nil, nil
+	}
+	return r.DecodeEvents(userTxn.Events)
+}
+
+// DecodeEvent decodes the event's data into a T, with the conversions of an [EventRegistry], without checking its type
+//
+//	deposit, err := aptos.DecodeEvent[aptos.FungibleAssetDeposit](event)
+func DecodeEvent[T any](event *api.Event) (*T, error) {
+	out := new(T)
+	if err := decodeEventData(event, reflect.ValueOf(out)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// decodeEventData decodes the event's data into the pointer out
+func decodeEventData(event *api.Event, out reflect.Value) error {
+	var data any = event.Data
+	if value, ok := event.Data[api.AnyDataName]; ok && len(event.Data) == 1 {
+		data = value
+	}
+	if err := decodeMoveValue(data, out.Elem()); err != nil {
+		return fmt.Errorf("failed to decode event %s: %w", event.Type, err)
+	}
+	return nil
+}
+
+var (
+	accountAddressType  = reflect.TypeOf(AccountAddress{})
+	bigIntType          = reflect.TypeOf(big.Int{})
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// decodeMoveValue decodes a JSON value of a Move value into out
+func decodeMoveValue(value any, out reflect.Value) error {
+	t := out.Type()
+	switch {
+	case t == accountAddressType:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected address, got %T", value)
+		}
+		address := AccountAddress{}
+		if err := address.ParseStringRelaxed(str); err != nil {
+			return err
+		}
+		out.Set(reflect.ValueOf(address))
+		return nil
+	case t == bigIntType:
+		str := fmt.Sprint(value)
+		num, ok := new(big.Int).SetString(str, 10)
+		if !ok {
+			return fmt.Errorf("expected integer, got %s", str)
+		}
+		out.Set(reflect.ValueOf(*num))
+		return nil
+	case t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(jsonUnmarshalerType):
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return out.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(b)
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		// A Move Option is {"vec": []} or {"vec": [value]}
+		if option, ok := value.(map[string]any); ok && len(option) == 1 {
+			if vec, ok := option["vec"].([]any); ok && len(vec) <= 1 {
+				if len(vec) == 0 {
+					out.SetZero()
+					return nil
+				}
+				value = vec[0]
+			}
+		}
+		if value == nil {
+			out.SetZero()
+			return nil
+		}
+		elem := reflect.New(t.Elem())
+		if err := decodeMoveValue(value, elem.Elem()); err != nil {
+			return err
+		}
+		out.Set(elem)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		num, err := strconv.ParseUint(fmt.Sprint(value), 10, t.Bits())
+		if err != nil {
+			return err
+		}
+		out.SetUint(num)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		num, err := strconv.ParseInt(fmt.Sprint(value), 10, t.Bits())
+		if err != nil {
+			return err
+		}
+		out.SetInt(num)
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", value)
+		}
+		out.SetBool(b)
+	case reflect.String:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", value)
+		}
+		out.SetString(str)
+	case reflect.Slice:
+		if str, ok := value.(string); ok && t.Elem().Kind() == reflect.Uint8 {
+			b, err := ParseHex(str)
+			if err != nil {
+				return err
+			}
+			out.SetBytes(b)
+			return nil
+		}
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("expected vector, got %T", value)
+		}
+		slice := reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			if err := decodeMoveValue(item, slice.Index(i)); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		out.Set(slice)
+	case reflect.Struct:
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected struct, got %T", value)
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := moveFieldName(field)
+			if name == "-" {
+				continue
+			}
+			fieldValue, ok := fields[name]
+			if !ok {
+				continue
+			}
+			if err := decodeMoveValue(fieldValue, out.Field(i)); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	case reflect.Interface:
+		if value != nil {
+			out.Set(reflect.ValueOf(value))
+		}
+	default:
+		return fmt.Errorf("unsupported field type %s", t)
+	}
+	return nil
+}
+
+// moveFieldName is the Move field of a struct field, its json tag or the snake case of its name
+func moveFieldName(field reflect.StructField) string {
+	if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag != "" {
+		return tag
+	}
+	runes := []rune(field.Name)
+	var name strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word, unless in the middle of an acronym e.g. the Id of PoolId but not of ID
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				name.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		name.WriteRune(r)
+	}
+	return name.String()
+}
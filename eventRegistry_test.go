@@ -0,0 +1,107 @@
+package aptos
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSwapEvent struct {
+	Pool      AccountAddress
+	AmountIn  uint64
+	AmountOut *big.Int
+	Referrer  *AccountAddress
+	Route     []AccountAddress
+	Memo      []byte
+	Fee       api.U64 `json:"fee_amount"`
+	Active    bool
+	Extra     any
+}
+
+func TestEventRegistry(t *testing.T) {
+	txn := &api.CommittedTransaction{}
+	err := json.Unmarshal([]byte(`{
+		"type": "user_transaction",
+		"version": "1",
+		"hash": "0x1",
+		"state_change_hash": "0x1",
+		"event_root_hash": "0x1",
+		"accumulator_root_hash": "0x1",
+		"gas_used": "1",
+		"success": true,
+		"vm_status": "Executed successfully",
+		"changes": [],
+		"sender": "0x1",
+		"sequence_number": "0",
+		"max_gas_amount": "1",
+		"gas_unit_price": "1",
+		"expiration_timestamp_secs": "1",
+		"payload": {"type": "entry_function_payload", "function": "0xcafe::pool::swap", "type_arguments": [], "arguments": []},
+		"timestamp": "1",
+		"events": [
+			{"type": "0x1::fungible_asset::Withdraw", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {"store": "0xa", "amount": "100"}},
+			{"type": "0x000000000000000000000000000000000000000000000000000000000000cafe::pool::SwapEvent<0x1::aptos_coin::AptosCoin>", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {
+				"pool": "0xb",
+				"amount_in": "100",
+				"amount_out": "340282366920938463463374607431768211455",
+				"referrer": {"vec": []},
+				"route": ["0xb", "0xc"],
+				"memo": "0x0102",
+				"fee_amount": "3",
+				"active": true,
+				"extra": {"note": "hi"}
+			}},
+			{"type": "0xcafe::pool::Unknown", "guid": {"addr": "0x0", "creation_num": "0"}, "sequence_number": "0", "data": {}}
+		]
+	}`), txn)
+	assert.NoError(t, err)
+
+	registry := NewEventRegistry()
+	registry.Register("0xcafe::pool::SwapEvent", &testSwapEvent{})
+	events, err := registry.DecodeTransaction(txn)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+
+	withdraw, ok := events[0].Data.(*FungibleAssetWithdraw)
+	assert.True(t, ok)
+	address := AccountAddress{}
+	assert.NoError(t, address.ParseStringRelaxed("0xa"))
+	assert.Equal(t, &FungibleAssetWithdraw{Store: address, Amount: 100}, withdraw)
+
+	swap, ok := events[1].Data.(*testSwapEvent)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), swap.AmountIn)
+	maxU128, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	assert.Equal(t, maxU128, swap.AmountOut)
+	assert.Nil(t, swap.Referrer)
+	assert.Len(t, swap.Route, 2)
+	assert.Equal(t, swap.Pool, swap.Route[0])
+	assert.Equal(t, []byte{0x01, 0x02}, swap.Memo)
+	assert.Equal(t, api.U64(3), swap.Fee)
+	assert.True(t, swap.Active)
+	assert.Equal(t, map[string]any{"note": "hi"}, swap.Extra)
+
+	assert.Nil(t, events[2].Data)
+	_, err = registry.Decode(events[2].Event)
+	assert.ErrorIs(t, err, ErrEventNotRegistered)
+
+	// Options with a value, and bad values
+	event := &api.Event{Type: "0xcafe::pool::SwapEvent", Data: map[string]any{"referrer": map[string]any{"vec": []any{"0xd"}}, "amount_in": "-1"}}
+	_, err = registry.Decode(event)
+	assert.ErrorContains(t, err, "amount_in")
+	delete(event.Data, "amount_in")
+	decoded, err := DecodeEvent[testSwapEvent](event)
+	assert.NoError(t, err)
+	assert.NoError(t, address.ParseStringRelaxed("0xd"))
+	assert.Equal(t, &address, decoded.Referrer)
+
+	assert.True(t, registry.Unregister("0xcafe::pool::SwapEvent"))
+	assert.False(t, registry.Unregister("0xcafe::pool::SwapEvent"))
+
+	assert.Equal(t, "creation_num", moveFieldName(reflect.TypeOf(struct{ CreationNum uint64 }{}).Field(0)))
+	assert.Equal(t, "pool_id", moveFieldName(reflect.TypeOf(struct{ PoolID uint64 }{}).Field(0)))
+}
@@ -0,0 +1,624 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+const (
+	DefaultSubscriptionPollInterval = time.Second // DefaultSubscriptionPollInterval is how long the polling backend waits when caught up with the ledger
+	DefaultSubscriptionBatchSize    = uint64(100) // DefaultSubscriptionBatchSize is the number of transactions the polling backend fetches per request
+)
+
+// SubscribedEvent is an event delivered to an [EventSubscription] handler
+type SubscribedEvent struct {
+	Version         uint64         // Version of the transaction that emitted the event
+	TransactionHash string         // TransactionHash of the transaction that emitted the event, not known for events fetched by handle
+	Sender          AccountAddress // Sender of the transaction that emitted the event, not known for events fetched by handle
+	Index           int            // Index of the event within the transaction, not known for events fetched by handle
+	Event           *api.Event     // Event is the event itself
+}
+
+// EventHandleId identifies an event handle by the GUID of its events, e.g. [EventHandle.Address] and
+// [EventHandle.CreationNumber] of a resource's handle
+type EventHandleId struct {
+	Account        AccountAddress // Account the handle was created by
+	CreationNumber uint64         // CreationNumber of the handle's GUID
+}
+
+// EventFilter selects the events of [Client.SubscribeEvents].  Each field that is set must match, and within a field
+// any of the values matches.
+type EventFilter struct {
+	Addresses    []AccountAddress // Addresses of senders or event handle owners, see [EventSubscription.Address]
+	EventTypes   []string         // EventTypes are Move struct types, see [EventSubscription.EventType]
+	EventHandles []EventHandleId  // EventHandles the events are emitted to, see [EventSubscription.EventHandle]
+	FromVersion  *uint64          // FromVersion is the first ledger version, only new transactions if not set
+	Buffer       int              // Buffer is the number of events the channel holds before polling pauses
+}
+
+// Decode decodes the event data into out, using its JSON struct tags
+//
+//	type MintEvent struct {
+//		Collection string `json:"collection"`
+//		Index      string `json:"index"`
+//		Token      string `json:"token"`
+//	}
+//	mint := MintEvent{}
+//	err := event.Decode(&mint)
+func (e SubscribedEvent) Decode(out any) error {
+	blob, err := json.Marshal(e.Event.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(blob, out)
+}
+
+// TypedEventHandler adapts a handler of a struct decoded with [SubscribedEvent.Decode] to [EventSubscription.Handler]
+//
+//	subscription.Handler(TypedEventHandler(func(event SubscribedEvent, mint MintEvent) error {
+//		return nil
+//	}))
+func TypedEventHandler[T any](handler func(event SubscribedEvent, data T) error) func(event SubscribedEvent) error {
+	return func(event SubscribedEvent) error {
+		var data T
+		err := event.Decode(&data)
+		if err != nil {
+			return fmt.Errorf("failed to decode event %s at version %d: %w", event.Event.Type, event.Version, err)
+		}
+		return handler(event, data)
+	}
+}
+
+// EventSubscriptionBackend delivers committed user transactions in version order, starting at fromVersion, until ctx
+// is done or handle returns an error.
+//
+// This is the transport for [EventSubscription], so the same subscription can run on polling or on a stream.
+type EventSubscriptionBackend interface {
+	Run(ctx context.Context, fromVersion uint64, handle func(txn *api.UserTransaction) error) error
+	// LatestVersion returns the newest committed version, used when no start version is given
+	LatestVersion() (uint64, error)
+}
+
+// EventSubscription is a builder for a subscription to on-chain events, compiled onto an [EventSubscriptionBackend]
+//
+//	err := client.Subscribe().
+//		Address(creator).
+//		EventType("0x4::collection::Mint").
+//		FromVersion(version).
+//		Handler(func(event SubscribedEvent) error {
+//			return nil
+//		}).
+//		Run(ctx)
+//
+// In a long-running service, Start runs it in the background instead, and Close stops it after the event being handled,
+// so [EventSubscription.NextVersion] is exact for resuming.
+type EventSubscription struct {
+	backend      EventSubscriptionBackend
+	addresses    map[AccountAddress]bool
+	eventTypes   []string
+	handles      map[EventHandleId]bool
+	fromVersion  *uint64
+	nextVersion  uint64
+	handler      func(event SubscribedEvent) error
+	handlePoller *eventHandlePoller // handlePoller fetches the events of the handles instead of the backend, if set
+
+	life    lifecycle
+	cancel  context.CancelFunc
+	closing bool // closing is set by Close, so the cancellation isn't reported as an error
+}
+
+// NewEventSubscription creates an [EventSubscription] on the given backend
+func NewEventSubscription(backend EventSubscriptionBackend) *EventSubscription {
+	return &EventSubscription{
+		backend:   backend,
+		addresses: make(map[AccountAddress]bool),
+		handles:   make(map[EventHandleId]bool),
+	}
+}
+
+// Backend changes the transport of the subscription
+func (s *EventSubscription) Backend(backend EventSubscriptionBackend) *EventSubscription {
+	s.backend = backend
+	return s
+}
+
+// Address only delivers events from transactions sent by the address, or from event handles owned by the address.
+// Calling it multiple times matches any of the addresses.
+func (s *EventSubscription) Address(address AccountAddress) *EventSubscription {
+	s.addresses[address] = true
+	return s
+}
+
+// EventType only delivers events of the Move struct type e.g. 0x4::collection::Mint.  Type arguments are only compared
+// if given.  Calling it multiple times matches any of the types.
+func (s *EventSubscription) EventType(eventType string) *EventSubscription {
+	s.eventTypes = append(s.eventTypes, normalizeMoveType(eventType))
+	return s
+}
+
+// EventHandle only delivers events emitted to the event handle, identified by the GUID of the handle.  Calling it
+// multiple times matches any of the handles.
+func (s *EventSubscription) EventHandle(handle EventHandleId) *EventSubscription {
+	s.handles[handle] = true
+	return s
+}
+
+// FromVersion starts the subscription at the ledger version, inclusive.  By default, only new transactions are delivered.
+func (s *EventSubscription) FromVersion(version uint64) *EventSubscription {
+	s.fromVersion = &version
+	return s
+}
+
+// Handler is called for each matching event in order.  Returning an error stops the subscription.
+func (s *EventSubscription) Handler(handler func(event SubscribedEvent) error) *EventSubscription {
+	s.handler = handler
+	return s
+}
+
+// NextVersion is the first version not yet fully delivered, so a stopped subscription can be resumed with
+// [EventSubscription.FromVersion]
+func (s *EventSubscription) NextVersion() uint64 {
+	return s.nextVersion
+}
+
+// Run delivers events to the handler until ctx is done or the handler returns an error.  It returns ctx.Err() when
+// cancelled.
+func (s *EventSubscription) Run(ctx context.Context) error {
+	byHandle := s.handlePoller != nil && len(s.handles) > 0
+	if s.backend == nil && !byHandle {
+		return errors.New("event subscription has no backend")
+	}
+	if s.handler == nil {
+		return errors.New("event subscription has no handler")
+	}
+	if s.fromVersion != nil {
+		s.nextVersion = *s.fromVersion
+	} else {
+		latestVersion := s.backend.LatestVersion
+		if byHandle {
+			latestVersion = s.handlePoller.latestVersion
+		}
+		latest, err := latestVersion()
+		if err != nil {
+			return fmt.Errorf("failed to get latest version for event subscription: %w", err)
+		}
+		s.nextVersion = latest + 1
+	}
+
+	if byHandle {
+		return s.runByHandle(ctx)
+	}
+	return s.backend.Run(ctx, s.nextVersion, func(txn *api.UserTransaction) error {
+		if txn.Version < s.nextVersion {
+			return nil
+		}
+		err := s.deliver(txn)
+		if err != nil {
+			return err
+		}
+		s.nextVersion = txn.Version + 1
+		return nil
+	})
+}
+
+// Channel runs the subscription in the background, delivering events on the returned channel instead of to a handler.
+// The channel holds up to buffer events, and polling pauses while it's full, so a slow reader holds back the
+// subscription rather than losing events.
+//
+// Both channels are closed once the subscription stops.  It stops when ctx is done, without an error, or on the first
+// error, which is sent on the error channel.
+//
+//	events, errs := client.Subscribe().EventType("0x4::collection::Mint").Channel(ctx, 16)
+//	for event := range events {
+//		// ...
+//	}
+//	if err := <-errs; err != nil {
+//		// ...
+//	}
+func (s *EventSubscription) Channel(ctx context.Context, buffer int) (<-chan SubscribedEvent, <-chan error) {
+	events := make(chan SubscribedEvent, buffer)
+	errs := make(chan error, 1)
+	s.handler = func(event SubscribedEvent) error {
+		select {
+		case events <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	go func() {
+		defer close(errs)
+		defer close(events)
+		err := s.Run(ctx)
+		if err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+	return events, errs
+}
+
+// Start runs the subscription in the background, see [EventSubscription.Run]
+//
+// Implements:
+//   - [Lifecycle]
+func (s *EventSubscription) Start(ctx context.Context) error {
+	return s.life.start(func() {
+		ctx, s.cancel = context.WithCancel(ctx)
+		go func() {
+			err := s.Run(ctx)
+			s.life.mutex.RLock()
+			if s.closing && errors.Is(err, context.Canceled) {
+				err = nil
+			}
+			s.life.mutex.RUnlock()
+			s.life.finish(err)
+		}()
+	})
+}
+
+// Close stops the subscription once the event being handled is done, and returns the error it stopped with, if it
+// stopped before being closed
+//
+// Implements:
+//   - [Lifecycle]
+func (s *EventSubscription) Close() error {
+	s.life.close(func() {
+		s.closing = true
+		s.cancel()
+	})
+	return s.life.wait()
+}
+
+// Done is closed once the subscription has stopped, see [EventSubscription.Err] for why
+//
+// Implements:
+//   - [Lifecycle]
+func (s *EventSubscription) Done() <-chan struct{} {
+	return s.life.doneChan()
+}
+
+// Err is the error a started subscription stopped with, nil while it is running or if it was closed
+func (s *EventSubscription) Err() error {
+	select {
+	case <-s.Done():
+		return s.life.err
+	default:
+		return nil
+	}
+}
+
+func (s *EventSubscription) deliver(txn *api.UserTransaction) error {
+	sender := AccountAddress{}
+	if txn.Sender != nil {
+		sender = *txn.Sender
+	}
+	for i, event := range txn.Events {
+		if event == nil || !s.matchesType(event.Type) || !s.matchesAddress(sender, event) || !s.matchesHandle(event) {
+			continue
+		}
+		err := s.handler(SubscribedEvent{
+			Version:         txn.Version,
+			TransactionHash: txn.Hash,
+			Sender:          sender,
+			Index:           i,
+			Event:           event,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *EventSubscription) matchesType(eventType string) bool {
+	if len(s.eventTypes) == 0 {
+		return true
+	}
+	eventType = normalizeMoveType(eventType)
+	for _, expected := range s.eventTypes {
+		if expected == eventType || (!strings.Contains(expected, "<") && expected == normalizeMoveId(eventType)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *EventSubscription) matchesAddress(sender AccountAddress, event *api.Event) bool {
+	if len(s.addresses) == 0 || s.addresses[sender] {
+		return true
+	}
+	return event.Guid != nil && event.Guid.AccountAddress != nil && s.addresses[*event.Guid.AccountAddress]
+}
+
+func (s *EventSubscription) matchesHandle(event *api.Event) bool {
+	if len(s.handles) == 0 {
+		return true
+	}
+	return event.Guid != nil && event.Guid.AccountAddress != nil && s.handles[EventHandleId{*event.Guid.AccountAddress, event.Guid.CreationNumber}]
+}
+
+// normalizeMoveType normalizes the addresses of a Move type, including its type arguments, and removes whitespace
+func normalizeMoveType(moveType string) string {
+	moveType = strings.ReplaceAll(moveType, " ", "")
+	var out strings.Builder
+	start := 0
+	for i := 0; i <= len(moveType); i++ {
+		if i < len(moveType) && moveType[i] != '<' && moveType[i] != '>' && moveType[i] != ',' {
+			continue
+		}
+		out.WriteString(normalizeMoveId(moveType[start:i]))
+		if i < len(moveType) {
+			out.WriteByte(moveType[i])
+		}
+		start = i + 1
+	}
+	return out.String()
+}
+
+//region EventHandlePoller
+
+// EventHandleClient is the subset of [Client] used by [Client.SubscribeEvents] to fetch events by handle
+type EventHandleClient interface {
+	Info() (info NodeInfo, err error)
+	EventsByCreationNumber(account AccountAddress, creationNumber uint64, start *uint64, limit *uint64) (data []*api.Event, err error)
+}
+
+// eventHandlePoller pages through the events of event handles by sequence number, so a subscription to a few handles
+// doesn't scan every transaction
+type eventHandlePoller struct {
+	client       EventHandleClient
+	pollInterval time.Duration // pollInterval defaults to [DefaultSubscriptionPollInterval]
+	batchSize    uint64        // batchSize is the number of events per request, defaults to [DefaultSubscriptionBatchSize]
+}
+
+func (p *eventHandlePoller) latestVersion() (uint64, error) {
+	info, err := p.client.Info()
+	if err != nil {
+		return 0, err
+	}
+	return info.LedgerVersion(), nil
+}
+
+// firstSequenceNumber finds the first event of the handle at or after the version.  Events of a handle are in version
+// order, so it searches exponentially for an upper bound, then binary searches below it.
+func (p *eventHandlePoller) firstSequenceNumber(handle EventHandleId, version uint64) (uint64, error) {
+	if version == 0 {
+		return 0, nil
+	}
+	// atOrAfter is true if the event is at or after the version, or there is no such event yet
+	atOrAfter := func(sequenceNumber uint64) (bool, error) {
+		limit := uint64(1)
+		events, err := p.client.EventsByCreationNumber(handle.Account, handle.CreationNumber, &sequenceNumber, &limit)
+		if err != nil {
+			return false, err
+		}
+		return len(events) == 0 || events[0].Version >= version, nil
+	}
+
+	low, high := uint64(0), uint64(0)
+	for {
+		found, err := atOrAfter(high)
+		if err != nil {
+			return 0, err
+		}
+		if found {
+			break
+		}
+		low = high + 1
+		high = 2*high + 1
+	}
+	for low < high {
+		mid := low + (high-low)/2
+		found, err := atOrAfter(mid)
+		if err != nil {
+			return 0, err
+		}
+		if found {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return low, nil
+}
+
+// events returns the events of the handle from the sequence number up to the ledger version, and the sequence number
+// to continue from
+func (p *eventHandlePoller) events(handle EventHandleId, start uint64, latest uint64) ([]*api.Event, uint64, error) {
+	batchSize := p.batchSize
+	if batchSize == 0 {
+		batchSize = DefaultSubscriptionBatchSize
+	}
+	var out []*api.Event
+	for {
+		from, limit := start, batchSize
+		events, err := p.client.EventsByCreationNumber(handle.Account, handle.CreationNumber, &from, &limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, event := range events {
+			if event.Version > latest {
+				return out, start, nil
+			}
+			// Events already delivered are skipped by sequence number
+			if event.SequenceNumber < start {
+				continue
+			}
+			out = append(out, event)
+			start = event.SequenceNumber + 1
+		}
+		if uint64(len(events)) < limit || start == from {
+			return out, start, nil
+		}
+	}
+}
+
+// runByHandle delivers the events of the subscribed handles in version order, until ctx is done or the handler returns
+// an error
+func (s *EventSubscription) runByHandle(ctx context.Context) error {
+	pollInterval := s.handlePoller.pollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultSubscriptionPollInterval
+	}
+
+	handles := make([]EventHandleId, 0, len(s.handles))
+	for handle := range s.handles {
+		handles = append(handles, handle)
+	}
+	sort.Slice(handles, func(i, j int) bool {
+		if handles[i].Account != handles[j].Account {
+			return bytes.Compare(handles[i].Account[:], handles[j].Account[:]) < 0
+		}
+		return handles[i].CreationNumber < handles[j].CreationNumber
+	})
+	sequenceNumbers := make([]uint64, len(handles))
+	for i, handle := range handles {
+		sequenceNumber, err := s.handlePoller.firstSequenceNumber(handle, s.nextVersion)
+		if err != nil {
+			return fmt.Errorf("event subscription failed to find events of handle %s %d: %w", handle.Account.String(), handle.CreationNumber, err)
+		}
+		sequenceNumbers[i] = sequenceNumber
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		latest, err := s.handlePoller.latestVersion()
+		if err != nil {
+			return fmt.Errorf("event subscription failed to get ledger version: %w", err)
+		}
+		if s.nextVersion <= latest {
+			var events []*api.Event
+			for i, handle := range handles {
+				handleEvents, next, err := s.handlePoller.events(handle, sequenceNumbers[i], latest)
+				if err != nil {
+					return fmt.Errorf("event subscription failed to get events of handle %s %d: %w", handle.Account.String(), handle.CreationNumber, err)
+				}
+				events = append(events, handleEvents...)
+				sequenceNumbers[i] = next
+			}
+			sort.SliceStable(events, func(i, j int) bool {
+				return events[i].Version < events[j].Version
+			})
+			for _, event := range events {
+				if err = ctx.Err(); err != nil {
+					return err
+				}
+				if !s.matchesType(event.Type) || !s.matchesAddress(AccountAddress{}, event) {
+					continue
+				}
+				s.nextVersion = event.Version
+				err = s.handler(SubscribedEvent{Version: event.Version, Event: event})
+				if err != nil {
+					return err
+				}
+			}
+			s.nextVersion = latest + 1
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+//endregion
+
+//region PollingEventBackend
+
+// PollingEventClient is the subset of [Client] used by [PollingEventBackend]
+type PollingEventClient interface {
+	Info() (info NodeInfo, err error)
+	Transactions(start *uint64, limit *uint64) (data []*api.CommittedTransaction, err error)
+}
+
+// PollingEventBackend is an [EventSubscriptionBackend] that pages through transactions on the node API
+type PollingEventBackend struct {
+	Client       PollingEventClient // Client is the node client to poll
+	PollInterval time.Duration      // PollInterval is how long to wait when caught up, defaults to [DefaultSubscriptionPollInterval]
+	BatchSize    uint64             // BatchSize is the number of transactions per request, defaults to [DefaultSubscriptionBatchSize]
+}
+
+// LatestVersion returns the ledger version of the node
+//
+// Implements:
+//   - [EventSubscriptionBackend]
+func (b *PollingEventBackend) LatestVersion() (uint64, error) {
+	info, err := b.Client.Info()
+	if err != nil {
+		return 0, err
+	}
+	return info.LedgerVersion(), nil
+}
+
+// Run polls for transactions starting at fromVersion
+//
+// Implements:
+//   - [EventSubscriptionBackend]
+func (b *PollingEventBackend) Run(ctx context.Context, fromVersion uint64, handle func(txn *api.UserTransaction) error) error {
+	pollInterval := b.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultSubscriptionPollInterval
+	}
+	batchSize := b.BatchSize
+	if batchSize == 0 {
+		batchSize = DefaultSubscriptionBatchSize
+	}
+
+	next := fromVersion
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		latest, err := b.LatestVersion()
+		if err != nil {
+			return fmt.Errorf("event subscription failed to get ledger version: %w", err)
+		}
+		if next > latest {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		limit := min(batchSize, latest-next+1)
+		start := next
+		txns, err := b.Client.Transactions(&start, &limit)
+		if err != nil {
+			return fmt.Errorf("event subscription failed to get transactions at %d: %w", next, err)
+		}
+		for _, txn := range txns {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+			// Events from system transactions e.g. block metadata aren't delivered
+			if userTxn, err := txn.UserTransaction(); err == nil {
+				err = handle(userTxn)
+				if err != nil {
+					return err
+				}
+			}
+			next = txn.Version() + 1
+		}
+		if len(txns) == 0 {
+			next = start + limit
+		}
+	}
+}
+
+//endregion
@@ -0,0 +1,291 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPollingEventClient struct {
+	mutex sync.Mutex
+	txns  []*api.CommittedTransaction
+}
+
+func (m *mockPollingEventClient) Info() (NodeInfo, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return NodeInfo{LedgerVersionStr: strconv.Itoa(len(m.txns) - 1)}, nil
+}
+
+func (m *mockPollingEventClient) Transactions(start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	end := min(*start+*limit, uint64(len(m.txns)))
+	return m.txns[*start:end], nil
+}
+
+func (m *mockPollingEventClient) add(t *testing.T, sender string, events []map[string]any) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	userTxn := testTransferTransaction(t, uint64(len(m.txns)), "0x"+strconv.Itoa(len(m.txns)), events, nil)
+	address := AccountAddress{}
+	assert.NoError(t, address.ParseStringRelaxed(sender))
+	userTxn.Sender = &address
+	m.txns = append(m.txns, &api.CommittedTransaction{Type: api.TransactionVariantUser, Inner: userTxn})
+}
+
+func TestEventSubscription(t *testing.T) {
+	type MintEvent struct {
+		Collection string `json:"collection"`
+		Index      string `json:"index"`
+	}
+	mint := func(index string) map[string]any {
+		return testCategoryEvent("0x4::collection::Mint", map[string]any{"collection": "0xc", "index": index})
+	}
+
+	client := &mockPollingEventClient{}
+	client.add(t, "0xa", []map[string]any{mint("1")})
+	client.add(t, "0xb", []map[string]any{mint("2")})
+	client.add(t, "0xa", []map[string]any{testCategoryEvent("0x1::coin::CoinDeposit<0x1::aptos_coin::AptosCoin>", map[string]any{}), mint("3")})
+
+	alice := AccountAddress{}
+	assert.NoError(t, alice.ParseStringRelaxed("0xa"))
+
+	var received []MintEvent
+	var versions []uint64
+	ctx, cancel := context.WithCancel(context.Background())
+	subscription := NewEventSubscription(&PollingEventBackend{Client: client, PollInterval: time.Millisecond, BatchSize: 2}).
+		Address(alice).
+		EventType("0x0000000000000000000000000000000000000000000000000000000000000004::collection::Mint").
+		FromVersion(0).
+		Handler(TypedEventHandler(func(event SubscribedEvent, data MintEvent) error {
+			received = append(received, data)
+			versions = append(versions, event.Version)
+			if data.Index == "4" {
+				cancel()
+			}
+			return nil
+		}))
+
+	// Events committed after the subscription starts are delivered too
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		client.add(t, "0xa", []map[string]any{mint("4")})
+	}()
+	err := subscription.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []MintEvent{{"0xc", "1"}, {"0xc", "3"}, {"0xc", "4"}}, received)
+	assert.Equal(t, []uint64{0, 2, 3}, versions)
+	assert.Equal(t, uint64(4), subscription.NextVersion())
+}
+
+func TestEventSubscriptionHandlerError(t *testing.T) {
+	client := &mockPollingEventClient{}
+	client.add(t, "0xa", []map[string]any{testCategoryEvent("0x1::coin::CoinDeposit<0x1::aptos_coin::AptosCoin>", map[string]any{})})
+	client.add(t, "0xa", []map[string]any{testCategoryEvent("0x1::coin::CoinDeposit<0xcafe::usd::USD>", map[string]any{})})
+
+	// Type arguments are compared when given
+	stop := errors.New("stop")
+	var versions []uint64
+	err := NewEventSubscription(&PollingEventBackend{Client: client, PollInterval: time.Millisecond}).
+		EventType("0x1::coin::CoinDeposit<0xcafe::usd::USD>").
+		FromVersion(0).
+		Handler(func(event SubscribedEvent) error {
+			versions = append(versions, event.Version)
+			return stop
+		}).
+		Run(context.Background())
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, []uint64{1}, versions)
+
+	// Without a start version, only new transactions are delivered
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = NewEventSubscription(&PollingEventBackend{Client: client, PollInterval: time.Millisecond}).
+		Handler(func(event SubscribedEvent) error {
+			t.Errorf("unexpected event at version %d", event.Version)
+			return nil
+		}).
+		Run(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestEventSubscriptionLifecycle(t *testing.T) {
+	client := &mockPollingEventClient{}
+	client.add(t, "0xa", []map[string]any{testCategoryEvent("0x1::coin::CoinDeposit<0x1::aptos_coin::AptosCoin>", map[string]any{})})
+
+	handled := make(chan uint64)
+	release := make(chan struct{})
+	subscription := NewEventSubscription(&PollingEventBackend{Client: client, PollInterval: time.Millisecond}).
+		FromVersion(0).
+		Handler(func(event SubscribedEvent) error {
+			handled <- event.Version
+			<-release
+			return nil
+		})
+	var _ Lifecycle = subscription
+
+	assert.NoError(t, subscription.Start(context.Background()))
+	assert.ErrorIs(t, subscription.Start(context.Background()), ErrAlreadyStarted)
+	assert.Equal(t, uint64(0), <-handled)
+
+	// Close waits for the event being handled
+	closed := make(chan error)
+	go func() {
+		closed <- subscription.Close()
+	}()
+	select {
+	case <-subscription.Done():
+		t.Fatal("closed before the handler finished")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	assert.NoError(t, <-closed)
+	<-subscription.Done()
+	assert.NoError(t, subscription.Err())
+	assert.Equal(t, uint64(1), subscription.NextVersion())
+	assert.NoError(t, subscription.Close())
+	assert.ErrorIs(t, subscription.Start(context.Background()), ErrClosed)
+
+	// Stopping on its own is reported
+	failing := NewEventSubscription(&PollingEventBackend{Client: client}).FromVersion(0)
+	assert.NoError(t, failing.Start(context.Background()))
+	<-failing.Done()
+	assert.ErrorContains(t, failing.Err(), "no handler")
+	assert.ErrorContains(t, failing.Close(), "no handler")
+
+	// Closing before starting is done straight away
+	unstarted := NewEventSubscription(&PollingEventBackend{Client: client})
+	assert.NoError(t, unstarted.Close())
+	<-unstarted.Done()
+}
+
+func TestEventSubscriptionChannel(t *testing.T) {
+	handleEvent := func(address string, creationNumber string) map[string]any {
+		event := testCategoryEvent("0x1::coin::DepositEvent", map[string]any{"amount": "1"})
+		event["guid"] = map[string]any{"creation_number": creationNumber, "account_address": address}
+		return event
+	}
+	client := &mockPollingEventClient{}
+	client.add(t, "0xa", []map[string]any{handleEvent("0xb", "2"), handleEvent("0xb", "3")})
+	client.add(t, "0xa", []map[string]any{handleEvent("0xc", "2")})
+	client.add(t, "0xa", []map[string]any{handleEvent("0xb", "2")})
+
+	owner := AccountAddress{}
+	assert.NoError(t, owner.ParseStringRelaxed("0xb"))
+
+	// An unbuffered channel holds back polling until each event is read
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errs := NewEventSubscription(&PollingEventBackend{Client: client, PollInterval: time.Millisecond, BatchSize: 1}).
+		EventHandle(EventHandleId{Account: owner, CreationNumber: 2}).
+		FromVersion(0).
+		Channel(ctx, 0)
+	assert.Equal(t, uint64(0), (<-events).Version)
+	assert.Equal(t, uint64(2), (<-events).Version)
+	cancel()
+	for range events {
+	}
+	assert.NoError(t, <-errs)
+
+	// Errors are reported on the error channel
+	failing := errors.New("failing")
+	events, errs = NewEventSubscription(&PollingEventBackend{Client: &failingPollingEventClient{failing}}).
+		FromVersion(0).
+		Channel(context.Background(), 1)
+	_, ok := <-events
+	assert.False(t, ok)
+	assert.ErrorIs(t, <-errs, failing)
+}
+
+type failingPollingEventClient struct {
+	err error
+}
+
+func (m *failingPollingEventClient) Info() (NodeInfo, error) {
+	return NodeInfo{}, m.err
+}
+
+func (m *failingPollingEventClient) Transactions(*uint64, *uint64) ([]*api.CommittedTransaction, error) {
+	return nil, m.err
+}
+
+type mockEventHandleClient struct {
+	mutex   sync.Mutex
+	latest  uint64
+	events  map[EventHandleId][]*api.Event
+	fetches int
+}
+
+func (m *mockEventHandleClient) Info() (NodeInfo, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return NodeInfo{LedgerVersionStr: strconv.FormatUint(m.latest, 10)}, nil
+}
+
+func (m *mockEventHandleClient) EventsByCreationNumber(account AccountAddress, creationNumber uint64, start *uint64, limit *uint64) ([]*api.Event, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.fetches++
+	events := m.events[EventHandleId{account, creationNumber}]
+	if *start >= uint64(len(events)) {
+		return []*api.Event{}, nil
+	}
+	return events[*start:min(*start+*limit, uint64(len(events)))], nil
+}
+
+func (m *mockEventHandleClient) add(handle EventHandleId, version uint64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	events := m.events[handle]
+	m.events[handle] = append(events, &api.Event{
+		Type:           "0x1::coin::DepositEvent",
+		Guid:           &api.GUID{CreationNumber: handle.CreationNumber, AccountAddress: &handle.Account},
+		SequenceNumber: uint64(len(events)),
+		Version:        version,
+	})
+	m.latest = max(m.latest, version)
+}
+
+func TestEventSubscriptionByHandle(t *testing.T) {
+	first := EventHandleId{Account: AccountOne, CreationNumber: 2}
+	second := EventHandleId{Account: AccountTwo, CreationNumber: 3}
+	client := &mockEventHandleClient{events: make(map[EventHandleId][]*api.Event)}
+	for version := uint64(0); version < 100; version += 2 {
+		client.add(first, version)
+	}
+	client.add(second, 51)
+	client.add(second, 97)
+
+	subscription := NewEventSubscription(nil).EventHandle(first).EventHandle(second).FromVersion(95)
+	subscription.handlePoller = &eventHandlePoller{client: client, pollInterval: time.Millisecond, batchSize: 2}
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errs := subscription.Channel(ctx, 0)
+
+	// The start is found by searching the sequence numbers, rather than paging through every event
+	versions := []uint64{(<-events).Version, (<-events).Version, (<-events).Version}
+	assert.Equal(t, []uint64{96, 97, 98}, versions)
+	client.mutex.Lock()
+	assert.Less(t, client.fetches, 20)
+	client.mutex.Unlock()
+
+	// New events are delivered once, in version order across handles
+	client.add(second, 100)
+	client.add(first, 101)
+	event := <-events
+	assert.Equal(t, uint64(100), event.Version)
+	assert.Equal(t, uint64(2), event.Event.SequenceNumber)
+	event = <-events
+	assert.Equal(t, uint64(101), event.Version)
+	assert.Equal(t, uint64(50), event.Event.SequenceNumber)
+
+	cancel()
+	for range events {
+	}
+	assert.NoError(t, <-errs)
+}
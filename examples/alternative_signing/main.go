@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk"
 	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/aptos-labs/aptos-go-sdk/examples/internal/harness"
 	"golang.org/x/crypto/ed25519"
 )
 
@@ -132,5 +133,5 @@ func example(network aptos.NetworkConfig) {
 
 // main This example shows you how to make an alternative signer for the SDK, if you prefer a different library
 func main() {
-	example(aptos.DevnetConfig)
+	example(harness.NetworkFromFlags())
 }
@@ -2,4 +2,9 @@
 //
 // Each runs on its own and is meant to be a standalone example of how to use the SDK.
 // Additionally, each run in CI to ensure that they are working as expected as a unit test.
+//
+// Shared client, network flag, and account setup lives in examples/internal/harness.  All examples can be run at once
+// against a network with the runner:
+//
+//	go run ./examples/runner -network localnet -parallel 4
 package examples
@@ -0,0 +1,90 @@
+// Package harness contains shared setup for the examples, so each example can focus on the SDK feature it shows
+package harness
+
+import (
+	"flag"
+	"fmt"
+	"sync"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+)
+
+// NewClient creates a client for the network, panicking on failure like the rest of the examples
+func NewClient(networkConfig aptos.NetworkConfig) *aptos.Client {
+	client, err := aptos.NewClient(networkConfig)
+	if err != nil {
+		panic("Failed to create client:" + err.Error())
+	}
+	return client
+}
+
+// NewAccounts creates count new Ed25519 accounts locally
+func NewAccounts(count int) ([]*aptos.Account, error) {
+	accounts := make([]*aptos.Account, count)
+	for i := range accounts {
+		account, err := aptos.NewEd25519Account()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create account %d: %w", i, err)
+		}
+		accounts[i] = account
+	}
+	return accounts, nil
+}
+
+// FundAccounts funds all addresses from the faucet in parallel, returning the first error encountered
+func FundAccounts(client *aptos.Client, amount uint64, addresses ...aptos.AccountAddress) error {
+	errs := make([]error, len(addresses))
+	var wg sync.WaitGroup
+	for i, address := range addresses {
+		wg.Add(1)
+		go func(i int, address aptos.AccountAddress) {
+			defer wg.Done()
+			errs[i] = client.Fund(address, amount)
+		}(i, address)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to fund %s: %w", addresses[i].String(), err)
+		}
+	}
+	return nil
+}
+
+// SetupAccounts creates count accounts and funds each of them with amount, panicking on failure
+//
+// An amount of 0 skips funding, which is useful for receivers that only need to exist locally
+func SetupAccounts(client *aptos.Client, count int, amount uint64) []*aptos.Account {
+	accounts, err := NewAccounts(count)
+	if err != nil {
+		panic("Failed to create accounts:" + err.Error())
+	}
+	if amount == 0 {
+		return accounts
+	}
+
+	addresses := make([]aptos.AccountAddress, len(accounts))
+	for i, account := range accounts {
+		addresses[i] = account.Address
+	}
+	err = FundAccounts(client, amount, addresses...)
+	if err != nil {
+		panic("Failed to fund accounts:" + err.Error())
+	}
+	return accounts
+}
+
+// NetworkFromFlags parses the common example flags and returns the selected network
+//
+//	go run ./examples/transfer_coin -network localnet
+func NetworkFromFlags() aptos.NetworkConfig {
+	network := flag.String("network", "devnet", "network to run against: localnet, devnet, testnet, or mainnet")
+	flag.Parse()
+
+	config, ok := aptos.NamedNetworks[*network]
+	if !ok {
+		panic("Unknown network: " + *network)
+	}
+	return config
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/aptos-labs/aptos-go-sdk/api"
 
 	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/examples/internal/harness"
 )
 
 const FundAmount = 100_000_000
@@ -161,5 +162,5 @@ func example(networkConfig aptos.NetworkConfig) {
 }
 
 func main() {
-	example(aptos.DevnetConfig)
+	example(harness.NetworkFromFlags())
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/aptos-labs/aptos-go-sdk"
 	"github.com/aptos-labs/aptos-go-sdk/api"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/examples/internal/harness"
 	"time"
 )
 
@@ -327,5 +328,5 @@ func submitAndWait(client *aptos.Client, sender *aptos.Account, payload aptos.Tr
 }
 
 func main() {
-	example(aptos.DevnetConfig)
+	example(harness.NetworkFromFlags())
 }
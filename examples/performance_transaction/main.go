@@ -4,6 +4,7 @@ package main
 import (
 	"encoding/json"
 	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/examples/internal/harness"
 	"time"
 )
 
@@ -91,5 +92,5 @@ func example(networkConfig aptos.NetworkConfig) {
 }
 
 func main() {
-	example(aptos.DevnetConfig)
+	example(harness.NetworkFromFlags())
 }
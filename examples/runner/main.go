@@ -0,0 +1,166 @@
+// runner runs the examples against a network, so they can all be checked in one command
+//
+//	go run ./examples/runner -network localnet -run transfer_coin,sponsored_transaction
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// skipped examples are directories that are not runnable examples
+var skipped = map[string]bool{
+	"internal": true,
+	"move":     true,
+	"runner":   true,
+}
+
+// result of a single example run
+type result struct {
+	name     string
+	output   []byte
+	err      error
+	duration time.Duration
+}
+
+// discover finds all runnable examples in the examples directory
+func discover(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() || skipped[entry.Name()] {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, entry.Name(), "main.go")); err != nil {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// filter keeps only the examples requested, erroring on unknown names
+func filter(names []string, run string) ([]string, error) {
+	if run == "" {
+		return names, nil
+	}
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+	selected := make([]string, 0)
+	for _, name := range strings.Split(run, ",") {
+		name = strings.TrimSpace(name)
+		if !known[name] {
+			return nil, fmt.Errorf("unknown example %s", name)
+		}
+		selected = append(selected, name)
+	}
+	return selected, nil
+}
+
+// examplePath is the package path of an example for `go run`, which needs relative paths to start with ./
+func examplePath(dir string, name string) string {
+	path := filepath.Join(dir, name)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return "." + string(filepath.Separator) + path
+}
+
+// runExample runs a single example with `go run`, forwarding the network flag
+func runExample(dir string, name string, network string, timeout time.Duration) result {
+	start := time.Now()
+	cmd := exec.Command("go", "run", examplePath(dir, name), "-network", network)
+	done := make(chan struct{})
+	var output []byte
+	var err error
+	go func() {
+		output, err = cmd.CombinedOutput()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		if cmd.Process != nil {
+			_ = cmd.Process.Kill()
+		}
+		<-done
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	return result{name: name, output: output, err: err, duration: time.Since(start)}
+}
+
+func main() {
+	dir := flag.String("dir", "examples", "directory containing the examples")
+	network := flag.String("network", "localnet", "network to run against: localnet, devnet, testnet, or mainnet")
+	run := flag.String("run", "", "comma separated list of examples to run, defaults to all")
+	list := flag.Bool("list", false, "list the examples and exit")
+	parallel := flag.Int("parallel", 1, "number of examples to run at once")
+	timeout := flag.Duration("timeout", 5*time.Minute, "timeout per example")
+	verbose := flag.Bool("v", false, "print output of successful examples")
+	flag.Parse()
+
+	names, err := discover(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to find examples: %v\n", err)
+		os.Exit(2)
+	}
+	if *list {
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return
+	}
+	names, err = filter(names, *run)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+	if *parallel < 1 {
+		*parallel = 1
+	}
+
+	results := make([]result, len(names))
+	sem := make(chan struct{}, *parallel)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = runExample(*dir, name, *network, *timeout)
+		}(i, name)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, res := range results {
+		if res.err != nil {
+			failed++
+			fmt.Printf("FAIL %s (%s): %v\n%s\n", res.name, res.duration.Round(time.Millisecond), res.err, res.output)
+			continue
+		}
+		fmt.Printf("ok   %s (%s)\n", res.name, res.duration.Round(time.Millisecond))
+		if *verbose {
+			fmt.Printf("%s\n", res.output)
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("%d of %d examples failed\n", failed, len(results))
+		os.Exit(1)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aptos-labs/aptos-go-sdk"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/examples/internal/harness"
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
 )
 
@@ -312,5 +313,5 @@ func runScript(client *aptos.Client, alice *aptos.Account) {
 	}
 }
 func main() {
-	example(aptos.DevnetConfig)
+	example(harness.NetworkFromFlags())
 }
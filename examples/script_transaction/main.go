@@ -7,6 +7,7 @@ import (
 	"github.com/aptos-labs/aptos-go-sdk"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/aptos-labs/aptos-go-sdk/examples/internal/harness"
 )
 
 const testEd25519PrivateKey = "ed25519-priv-0xc5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5"
@@ -103,5 +104,5 @@ func runScript(client *aptos.Client, alice *aptos.Account, bob *aptos.AccountAdd
 
 // main This example shows how to send a transaction with a script
 func main() {
-	example(aptos.DevnetConfig)
+	example(harness.NetworkFromFlags())
 }
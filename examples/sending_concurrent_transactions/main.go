@@ -4,6 +4,7 @@ package main
 import (
 	"github.com/aptos-labs/aptos-go-sdk"
 	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/examples/internal/harness"
 	"time"
 )
 
@@ -126,5 +127,5 @@ func example(networkConfig aptos.NetworkConfig, numTransactions uint64) {
 }
 
 func main() {
-	example(aptos.DevnetConfig, 100)
+	example(harness.NetworkFromFlags(), 100)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aptos-labs/aptos-go-sdk"
 	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/aptos-labs/aptos-go-sdk/examples/internal/harness"
 )
 
 const FundAmount = 100_000_000
@@ -14,42 +15,19 @@ const TransferAmount = 1_000
 // example This example shows you how to make an APT transfer transaction in the simplest possible way
 func example(networkConfig aptos.NetworkConfig) {
 	// Create a client for Aptos
-	client, err := aptos.NewClient(networkConfig)
-	if err != nil {
-		panic("Failed to create client:" + err.Error())
-	}
+	client := harness.NewClient(networkConfig)
 
-	// Create accounts locally for alice and bob
-	alice, err := aptos.NewEd25519Account()
-	if err != nil {
-		panic("Failed to create alice:" + err.Error())
-	}
-	bob, err := aptos.NewEd25519Account()
-	if err != nil {
-		panic("Failed to create bob:" + err.Error())
-	}
-	sponsor, err := aptos.NewEd25519Account()
-	if err != nil {
-		panic("Failed to create sponsor:" + err.Error())
-	}
+	// Create accounts locally for alice, bob, and the sponsor.  Alice and the sponsor are funded in parallel,
+	// bob only needs to exist locally to receive the transfer
+	funded := harness.SetupAccounts(client, 2, FundAmount)
+	alice, sponsor := funded[0], funded[1]
+	bob := harness.SetupAccounts(client, 1, 0)[0]
 
 	fmt.Printf("\n=== Addresses ===\n")
 	fmt.Printf("Alice: %s\n", alice.Address.String())
 	fmt.Printf("Bob:%s\n", bob.Address.String())
 	fmt.Printf("Sponsor:%s\n", sponsor.Address.String())
 
-	// Fund the alice with the faucet to create it on-chain
-	err = client.Fund(alice.Address, FundAmount)
-	if err != nil {
-		panic("Failed to fund alice:" + err.Error())
-	}
-
-	// And the sponsor
-	err = client.Fund(sponsor.Address, FundAmount)
-	if err != nil {
-		panic("Failed to fund sponsor:" + err.Error())
-	}
-
 	aliceBalance, err := client.AccountAPTBalance(alice.Address)
 	if err != nil {
 		panic("Failed to retrieve alice balance:" + err.Error())
@@ -218,5 +196,5 @@ func example(networkConfig aptos.NetworkConfig) {
 }
 
 func main() {
-	example(aptos.DevnetConfig)
+	example(harness.NetworkFromFlags())
 }
@@ -0,0 +1,221 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FailoverStrategy is the order a [Failover] tries its fullnodes in
+type FailoverStrategy int
+
+const (
+	FailoverPrimaryBackup FailoverStrategy = iota // FailoverPrimaryBackup sends to the first healthy node, in the configured order
+	FailoverRoundRobin                            // FailoverRoundRobin spreads requests over the healthy nodes in turn
+	FailoverHealthAware                           // FailoverHealthAware sends to the healthy node with the newest ledger version
+)
+
+// DefaultFailoverCooldown is how long a failed node is skipped, see [Failover.Cooldown]
+const DefaultFailoverCooldown = 30 * time.Second
+
+// Failover is a [NewClient] option to spread node requests over several fullnodes of the same network.  A node that
+// fails with a network error or a 5xx response is skipped for the cooldown, and the request is sent to the next node,
+// if its body can be sent again.  Like [RetryPolicy], a POST that may have taken effect e.g. a transaction submission
+// isn't sent to the next node unless RetryNonIdempotent is set.  A node whose responses fall more than MaxLag versions
+// behind the newest seen is skipped too.  When every node is skipped, they're all tried anyway.
+//
+// Reads may go to different nodes, so pin them with [Client.AtLedgerVersion] if they must agree.
+//
+//	client, err := NewClient(MainnetConfig, Failover{
+//		NodeUrls: []string{"https://fullnode.backup.example/v1"},
+//		Strategy: FailoverHealthAware,
+//		MaxLag:   1000,
+//	})
+type Failover struct {
+	NodeUrls []string         // NodeUrls are the other fullnodes, tried after the network config's NodeUrl
+	Strategy FailoverStrategy // Strategy is the order nodes are tried in, defaults to [FailoverPrimaryBackup]
+	Cooldown time.Duration    // Cooldown is how long a failed or lagging node is skipped, defaults to [DefaultFailoverCooldown]
+	MaxLag   uint64           // MaxLag is the number of versions a node may fall behind, no limit if 0
+
+	RetryNonIdempotent bool // RetryNonIdempotent sends POSTs other than views, simulations, and indexer queries to the next node too
+}
+
+// failoverEndpoint is the state of one node of a [Failover]
+type failoverEndpoint struct {
+	baseUrl       *url.URL
+	skipUntil     time.Time
+	ledgerVersion uint64
+}
+
+// failoverTransport sends node requests, addressed to the primary node, to the chosen node
+type failoverTransport struct {
+	inner     http.RoundTripper
+	primary   *url.URL
+	failover  Failover
+	next      atomic.Uint64 // next is the round-robin counter
+	mutex     sync.Mutex
+	endpoints []*failoverEndpoint
+	newest    uint64 // newest is the newest ledger version seen on any node
+}
+
+// transport wraps inner to route requests for the primary node over every node
+func (failover Failover) transport(inner http.RoundTripper, primary *url.URL) (http.RoundTripper, error) {
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	if failover.Cooldown <= 0 {
+		failover.Cooldown = DefaultFailoverCooldown
+	}
+	t := &failoverTransport{
+		inner:     inner,
+		primary:   primary,
+		failover:  failover,
+		endpoints: []*failoverEndpoint{{baseUrl: primary}},
+	}
+	for _, nodeUrl := range failover.NodeUrls {
+		baseUrl, err := url.Parse(nodeUrl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse failover node url '%s': %w", nodeUrl, err)
+		}
+		t.endpoints = append(t.endpoints, &failoverEndpoint{baseUrl: baseUrl})
+	}
+	return t, nil
+}
+
+func (t *failoverTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	suffix, ok := t.nodePath(request.URL)
+	if !ok {
+		// Not a node request e.g. the indexer
+		return t.inner.RoundTrip(request)
+	}
+	// A body that can't be rewound can only be sent once, and a submission may have taken effect on the failed node
+	resendable := request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+	resendable = resendable && (t.failover.RetryNonIdempotent || idempotent(request))
+
+	var response *http.Response
+	var err error
+	for i, endpoint := range t.candidates() {
+		if i > 0 {
+			if !resendable {
+				break
+			}
+			if response != nil {
+				_ = response.Body.Close()
+			}
+		}
+		attempt, attemptErr := t.rewrite(request, endpoint, suffix, i > 0)
+		if attemptErr != nil {
+			return nil, attemptErr
+		}
+		response, err = t.inner.RoundTrip(attempt)
+		if request.Context().Err() != nil || errors.Is(err, context.Canceled) {
+			return response, err
+		}
+		if err == nil && response.StatusCode < 500 {
+			t.record(endpoint, response)
+			return response, nil
+		}
+		t.skip(endpoint)
+	}
+	return response, err
+}
+
+// nodePath is the path of a request under the primary node's URL, false if the request isn't for the node
+func (t *failoverTransport) nodePath(requestUrl *url.URL) (string, bool) {
+	if requestUrl.Scheme != t.primary.Scheme || requestUrl.Host != t.primary.Host {
+		return "", false
+	}
+	suffix, ok := strings.CutPrefix(requestUrl.Path, strings.TrimSuffix(t.primary.Path, "/"))
+	if !ok || (suffix != "" && !strings.HasPrefix(suffix, "/")) {
+		return "", false
+	}
+	return suffix, true
+}
+
+// rewrite addresses the request to the endpoint, with a fresh body if it was already sent
+func (t *failoverTransport) rewrite(request *http.Request, endpoint *failoverEndpoint, suffix string, resend bool) (*http.Request, error) {
+	attempt := request.Clone(request.Context())
+	target := *endpoint.baseUrl
+	target.Path = strings.TrimSuffix(target.Path, "/") + suffix
+	target.RawPath = ""
+	target.RawQuery = request.URL.RawQuery
+	attempt.URL = &target
+	attempt.Host = ""
+	if resend && request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		attempt.Body = body
+	}
+	return attempt, nil
+}
+
+// candidates orders the endpoints by the strategy, skipped ones last
+func (t *failoverTransport) candidates() []*failoverEndpoint {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	ordered := slices.Clone(t.endpoints)
+	switch t.failover.Strategy {
+	case FailoverRoundRobin:
+		start := int((t.next.Add(1) - 1) % uint64(len(ordered)))
+		ordered = append(ordered[start:], ordered[:start]...)
+	case FailoverHealthAware:
+		slices.SortStableFunc(ordered, func(a, b *failoverEndpoint) int {
+			switch {
+			case a.ledgerVersion > b.ledgerVersion:
+				return -1
+			case a.ledgerVersion < b.ledgerVersion:
+				return 1
+			}
+			return 0
+		})
+	}
+	now := time.Now()
+	slices.SortStableFunc(ordered, func(a, b *failoverEndpoint) int {
+		aSkipped, bSkipped := now.Before(a.skipUntil), now.Before(b.skipUntil)
+		switch {
+		case !aSkipped && bSkipped:
+			return -1
+		case aSkipped && !bSkipped:
+			return 1
+		}
+		return 0
+	})
+	return ordered
+}
+
+// skip skips the endpoint for the cooldown
+func (t *failoverTransport) skip(endpoint *failoverEndpoint) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	endpoint.skipUntil = time.Now().Add(t.failover.Cooldown)
+}
+
+// record notes the ledger version of a response, and skips the endpoints lagging behind it
+func (t *failoverTransport) record(endpoint *failoverEndpoint, response *http.Response) {
+	info, ok := LedgerInfoFromHeaders(response.Header)
+	if !ok {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	endpoint.ledgerVersion = info.LedgerVersion
+	endpoint.skipUntil = time.Time{}
+	t.newest = max(t.newest, info.LedgerVersion)
+	if t.failover.MaxLag == 0 {
+		return
+	}
+	for _, other := range t.endpoints {
+		if other.ledgerVersion > 0 && t.newest-other.ledgerVersion > t.failover.MaxLag {
+			other.skipUntil = time.Now().Add(t.failover.Cooldown)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package aptos
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failoverTestNode is a fullnode answering account requests at a ledger version, or failing with a status
+type failoverTestNode struct {
+	server        *httptest.Server
+	requests      atomic.Int32
+	status        atomic.Int32
+	ledgerVersion atomic.Uint64
+}
+
+func newFailoverTestNode(t *testing.T, basePath string) *failoverTestNode {
+	node := &failoverTestNode{}
+	node.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node.requests.Add(1)
+		assert.Equal(t, basePath+"/accounts/0x1", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("ledger_version"))
+		if status := node.status.Load(); status != 0 {
+			w.WriteHeader(int(status))
+			return
+		}
+		w.Header().Set(HeaderAptosLedgerVersion, strconv.FormatUint(node.ledgerVersion.Load(), 10))
+		_, _ = w.Write([]byte(`{"sequence_number": "7", "authentication_key": "0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+	}))
+	t.Cleanup(node.server.Close)
+	return node
+}
+
+func TestFailover_PrimaryBackup(t *testing.T) {
+	primary := newFailoverTestNode(t, "/v1")
+	backup := newFailoverTestNode(t, "/api/v1")
+	primary.status.Store(http.StatusBadGateway)
+
+	client, err := NewClient(NetworkConfig{NodeUrl: primary.server.URL + "/v1", ChainId: 4}, Failover{NodeUrls: []string{backup.server.URL + "/api/v1"}})
+	assert.NoError(t, err)
+	for range 3 {
+		account, err := client.Account(AccountOne, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, "7", account.SequenceNumberStr)
+	}
+	// The failed primary is skipped after its first failure
+	assert.Equal(t, int32(1), primary.requests.Load())
+	assert.Equal(t, int32(3), backup.requests.Load())
+
+	// Every node failing fails the request
+	backup.status.Store(http.StatusServiceUnavailable)
+	_, err = client.Account(AccountOne, 2)
+	assert.Error(t, err)
+}
+
+func TestFailover_RoundRobin(t *testing.T) {
+	first := newFailoverTestNode(t, "/v1")
+	second := newFailoverTestNode(t, "/v1")
+
+	client, err := NewClient(NetworkConfig{NodeUrl: first.server.URL + "/v1", ChainId: 4}, Failover{NodeUrls: []string{second.server.URL + "/v1"}, Strategy: FailoverRoundRobin})
+	assert.NoError(t, err)
+	for range 4 {
+		_, err := client.Account(AccountOne, 2)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), first.requests.Load())
+	assert.Equal(t, int32(2), second.requests.Load())
+}
+
+func TestFailover_Lagging(t *testing.T) {
+	lagging := newFailoverTestNode(t, "/v1")
+	synced := newFailoverTestNode(t, "/v1")
+	lagging.ledgerVersion.Store(100)
+	synced.ledgerVersion.Store(5000)
+
+	client, err := NewClient(NetworkConfig{NodeUrl: lagging.server.URL + "/v1", ChainId: 4}, Failover{NodeUrls: []string{synced.server.URL + "/v1"}, Strategy: FailoverRoundRobin, MaxLag: 1000})
+	assert.NoError(t, err)
+	for range 6 {
+		_, err := client.Account(AccountOne, 2)
+		assert.NoError(t, err)
+	}
+	// Once the newer node is seen, the lagging one is skipped
+	assert.Equal(t, int32(1), lagging.requests.Load())
+	assert.Equal(t, int32(5), synced.requests.Load())
+}
+
+func TestFailover_HealthAware(t *testing.T) {
+	older := newFailoverTestNode(t, "/v1")
+	newer := newFailoverTestNode(t, "/v1")
+	older.ledgerVersion.Store(100)
+	newer.ledgerVersion.Store(150)
+
+	client, err := NewClient(NetworkConfig{NodeUrl: older.server.URL + "/v1", ChainId: 4}, Failover{NodeUrls: []string{newer.server.URL + "/v1"}, Strategy: FailoverHealthAware})
+	assert.NoError(t, err)
+	// The primary answers first, as no version is known
+	_, err = client.Account(AccountOne, 2)
+	assert.NoError(t, err)
+	// Failing once lets the newer node report its version
+	older.status.Store(http.StatusInternalServerError)
+	_, err = client.Account(AccountOne, 2)
+	assert.NoError(t, err)
+	older.status.Store(0)
+	for range 3 {
+		_, err := client.Account(AccountOne, 2)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), older.requests.Load())
+	assert.Equal(t, int32(4), newer.requests.Load())
+}
+
+func TestFailover_NonIdempotent(t *testing.T) {
+	var primaryRequests, backupRequests atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryRequests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		backupRequests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backup.Close()
+
+	post := func(failover Failover, path string) int {
+		primaryUrl, err := url.Parse(primary.URL + "/v1")
+		assert.NoError(t, err)
+		transport, err := failover.transport(nil, primaryUrl)
+		assert.NoError(t, err)
+		request, err := http.NewRequest(http.MethodPost, primary.URL+"/v1"+path, bytes.NewReader([]byte("{}")))
+		assert.NoError(t, err)
+		response, err := transport.RoundTrip(request)
+		assert.NoError(t, err)
+		_ = response.Body.Close()
+		return response.StatusCode
+	}
+	failover := Failover{NodeUrls: []string{backup.URL + "/v1"}}
+
+	// A submission isn't sent to another node, as it may have reached the mempool
+	assert.Equal(t, http.StatusBadGateway, post(failover, "/transactions"))
+	assert.Equal(t, int32(0), backupRequests.Load())
+
+	// Views are read-only, so they fail over
+	assert.Equal(t, http.StatusOK, post(failover, "/view"))
+	assert.Equal(t, int32(1), backupRequests.Load())
+
+	failover.RetryNonIdempotent = true
+	assert.Equal(t, http.StatusOK, post(failover, "/transactions"))
+	assert.Equal(t, int32(2), backupRequests.Load())
+	assert.Equal(t, int32(3), primaryRequests.Load())
+}
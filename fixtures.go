@@ -0,0 +1,388 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// FixtureOptions configures the Go source made by [GenerateFixtures]
+type FixtureOptions struct {
+	Package string   // Package is the Go package name of the generated file, defaults to "fixtures"
+	Modules []string // Modules limits the generated code to these modules, all modules are used if empty
+}
+
+// GenerateFixtures generates Go test scaffolding for the Move modules at an address:
+//
+//   - A Modules type with a typed payload builder method for every entry function
+//   - Go structs for the module's structs, in the node API's JSON format, with a fetch method for each resource
+//   - SetupLocalnet, which funds a publisher on localnet and publishes the package from the JSON file made by
+//     `aptos move build-publish-payload`, so the builders can be used against a fresh copy of the package
+//
+// Argument types with no simple Go equivalent, e.g. options and generics, are taken as any and converted the same way
+// as [EntryFunctionFromAbi].  The output is formatted Go source.
+//
+//	source, err := GenerateFixtures(address, modules, FixtureOptions{Package: "counter_test"})
+//	err = os.WriteFile("fixtures_test.go", source, 0644)
+func GenerateFixtures(address AccountAddress, modules []*api.MoveModule, options FixtureOptions) ([]byte, error) {
+	if options.Package == "" {
+		options.Package = "fixtures"
+	}
+	if len(options.Modules) > 0 {
+		filtered := make([]*api.MoveModule, 0, len(options.Modules))
+		for _, name := range options.Modules {
+			found := false
+			for _, module := range modules {
+				if module.Name == name {
+					filtered = append(filtered, module)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("module %s not found at %s", name, address.String())
+			}
+		}
+		modules = filtered
+	}
+	if len(modules) == 0 {
+		return nil, errors.New("no modules to generate fixtures for")
+	}
+
+	gen := &fixtureGenerator{
+		address: address,
+		imports: map[string]bool{"fmt": true, "os": true, "github.com/aptos-labs/aptos-go-sdk": true},
+	}
+	for _, module := range modules {
+		gen.module(module)
+	}
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "// Code generated by aptos-go-sdk GenerateFixtures. DO NOT EDIT.\n\n")
+	fmt.Fprintf(out, "package %s\n\n", options.Package)
+	imports := make([]string, 0, len(gen.imports))
+	for name := range gen.imports {
+		imports = append(imports, name)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		iStd, jStd := !strings.Contains(imports[i], "."), !strings.Contains(imports[j], ".")
+		if iStd != jStd {
+			return iStd
+		}
+		return imports[i] < imports[j]
+	})
+	// Standard library imports first, then the SDK
+	out.WriteString("import (\n")
+	for i, name := range imports {
+		if i > 0 && strings.Contains(name, ".") && !strings.Contains(imports[i-1], ".") {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(out, "\t%q\n", name)
+	}
+	out.WriteString(")\n\n")
+	fmt.Fprintf(out, fixtureHeader, address.String())
+	out.WriteString(gen.body.String())
+
+	source, err := format.Source([]byte(out.String()))
+	if err != nil {
+		return nil, fmt.Errorf("generated fixtures don't compile: %w", err)
+	}
+	return source, nil
+}
+
+// GenerateFixtures fetches the ABIs of the modules at an address, and generates Go test scaffolding for them, see
+// [GenerateFixtures]
+func (client *Client) GenerateFixtures(address AccountAddress, options FixtureOptions) ([]byte, error) {
+	bytecodes, err := client.AccountModules(address)
+	if err != nil {
+		return nil, err
+	}
+	modules := make([]*api.MoveModule, 0, len(bytecodes))
+	for _, bytecode := range bytecodes {
+		if bytecode.Abi != nil {
+			modules = append(modules, bytecode.Abi)
+		}
+	}
+	return GenerateFixtures(address, modules, options)
+}
+
+// fixtureHeader is the code that doesn't depend on the modules, it takes the module address
+const fixtureHeader = `// Modules builds payloads for the Move modules published at Address
+type Modules struct {
+	Address aptos.AccountAddress
+}
+
+// Deployed is the modules at the address the fixtures were generated from
+var Deployed = Modules{Address: mustParseAddress(%q)}
+
+// Localnet is a publisher account on localnet, with the package published by it
+type Localnet struct {
+	Client    *aptos.Client
+	Publisher *aptos.Account
+	Modules   Modules
+}
+
+// LocalnetFundAmount is the amount of octas given to the publisher
+const LocalnetFundAmount = 100_000_000
+
+// SetupLocalnet funds the publisher on localnet and publishes the package in payloadPath, which is made by
+// aptos move build-publish-payload.  If publisher is nil, a new account is made.  The package's named address must be
+// compiled as the publisher's address.
+func SetupLocalnet(publisher *aptos.Account, payloadPath string) (*Localnet, error) {
+	client, err := aptos.NewClient(aptos.LocalnetConfig)
+	if err != nil {
+		return nil, err
+	}
+	if publisher == nil {
+		publisher, err = aptos.NewEd25519Account()
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = client.Fund(publisher.Address, LocalnetFundAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fund publisher: %%w", err)
+	}
+
+	file, err := os.Open(payloadPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	payload, err := aptos.PublishPackagePayloadFromCliJson(file)
+	if err != nil {
+		return nil, err
+	}
+	submitted, err := client.BuildSignAndSubmitTransaction(publisher, *payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish package: %%w", err)
+	}
+	txn, err := client.WaitForTransaction(submitted.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish package: %%w", err)
+	}
+	if !txn.Success {
+		return nil, fmt.Errorf("failed to publish package: %%s", txn.VmStatus)
+	}
+	return &Localnet{Client: client, Publisher: publisher, Modules: Modules{Address: publisher.Address}}, nil
+}
+
+func mustParseAddress(address string) aptos.AccountAddress {
+	out := aptos.AccountAddress{}
+	err := out.ParseStringRelaxed(address)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+`
+
+// fixtureGenerator collects the generated code for each module, and the imports it needs
+type fixtureGenerator struct {
+	address AccountAddress
+	imports map[string]bool
+	body    strings.Builder
+	current *api.MoveModule // current is the module being generated
+}
+
+// module generates the payload builders and structs for a module
+func (gen *fixtureGenerator) module(module *api.MoveModule) {
+	gen.current = module
+	prefix := fixtureIdentifier(module.Name)
+
+	for _, function := range module.ExposedFunctions {
+		if !function.IsEntry {
+			continue
+		}
+		gen.entryFunction(prefix, function)
+	}
+	for _, moveStruct := range module.Structs {
+		if moveStruct.IsNative || len(moveStruct.GenericTypeParams) > 0 {
+			continue
+		}
+		gen.moveStruct(prefix, moveStruct)
+	}
+}
+
+// entryFunction generates a typed payload builder for an entry function
+func (gen *fixtureGenerator) entryFunction(prefix string, function *api.MoveFunction) {
+	module := gen.current
+	params := make([]string, 0, len(function.Params))
+	paramTypes := make([]string, 0, len(function.Params))
+	args := make([]string, 0, len(function.Params))
+	if len(function.GenericTypeParams) > 0 {
+		params = append(params, "typeArgs []any")
+	}
+	leadingSigners := true
+	for _, param := range function.Params {
+		typeTag, err := ParseTypeTag(param)
+		if err == nil && leadingSigners && isSignerTypeTag(typeTag) {
+			continue
+		}
+		leadingSigners = false
+		name := fmt.Sprintf("arg%d", len(args))
+		goType := "any"
+		if err == nil {
+			goType = gen.argType(*typeTag)
+		}
+		params = append(params, name+" "+goType)
+		paramTypes = append(paramTypes, fmt.Sprintf("%q", param))
+		args = append(args, name)
+	}
+	typeArgs := "nil"
+	if len(function.GenericTypeParams) > 0 {
+		typeArgs = "typeArgs"
+	}
+
+	name := prefix + fixtureIdentifier(function.Name)
+	fmt.Fprintf(&gen.body, "\n// %s builds a payload for %s::%s::%s\n", name, gen.address.String(), module.Name, function.Name)
+	fmt.Fprintf(&gen.body, "func (modules Modules) %s(%s) (aptos.TransactionPayload, error) {\n", name, strings.Join(params, ", "))
+	fmt.Fprintf(&gen.body, "\tentry, err := aptos.NewEntryFunction(aptos.ModuleId{Address: modules.Address, Name: %q}, %q, %s, []string{%s}, []any{%s})\n",
+		module.Name, function.Name, typeArgs, strings.Join(paramTypes, ", "), strings.Join(args, ", "))
+	gen.body.WriteString("\tif err != nil {\n\t\treturn aptos.TransactionPayload{}, err\n\t}\n")
+	gen.body.WriteString("\treturn aptos.TransactionPayload{Payload: entry}, nil\n}\n")
+}
+
+// moveStruct generates a Go struct for the JSON of a Move struct, and a fetch method if it's a resource
+func (gen *fixtureGenerator) moveStruct(prefix string, moveStruct *api.MoveStruct) {
+	module := gen.current
+	name := prefix + fixtureIdentifier(moveStruct.Name)
+	moveType := fmt.Sprintf("%s::%s::%s", gen.address.String(), module.Name, moveStruct.Name)
+	fmt.Fprintf(&gen.body, "\n// %s is the JSON form of %s\n", name, moveType)
+	fmt.Fprintf(&gen.body, "type %s struct {\n", name)
+	for _, field := range moveStruct.Fields {
+		goType, tag := "json.RawMessage", field.Name
+		typeTag, err := ParseTypeTag(field.Type)
+		if err == nil {
+			goType, tag = gen.jsonType(*typeTag, field.Name)
+		}
+		if goType == "json.RawMessage" {
+			gen.imports["encoding/json"] = true
+		}
+		fmt.Fprintf(&gen.body, "\t%s %s `json:\"%s\"`\n", fixtureIdentifier(field.Name), goType, tag)
+	}
+	gen.body.WriteString("}\n")
+
+	for _, ability := range moveStruct.Abilities {
+		if ability != api.MoveAbilityKey {
+			continue
+		}
+		fmt.Fprintf(&gen.body, "\n// Fetch%s reads the %s resource from an account\n", name, moveType)
+		fmt.Fprintf(&gen.body, "func (modules Modules) Fetch%s(client *aptos.Client, owner aptos.AccountAddress) (*%s, error) {\n", name, name)
+		fmt.Fprintf(&gen.body, "\tout := &%s{}\n", name)
+		fmt.Fprintf(&gen.body, "\terr := client.AccountResourceInto(owner, modules.Address.String()+%q, out)\n", fmt.Sprintf("::%s::%s", module.Name, moveStruct.Name))
+		gen.body.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn out, nil\n}\n")
+	}
+}
+
+// argType is the Go type for an entry function argument, which must be accepted by [ConvertArg]
+func (gen *fixtureGenerator) argType(typeTag TypeTag) string {
+	switch inner := typeTag.Value.(type) {
+	case *BoolTag:
+		return "bool"
+	case *U8Tag:
+		return "uint8"
+	case *U16Tag:
+		return "uint16"
+	case *U32Tag:
+		return "uint32"
+	case *U64Tag:
+		return "uint64"
+	case *U128Tag, *U256Tag:
+		gen.imports["math/big"] = true
+		return "*big.Int"
+	case *AddressTag:
+		return "aptos.AccountAddress"
+	case *ReferenceTag:
+		return gen.argType(inner.TypeParam)
+	case *VectorTag:
+		switch inner.TypeParam.Value.(type) {
+		case *U8Tag:
+			return "[]byte"
+		case *BoolTag, *U16Tag, *U32Tag, *U64Tag, *AddressTag:
+			return "[]" + gen.argType(inner.TypeParam)
+		}
+	case *StructTag:
+		if inner.Address == AccountOne && inner.Module == "string" && inner.Name == "String" {
+			return "string"
+		}
+		if inner.Address == AccountOne && inner.Module == "object" && inner.Name == "Object" {
+			return "aptos.AccountAddress"
+		}
+	}
+	return "any"
+}
+
+// jsonType is the Go type and JSON tag for a struct field, in the node API's JSON format.  Anything without an exact
+// Go equivalent is kept as json.RawMessage.
+func (gen *fixtureGenerator) jsonType(typeTag TypeTag, name string) (string, string) {
+	switch inner := typeTag.Value.(type) {
+	case *BoolTag:
+		return "bool", name
+	case *U8Tag:
+		return "uint8", name
+	case *U16Tag:
+		return "uint16", name
+	case *U32Tag:
+		return "uint32", name
+	case *U64Tag:
+		// u64 is a string in JSON
+		return "uint64", name + ",string"
+	case *U128Tag:
+		gen.imports["github.com/aptos-labs/aptos-go-sdk/bcs"] = true
+		return "bcs.Uint128", name
+	case *U256Tag:
+		gen.imports["github.com/aptos-labs/aptos-go-sdk/bcs"] = true
+		return "bcs.Uint256", name
+	case *AddressTag:
+		return "aptos.AccountAddress", name
+	case *VectorTag:
+		switch inner.TypeParam.Value.(type) {
+		case *U8Tag:
+			gen.imports["github.com/aptos-labs/aptos-go-sdk/api"] = true
+			return "api.HexBytes", name
+		}
+		elemType, elemTag := gen.jsonType(inner.TypeParam, name)
+		if elemType != "json.RawMessage" && elemTag == name {
+			return "[]" + elemType, name
+		}
+	case *StructTag:
+		if inner.Address == AccountOne && inner.Module == "string" && inner.Name == "String" {
+			return "string", name
+		}
+		// Structs from the same module are generated, so can be nested
+		if inner.Address == gen.address && inner.Module == gen.current.Name && len(inner.TypeParams) == 0 {
+			for _, moveStruct := range gen.current.Structs {
+				if moveStruct.Name == inner.Name && !moveStruct.IsNative && len(moveStruct.GenericTypeParams) == 0 {
+					return fixtureIdentifier(gen.current.Name) + fixtureIdentifier(inner.Name), name
+				}
+			}
+		}
+	}
+	return "json.RawMessage", name
+}
+
+// fixtureIdentifier converts a Move snake_case name to an exported Go CamelCase identifier
+func fixtureIdentifier(name string) string {
+	out := &strings.Builder{}
+	upper := true
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upper = false
+		out.WriteRune(r)
+	}
+	if out.Len() == 0 {
+		return "X"
+	}
+	return out.String()
+}
@@ -0,0 +1,112 @@
+package aptos
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+const fixtureTestAbi = `{
+	"address": "0xcafe",
+	"name": "game_board",
+	"friends": [],
+	"exposed_functions": [
+		{"name": "place", "visibility": "public", "is_entry": true, "is_view": false, "generic_type_params": [], "params": ["&signer", "u64", "u128", "address", "vector<u8>", "0x1::string::String", "0x1::option::Option<u64>"], "return": []},
+		{"name": "deposit", "visibility": "public", "is_entry": true, "is_view": false, "generic_type_params": [{"constraints": []}], "params": ["&signer", "0x1::object::Object<T0>", "vector<address>"], "return": []},
+		{"name": "size", "visibility": "public", "is_entry": false, "is_view": true, "generic_type_params": [], "params": [], "return": ["u64"]}
+	],
+	"structs": [
+		{"name": "Board", "is_native": false, "abilities": ["key"], "generic_type_params": [], "fields": [
+			{"name": "owner", "type": "address"},
+			{"name": "moves", "type": "u64"},
+			{"name": "pot", "type": "u128"},
+			{"name": "cells", "type": "vector<u8>"},
+			{"name": "players", "type": "vector<address>"},
+			{"name": "last_piece", "type": "0xcafe::game_board::Piece"},
+			{"name": "winner", "type": "0x1::option::Option<address>"}
+		]},
+		{"name": "Piece", "is_native": false, "abilities": ["copy", "drop", "store"], "generic_type_params": [], "fields": [
+			{"name": "name", "type": "0x1::string::String"},
+			{"name": "enabled", "type": "bool"}
+		]},
+		{"name": "Holder", "is_native": false, "abilities": ["key"], "generic_type_params": [{"constraints": []}], "fields": [
+			{"name": "value", "type": "T0"}
+		]}
+	]
+}`
+
+func TestGenerateFixtures(t *testing.T) {
+	abi := &api.MoveModule{}
+	assert.NoError(t, json.Unmarshal([]byte(fixtureTestAbi), abi))
+	address := AccountAddress{}
+	assert.NoError(t, address.ParseStringRelaxed("0xcafe"))
+
+	source, err := GenerateFixtures(address, []*api.MoveModule{abi}, FixtureOptions{Package: "board"})
+	assert.NoError(t, err)
+	code := string(source)
+	assert.Contains(t, code, "package board\n")
+	assert.Contains(t, code, "func (modules Modules) GameBoardPlace(arg0 uint64, arg1 *big.Int, arg2 aptos.AccountAddress, arg3 []byte, arg4 string, arg5 any) (aptos.TransactionPayload, error)")
+	assert.Contains(t, code, "func (modules Modules) GameBoardDeposit(typeArgs []any, arg0 aptos.AccountAddress, arg1 []aptos.AccountAddress)")
+	assert.NotContains(t, code, "GameBoardSize")
+	assert.Regexp(t, "Moves +uint64 +`json:\"moves,string\"`", code)
+	assert.Regexp(t, "Pot +bcs.Uint128 +`json:\"pot\"`", code)
+	assert.Regexp(t, "Players +\\[\\]aptos.AccountAddress +`json:\"players\"`", code)
+	assert.Regexp(t, "LastPiece +GameBoardPiece +`json:\"last_piece\"`", code)
+	assert.Regexp(t, "Winner +json.RawMessage +`json:\"winner\"`", code)
+	assert.Contains(t, code, "func (modules Modules) FetchGameBoardBoard(")
+	assert.NotContains(t, code, "FetchGameBoardPiece")
+	// Generic structs aren't generated
+	assert.NotContains(t, code, "GameBoardHolder")
+	assert.Contains(t, code, `var Deployed = Modules{Address: mustParseAddress("`+address.String()+`")}`)
+
+	_, err = GenerateFixtures(address, []*api.MoveModule{abi}, FixtureOptions{Modules: []string{"missing"}})
+	assert.Error(t, err)
+	_, err = GenerateFixtures(address, nil, FixtureOptions{})
+	assert.Error(t, err)
+}
+
+func TestGenerateFixturesTypeChecks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type checks the SDK from source")
+	}
+	abi := &api.MoveModule{}
+	assert.NoError(t, json.Unmarshal([]byte(fixtureTestAbi), abi))
+	address := AccountAddress{}
+	assert.NoError(t, address.ParseStringRelaxed("0xcafe"))
+	source, err := GenerateFixtures(address, []*api.MoveModule{abi}, FixtureOptions{Package: "board"})
+	assert.NoError(t, err)
+
+	// The file is placed in this module, so the SDK imports resolve to this tree
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "board_fixtures.go", source, 0)
+	assert.NoError(t, err)
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = config.Check("board", fset, []*ast.File{file}, nil)
+	assert.NoError(t, err)
+}
+
+func TestClient_GenerateFixtures(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, "cafe/modules"))
+		_, _ = w.Write([]byte(`[{"bytecode": "0xa11ceb0b", "abi": ` + fixtureTestAbi + `}]`))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1"})
+	assert.NoError(t, err)
+	address := AccountAddress{}
+	assert.NoError(t, address.ParseStringRelaxed("0xcafe"))
+	source, err := client.GenerateFixtures(address, FixtureOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), "package fixtures\n")
+}
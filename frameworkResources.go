@@ -0,0 +1,291 @@
+package aptos
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// Resource types of the most used framework resources, for [Client.AccountResource] and [Client.AccountResourceInto]
+const (
+	AccountResourceType               = "0x1::account::Account"                            // AccountResourceType is decoded by [AccountResource]
+	AptosCoinStoreResourceType        = "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>" // AptosCoinStoreResourceType is decoded by [CoinStoreResource]
+	ObjectCoreResourceType            = "0x1::object::ObjectCore"                          // ObjectCoreResourceType is decoded by [ObjectCoreResource]
+	StakePoolResourceType             = "0x1::stake::StakePool"                            // StakePoolResourceType is decoded by [StakePoolResource]
+	FungibleAssetMetadataResourceType = "0x1::fungible_asset::Metadata"                    // FungibleAssetMetadataResourceType is decoded by [FungibleAssetMetadataResource]
+	FungibleStoreResourceType         = "0x1::fungible_asset::FungibleStore"               // FungibleStoreResourceType is decoded by [FungibleStoreResource]
+)
+
+// Event handle fields of [CoinStoreResource], for [Client.EventsByHandle]
+//
+//	events, err := client.EventsByHandle(address, AptosCoinStoreResourceType, CoinDepositEventsField, nil, nil)
+const (
+	CoinDepositEventsField  = "deposit_events"  // CoinDepositEventsField emits 0x1::coin::DepositEvent
+	CoinWithdrawEventsField = "withdraw_events" // CoinWithdrawEventsField emits 0x1::coin::WithdrawEvent
+)
+
+// CoinStoreResourceType is the resource type of the [CoinStoreResource] for a coin type e.g. 0x1::aptos_coin::AptosCoin
+func CoinStoreResourceType(coinType string) string {
+	return coinStorePrefix + coinType + ">"
+}
+
+// AccountResourceInto fetches a resource and decodes its data into out, e.g. one of the framework resource structs
+//
+//	store := &CoinStoreResource{}
+//	err := client.AccountResourceInto(address, AptosCoinStoreResourceType, store)
+func (rc *NodeClient) AccountResourceInto(address AccountAddress, resourceType string, out any, ledgerVersion ...uint64) (err error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "resource", resourceType)
+	rc.setLedgerVersion(au, ledgerVersion)
+	type resource struct {
+		Data json.RawMessage `json:"data"`
+	}
+	data, err := Get[resource](rc, au.String())
+	if err != nil {
+		return fmt.Errorf("get resource api err: %w", err)
+	}
+	err = json.Unmarshal(data.Data, out)
+	if err != nil {
+		return fmt.Errorf("failed to decode resource %s: %w", resourceType, err)
+	}
+	return nil
+}
+
+// EventHandle is a 0x1::event::EventHandle, the legacy handle events are emitted on
+type EventHandle struct {
+	Counter        uint64         // Counter is the number of events emitted on the handle
+	Address        AccountAddress // Address is the account that created the handle
+	CreationNumber uint64         // CreationNumber is the creation number of the handle's GUID
+}
+
+// UnmarshalJSON deserializes a JSON data blob into an [EventHandle]
+func (o *EventHandle) UnmarshalJSON(b []byte) error {
+	type inner struct {
+		Counter api.U64 `json:"counter"`
+		Guid    struct {
+			Id struct {
+				Addr        AccountAddress `json:"addr"`
+				CreationNum api.U64        `json:"creation_num"`
+			} `json:"id"`
+		} `json:"guid"`
+	}
+	data := &inner{}
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return err
+	}
+	o.Counter = data.Counter.ToUint64()
+	o.Address = data.Guid.Id.Addr
+	o.CreationNumber = data.Guid.Id.CreationNum.ToUint64()
+	return nil
+}
+
+// UnmarshalBCS deserializes the Move struct BCS of an [EventHandle]
+func (o *EventHandle) UnmarshalBCS(des *bcs.Deserializer) {
+	o.Counter = des.U64()
+	o.CreationNumber = des.U64()
+	o.Address.UnmarshalBCS(des)
+}
+
+// optionalAddress is the JSON representation of an 0x1::option::Option<address>
+type optionalAddress struct {
+	Vec []AccountAddress `json:"vec"`
+}
+
+func (o optionalAddress) toPointer() *AccountAddress {
+	if len(o.Vec) == 0 {
+		return nil
+	}
+	return &o.Vec[0]
+}
+
+// objectRef is the JSON representation of an 0x1::object::Object<T>
+type objectRef struct {
+	Inner AccountAddress `json:"inner"`
+}
+
+// coinValue is the JSON representation of an 0x1::coin::Coin<T>
+type coinValue struct {
+	Value api.U64 `json:"value"`
+}
+
+// AccountResource is the 0x1::account::Account resource, see [AccountResourceType]
+type AccountResource struct {
+	AuthenticationKey       []byte          // AuthenticationKey is the current authentication key of the account
+	SequenceNumber          uint64          // SequenceNumber is the next sequence number of the account
+	GuidCreationNum         uint64          // GuidCreationNum is the next GUID creation number
+	CoinRegisterEvents      EventHandle     // CoinRegisterEvents is the legacy handle for coin registration
+	KeyRotationEvents       EventHandle     // KeyRotationEvents is the legacy handle for key rotation
+	RotationCapabilityOffer *AccountAddress // RotationCapabilityOffer is the account offered the rotation capability, if any
+	SignerCapabilityOffer   *AccountAddress // SignerCapabilityOffer is the account offered the signer capability, if any
+}
+
+// UnmarshalJSON deserializes a JSON data blob into an [AccountResource]
+func (o *AccountResource) UnmarshalJSON(b []byte) error {
+	type inner struct {
+		AuthenticationKey       api.HexBytes `json:"authentication_key"`
+		SequenceNumber          api.U64      `json:"sequence_number"`
+		GuidCreationNum         api.U64      `json:"guid_creation_num"`
+		CoinRegisterEvents      EventHandle  `json:"coin_register_events"`
+		KeyRotationEvents       EventHandle  `json:"key_rotation_events"`
+		RotationCapabilityOffer struct {
+			For optionalAddress `json:"for"`
+		} `json:"rotation_capability_offer"`
+		SignerCapabilityOffer struct {
+			For optionalAddress `json:"for"`
+		} `json:"signer_capability_offer"`
+	}
+	data := &inner{}
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return err
+	}
+	o.AuthenticationKey = data.AuthenticationKey
+	o.SequenceNumber = data.SequenceNumber.ToUint64()
+	o.GuidCreationNum = data.GuidCreationNum.ToUint64()
+	o.CoinRegisterEvents = data.CoinRegisterEvents
+	o.KeyRotationEvents = data.KeyRotationEvents
+	o.RotationCapabilityOffer = data.RotationCapabilityOffer.For.toPointer()
+	o.SignerCapabilityOffer = data.SignerCapabilityOffer.For.toPointer()
+	return nil
+}
+
+// UnmarshalBCS deserializes the Move struct BCS of an [AccountResource]
+func (o *AccountResource) UnmarshalBCS(des *bcs.Deserializer) {
+	o.AuthenticationKey = des.ReadBytes()
+	o.SequenceNumber = des.U64()
+	o.GuidCreationNum = des.U64()
+	o.CoinRegisterEvents.UnmarshalBCS(des)
+	o.KeyRotationEvents.UnmarshalBCS(des)
+	// Each capability offer is a struct of an Option<address>, the same as a BCS option
+	o.RotationCapabilityOffer = bcs.DeserializeStructOption[AccountAddress](des)
+	o.SignerCapabilityOffer = bcs.DeserializeStructOption[AccountAddress](des)
+}
+
+// CoinStoreResource is the 0x1::coin::CoinStore<T> resource, see [CoinStoreResourceType]
+type CoinStoreResource struct {
+	Value          uint64      // Value is the balance of the coin store
+	Frozen         bool        // Frozen is true if the coin store can't be deposited to or withdrawn from
+	DepositEvents  EventHandle // DepositEvents is the legacy handle for deposits
+	WithdrawEvents EventHandle // WithdrawEvents is the legacy handle for withdrawals
+}
+
+// UnmarshalJSON deserializes a JSON data blob into a [CoinStoreResource]
+func (o *CoinStoreResource) UnmarshalJSON(b []byte) error {
+	type inner struct {
+		Coin           coinValue   `json:"coin"`
+		Frozen         bool        `json:"frozen"`
+		DepositEvents  EventHandle `json:"deposit_events"`
+		WithdrawEvents EventHandle `json:"withdraw_events"`
+	}
+	data := &inner{}
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return err
+	}
+	o.Value = data.Coin.Value.ToUint64()
+	o.Frozen = data.Frozen
+	o.DepositEvents = data.DepositEvents
+	o.WithdrawEvents = data.WithdrawEvents
+	return nil
+}
+
+// ObjectCoreResource is the 0x1::object::ObjectCore resource, see [ObjectCoreResourceType]
+type ObjectCoreResource struct {
+	Owner                AccountAddress // Owner is the current owner of the object
+	AllowUngatedTransfer bool           // AllowUngatedTransfer is true if the owner can transfer the object freely
+	GuidCreationNum      uint64         // GuidCreationNum is the next GUID creation number
+	TransferEvents       EventHandle    // TransferEvents is the legacy handle for transfers
+}
+
+// UnmarshalJSON deserializes a JSON data blob into an [ObjectCoreResource]
+func (o *ObjectCoreResource) UnmarshalJSON(b []byte) error {
+	type inner struct {
+		Owner                AccountAddress `json:"owner"`
+		AllowUngatedTransfer bool           `json:"allow_ungated_transfer"`
+		GuidCreationNum      api.U64        `json:"guid_creation_num"`
+		TransferEvents       EventHandle    `json:"transfer_events"`
+	}
+	data := &inner{}
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return err
+	}
+	o.Owner = data.Owner
+	o.AllowUngatedTransfer = data.AllowUngatedTransfer
+	o.GuidCreationNum = data.GuidCreationNum.ToUint64()
+	o.TransferEvents = data.TransferEvents
+	return nil
+}
+
+// StakePoolResource is the 0x1::stake::StakePool resource, see [StakePoolResourceType].  Amounts are in octas.
+type StakePoolResource struct {
+	Active          uint64         // Active is the stake earning rewards and counting for voting power
+	Inactive        uint64         // Inactive is the stake that can be withdrawn
+	PendingActive   uint64         // PendingActive is the stake that becomes active next epoch
+	PendingInactive uint64         // PendingInactive is the stake that becomes inactive when the lockup expires
+	LockedUntilSecs uint64         // LockedUntilSecs is the end of the lockup in seconds since the Unix epoch
+	OperatorAddress AccountAddress // OperatorAddress is the operator of the validator
+	DelegatedVoter  AccountAddress // DelegatedVoter votes in governance with the pool's stake
+}
+
+// UnmarshalJSON deserializes a JSON data blob into a [StakePoolResource]
+func (o *StakePoolResource) UnmarshalJSON(b []byte) error {
+	type inner struct {
+		Active          coinValue      `json:"active"`
+		Inactive        coinValue      `json:"inactive"`
+		PendingActive   coinValue      `json:"pending_active"`
+		PendingInactive coinValue      `json:"pending_inactive"`
+		LockedUntilSecs api.U64        `json:"locked_until_secs"`
+		OperatorAddress AccountAddress `json:"operator_address"`
+		DelegatedVoter  AccountAddress `json:"delegated_voter"`
+	}
+	data := &inner{}
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return err
+	}
+	o.Active = data.Active.Value.ToUint64()
+	o.Inactive = data.Inactive.Value.ToUint64()
+	o.PendingActive = data.PendingActive.Value.ToUint64()
+	o.PendingInactive = data.PendingInactive.Value.ToUint64()
+	o.LockedUntilSecs = data.LockedUntilSecs.ToUint64()
+	o.OperatorAddress = data.OperatorAddress
+	o.DelegatedVoter = data.DelegatedVoter
+	return nil
+}
+
+// FungibleAssetMetadataResource is the 0x1::fungible_asset::Metadata resource, see [FungibleAssetMetadataResourceType]
+type FungibleAssetMetadataResource struct {
+	Name       string `json:"name"`        // Name of the fungible asset e.g. Aptos Coin
+	Symbol     string `json:"symbol"`      // Symbol of the fungible asset e.g. APT
+	Decimals   uint8  `json:"decimals"`    // Decimals is the number of decimal places of the displayed amount
+	IconUri    string `json:"icon_uri"`    // IconUri is the icon of the fungible asset
+	ProjectUri string `json:"project_uri"` // ProjectUri is the website of the fungible asset
+}
+
+// FungibleStoreResource is the 0x1::fungible_asset::FungibleStore resource, see [FungibleStoreResourceType]
+type FungibleStoreResource struct {
+	Metadata AccountAddress // Metadata is the address of the fungible asset metadata object
+	Balance  uint64         // Balance of the store
+	Frozen   bool           // Frozen is true if the owner can't deposit to or withdraw from the store
+}
+
+// UnmarshalJSON deserializes a JSON data blob into a [FungibleStoreResource]
+func (o *FungibleStoreResource) UnmarshalJSON(b []byte) error {
+	type inner struct {
+		Metadata objectRef `json:"metadata"`
+		Balance  api.U64   `json:"balance"`
+		Frozen   bool      `json:"frozen"`
+	}
+	data := &inner{}
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return err
+	}
+	o.Metadata = data.Metadata.Inner
+	o.Balance = data.Balance.ToUint64()
+	o.Frozen = data.Frozen
+	return nil
+}
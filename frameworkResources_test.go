@@ -0,0 +1,92 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testEventHandleJson = `{"counter": "3", "guid": {"id": {"addr": "0xa", "creation_num": "2"}}}`
+
+func TestFrameworkResources(t *testing.T) {
+	alice := AccountAddress{}
+	assert.NoError(t, alice.ParseStringRelaxed("0xa"))
+
+	account := &AccountResource{}
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"authentication_key": "0x000000000000000000000000000000000000000000000000000000000000000a",
+		"sequence_number": "12",
+		"guid_creation_num": "4",
+		"coin_register_events": `+testEventHandleJson+`,
+		"key_rotation_events": `+testEventHandleJson+`,
+		"rotation_capability_offer": {"for": {"vec": []}},
+		"signer_capability_offer": {"for": {"vec": ["0xb"]}}
+	}`), account))
+	assert.Equal(t, alice[:], account.AuthenticationKey)
+	assert.Equal(t, uint64(12), account.SequenceNumber)
+	assert.Equal(t, EventHandle{Counter: 3, Address: alice, CreationNumber: 2}, account.KeyRotationEvents)
+	assert.Nil(t, account.RotationCapabilityOffer)
+	assert.Equal(t, "0xb", account.SignerCapabilityOffer.String())
+
+	store := &CoinStoreResource{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"coin": {"value": "100"}, "frozen": true, "deposit_events": `+testEventHandleJson+`, "withdraw_events": `+testEventHandleJson+`}`), store))
+	assert.Equal(t, uint64(100), store.Value)
+	assert.True(t, store.Frozen)
+	assert.Equal(t, uint64(3), store.DepositEvents.Counter)
+
+	object := &ObjectCoreResource{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"owner": "0xa", "allow_ungated_transfer": true, "guid_creation_num": "1125899906842625", "transfer_events": `+testEventHandleJson+`}`), object))
+	assert.Equal(t, alice, object.Owner)
+	assert.True(t, object.AllowUngatedTransfer)
+	assert.Equal(t, uint64(1125899906842625), object.GuidCreationNum)
+
+	pool := &StakePoolResource{}
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"active": {"value": "1000"},
+		"inactive": {"value": "1"},
+		"pending_active": {"value": "2"},
+		"pending_inactive": {"value": "3"},
+		"locked_until_secs": "1700000000",
+		"operator_address": "0xa",
+		"delegated_voter": "0xb"
+	}`), pool))
+	assert.Equal(t, uint64(1000), pool.Active)
+	assert.Equal(t, uint64(3), pool.PendingInactive)
+	assert.Equal(t, uint64(1700000000), pool.LockedUntilSecs)
+	assert.Equal(t, alice, pool.OperatorAddress)
+
+	metadata := &FungibleAssetMetadataResource{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"name": "Aptos Coin", "symbol": "APT", "decimals": 8, "icon_uri": "", "project_uri": ""}`), metadata))
+	assert.Equal(t, FungibleAssetMetadataResource{Name: "Aptos Coin", Symbol: "APT", Decimals: 8}, *metadata)
+
+	fungibleStore := &FungibleStoreResource{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"metadata": {"inner": "0xa"}, "balance": "55", "frozen": false}`), fungibleStore))
+	assert.Equal(t, FungibleStoreResource{Metadata: alice, Balance: 55}, *fungibleStore)
+
+	assert.Equal(t, AptosCoinStoreResourceType, CoinStoreResourceType(AptosCoinTypeTag.String()))
+}
+
+func TestClient_AccountResourceInto(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/accounts/0x1/resource/"+AptosCoinStoreResourceType && r.URL.Query().Get("ledger_version") == "5" {
+			_, _ = w.Write([]byte(`{"type": "` + AptosCoinStoreResourceType + `", "data": {"coin": {"value": "42"}, "frozen": false, "deposit_events": ` + testEventHandleJson + `, "withdraw_events": ` + testEventHandleJson + `}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "not found", "error_code": "resource_not_found"}`))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	store := &CoinStoreResource{}
+	assert.NoError(t, client.AccountResourceInto(AccountOne, AptosCoinStoreResourceType, store, 5))
+	assert.Equal(t, uint64(42), store.Value)
+
+	err = client.AccountResourceInto(AccountOne, AccountResourceType, &AccountResource{})
+	assert.ErrorContains(t, err, "get resource api err")
+}
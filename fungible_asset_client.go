@@ -15,7 +15,7 @@ type FungibleAssetClient struct {
 // NewFungibleAssetClient verifies the [AccountAddress] of the metadata exists when creating the client
 func NewFungibleAssetClient(client *Client, metadataAddress *AccountAddress) (faClient *FungibleAssetClient, err error) {
 	// Retrieve the Metadata resource to ensure the fungible asset actually exists
-	_, err = client.AccountResource(*metadataAddress, "0x1::fungible_asset::Metadata")
+	_, err = client.AccountResource(*metadataAddress, FungibleAssetMetadataResourceType)
 	if err != nil {
 		return
 	}
@@ -27,6 +27,11 @@ func NewFungibleAssetClient(client *Client, metadataAddress *AccountAddress) (fa
 	return
 }
 
+// Asset returns the [Asset] for the fungible asset
+func (client *FungibleAssetClient) Asset() Asset {
+	return FungibleAsset(*client.metadataAddress)
+}
+
 // -- Entry functions -- //
 
 // Transfer sends amount of the fungible asset from senderStore to receiverStore
@@ -99,6 +104,11 @@ func (client *FungibleAssetClient) PrimaryBalance(owner *AccountAddress, ledgerV
 	return StrToUint64(balanceStr)
 }
 
+// PrimaryBalanceAmount returns the balance of the primary store for the owner as an [Amount] with the asset's decimals
+func (client *FungibleAssetClient) PrimaryBalanceAmount(owner *AccountAddress, ledgerVersion ...uint64) (amount Amount, err error) {
+	return client.aptosClient.Balance(*owner, client.Asset(), ledgerVersion...)
+}
+
 // PrimaryIsFrozen returns true if the primary store for the owner is frozen
 func (client *FungibleAssetClient) PrimaryIsFrozen(owner *AccountAddress, ledgerVersion ...uint64) (isFrozen bool, err error) {
 	val, err := client.viewPrimaryStore([][]byte{owner[:], client.metadataAddress[:]}, "is_frozen", ledgerVersion...)
@@ -208,7 +218,7 @@ func (client *FungibleAssetClient) Decimals() (decimals uint8, err error) {
 }
 
 // IconUri returns the URI of the icon for the fungible asset
-func (client *FungibleAssetClient) IconUri(uri string, err error) {
+func (client *FungibleAssetClient) IconUri() (uri string, err error) {
 	val, err := client.viewMetadata([][]byte{client.metadataAddress[:]}, "icon_uri")
 	if err != nil {
 		return
@@ -218,7 +228,7 @@ func (client *FungibleAssetClient) IconUri(uri string, err error) {
 }
 
 // ProjectUri returns the URI of the project for the fungible asset
-func (client *FungibleAssetClient) ProjectUri(uri string, err error) {
+func (client *FungibleAssetClient) ProjectUri() (uri string, err error) {
 	val, err := client.viewMetadata([][]byte{client.metadataAddress[:]}, "project_uri")
 	if err != nil {
 		return
@@ -227,6 +237,28 @@ func (client *FungibleAssetClient) ProjectUri(uri string, err error) {
 	return
 }
 
+// FungibleAssetMetadata is the metadata and supply of a fungible asset, see [FungibleAssetClient.Metadata]
+type FungibleAssetMetadata struct {
+	FungibleAssetMetadataResource
+	Supply  *big.Int // Supply is the current supply, nil if the supply isn't tracked
+	Maximum *big.Int // Maximum is the maximum supply, nil if the supply is unlimited
+}
+
+// Metadata returns the name, symbol, decimals, URIs, and supply of the fungible asset in one call, reading the
+// metadata resource instead of a view function per field
+func (client *FungibleAssetClient) Metadata(ledgerVersion ...uint64) (metadata FungibleAssetMetadata, err error) {
+	err = client.aptosClient.AccountResourceInto(*client.metadataAddress, FungibleAssetMetadataResourceType, &metadata.FungibleAssetMetadataResource, ledgerVersion...)
+	if err != nil {
+		return
+	}
+	metadata.Supply, err = client.Supply(ledgerVersion...)
+	if err != nil {
+		return
+	}
+	metadata.Maximum, err = client.Maximum(ledgerVersion...)
+	return
+}
+
 // viewMetadata calls a view function on the fungible asset metadata
 func (client *FungibleAssetClient) viewMetadata(args [][]byte, functionName string, ledgerVersion ...uint64) (result any, err error) {
 	payload := &ViewPayload{
@@ -1,5 +1,16 @@
 package aptos
 
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
 /* TODO: Re-enable when running on localnet
 func TestClient(t *testing.T) {
 	if testing.Short() {
@@ -83,3 +94,48 @@ func TestClient(t *testing.T) {
 	assert.False(t, isFrozen
 
 }*/
+
+func TestFungibleAssetClient_Metadata(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/0x2/resource/" + FungibleAssetMetadataResourceType:
+			_, _ = w.Write([]byte(`{"type": "` + FungibleAssetMetadataResourceType + `", "data": {"name": "Test Coin", "symbol": "TST", "decimals": 6, "icon_uri": "", "project_uri": "https://example.com"}}`))
+		case "/view":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			// The function name is in the BCS body
+			switch {
+			case bytes.Contains(body, []byte("supply")):
+				_ = json.NewEncoder(w).Encode([]any{map[string]any{"vec": []any{"1000000"}}})
+			case bytes.Contains(body, []byte("maximum")):
+				_ = json.NewEncoder(w).Encode([]any{map[string]any{"vec": []any{}}})
+			default:
+				_ = json.NewEncoder(w).Encode([]any{"https://example.com"})
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found", "error_code": "resource_not_found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL})
+	assert.NoError(t, err)
+	faClient, err := NewFungibleAssetClient(client, &AccountTwo)
+	assert.NoError(t, err)
+
+	metadata, err := faClient.Metadata()
+	assert.NoError(t, err)
+	assert.Equal(t, "Test Coin", metadata.Name)
+	assert.Equal(t, "TST", metadata.Symbol)
+	assert.Equal(t, uint8(6), metadata.Decimals)
+	assert.Equal(t, "1000000", metadata.Supply.String())
+	assert.Nil(t, metadata.Maximum)
+
+	uri, err := faClient.ProjectUri()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", uri)
+
+	_, err = NewFungibleAssetClient(client, &AccountThree)
+	assert.Error(t, err)
+}
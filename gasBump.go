@@ -0,0 +1,349 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+const (
+	DefaultGasBumpMultiplier            = 1.5              // DefaultGasBumpMultiplier is how much a [StuckTransactionWatcher] raises the gas unit price each time
+	DefaultStuckTransactionAge          = 30 * time.Second // DefaultStuckTransactionAge is how long a [StuckTransactionWatcher] waits for a transaction to commit before replacing it
+	DefaultStuckTransactionPollInterval = time.Second      // DefaultStuckTransactionPollInterval is how often a [StuckTransactionWatcher] checks its transactions
+)
+
+// BumpGasUnitPrice copies the raw transaction of a single signer transaction with the gas unit price multiplied, and
+// at least one more, so it can replace the transaction in mempool at the same sequence number.  The price is capped at
+// maxGasUnitPrice, if it isn't 0, and it errors if the price can't be raised.
+func BumpGasUnitPrice(signedTxn *SignedTransaction, multiplier float64, maxGasUnitPrice uint64) (*RawTransaction, error) {
+	if signedTxn == nil || signedTxn.Transaction == nil || signedTxn.Authenticator == nil {
+		return nil, errors.New("signed transaction is missing its transaction or authenticator")
+	}
+	switch signedTxn.Authenticator.Variant {
+	case TransactionAuthenticatorMultiAgent, TransactionAuthenticatorFeePayer:
+		return nil, errors.New("only single signer transactions can be resubmitted with higher gas")
+	}
+	if multiplier < 1 || math.IsNaN(multiplier) || math.IsInf(multiplier, 0) {
+		return nil, fmt.Errorf("gas bump multiplier must be at least 1, got %v", multiplier)
+	}
+
+	rawTxn := *signedTxn.Transaction
+	bumped := math.Ceil(float64(rawTxn.GasUnitPrice) * multiplier)
+	gasUnitPrice := uint64(math.MaxUint64)
+	if bumped < math.MaxUint64 {
+		gasUnitPrice = max(uint64(bumped), rawTxn.GasUnitPrice+1)
+	}
+	if maxGasUnitPrice != 0 {
+		gasUnitPrice = min(gasUnitPrice, maxGasUnitPrice)
+	}
+	if gasUnitPrice <= rawTxn.GasUnitPrice {
+		return nil, fmt.Errorf("gas unit price %d can't be raised above the maximum %d", rawTxn.GasUnitPrice, maxGasUnitPrice)
+	}
+	rawTxn.GasUnitPrice = gasUnitPrice
+	return &rawTxn, nil
+}
+
+// GasBumpClient is the subset of [Client] used to replace stuck transactions
+type GasBumpClient interface {
+	SubmitTransaction(signedTransaction *SignedTransaction) (data *api.SubmitTransactionResponse, err error)
+	TransactionByHash(txnHash string) (data *api.Transaction, err error)
+}
+
+// ResubmitWithHigherGas re-signs a single signer transaction at the same sequence number with its gas unit price
+// multiplied, see [BumpGasUnitPrice], and submits it.  Mempool replaces the original with it, so only one of them can
+// commit.
+//
+//	replacement, response, err := client.ResubmitWithHigherGas(sender, signedTxn, aptos.DefaultGasBumpMultiplier)
+func (client *Client) ResubmitWithHigherGas(sender TransactionSigner, signedTxn *SignedTransaction, multiplier float64) (*SignedTransaction, *api.SubmitTransactionResponse, error) {
+	return resubmitWithHigherGas(client, sender, signedTxn, multiplier, 0)
+}
+
+func resubmitWithHigherGas(client GasBumpClient, sender TransactionSigner, signedTxn *SignedTransaction, multiplier float64, maxGasUnitPrice uint64) (*SignedTransaction, *api.SubmitTransactionResponse, error) {
+	rawTxn, err := BumpGasUnitPrice(signedTxn, multiplier, maxGasUnitPrice)
+	if err != nil {
+		return nil, nil, err
+	}
+	if address := sender.AccountAddress(); address != rawTxn.Sender {
+		return nil, nil, fmt.Errorf("signer %s is not the sender %s", address.String(), rawTxn.Sender.String())
+	}
+	replacement, err := rawTxn.SignedTransaction(sender)
+	if err != nil {
+		return nil, nil, err
+	}
+	response, err := client.SubmitTransaction(replacement)
+	if err != nil {
+		return nil, nil, err
+	}
+	return replacement, response, nil
+}
+
+// StuckTransactionEventType is the kind of [StuckTransactionEvent]
+type StuckTransactionEventType uint8
+
+const (
+	StuckTransactionReplaced      StuckTransactionEventType = iota // StuckTransactionReplaced is emitted when a transaction is resubmitted with higher gas
+	StuckTransactionCommitted                                      // StuckTransactionCommitted is emitted when the transaction, or one of its replacements, commits
+	StuckTransactionExpired                                        // StuckTransactionExpired is emitted when the transaction expires without committing
+	StuckTransactionReplaceFailed                                  // StuckTransactionReplaceFailed is emitted when a replacement can't be submitted, it is tried again after the next poll
+)
+
+// String returns a readable name for the event type
+func (t StuckTransactionEventType) String() string {
+	switch t {
+	case StuckTransactionReplaced:
+		return "replaced"
+	case StuckTransactionCommitted:
+		return "committed"
+	case StuckTransactionExpired:
+		return "expired"
+	case StuckTransactionReplaceFailed:
+		return "replace_failed"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// StuckTransactionEvent reports progress of a transaction watched by a [StuckTransactionWatcher]
+type StuckTransactionEvent struct {
+	Type           StuckTransactionEventType // Type of the event
+	Sender         AccountAddress            // Sender of the transaction
+	SequenceNumber uint64                    // SequenceNumber of the transaction and its replacements
+	Hash           string                    // Hash is the replacement for [StuckTransactionReplaced], the one committed for [StuckTransactionCommitted], and the latest otherwise
+	GasUnitPrice   uint64                    // GasUnitPrice of the transaction with Hash
+	Transaction    *api.UserTransaction      // Transaction is the committed transaction, only for [StuckTransactionCommitted]
+	Err            error                     // Err is the reason for a [StuckTransactionReplaceFailed]
+}
+
+// StuckTransactionWatcher watches submitted transactions, and replaces those that haven't committed within MaxAge with
+// the same transaction at a higher gas unit price, until one commits or the transaction expires.
+//
+//	watcher := aptos.NewStuckTransactionWatcher(client).Handler(func(event aptos.StuckTransactionEvent) {
+//		// record replacements and outcomes
+//	})
+//	watcher.MaxGasUnitPrice = 10_000
+//	err := watcher.Start(ctx)
+//	response, err := client.SubmitTransaction(signedTxn)
+//	err = watcher.Watch(sender, signedTxn)
+//
+// Only single signer transactions can be watched, as the replacement is re-signed by the sender alone.
+type StuckTransactionWatcher struct {
+	MaxAge          time.Duration // MaxAge is how long a transaction may be pending before it is replaced, defaults to [DefaultStuckTransactionAge]
+	Multiplier      float64       // Multiplier raises the gas unit price of each replacement, defaults to [DefaultGasBumpMultiplier]
+	MaxGasUnitPrice uint64        // MaxGasUnitPrice caps the gas unit price of replacements, 0 for no cap
+	PollInterval    time.Duration // PollInterval is how often transactions are checked, defaults to [DefaultStuckTransactionPollInterval]
+
+	client  GasBumpClient
+	mutex   sync.Mutex
+	watched []*watchedTransaction
+	handler func(event StuckTransactionEvent)
+
+	life    lifecycle
+	cancel  context.CancelFunc
+	closing bool // closing is set by Close, so the cancellation isn't reported as an error
+}
+
+// watchedTransaction is a transaction and its replacements
+type watchedTransaction struct {
+	sender      TransactionSigner
+	latest      *SignedTransaction
+	hashes      []string
+	submittedAt time.Time
+}
+
+// NewStuckTransactionWatcher creates a [StuckTransactionWatcher] with the defaults
+func NewStuckTransactionWatcher(client GasBumpClient) *StuckTransactionWatcher {
+	return &StuckTransactionWatcher{
+		MaxAge:       DefaultStuckTransactionAge,
+		Multiplier:   DefaultGasBumpMultiplier,
+		PollInterval: DefaultStuckTransactionPollInterval,
+		client:       client,
+	}
+}
+
+// Watch watches a transaction that was just submitted, it can be called while the watcher is running
+func (w *StuckTransactionWatcher) Watch(sender TransactionSigner, signedTxn *SignedTransaction) error {
+	// Check up front that the transaction can be replaced
+	_, err := BumpGasUnitPrice(signedTxn, max(w.Multiplier, 1), 0)
+	if err != nil {
+		return err
+	}
+	hash, err := signedTxn.Hash()
+	if err != nil {
+		return err
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.watched = append(w.watched, &watchedTransaction{sender: sender, latest: signedTxn, hashes: []string{hash}, submittedAt: time.Now()})
+	return nil
+}
+
+// Pending is the number of transactions being watched
+func (w *StuckTransactionWatcher) Pending() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return len(w.watched)
+}
+
+// Handler is called for each event by [StuckTransactionWatcher.Start]
+func (w *StuckTransactionWatcher) Handler(handler func(event StuckTransactionEvent)) *StuckTransactionWatcher {
+	w.handler = handler
+	return w
+}
+
+// Start checks the watched transactions in the background, see [StuckTransactionWatcher.Run]
+//
+// Implements:
+//   - [Lifecycle]
+func (w *StuckTransactionWatcher) Start(ctx context.Context) error {
+	return w.life.start(func() {
+		ctx, w.cancel = context.WithCancel(ctx)
+		go func() {
+			err := w.Run(ctx, w.handler)
+			w.life.mutex.RLock()
+			if w.closing && errors.Is(err, context.Canceled) {
+				err = nil
+			}
+			w.life.mutex.RUnlock()
+			w.life.finish(err)
+		}()
+	})
+}
+
+// Close stops the watcher once the check in progress is done.  Transactions still being watched are left as they are.
+//
+// Implements:
+//   - [Lifecycle]
+func (w *StuckTransactionWatcher) Close() error {
+	w.life.close(func() {
+		w.closing = true
+		w.cancel()
+	})
+	return w.life.wait()
+}
+
+// Done is closed once the watcher has stopped
+//
+// Implements:
+//   - [Lifecycle]
+func (w *StuckTransactionWatcher) Done() <-chan struct{} {
+	return w.life.doneChan()
+}
+
+// Run checks the watched transactions every poll interval until ctx is done, and calls handler for each event.  It
+// returns ctx.Err().
+func (w *StuckTransactionWatcher) Run(ctx context.Context, handler func(event StuckTransactionEvent)) error {
+	pollInterval := w.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultStuckTransactionPollInterval
+	}
+	for {
+		w.Poll(handler)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Poll checks each watched transaction once, replacing those older than MaxAge, and calls handler for each event
+func (w *StuckTransactionWatcher) Poll(handler func(event StuckTransactionEvent)) {
+	w.mutex.Lock()
+	watched := w.watched
+	w.mutex.Unlock()
+
+	done := make(map[*watchedTransaction]bool)
+	for _, txn := range watched {
+		event, finished := w.check(txn)
+		if event != nil && handler != nil {
+			handler(*event)
+		}
+		if finished {
+			done[txn] = true
+		}
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	remaining := w.watched[:0]
+	for _, txn := range w.watched {
+		if !done[txn] {
+			remaining = append(remaining, txn)
+		}
+	}
+	clear(w.watched[len(remaining):])
+	w.watched = remaining
+}
+
+// check looks up each hash of the transaction, and replaces it if it is stuck, returning true once it is finished
+func (w *StuckTransactionWatcher) check(txn *watchedTransaction) (*StuckTransactionEvent, bool) {
+	rawTxn := txn.latest.Transaction
+	event := &StuckTransactionEvent{
+		Sender:         rawTxn.Sender,
+		SequenceNumber: rawTxn.SequenceNumber,
+		Hash:           txn.hashes[len(txn.hashes)-1],
+		GasUnitPrice:   rawTxn.GasUnitPrice,
+	}
+
+	// Any of the transactions may commit, as a node may not have seen a replacement yet
+	for _, hash := range txn.hashes {
+		data, err := w.client.TransactionByHash(hash)
+		if err != nil {
+			// Not found, or the node is unavailable, either way it may still commit
+			continue
+		}
+		if userTxn, err := data.UserTransaction(); err == nil {
+			event.Type = StuckTransactionCommitted
+			event.Hash = userTxn.Hash
+			event.GasUnitPrice = userTxn.GasUnitPrice
+			event.Transaction = userTxn
+			return event, true
+		}
+	}
+
+	now := time.Now()
+	if uint64(now.Unix()) > rawTxn.ExpirationTimestampSeconds {
+		event.Type = StuckTransactionExpired
+		return event, true
+	}
+	maxAge := w.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultStuckTransactionAge
+	}
+	if now.Sub(txn.submittedAt) < maxAge {
+		return nil, false
+	}
+	// At the maximum price, there's nothing more to do than wait
+	if w.MaxGasUnitPrice != 0 && rawTxn.GasUnitPrice >= w.MaxGasUnitPrice {
+		return nil, false
+	}
+
+	multiplier := w.Multiplier
+	if multiplier == 0 {
+		multiplier = DefaultGasBumpMultiplier
+	}
+	replacement, _, err := resubmitWithHigherGas(w.client, txn.sender, txn.latest, multiplier, w.MaxGasUnitPrice)
+	if err != nil {
+		event.Type = StuckTransactionReplaceFailed
+		event.Err = err
+		return event, false
+	}
+	hash, err := replacement.Hash()
+	if err != nil {
+		event.Type = StuckTransactionReplaceFailed
+		event.Err = err
+		return event, false
+	}
+	txn.latest = replacement
+	txn.hashes = append(txn.hashes, hash)
+	txn.submittedAt = now
+	event.Type = StuckTransactionReplaced
+	event.Hash = hash
+	event.GasUnitPrice = replacement.Transaction.GasUnitPrice
+	return event, false
+}
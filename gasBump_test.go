@@ -0,0 +1,139 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockGasBumpClient struct {
+	mutex     sync.Mutex
+	submitted []*SignedTransaction
+	committed string
+}
+
+func (m *mockGasBumpClient) SubmitTransaction(signedTxn *SignedTransaction) (*api.SubmitTransactionResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.submitted = append(m.submitted, signedTxn)
+	return &api.SubmitTransactionResponse{}, nil
+}
+
+func (m *mockGasBumpClient) TransactionByHash(txnHash string) (*api.Transaction, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if txnHash != m.committed {
+		return nil, errors.New("transaction not found")
+	}
+	return &api.Transaction{Type: api.TransactionVariantUser, Inner: &api.UserTransaction{Hash: txnHash, GasUnitPrice: 150, Success: true}}, nil
+}
+
+func TestBumpGasUnitPrice(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{Sender: sender.Address, SequenceNumber: 7, Payload: TransactionPayload{Payload: payload}, MaxGasAmount: 1000, GasUnitPrice: 100, ChainId: 4}
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+
+	bumped, err := BumpGasUnitPrice(signedTxn, 1.5, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(150), bumped.GasUnitPrice)
+	assert.Equal(t, uint64(7), bumped.SequenceNumber)
+	assert.Equal(t, uint64(100), rawTxn.GasUnitPrice)
+
+	bumped, err = BumpGasUnitPrice(signedTxn, 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(101), bumped.GasUnitPrice)
+	bumped, err = BumpGasUnitPrice(signedTxn, 2, 120)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(120), bumped.GasUnitPrice)
+	_, err = BumpGasUnitPrice(signedTxn, 2, 100)
+	assert.Error(t, err)
+	_, err = BumpGasUnitPrice(signedTxn, 0.5, 0)
+	assert.Error(t, err)
+
+	feePayerTxn, ok := NewFeePayerRawTransaction(rawTxn, AccountZero).ToFeePayerSignedTransaction(nil, nil, nil)
+	assert.True(t, ok)
+	_, err = BumpGasUnitPrice(feePayerTxn, 1.5, 0)
+	assert.Error(t, err)
+}
+
+func TestStuckTransactionWatcher(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{Sender: sender.Address, SequenceNumber: 7, Payload: TransactionPayload{Payload: payload}, MaxGasAmount: 1000, GasUnitPrice: 100, ExpirationTimestampSeconds: uint64(time.Now().Add(time.Minute).Unix()), ChainId: 4}
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+
+	client := &mockGasBumpClient{}
+	watcher := NewStuckTransactionWatcher(client)
+	watcher.MaxAge = time.Nanosecond
+	assert.NoError(t, watcher.Watch(sender, signedTxn))
+	var events []StuckTransactionEvent
+	handler := func(event StuckTransactionEvent) {
+		events = append(events, event)
+	}
+
+	// Not committed in time, so it's replaced at the same sequence number
+	time.Sleep(time.Millisecond)
+	watcher.Poll(handler)
+	assert.Len(t, events, 1)
+	assert.Equal(t, StuckTransactionReplaced, events[0].Type)
+	assert.Equal(t, uint64(150), events[0].GasUnitPrice)
+	assert.Len(t, client.submitted, 1)
+	assert.Equal(t, uint64(7), client.submitted[0].Transaction.SequenceNumber)
+	assert.NoError(t, client.submitted[0].Verify())
+	replacementHash, err := client.submitted[0].Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, replacementHash, events[0].Hash)
+
+	// The replacement commits
+	client.committed = replacementHash
+	watcher.Poll(handler)
+	assert.Len(t, events, 2)
+	assert.Equal(t, StuckTransactionCommitted, events[1].Type)
+	assert.Equal(t, replacementHash, events[1].Hash)
+	assert.Equal(t, 0, watcher.Pending())
+
+	// Expired transactions are given up on
+	rawTxn.ExpirationTimestampSeconds = 1
+	signedTxn, err = rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+	assert.NoError(t, watcher.Watch(sender, signedTxn))
+	watcher.Poll(handler)
+	assert.Equal(t, StuckTransactionExpired, events[2].Type)
+	assert.Equal(t, 0, watcher.Pending())
+}
+
+func TestStuckTransactionWatcher_Lifecycle(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{Sender: sender.Address, SequenceNumber: 7, Payload: TransactionPayload{Payload: payload}, MaxGasAmount: 1000, GasUnitPrice: 100, ExpirationTimestampSeconds: 1, ChainId: 4}
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+
+	events := make(chan StuckTransactionEvent, 1)
+	watcher := NewStuckTransactionWatcher(&mockGasBumpClient{}).Handler(func(event StuckTransactionEvent) {
+		events <- event
+	})
+	watcher.PollInterval = time.Millisecond
+	var _ Lifecycle = watcher
+
+	assert.NoError(t, watcher.Start(context.Background()))
+	assert.NoError(t, watcher.Watch(sender, signedTxn))
+	assert.Equal(t, StuckTransactionExpired, (<-events).Type)
+	assert.NoError(t, watcher.Close())
+	<-watcher.Done()
+	assert.ErrorIs(t, watcher.Start(context.Background()), ErrClosed)
+}
@@ -0,0 +1,194 @@
+package aptos
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// FeeStatementEventType is the event emitted by every user transaction with its gas breakdown
+const FeeStatementEventType = "0x1::transaction_fee::FeeStatement"
+
+// SponsoredGasCost is the gas paid by the fee payer of a single committed sponsored transaction
+//
+// The fee payer is charged GasUsed * GasUnitPrice, and receives StorageFeeRefund back for storage freed by the
+// transaction, so NetCost can be negative.
+type SponsoredGasCost struct {
+	Version          uint64         // Version of the transaction
+	TransactionHash  string         // TransactionHash of the transaction
+	Timestamp        time.Time      // Timestamp the transaction was committed
+	Sender           AccountAddress // Sender of the transaction, the sponsored user
+	FeePayer         AccountAddress // FeePayer is the sponsor that paid for gas
+	Function         string         // Function is the entry function called, empty for scripts
+	Success          bool           // Success is false for aborted transactions, which are still charged
+	GasUsed          uint64         // GasUsed in gas units
+	GasUnitPrice     uint64         // GasUnitPrice in octas per gas unit
+	Charged          uint64         // Charged is GasUsed * GasUnitPrice in octas
+	StorageFeeRefund uint64         // StorageFeeRefund is the refund in octas paid back to the fee payer
+	NetCost          int64          // NetCost is Charged - StorageFeeRefund in octas
+}
+
+// SponsoredGasCostFromTransaction computes the gas paid by the fee payer of a committed transaction.  ok is false if
+// the transaction isn't sponsored.
+func SponsoredGasCostFromTransaction(txn *api.UserTransaction) (cost SponsoredGasCost, ok bool) {
+	if txn == nil || txn.Signature == nil || txn.Sender == nil {
+		return cost, false
+	}
+	feePayerSig, ok := txn.Signature.Inner.(*api.FeePayerSignature)
+	if !ok || feePayerSig.FeePayerAddress == nil {
+		return cost, false
+	}
+
+	cost = SponsoredGasCost{
+		Version:         txn.Version,
+		TransactionHash: txn.Hash,
+		Timestamp:       time.UnixMicro(int64(txn.Timestamp)),
+		Sender:          *txn.Sender,
+		FeePayer:        *feePayerSig.FeePayerAddress,
+		Success:         txn.Success,
+		GasUsed:         txn.GasUsed,
+		GasUnitPrice:    txn.GasUnitPrice,
+		Charged:         txn.GasUsed * txn.GasUnitPrice,
+	}
+	cost.Function, _ = entryFunctionOf(txn)
+	for _, event := range txn.Events {
+		if event == nil || event.Type != FeeStatementEventType {
+			continue
+		}
+		if refund, found := event.Data["storage_fee_refund_octas"].(string); found {
+			cost.StorageFeeRefund, _ = StrToUint64(refund)
+		}
+	}
+	cost.NetCost = int64(cost.Charged) - int64(cost.StorageFeeRefund)
+	return cost, true
+}
+
+// GroupSponsoredGasBySender groups [SponsoredGasCost]s by the sponsored user, this is the default for [SponsoredGasAggregator]
+func GroupSponsoredGasBySender(cost SponsoredGasCost) string {
+	return cost.Sender.String()
+}
+
+// GroupSponsoredGasByModule groups [SponsoredGasCost]s by the module of the entry function called, e.g. to bill per app.
+// Module addresses are in long form, except special addresses e.g. 0x1::coin
+func GroupSponsoredGasByModule(cost SponsoredGasCost) string {
+	function := normalizeMoveId(cost.Function)
+	for i := len(function) - 1; i > 0; i-- {
+		if function[i] == ':' && function[i-1] == ':' {
+			return function[:i-1]
+		}
+	}
+	return function
+}
+
+// SponsoredGasTotal is the aggregated gas paid for one group over one period
+type SponsoredGasTotal struct {
+	Group            string    // Group is the key returned by [SponsoredGasAggregator.GroupBy]
+	PeriodStart      time.Time // PeriodStart is the start of the period, zero if not grouped by time
+	Transactions     uint64    // Transactions is the number of sponsored transactions
+	Failed           uint64    // Failed is the number of those transactions that aborted, they are still charged
+	GasUsed          uint64    // GasUsed in gas units
+	Charged          uint64    // Charged in octas
+	StorageFeeRefund uint64    // StorageFeeRefund in octas
+	NetCost          int64     // NetCost in octas
+	FirstVersion     uint64    // FirstVersion is the lowest transaction version counted
+	LastVersion      uint64    // LastVersion is the highest transaction version counted
+}
+
+// SponsoredGasAggregator totals the gas a sponsor paid per user or app over time, so gas stations can bill customers.
+// Adding the same transaction twice is ignored.  It is safe for concurrent use.
+//
+//	aggregator := &SponsoredGasAggregator{FeePayer: &sponsor, Period: 24 * time.Hour}
+//	for _, txn := range txns {
+//		aggregator.Add(txn)
+//	}
+//	for _, total := range aggregator.Totals() {
+//		// Bill total.Group for total.NetCost
+//	}
+type SponsoredGasAggregator struct {
+	FeePayer *AccountAddress                    // FeePayer only counts transactions paid by this sponsor, all sponsors if nil
+	GroupBy  func(cost SponsoredGasCost) string // GroupBy keys the totals, defaults to [GroupSponsoredGasBySender]
+	Period   time.Duration                      // Period buckets totals by commit time in UTC, no bucketing if 0
+
+	mutex  sync.Mutex
+	seen   map[uint64]bool
+	totals map[sponsoredGasKey]*SponsoredGasTotal
+}
+
+type sponsoredGasKey struct {
+	group       string
+	periodStart int64
+}
+
+// Add counts a committed transaction, returning false if it isn't sponsored by the fee payer or was already added
+func (a *SponsoredGasAggregator) Add(txn *api.UserTransaction) bool {
+	cost, ok := SponsoredGasCostFromTransaction(txn)
+	if !ok {
+		return false
+	}
+	return a.AddCost(cost)
+}
+
+// AddCost counts a [SponsoredGasCost], returning false if it isn't sponsored by the fee payer or was already added
+func (a *SponsoredGasAggregator) AddCost(cost SponsoredGasCost) bool {
+	if a.FeePayer != nil && *a.FeePayer != cost.FeePayer {
+		return false
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.seen == nil {
+		a.seen = make(map[uint64]bool)
+		a.totals = make(map[sponsoredGasKey]*SponsoredGasTotal)
+	}
+	if a.seen[cost.Version] {
+		return false
+	}
+	a.seen[cost.Version] = true
+
+	groupBy := a.GroupBy
+	if groupBy == nil {
+		groupBy = GroupSponsoredGasBySender
+	}
+	key := sponsoredGasKey{group: groupBy(cost)}
+	var periodStart time.Time
+	if a.Period > 0 {
+		periodStart = cost.Timestamp.UTC().Truncate(a.Period)
+		key.periodStart = periodStart.UnixMicro()
+	}
+
+	total, ok := a.totals[key]
+	if !ok {
+		total = &SponsoredGasTotal{Group: key.group, PeriodStart: periodStart, FirstVersion: cost.Version, LastVersion: cost.Version}
+		a.totals[key] = total
+	}
+	total.Transactions++
+	if !cost.Success {
+		total.Failed++
+	}
+	total.GasUsed += cost.GasUsed
+	total.Charged += cost.Charged
+	total.StorageFeeRefund += cost.StorageFeeRefund
+	total.NetCost += cost.NetCost
+	total.FirstVersion = min(total.FirstVersion, cost.Version)
+	total.LastVersion = max(total.LastVersion, cost.Version)
+	return true
+}
+
+// Totals returns the totals ordered by period, then group
+func (a *SponsoredGasAggregator) Totals() []SponsoredGasTotal {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	totals := make([]SponsoredGasTotal, 0, len(a.totals))
+	for _, total := range a.totals {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if !totals[i].PeriodStart.Equal(totals[j].PeriodStart) {
+			return totals[i].PeriodStart.Before(totals[j].PeriodStart)
+		}
+		return totals[i].Group < totals[j].Group
+	})
+	return totals
+}
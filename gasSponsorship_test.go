@@ -0,0 +1,107 @@
+package aptos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSponsoredTransaction(t *testing.T, version uint64, sender string, feePayer string, function string, timestamp time.Time, gasUsed uint64, refund string) *api.UserTransaction {
+	t.Helper()
+	txn := testCategoryTransaction(t, testEntryFunctionPayload(function), []map[string]any{
+		testCategoryEvent(FeeStatementEventType, map[string]any{"total_charge_gas_units": "5", "storage_fee_refund_octas": refund}),
+	})
+	txn.Version = version
+	txn.Timestamp = uint64(timestamp.UnixMicro())
+	txn.GasUsed = gasUsed
+	txn.GasUnitPrice = 100
+	assert.NoError(t, txn.Sender.ParseStringRelaxed(sender))
+	if feePayer != "" {
+		feePayerAddress := AccountAddress{}
+		assert.NoError(t, feePayerAddress.ParseStringRelaxed(feePayer))
+		txn.Signature = &api.Signature{Type: api.SignatureVariantFeePayer, Inner: &api.FeePayerSignature{FeePayerAddress: &feePayerAddress}}
+	} else {
+		txn.Signature = &api.Signature{Type: api.SignatureVariantEd25519, Inner: &api.Ed25519Signature{}}
+	}
+	return txn
+}
+
+func TestSponsoredGasCostFromTransaction(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	txn := testSponsoredTransaction(t, 7, "0xa", "0xf", "0x0001::aptos_account::transfer", now, 10, "300")
+	txn.Success = false
+
+	cost, ok := SponsoredGasCostFromTransaction(txn)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(7), cost.Version)
+	assert.Equal(t, "0xa", cost.Sender.String())
+	assert.Equal(t, "0xf", cost.FeePayer.String())
+	assert.Equal(t, "0x0001::aptos_account::transfer", cost.Function)
+	assert.False(t, cost.Success)
+	assert.True(t, now.Equal(cost.Timestamp))
+	assert.Equal(t, uint64(1000), cost.Charged)
+	assert.Equal(t, uint64(300), cost.StorageFeeRefund)
+	assert.Equal(t, int64(700), cost.NetCost)
+
+	// Refunds can exceed the charge
+	cost, ok = SponsoredGasCostFromTransaction(testSponsoredTransaction(t, 8, "0xa", "0xf", "0x1::object::delete", now, 1, "500"))
+	assert.True(t, ok)
+	assert.Equal(t, int64(-400), cost.NetCost)
+
+	_, ok = SponsoredGasCostFromTransaction(testSponsoredTransaction(t, 9, "0xa", "", "0x1::aptos_account::transfer", now, 10, "0"))
+	assert.False(t, ok)
+}
+
+func TestSponsoredGasAggregator(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	txns := []*api.UserTransaction{
+		testSponsoredTransaction(t, 1, "0xa", "0xf", "0xcafe::game::play", day.Add(time.Hour), 10, "0"),
+		testSponsoredTransaction(t, 2, "0xb", "0xf", "0xcafe::game::play", day.Add(2*time.Hour), 20, "100"),
+		testSponsoredTransaction(t, 3, "0xa", "0xf", "0xbeef::shop::buy", day.Add(25*time.Hour), 5, "0"),
+		testSponsoredTransaction(t, 4, "0xa", "0xe", "0xcafe::game::play", day.Add(time.Hour), 10, "0"),
+		testSponsoredTransaction(t, 5, "0xa", "", "0xcafe::game::play", day.Add(time.Hour), 10, "0"),
+	}
+	sponsor := AccountAddress{}
+	assert.NoError(t, sponsor.ParseStringRelaxed("0xf"))
+
+	bySender := &SponsoredGasAggregator{FeePayer: &sponsor}
+	added := 0
+	for _, txn := range txns {
+		if bySender.Add(txn) {
+			added++
+		}
+	}
+	assert.Equal(t, 3, added)
+	// Duplicates are ignored
+	assert.False(t, bySender.Add(txns[0]))
+
+	totals := bySender.Totals()
+	assert.Len(t, totals, 2)
+	alice := AccountAddress{}
+	assert.NoError(t, alice.ParseStringRelaxed("0xa"))
+	assert.Equal(t, alice.String(), totals[0].Group)
+	assert.Equal(t, uint64(2), totals[0].Transactions)
+	assert.Equal(t, uint64(15), totals[0].GasUsed)
+	assert.Equal(t, int64(1500), totals[0].NetCost)
+	assert.Equal(t, uint64(1), totals[0].FirstVersion)
+	assert.Equal(t, uint64(3), totals[0].LastVersion)
+	assert.Equal(t, uint64(2000), totals[1].Charged)
+	assert.Equal(t, uint64(100), totals[1].StorageFeeRefund)
+	assert.Equal(t, int64(1900), totals[1].NetCost)
+
+	// Per app, per day, across all sponsors
+	byApp := &SponsoredGasAggregator{GroupBy: GroupSponsoredGasByModule, Period: 24 * time.Hour}
+	for _, txn := range txns {
+		byApp.Add(txn)
+	}
+	totals = byApp.Totals()
+	assert.Len(t, totals, 2)
+	assert.Equal(t, normalizeMoveId("0xcafe::game"), totals[0].Group)
+	assert.True(t, day.Equal(totals[0].PeriodStart))
+	assert.Equal(t, uint64(3), totals[0].Transactions)
+	assert.Equal(t, int64(3900), totals[0].NetCost)
+	assert.Equal(t, normalizeMoveId("0xbeef::shop"), totals[1].Group)
+	assert.True(t, day.Add(24*time.Hour).Equal(totals[1].PeriodStart))
+}
@@ -0,0 +1,113 @@
+package aptos
+
+import (
+	"math/rand"
+	"reflect"
+
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+)
+
+// maxGeneratedDepth is the deepest nesting of generated type tags, e.g. vector<vector<u8>> is a depth of 2
+const maxGeneratedDepth = 3
+
+// RandomAccountAddress generates a random [AccountAddress].  One in four addresses is special e.g. 0x1, so both the
+// short and long string forms are covered.
+//
+// It takes a [rand.Rand] so it can back other property testing libraries, e.g. with rapid:
+//
+//	rapid.Custom(func(t *rapid.T) AccountAddress {
+//		return RandomAccountAddress(rand.New(rand.NewSource(rapid.Int64().Draw(t, "seed"))))
+//	})
+func RandomAccountAddress(r *rand.Rand) AccountAddress {
+	return types.RandomAccountAddress(r)
+}
+
+// RandomTypeTag generates a random valid [TypeTag] that can be used as a type argument, with vectors and structs nested
+// up to depth deep.  Generated tags round trip through [TypeTag.String] and [ParseTypeTag], and through BCS.
+func RandomTypeTag(r *rand.Rand, depth int) TypeTag {
+	primitives := []func() TypeTagImpl{
+		func() TypeTagImpl { return &BoolTag{} },
+		func() TypeTagImpl { return &U8Tag{} },
+		func() TypeTagImpl { return &U16Tag{} },
+		func() TypeTagImpl { return &U32Tag{} },
+		func() TypeTagImpl { return &U64Tag{} },
+		func() TypeTagImpl { return &U128Tag{} },
+		func() TypeTagImpl { return &U256Tag{} },
+		func() TypeTagImpl { return &AddressTag{} },
+	}
+	choice := r.Intn(len(primitives) + 2)
+	if depth <= 0 || choice < len(primitives) {
+		return TypeTag{Value: primitives[r.Intn(len(primitives))]()}
+	}
+	if choice == len(primitives) {
+		return TypeTag{Value: &VectorTag{TypeParam: RandomTypeTag(r, depth-1)}}
+	}
+	return TypeTag{Value: RandomStructTag(r, depth-1)}
+}
+
+// RandomStructTag generates a random [StructTag] with up to 3 type parameters nested up to depth deep
+func RandomStructTag(r *rand.Rand, depth int) *StructTag {
+	tag := &StructTag{
+		Address: RandomAccountAddress(r),
+		Module:  randomIdentifier(r),
+		Name:    randomIdentifier(r),
+	}
+	if depth > 0 {
+		tag.TypeParams = make([]TypeTag, r.Intn(4))
+		for i := range tag.TypeParams {
+			tag.TypeParams[i] = RandomTypeTag(r, depth-1)
+		}
+	}
+	return tag
+}
+
+// Generate implements [testing/quick.Generator] with [RandomTypeTag], size bounds the nesting depth
+func (tt TypeTag) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(RandomTypeTag(r, min(size, maxGeneratedDepth)))
+}
+
+// RandomEntryFunction generates a random [EntryFunction] with up to 3 type arguments and 8 arguments.  The arguments
+// are random bytes, as their types aren't known without an ABI.
+func RandomEntryFunction(r *rand.Rand) *EntryFunction {
+	entry := &EntryFunction{
+		Module:   ModuleId{Address: RandomAccountAddress(r), Name: randomIdentifier(r)},
+		Function: randomIdentifier(r),
+		ArgTypes: make([]TypeTag, r.Intn(4)),
+		Args:     make([][]byte, r.Intn(9)),
+	}
+	for i := range entry.ArgTypes {
+		entry.ArgTypes[i] = RandomTypeTag(r, maxGeneratedDepth)
+	}
+	for i := range entry.Args {
+		entry.Args[i] = make([]byte, r.Intn(65))
+		_, _ = r.Read(entry.Args[i])
+	}
+	return entry
+}
+
+// Generate implements [testing/quick.Generator] with [RandomEntryFunction]
+func (sf EntryFunction) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(*RandomEntryFunction(r))
+}
+
+// RandomTransactionPayload generates a random [TransactionPayload] of an [EntryFunction]
+func RandomTransactionPayload(r *rand.Rand) TransactionPayload {
+	return TransactionPayload{Payload: RandomEntryFunction(r)}
+}
+
+// Generate implements [testing/quick.Generator] with [RandomTransactionPayload]
+func (txn TransactionPayload) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(RandomTransactionPayload(r))
+}
+
+// randomIdentifier generates a random Move identifier of 1 to 16 characters
+func randomIdentifier(r *rand.Rand) string {
+	const first = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	const rest = first + "0123456789_"
+	out := make([]byte, 1+r.Intn(16))
+	out[0] = first[r.Intn(len(first))]
+	for i := 1; i < len(out); i++ {
+		out[i] = rest[r.Intn(len(rest))]
+	}
+	return string(out)
+}
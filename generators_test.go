@@ -0,0 +1,50 @@
+package aptos
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerators_AccountAddress(t *testing.T) {
+	err := quick.Check(func(address AccountAddress) bool {
+		parsed := AccountAddress{}
+		return parsed.ParseStringRelaxed(address.String()) == nil && parsed == address
+	}, nil)
+	assert.NoError(t, err)
+}
+
+func TestGenerators_TypeTag(t *testing.T) {
+	err := quick.Check(func(tag TypeTag) bool {
+		parsed, err := ParseTypeTag(tag.String())
+		if err != nil || parsed.String() != tag.String() {
+			t.Logf("%s parsed as %v: %v", tag.String(), parsed, err)
+			return false
+		}
+		bytes, err := bcs.Serialize(&tag)
+		if err != nil {
+			return false
+		}
+		decoded := TypeTag{}
+		return bcs.Deserialize(&decoded, bytes) == nil && decoded.String() == tag.String()
+	}, nil)
+	assert.NoError(t, err)
+}
+
+func TestGenerators_TransactionPayload(t *testing.T) {
+	err := quick.Check(func(payload TransactionPayload) bool {
+		bytes, err := bcs.Serialize(&payload)
+		if err != nil {
+			return false
+		}
+		decoded := TransactionPayload{}
+		if bcs.Deserialize(&decoded, bytes) != nil {
+			return false
+		}
+		again, err := bcs.Serialize(&decoded)
+		return err == nil && string(again) == string(bytes)
+	}, nil)
+	assert.NoError(t, err)
+}
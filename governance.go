@@ -0,0 +1,251 @@
+package aptos
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// aptosGovernanceModule is the 0x1::aptos_governance module
+var aptosGovernanceModule = ModuleId{Address: AccountOne, Name: "aptos_governance"}
+
+// votingModule is the 0x1::voting module, which holds the proposals of the governance forum at 0x1
+var votingModule = ModuleId{Address: AccountOne, Name: "voting"}
+
+// governanceProposalTypeTag is the 0x1::governance_proposal::GovernanceProposal type of on-chain governance proposals
+func governanceProposalTypeTag() TypeTag {
+	return TypeTag{Value: &StructTag{Address: AccountOne, Module: "governance_proposal", Name: "GovernanceProposal"}}
+}
+
+// GovernanceProposalState is the state of a governance proposal, see [GovernanceClient.ProposalState]
+type GovernanceProposalState uint64
+
+const (
+	GovernanceProposalPending   GovernanceProposalState = 0 // GovernanceProposalPending is a proposal still being voted on, or not yet resolvable
+	GovernanceProposalSucceeded GovernanceProposalState = 1 // GovernanceProposalSucceeded is a proposal that passed, and can be resolved
+	GovernanceProposalFailed    GovernanceProposalState = 3 // GovernanceProposalFailed is a proposal that didn't pass
+)
+
+// String returns the name of the state e.g. "succeeded"
+func (state GovernanceProposalState) String() string {
+	switch state {
+	case GovernanceProposalPending:
+		return "pending"
+	case GovernanceProposalSucceeded:
+		return "succeeded"
+	case GovernanceProposalFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint64(state))
+	}
+}
+
+// GovernanceVotePayload builds a 0x1::aptos_governance::vote on the proposal with all the remaining voting power of the
+// stake pool.  The signer must be the pool's delegated voter.
+func GovernanceVotePayload(stakePool AccountAddress, proposalId uint64, shouldPass bool) (*EntryFunction, error) {
+	proposalIdBytes, err := bcs.SerializeU64(proposalId)
+	if err != nil {
+		return nil, err
+	}
+	shouldPassBytes, err := bcs.SerializeBool(shouldPass)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryFunction{
+		Module:   aptosGovernanceModule,
+		Function: "vote",
+		ArgTypes: []TypeTag{},
+		Args:     [][]byte{stakePool[:], proposalIdBytes, shouldPassBytes},
+	}, nil
+}
+
+// GovernancePartialVotePayload builds a 0x1::aptos_governance::partial_vote on the proposal with votingPower of the
+// stake pool's voting power, so a pool can split its votes
+func GovernancePartialVotePayload(stakePool AccountAddress, proposalId uint64, votingPower uint64, shouldPass bool) (*EntryFunction, error) {
+	proposalIdBytes, err := bcs.SerializeU64(proposalId)
+	if err != nil {
+		return nil, err
+	}
+	votingPowerBytes, err := bcs.SerializeU64(votingPower)
+	if err != nil {
+		return nil, err
+	}
+	shouldPassBytes, err := bcs.SerializeBool(shouldPass)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryFunction{
+		Module:   aptosGovernanceModule,
+		Function: "partial_vote",
+		ArgTypes: []TypeTag{},
+		Args:     [][]byte{stakePool[:], proposalIdBytes, votingPowerBytes, shouldPassBytes},
+	}, nil
+}
+
+// GovernanceClient votes on 0x1::aptos_governance proposals with stake pools, and queries proposals and voting
+// records.  Each transaction method takes the same options as [Client.BuildTransaction].
+//
+//	governance := NewGovernanceClient(client)
+//	remaining, err := governance.RemainingVotingPower(pool, proposalId)
+//	if remaining > 0 {
+//		signedTxn, err := governance.Vote(voter, pool, proposalId, true)
+//	}
+type GovernanceClient struct {
+	aptosClient *Client
+}
+
+// NewGovernanceClient creates a client for 0x1::aptos_governance
+func NewGovernanceClient(client *Client) *GovernanceClient {
+	return &GovernanceClient{client}
+}
+
+// Vote votes on the proposal with all the remaining voting power of the stake pool
+func (client *GovernanceClient) Vote(voter TransactionSigner, stakePool AccountAddress, proposalId uint64, shouldPass bool, options ...any) (*SignedTransaction, error) {
+	payload, err := GovernanceVotePayload(stakePool, proposalId, shouldPass)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(voter, payload, options)
+}
+
+// PartialVote votes on the proposal with votingPower of the stake pool's voting power
+func (client *GovernanceClient) PartialVote(voter TransactionSigner, stakePool AccountAddress, proposalId uint64, votingPower uint64, shouldPass bool, options ...any) (*SignedTransaction, error) {
+	payload, err := GovernancePartialVotePayload(stakePool, proposalId, votingPower, shouldPass)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(voter, payload, options)
+}
+
+// ProposalState returns whether the proposal is pending, succeeded, or failed
+func (client *GovernanceClient) ProposalState(proposalId uint64, ledgerVersion ...uint64) (GovernanceProposalState, error) {
+	vals, err := client.viewProposal("get_proposal_state", proposalId, 1, ledgerVersion)
+	if err != nil {
+		return 0, err
+	}
+	states, err := viewU64s(vals)
+	if err != nil {
+		return 0, err
+	}
+	return GovernanceProposalState(states[0]), nil
+}
+
+// ProposalVotes returns the voting power voted for and against the proposal
+func (client *GovernanceClient) ProposalVotes(proposalId uint64, ledgerVersion ...uint64) (yes *big.Int, no *big.Int, err error) {
+	vals, err := client.viewProposal("get_votes", proposalId, 2, ledgerVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	votes := make([]*big.Int, len(vals))
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("bad view return from node, expected a u128 string, got %T", val)
+		}
+		votes[i], err = StrToBigInt(str)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return votes[0], votes[1], nil
+}
+
+// IsVotingClosed tells if voting on the proposal has ended, either by expiring or reaching early resolution
+func (client *GovernanceClient) IsVotingClosed(proposalId uint64, ledgerVersion ...uint64) (bool, error) {
+	vals, err := client.viewProposal("is_voting_closed", proposalId, 1, ledgerVersion)
+	if err != nil {
+		return false, err
+	}
+	closed, ok := vals[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("bad view return from node, is_voting_closed is %T", vals[0])
+	}
+	return closed, nil
+}
+
+// VotingPower returns the voting power of the stake pool
+func (client *GovernanceClient) VotingPower(stakePool AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	vals, err := client.view(aptosGovernanceModule, "get_voting_power", nil, [][]byte{stakePool[:]}, 1, ledgerVersion)
+	if err != nil {
+		return 0, err
+	}
+	powers, err := viewU64s(vals)
+	if err != nil {
+		return 0, err
+	}
+	return powers[0], nil
+}
+
+// RemainingVotingPower returns the voting power the stake pool hasn't yet used on the proposal, 0 once it has
+// entirely voted
+func (client *GovernanceClient) RemainingVotingPower(stakePool AccountAddress, proposalId uint64, ledgerVersion ...uint64) (uint64, error) {
+	proposalIdBytes, err := bcs.SerializeU64(proposalId)
+	if err != nil {
+		return 0, err
+	}
+	vals, err := client.view(aptosGovernanceModule, "get_remaining_voting_power", nil, [][]byte{stakePool[:], proposalIdBytes}, 1, ledgerVersion)
+	if err != nil {
+		return 0, err
+	}
+	powers, err := viewU64s(vals)
+	if err != nil {
+		return 0, err
+	}
+	return powers[0], nil
+}
+
+// HasVoted tells if the stake pool has used all its voting power on the proposal
+func (client *GovernanceClient) HasVoted(stakePool AccountAddress, proposalId uint64, ledgerVersion ...uint64) (bool, error) {
+	proposalIdBytes, err := bcs.SerializeU64(proposalId)
+	if err != nil {
+		return false, err
+	}
+	vals, err := client.view(aptosGovernanceModule, "has_entirely_voted", nil, [][]byte{stakePool[:], proposalIdBytes}, 1, ledgerVersion)
+	if err != nil {
+		return false, err
+	}
+	voted, ok := vals[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("bad view return from node, has_entirely_voted is %T", vals[0])
+	}
+	return voted, nil
+}
+
+// viewProposal calls a 0x1::voting view function on a governance proposal
+func (client *GovernanceClient) viewProposal(function string, proposalId uint64, returns int, ledgerVersion []uint64) ([]any, error) {
+	proposalIdBytes, err := bcs.SerializeU64(proposalId)
+	if err != nil {
+		return nil, err
+	}
+	return client.view(votingModule, function, []TypeTag{governanceProposalTypeTag()}, [][]byte{AccountOne[:], proposalIdBytes}, returns, ledgerVersion)
+}
+
+// view calls a view function, checking the number of return values
+func (client *GovernanceClient) view(module ModuleId, function string, typeArgs []TypeTag, args [][]byte, returns int, ledgerVersion []uint64) ([]any, error) {
+	if typeArgs == nil {
+		typeArgs = []TypeTag{}
+	}
+	vals, err := client.aptosClient.View(&ViewPayload{
+		Module:   module,
+		Function: function,
+		ArgTypes: typeArgs,
+		Args:     args,
+	}, ledgerVersion...)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) != returns {
+		return nil, fmt.Errorf("bad view return from node, %s returned %d values, expected %d", function, len(vals), returns)
+	}
+	return vals, nil
+}
+
+// sign builds and signs a transaction of the payload
+func (client *GovernanceClient) sign(sender TransactionSigner, payload *EntryFunction, options []any) (*SignedTransaction, error) {
+	rawTxn, err := client.aptosClient.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return rawTxn.SignedTransaction(sender)
+}
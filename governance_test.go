@@ -0,0 +1,73 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGovernancePayloads(t *testing.T) {
+	vote, err := GovernanceVotePayload(AccountTwo, 42, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "vote", vote.Function)
+	assert.Equal(t, [][]byte{AccountTwo[:], {42, 0, 0, 0, 0, 0, 0, 0}, {1}}, vote.Args)
+
+	partial, err := GovernancePartialVotePayload(AccountTwo, 42, 7, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "partial_vote", partial.Function)
+	assert.Equal(t, [][]byte{AccountTwo[:], {42, 0, 0, 0, 0, 0, 0, 0}, {7, 0, 0, 0, 0, 0, 0, 0}, {0}}, partial.Args)
+
+	assert.Equal(t, "succeeded", GovernanceProposalSucceeded.String())
+	assert.Equal(t, "unknown(2)", GovernanceProposalState(2).String())
+}
+
+func TestGovernanceClient_Views(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/view", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		// The function name is in the BCS body
+		switch {
+		case bytes.Contains(body, []byte("get_proposal_state")):
+			assert.True(t, bytes.Contains(body, []byte("GovernanceProposal")))
+			_ = json.NewEncoder(w).Encode([]any{"1"})
+		case bytes.Contains(body, []byte("get_votes")):
+			_ = json.NewEncoder(w).Encode([]any{"340282366920938463463374607431768211455", "5"})
+		case bytes.Contains(body, []byte("is_voting_closed")), bytes.Contains(body, []byte("has_entirely_voted")):
+			_ = json.NewEncoder(w).Encode([]any{true})
+		default:
+			_ = json.NewEncoder(w).Encode([]any{"100"})
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL})
+	assert.NoError(t, err)
+	governance := NewGovernanceClient(client)
+
+	state, err := governance.ProposalState(42)
+	assert.NoError(t, err)
+	assert.Equal(t, GovernanceProposalSucceeded, state)
+
+	yes, no, err := governance.ProposalVotes(42)
+	assert.NoError(t, err)
+	assert.Equal(t, "340282366920938463463374607431768211455", yes.String())
+	assert.Equal(t, "5", no.String())
+
+	closed, err := governance.IsVotingClosed(42)
+	assert.NoError(t, err)
+	assert.True(t, closed)
+
+	voted, err := governance.HasVoted(AccountTwo, 42)
+	assert.NoError(t, err)
+	assert.True(t, voted)
+
+	remaining, err := governance.RemainingVotingPower(AccountTwo, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), remaining)
+}
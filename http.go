@@ -1,10 +1,14 @@
 package aptos
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
 )
 
 // HttpErrSummaryLength is the maximum length of the body to include in the error message
@@ -18,13 +22,18 @@ type HttpError struct {
 	Method     string      // HTTP method e.g. "GET"
 	RequestUrl url.URL     // URL of the request
 	Body       []byte      // Body of the response
+
+	// Fields parsed from the standard node error body, empty if the body isn't one e.g. from a proxy
+	Message     string // Message is the error message
+	ErrorCode   string // ErrorCode is the kind of error e.g. [api.ErrorCodeAccountNotFound]
+	VmErrorCode uint64 // VmErrorCode is the VM status code for [api.ErrorCodeVmError], 0 if not set
 }
 
 // NewHttpError creates a new HttpError from a http.Response
 func NewHttpError(response *http.Response) *HttpError {
 	body, _ := io.ReadAll(response.Body)
 	_ = response.Body.Close()
-	return &HttpError{
+	httpErr := &HttpError{
 		Status:     response.Status,
 		StatusCode: response.StatusCode,
 		Header:     response.Header,
@@ -32,6 +41,103 @@ func NewHttpError(response *http.Response) *HttpError {
 		Method:     response.Request.Method,
 		RequestUrl: *response.Request.URL,
 	}
+	apiErr := api.Error{}
+	if json.Unmarshal(body, &apiErr) == nil {
+		httpErr.Message = apiErr.Message
+		httpErr.ErrorCode = apiErr.ErrorCode
+		httpErr.VmErrorCode = apiErr.VmErrorCode
+	}
+	return httpErr
+}
+
+// Errors for classes of node failures, matched with errors.Is on an [HttpError] or [AptosApiError] e.g. to branch on
+// the failure of a submission
+//
+//	_, err := client.SubmitTransaction(signedTxn)
+//	switch {
+//	case errors.Is(err, aptos.ErrSequenceNumberTooOld):
+//		// resynchronize the sequence number and sign again
+//	case errors.Is(err, aptos.ErrRateLimited):
+//		// back off
+//	}
+var (
+	ErrAccountNotFound      = errors.New("account not found")                        // ErrAccountNotFound the account doesn't exist at the ledger version
+	ErrSequenceNumberTooOld = errors.New("sequence number too old")                  // ErrSequenceNumberTooOld the transaction's sequence number was already used
+	ErrInsufficientBalance  = errors.New("insufficient balance for transaction fee") // ErrInsufficientBalance the sender can't pay the max gas of the transaction
+	ErrRateLimited          = errors.New("rate limited")                             // ErrRateLimited the request was rejected with 429 Too Many Requests
+)
+
+// VM status codes of [AptosApiError.VmErrorCode] that map to the errors above
+const (
+	vmStatusSequenceNumberTooOld                 = 3
+	vmStatusInsufficientBalanceForTransactionFee = 5
+)
+
+// AptosApiError is the standard error body of a node, wrapped by the [HttpError] of the response.  Use errors.As to get
+// it, or errors.Is with [ErrAccountNotFound], [ErrSequenceNumberTooOld], [ErrInsufficientBalance], or [ErrRateLimited].
+//
+//	apiErr := &aptos.AptosApiError{}
+//	if errors.As(err, &apiErr) && apiErr.ErrorCode == api.ErrorCodeMempoolIsFull {
+//		// try again later
+//	}
+type AptosApiError struct {
+	StatusCode  int    // StatusCode is the HTTP status code e.g. 404
+	ErrorCode   string // ErrorCode is the kind of error e.g. [api.ErrorCodeAccountNotFound]
+	VmErrorCode uint64 // VmErrorCode is the VM status code for [api.ErrorCodeVmError], 0 if not set
+	Message     string // Message is the error message
+}
+
+// Error returns the error code and message
+func (e *AptosApiError) Error() string {
+	if e.VmErrorCode != 0 {
+		return fmt.Sprintf("%s (vm_error_code %d): %s", e.ErrorCode, e.VmErrorCode, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.ErrorCode, e.Message)
+}
+
+// Is matches the error classes, see [ErrAccountNotFound]
+func (e *AptosApiError) Is(target error) bool {
+	switch target {
+	case ErrAccountNotFound:
+		return e.ErrorCode == api.ErrorCodeAccountNotFound
+	case ErrSequenceNumberTooOld:
+		return e.ErrorCode == api.ErrorCodeSequenceNumberTooOld ||
+			(e.ErrorCode == api.ErrorCodeVmError && e.VmErrorCode == vmStatusSequenceNumberTooOld)
+	case ErrInsufficientBalance:
+		return e.ErrorCode == api.ErrorCodeVmError && e.VmErrorCode == vmStatusInsufficientBalanceForTransactionFee
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// HasErrorCode checks if err is, or wraps, an [AptosApiError] with the node error code, e.g. [api.ErrorCodeAccountNotFound]
+//
+//	info, err := client.Account(address)
+//	if aptos.HasErrorCode(err, api.ErrorCodeAccountNotFound) {
+//		// the account hasn't been created yet
+//	}
+func HasErrorCode(err error, errorCode string) bool {
+	apiErr := &AptosApiError{}
+	return errors.As(err, &apiErr) && apiErr.ErrorCode == errorCode
+}
+
+// Unwrap returns the [AptosApiError] of the body, nil if the body isn't one e.g. from a proxy
+func (he *HttpError) Unwrap() error {
+	if he.ErrorCode == "" {
+		return nil
+	}
+	return &AptosApiError{
+		StatusCode:  he.StatusCode,
+		ErrorCode:   he.ErrorCode,
+		VmErrorCode: he.VmErrorCode,
+		Message:     he.Message,
+	}
+}
+
+// Is matches [ErrRateLimited] on the status code, which rate limiters in front of the node send without an API body
+func (he *HttpError) Is(target error) bool {
+	return target == ErrRateLimited && he.StatusCode == http.StatusTooManyRequests
 }
 
 // Error returns a string representation of the HttpError
@@ -39,7 +145,16 @@ func NewHttpError(response *http.Response) *HttpError {
 // Implements:
 //   - [Error]
 func (he *HttpError) Error() string {
-	if len(he.Body) < HttpErrSummaryLength {
+	if he.ErrorCode != "" && len(he.Message) < HttpErrSummaryLength {
+		if he.VmErrorCode != 0 {
+			return fmt.Sprintf("HttpError %s %#v -> %#v %s (vm_error_code %d): %s",
+				he.Method, he.RequestUrl.String(), he.Status, he.ErrorCode, he.VmErrorCode, he.Message,
+			)
+		}
+		return fmt.Sprintf("HttpError %s %#v -> %#v %s: %s",
+			he.Method, he.RequestUrl.String(), he.Status, he.ErrorCode, he.Message,
+		)
+	} else if len(he.Body) < HttpErrSummaryLength {
 		return fmt.Sprintf("HttpError %s %#v -> %#v %#v",
 			he.Method, he.RequestUrl.String(), he.Status,
 			string(he.Body),
@@ -0,0 +1,107 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpError(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/0x1":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Account not found by Address(0x1) and Ledger version(5)", "error_code": "account_not_found", "vm_error_code": null}`))
+		case "/transactions":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "Invalid transaction: Type: Validation Code: SEQUENCE_NUMBER_TOO_OLD", "error_code": "vm_error", "vm_error_code": 3}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html>bad gateway</html>"))
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewNodeClient(mockServer.URL, 4)
+	assert.NoError(t, err)
+
+	_, err = client.Account(AccountOne)
+	assert.True(t, HasErrorCode(err, api.ErrorCodeAccountNotFound))
+	assert.False(t, HasErrorCode(err, api.ErrorCodeResourceNotFound))
+	httpErr := &HttpError{}
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	assert.Equal(t, "Account not found by Address(0x1) and Ledger version(5)", httpErr.Message)
+	assert.Equal(t, uint64(0), httpErr.VmErrorCode)
+	assert.Contains(t, httpErr.Error(), "account_not_found: Account not found")
+
+	_, err = Post[map[string]any](client, mockServer.URL+"/transactions", ContentTypeAptosSignedTxnBcs, nil)
+	assert.True(t, HasErrorCode(err, api.ErrorCodeVmError))
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, uint64(3), httpErr.VmErrorCode)
+	assert.Contains(t, httpErr.Error(), "vm_error (vm_error_code 3)")
+
+	// Bodies that aren't from the node, e.g. from a proxy, are kept raw
+	_, err = client.Info()
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, "", httpErr.ErrorCode)
+	assert.Equal(t, []byte("<html>bad gateway</html>"), httpErr.Body)
+	assert.Contains(t, httpErr.Error(), "bad gateway")
+
+	assert.False(t, HasErrorCode(fmt.Errorf("not an http error"), api.ErrorCodeAccountNotFound))
+	assert.False(t, HasErrorCode(nil, api.ErrorCodeAccountNotFound))
+}
+
+func TestAptosApiError(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/0x1":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Account not found by Address(0x1) and Ledger version(5)", "error_code": "account_not_found", "vm_error_code": null}`))
+		case "/transactions":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(r.URL.Query().Get("body")))
+		default:
+			// A rate limiter in front of the node
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte("slow down"))
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewNodeClient(mockServer.URL, 4)
+	assert.NoError(t, err)
+
+	_, err = client.Account(AccountOne)
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+	assert.NotErrorIs(t, err, ErrSequenceNumberTooOld)
+	apiErr := &AptosApiError{}
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, api.ErrorCodeAccountNotFound, apiErr.ErrorCode)
+	assert.Equal(t, "account_not_found: Account not found by Address(0x1) and Ledger version(5)", apiErr.Error())
+
+	submit := func(body string) error {
+		_, err := Post[map[string]any](client, mockServer.URL+"/transactions?body="+url.QueryEscape(body), ContentTypeAptosSignedTxnBcs, nil)
+		return err
+	}
+	err = submit(`{"message": "Invalid transaction: Type: Validation Code: SEQUENCE_NUMBER_TOO_OLD", "error_code": "vm_error", "vm_error_code": 3}`)
+	assert.ErrorIs(t, err, ErrSequenceNumberTooOld)
+	assert.NotErrorIs(t, err, ErrInsufficientBalance)
+	err = submit(`{"message": "transaction already in mempool", "error_code": "sequence_number_too_old", "vm_error_code": null}`)
+	assert.ErrorIs(t, err, ErrSequenceNumberTooOld)
+	err = submit(`{"message": "Invalid transaction: Type: Validation Code: INSUFFICIENT_BALANCE_FOR_TRANSACTION_FEE", "error_code": "vm_error", "vm_error_code": 5}`)
+	assert.ErrorIs(t, err, ErrInsufficientBalance)
+	assert.NotErrorIs(t, err, ErrRateLimited)
+
+	// Rate limits are matched without an API body
+	_, err = client.Info()
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.False(t, errors.As(err, &apiErr))
+}
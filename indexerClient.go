@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/hasura/go-graphql-client"
 	"net/http"
+	"slices"
 	"time"
 )
 
@@ -34,6 +35,11 @@ type CoinBalance struct {
 	Amount   uint64
 }
 
+// Asset parses the [CoinBalance.CoinType] into an [Asset].  The indexer reports fungible assets by metadata address.
+func (cb *CoinBalance) Asset() (Asset, error) {
+	return ParseAsset(cb.CoinType)
+}
+
 // GetCoinBalances retrieve the coin balances for all coins owned by the address
 func (ic *IndexerClient) GetCoinBalances(address AccountAddress) ([]CoinBalance, error) {
 	var out []CoinBalance
@@ -78,6 +84,9 @@ func (ic *IndexerClient) GetProcessorStatus(processorName string) (uint64, error
 	if err != nil {
 		return 0, err
 	}
+	if len(q.ProcessorStatus) == 0 {
+		return 0, fmt.Errorf("indexer has no status for processor %s", processorName)
+	}
 
 	return q.ProcessorStatus[0].LastSuccessVersion, err
 }
@@ -107,3 +116,121 @@ func (ic *IndexerClient) WaitOnIndexer(processorName string, requestedVersion ui
 	}
 	return nil
 }
+
+// ProcessorStatus is how far an indexer processor has processed the chain
+type ProcessorStatus struct {
+	Processor                string    // Processor is the name of the processor e.g. fungible_asset_processor
+	LastSuccessVersion       uint64    // LastSuccessVersion is the ledger version processed up to
+	LastUpdated              time.Time // LastUpdated is when the processor last reported progress
+	LastTransactionTimestamp time.Time // LastTransactionTimestamp is the time of the transaction at LastSuccessVersion, zero if unknown
+}
+
+// GetProcessorStatuses gets the status of every processor of the indexer, ordered by name
+func (ic *IndexerClient) GetProcessorStatuses() ([]ProcessorStatus, error) {
+	var q struct {
+		ProcessorStatus []struct {
+			Processor                string  `graphql:"processor"`
+			LastSuccessVersion       uint64  `graphql:"last_success_version"`
+			LastUpdated              string  `graphql:"last_updated"`
+			LastTransactionTimestamp *string `graphql:"last_transaction_timestamp"`
+		} `graphql:"processor_status(order_by: {processor: asc})"`
+	}
+	err := ic.Query(&q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]ProcessorStatus, 0, len(q.ProcessorStatus))
+	for _, processor := range q.ProcessorStatus {
+		status := ProcessorStatus{
+			Processor:          processor.Processor,
+			LastSuccessVersion: processor.LastSuccessVersion,
+		}
+		status.LastUpdated, err = time.Parse(indexerTimeLayout, processor.LastUpdated)
+		if err != nil {
+			return nil, fmt.Errorf("bad last updated %s for processor %s: %w", processor.LastUpdated, processor.Processor, err)
+		}
+		if processor.LastTransactionTimestamp != nil {
+			status.LastTransactionTimestamp, err = time.Parse(indexerTimeLayout, *processor.LastTransactionTimestamp)
+			if err != nil {
+				return nil, fmt.Errorf("bad last transaction timestamp %s for processor %s: %w", *processor.LastTransactionTimestamp, processor.Processor, err)
+			}
+		}
+		out = append(out, status)
+	}
+	return out, nil
+}
+
+// IndexerProcessors is an option to [IndexerClient.WaitForIndexerOnVersion], naming the processors to wait for
+type IndexerProcessors []string
+
+// WaitForIndexerOnVersion waits for the indexer to process the ledger version, so data derived from a transaction can
+// be read right after it commits.  By default, it waits for every processor, pass [IndexerProcessors] to only wait for
+// the ones a query reads from, as a stalled processor would otherwise hold up every wait.
+//
+// Optional arguments:
+//   - PollPeriod: time.Duration, how often to check the processors. Default 100ms.
+//   - PollTimeout: time.Duration, how long to wait for the processors. Default 10s.
+//   - IndexerProcessors: names of the processors to wait for. Default all.
+//
+// Errors returned while polling are retried until the timeout, in case the indexer is briefly unavailable.
+//
+//	txn, err := client.WaitForTransaction(hash)
+//	err = client.WaitForIndexerOnVersion(txn.Version, IndexerProcessors{"fungible_asset_processor"})
+//	balances, err := client.GetAccountCoinsData(owner)
+func (ic *IndexerClient) WaitForIndexerOnVersion(version uint64, options ...any) error {
+	period := 100 * time.Millisecond
+	timeout := 10 * time.Second
+	var processors IndexerProcessors
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case PollPeriod:
+			period = time.Duration(value)
+		case PollTimeout:
+			timeout = time.Duration(value)
+		case IndexerProcessors:
+			processors = value
+		default:
+			return fmt.Errorf("WaitForIndexerOnVersion arg %d bad type %T", i+1, arg)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		lagging, err := ic.laggingProcessor(version, processors)
+		if err == nil && lagging == "" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("timeout waiting for indexer on version %d: %w", version, err)
+			}
+			return fmt.Errorf("timeout waiting for indexer on version %d: %s", version, lagging)
+		}
+		time.Sleep(period)
+	}
+}
+
+// laggingProcessor describes a processor behind version, or is empty if all the processors have caught up
+func (ic *IndexerClient) laggingProcessor(version uint64, processors IndexerProcessors) (string, error) {
+	statuses, err := ic.GetProcessorStatuses()
+	if err != nil {
+		return "", err
+	}
+	seen := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		seen[status.Processor] = true
+		if len(processors) > 0 && !slices.Contains(processors, status.Processor) {
+			continue
+		}
+		if status.LastSuccessVersion < version {
+			return fmt.Sprintf("processor %s is at version %d", status.Processor, status.LastSuccessVersion), nil
+		}
+	}
+	for _, processor := range processors {
+		if !seen[processor] {
+			return "", fmt.Errorf("indexer has no status for processor %s", processor)
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,85 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// processorStatusServer reports the fungible asset processor advancing 10 versions per request
+func processorStatusServer(t *testing.T, requests *atomic.Uint64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query string
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Contains(t, request.Query, "processor_status(order_by: {processor: asc})")
+		count := requests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"processor_status": []map[string]any{{
+			"processor":                  "fungible_asset_processor",
+			"last_success_version":       100 + 10*count,
+			"last_updated":               "2024-05-06T07:08:09.5",
+			"last_transaction_timestamp": "2024-05-06T07:08:08",
+		}, {
+			"processor":                  "stalled_processor",
+			"last_success_version":       5,
+			"last_updated":               "2024-01-01T00:00:00",
+			"last_transaction_timestamp": nil,
+		}}}})
+	}))
+}
+
+func TestClient_GetProcessorStatuses(t *testing.T) {
+	requests := &atomic.Uint64{}
+	mockServer := processorStatusServer(t, requests)
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1", IndexerUrl: mockServer.URL + "/graphql"})
+	assert.NoError(t, err)
+	statuses, err := client.GetProcessorStatuses()
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "fungible_asset_processor", statuses[0].Processor)
+	assert.Equal(t, uint64(110), statuses[0].LastSuccessVersion)
+	assert.Equal(t, time.Date(2024, 5, 6, 7, 8, 9, 500000000, time.UTC), statuses[0].LastUpdated)
+	assert.Equal(t, time.Date(2024, 5, 6, 7, 8, 8, 0, time.UTC), statuses[0].LastTransactionTimestamp)
+	assert.True(t, statuses[1].LastTransactionTimestamp.IsZero())
+}
+
+func TestClient_WaitForIndexerOnVersion(t *testing.T) {
+	requests := &atomic.Uint64{}
+	mockServer := processorStatusServer(t, requests)
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1", IndexerUrl: mockServer.URL + "/graphql"})
+	assert.NoError(t, err)
+
+	// Polls until the processor catches up
+	err = client.WaitForIndexerOnVersion(130, IndexerProcessors{"fungible_asset_processor"}, PollPeriod(time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), requests.Load())
+
+	// A stalled processor holds up waiting on every processor
+	err = client.WaitForIndexerOnVersion(130, PollPeriod(time.Millisecond), PollTimeout(20*time.Millisecond))
+	assert.ErrorContains(t, err, "processor stalled_processor is at version 5")
+
+	// An unknown processor never catches up
+	err = client.WaitForIndexerOnVersion(130, IndexerProcessors{"missing_processor"}, PollPeriod(time.Millisecond), PollTimeout(10*time.Millisecond))
+	assert.ErrorContains(t, err, "no status for processor missing_processor")
+
+	err = client.WaitForIndexerOnVersion(130, "fungible_asset_processor")
+	assert.Error(t, err)
+}
+
+func TestClient_WaitForIndexerOnVersionNoIndexer(t *testing.T) {
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: "http://localhost:8080/v1"})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, client.WaitForIndexerOnVersion(1), ErrNoIndexer)
+	_, err = client.GetProcessorStatuses()
+	assert.ErrorIs(t, err, ErrNoIndexer)
+}
@@ -31,6 +31,24 @@ func NewAccountFromSigner(signer crypto.Signer, address ...AccountAddress) (*Acc
 	return out, nil
 }
 
+// NewAccountFromPrivateKeyString creates an account from an AIP-80 private key string, e.g. as exported by the Aptos
+// CLI or TypeScript SDK, with an optional address for rotated accounts.  Ed25519 keys create legacy Ed25519 accounts,
+// and Secp256k1 keys create single key accounts.  See [crypto.ParseAIP80PrivateKey] for strictness.
+func NewAccountFromPrivateKeyString(privateKey string, strict bool, address ...AccountAddress) (*Account, error) {
+	key, err := crypto.ParseAIP80PrivateKey(privateKey, strict)
+	if err != nil {
+		return nil, err
+	}
+	var signer crypto.Signer
+	switch key := key.(type) {
+	case *crypto.Ed25519PrivateKey:
+		signer = key
+	default:
+		signer = crypto.NewSingleSigner(key)
+	}
+	return NewAccountFromSigner(signer, address...)
+}
+
 // NewEd25519Account creates an account with a new random Ed25519 private key
 func NewEd25519Account() (*Account, error) {
 	privateKey, err := crypto.GenerateEd25519PrivateKey()
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
@@ -118,6 +119,18 @@ func (aa *AccountAddress) ObjectAddressFromObject(objectAddress *AccountAddress)
 	return aa.DerivedAddress(objectAddress[:], crypto.DeriveObjectScheme)
 }
 
+// ObjectAddressFromGuid derives an object address based on the input address as the creator, and the creation number
+// of the creator's GUID used to create the object
+func (aa *AccountAddress) ObjectAddressFromGuid(creationNum uint64) (accountAddress AccountAddress) {
+	// The BCS of a GUID is the creation number, then the creator address
+	guid := binary.LittleEndian.AppendUint64(make([]byte, 0, 8+len(aa)), creationNum)
+	guid = append(guid, aa[:]...)
+	authKey := crypto.AuthenticationKey{}
+	authKey.FromBytesAndScheme(guid, crypto.ObjectFromGuidScheme)
+	copy(accountAddress[:], authKey[:])
+	return
+}
+
 // ResourceAccount derives an object address based on the input address as the creator
 func (aa *AccountAddress) ResourceAccount(seed []byte) (accountAddress AccountAddress) {
 	return aa.DerivedAddress(seed, crypto.ResourceAccountScheme)
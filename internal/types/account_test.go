@@ -181,3 +181,31 @@ func TestAccount_ExtractPrivateKeyString(t *testing.T) {
 	assert.Error(t, err)
 	assert.Empty(t, out)
 }
+
+func TestNewAccountFromPrivateKeyString(t *testing.T) {
+	ed25519PrivateKey, err := crypto.GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	ed25519KeyString, err := ed25519PrivateKey.ToAIP80()
+	assert.NoError(t, err)
+	ed25519Account, err := NewAccountFromPrivateKeyString(ed25519KeyString, true)
+	assert.NoError(t, err)
+	assert.Equal(t, ed25519PrivateKey, ed25519Account.Signer)
+	assert.Equal(t, AccountAddress(*ed25519PrivateKey.AuthKey()), ed25519Account.Address)
+
+	// Plain hex needs loose mode
+	_, err = NewAccountFromPrivateKeyString(ed25519PrivateKey.ToHex(), true)
+	assert.Error(t, err)
+	_, err = NewAccountFromPrivateKeyString(ed25519PrivateKey.ToHex(), false)
+	assert.NoError(t, err)
+
+	secp256k1PrivateKey, err := crypto.GenerateSecp256k1Key()
+	assert.NoError(t, err)
+	secp256k1KeyString, err := secp256k1PrivateKey.ToAIP80()
+	assert.NoError(t, err)
+	secp256k1Account, err := NewAccountFromPrivateKeyString(secp256k1KeyString, true, AccountTwo)
+	assert.NoError(t, err)
+	assert.Equal(t, AccountTwo, secp256k1Account.Address)
+	keyString, err := secp256k1Account.PrivateKeyString()
+	assert.NoError(t, err)
+	assert.Equal(t, secp256k1KeyString, keyString)
+}
@@ -0,0 +1,23 @@
+package types
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// RandomAccountAddress generates a random [AccountAddress].  One in four addresses is special e.g. 0x1, so both the
+// short and long string forms are covered.
+func RandomAccountAddress(r *rand.Rand) AccountAddress {
+	address := AccountAddress{}
+	if r.Intn(4) == 0 {
+		address[31] = byte(r.Intn(16))
+		return address
+	}
+	_, _ = r.Read(address[:])
+	return address
+}
+
+// Generate implements [testing/quick.Generator] with [RandomAccountAddress]
+func (aa AccountAddress) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(RandomAccountAddress(r))
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"bufio"
+	"encoding/hex"
+	"io"
+)
+
+// hexPrefix is the prefix used for all hex strings in Aptos
+const hexPrefix = "0x"
+
+// AppendHex appends the 0x prefixed hex encoding of src to dst, in a single allocation
+func AppendHex(dst []byte, src []byte) []byte {
+	start := len(dst)
+	size := len(hexPrefix) + hex.EncodedLen(len(src))
+	if cap(dst)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, dst)
+		dst = grown
+	}
+	dst = dst[:start+size]
+	copy(dst[start:], hexPrefix)
+	hex.Encode(dst[start+len(hexPrefix):], src)
+	return dst
+}
+
+// DecodeHexBytes decodes hex bytes with or without a leading 0x, without converting to a string first
+func DecodeHexBytes(src []byte) ([]byte, error) {
+	if len(src) >= 2 && src[0] == '0' && src[1] == 'x' {
+		src = src[2:]
+	}
+	out := make([]byte, hex.DecodedLen(len(src)))
+	n, err := hex.Decode(out, src)
+	if err != nil {
+		return nil, err
+	}
+	return out[:n], nil
+}
+
+// NewHexDecoder returns a reader that decodes hex from r, with or without a leading 0x
+func NewHexDecoder(r io.Reader) io.Reader {
+	buffered := bufio.NewReader(r)
+	prefix, err := buffered.Peek(len(hexPrefix))
+	if err == nil && string(prefix) == hexPrefix {
+		_, _ = buffered.Discard(len(hexPrefix))
+	}
+	return hex.NewDecoder(buffered)
+}
+
+// EncodeHex streams the 0x prefixed hex encoding of r to w, returning the number of bytes read from r
+func EncodeHex(w io.Writer, r io.Reader) (int64, error) {
+	_, err := io.WriteString(w, hexPrefix)
+	if err != nil {
+		return 0, err
+	}
+	return io.Copy(hex.NewEncoder(w), r)
+}
+
+// DecodeHex streams the decoded bytes of hex from r, with or without a leading 0x, to w, returning the number of bytes
+// written to w
+func DecodeHex(w io.Writer, r io.Reader) (int64, error) {
+	return io.Copy(w, NewHexDecoder(r))
+}
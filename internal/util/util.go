@@ -32,7 +32,7 @@ func ParseHex(hexStr string) ([]byte, error) {
 
 // BytesToHex converts a byte slice to a hex string with a leading 0x
 func BytesToHex(bytes []byte) string {
-	return "0x" + hex.EncodeToString(bytes)
+	return string(AppendHex(nil, bytes))
 }
 
 // StrToUint64 converts a string to a uint64
@@ -0,0 +1,249 @@
+//go:build go1.23
+
+package aptos
+
+import (
+	"iter"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// IteratorPageSize is the number of items fetched per request by the paginated iterators e.g. [Client.TransactionsIter]
+const IteratorPageSize = uint64(100)
+
+// pagesIter yields the items of each page fetched by next, which returns the items and the start of the next page.  It
+// stops at the first page shorter than [IteratorPageSize], at the first error, or when the caller breaks.
+func pagesIter[T any](start uint64, next func(start uint64, limit uint64) ([]T, uint64, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			items, nextStart, err := next(start, IteratorPageSize)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if uint64(len(items)) < IteratorPageSize {
+				return
+			}
+			start = nextStart
+		}
+	}
+}
+
+// TransactionsIter iterates over committed transactions in version order, starting at the version, until the end of the
+// ledger.  Pages are fetched as the loop advances, and breaking stops fetching.
+//
+//	for txn, err := range client.TransactionsIter(start) {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func (rc *NodeClient) TransactionsIter(start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return pagesIter(start, func(start uint64, limit uint64) ([]*api.CommittedTransaction, uint64, error) {
+		txns, err := rc.transactionsInner(&start, &limit)
+		if err != nil || len(txns) == 0 {
+			return nil, 0, err
+		}
+		return txns, txns[len(txns)-1].Version() + 1, nil
+	})
+}
+
+// BlocksIter iterates over blocks in height order, starting at the height, until the latest block when the iteration
+// reaches it.  Each block is fetched as the loop advances, with all its transactions if withTransactions is true, and
+// breaking stops fetching.
+//
+//	for block, err := range client.BlocksIter(lastScanned+1, true) {
+//		if err != nil {
+//			return err
+//		}
+//		lastScanned = block.BlockHeight
+//	}
+func (rc *NodeClient) BlocksIter(startHeight uint64, withTransactions bool) iter.Seq2[*api.Block, error] {
+	return func(yield func(*api.Block, error) bool) {
+		height := startHeight
+		for {
+			info, err := rc.Info()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			latest := info.BlockHeight()
+			if height > latest {
+				return
+			}
+			for ; height <= latest; height++ {
+				block, err := rc.BlockByHeight(height, withTransactions)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if !yield(block, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// All adapts the iterator to a range loop, yielding the error that stops the iteration, if any, last
+//
+//	for txn, err := range client.TransactionRange(start, end).All() {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func (it *TransactionRangeIterator) All() iter.Seq2[*api.CommittedTransaction, error] {
+	return func(yield func(*api.CommittedTransaction, error) bool) {
+		for it.Next() {
+			if !yield(it.Transaction(), nil) {
+				return
+			}
+		}
+		if it.Err() != nil {
+			yield(nil, it.Err())
+		}
+	}
+}
+
+// AccountTransactionsIter iterates over the committed transactions sent by an account in order, starting at the sequence
+// number.  Pages are fetched as the loop advances, and breaking stops fetching.
+func (rc *NodeClient) AccountTransactionsIter(account AccountAddress, startSequenceNumber uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return pagesIter(startSequenceNumber, func(start uint64, limit uint64) ([]*api.CommittedTransaction, uint64, error) {
+		txns, err := rc.accountTransactionsInner(account, &start, &limit)
+		if err != nil || len(txns) == 0 {
+			return nil, 0, err
+		}
+		// It will always be a UserTransaction, no other type will come from the API
+		userTxn, err := txns[len(txns)-1].UserTransaction()
+		if err != nil {
+			return nil, 0, err
+		}
+		return txns, userTxn.SequenceNumber + 1, nil
+	})
+}
+
+// EventsByHandleIter iterates over the events of an event handle in sequence number order, starting at the sequence
+// number.  Pages are fetched as the loop advances, and breaking stops fetching.
+func (rc *NodeClient) EventsByHandleIter(account AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error] {
+	return pagesIter(start, func(start uint64, limit uint64) ([]*api.Event, uint64, error) {
+		events, err := rc.EventsByHandle(account, eventHandle, fieldName, &start, &limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		return events, start + uint64(len(events)), nil
+	})
+}
+
+// EventsByCreationNumberIter iterates over the events of an event handle, by the creation number of its GUID, in
+// sequence number order, starting at the sequence number.  Pages are fetched as the loop advances, and breaking stops
+// fetching.
+func (rc *NodeClient) EventsByCreationNumberIter(account AccountAddress, creationNumber uint64, start uint64) iter.Seq2[*api.Event, error] {
+	return pagesIter(start, func(start uint64, limit uint64) ([]*api.Event, uint64, error) {
+		events, err := rc.EventsByCreationNumber(account, creationNumber, &start, &limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		return events, start + uint64(len(events)), nil
+	})
+}
+
+// AccountResourcesIter iterates over the resources of an account.  The node returns all resources in one request.
+func (rc *NodeClient) AccountResourcesIter(address AccountAddress, ledgerVersion ...uint64) iter.Seq2[AccountResourceInfo, error] {
+	return func(yield func(AccountResourceInfo, error) bool) {
+		resources, err := rc.AccountResources(address, ledgerVersion...)
+		if err != nil {
+			yield(AccountResourceInfo{}, err)
+			return
+		}
+		for _, resource := range resources {
+			if !yield(resource, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CoinBalancesIter iterates over the coin balances of an address from the indexer.  Pages are fetched as the loop
+// advances, and breaking stops fetching.
+func (ic *IndexerClient) CoinBalancesIter(address AccountAddress) iter.Seq2[CoinBalance, error] {
+	return pagesIter(0, func(offset uint64, limit uint64) ([]CoinBalance, uint64, error) {
+		var q struct {
+			CurrentCoinBalances []struct {
+				CoinType string `graphql:"coin_type"`
+				Amount   uint64
+			} `graphql:"current_coin_balances(where: {owner_address: {_eq: $address}}, order_by: {coin_type: asc}, limit: $limit, offset: $offset)"`
+		}
+		variables := map[string]any{
+			"address": address.StringLong(),
+			"limit":   int(limit),
+			"offset":  int(offset),
+		}
+		err := ic.Query(&q, variables)
+		if err != nil {
+			return nil, 0, err
+		}
+		balances := make([]CoinBalance, len(q.CurrentCoinBalances))
+		for i, coin := range q.CurrentCoinBalances {
+			balances[i] = CoinBalance{CoinType: coin.CoinType, Amount: coin.Amount}
+		}
+		return balances, offset + uint64(len(balances)), nil
+	})
+}
+
+// TransactionsIter iterates over committed transactions in version order, starting at the version, until the end of the
+// ledger.  Pages are fetched as the loop advances, and breaking stops fetching.
+//
+//	for txn, err := range client.TransactionsIter(start) {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func (client *Client) TransactionsIter(start uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return client.nodeClient.TransactionsIter(start)
+}
+
+// BlocksIter iterates over blocks in height order, starting at the height, until the latest block when the iteration
+// reaches it.  Each block is fetched as the loop advances, with all its transactions if withTransactions is true, and
+// breaking stops fetching.
+func (client *Client) BlocksIter(startHeight uint64, withTransactions bool) iter.Seq2[*api.Block, error] {
+	return client.nodeClient.BlocksIter(startHeight, withTransactions)
+}
+
+// AccountTransactionsIter iterates over the committed transactions sent by an account in order, starting at the sequence
+// number.  Pages are fetched as the loop advances, and breaking stops fetching.
+func (client *Client) AccountTransactionsIter(account AccountAddress, startSequenceNumber uint64) iter.Seq2[*api.CommittedTransaction, error] {
+	return client.nodeClient.AccountTransactionsIter(account, startSequenceNumber)
+}
+
+// EventsByHandleIter iterates over the events of an event handle in sequence number order, starting at the sequence
+// number.  Pages are fetched as the loop advances, and breaking stops fetching.
+func (client *Client) EventsByHandleIter(account AccountAddress, eventHandle string, fieldName string, start uint64) iter.Seq2[*api.Event, error] {
+	return client.nodeClient.EventsByHandleIter(account, eventHandle, fieldName, start)
+}
+
+// EventsByCreationNumberIter iterates over the events of an event handle, by the creation number of its GUID, in
+// sequence number order, starting at the sequence number.  Pages are fetched as the loop advances, and breaking stops
+// fetching.
+func (client *Client) EventsByCreationNumberIter(account AccountAddress, creationNumber uint64, start uint64) iter.Seq2[*api.Event, error] {
+	return client.nodeClient.EventsByCreationNumberIter(account, creationNumber, start)
+}
+
+// AccountResourcesIter iterates over the resources of an account.  The node returns all resources in one request.
+func (client *Client) AccountResourcesIter(address AccountAddress, ledgerVersion ...uint64) iter.Seq2[AccountResourceInfo, error] {
+	return client.nodeClient.AccountResourcesIter(address, ledgerVersion...)
+}
+
+// CoinBalancesIter iterates over the coin balances of an address from the indexer, or yields [ErrNoIndexer]
+func (client *Client) CoinBalancesIter(address AccountAddress) iter.Seq2[CoinBalance, error] {
+	if client.indexerClient == nil {
+		return func(yield func(CoinBalance, error) bool) {
+			yield(CoinBalance{}, ErrNoIndexer)
+		}
+	}
+	return client.indexerClient.CoinBalancesIter(address)
+}
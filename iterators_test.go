@@ -0,0 +1,174 @@
+//go:build go1.23
+
+package aptos
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Iterators(t *testing.T) {
+	const numTxns = 250
+	const numEvents = 120
+	requests := atomic.Int32{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		limit, _ := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+		var items []string
+		switch r.URL.Path {
+		case "/transactions":
+			for version := start; version < min(start+limit, numTxns); version++ {
+				items = append(items, fmt.Sprintf(`{"type": "state_checkpoint_transaction", "version": "%d", "hash": "0x1", "state_change_hash": "0x1", "event_root_hash": "0x1", "accumulator_root_hash": "0x1", "gas_used": "0", "success": true, "vm_status": "Executed successfully", "changes": [], "timestamp": "1"}`, version))
+			}
+		case "/accounts/0x1/events/0x2/transfer":
+			for sequenceNumber := start; sequenceNumber < min(start+limit, numEvents); sequenceNumber++ {
+				items = append(items, fmt.Sprintf(`{"type": "0x1::coin::WithdrawEvent", "guid": {"creation_number": "2", "account_address": "0x1"}, "sequence_number": "%d", "data": {}}`, sequenceNumber))
+			}
+		case "/accounts/0x1/resources":
+			items = append(items, `{"type": "0x1::account::Account", "data": {}}`, `{"type": "`+AptosCoinStoreResourceType+`", "data": {}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	// All pages until the end of the ledger
+	versions := make([]uint64, 0)
+	for txn, err := range client.TransactionsIter(10) {
+		assert.NoError(t, err)
+		versions = append(versions, txn.Version())
+	}
+	assert.Len(t, versions, numTxns-10)
+	assert.Equal(t, uint64(10), versions[0])
+	assert.Equal(t, uint64(numTxns-1), versions[len(versions)-1])
+	assert.Equal(t, int32(3), requests.Load())
+
+	// Breaking stops fetching
+	requests.Store(0)
+	count := 0
+	for _, err := range client.TransactionsIter(0) {
+		assert.NoError(t, err)
+		count++
+		if count == 5 {
+			break
+		}
+	}
+	assert.Equal(t, 5, count)
+	assert.Equal(t, int32(1), requests.Load())
+
+	sequenceNumbers := make([]uint64, 0)
+	for event, err := range client.EventsByHandleIter(AccountOne, "0x2", "transfer", 0) {
+		assert.NoError(t, err)
+		sequenceNumbers = append(sequenceNumbers, event.SequenceNumber)
+	}
+	assert.Len(t, sequenceNumbers, numEvents)
+	assert.Equal(t, uint64(numEvents-1), sequenceNumbers[numEvents-1])
+
+	types := make([]string, 0)
+	for resource, err := range client.AccountResourcesIter(AccountOne) {
+		assert.NoError(t, err)
+		types = append(types, resource.Type)
+	}
+	assert.Equal(t, []string{AccountResourceType, AptosCoinStoreResourceType}, types)
+
+	// Errors are yielded once, and end the iteration
+	errs := 0
+	for _, err := range client.AccountTransactionsIter(AccountOne, 0) {
+		assert.Error(t, err)
+		errs++
+	}
+	assert.Equal(t, 1, errs)
+
+	for _, err := range client.CoinBalancesIter(AccountOne) {
+		assert.ErrorIs(t, err, ErrNoIndexer)
+	}
+}
+
+func TestClient_BlocksIter(t *testing.T) {
+	latest := atomic.Uint64{}
+	latest.Store(3)
+	testTxn := func(version uint64) string {
+		return fmt.Sprintf(`{"type": "state_checkpoint_transaction", "version": "%d", "hash": "0x1", "state_change_hash": "0x1", "event_root_hash": "0x1", "accumulator_root_hash": "0x1", "gas_used": "0", "success": true, "vm_status": "Executed successfully", "changes": [], "timestamp": "1"}`, version)
+	}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "" || r.URL.Path == "/":
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"chain_id": 4, "block_height": "%d"}`, latest.Load())))
+		case strings.HasPrefix(r.URL.Path, "/blocks/by_height/"):
+			height, _ := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/blocks/by_height/"), 10, 64)
+			// Blocks have 3 transactions, and the node only returns the first
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"block_height": "%d", "block_hash": "0x1", "block_timestamp": "1", "first_version": "%d", "last_version": "%d", "transactions": [%s]}`, height, height*10, height*10+2, testTxn(height*10))))
+			// The chain advances while iterating
+			if height == 3 {
+				latest.Store(4)
+			}
+		case r.URL.Path == "/transactions":
+			start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+			limit, _ := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+			items := make([]string, 0)
+			for version := start; version < start+limit; version++ {
+				items = append(items, testTxn(version))
+			}
+			_, _ = w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	heights := make([]uint64, 0)
+	for block, err := range client.BlocksIter(2, true) {
+		assert.NoError(t, err)
+		heights = append(heights, block.BlockHeight)
+		versions := make([]uint64, 0)
+		for _, txn := range block.Transactions {
+			versions = append(versions, txn.Version())
+		}
+		assert.Equal(t, []uint64{block.FirstVersion, block.FirstVersion + 1, block.FirstVersion + 2}, versions)
+	}
+	assert.Equal(t, []uint64{2, 3, 4}, heights)
+}
+
+func TestTransactionRangeIterator_All(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		if start >= 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message": "bad start", "error_code": "invalid_input"}`))
+			return
+		}
+		_, _ = w.Write([]byte(fmt.Sprintf(`[{"type": "state_checkpoint_transaction", "version": "%d", "hash": "0x1", "state_change_hash": "0x1", "event_root_hash": "0x1", "accumulator_root_hash": "0x1", "gas_used": "0", "success": true, "vm_status": "Executed successfully", "changes": [], "timestamp": "1"}]`, start)))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	versions := make([]uint64, 0)
+	var iterErr error
+	for txn, err := range client.TransactionRange(0, 10).All() {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		versions = append(versions, txn.Version())
+	}
+	assert.Equal(t, []uint64{0, 1, 2}, versions)
+	assert.ErrorContains(t, iterErr, "bad start")
+}
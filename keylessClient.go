@@ -0,0 +1,253 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrNoKeylessService is returned when the pepper or prover URL is not configured for the network
+var ErrNoKeylessService = errors.New("keyless service url not configured")
+
+// KeylessRequestSigner is called on every pepper and prover request before it is sent, e.g. to add authentication
+// headers or a request signature for a self-hosted prover.  Returning an error aborts the request.
+//
+//	signer := KeylessRequestSigner(func(request *http.Request) error {
+//		request.Header.Set("Authorization", "Bearer "+token)
+//		return nil
+//	})
+type KeylessRequestSigner func(request *http.Request) error
+
+// KeylessRetry configures retries of pepper and prover requests.  Only network errors, 429 and 5xx responses are
+// retried.  The backoff doubles after each attempt.
+type KeylessRetry struct {
+	Attempts int           // Attempts is the total number of attempts, values below 1 are treated as 1
+	Backoff  time.Duration // Backoff is the wait before the first retry
+}
+
+// DefaultKeylessRetry is the retry policy used when none is given to [NewKeylessClient]
+var DefaultKeylessRetry = KeylessRetry{Attempts: 3, Backoff: 250 * time.Millisecond}
+
+// KeylessPepperRequest is the request body for the pepper service's fetch endpoint
+type KeylessPepperRequest struct {
+	JwtB64         string   `json:"jwt_b64"`                   // JwtB64 is the encoded JWT from the OIDC provider
+	Epk            string   `json:"epk"`                       // Epk is the hex BCS encoded ephemeral public key
+	ExpDateSecs    uint64   `json:"exp_date_secs"`             // ExpDateSecs is the expiry of the ephemeral key pair
+	EpkBlinder     string   `json:"epk_blinder"`               // EpkBlinder is the hex blinder used in the nonce
+	UidKey         string   `json:"uid_key,omitempty"`         // UidKey is the JWT claim to use, defaults to "sub"
+	DerivationPath []string `json:"derivation_path,omitempty"` // DerivationPath optionally derives a child pepper
+}
+
+// KeylessPepperResponse is the response from the pepper service
+type KeylessPepperResponse struct {
+	Pepper  string `json:"pepper"`  // Pepper is the hex encoded pepper
+	Address string `json:"address"` // Address is the account address derived from the pepper
+}
+
+// KeylessProofRequest is the request body for the prover service's prove endpoint
+type KeylessProofRequest struct {
+	JwtB64         string `json:"jwt_b64"`               // JwtB64 is the encoded JWT from the OIDC provider
+	Epk            string `json:"epk"`                   // Epk is the hex BCS encoded ephemeral public key
+	EpkBlinder     string `json:"epk_blinder"`           // EpkBlinder is the hex blinder used in the nonce
+	ExpDateSecs    uint64 `json:"exp_date_secs"`         // ExpDateSecs is the expiry of the ephemeral key pair
+	ExpHorizonSecs uint64 `json:"exp_horizon_secs"`      // ExpHorizonSecs is the maximum lifetime of the ephemeral key
+	Pepper         string `json:"pepper"`                // Pepper is the hex encoded pepper from the pepper service
+	UidKey         string `json:"uid_key,omitempty"`     // UidKey is the JWT claim to use, defaults to "sub"
+	ExtraField     string `json:"extra_field,omitempty"` // ExtraField is an optional JWT field to reveal
+}
+
+// KeylessProofResponse is the response from the prover service.  The proof is kept as raw JSON, so it can be passed
+// through to the signer unchanged.
+type KeylessProofResponse struct {
+	Proof                   json.RawMessage `json:"proof"`                     // Proof is the Groth16 proof
+	PublicInputsHash        string          `json:"public_inputs_hash"`        // PublicInputsHash is the hash the proof is over
+	TrainingWheelsSignature string          `json:"training_wheels_signature"` // TrainingWheelsSignature is the prover's signature
+}
+
+// KeylessClient talks to the keyless pepper and prover services for a network.  Enterprises running their own services
+// can point it at them with [NetworkConfig.PepperUrl] and [NetworkConfig.ProverUrl].
+//
+// Proofs are cached by request until the ephemeral key expires, as generating them is slow and they're reused for
+// every transaction signed with the same ephemeral key.
+//
+//	client, err := NewKeylessClient(config, KeylessRequestSigner(signer), KeylessRetry{Attempts: 5, Backoff: time.Second})
+//	pepper, err := client.FetchPepper(ctx, pepperRequest)
+//	proof, err := client.Prove(ctx, proofRequest)
+type KeylessClient struct {
+	pepperUrl *url.URL // pepperUrl is nil if there's no pepper service
+	proverUrl *url.URL // proverUrl is nil if there's no prover service
+	client    *http.Client
+	signer    KeylessRequestSigner
+	retry     KeylessRetry
+
+	proofsMutex sync.Mutex
+	proofs      map[string]keylessCachedProof
+}
+
+// keylessCachedProof is a proof with the time after which it can't be used
+type keylessCachedProof struct {
+	proof   *KeylessProofResponse
+	expires time.Time
+}
+
+// NewKeylessClient creates a client for the pepper and prover URLs in the config.  Options are a *http.Client,
+// a [KeylessRequestSigner], and a [KeylessRetry].
+func NewKeylessClient(config NetworkConfig, options ...any) (*KeylessClient, error) {
+	client := &KeylessClient{
+		retry:  DefaultKeylessRetry,
+		proofs: make(map[string]keylessCachedProof),
+	}
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case *http.Client:
+			client.client = value
+		case KeylessRequestSigner:
+			client.signer = value
+		case KeylessRetry:
+			client.retry = value
+		default:
+			return nil, fmt.Errorf("NewKeylessClient arg %d bad type %T", i+1, arg)
+		}
+	}
+	if client.client == nil {
+		client.client = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	var err error
+	if config.PepperUrl != "" {
+		client.pepperUrl, err = url.Parse(config.PepperUrl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pepper url '%s': %w", config.PepperUrl, err)
+		}
+	}
+	if config.ProverUrl != "" {
+		client.proverUrl, err = url.Parse(config.ProverUrl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse prover url '%s': %w", config.ProverUrl, err)
+		}
+	}
+	return client, nil
+}
+
+// FetchPepper fetches the pepper, and the derived address, for a JWT
+func (kc *KeylessClient) FetchPepper(ctx context.Context, request KeylessPepperRequest) (*KeylessPepperResponse, error) {
+	if kc.pepperUrl == nil {
+		return nil, fmt.Errorf("pepper: %w", ErrNoKeylessService)
+	}
+	response := &KeylessPepperResponse{}
+	err := kc.post(ctx, kc.pepperUrl.JoinPath("fetch"), request, response)
+	if err != nil {
+		return nil, fmt.Errorf("pepper: %w", err)
+	}
+	return response, nil
+}
+
+// Prove requests a zero-knowledge proof for a JWT and ephemeral key.  The proof is cached until ExpDateSecs, so calling
+// again with the same request doesn't go back to the prover.
+func (kc *KeylessClient) Prove(ctx context.Context, request KeylessProofRequest) (*KeylessProofResponse, error) {
+	if kc.proverUrl == nil {
+		return nil, fmt.Errorf("prover: %w", ErrNoKeylessService)
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	keyBytes := sha256.Sum256(body)
+	key := hex.EncodeToString(keyBytes[:])
+
+	kc.proofsMutex.Lock()
+	cached, ok := kc.proofs[key]
+	if ok && time.Now().After(cached.expires) {
+		delete(kc.proofs, key)
+		ok = false
+	}
+	kc.proofsMutex.Unlock()
+	if ok {
+		return cached.proof, nil
+	}
+
+	response := &KeylessProofResponse{}
+	err = kc.post(ctx, kc.proverUrl.JoinPath("prove"), request, response)
+	if err != nil {
+		return nil, fmt.Errorf("prover: %w", err)
+	}
+
+	kc.proofsMutex.Lock()
+	kc.proofs[key] = keylessCachedProof{proof: response, expires: time.Unix(int64(request.ExpDateSecs), 0)}
+	kc.proofsMutex.Unlock()
+	return response, nil
+}
+
+// ClearProofCache removes all cached proofs, e.g. after the user logs out
+func (kc *KeylessClient) ClearProofCache() {
+	kc.proofsMutex.Lock()
+	defer kc.proofsMutex.Unlock()
+	kc.proofs = make(map[string]keylessCachedProof)
+}
+
+// post sends the JSON body to the URL, retrying on transient failures, and decodes the JSON response
+func (kc *KeylessClient) post(ctx context.Context, target *url.URL, body any, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	attempts := max(kc.retry.Attempts, 1)
+	backoff := kc.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		var retryable bool
+		retryable, err = kc.postOnce(ctx, target, data, out)
+		if err == nil || !retryable || attempt >= attempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// postOnce makes a single request, returning whether a failure can be retried
+func (kc *KeylessClient) postOnce(ctx context.Context, target *url.URL, data []byte, out any) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(data))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if kc.signer != nil {
+		err = kc.signer(request)
+		if err != nil {
+			return false, fmt.Errorf("failed to sign request: %w", err)
+		}
+	}
+
+	response, err := kc.client.Do(request)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	if response.StatusCode >= 400 {
+		httpErr := NewHttpError(response)
+		retryable := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+		return retryable, httpErr
+	}
+	blob, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return true, err
+	}
+	err = json.Unmarshal(blob, out)
+	if err != nil {
+		return false, fmt.Errorf("response decode error: %w", err)
+	}
+	return false, nil
+}
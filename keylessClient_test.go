@@ -0,0 +1,110 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeylessClient(t *testing.T) {
+	var proveCalls, fetchCalls atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/pepper/fetch":
+			// Fail the first time, to check it's retried
+			if fetchCalls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			request := KeylessPepperRequest{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "jwt", request.JwtB64)
+			_, _ = w.Write([]byte(`{"pepper":"0x01","address":"0x2"}`))
+		case "/prover/prove":
+			proveCalls.Add(1)
+			_, _ = w.Write([]byte(`{"proof":{"a":"0x1"},"public_inputs_hash":"5","training_wheels_signature":"0x3"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer mockServer.Close()
+
+	config := NetworkConfig{PepperUrl: mockServer.URL + "/pepper", ProverUrl: mockServer.URL + "/prover"}
+	signer := KeylessRequestSigner(func(request *http.Request) error {
+		request.Header.Set("Authorization", "Bearer token")
+		return nil
+	})
+	client, err := NewKeylessClient(config, signer, KeylessRetry{Attempts: 2, Backoff: time.Millisecond})
+	assert.NoError(t, err)
+
+	pepper, err := client.FetchPepper(context.Background(), KeylessPepperRequest{JwtB64: "jwt"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x01", pepper.Pepper)
+	assert.Equal(t, int32(2), fetchCalls.Load())
+
+	// The second proof comes from the cache
+	request := KeylessProofRequest{JwtB64: "jwt", ExpDateSecs: uint64(time.Now().Add(time.Hour).Unix())}
+	proof, err := client.Prove(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, "5", proof.PublicInputsHash)
+	assert.JSONEq(t, `{"a":"0x1"}`, string(proof.Proof))
+	_, err = client.Prove(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), proveCalls.Load())
+
+	// Expired proofs aren't reused
+	expired := KeylessProofRequest{JwtB64: "jwt", ExpDateSecs: 1}
+	_, err = client.Prove(context.Background(), expired)
+	assert.NoError(t, err)
+	_, err = client.Prove(context.Background(), expired)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), proveCalls.Load())
+
+	client.ClearProofCache()
+	_, err = client.Prove(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), proveCalls.Load())
+}
+
+func TestKeylessClient_Errors(t *testing.T) {
+	var calls atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer mockServer.Close()
+
+	// Client errors aren't retried
+	client, err := NewKeylessClient(NetworkConfig{PepperUrl: mockServer.URL}, KeylessRetry{Attempts: 3})
+	assert.NoError(t, err)
+	_, err = client.FetchPepper(context.Background(), KeylessPepperRequest{})
+	httpErr := &HttpError{}
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+
+	_, err = client.Prove(context.Background(), KeylessProofRequest{})
+	assert.ErrorIs(t, err, ErrNoKeylessService)
+
+	// Signer errors abort the request
+	failing := KeylessRequestSigner(func(request *http.Request) error { return errors.New("no key") })
+	client, err = NewKeylessClient(NetworkConfig{PepperUrl: mockServer.URL}, failing)
+	assert.NoError(t, err)
+	_, err = client.FetchPepper(context.Background(), KeylessPepperRequest{})
+	assert.ErrorContains(t, err, "no key")
+	assert.Equal(t, int32(1), calls.Load())
+
+	_, err = NewKeylessClient(NetworkConfig{}, "bad")
+	assert.Error(t, err)
+}
@@ -0,0 +1,274 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// Phases of [Client.BuildSimulateSubmitAndWait]
+const (
+	PhaseBuild    = "build"
+	PhaseSimulate = "simulate"
+	PhaseSubmit   = "submit"
+	PhaseWait     = "wait"
+)
+
+// BudgetPhase is one step of a composite operation, with its share of the deadline
+type BudgetPhase struct {
+	Name   string  // Name of the phase, reported in [PhaseTimeoutError]
+	Weight float64 // Weight is the share of the remaining time given to the phase, relative to the phases after it
+}
+
+// BudgetPhases is an option to [Client.BuildSimulateSubmitAndWait] to change how the deadline is split
+type BudgetPhases []BudgetPhase
+
+// DefaultBudgetPhases splits the deadline of [Client.BuildSimulateSubmitAndWait], most of it goes to waiting for commit
+var DefaultBudgetPhases = BudgetPhases{
+	{Name: PhaseBuild, Weight: 1},
+	{Name: PhaseSimulate, Weight: 1},
+	{Name: PhaseSubmit, Weight: 1},
+	{Name: PhaseWait, Weight: 5},
+}
+
+// PhaseTiming is the time budgeted and spent on one phase of a [LatencyBudget]
+type PhaseTiming struct {
+	Phase   string        // Phase is the name of the phase
+	Budget  time.Duration // Budget is the time the phase was given, 0 if the context had no deadline
+	Elapsed time.Duration // Elapsed is the time the phase took
+}
+
+// String formats the timing as e.g. "submit 1.2s/1s"
+func (timing PhaseTiming) String() string {
+	if timing.Budget == 0 {
+		return fmt.Sprintf("%s %s", timing.Phase, timing.Elapsed.Round(time.Millisecond))
+	}
+	return fmt.Sprintf("%s %s/%s", timing.Phase, timing.Elapsed.Round(time.Millisecond), timing.Budget.Round(time.Millisecond))
+}
+
+// PhaseTimeoutError is returned by [LatencyBudget.Run] when a phase runs out of its share of the deadline, or the
+// deadline has already passed before the phase starts.
+type PhaseTimeoutError struct {
+	Phase   string        // Phase is the name of the phase that timed out
+	Timings []PhaseTiming // Timings of every phase run so far, including the one that timed out
+	Err     error         // Err is the error returned by the phase
+}
+
+// Error formats the phase and the timings of every phase
+func (e *PhaseTimeoutError) Error() string {
+	timings := make([]string, len(e.Timings))
+	for i, timing := range e.Timings {
+		timings[i] = timing.String()
+	}
+	return fmt.Sprintf("phase %s exceeded its latency budget (%s): %v", e.Phase, strings.Join(timings, ", "), e.Err)
+}
+
+// Unwrap returns the error returned by the phase
+func (e *PhaseTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// LatencyBudget divides the deadline of a context across the phases of a composite operation, so one slow phase fails
+// fast instead of using up the time of the phases after it.  Time a phase doesn't use is passed on to the next phases.
+//
+// Phases must be run in order, but may be skipped.  If the context has no deadline, phases aren't limited, and only
+// timed.  A LatencyBudget is not safe for concurrent use.
+//
+//	budget := NewLatencyBudget(ctx, BudgetPhase{Name: "fetch", Weight: 1}, BudgetPhase{Name: "store", Weight: 2})
+//	err := budget.Run("fetch", func(ctx context.Context) error {
+//		info, err = client.WithContext(ctx).Account(address)
+//		return err
+//	})
+type LatencyBudget struct {
+	ctx     context.Context
+	phases  []BudgetPhase
+	next    int
+	timings []PhaseTiming
+}
+
+// NewLatencyBudget creates a [LatencyBudget] for the deadline of ctx
+func NewLatencyBudget(ctx context.Context, phases ...BudgetPhase) *LatencyBudget {
+	return &LatencyBudget{
+		ctx:    ctx,
+		phases: phases,
+	}
+}
+
+// Timings returns the timings of every phase run so far
+func (budget *LatencyBudget) Timings() []PhaseTiming {
+	return append([]PhaseTiming(nil), budget.timings...)
+}
+
+// Run runs the phase with a context limited to its share of the remaining time.  Errors from a phase that ran out of
+// time are wrapped in a [PhaseTimeoutError], other errors are returned as is.
+func (budget *LatencyBudget) Run(phase string, run func(ctx context.Context) error) error {
+	index := -1
+	for i := budget.next; i < len(budget.phases); i++ {
+		if budget.phases[i].Name == phase {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("latency budget has no phase %s after %d phases run", phase, budget.next)
+	}
+
+	totalWeight := 0.0
+	for _, remaining := range budget.phases[index:] {
+		totalWeight += remaining.Weight
+	}
+	budget.next = index + 1
+
+	ctx := budget.ctx
+	timing := PhaseTiming{Phase: phase}
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := time.Until(deadline)
+		if remaining <= 0 || ctx.Err() != nil {
+			budget.timings = append(budget.timings, timing)
+			return &PhaseTimeoutError{Phase: phase, Timings: budget.Timings(), Err: context.DeadlineExceeded}
+		}
+		timing.Budget = remaining
+		if totalWeight > 0 {
+			timing.Budget = time.Duration(float64(remaining) * budget.phases[index].Weight / totalWeight)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timing.Budget)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := run(ctx)
+	timing.Elapsed = time.Since(start)
+	budget.timings = append(budget.timings, timing)
+	if err != nil && budgetExceeded(ctx) {
+		return &PhaseTimeoutError{Phase: phase, Timings: budget.Timings(), Err: err}
+	}
+	return err
+}
+
+// budgetExceeded checks if the phase is out of time.  Polling may time out on its own clock just before the context
+// does, so the deadline is checked as well.
+func budgetExceeded(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && !time.Now().Before(deadline)
+}
+
+// BuildSimulateSubmitAndWait builds a transaction, checks it succeeds in simulation, then submits and waits for it to
+// commit.  The deadline of ctx is split across the phases with a [LatencyBudget], and if a phase runs out of time a
+// [PhaseTimeoutError] reports where the time went.  Without a deadline, it behaves as if each phase were called
+// separately.
+//
+// Accepts options:
+//   - [MaxGasAmount]
+//   - [GasUnitPrice]
+//   - [GasPriority]
+//   - [ExpirationSeconds]
+//   - [SequenceNumber]
+//   - [ChainIdOption]
+//   - [PollPeriod]
+//   - [PollBackoff]
+//   - [MaxPollPeriod]
+//   - [LongPoll]
+//   - [ErrorOnFailure]
+//   - [BudgetPhases]
+func (client *Client) BuildSimulateSubmitAndWait(ctx context.Context, sender TransactionSigner, payload TransactionPayload, options ...any) (data *api.UserTransaction, err error) {
+	return client.nodeClient.BuildSimulateSubmitAndWait(ctx, sender, payload, options...)
+}
+
+// BuildSimulateSubmitAndWait builds a transaction, checks it succeeds in simulation, then submits and waits for it to
+// commit.  The deadline of ctx is split across the phases with a [LatencyBudget], and if a phase runs out of time a
+// [PhaseTimeoutError] reports where the time went.
+//
+// Accepts options:
+//   - [MaxGasAmount]
+//   - [GasUnitPrice]
+//   - [GasPriority]
+//   - [ExpirationSeconds]
+//   - [SequenceNumber]
+//   - [ChainIdOption]
+//   - [PollPeriod]
+//   - [PollBackoff]
+//   - [MaxPollPeriod]
+//   - [LongPoll]
+//   - [ErrorOnFailure]
+//   - [BudgetPhases]
+func (rc *NodeClient) BuildSimulateSubmitAndWait(ctx context.Context, sender TransactionSigner, payload TransactionPayload, options ...any) (data *api.UserTransaction, err error) {
+	phases := DefaultBudgetPhases
+	buildOptions := make([]any, 0, len(options))
+	pollOptions := make([]any, 0, 2)
+	for _, option := range options {
+		switch value := option.(type) {
+		case BudgetPhases:
+			phases = value
+		case PollPeriod, PollBackoff, MaxPollPeriod, LongPoll, ErrorOnFailure:
+			pollOptions = append(pollOptions, value)
+		case PollTimeout:
+			return nil, errors.New("BuildSimulateSubmitAndWait waits until the deadline of the context, PollTimeout is not allowed")
+		default:
+			buildOptions = append(buildOptions, option)
+		}
+	}
+	budget := NewLatencyBudget(ctx, phases...)
+
+	var rawTxn *RawTransaction
+	err = budget.Run(PhaseBuild, func(ctx context.Context) (err error) {
+		rawTxn, err = rc.WithContext(ctx).BuildTransaction(sender.AccountAddress(), payload, buildOptions...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = budget.Run(PhaseSimulate, func(ctx context.Context) error {
+		simulated, err := rc.WithContext(ctx).SimulateTransaction(rawTxn, sender)
+		if err != nil {
+			return err
+		}
+		if len(simulated) == 0 {
+			return errors.New("simulation returned no transactions")
+		}
+		if !simulated[0].Success {
+			return fmt.Errorf("simulation failed: %s", simulated[0].VmStatus)
+		}
+		if rc.simulationGate != nil {
+			return rc.simulationGate.check(simulated)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var submitResponse *api.SubmitTransactionResponse
+	err = budget.Run(PhaseSubmit, func(ctx context.Context) error {
+		signedTxn, err := rawTxn.SignedTransaction(sender)
+		if err != nil {
+			return err
+		}
+		// Already simulated above, so skip the simulation gate
+		submitResponse, err = rc.WithContext(ctx).WithSimulationGate(nil).SubmitTransaction(signedTxn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = budget.Run(PhaseWait, func(ctx context.Context) (err error) {
+		if deadline, ok := ctx.Deadline(); ok {
+			pollOptions = append(pollOptions, PollTimeout(time.Until(deadline)))
+		}
+		data, err = rc.WithContext(ctx).WaitForTransaction(submitResponse.Hash, pollOptions...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,139 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyBudget(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+	budget := NewLatencyBudget(ctx, BudgetPhase{Name: "a", Weight: 1}, BudgetPhase{Name: "b", Weight: 1}, BudgetPhase{Name: "c", Weight: 2})
+
+	// Half of the time is given to c, the rest is split between a and b
+	err := budget.Run("a", func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.InDelta(t, 100*time.Millisecond, time.Until(deadline), float64(20*time.Millisecond))
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// Time a doesn't use goes to the rest, and errors that aren't timeouts are returned as is
+	phaseErr := errors.New("phase failed")
+	err = budget.Run("b", func(ctx context.Context) error {
+		deadline, _ := ctx.Deadline()
+		assert.InDelta(t, 133*time.Millisecond, time.Until(deadline), float64(20*time.Millisecond))
+		return phaseErr
+	})
+	assert.Same(t, phaseErr, err)
+
+	// Phases can't be run out of order
+	err = budget.Run("a", func(ctx context.Context) error { return nil })
+	assert.Error(t, err)
+
+	// A slow phase fails with the timings of each phase
+	err = budget.Run("c", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	timeoutErr := &PhaseTimeoutError{}
+	assert.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "c", timeoutErr.Phase)
+	assert.Len(t, timeoutErr.Timings, 3)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "phase c exceeded its latency budget (a ")
+
+	// With no time left, the phase isn't run
+	budget = NewLatencyBudget(ctx, BudgetPhase{Name: "a", Weight: 1})
+	err = budget.Run("a", func(ctx context.Context) error {
+		t.Fatal("phase should not run")
+		return nil
+	})
+	assert.ErrorAs(t, err, &timeoutErr)
+}
+
+func TestLatencyBudget_NoDeadline(t *testing.T) {
+	budget := NewLatencyBudget(context.Background(), BudgetPhase{Name: "a", Weight: 1}, BudgetPhase{Name: "b", Weight: 1})
+
+	// Skipping a phase is fine
+	err := budget.Run("b", func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, budget.Timings(), 1)
+	assert.Equal(t, time.Duration(0), budget.Timings()[0].Budget)
+}
+
+func TestBuildSimulateSubmitAndWait(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+
+	slowSimulate := false
+	release := make(chan struct{})
+	submitted := 0
+	userTxn := fmt.Sprintf(`{"type": "user_transaction", "version": "10", "hash": "0x1234", "sender": "%s", "sequence_number": "0", "max_gas_amount": "100", "gas_unit_price": "100", "expiration_timestamp_secs": "1", "gas_used": "10", "success": true, "vm_status": "Executed successfully", "changes": [], "events": [], "timestamp": "1"}`, sender.Address.String())
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/estimate_gas_price":
+			json.NewEncoder(w).Encode(map[string]any{"gas_estimate": 100})
+		case strings.HasPrefix(r.URL.Path, "/accounts/"):
+			json.NewEncoder(w).Encode(map[string]any{"sequence_number": "0", "authentication_key": "0x0"})
+		case r.URL.Path == "/transactions/simulate":
+			if slowSimulate {
+				<-release
+				return
+			}
+			w.Write([]byte("[" + userTxn + "]"))
+		case r.URL.Path == "/transactions":
+			submitted++
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(map[string]any{"hash": "0x1234", "sender": sender.Address.String(), "sequence_number": "0"})
+		case strings.HasPrefix(r.URL.Path, "/transactions/wait_by_hash/"):
+			w.Write([]byte(userTxn))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	defer close(release)
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	txn, err := client.BuildSimulateSubmitAndWait(ctx, sender, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), txn.Version)
+	assert.Equal(t, 1, submitted)
+
+	// A slow simulation fails fast, without submitting or using the whole deadline
+	slowSimulate = true
+	ctx, cancel = context.WithTimeout(context.Background(), 800*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.BuildSimulateSubmitAndWait(ctx, sender, TransactionPayload{Payload: payload})
+	timeoutErr := &PhaseTimeoutError{}
+	assert.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, PhaseSimulate, timeoutErr.Phase)
+	assert.Len(t, timeoutErr.Timings, 2)
+	assert.Less(t, time.Since(start), 400*time.Millisecond)
+	assert.Equal(t, 1, submitted)
+
+	_, err = client.BuildSimulateSubmitAndWait(ctx, sender, TransactionPayload{Payload: payload}, PollTimeout(time.Second))
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package aptos
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Response headers describing the ledger state a node API answer was served at
+const (
+	HeaderAptosChainId             = "X-Aptos-Chain-Id"              // HeaderAptosChainId is the chain id of the network
+	HeaderAptosEpoch               = "X-Aptos-Epoch"                 // HeaderAptosEpoch is the epoch of the ledger
+	HeaderAptosLedgerVersion       = "X-Aptos-Ledger-Version"        // HeaderAptosLedgerVersion is the ledger version the response was read at
+	HeaderAptosLedgerOldestVersion = "X-Aptos-Ledger-Oldest-Version" // HeaderAptosLedgerOldestVersion is the oldest ledger version not pruned on the node
+	HeaderAptosLedgerTimestamp     = "X-Aptos-Ledger-TimestampUsec"  // HeaderAptosLedgerTimestamp is the ledger timestamp in microseconds
+	HeaderAptosBlockHeight         = "X-Aptos-Block-Height"          // HeaderAptosBlockHeight is the newest block height on the node
+	HeaderAptosOldestBlockHeight   = "X-Aptos-Oldest-Block-Height"   // HeaderAptosOldestBlockHeight is the oldest block height not pruned on the node
+)
+
+// LedgerInfo is the ledger state a single node API response was served at, taken from the response headers.
+//
+// Unlike [NodeInfo], it doesn't need a separate call.  Reads have WithLedgerInfo variants, e.g.
+// [Client.AccountWithLedgerInfo], which return it with each answer, and [GetWithLedgerInfo] covers any other endpoint.
+//
+//	balance, ledger, err := client.AccountAPTBalanceWithLedgerInfo(address)
+//	// balance is as of ledger.LedgerVersion
+type LedgerInfo struct {
+	ChainId             uint8  // ChainId is the chain id of the network
+	Epoch               uint64 // Epoch is the epoch of the ledger
+	LedgerVersion       uint64 // LedgerVersion is the ledger version the response was read at
+	OldestLedgerVersion uint64 // OldestLedgerVersion is the oldest ledger version not pruned on the node
+	LedgerTimestampUsec uint64 // LedgerTimestampUsec is the ledger timestamp in microseconds
+	BlockHeight         uint64 // BlockHeight is the newest block height on the node
+	OldestBlockHeight   uint64 // OldestBlockHeight is the oldest block height not pruned on the node
+}
+
+// LedgerInfoFromHeaders parses the ledger state from node API response headers.  ok is false if the headers don't have
+// a ledger version, e.g. the response didn't come from a node.
+func LedgerInfoFromHeaders(header http.Header) (info LedgerInfo, ok bool) {
+	info.LedgerVersion, ok = parseLedgerHeader(header, HeaderAptosLedgerVersion)
+	if !ok {
+		return LedgerInfo{}, false
+	}
+	chainId, _ := parseLedgerHeader(header, HeaderAptosChainId)
+	info.ChainId = uint8(chainId)
+	info.Epoch, _ = parseLedgerHeader(header, HeaderAptosEpoch)
+	info.OldestLedgerVersion, _ = parseLedgerHeader(header, HeaderAptosLedgerOldestVersion)
+	info.LedgerTimestampUsec, _ = parseLedgerHeader(header, HeaderAptosLedgerTimestamp)
+	info.BlockHeight, _ = parseLedgerHeader(header, HeaderAptosBlockHeight)
+	info.OldestBlockHeight, _ = parseLedgerHeader(header, HeaderAptosOldestBlockHeight)
+	return info, true
+}
+
+// LedgerTimestamp is the ledger timestamp as a [time.Time]
+func (info LedgerInfo) LedgerTimestamp() time.Time {
+	return time.UnixMicro(int64(info.LedgerTimestampUsec))
+}
+
+func parseLedgerHeader(header http.Header, key string) (uint64, bool) {
+	value := header.Get(key)
+	if value == "" {
+		return 0, false
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return parsed, true
+}
@@ -0,0 +1,141 @@
+package aptos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerInfoFromHeaders(t *testing.T) {
+	header := http.Header{}
+	_, ok := LedgerInfoFromHeaders(header)
+	assert.False(t, ok)
+
+	header.Set(HeaderAptosChainId, "4")
+	header.Set(HeaderAptosEpoch, "12")
+	header.Set(HeaderAptosLedgerVersion, "1000")
+	header.Set(HeaderAptosLedgerOldestVersion, "10")
+	header.Set(HeaderAptosLedgerTimestamp, "1700000000123456")
+	header.Set(HeaderAptosBlockHeight, "500")
+	header.Set(HeaderAptosOldestBlockHeight, "5")
+	info, ok := LedgerInfoFromHeaders(header)
+	assert.True(t, ok)
+	assert.Equal(t, LedgerInfo{
+		ChainId:             4,
+		Epoch:               12,
+		LedgerVersion:       1000,
+		OldestLedgerVersion: 10,
+		LedgerTimestampUsec: 1700000000123456,
+		BlockHeight:         500,
+		OldestBlockHeight:   5,
+	}, info)
+	assert.Equal(t, int64(1700000000123456), info.LedgerTimestamp().UnixMicro())
+}
+
+func TestClient_ReadsWithLedgerInfo(t *testing.T) {
+	version := atomic.Uint64{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderAptosChainId, "4")
+		w.Header().Set(HeaderAptosLedgerVersion, strconv.FormatUint(version.Add(1), 10))
+		switch r.URL.Path {
+		case "/accounts/0x1":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"sequence_number": "7", "authentication_key": "0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+		case "/view":
+			_, _ = w.Write([]byte(`["100"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found", "error_code": "account_not_found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	account, ledger, err := client.AccountWithLedgerInfo(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", account.SequenceNumberStr)
+	assert.Equal(t, uint8(4), ledger.ChainId)
+	assert.Equal(t, uint64(1), ledger.LedgerVersion)
+
+	// Errors from the node still report the ledger state
+	_, ledger, err = client.AccountWithLedgerInfo(AccountTwo)
+	assert.Error(t, err)
+	assert.Equal(t, uint64(2), ledger.LedgerVersion)
+
+	balance, ledger, err := client.AccountAPTBalanceWithLedgerInfo(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), balance)
+	assert.Equal(t, uint64(3), ledger.LedgerVersion)
+
+	// Each answer has its own ledger state, even for concurrent calls
+	seen := make(chan uint64, 10)
+	wg := sync.WaitGroup{}
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, ledger, err := client.AccountWithLedgerInfo(AccountOne)
+			assert.NoError(t, err)
+			seen <- ledger.LedgerVersion
+		}()
+	}
+	wg.Wait()
+	close(seen)
+	versions := make(map[uint64]bool)
+	for v := range seen {
+		versions[v] = true
+	}
+	assert.Len(t, versions, 10)
+}
+
+func TestClient_AtLedgerVersion(t *testing.T) {
+	versions := make(chan string, 10)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versions <- r.URL.Query().Get("ledger_version")
+		switch r.URL.Path {
+		case "/accounts/0x1":
+			_, _ = w.Write([]byte(`{"sequence_number": "7", "authentication_key": "0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+		case "/view":
+			_, _ = w.Write([]byte(`["100"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found", "error_code": "web_framework_error"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+	pinned := client.AtLedgerVersion(1000)
+
+	_, err = pinned.Account(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", <-versions)
+
+	balance, err := pinned.AccountAPTBalance(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), balance)
+	assert.Equal(t, "1000", <-versions)
+
+	// A call's own ledger version wins
+	_, err = pinned.Account(AccountOne, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "5", <-versions)
+
+	// Transactions are built with the latest sequence number
+	_, err = pinned.BuildTransaction(AccountOne, TransactionPayload{Payload: &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "m"}, Function: "f"}}, GasUnitPrice(100), MaxGasAmount(1000))
+	assert.NoError(t, err)
+	assert.Equal(t, "", <-versions)
+
+	// The original client isn't pinned
+	_, err = client.Account(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, "", <-versions)
+}
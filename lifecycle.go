@@ -0,0 +1,132 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrAlreadyStarted is returned when a background component is started twice
+var ErrAlreadyStarted = errors.New("already started")
+
+// ErrNotStarted is returned when a background component is used before it is started
+var ErrNotStarted = errors.New("not started")
+
+// ErrClosed is returned when a background component is used after it is closed
+var ErrClosed = errors.New("closed")
+
+// Lifecycle is the shared lifecycle of background components, e.g. [TransactionWorker] and [EventSubscription], so a
+// service can start and stop them all the same way.
+//
+//   - Start begins work in the background, tied to ctx.  Cancelling ctx is a hard stop.
+//   - Close stops taking new work, waits for in-flight work to drain, and returns any error from running.  It is safe
+//     to call more than once, and before Start.
+//   - Done is closed once the component has fully stopped.
+//
+// A service shutting down would call Close on each component, rather than cancelling their context, so in-flight
+// transactions aren't lost.
+//
+//	err := worker.Start(ctx)
+//	...
+//	<-shutdown
+//	err = worker.Close()
+type Lifecycle interface {
+	Start(ctx context.Context) error
+	Close() error
+	Done() <-chan struct{}
+}
+
+// lifecycle is the state shared by [Lifecycle] implementations.  The mutex guards started and closed, and is held for
+// reading while work is handed to a running component, so closing can't race with it.
+type lifecycle struct {
+	mutex   sync.RWMutex
+	started bool
+	closed  bool
+
+	doneInit  sync.Once
+	doneClose sync.Once
+	done      chan struct{}
+	err       error // err is the error the component stopped with, set before done is closed
+}
+
+// start marks the component started and calls run while holding the lock, erroring if it was already started or closed
+func (l *lifecycle) start(run func()) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	switch {
+	case l.closed:
+		return ErrClosed
+	case l.started:
+		return ErrAlreadyStarted
+	}
+	l.started = true
+	run()
+	return nil
+}
+
+// close marks the component closed, calling stop while holding the lock if it was running.  Later calls do nothing.
+func (l *lifecycle) close(stop func()) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.closed {
+		return
+	}
+	l.closed = true
+	if !l.started {
+		// Never started, so there's nothing to drain
+		l.finish(nil)
+		return
+	}
+	stop()
+}
+
+// running checks the component can take work, the read lock must be held
+func (l *lifecycle) running() error {
+	switch {
+	case l.closed:
+		return ErrClosed
+	case !l.started:
+		return ErrNotStarted
+	}
+	return nil
+}
+
+// finish records the error the component stopped with, and closes the done channel
+func (l *lifecycle) finish(err error) {
+	l.doneClose.Do(func() {
+		l.err = err
+		close(l.doneChan())
+	})
+}
+
+// doneChan lazily creates the done channel
+func (l *lifecycle) doneChan() chan struct{} {
+	l.doneInit.Do(func() {
+		l.done = make(chan struct{})
+	})
+	return l.done
+}
+
+// wait waits for the component to stop, and returns its error
+func (l *lifecycle) wait() error {
+	<-l.doneChan()
+	return l.err
+}
+
+// withoutCanceled drops [context.Canceled] from the error a closed component stopped with, keeping any errors joined
+// with it, e.g. from a final save
+func withoutCanceled(err error) error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var rest []error
+		for _, e := range joined.Unwrap() {
+			if !errors.Is(e, context.Canceled) {
+				rest = append(rest, e)
+			}
+		}
+		return errors.Join(rest...)
+	}
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
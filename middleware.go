@@ -0,0 +1,41 @@
+package aptos
+
+import (
+	"net/http"
+)
+
+// RequestHandler sends a request and returns its response, the rest of a [Middleware] chain
+type RequestHandler func(request *http.Request) (*http.Response, error)
+
+// Middleware intercepts every request of a client e.g. for logging, metrics, signing, or headers.  It calls next to
+// send the request on, and may change the request or response, or answer without calling next.  Pass them to
+// [NewClient], where the first is the outermost.  Each retry of a [RetryPolicy] goes through the chain again.
+//
+// As with an [http.RoundTripper], the request must not be modified, clone it to change it.
+//
+//	logging := Middleware(func(request *http.Request, next RequestHandler) (*http.Response, error) {
+//		start := time.Now()
+//		response, err := next(request)
+//		slog.Info("aptos request", "method", request.Method, "url", request.URL, "duration", time.Since(start))
+//		return response, err
+//	})
+//	client, err := NewClient(MainnetConfig, logging)
+type Middleware func(request *http.Request, next RequestHandler) (*http.Response, error)
+
+// Transport wraps an [http.RoundTripper] with the middleware, [http.DefaultTransport] if inner is nil
+func (middleware Middleware) Transport(inner http.RoundTripper) http.RoundTripper {
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	return &middlewareTransport{inner: inner, middleware: middleware}
+}
+
+// middlewareTransport runs a [Middleware] before the inner transport
+type middlewareTransport struct {
+	inner      http.RoundTripper
+	middleware Middleware
+}
+
+func (t *middlewareTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return t.middleware(request, t.inner.RoundTrip)
+}
@@ -0,0 +1,62 @@
+package aptos
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	requests := atomic.Int32{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "signed", r.Header.Get("X-Signature"))
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"sequence_number": "7", "authentication_key": "0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+	}))
+	defer mockServer.Close()
+
+	calls := make([]string, 0)
+	outer := Middleware(func(request *http.Request, next RequestHandler) (*http.Response, error) {
+		calls = append(calls, "outer")
+		return next(request)
+	})
+	signing := Middleware(func(request *http.Request, next RequestHandler) (*http.Response, error) {
+		calls = append(calls, "signing")
+		request = request.Clone(request.Context())
+		request.Header.Set("X-Signature", "signed")
+		return next(request)
+	})
+	retry := RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4}, outer, signing, retry)
+	assert.NoError(t, err)
+
+	account, err := client.Account(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", account.SequenceNumberStr)
+	// Each attempt goes through the chain, in order
+	assert.Equal(t, []string{"outer", "signing", "outer", "signing"}, calls)
+
+	// A middleware can answer without sending the request
+	cached := Middleware(func(request *http.Request, next RequestHandler) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"sequence_number": "8", "authentication_key": "0x1"}`)),
+			Request:    request,
+		}, nil
+	})
+	client, err = NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4}, cached)
+	assert.NoError(t, err)
+	account, err = client.Account(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, "8", account.SequenceNumberStr)
+	assert.Equal(t, int32(2), requests.Load())
+}
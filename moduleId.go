@@ -19,3 +19,8 @@ func (mod *ModuleId) UnmarshalBCS(des *bcs.Deserializer) {
 	mod.Address.UnmarshalBCS(des)
 	mod.Name = des.ReadString()
 }
+
+// String is the module's identifier, with the address in its standard form e.g. 0x1::coin
+func (mod *ModuleId) String() string {
+	return mod.Address.String() + "::" + mod.Name
+}
@@ -1,6 +1,13 @@
 package aptos
 
-import "github.com/aptos-labs/aptos-go-sdk/bcs"
+import (
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// multisigAccountModule is the 0x1::multisig_account module
+var multisigAccountModule = ModuleId{Address: AccountOne, Name: "multisig_account"}
 
 // FetchNextMultisigAddress retrieves the next multisig address to be created from the given account
 func (client *Client) FetchNextMultisigAddress(address AccountAddress) (*AccountAddress, error) {
@@ -143,6 +150,31 @@ func MultisigRejectPayload(multisigAddress AccountAddress, transactionId uint64)
 	return multisigTransactionWithTransactionIdCommon("reject_transaction", multisigAddress, transactionId)
 }
 
+// MultisigExecuteRejectedPayload generates a payload for removing the next transaction of the multisig, once it has
+// enough rejections.  The caller must be an owner of the multisig
+func MultisigExecuteRejectedPayload(multisigAddress AccountAddress) *EntryFunction {
+	return multisigTransactionCommon("execute_rejected_transaction", multisigAddress, [][]byte{})
+}
+
+// MultisigExecutePayload builds the [Multisig] transaction payload to execute the next transaction of the multisig,
+// once it has enough approvals.  The entry function may be nil if the whole payload was proposed with
+// [MultisigCreateTransactionPayload], but must be given if only its hash was proposed.
+func MultisigExecutePayload(multisigAddress AccountAddress, payload *EntryFunction) *Multisig {
+	execute := &Multisig{MultisigAddress: multisigAddress}
+	if payload != nil {
+		execute.Payload = multisigEntryFunction(payload)
+	}
+	return execute
+}
+
+// multisigEntryFunction wraps an entry function as a [MultisigTransactionPayload]
+func multisigEntryFunction(payload *EntryFunction) *MultisigTransactionPayload {
+	return &MultisigTransactionPayload{
+		Variant: MultisigTransactionPayloadVariantEntryFunction,
+		Payload: payload,
+	}
+}
+
 // multisigTransactionWithTransactionIdCommon is a helper for functions that take TransactionId
 func multisigTransactionWithTransactionIdCommon(functionName string, multisigAddress AccountAddress, transactionId uint64) (*EntryFunction, error) {
 	transactionIdBytes, err := bcs.SerializeU64(transactionId)
@@ -174,3 +206,191 @@ func multisigOwnerPayloadCommon(functionName string, owner AccountAddress) *Entr
 		Args:     [][]byte{owner[:]},
 	}
 }
+
+// MultisigClient manages 0x1::multisig_account on-chain multisigs: creating them, proposing, voting on, and executing
+// their transactions, and querying their state.  Each transaction method takes the same options as
+// [Client.BuildTransaction].
+//
+//	multisigClient := NewMultisigClient(client)
+//	signedTxn, err := multisigClient.Propose(owner1, multisig, payload)
+//	// ... submit, then a second owner approves the next sequence number
+//	signedTxn, err = multisigClient.Approve(owner2, multisig, sequenceNumber)
+//	canExecute, err := multisigClient.CanExecute(multisig, sequenceNumber)
+//	signedTxn, err = multisigClient.Execute(owner1, multisig, nil)
+type MultisigClient struct {
+	aptosClient *Client
+}
+
+// NewMultisigClient creates a client for 0x1::multisig_account multisigs
+func NewMultisigClient(client *Client) *MultisigClient {
+	return &MultisigClient{client}
+}
+
+// NextAddress returns the address of the next multisig the owner creates, see [Client.FetchNextMultisigAddress]
+func (client *MultisigClient) NextAddress(owner AccountAddress) (*AccountAddress, error) {
+	return client.aptosClient.FetchNextMultisigAddress(owner)
+}
+
+// Create creates a multisig owned by the sender and additionalOwners, requiring requiredSigners approvals for each
+// transaction.  Its address is [MultisigClient.NextAddress] of the sender, read before submitting.
+func (client *MultisigClient) Create(owner TransactionSigner, additionalOwners []AccountAddress, requiredSigners uint64, options ...any) (*SignedTransaction, error) {
+	// No metadata, an empty vector<vector<u8>> of values
+	payload, err := MultisigCreateAccountPayload(requiredSigners, additionalOwners, []string{}, []byte{0})
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(owner, payload, options)
+}
+
+// Propose proposes the entry function as the next transaction of the multisig, storing it on-chain.  The proposer
+// approves it.
+func (client *MultisigClient) Propose(owner TransactionSigner, multisigAddress AccountAddress, payload *EntryFunction, options ...any) (*SignedTransaction, error) {
+	proposal, err := MultisigCreateTransactionPayload(multisigAddress, multisigEntryFunction(payload))
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(owner, proposal, options)
+}
+
+// ProposeHash proposes the entry function as the next transaction of the multisig, storing only its hash on-chain, for
+// large payloads.  The same entry function must be passed to [MultisigClient.Execute].
+func (client *MultisigClient) ProposeHash(owner TransactionSigner, multisigAddress AccountAddress, payload *EntryFunction, options ...any) (*SignedTransaction, error) {
+	proposal, err := MultisigCreateTransactionPayloadWithHash(multisigAddress, multisigEntryFunction(payload))
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(owner, proposal, options)
+}
+
+// Approve approves the multisig transaction with the sequence number
+func (client *MultisigClient) Approve(owner TransactionSigner, multisigAddress AccountAddress, sequenceNumber uint64, options ...any) (*SignedTransaction, error) {
+	payload, err := MultisigApprovePayload(multisigAddress, sequenceNumber)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(owner, payload, options)
+}
+
+// Reject rejects the multisig transaction with the sequence number
+func (client *MultisigClient) Reject(owner TransactionSigner, multisigAddress AccountAddress, sequenceNumber uint64, options ...any) (*SignedTransaction, error) {
+	payload, err := MultisigRejectPayload(multisigAddress, sequenceNumber)
+	if err != nil {
+		return nil, err
+	}
+	return client.sign(owner, payload, options)
+}
+
+// Execute executes the next transaction of the multisig, see [MultisigExecutePayload] for when payload may be nil
+func (client *MultisigClient) Execute(owner TransactionSigner, multisigAddress AccountAddress, payload *EntryFunction, options ...any) (*SignedTransaction, error) {
+	return client.sign(owner, MultisigExecutePayload(multisigAddress, payload), options)
+}
+
+// ExecuteRejected removes the next transaction of the multisig, once it has enough rejections
+func (client *MultisigClient) ExecuteRejected(owner TransactionSigner, multisigAddress AccountAddress, options ...any) (*SignedTransaction, error) {
+	return client.sign(owner, MultisigExecuteRejectedPayload(multisigAddress), options)
+}
+
+// Owners returns the owners of the multisig
+func (client *MultisigClient) Owners(multisigAddress AccountAddress, ledgerVersion ...uint64) ([]AccountAddress, error) {
+	vals, err := client.view("owners", [][]byte{multisigAddress[:]}, ledgerVersion)
+	if err != nil {
+		return nil, err
+	}
+	owners, ok := vals[0].([]any)
+	if !ok {
+		return nil, fmt.Errorf("bad view return from node, owners is %T", vals[0])
+	}
+	addresses := make([]AccountAddress, len(owners))
+	for i, owner := range owners {
+		str, ok := owner.(string)
+		if !ok {
+			return nil, fmt.Errorf("bad view return from node, owner is %T", owner)
+		}
+		err = addresses[i].ParseStringRelaxed(str)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return addresses, nil
+}
+
+// SignaturesRequired returns the number of approvals or rejections a transaction of the multisig needs
+func (client *MultisigClient) SignaturesRequired(multisigAddress AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	return client.viewU64("num_signatures_required", [][]byte{multisigAddress[:]}, ledgerVersion)
+}
+
+// NextSequenceNumber returns the sequence number the next proposed transaction of the multisig gets
+func (client *MultisigClient) NextSequenceNumber(multisigAddress AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	return client.viewU64("next_sequence_number", [][]byte{multisigAddress[:]}, ledgerVersion)
+}
+
+// LastResolvedSequenceNumber returns the sequence number of the last executed or rejected transaction of the multisig
+func (client *MultisigClient) LastResolvedSequenceNumber(multisigAddress AccountAddress, ledgerVersion ...uint64) (uint64, error) {
+	return client.viewU64("last_resolved_sequence_number", [][]byte{multisigAddress[:]}, ledgerVersion)
+}
+
+// CanExecute tells if the multisig transaction with the sequence number is next, and has enough approvals to execute
+func (client *MultisigClient) CanExecute(multisigAddress AccountAddress, sequenceNumber uint64, ledgerVersion ...uint64) (bool, error) {
+	return client.viewSequenceNumberBool("can_be_executed", multisigAddress, sequenceNumber, ledgerVersion)
+}
+
+// CanReject tells if the multisig transaction with the sequence number is next, and has enough rejections to remove
+func (client *MultisigClient) CanReject(multisigAddress AccountAddress, sequenceNumber uint64, ledgerVersion ...uint64) (bool, error) {
+	return client.viewSequenceNumberBool("can_be_rejected", multisigAddress, sequenceNumber, ledgerVersion)
+}
+
+// viewSequenceNumberBool calls a view function of a multisig transaction returning a bool
+func (client *MultisigClient) viewSequenceNumberBool(function string, multisigAddress AccountAddress, sequenceNumber uint64, ledgerVersion []uint64) (bool, error) {
+	sequenceNumberBytes, err := bcs.SerializeU64(sequenceNumber)
+	if err != nil {
+		return false, err
+	}
+	vals, err := client.view(function, [][]byte{multisigAddress[:], sequenceNumberBytes}, ledgerVersion)
+	if err != nil {
+		return false, err
+	}
+	result, ok := vals[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("bad view return from node, %s is %T", function, vals[0])
+	}
+	return result, nil
+}
+
+// viewU64 calls a view function returning a u64
+func (client *MultisigClient) viewU64(function string, args [][]byte, ledgerVersion []uint64) (uint64, error) {
+	vals, err := client.view(function, args, ledgerVersion)
+	if err != nil {
+		return 0, err
+	}
+	amounts, err := viewU64s(vals)
+	if err != nil {
+		return 0, err
+	}
+	return amounts[0], nil
+}
+
+// view calls a multisig_account view function returning one value
+func (client *MultisigClient) view(function string, args [][]byte, ledgerVersion []uint64) ([]any, error) {
+	vals, err := client.aptosClient.View(&ViewPayload{
+		Module:   multisigAccountModule,
+		Function: function,
+		ArgTypes: []TypeTag{},
+		Args:     args,
+	}, ledgerVersion...)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) != 1 {
+		return nil, fmt.Errorf("bad view return from node, %s returned %d values, expected 1", function, len(vals))
+	}
+	return vals, nil
+}
+
+// sign builds and signs a transaction of the payload
+func (client *MultisigClient) sign(sender TransactionSigner, payload TransactionPayloadImpl, options []any) (*SignedTransaction, error) {
+	rawTxn, err := client.aptosClient.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return rawTxn.SignedTransaction(sender)
+}
@@ -0,0 +1,99 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultisigExecutePayload(t *testing.T) {
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+
+	// The stored payload is executed without repeating it
+	stored := MultisigExecutePayload(AccountThree, nil)
+	assert.Nil(t, stored.Payload)
+
+	execute := MultisigExecutePayload(AccountThree, transfer)
+	payloadBytes, err := bcs.Serialize(&TransactionPayload{Payload: execute})
+	assert.NoError(t, err)
+	payload := &TransactionPayload{}
+	assert.NoError(t, bcs.Deserialize(payload, payloadBytes))
+	multisig, ok := payload.Payload.(*Multisig)
+	assert.True(t, ok)
+	assert.Equal(t, AccountThree, multisig.MultisigAddress)
+	assert.Equal(t, transfer, multisig.Payload.Payload)
+
+	rejected := MultisigExecuteRejectedPayload(AccountThree)
+	assert.Equal(t, "execute_rejected_transaction", rejected.Function)
+	assert.Equal(t, [][]byte{AccountThree[:]}, rejected.Args)
+}
+
+func TestMultisigClient_Transactions(t *testing.T) {
+	client, err := NewClient(LocalnetConfig)
+	assert.NoError(t, err)
+	multisigClient := NewMultisigClient(client)
+	owner, err := NewEd25519Account()
+	assert.NoError(t, err)
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	options := []any{SequenceNumber(1), GasUnitPrice(100), MaxGasAmount(1000), ChainIdOption(4)}
+
+	signedTxn, err := multisigClient.Create(owner, []AccountAddress{AccountTwo}, 2, options...)
+	assert.NoError(t, err)
+	assert.Equal(t, "create_with_owners", signedTxn.Transaction.Payload.Payload.(*EntryFunction).Function)
+
+	signedTxn, err = multisigClient.ProposeHash(owner, AccountThree, transfer, options...)
+	assert.NoError(t, err)
+	assert.Equal(t, "create_transaction_with_hash", signedTxn.Transaction.Payload.Payload.(*EntryFunction).Function)
+
+	signedTxn, err = multisigClient.Execute(owner, AccountThree, transfer, options...)
+	assert.NoError(t, err)
+	assert.Equal(t, transfer, signedTxn.Transaction.Payload.Payload.(*Multisig).Payload.Payload)
+}
+
+func TestMultisigClient_Views(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/view", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		// The function name is in the BCS body
+		switch {
+		case bytes.Contains(body, []byte("owners")):
+			_ = json.NewEncoder(w).Encode([]any{[]any{"0x2", "0x3"}})
+		case bytes.Contains(body, []byte("can_be_executed")):
+			_ = json.NewEncoder(w).Encode([]any{true})
+		case bytes.Contains(body, []byte("can_be_rejected")):
+			_ = json.NewEncoder(w).Encode([]any{false})
+		default:
+			_ = json.NewEncoder(w).Encode([]any{"2"})
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL})
+	assert.NoError(t, err)
+	multisigClient := NewMultisigClient(client)
+
+	owners, err := multisigClient.Owners(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, []AccountAddress{AccountTwo, AccountThree}, owners)
+
+	required, err := multisigClient.SignaturesRequired(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), required)
+
+	canExecute, err := multisigClient.CanExecute(AccountOne, 1)
+	assert.NoError(t, err)
+	assert.True(t, canExecute)
+
+	canReject, err := multisigClient.CanReject(AccountOne, 1)
+	assert.NoError(t, err)
+	assert.False(t, canReject)
+}
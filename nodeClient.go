@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/aptos-labs/aptos-go-sdk/api"
@@ -40,6 +41,13 @@ type NodeClient struct {
 	baseUrl *url.URL          // Base URL of the node e.g. https://fullnode.testnet.aptoslabs.com/v1
 	chainId uint8             // Chain ID of the network e.g. 2 for Testnet
 	headers map[string]string // Headers to be added to every transaction
+
+	moduleAbis *sync.Map       // moduleAbis caches [api.MoveModule] by [ModuleId], refetched when a function is missing as upgrades can add them
+	ctx        context.Context // ctx is used for every request if set, see [NodeClient.WithContext]
+
+	simulationGate *RequireSuccessfulSimulation // simulationGate simulates transactions before submitting them if set, see [NodeClient.WithSimulationGate]
+	gasDefaults    []any                        // gasDefaults are the gas options of every built transaction, overridden by the caller's options, see [ClientGasConfig]
+	ledgerVersion  *uint64                      // ledgerVersion pins state reads to a version if set, see [NodeClient.AtLedgerVersion]
 }
 
 // NewNodeClient creates a new client for interacting with an Aptos node API
@@ -65,13 +73,94 @@ func NewNodeClientWithHttpClient(rpcUrl string, chainId uint8, client *http.Clie
 		return nil, fmt.Errorf("failed to parse RPC url '%s': %w", rpcUrl, err)
 	}
 	return &NodeClient{
-		client:  client,
-		baseUrl: baseUrl,
-		chainId: chainId,
-		headers: make(map[string]string),
+		client:     client,
+		baseUrl:    baseUrl,
+		chainId:    chainId,
+		headers:    make(map[string]string),
+		moduleAbis: &sync.Map{},
 	}, nil
 }
 
+// WithContext returns a client sharing the connection and settings of this one, which sends every request with ctx, so
+// requests are cancelled when ctx is done.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+//	defer cancel()
+//	info, err := client.WithContext(ctx).Account(address)
+func (rc *NodeClient) WithContext(ctx context.Context) *NodeClient {
+	return &NodeClient{
+		client:     rc.client,
+		baseUrl:    rc.baseUrl,
+		chainId:    rc.chainId,
+		headers:    rc.headers,
+		moduleAbis: rc.moduleAbis,
+		ctx:        ctx,
+
+		simulationGate: rc.simulationGate,
+		gasDefaults:    rc.gasDefaults,
+		ledgerVersion:  rc.ledgerVersion,
+	}
+}
+
+// AtLedgerVersion returns a client sharing the connection and settings of this one, which reads state at version
+// unless a call is given its own ledger version.  This covers accounts, resources, modules, tables, and view functions,
+// so several reads see the same state.  Transactions built with it still use the latest sequence number.
+//
+//	pinned := client.AtLedgerVersion(version)
+//	balance, err := pinned.AccountAPTBalance(address)
+//	account, err := pinned.Account(address)
+func (rc *NodeClient) AtLedgerVersion(version uint64) *NodeClient {
+	pinned := *rc
+	pinned.ledgerVersion = &version
+	return &pinned
+}
+
+// setLedgerVersion adds the ledger version to a state read, the given one, or the pinned one if none is given
+func (rc *NodeClient) setLedgerVersion(au *url.URL, ledgerVersion []uint64) {
+	var version uint64
+	switch {
+	case len(ledgerVersion) > 0:
+		version = ledgerVersion[0]
+	case rc.ledgerVersion != nil:
+		version = *rc.ledgerVersion
+	default:
+		return
+	}
+	params := au.Query()
+	params.Set("ledger_version", strconv.FormatUint(version, 10))
+	au.RawQuery = params.Encode()
+}
+
+// context returns the context for requests, see [NodeClient.WithContext]
+func (rc *NodeClient) context() context.Context {
+	if rc.ctx == nil {
+		return context.Background()
+	}
+	return rc.ctx
+}
+
+// withGasDefaults puts the client's gas options before the caller's, so later options of the same type override them.
+// A gas unit price always beats a priority, so a default price is dropped when the caller asks for a priority.
+func (rc *NodeClient) withGasDefaults(options []any) []any {
+	if len(rc.gasDefaults) == 0 {
+		return options
+	}
+	callerPriority := false
+	for _, option := range options {
+		if _, ok := option.(GasPriority); ok {
+			callerPriority = true
+		}
+	}
+	withDefaults := make([]any, 0, len(rc.gasDefaults)+len(options))
+	for _, option := range rc.gasDefaults {
+		if _, ok := option.(GasUnitPrice); ok && callerPriority {
+			continue
+		}
+		withDefaults = append(withDefaults, option)
+	}
+	return append(withDefaults, options...)
+}
+
 // SetTimeout adjusts the HTTP client timeout
 //
 //	client.SetTimeout(5 * time.Millisecond)
@@ -109,64 +198,95 @@ func (rc *NodeClient) Info() (info NodeInfo, err error) {
 //
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
 func (rc *NodeClient) Account(address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
+	info, _, err = rc.AccountWithLedgerInfo(address, ledgerVersion...)
+	return info, err
+}
+
+// AccountWithLedgerInfo is [NodeClient.Account], which also returns the ledger state the account was read at
+func (rc *NodeClient) AccountWithLedgerInfo(address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, ledger LedgerInfo, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String())
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
-	info, err = Get[AccountInfo](rc, au.String())
+	rc.setLedgerVersion(au, ledgerVersion)
+	info, ledger, err = GetWithLedgerInfo[AccountInfo](rc, au.String())
 	if err != nil {
-		return info, fmt.Errorf("get account info api err: %w", err)
+		return info, ledger, fmt.Errorf("get account info api err: %w", err)
 	}
-	return info, nil
+	return info, ledger, nil
 }
 
 // AccountResource fetches a resource for an account into a JSON-like map[string]any.
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
 //
+// Resources in a resource group e.g. 0x1::fungible_asset::Metadata are fetched the same as any other resource.
+//
 // For fetching raw Move structs as BCS, See #AccountResourceBCS
 func (rc *NodeClient) AccountResource(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error) {
+	data, _, err = rc.AccountResourceWithLedgerInfo(address, resourceType, ledgerVersion...)
+	return data, err
+}
+
+// AccountResourceWithLedgerInfo is [NodeClient.AccountResource], which also returns the ledger state the resource was
+// read at
+func (rc *NodeClient) AccountResourceWithLedgerInfo(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, ledger LedgerInfo, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resource", resourceType)
-	// TODO: offer a list of known-good resourceType string constants
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
-	data, err = Get[map[string]any](rc, au.String())
+	rc.setLedgerVersion(au, ledgerVersion)
+	data, ledger, err = GetWithLedgerInfo[map[string]any](rc, au.String())
 	if err != nil {
-		return nil, fmt.Errorf("get resource api err: %w", err)
+		return nil, ledger, fmt.Errorf("get resource api err: %w", err)
 	}
-	return data, nil
+	return data, ledger, nil
 }
 
 // AccountResources fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
 // For fetching raw Move structs as BCS, See #AccountResourcesBCS
 func (rc *NodeClient) AccountResources(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	resources, _, err = rc.AccountResourcesWithLedgerInfo(address, ledgerVersion...)
+	return resources, err
+}
+
+// AccountResourcesWithLedgerInfo is [NodeClient.AccountResources], which also returns the ledger state the resources
+// were read at
+func (rc *NodeClient) AccountResourcesWithLedgerInfo(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, ledger LedgerInfo, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resources")
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
-	resources, err = Get[[]AccountResourceInfo](rc, au.String())
+	rc.setLedgerVersion(au, ledgerVersion)
+	resources, ledger, err = GetWithLedgerInfo[[]AccountResourceInfo](rc, au.String())
 	if err != nil {
-		return nil, fmt.Errorf("get resources api err: %w", err)
+		return nil, ledger, fmt.Errorf("get resources api err: %w", err)
 	}
-	return resources, err
+	return resources, ledger, nil
+}
+
+// AccountResourceBCS fetches a resource for an account as the raw Move struct BCS blob.
+// Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+//
+// Resources in a resource group e.g. 0x1::fungible_asset::Metadata are fetched the same as any other resource, see
+// [ResourceGroupTypes]
+func (rc *NodeClient) AccountResourceBCS(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data []byte, err error) {
+	data, err = rc.getResourceBCS(address, resourceType, ledgerVersion)
+	if !HasErrorCode(err, api.ErrorCodeResourceNotFound) {
+		return data, err
+	}
+	grouped, found, groupErr := rc.findInResourceGroups(address, resourceType, ledgerVersion)
+	if groupErr != nil || !found {
+		return nil, err
+	}
+	return grouped, nil
+}
+
+// getResourceBCS fetches a single resource as BCS
+func (rc *NodeClient) getResourceBCS(address AccountAddress, resourceType string, ledgerVersion []uint64) ([]byte, error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "resource", resourceType)
+	rc.setLedgerVersion(au, ledgerVersion)
+	return rc.GetBCS(au.String())
 }
 
 // AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+//
+// Resource groups e.g. 0x1::object::ObjectGroup are flattened into their member resources, see [ResourceGroupTypes]
 func (rc *NodeClient) AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resources")
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
+	rc.setLedgerVersion(au, ledgerVersion)
 	blob, err := rc.GetBCS(au.String())
 	if err != nil {
 		return nil, err
@@ -175,32 +295,105 @@ func (rc *NodeClient) AccountResourcesBCS(address AccountAddress, ledgerVersion
 	deserializer := bcs.NewDeserializer(blob)
 	// See resource_test.go TestMoveResourceBCS
 	resources = bcs.DeserializeSequence[AccountResourceRecord](deserializer)
-	return
+	if deserializer.Error() != nil {
+		return nil, deserializer.Error()
+	}
+	return FlattenResourceGroups(resources)
 }
 
-// AccountModule
+// AccountModule fetches a module published at an address, with its bytecode and ABI
 func (rc *NodeClient) AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (data *api.MoveBytecode, err error) {
+	data, _, err = rc.AccountModuleWithLedgerInfo(address, moduleName, ledgerVersion...)
+	return data, err
+}
+
+// AccountModuleWithLedgerInfo is [NodeClient.AccountModule], which also returns the ledger state the module was read at
+func (rc *NodeClient) AccountModuleWithLedgerInfo(address AccountAddress, moduleName string, ledgerVersion ...uint64) (data *api.MoveBytecode, ledger LedgerInfo, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "module", moduleName)
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
+	rc.setLedgerVersion(au, ledgerVersion)
+	data, ledger, err = GetWithLedgerInfo[*api.MoveBytecode](rc, au.String())
+	if err != nil {
+		return nil, ledger, fmt.Errorf("get module api err: %w", err)
 	}
-	data, err = Get[*api.MoveBytecode](rc, au.String())
+	return data, ledger, nil
+}
+
+// AccountModules fetches all modules published at an address, with their ABIs
+func (rc *NodeClient) AccountModules(address AccountAddress, ledgerVersion ...uint64) (data []*api.MoveBytecode, err error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "modules")
+	rc.setLedgerVersion(au, ledgerVersion)
+	data, err = Get[[]*api.MoveBytecode](rc, au.String())
 	if err != nil {
-		return nil, fmt.Errorf("get module api err: %w", err)
+		return nil, fmt.Errorf("get modules api err: %w", err)
 	}
 	return data, nil
 }
 
+// EntryFunctionWithArgs builds an [EntryFunction] using the on-chain ABI to convert args, see [EntryFunctionFromAbi]
 func (rc *NodeClient) EntryFunctionWithArgs(moduleAddress AccountAddress, moduleName string, functionName string, typeArgs []any, args []any) (entry *EntryFunction, err error) {
-	// TODO: This should be cached / we should be able to take in an ABI
-	module, err := rc.AccountModule(moduleAddress, moduleName)
+	abi, err := rc.functionModuleAbi(ModuleId{Address: moduleAddress, Name: moduleName}, functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	return EntryFunctionFromAbi(abi, moduleAddress, moduleName, functionName, typeArgs, args)
+}
+
+// EntryFunctionFromABI builds an [EntryFunction] from arguments as they would appear in JSON, using the on-chain ABI
+// to check the number and types of arguments and to encode them.
+//
+// Args may be decoded JSON values (string, float64, [json.Number], bool, []any, nil) or [json.RawMessage], along with
+// anything [EntryFunctionFromAbi] accepts.  Numbers are best passed as strings or [json.Number], as float64 loses
+// precision above 2^53.  Options may be given as nil, the inner value, or the API's {"vec": [...]} form.
+//
+//	entry, err := client.EntryFunctionFromABI(ModuleId{Address: AccountOne, Name: "aptos_account"}, "transfer_coins",
+//		[]any{"0x1::aptos_coin::AptosCoin"}, []any{"0xb0b", "100"})
+func (rc *NodeClient) EntryFunctionFromABI(module ModuleId, function string, typeArgs []any, jsonArgs []any) (entry *EntryFunction, err error) {
+	abi, err := rc.functionModuleAbi(module, function)
 	if err != nil {
 		return nil, err
 	}
 
-	return EntryFunctionFromAbi(module.Abi, moduleAddress, moduleName, functionName, typeArgs, args)
+	args := make([]any, len(jsonArgs))
+	for i, arg := range jsonArgs {
+		args[i], err = decodeJsonArg(arg)
+		if err != nil {
+			return nil, fmt.Errorf("entry function %s argument %d: %w", function, i, err)
+		}
+	}
+	return EntryFunctionFromAbi(abi, module.Address, module.Name, function, typeArgs, args)
+}
+
+// ModuleAbi fetches the ABI of a module, caching it for later calls
+func (rc *NodeClient) ModuleAbi(module ModuleId) (abi *api.MoveModule, err error) {
+	if cached, ok := rc.moduleAbis.Load(module); ok {
+		return cached.(*api.MoveModule), nil
+	}
+	bytecode, err := rc.AccountModule(module.Address, module.Name)
+	if err != nil {
+		return nil, err
+	}
+	if bytecode.Abi == nil {
+		return nil, fmt.Errorf("module %s::%s has no ABI", module.Address.String(), module.Name)
+	}
+	rc.moduleAbis.Store(module, bytecode.Abi)
+	return bytecode.Abi, nil
+}
+
+// functionModuleAbi fetches the ABI of a module like [NodeClient.ModuleAbi], but refetches a cached ABI missing the
+// function, as the module may have been upgraded to add it since
+func (rc *NodeClient) functionModuleAbi(module ModuleId, function string) (abi *api.MoveModule, err error) {
+	abi, err = rc.ModuleAbi(module)
+	if err != nil {
+		return nil, err
+	}
+	for _, fun := range abi.ExposedFunctions {
+		if fun.Name == function {
+			return abi, nil
+		}
+	}
+	rc.moduleAbis.Delete(module)
+	return rc.ModuleAbi(module)
 }
 
 // TransactionByHash gets info on a transaction
@@ -208,24 +401,27 @@ func (rc *NodeClient) EntryFunctionWithArgs(moduleAddress AccountAddress, module
 // still in the mempool.  If the transaction is any other type, it has been committed.
 //
 //	data, err := c.TransactionByHash("0xabcd")
-//	if err != nil {
-//		if httpErr, ok := err.(aptos.HttpError) {
-//			if httpErr.StatusCode == 404 {
-//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-//			}
-//		}
-//	} else {
+//	if aptos.HasErrorCode(err, api.ErrorCodeTransactionNotFound) {
+//		// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
+//	} else if err == nil {
 //		if data["type"] == "pending_transaction" {
 //			// known to local mempool, but not committed yet
 //		}
 //	}
 func (rc *NodeClient) TransactionByHash(txnHash string) (data *api.Transaction, err error) {
+	data, _, err = rc.TransactionByHashWithLedgerInfo(txnHash)
+	return data, err
+}
+
+// TransactionByHashWithLedgerInfo is [NodeClient.TransactionByHash], which also returns the ledger state the
+// transaction was looked up at, e.g. to tell how far behind a pending transaction the node is
+func (rc *NodeClient) TransactionByHashWithLedgerInfo(txnHash string) (data *api.Transaction, ledger LedgerInfo, err error) {
 	restUrl := rc.baseUrl.JoinPath("transactions/by_hash", txnHash)
-	data, err = Get[*api.Transaction](rc, restUrl.String())
+	data, ledger, err = GetWithLedgerInfo[*api.Transaction](rc, restUrl.String())
 	if err != nil {
-		return data, fmt.Errorf("get transaction api err: %w", err)
+		return data, ledger, fmt.Errorf("get transaction api err: %w", err)
 	}
-	return data, nil
+	return data, ledger, nil
 }
 
 // Waits for a transaction to be confirmed by its hash.
@@ -242,12 +438,19 @@ func (rc *NodeClient) WaitTransactionByHash(txnHash string) (data *api.Transacti
 // TransactionByVersion gets info on a transaction by version number
 // The transaction will have been committed.  The response will not be of the type [api.PendingTransaction].
 func (rc *NodeClient) TransactionByVersion(version uint64) (data *api.CommittedTransaction, err error) {
+	data, _, err = rc.TransactionByVersionWithLedgerInfo(version)
+	return data, err
+}
+
+// TransactionByVersionWithLedgerInfo is [NodeClient.TransactionByVersion], which also returns the ledger state the
+// transaction was looked up at
+func (rc *NodeClient) TransactionByVersionWithLedgerInfo(version uint64) (data *api.CommittedTransaction, ledger LedgerInfo, err error) {
 	restUrl := rc.baseUrl.JoinPath("transactions/by_version", strconv.FormatUint(version, 10))
-	data, err = Get[*api.CommittedTransaction](rc, restUrl.String())
+	data, ledger, err = GetWithLedgerInfo[*api.CommittedTransaction](rc, restUrl.String())
 	if err != nil {
-		return data, fmt.Errorf("get transaction api err: %w", err)
+		return data, ledger, fmt.Errorf("get transaction api err: %w", err)
 	}
-	return data, nil
+	return data, ledger, nil
 }
 
 // BlockByVersion gets a block by a transaction's version number
@@ -296,8 +499,8 @@ func (rc *NodeClient) getBlockCommon(restUrl *url.URL, withTransactions bool) (b
 	numTransactions := block.LastVersion - block.FirstVersion + 1
 	retrievedTransactions := uint64(len(block.Transactions))
 
-	// Transaction is always not pending, so it will never be nil
-	cursor := block.Transactions[len(block.Transactions)-1].Version()
+	// The node pages the transactions of large blocks, fetch the rest after the last one returned
+	cursor := block.FirstVersion + retrievedTransactions
 
 	// TODO: I maybe should pull these concurrently, but not for now
 	for retrievedTransactions < numTransactions {
@@ -307,11 +510,14 @@ func (rc *NodeClient) getBlockCommon(restUrl *url.URL, withTransactions bool) (b
 			// We will still return the block, since we did so much work for it
 			return block, innerError
 		}
+		if len(transactions) == 0 {
+			return block, fmt.Errorf("get block api err: no transactions at version %d of block %d", cursor, block.BlockHeight)
+		}
 
 		// Add transactions to the list
 		block.Transactions = append(block.Transactions, transactions...)
 		retrievedTransactions = uint64(len(block.Transactions))
-		cursor = block.Transactions[len(block.Transactions)-1].Version()
+		cursor = block.FirstVersion + retrievedTransactions
 	}
 	return
 }
@@ -321,61 +527,138 @@ func (rc *NodeClient) getBlockCommon(restUrl *url.URL, withTransactions bool) (b
 //
 // Optional arguments:
 //   - PollPeriod: time.Duration, how often to poll for the transaction. Default 100ms.
-//   - PollTimeout: time.Duration, how long to wait for the transaction. Default 10s.
+//   - PollTimeout: time.Duration, how long to wait for the transaction in total. Default 10s.
+//   - PollBackoff: float64, multiplies the poll period after each poll. Default 1, no backoff.
+//   - MaxPollPeriod: time.Duration, caps the poll period with backoff. Default no cap.
+//   - LongPoll: bool, whether to wait on the node's wait_by_hash endpoint before polling. Default true.
+//   - ErrorOnFailure: bool, whether a transaction committed with success false returns [ErrTransactionFailed]. Default false.
 func (rc *NodeClient) WaitForTransaction(txnHash string, options ...any) (data *api.UserTransaction, err error) {
 	return rc.PollForTransaction(txnHash, options...)
 }
 
+// ErrTransactionFailed is returned waiting with [ErrorOnFailure] for a transaction that committed but failed to
+// execute, along with the transaction
+var ErrTransactionFailed = errors.New("transaction failed")
+
 // PollPeriod is an option to PollForTransactions
 type PollPeriod time.Duration
 
 // PollTimeout is an option to PollForTransactions
 type PollTimeout time.Duration
 
-func getTransactionPollOptions(defaultPeriod, defaultTimeout time.Duration, options ...any) (period time.Duration, timeout time.Duration, err error) {
-	period = defaultPeriod
-	timeout = defaultTimeout
+// PollBackoff is an option to PollForTransactions, multiplying the poll period after each poll e.g. 2 doubles it
+type PollBackoff float64
+
+// MaxPollPeriod is an option to PollForTransactions, capping the poll period when backing off with [PollBackoff]
+type MaxPollPeriod time.Duration
+
+// LongPoll is an option to WaitForTransaction, false skips the node's wait_by_hash endpoint and only polls
+type LongPoll bool
+
+// ErrorOnFailure is an option to PollForTransactions, true returns [ErrTransactionFailed] for a transaction that
+// committed with success false
+type ErrorOnFailure bool
+
+// transactionPollOptions are the options of PollForTransaction and PollForTransactions
+type transactionPollOptions struct {
+	period         time.Duration
+	timeout        time.Duration
+	backoff        float64
+	maxPeriod      time.Duration
+	longPoll       bool
+	errorOnFailure bool
+}
+
+func getTransactionPollOptions(defaultPeriod, defaultTimeout time.Duration, options ...any) (pollOptions transactionPollOptions, err error) {
+	pollOptions = transactionPollOptions{period: defaultPeriod, timeout: defaultTimeout, backoff: 1, longPoll: true}
 	for i, arg := range options {
 		switch value := arg.(type) {
 		case PollPeriod:
-			period = time.Duration(value)
+			pollOptions.period = time.Duration(value)
 		case PollTimeout:
-			timeout = time.Duration(value)
+			pollOptions.timeout = time.Duration(value)
+		case PollBackoff:
+			if value < 1 {
+				return pollOptions, fmt.Errorf("PollBackoff must be at least 1, got %v", float64(value))
+			}
+			pollOptions.backoff = float64(value)
+		case MaxPollPeriod:
+			pollOptions.maxPeriod = time.Duration(value)
+		case LongPoll:
+			pollOptions.longPoll = bool(value)
+		case ErrorOnFailure:
+			pollOptions.errorOnFailure = bool(value)
 		default:
 			err = fmt.Errorf("PollForTransactions arg %d bad type %T", i+1, arg)
 			return
 		}
 	}
+	if pollOptions.period <= 0 {
+		return pollOptions, fmt.Errorf("PollPeriod must be positive, got %s", pollOptions.period)
+	}
 	return
 }
 
+// next is the poll period after period, backed off
+func (o transactionPollOptions) next(period time.Duration) time.Duration {
+	period = time.Duration(float64(period) * o.backoff)
+	if o.maxPeriod > 0 && period > o.maxPeriod {
+		period = o.maxPeriod
+	}
+	return period
+}
+
+// committed returns the transaction, or [ErrTransactionFailed] with it if it failed and errorOnFailure is set
+func (o transactionPollOptions) committed(txn *api.Transaction) (*api.UserTransaction, error) {
+	userTxn, err := txn.UserTransaction()
+	if err != nil {
+		return nil, err
+	}
+	if o.errorOnFailure && !userTxn.Success {
+		return userTxn, fmt.Errorf("%w: %s: %s", ErrTransactionFailed, userTxn.Hash, userTxn.VmStatus)
+	}
+	return userTxn, nil
+}
+
 // PollForTransaction waits up to 10 seconds for a transaction to be done, polling at 10Hz
-// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
+// Accepts the options of [NodeClient.WaitForTransaction].
 // Not just a degenerate case of PollForTransactions, it may return additional information for the single transaction polled.
 func (rc *NodeClient) PollForTransaction(hash string, options ...any) (*api.UserTransaction, error) {
-	// Wait for the transaction to be done
-	txn, err := rc.WaitTransactionByHash(hash)
-	if err == nil && txn.Type == api.TransactionVariantUser {
-		return txn.UserTransaction()
-	}
-
-	// Poll for the transaction to be done
-	period, timeout, err := getTransactionPollOptions(100*time.Millisecond, 10*time.Second, options...)
+	pollOptions, err := getTransactionPollOptions(100*time.Millisecond, 10*time.Second, options...)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	parent := rc.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, pollOptions.timeout)
 	defer cancel()
 
-	ticker := time.NewTicker(period)
-	defer ticker.Stop()
+	// Wait for the transaction to be done
+	if pollOptions.longPoll {
+		txn, err := rc.WithContext(ctx).WaitTransactionByHash(hash)
+		if err == nil && txn.Type == api.TransactionVariantUser {
+			return pollOptions.committed(txn)
+		}
+	}
+
+	// Poll for the transaction to be done
+	period := pollOptions.period
+	timer := time.NewTimer(period)
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
+			if parent.Err() != nil {
+				return nil, parent.Err()
+			}
 			return nil, errors.New("PollForTransaction timeout")
-		case <-ticker.C:
+		case <-timer.C:
+			period = pollOptions.next(period)
+			timer.Reset(period)
 			txn, err := rc.TransactionByHash(hash)
 			if err != nil {
 				continue
@@ -387,16 +670,17 @@ func (rc *NodeClient) PollForTransaction(hash string, options ...any) (*api.User
 			case api.TransactionVariantUser:
 				// done!
 				slog.Debug("txn done", "hash", hash)
-				return txn.UserTransaction()
+				return pollOptions.committed(txn)
 			}
 		}
 	}
 }
 
 // PollForTransactions waits up to 10 seconds for transactions to be done, polling at 10Hz
-// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
+// Accepts options PollPeriod, PollTimeout, PollBackoff, MaxPollPeriod, and ErrorOnFailure.  With ErrorOnFailure, it
+// returns [ErrTransactionFailed] once all are done if any failed.
 func (rc *NodeClient) PollForTransactions(txnHashes []string, options ...any) error {
-	period, timeout, err := getTransactionPollOptions(100*time.Millisecond, 10*time.Second, options...)
+	pollOptions, err := getTransactionPollOptions(100*time.Millisecond, 10*time.Second, options...)
 	if err != nil {
 		return err
 	}
@@ -404,13 +688,16 @@ func (rc *NodeClient) PollForTransactions(txnHashes []string, options ...any) er
 	for _, hash := range txnHashes {
 		hashSet[hash] = true
 	}
+	var failures []error
+	period := pollOptions.period
 	start := time.Now()
-	deadline := start.Add(timeout)
+	deadline := start.Add(pollOptions.timeout)
 	for len(hashSet) > 0 {
 		if time.Now().After(deadline) {
 			return errors.New("PollForTransactions timeout")
 		}
 		time.Sleep(period)
+		period = pollOptions.next(period)
 		for _, hash := range txnHashes {
 			if !hashSet[hash] {
 				// already done
@@ -424,11 +711,14 @@ func (rc *NodeClient) PollForTransactions(txnHashes []string, options ...any) er
 					// done!
 					delete(hashSet, hash)
 					slog.Debug("txn done", "hash", hash)
+					if _, err = pollOptions.committed(txn); err != nil {
+						failures = append(failures, err)
+					}
 				}
 			}
 		}
 	}
-	return nil
+	return errors.Join(failures...)
 }
 
 // Transactions Get recent transactions.
@@ -460,6 +750,14 @@ func (rc *NodeClient) AccountTransactions(account AccountAddress, start *uint64,
 	})
 }
 
+// EventsByHandle Get events by the event handle struct and field name for an account e.g. the deposit events of a coin
+// store
+//
+// Arguments:
+//   - eventHandle is the struct tag of the resource holding the handle e.g. [AptosCoinStoreResourceType].
+//   - fieldName is the handle's field in the struct e.g. [CoinDepositEventsField].
+//   - start is a sequence number. Nil for the first events.
+//   - limit is a number of events to return, 100 by default.
 func (rc *NodeClient) EventsByHandle(
 	account AccountAddress,
 	eventHandle string,
@@ -471,7 +769,28 @@ func (rc *NodeClient) EventsByHandle(
 		account.String(),
 		eventHandle,
 		fieldName)
+	return rc.events(basePath, start, limit)
+}
+
+// EventsByCreationNumber Get events by the creation number of an event handle's GUID for an account
+//
+// Arguments:
+//   - start is a sequence number. Nil for the first events.
+//   - limit is a number of events to return, 100 by default.
+func (rc *NodeClient) EventsByCreationNumber(
+	account AccountAddress,
+	creationNumber uint64,
+	start *uint64,
+	limit *uint64,
+) (data []*api.Event, err error) {
+	basePath := fmt.Sprintf("accounts/%s/events/%d", account.String(), creationNumber)
+	return rc.events(basePath, start, limit)
+}
 
+// events is a helper function for fetching the events of an event handle in sequence number order
+//
+// It will fetch the events from the node in a single request if possible, otherwise it will fetch them concurrently.
+func (rc *NodeClient) events(basePath string, start *uint64, limit *uint64) (data []*api.Event, err error) {
 	baseUrl := rc.baseUrl.JoinPath(basePath)
 
 	const eventsPageSize = 100
@@ -688,6 +1007,10 @@ func (rc *NodeClient) accountTransactionsInner(account AccountAddress, start *ui
 
 // SubmitTransaction submits a signed transaction to the network
 func (rc *NodeClient) SubmitTransaction(signedTxn *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
+	err = rc.checkSimulationGate(signedTxn)
+	if err != nil {
+		return nil, err
+	}
 	sblob, err := bcs.Serialize(signedTxn)
 	if err != nil {
 		return
@@ -706,6 +1029,12 @@ func (rc *NodeClient) SubmitTransaction(signedTxn *SignedTransaction) (data *api
 // It will return the responses in the same order as the input transactions that failed.  If the response is empty, then
 // all transactions succeeded.
 func (rc *NodeClient) BatchSubmitTransaction(signedTxns []*SignedTransaction) (response *api.BatchSubmitTransactionResponse, err error) {
+	for _, signedTxn := range signedTxns {
+		err = rc.checkSimulationGate(signedTxn)
+		if err != nil {
+			return nil, err
+		}
+	}
 	sblob, err := bcs.SerializeSequenceOnly(signedTxns)
 	if err != nil {
 		return
@@ -871,10 +1200,12 @@ type ChainIdOption uint8
 // Accepts options:
 //   - [MaxGasAmount]
 //   - [GasUnitPrice]
+//   - [GasPriority]
 //   - [ExpirationSeconds]
 //   - [SequenceNumber]
 //   - [ChainIdOption]
 func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransaction, err error) {
+	options = rc.withGasDefaults(options)
 
 	maxGasAmount := DefaultMaxGasAmount
 	gasUnitPrice := DefaultGasUnitPrice
@@ -884,6 +1215,7 @@ func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload Transactio
 	chainId := uint8(0)
 	haveChainId := false
 	haveGasUnitPrice := false
+	gasPriority := GasPriorityNormal
 
 	for opti, option := range options {
 		switch ovalue := option.(type) {
@@ -892,6 +1224,8 @@ func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload Transactio
 		case GasUnitPrice:
 			gasUnitPrice = uint64(ovalue)
 			haveGasUnitPrice = true
+		case GasPriority:
+			gasPriority = ovalue
 		case ExpirationSeconds:
 			expirationSeconds = int64(ovalue)
 			if expirationSeconds < 0 {
@@ -910,7 +1244,7 @@ func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload Transactio
 		}
 	}
 
-	return rc.buildTransactionInner(sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, expirationSeconds, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
+	return rc.buildTransactionInner(sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, gasPriority, expirationSeconds, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
 }
 
 // BuildTransactionMultiAgent builds a raw transaction for signing with fee payer or multi-agent
@@ -920,12 +1254,14 @@ func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload Transactio
 // Accepts options:
 //   - [MaxGasAmount]
 //   - [GasUnitPrice]
+//   - [GasPriority]
 //   - [ExpirationSeconds]
 //   - [SequenceNumber]
 //   - [ChainIdOption]
 //   - [FeePayer]
 //   - [AdditionalSigners]
 func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload TransactionPayload, options ...any) (rawTxnImpl *RawTransactionWithData, err error) {
+	options = rc.withGasDefaults(options)
 
 	maxGasAmount := DefaultMaxGasAmount
 	gasUnitPrice := DefaultGasUnitPrice
@@ -935,6 +1271,7 @@ func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload
 	chainId := uint8(0)
 	haveChainId := false
 	haveGasUnitPrice := false
+	gasPriority := GasPriorityNormal
 
 	var feePayer *AccountAddress
 	var additionalSigners []AccountAddress
@@ -946,6 +1283,8 @@ func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload
 		case GasUnitPrice:
 			gasUnitPrice = uint64(ovalue)
 			haveGasUnitPrice = true
+		case GasPriority:
+			gasPriority = ovalue
 		case ExpirationSeconds:
 			expirationSeconds = int64(ovalue)
 			if expirationSeconds < 0 {
@@ -969,7 +1308,7 @@ func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload
 	}
 
 	// Build the base raw transaction
-	rawTxn, err := rc.buildTransactionInner(sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, expirationSeconds, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
+	rawTxn, err := rc.buildTransactionInner(sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, gasPriority, expirationSeconds, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
 	if err != nil {
 		return nil, err
 	}
@@ -1001,6 +1340,7 @@ func (rc *NodeClient) buildTransactionInner(
 	maxGasAmount uint64,
 	gasUnitPrice uint64,
 	haveGasUnitPrice bool,
+	gasPriority GasPriority,
 	expirationSeconds int64,
 	sequenceNumber uint64,
 	haveSequenceNumber bool,
@@ -1018,7 +1358,7 @@ func (rc *NodeClient) buildTransactionInner(
 			if innerErr != nil {
 				gasPriceErrChannel <- innerErr
 			} else {
-				gasUnitPrice = gasPriceEstimation.GasEstimate
+				gasUnitPrice = gasPriceEstimation.Price(gasPriority)
 				gasPriceErrChannel <- nil
 			}
 			close(gasPriceErrChannel)
@@ -1050,7 +1390,10 @@ func (rc *NodeClient) buildTransactionInner(
 	if !haveSequenceNumber {
 		accountErrChannel = make(chan error, 1)
 		go func() {
-			account, innerErr := rc.Account(sender)
+			// The sequence number is always the latest, even on a pinned client
+			latest := *rc
+			latest.ledgerVersion = nil
+			account, innerErr := latest.Account(sender)
 			if innerErr != nil {
 				accountErrChannel <- innerErr
 				close(accountErrChannel)
@@ -1124,6 +1467,12 @@ func (vp *ViewPayload) MarshalBCS(ser *bcs.Serializer) {
 
 // View calls a view function on the blockchain and returns the return value of the function
 func (rc *NodeClient) View(payload *ViewPayload, ledgerVersion ...uint64) (data []any, err error) {
+	data, _, err = rc.ViewWithLedgerInfo(payload, ledgerVersion...)
+	return data, err
+}
+
+// ViewWithLedgerInfo is [NodeClient.View], which also returns the ledger state the view function ran at
+func (rc *NodeClient) ViewWithLedgerInfo(payload *ViewPayload, ledgerVersion ...uint64) (data []any, ledger LedgerInfo, err error) {
 	serializer := bcs.Serializer{}
 	payload.MarshalBCS(&serializer)
 	err = serializer.Error()
@@ -1133,17 +1482,13 @@ func (rc *NodeClient) View(payload *ViewPayload, ledgerVersion ...uint64) (data
 	sblob := serializer.ToBytes()
 	bodyReader := bytes.NewReader(sblob)
 	au := rc.baseUrl.JoinPath("view")
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
+	rc.setLedgerVersion(au, ledgerVersion)
 
-	data, err = Post[[]any](rc, au.String(), ContentTypeAptosViewFunctionBcs, bodyReader)
+	data, ledger, err = PostWithLedgerInfo[[]any](rc, au.String(), ContentTypeAptosViewFunctionBcs, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("view function api err: %w", err)
+		return nil, ledger, fmt.Errorf("view function api err: %w", err)
 	}
-	return data, nil
+	return data, ledger, nil
 }
 
 // EstimateGasPrice estimates the gas price given on-chain data
@@ -1159,11 +1504,18 @@ func (rc *NodeClient) EstimateGasPrice() (info EstimateGasInfo, err error) {
 
 // AccountAPTBalance fetches the balance of an account of APT.  Response is in octas or 1/10^8 APT.
 func (rc *NodeClient) AccountAPTBalance(account AccountAddress, ledgerVersion ...uint64) (balance uint64, err error) {
+	balance, _, err = rc.AccountAPTBalanceWithLedgerInfo(account, ledgerVersion...)
+	return balance, err
+}
+
+// AccountAPTBalanceWithLedgerInfo is [NodeClient.AccountAPTBalance], which also returns the ledger state the balance
+// was read at
+func (rc *NodeClient) AccountAPTBalanceWithLedgerInfo(account AccountAddress, ledgerVersion ...uint64) (balance uint64, ledger LedgerInfo, err error) {
 	accountBytes, err := bcs.Serialize(&account)
 	if err != nil {
-		return 0, err
+		return 0, ledger, err
 	}
-	values, err := rc.View(&ViewPayload{Module: ModuleId{
+	values, ledger, err := rc.ViewWithLedgerInfo(&ViewPayload{Module: ModuleId{
 		Address: AccountOne,
 		Name:    "coin",
 	},
@@ -1172,9 +1524,10 @@ func (rc *NodeClient) AccountAPTBalance(account AccountAddress, ledgerVersion ..
 		Args:     [][]byte{accountBytes},
 	}, ledgerVersion...)
 	if err != nil {
-		return 0, err
+		return 0, ledger, err
 	}
-	return StrToUint64(values[0].(string))
+	balance, err = StrToUint64(values[0].(string))
+	return balance, ledger, err
 }
 
 // BuildSignAndSubmitTransaction builds, signs, and submits a transaction to the network
@@ -1194,13 +1547,19 @@ func (rc *NodeClient) BuildSignAndSubmitTransaction(sender TransactionSigner, pa
 //
 // Returns a HealthCheckResponse if successful, returns error if not.
 func (rc *NodeClient) NodeAPIHealthCheck(durationSecs ...uint64) (api.HealthCheckResponse, error) {
+	response, _, err := rc.nodeAPIHealthCheck(durationSecs...)
+	return response, err
+}
+
+// nodeAPIHealthCheck is [NodeClient.NodeAPIHealthCheck], which also returns the ledger state of the node
+func (rc *NodeClient) nodeAPIHealthCheck(durationSecs ...uint64) (api.HealthCheckResponse, LedgerInfo, error) {
 	au := rc.baseUrl.JoinPath("-/healthy")
 	if len(durationSecs) > 0 {
 		params := url.Values{}
 		params.Set("duration_secs", strconv.FormatUint(durationSecs[0], 10))
 		au.RawQuery = params.Encode()
 	}
-	return Get[api.HealthCheckResponse](rc, au.String())
+	return GetWithLedgerInfo[api.HealthCheckResponse](rc, au.String())
 }
 
 // NodeHealthCheck performs a health check on the node
@@ -1214,9 +1573,17 @@ func (rc *NodeClient) NodeHealthCheck(durationSecs ...uint64) (api.HealthCheckRe
 
 // Get makes a GET request to the endpoint and parses the response into the given type with JSON
 func Get[T any](rc *NodeClient, getUrl string) (out T, err error) {
-	req, err := http.NewRequest("GET", getUrl, nil)
+	out, _, err = GetWithLedgerInfo[T](rc, getUrl)
+	return out, err
+}
+
+// GetWithLedgerInfo makes a GET request to the endpoint and parses the response into the given type with JSON.  It also
+// returns the ledger state the response was served at, including for errors from the node, zero if the response didn't
+// have it.
+func GetWithLedgerInfo[T any](rc *NodeClient, getUrl string) (out T, info LedgerInfo, err error) {
+	req, err := http.NewRequestWithContext(rc.context(), "GET", getUrl, nil)
 	if err != nil {
-		return out, err
+		return out, info, err
 	}
 	req.Header.Set(ClientHeader, ClientHeaderValue)
 
@@ -1228,28 +1595,29 @@ func Get[T any](rc *NodeClient, getUrl string) (out T, err error) {
 	response, err := rc.client.Do(req)
 	if err != nil {
 		err = fmt.Errorf("GET %s, %w", getUrl, err)
-		return out, err
+		return out, info, err
 	}
+	info, _ = LedgerInfoFromHeaders(response.Header)
 
 	if response.StatusCode >= 400 {
 		err = NewHttpError(response)
-		return out, err
+		return out, info, err
 	}
 	defer response.Body.Close()
 	blob, err := io.ReadAll(response.Body)
 	if err != nil {
-		return out, fmt.Errorf("error getting response data, %w", err)
+		return out, info, fmt.Errorf("error getting response data, %w", err)
 	}
 	err = json.Unmarshal(blob, &out)
 	if err != nil {
-		return out, err
+		return out, info, err
 	}
-	return out, nil
+	return out, info, nil
 }
 
 // GetBCS makes a GET request to the endpoint and parses the response into the given type with BCS
 func (rc *NodeClient) GetBCS(getUrl string) (out []byte, err error) {
-	req, err := http.NewRequest("GET", getUrl, nil)
+	req, err := http.NewRequestWithContext(rc.context(), "GET", getUrl, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1281,12 +1649,19 @@ func (rc *NodeClient) GetBCS(getUrl string) (out []byte, err error) {
 
 // Post makes a POST request to the endpoint with the given body and parses the response into the given type with JSON
 func Post[T any](rc *NodeClient, postUrl string, contentType string, body io.Reader) (data T, err error) {
+	data, _, err = PostWithLedgerInfo[T](rc, postUrl, contentType, body)
+	return data, err
+}
+
+// PostWithLedgerInfo makes a POST request to the endpoint with the given body and parses the response into the given
+// type with JSON.  It also returns the ledger state the response was served at, see [GetWithLedgerInfo].
+func PostWithLedgerInfo[T any](rc *NodeClient, postUrl string, contentType string, body io.Reader) (data T, info LedgerInfo, err error) {
 	if body == nil {
 		body = http.NoBody
 	}
-	req, err := http.NewRequest("POST", postUrl, body)
+	req, err := http.NewRequestWithContext(rc.context(), "POST", postUrl, body)
 	if err != nil {
-		return data, err
+		return data, info, err
 	}
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set(ClientHeader, ClientHeaderValue)
@@ -1299,21 +1674,22 @@ func Post[T any](rc *NodeClient, postUrl string, contentType string, body io.Rea
 	response, err := rc.client.Do(req)
 	if err != nil {
 		err = fmt.Errorf("POST %s, %w", postUrl, err)
-		return data, err
+		return data, info, err
 	}
+	info, _ = LedgerInfoFromHeaders(response.Header)
 	if response.StatusCode >= 400 {
 		err = NewHttpError(response)
-		return data, err
+		return data, info, err
 	}
 	defer response.Body.Close()
 	blob, err := io.ReadAll(response.Body)
 	if err != nil {
 		err = fmt.Errorf("error getting response data, %w", err)
-		return data, err
+		return data, info, err
 	}
 
 	err = json.Unmarshal(blob, &data)
-	return data, err
+	return data, info, err
 }
 
 // ConcResponse is a concurrent response wrapper as a return type for all APIs.  It is meant to specifically be used in channels.
@@ -3,9 +3,12 @@ package aptos
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -27,6 +30,65 @@ func TestPollForTransaction(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestWaitForTransaction_Options(t *testing.T) {
+	var longPolls, polls atomic.Int32
+	committed := atomic.Bool{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userTxn := `{"type": "user_transaction", "version": "10", "hash": "0x1234", "sender": "0x1", "sequence_number": "9", "max_gas_amount": "100", "gas_unit_price": "100", "expiration_timestamp_secs": "1", "gas_used": "10", "success": false, "vm_status": "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006): Not enough coins to complete transaction", "changes": [], "events": [], "timestamp": "1"}`
+		switch {
+		case r.URL.Path == "/":
+			w.WriteHeader(http.StatusOK)
+		case strings.HasPrefix(r.URL.Path, "/transactions/wait_by_hash/"):
+			longPolls.Add(1)
+			fmt.Fprint(w, userTxn)
+		case strings.HasPrefix(r.URL.Path, "/transactions/by_hash/"):
+			polls.Add(1)
+			if !committed.Load() {
+				fmt.Fprint(w, `{"type": "pending_transaction", "hash": "0x1234", "sender": "0x1", "sequence_number": "9", "max_gas_amount": "100", "gas_unit_price": "100", "expiration_timestamp_secs": "1"}`)
+				return
+			}
+			fmt.Fprint(w, userTxn)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	// The long poll returns the failed transaction, which is only an error if asked for
+	txn, err := client.WaitForTransaction("0x1234")
+	assert.NoError(t, err)
+	assert.False(t, txn.Success)
+	txn, err = client.WaitForTransaction("0x1234", ErrorOnFailure(true))
+	assert.ErrorIs(t, err, ErrTransactionFailed)
+	assert.ErrorContains(t, err, "EINSUFFICIENT_BALANCE")
+	assert.Equal(t, "0x1234", txn.Hash)
+	assert.Equal(t, int32(2), longPolls.Load())
+	assert.Equal(t, int32(0), polls.Load())
+
+	// Without the long poll, polling backs off until the timeout
+	start := time.Now()
+	_, err = client.WaitForTransaction("0x1234", LongPoll(false), PollPeriod(time.Millisecond), PollBackoff(2), MaxPollPeriod(8*time.Millisecond), PollTimeout(50*time.Millisecond))
+	assert.ErrorContains(t, err, "timeout")
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, int32(2), longPolls.Load())
+	// 1, 2, 4, then every 8ms, rather than 50 polls at 1ms
+	assert.Less(t, polls.Load(), int32(12))
+
+	committed.Store(true)
+	txn, err = client.WaitForTransaction("0x1234", LongPoll(false), PollPeriod(time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), txn.Version)
+
+	err = client.PollForTransactions([]string{"0x1234"}, PollPeriod(time.Millisecond), ErrorOnFailure(true))
+	assert.ErrorIs(t, err, ErrTransactionFailed)
+
+	_, err = client.WaitForTransaction("0x1234", PollBackoff(0.5))
+	assert.Error(t, err)
+}
+
 func TestEventsByHandle(t *testing.T) {
 	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
@@ -117,3 +179,249 @@ func TestEventsByHandle(t *testing.T) {
 		assert.Equal(t, uint64(54), events[4].SequenceNumber)
 	})
 }
+
+func TestEventsByHandle_CoinStore(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The struct tag is escaped in the path
+		assert.Equal(t, "/accounts/0x1/events/0x1::coin::CoinStore%3C0x1::aptos_coin::AptosCoin%3E/deposit_events", r.URL.EscapedPath())
+		assert.Equal(t, "/accounts/0x1/events/"+AptosCoinStoreResourceType+"/"+CoinDepositEventsField, r.URL.Path)
+		_, _ = w.Write([]byte(`[{"type": "0x1::coin::DepositEvent", "guid": {"creation_number": "2", "account_address": "0x1"}, "sequence_number": "0", "data": {"amount": "100"}}]`))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{
+		Name:    "mocknet",
+		ChainId: 4,
+		NodeUrl: mockServer.URL,
+	})
+	assert.NoError(t, err)
+
+	events, err := client.EventsByHandle(AccountOne, AptosCoinStoreResourceType, CoinDepositEventsField, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	deposit := struct {
+		Amount string `json:"amount"`
+	}{}
+	assert.NoError(t, events[0].DataInto(&deposit))
+	assert.Equal(t, "100", deposit.Amount)
+}
+
+func TestEventsByCreationNumber(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/0x1/events/7", r.URL.Path)
+
+		startInt, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		limitInt, _ := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+		// The handle has 130 events
+		events := make([]map[string]interface{}, 0, limitInt)
+		for i := startInt; i < min(startInt+limitInt, 130); i++ {
+			events = append(events, map[string]interface{}{
+				"type": "0x1::coin::DepositEvent",
+				"guid": map[string]interface{}{
+					"creation_number": "7",
+					"account_address": AccountOne.String(),
+				},
+				"sequence_number": strconv.FormatUint(i, 10),
+				"data": map[string]interface{}{
+					"amount": fmt.Sprintf("%d", i*100),
+				},
+			})
+		}
+
+		json.NewEncoder(w).Encode(events)
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{
+		Name:    "mocknet",
+		ChainId: 4,
+		NodeUrl: mockServer.URL,
+	})
+	assert.NoError(t, err)
+
+	start := uint64(10)
+	limit := uint64(150)
+	events, err := client.EventsByCreationNumber(AccountOne, 7, &start, &limit)
+	assert.NoError(t, err)
+	assert.Len(t, events, 120)
+	assert.Equal(t, uint64(10), events[0].SequenceNumber)
+	assert.Equal(t, uint64(129), events[119].SequenceNumber)
+	assert.Equal(t, uint64(7), events[0].Guid.CreationNumber)
+}
+
+func TestBuildTransactionGasPriority(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/estimate_gas_price":
+			json.NewEncoder(w).Encode(map[string]any{
+				"deprioritized_gas_estimate": 100,
+				"gas_estimate":               150,
+				"prioritized_gas_estimate":   300,
+			})
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewNodeClient(mockServer.URL, 4)
+	assert.NoError(t, err)
+
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+
+	priorities := map[GasPriority]uint64{
+		GasPriorityNormal:        150,
+		GasPriorityDeprioritized: 100,
+		GasPriorityPrioritized:   300,
+	}
+	for priority, expected := range priorities {
+		rawTxn, err := client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload}, SequenceNumber(1), priority)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, rawTxn.GasUnitPrice)
+	}
+
+	// An explicit gas unit price skips the estimate
+	rawTxn, err := client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload}, SequenceNumber(1), GasUnitPrice(123), GasPriorityPrioritized)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(123), rawTxn.GasUnitPrice)
+}
+
+func TestEntryFunctionFromABI(t *testing.T) {
+	moduleRequests := 0
+	upgraded := false
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/0x1/module/example", r.URL.Path)
+		moduleRequests++
+		functions := []map[string]any{
+			{
+				"name":                "run",
+				"visibility":          "public",
+				"is_entry":            true,
+				"is_view":             false,
+				"generic_type_params": []map[string]any{},
+				"params":              []string{"&signer", "address", "u64", "vector<u128>", "0x1::option::Option<u8>", "0x1::string::String"},
+				"return":              []string{},
+			},
+		}
+		if upgraded {
+			functions = append(functions, map[string]any{
+				"name":                "added",
+				"visibility":          "public",
+				"is_entry":            true,
+				"is_view":             false,
+				"generic_type_params": []map[string]any{},
+				"params":              []string{"&signer", "u8"},
+				"return":              []string{},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"bytecode": "0x",
+			"abi": map[string]any{
+				"address":           "0x1",
+				"name":              "example",
+				"friends":           []string{},
+				"exposed_functions": functions,
+				"structs":           []map[string]any{},
+			},
+		})
+	}))
+	defer mockServer.Close()
+
+	client, err := NewNodeClient(mockServer.URL, 4)
+	assert.NoError(t, err)
+	module := ModuleId{Address: AccountOne, Name: "example"}
+
+	var jsonArgs []any
+	assert.NoError(t, json.Unmarshal([]byte(`["0x2", 100, ["1", "340282366920938463463374607431768211455"], {"vec": [7]}, "hello"]`), &jsonArgs))
+	entry, err := client.EntryFunctionFromABI(module, "run", nil, jsonArgs)
+	assert.NoError(t, err)
+
+	expected, err := NewEntryFunction(module, "run", nil,
+		[]string{"address", "u64", "vector<u128>", "0x1::option::Option<u8>", "0x1::string::String"},
+		[]any{AccountTwo, uint64(100), []big.Int{*big.NewInt(1), *new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))}, uint8(7), "hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, entry)
+
+	// Raw JSON keeps large numbers exact, and options can be none
+	entry, err = client.EntryFunctionFromABI(module, "run", nil, []any{
+		json.RawMessage(`"0x2"`), json.RawMessage(`18446744073709551615`), json.RawMessage(`[]`), json.RawMessage(`{"vec": []}`), json.RawMessage(`"hello"`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, entry.Args[1])
+	assert.Equal(t, []byte{0}, entry.Args[3])
+
+	// Wrong argument counts, inexact numbers and overflows are rejected
+	_, err = client.EntryFunctionFromABI(module, "run", nil, jsonArgs[:4])
+	assert.Error(t, err)
+	_, err = client.EntryFunctionFromABI(module, "run", nil, []any{"0x2", 1.5, []any{}, nil, "hello"})
+	assert.Error(t, err)
+	_, err = client.EntryFunctionFromABI(module, "run", nil, []any{"0x2", "100", []any{}, 256.0, "hello"})
+	assert.Error(t, err)
+
+	// The ABI is only fetched once
+	assert.Equal(t, 1, moduleRequests)
+
+	// A missing function refetches the ABI, in case the module was upgraded to add it
+	_, err = client.EntryFunctionFromABI(module, "added", nil, []any{"7"})
+	assert.Error(t, err)
+	assert.Equal(t, 2, moduleRequests)
+	upgraded = true
+	entry, err = client.EntryFunctionFromABI(module, "added", nil, []any{"7"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{7}}, entry.Args)
+	assert.Equal(t, 3, moduleRequests)
+	_, err = client.EntryFunctionFromABI(module, "run", nil, jsonArgs)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, moduleRequests)
+}
+
+func TestWaitForTransactions(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hash, found := strings.CutPrefix(r.URL.Path, "/transactions/wait_by_hash/")
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			previous := maxInFlight.Load()
+			if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if hash == "0xbad" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"type": "user_transaction", "version": "10", "hash": "%s", "sender": "0x1", "sequence_number": "9", "max_gas_amount": "100", "gas_unit_price": "100", "expiration_timestamp_secs": "1", "gas_used": "10", "success": true, "vm_status": "Executed successfully", "changes": [], "events": [], "timestamp": "1"}`, hash)
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	hashes := []string{"0x1", "0x2", "0xbad", "0x3", "0x4", "0x5"}
+	results, err := client.WaitForTransactions(hashes, WaitConcurrency(2), PollTimeout(20*time.Millisecond), PollPeriod(5*time.Millisecond))
+	assert.NoError(t, err)
+	assert.Len(t, results, len(hashes))
+	for i, result := range results {
+		assert.Equal(t, hashes[i], result.Hash)
+		if result.Hash == "0xbad" {
+			assert.Error(t, result.Err)
+			assert.Nil(t, result.Transaction)
+		} else {
+			assert.NoError(t, result.Err)
+			assert.Equal(t, hashes[i], result.Transaction.Hash)
+		}
+	}
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(2))
+
+	_, err = client.WaitForTransactions(hashes, WaitConcurrency(0))
+	assert.Error(t, err)
+	_, err = client.WaitForTransactions(hashes, "bad option")
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package aptos
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// NodeHealth is the result of a node health check, see [NodeClient.NodeHealth]
+type NodeHealth struct {
+	Healthy    bool          // Healthy is true if the node's ledger is within the freshness threshold
+	Message    string        // Message from the node e.g. aptos-node:ok, or why it's unhealthy
+	Latency    time.Duration // Latency of the health check request
+	LedgerInfo LedgerInfo    // LedgerInfo is the node's ledger state, zero if the node didn't report it
+}
+
+// NodeHealth checks if the node's ledger is within durationSecs of the current time, the node default if not given.
+// Unlike [NodeClient.NodeAPIHealthCheck], a stale node isn't an error, the error is for a node that can't be reached or
+// gave an unexpected answer.
+//
+//	health, err := client.NodeHealth(10)
+//	if err != nil || !health.Healthy {
+//		// take the node out of rotation
+//	}
+func (rc *NodeClient) NodeHealth(durationSecs ...uint64) (health NodeHealth, err error) {
+	start := time.Now()
+	response, ledger, err := rc.nodeAPIHealthCheck(durationSecs...)
+	health.LedgerInfo = ledger
+	health.Latency = time.Since(start)
+	apiErr := &AptosApiError{}
+	if errors.As(err, &apiErr) && apiErr.ErrorCode == api.ErrorCodeHealthCheckFailed {
+		health.Message = apiErr.Message
+		return health, nil
+	}
+	if err != nil {
+		return health, err
+	}
+	health.Healthy = true
+	health.Message = response.Message
+	return health, nil
+}
+
+// HealthCheckHandler is an [http.Handler] for load balancer and readiness probes, which checks the node on each request
+// with [NodeClient.NodeHealth] and durationSecs.  It answers 200 if the node is healthy, and 503 otherwise, with the
+// message as JSON.
+//
+//	http.Handle("/ready", aptos.HealthCheckHandler(client, 10))
+func HealthCheckHandler(client *Client, durationSecs ...uint64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health, err := client.WithContext(r.Context()).NodeHealth(durationSecs...)
+		status := http.StatusOK
+		message := health.Message
+		if err != nil {
+			status = http.StatusServiceUnavailable
+			message = err.Error()
+		} else if !health.Healthy {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(api.HealthCheckResponse{Message: message})
+	})
+}
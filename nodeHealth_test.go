@@ -0,0 +1,62 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_NodeHealth(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderAptosLedgerVersion, "1000")
+		switch r.URL.Query().Get("duration_secs") {
+		case "":
+			_, _ = w.Write([]byte(`{"message": "aptos-node:ok"}`))
+		case "1":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"message": "The latest ledger info timestamp is too old", "error_code": "health_check_failed"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message": "bad duration", "error_code": "invalid_input"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	health, err := client.NodeHealth()
+	assert.NoError(t, err)
+	assert.True(t, health.Healthy)
+	assert.Equal(t, "aptos-node:ok", health.Message)
+	assert.Equal(t, uint64(1000), health.LedgerInfo.LedgerVersion)
+
+	// A stale node isn't an error
+	health, err = client.NodeHealth(1)
+	assert.NoError(t, err)
+	assert.False(t, health.Healthy)
+	assert.Equal(t, "The latest ledger info timestamp is too old", health.Message)
+	assert.Equal(t, uint64(1000), health.LedgerInfo.LedgerVersion)
+
+	_, err = client.NodeHealth(2)
+	assert.Error(t, err)
+
+	// The handler reports the node's health
+	for _, durationSecs := range [][]uint64{nil, {1}, {2}} {
+		recorder := httptest.NewRecorder()
+		HealthCheckHandler(client, durationSecs...).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		response := api.HealthCheckResponse{}
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		if durationSecs == nil {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "aptos-node:ok", response.Message)
+		} else {
+			assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+			assert.NotEmpty(t, response.Message)
+		}
+	}
+}
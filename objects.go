@@ -0,0 +1,44 @@
+package aptos
+
+// objectModule is the 0x1::object module
+var objectModule = ModuleId{Address: AccountOne, Name: "object"}
+
+// CreateObjectAddress derives the address of the named object created by creator with the seed, as in
+// 0x1::object::create_named_object
+//
+//	collection := CreateObjectAddress(creator, []byte("My Collection"))
+func CreateObjectAddress(creator AccountAddress, seed []byte) AccountAddress {
+	return creator.NamedObjectAddress(seed)
+}
+
+// CreateGuidObjectAddress derives the address of the object created by creator from its GUID with the creation number,
+// as in 0x1::object::create_object.  The creation number is the creator's next GUID creation number at the time, e.g.
+// [ObjectCoreResource.GuidCreationNum] for an object creator.
+func CreateGuidObjectAddress(creator AccountAddress, creationNum uint64) AccountAddress {
+	return creator.ObjectAddressFromGuid(creationNum)
+}
+
+// ObjectTransferCallPayload builds an EntryFunction payload for 0x1::object::transfer_call, which transfers the object
+// at the address to the receiver, without the object's type.  See [ObjectTransferPayload] to transfer a typed object.
+func ObjectTransferCallPayload(object AccountAddress, receiver AccountAddress) *EntryFunction {
+	return &EntryFunction{
+		Module:   objectModule,
+		Function: "transfer_call",
+		ArgTypes: []TypeTag{},
+		Args: [][]byte{
+			object[:],
+			receiver[:],
+		},
+	}
+}
+
+// ObjectCore fetches the 0x1::object::ObjectCore resource of the object, with its owner and whether the owner can
+// transfer it without the creator's transfer ref
+func (client *Client) ObjectCore(object AccountAddress, ledgerVersion ...uint64) (*ObjectCoreResource, error) {
+	core := &ObjectCoreResource{}
+	err := client.AccountResourceInto(object, ObjectCoreResourceType, core, ledgerVersion...)
+	if err != nil {
+		return nil, err
+	}
+	return core, nil
+}
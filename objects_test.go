@@ -0,0 +1,41 @@
+package aptos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectAddresses(t *testing.T) {
+	named := CreateObjectAddress(AccountOne, []byte("seed"))
+	assert.Equal(t, "0x596f6d45ce187100ed4026ac81472dad87d7af9c520bb90eef56d01edc88be2b", named.String())
+
+	guid := CreateGuidObjectAddress(AccountOne, 5)
+	assert.Equal(t, "0xa01774303b66efc2c6792f61f87b6d7f1451594fde377cdafaa889d7322e943c", guid.String())
+	assert.NotEqual(t, guid, CreateGuidObjectAddress(AccountOne, 6))
+}
+
+func TestObjectTransferCallPayload(t *testing.T) {
+	payload := ObjectTransferCallPayload(AccountThree, AccountTwo)
+	assert.Equal(t, "transfer_call", payload.Function)
+	assert.Equal(t, ModuleId{Address: AccountOne, Name: "object"}, payload.Module)
+	assert.Equal(t, [][]byte{AccountThree[:], AccountTwo[:]}, payload.Args)
+}
+
+func TestClient_ObjectCore(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/0x3/resource/"+ObjectCoreResourceType, r.URL.Path)
+		_, _ = w.Write([]byte(`{"type":"0x1::object::ObjectCore","data":{"owner":"0x2","allow_ungated_transfer":true,"guid_creation_num":"1125899906842625","transfer_events":{"counter":"0","guid":{"id":{"addr":"0x3","creation_num":"1125899906842624"}}}}}`))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL})
+	assert.NoError(t, err)
+	core, err := client.ObjectCore(AccountThree)
+	assert.NoError(t, err)
+	assert.Equal(t, AccountTwo, core.Owner)
+	assert.True(t, core.AllowUngatedTransfer)
+	assert.Equal(t, uint64(1125899906842625), core.GuidCreationNum)
+}
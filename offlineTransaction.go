@@ -0,0 +1,337 @@
+package aptos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+)
+
+// OfflineTransactionFormat is the format of the current [OfflineTransaction] envelope
+const OfflineTransactionFormat = "aptos-offline-transaction/v1"
+
+// ErrMissingSignature is returned assembling an [OfflineTransaction] that hasn't been signed by all of its signers
+var ErrMissingSignature = errors.New("transaction is missing a signature")
+
+// OfflineTransactionKind is the kind of raw transaction in an [OfflineTransaction]
+type OfflineTransactionKind string
+
+const (
+	OfflineTransactionSingleSigner OfflineTransactionKind = "single_signer" // OfflineTransactionSingleSigner is a [RawTransaction] signed only by the sender
+	OfflineTransactionMultiAgent   OfflineTransactionKind = "multi_agent"   // OfflineTransactionMultiAgent is a multi-agent [RawTransactionWithData]
+	OfflineTransactionFeePayer     OfflineTransactionKind = "fee_payer"     // OfflineTransactionFeePayer is a fee payer [RawTransactionWithData]
+)
+
+// OfflineSignature is a signature of an [OfflineTransaction] by one of its signers
+type OfflineSignature struct {
+	Signer        AccountAddress `json:"signer"`        // Signer is the account that signed
+	Authenticator string         `json:"authenticator"` // Authenticator is the BCS of the [crypto.AccountAuthenticator] as hex
+}
+
+// OfflineTransaction is a JSON envelope carrying an unsigned transaction to an air-gapped machine for signing, and its
+// signatures back, for cold wallets.
+//
+// The transaction is built online, and written to a file:
+//
+//	rawTxn, err := client.BuildTransaction(coldAddress, payload)
+//	envelope, err := aptos.NewOfflineTransaction(rawTxn)
+//	err = envelope.WriteFile("transfer.json")
+//
+// On the air-gapped machine, it is signed without a client, only the private key:
+//
+//	envelope, err := aptos.ReadOfflineTransaction("transfer.json")
+//	err = envelope.Sign(privateKey)
+//	err = envelope.WriteFile("transfer.signed.json")
+//
+// Back online, it is assembled and submitted:
+//
+//	envelope, err := aptos.ReadOfflineTransaction("transfer.signed.json")
+//	signedTxn, err := envelope.SignedTransaction()
+//	response, err := client.SubmitTransaction(signedTxn)
+//
+// The sender, sequence number, expiration, and chain id are repeated in the clear for review before signing.  They are
+// checked against the BCS of the transaction, which is what is signed.
+type OfflineTransaction struct {
+	Format                     string                 `json:"format"`                           // Format is [OfflineTransactionFormat]
+	Kind                       OfflineTransactionKind `json:"kind"`                             // Kind of raw transaction
+	Sender                     AccountAddress         `json:"sender"`                           // Sender of the transaction
+	SequenceNumber             uint64                 `json:"sequence_number,string"`           // SequenceNumber of the transaction
+	ExpirationTimestampSeconds uint64                 `json:"expiration_timestamp_secs,string"` // ExpirationTimestampSeconds of the transaction
+	ChainId                    uint8                  `json:"chain_id"`                         // ChainId the transaction is for
+	SecondarySigners           []AccountAddress       `json:"secondary_signers,omitempty"`      // SecondarySigners must also sign a multi-agent or fee payer transaction
+	FeePayer                   *AccountAddress        `json:"fee_payer,omitempty"`              // FeePayer of a fee payer transaction, [AccountZero] if any account may pay
+	Transaction                string                 `json:"transaction"`                      // Transaction is the BCS of the [RawTransaction] or [RawTransactionWithData] as hex
+	Signatures                 []OfflineSignature     `json:"signatures,omitempty"`             // Signatures collected so far
+	Description                string                 `json:"description,omitempty"`            // Description is a note for the signer, it isn't signed
+	SigningMessage             string                 `json:"signing_message,omitempty"`        // SigningMessage is the message the sender and secondary signers sign as hex, for external signers
+}
+
+// NewOfflineTransaction wraps a [RawTransaction] or [RawTransactionWithData] in an [OfflineTransaction]
+func NewOfflineTransaction(txn RawTransactionImpl) (*OfflineTransaction, error) {
+	envelope := &OfflineTransaction{Format: OfflineTransactionFormat}
+	var rawTxn *RawTransaction
+	switch txn := txn.(type) {
+	case *RawTransaction:
+		envelope.Kind = OfflineTransactionSingleSigner
+		rawTxn = txn
+	case *RawTransactionWithData:
+		switch inner := txn.Inner.(type) {
+		case *MultiAgentRawTransactionWithData:
+			envelope.Kind = OfflineTransactionMultiAgent
+			rawTxn = inner.RawTxn
+			envelope.SecondarySigners = inner.SecondarySigners
+		case *MultiAgentWithFeePayerRawTransactionWithData:
+			envelope.Kind = OfflineTransactionFeePayer
+			rawTxn = inner.RawTxn
+			envelope.SecondarySigners = inner.SecondarySigners
+			envelope.FeePayer = inner.FeePayer
+		default:
+			return nil, fmt.Errorf("unknown RawTransactionWithData variant %d", txn.Variant)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported raw transaction type %T", txn)
+	}
+	if rawTxn == nil {
+		return nil, errors.New("raw transaction is missing")
+	}
+
+	txnBytes, err := bcs.Serialize(txn)
+	if err != nil {
+		return nil, err
+	}
+	message, err := txn.SigningMessage()
+	if err != nil {
+		return nil, err
+	}
+	envelope.Sender = rawTxn.Sender
+	envelope.SequenceNumber = rawTxn.SequenceNumber
+	envelope.ExpirationTimestampSeconds = rawTxn.ExpirationTimestampSeconds
+	envelope.ChainId = rawTxn.ChainId
+	envelope.Transaction = BytesToHex(txnBytes)
+	envelope.SigningMessage = BytesToHex(message)
+	return envelope, nil
+}
+
+// ReadOfflineTransaction reads an [OfflineTransaction] from a JSON file, see [OfflineTransaction.UnmarshalJSON]
+func ReadOfflineTransaction(path string) (*OfflineTransaction, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	envelope := &OfflineTransaction{}
+	err = json.Unmarshal(contents, envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offline transaction %s: %w", path, err)
+	}
+	return envelope, nil
+}
+
+// WriteFile writes the envelope as indented JSON
+func (o *OfflineTransaction) WriteFile(path string) error {
+	contents, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(contents, '\n'), 0o600)
+}
+
+// UnmarshalJSON deserializes an [OfflineTransaction], and checks that the fields in the clear match the BCS of the
+// transaction
+func (o *OfflineTransaction) UnmarshalJSON(b []byte) error {
+	type envelope OfflineTransaction
+	err := json.Unmarshal(b, (*envelope)(o))
+	if err != nil {
+		return err
+	}
+	if o.Format != OfflineTransactionFormat {
+		return fmt.Errorf("unsupported offline transaction format %q", o.Format)
+	}
+	txn, err := o.RawTransaction()
+	if err != nil {
+		return err
+	}
+	decoded, err := NewOfflineTransaction(txn)
+	if err != nil {
+		return err
+	}
+	switch {
+	case decoded.Kind != o.Kind:
+		return fmt.Errorf("offline transaction kind %s doesn't match its transaction %s", o.Kind, decoded.Kind)
+	case decoded.Sender != o.Sender || decoded.SequenceNumber != o.SequenceNumber || decoded.ExpirationTimestampSeconds != o.ExpirationTimestampSeconds || decoded.ChainId != o.ChainId:
+		return errors.New("offline transaction sender, sequence number, expiration, or chain id doesn't match its transaction")
+	case !slices.Equal(decoded.SecondarySigners, o.SecondarySigners) || !equalAddressPointers(decoded.FeePayer, o.FeePayer):
+		return errors.New("offline transaction signers don't match its transaction")
+	case o.SigningMessage != "" && o.SigningMessage != decoded.SigningMessage:
+		return errors.New("offline transaction signing message doesn't match its transaction")
+	}
+	return nil
+}
+
+// RawTransaction decodes the transaction, a [RawTransaction] or a [RawTransactionWithData]
+func (o *OfflineTransaction) RawTransaction() (RawTransactionImpl, error) {
+	txnBytes, err := ParseHex(o.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse offline transaction: %w", err)
+	}
+	var txn RawTransactionImpl
+	switch o.Kind {
+	case OfflineTransactionSingleSigner:
+		txn = &RawTransaction{}
+	case OfflineTransactionMultiAgent, OfflineTransactionFeePayer:
+		txn = &RawTransactionWithData{}
+	default:
+		return nil, fmt.Errorf("unknown offline transaction kind %q", o.Kind)
+	}
+	des := bcs.NewDeserializer(txnBytes)
+	txn.UnmarshalBCS(des)
+	if err = des.Error(); err != nil {
+		return nil, fmt.Errorf("failed to deserialize offline transaction: %w", err)
+	}
+	if des.Remaining() != 0 {
+		return nil, fmt.Errorf("failed to deserialize offline transaction: %d bytes left over", des.Remaining())
+	}
+	return txn, nil
+}
+
+// SigningMessageFor is the message the signer signs, the fee payer signs with its own address filled in.  It errors if
+// the address isn't a signer of the transaction.
+func (o *OfflineTransaction) SigningMessageFor(signer AccountAddress) ([]byte, error) {
+	txn, err := o.RawTransaction()
+	if err != nil {
+		return nil, err
+	}
+	if signer == o.Sender || slices.Contains(o.SecondarySigners, signer) {
+		return txn.SigningMessage()
+	}
+	if o.FeePayer != nil && (*o.FeePayer == signer || *o.FeePayer == AccountZero) {
+		withData := txn.(*RawTransactionWithData)
+		withData.SetFeePayer(signer)
+		return withData.SigningMessage()
+	}
+	return nil, fmt.Errorf("%s is not a signer of the transaction", signer.String())
+}
+
+// Sign signs the transaction with the key, and adds the signature.  The signer is the account of the key's
+// authentication key, or [TransactionSigner.AccountAddress] if the key has been rotated.
+func (o *OfflineTransaction) Sign(signer crypto.Signer) error {
+	var address AccountAddress
+	if txnSigner, ok := signer.(TransactionSigner); ok {
+		address = txnSigner.AccountAddress()
+	} else {
+		address = AccountAddress(*signer.AuthKey())
+	}
+	message, err := o.SigningMessageFor(address)
+	if err != nil {
+		return err
+	}
+	auth, err := signer.Sign(message)
+	if err != nil {
+		return err
+	}
+	return o.AddSignature(address, auth)
+}
+
+// AddSignature adds a signature made elsewhere, e.g. by signing [OfflineTransaction.SigningMessageFor] with an HSM.  It
+// replaces any earlier signature by the signer, and errors if the signature doesn't match the message.  Whether the key
+// is the signer's on-chain key is only checked by the chain.
+func (o *OfflineTransaction) AddSignature(signer AccountAddress, auth *crypto.AccountAuthenticator) error {
+	message, err := o.SigningMessageFor(signer)
+	if err != nil {
+		return err
+	}
+	if auth == nil || !auth.Verify(message) {
+		return fmt.Errorf("%w: signature by %s", ErrInvalidSignature, signer.String())
+	}
+	authBytes, err := bcs.Serialize(auth)
+	if err != nil {
+		return err
+	}
+	o.Signatures = slices.DeleteFunc(o.Signatures, func(signature OfflineSignature) bool {
+		return signature.Signer == signer
+	})
+	o.Signatures = append(o.Signatures, OfflineSignature{Signer: signer, Authenticator: BytesToHex(authBytes)})
+	return nil
+}
+
+// SignedTransaction assembles the transaction and its signatures for submission, or returns [ErrMissingSignature]
+func (o *OfflineTransaction) SignedTransaction() (*SignedTransaction, error) {
+	txn, err := o.RawTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signatures := make(map[AccountAddress]*crypto.AccountAuthenticator, len(o.Signatures))
+	for _, signature := range o.Signatures {
+		authBytes, err := ParseHex(signature.Authenticator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signature by %s: %w", signature.Signer.String(), err)
+		}
+		auth := &crypto.AccountAuthenticator{}
+		err = bcs.Deserialize(auth, authBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize signature by %s: %w", signature.Signer.String(), err)
+		}
+		signatures[signature.Signer] = auth
+	}
+	signatureOf := func(role string, signer AccountAddress) (*crypto.AccountAuthenticator, error) {
+		auth, ok := signatures[signer]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s %s", ErrMissingSignature, role, signer.String())
+		}
+		return auth, nil
+	}
+
+	sender, err := signatureOf("sender", o.Sender)
+	if err != nil {
+		return nil, err
+	}
+	secondarySigners := make([]crypto.AccountAuthenticator, len(o.SecondarySigners))
+	for i, address := range o.SecondarySigners {
+		auth, err := signatureOf("secondary signer", address)
+		if err != nil {
+			return nil, err
+		}
+		secondarySigners[i] = *auth
+	}
+
+	var signedTxn *SignedTransaction
+	switch o.Kind {
+	case OfflineTransactionSingleSigner:
+		signedTxn, err = txn.(*RawTransaction).SignedTransactionWithAuthenticator(sender)
+		if err != nil {
+			return nil, err
+		}
+	case OfflineTransactionMultiAgent:
+		signedTxn, _ = txn.(*RawTransactionWithData).ToMultiAgentSignedTransaction(sender, secondarySigners)
+	case OfflineTransactionFeePayer:
+		feePayer := *o.FeePayer
+		if feePayer == AccountZero {
+			// Any account may pay, so it's whichever other account signed
+			for address := range signatures {
+				if address != o.Sender && !slices.Contains(o.SecondarySigners, address) {
+					feePayer = address
+					break
+				}
+			}
+		}
+		feePayerAuth, err := signatureOf("fee payer", feePayer)
+		if err != nil {
+			return nil, err
+		}
+		withData := txn.(*RawTransactionWithData)
+		withData.SetFeePayer(feePayer)
+		signedTxn, _ = withData.ToFeePayerSignedTransaction(sender, feePayerAuth, secondarySigners)
+	}
+	return signedTxn, nil
+}
+
+// equalAddressPointers tells if both addresses are nil, or are equal
+func equalAddressPointers(a *AccountAddress, b *AccountAddress) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,98 @@
+package aptos
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfflineTransaction(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{Sender: sender.Address, SequenceNumber: 3, Payload: TransactionPayload{Payload: payload}, MaxGasAmount: 1000, GasUnitPrice: 100, ExpirationTimestampSeconds: 1700000000, ChainId: 4}
+
+	// Online, the unsigned transaction is written out
+	envelope, err := NewOfflineTransaction(rawTxn)
+	assert.NoError(t, err)
+	assert.Equal(t, OfflineTransactionSingleSigner, envelope.Kind)
+	assert.Equal(t, uint64(3), envelope.SequenceNumber)
+	path := filepath.Join(t.TempDir(), "transfer.json")
+	assert.NoError(t, envelope.WriteFile(path))
+
+	// Offline, it is signed with only the private key
+	offline, err := ReadOfflineTransaction(path)
+	assert.NoError(t, err)
+	_, err = offline.SignedTransaction()
+	assert.ErrorIs(t, err, ErrMissingSignature)
+	assert.NoError(t, offline.Sign(sender.Signer))
+	assert.NoError(t, offline.WriteFile(path))
+
+	// Online again, it is assembled
+	signed, err := ReadOfflineTransaction(path)
+	assert.NoError(t, err)
+	signedTxn, err := signed.SignedTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, signedTxn.Verify())
+	expected, err := rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+	expectedHash, err := expected.Hash()
+	assert.NoError(t, err)
+	hash, err := signedTxn.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+
+	// Tampering with the fields in the clear is caught
+	signed.SequenceNumber = 4
+	assert.NoError(t, signed.WriteFile(path))
+	_, err = ReadOfflineTransaction(path)
+	assert.ErrorContains(t, err, "doesn't match")
+
+	// Only signers can sign, and signatures must match
+	other, err := NewEd25519Account()
+	assert.NoError(t, err)
+	assert.ErrorContains(t, envelope.Sign(other), "not a signer")
+	auth, err := sender.Sign([]byte("not the transaction"))
+	assert.NoError(t, err)
+	assert.ErrorIs(t, envelope.AddSignature(sender.Address, auth), ErrInvalidSignature)
+}
+
+func TestOfflineTransaction_FeePayer(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	secondary, err := NewEd25519Account()
+	assert.NoError(t, err)
+	sponsor, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{Sender: sender.Address, Payload: TransactionPayload{Payload: payload}, MaxGasAmount: 1000, GasUnitPrice: 100, ChainId: 4}
+
+	// The sponsor isn't known when the transaction is built
+	envelope, err := NewOfflineTransaction(NewFeePayerRawTransaction(rawTxn, AccountZero, secondary.Address))
+	assert.NoError(t, err)
+	assert.Equal(t, OfflineTransactionFeePayer, envelope.Kind)
+	assert.NoError(t, envelope.Sign(sender))
+	assert.NoError(t, envelope.Sign(sponsor))
+	_, err = envelope.SignedTransaction()
+	assert.ErrorIs(t, err, ErrMissingSignature)
+
+	// A signature from an external signer is added directly
+	message, err := envelope.SigningMessageFor(secondary.Address)
+	assert.NoError(t, err)
+	signature, err := secondary.SignMessage(message)
+	assert.NoError(t, err)
+	auth := &crypto.AccountAuthenticator{}
+	assert.NoError(t, auth.FromKeyAndSignature(secondary.PubKey(), signature))
+	assert.NoError(t, envelope.AddSignature(secondary.Address, auth))
+
+	signedTxn, err := envelope.SignedTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, signedTxn.Verify())
+	feePayerAuth, ok := signedTxn.Authenticator.Auth.(*FeePayerTransactionAuthenticator)
+	assert.True(t, ok)
+	assert.Equal(t, sponsor.Address, *feePayerAuth.FeePayer)
+}
@@ -0,0 +1,125 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PaymentUriScheme is the URI scheme of Aptos payment requests
+const PaymentUriScheme = "aptos"
+
+// MaxQrPayloadLength is the most bytes a QR code can hold in byte mode, at version 40 with low error correction
+const MaxQrPayloadLength = 2953
+
+// PaymentRequest is a request for payment to an address, encoded as an Aptos payment URI:
+//
+//	aptos:0x1234...?amount=150000000&asset=0x1::aptos_coin::AptosCoin&label=Coffee%20Shop&message=Order%2042
+//
+// Amount is in the smallest unit of the asset e.g. octas, so the URI is exact no matter the asset's decimals.  The
+// asset is a coin type or a fungible asset metadata address, and is APT if not given.
+//
+//	request := PaymentRequest{Address: shop, Asset: AptAsset, Amount: 150_000_000, Label: "Coffee Shop"}
+//	uri := request.String()
+//	parsed, err := ParsePaymentUri(uri)
+type PaymentRequest struct {
+	Address AccountAddress // Address to pay
+	Asset   Asset          // Asset to pay in, the zero value is APT
+	Amount  uint64         // Amount in the smallest unit of the asset, 0 lets the payer choose
+	Label   string         // Label is a name for the recipient e.g. a shop name
+	Message string         // Message describes the payment e.g. an order number
+	ChainId uint8          // ChainId is the network the payment is for, 0 if not given
+}
+
+// String encodes the request as a payment URI.  Optional fields are left out when empty, and parameters are sorted
+// so the same request always gives the same URI.
+func (request *PaymentRequest) String() string {
+	params := url.Values{}
+	if request.Amount != 0 {
+		params.Set("amount", strconv.FormatUint(request.Amount, 10))
+	}
+	if asset := request.Asset.String(); asset != "" {
+		params.Set("asset", asset)
+	}
+	if request.Label != "" {
+		params.Set("label", request.Label)
+	}
+	if request.Message != "" {
+		params.Set("message", request.Message)
+	}
+	if request.ChainId != 0 {
+		params.Set("chain_id", strconv.FormatUint(uint64(request.ChainId), 10))
+	}
+	uri := PaymentUriScheme + ":" + request.Address.String()
+	if len(params) > 0 {
+		// Spaces are %20 rather than +, as + is ambiguous outside of forms.  Colons are allowed in a query, and keep
+		// coin types readable.
+		uri += "?" + strings.NewReplacer("+", "%20", "%3A", ":").Replace(params.Encode())
+	}
+	return uri
+}
+
+// QrPayload is the text to encode in a QR code for the request, erroring if it is too long to fit in one
+func (request *PaymentRequest) QrPayload() (string, error) {
+	payload := request.String()
+	if len(payload) > MaxQrPayloadLength {
+		return "", fmt.Errorf("payment uri is %d bytes, more than the %d that fit in a QR code", len(payload), MaxQrPayloadLength)
+	}
+	return payload, nil
+}
+
+// TransferPayload builds the transaction paying the request.  Errors if the request has no amount, the payer's wallet
+// must choose one first.
+func (request *PaymentRequest) TransferPayload() (*EntryFunction, error) {
+	if request.Amount == 0 {
+		return nil, errors.New("payment request has no amount")
+	}
+	return request.Asset.TransferPayload(request.Address, request.Amount)
+}
+
+// ParsePaymentUri parses an Aptos payment URI, see [PaymentRequest].  The scheme is case-insensitive, and
+// aptos://0x1234 is accepted as well as aptos:0x1234, as it is what QR scanners often produce.  Unknown parameters are
+// ignored, so later additions to the scheme don't break older parsers.
+func ParsePaymentUri(uri string) (*PaymentRequest, error) {
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(uri), ":")
+	if !ok || !strings.EqualFold(scheme, PaymentUriScheme) {
+		return nil, fmt.Errorf("not an %s payment uri: %q", PaymentUriScheme, uri)
+	}
+	rest = strings.TrimPrefix(rest, "//")
+	address, query, _ := strings.Cut(rest, "?")
+
+	request := &PaymentRequest{}
+	err := request.Address.ParseStringRelaxed(strings.TrimSuffix(address, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment uri address: %w", err)
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment uri parameters: %w", err)
+	}
+
+	if amount := params.Get("amount"); amount != "" {
+		request.Amount, err = strconv.ParseUint(amount, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment uri amount %q: %w", amount, err)
+		}
+	}
+	if asset := params.Get("asset"); asset != "" {
+		request.Asset, err = ParseAsset(asset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment uri asset %q: %w", asset, err)
+		}
+	}
+	if chainId := params.Get("chain_id"); chainId != "" {
+		parsed, err := strconv.ParseUint(chainId, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment uri chain_id %q: %w", chainId, err)
+		}
+		request.ChainId = uint8(parsed)
+	}
+	request.Label = params.Get("label")
+	request.Message = params.Get("message")
+	return request, nil
+}
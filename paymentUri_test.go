@@ -0,0 +1,73 @@
+package aptos
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentRequest(t *testing.T) {
+	shop := AccountAddress{}
+	assert.NoError(t, shop.ParseStringRelaxed("0xcafe"))
+
+	request := PaymentRequest{Address: shop, Asset: AptAsset, Amount: 150_000_000, Label: "Coffee Shop", Message: "Order #42", ChainId: 1}
+	uri := request.String()
+	assert.Equal(t, "aptos:"+shop.String()+"?amount=150000000&asset=0x1::aptos_coin::AptosCoin&chain_id=1&label=Coffee%20Shop&message=Order%20%2342", uri)
+
+	parsed, err := ParsePaymentUri(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, shop, parsed.Address)
+	assert.True(t, AptAsset.Equal(parsed.Asset))
+	assert.Equal(t, uint64(150_000_000), parsed.Amount)
+	assert.Equal(t, "Coffee Shop", parsed.Label)
+	assert.Equal(t, "Order #42", parsed.Message)
+	assert.Equal(t, uint8(1), parsed.ChainId)
+	assert.Equal(t, uri, parsed.String())
+
+	payload, err := parsed.TransferPayload()
+	assert.NoError(t, err)
+	assert.Equal(t, "transfer_coins", payload.Function)
+
+	// Only the address is required, and the scheme is forgiving
+	parsed, err = ParsePaymentUri(" APTOS://0xcafe?label=Shop+Two&unknown=1 ")
+	assert.NoError(t, err)
+	assert.Equal(t, shop, parsed.Address)
+	assert.Equal(t, "Shop Two", parsed.Label)
+	assert.Equal(t, uint64(0), parsed.Amount)
+	_, err = parsed.TransferPayload()
+	assert.Error(t, err)
+	assert.Equal(t, "aptos:"+shop.String(), (&PaymentRequest{Address: shop}).String())
+
+	// Fungible assets are by metadata address
+	parsed, err = ParsePaymentUri("aptos:0xcafe?asset=0xa&amount=5")
+	assert.NoError(t, err)
+	assert.Equal(t, AssetKindFungibleAsset, parsed.Asset.Kind)
+	payload, err = parsed.TransferPayload()
+	assert.NoError(t, err)
+	assert.Equal(t, "primary_fungible_store", payload.Module.Name)
+
+	for _, bad := range []string{
+		"bitcoin:0xcafe",
+		"0xcafe",
+		"aptos:not_an_address",
+		"aptos:0xcafe?amount=-1",
+		"aptos:0xcafe?amount=1.5",
+		"aptos:0xcafe?asset=0x1::bad<",
+		"aptos:0xcafe?chain_id=256",
+	} {
+		_, err = ParsePaymentUri(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestPaymentRequest_QrPayload(t *testing.T) {
+	request := PaymentRequest{Address: AccountOne, Amount: 1}
+	payload, err := request.QrPayload()
+	assert.NoError(t, err)
+	assert.Equal(t, request.String(), payload)
+
+	request.Message = strings.Repeat("a", MaxQrPayloadLength)
+	_, err = request.QrPayload()
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package aptos
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+)
+
+// PersonalMessagePrefix is the prefix of every wallet-standard signed message
+const PersonalMessagePrefix = "APTOS"
+
+// PersonalMessage is an off-chain message for a wallet to sign, as in the wallet-standard signMessage input.  The
+// optional fields are only included in the full message when set, which matches a wallet asked to include them.
+type PersonalMessage struct {
+	Message     string          // Message is the text shown to the user
+	Nonce       string          // Nonce should be unique per request, e.g. issued by the backend, to prevent replays
+	Address     *AccountAddress // Address of the signer, optional
+	Application string          // Application is the origin of the dApp e.g. https://example.com, optional
+	ChainId     *uint8          // ChainId of the network the wallet is connected to, optional
+}
+
+// FullMessage assembles the canonical message that is signed, one field per line:
+//
+//	APTOS
+//	address: 0x1
+//	application: https://example.com
+//	chainId: 1
+//	message: Sign in to example.com
+//	nonce: 12345
+func (message *PersonalMessage) FullMessage() string {
+	builder := strings.Builder{}
+	builder.WriteString(PersonalMessagePrefix)
+	if message.Address != nil {
+		builder.WriteString("\naddress: ")
+		builder.WriteString(message.Address.String())
+	}
+	if message.Application != "" {
+		builder.WriteString("\napplication: ")
+		builder.WriteString(message.Application)
+	}
+	if message.ChainId != nil {
+		builder.WriteString("\nchainId: ")
+		builder.WriteString(strconv.FormatUint(uint64(*message.ChainId), 10))
+	}
+	builder.WriteString("\nmessage: ")
+	builder.WriteString(message.Message)
+	builder.WriteString("\nnonce: ")
+	builder.WriteString(message.Nonce)
+	return builder.String()
+}
+
+// SignedPersonalMessage is a signed [PersonalMessage], as in the wallet-standard signMessage output
+type SignedPersonalMessage struct {
+	PersonalMessage
+	Prefix      string           // Prefix is always [PersonalMessagePrefix]
+	FullMessage string           // FullMessage is the exact text signed, see [PersonalMessage.FullMessage]
+	Signature   crypto.Signature // Signature of the UTF-8 bytes of FullMessage
+	PublicKey   crypto.PublicKey // PublicKey to verify the signature with
+}
+
+// SignPersonalMessage signs a [PersonalMessage] the same way a wallet would, e.g. to test a backend
+//
+//	signed, err := SignPersonalMessage(account, &PersonalMessage{Message: "Sign in", Nonce: nonce})
+func SignPersonalMessage(signer crypto.Signer, message *PersonalMessage) (*SignedPersonalMessage, error) {
+	if message.Nonce == "" {
+		return nil, errors.New("personal message must have a nonce")
+	}
+	fullMessage := message.FullMessage()
+	signature, err := signer.SignMessage([]byte(fullMessage))
+	if err != nil {
+		return nil, err
+	}
+	return &SignedPersonalMessage{
+		PersonalMessage: *message,
+		Prefix:          PersonalMessagePrefix,
+		FullMessage:     fullMessage,
+		Signature:       signature,
+		PublicKey:       signer.PubKey(),
+	}, nil
+}
+
+// VerifyPersonalMessage checks a signature returned by a wallet, so a backend can authenticate the wallet owner.
+//
+// expected is the message the backend asked for, with the nonce it issued.  fullMessage must be exactly the message
+// assembled from expected, so a wallet can't be tricked into signing a different message or nonce.  If
+// expected.Address is set, the public key must derive that address, which is not the case for rotated accounts, see
+// [Client.VerifyPersonalMessage].
+func VerifyPersonalMessage(expected *PersonalMessage, fullMessage string, publicKey crypto.PublicKey, signature crypto.Signature) error {
+	if expected.Address != nil && AccountAddress(*publicKey.AuthKey()) != *expected.Address {
+		return fmt.Errorf("public key does not belong to address %s", expected.Address.String())
+	}
+	return verifyPersonalMessageSignature(expected, fullMessage, publicKey, signature)
+}
+
+// VerifyPersonalMessage checks a signature returned by a wallet for address, like [VerifyPersonalMessage], but checks
+// the public key against the on-chain authentication key of address, so rotated accounts are supported.
+func (client *Client) VerifyPersonalMessage(address AccountAddress, expected *PersonalMessage, fullMessage string, publicKey crypto.PublicKey, signature crypto.Signature) error {
+	if expected.Address != nil && *expected.Address != address {
+		return fmt.Errorf("expected message is for address %s, not %s", expected.Address.String(), address.String())
+	}
+	info, err := client.Account(address)
+	if err != nil {
+		return err
+	}
+	authKey, err := info.AuthenticationKey()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(authKey, publicKey.AuthKey()[:]) {
+		return fmt.Errorf("public key is not the authentication key of %s", address.String())
+	}
+	return verifyPersonalMessageSignature(expected, fullMessage, publicKey, signature)
+}
+
+// verifyPersonalMessageSignature checks the full message is assembled from expected, and is signed by publicKey
+func verifyPersonalMessageSignature(expected *PersonalMessage, fullMessage string, publicKey crypto.PublicKey, signature crypto.Signature) error {
+	if fullMessage != expected.FullMessage() {
+		return errors.New("signed message does not match the expected message")
+	}
+	if !publicKey.Verify([]byte(fullMessage), signature) {
+		return errors.New("invalid personal message signature")
+	}
+	return nil
+}
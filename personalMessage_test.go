@@ -0,0 +1,82 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalMessage_FullMessage(t *testing.T) {
+	message := &PersonalMessage{Message: "Sign in to example.com", Nonce: "12345"}
+	assert.Equal(t, "APTOS\nmessage: Sign in to example.com\nnonce: 12345", message.FullMessage())
+
+	chainId := uint8(1)
+	message.Address = &AccountOne
+	message.Application = "https://example.com"
+	message.ChainId = &chainId
+	assert.Equal(t, "APTOS\naddress: 0x1\napplication: https://example.com\nchainId: 1\nmessage: Sign in to example.com\nnonce: 12345", message.FullMessage())
+}
+
+func TestSignPersonalMessage(t *testing.T) {
+	for name, newAccount := range map[string]func() (*Account, error){
+		"Ed25519":   NewEd25519Account,
+		"Secp256k1": NewSecp256k1Account,
+	} {
+		t.Run(name, func(t *testing.T) {
+			account, err := newAccount()
+			assert.NoError(t, err)
+			expected := &PersonalMessage{Message: "Sign in", Nonce: "abc", Address: &account.Address, Application: "https://example.com"}
+
+			signed, err := SignPersonalMessage(account, expected)
+			assert.NoError(t, err)
+			assert.Equal(t, PersonalMessagePrefix, signed.Prefix)
+			assert.Equal(t, expected.FullMessage(), signed.FullMessage)
+			assert.NoError(t, VerifyPersonalMessage(expected, signed.FullMessage, signed.PublicKey, signed.Signature))
+
+			// A different nonce is a replay
+			replayed := *expected
+			replayed.Nonce = "def"
+			assert.Error(t, VerifyPersonalMessage(&replayed, signed.FullMessage, signed.PublicKey, signed.Signature))
+
+			// A different key can't sign for the address
+			other, err := newAccount()
+			assert.NoError(t, err)
+			forged, err := SignPersonalMessage(other, expected)
+			assert.NoError(t, err)
+			assert.Error(t, VerifyPersonalMessage(expected, forged.FullMessage, forged.PublicKey, forged.Signature))
+			assert.Error(t, VerifyPersonalMessage(expected, signed.FullMessage, signed.PublicKey, forged.Signature))
+		})
+	}
+
+	_, err := SignPersonalMessage(&Account{}, &PersonalMessage{Message: "no nonce"})
+	assert.Error(t, err)
+}
+
+func TestClient_VerifyPersonalMessage(t *testing.T) {
+	// The account was rotated from its original key to this one
+	account, err := NewEd25519Account()
+	assert.NoError(t, err)
+	account.Address = AccountTwo
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"sequence_number": "1", "authentication_key": account.AuthKey().ToHex()})
+	}))
+	defer mockServer.Close()
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	expected := &PersonalMessage{Message: "Sign in", Nonce: "abc", Address: &account.Address}
+	signed, err := SignPersonalMessage(account, expected)
+	assert.NoError(t, err)
+
+	assert.Error(t, VerifyPersonalMessage(expected, signed.FullMessage, signed.PublicKey, signed.Signature))
+	assert.NoError(t, client.VerifyPersonalMessage(AccountTwo, expected, signed.FullMessage, signed.PublicKey, signed.Signature))
+	assert.Error(t, client.VerifyPersonalMessage(AccountThree, expected, signed.FullMessage, signed.PublicKey, signed.Signature))
+
+	other, err := crypto.GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	assert.Error(t, client.VerifyPersonalMessage(AccountTwo, expected, signed.FullMessage, other.PubKey(), signed.Signature))
+}
@@ -0,0 +1,185 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Names of the checks in a [PreflightReport], ABI checks are named "abi " followed by the module e.g. "abi 0x1::coin"
+const (
+	PreflightNode     = "node"
+	PreflightChainId  = "chain_id"
+	PreflightGasPrice = "gas_price"
+	PreflightIndexer  = "indexer"
+	PreflightFaucet   = "faucet"
+)
+
+// PreflightCheck is the result of one check run by [Client.Preflight]
+type PreflightCheck struct {
+	Name    string        // Name of the check e.g. [PreflightNode]
+	Skipped bool          // Skipped is true if the check doesn't apply, e.g. no indexer is configured
+	Latency time.Duration // Latency of the request made for the check
+	Err     error         // Err is nil if the check passed
+}
+
+// PreflightReport is the result of [Client.Preflight]
+type PreflightReport struct {
+	ChainId        uint8            // ChainId reported by the node
+	LedgerVersion  uint64           // LedgerVersion of the node at startup
+	LedgerTime     time.Time        // LedgerTime of the node at startup, to spot a node that is behind
+	GasEstimate    uint64           // GasEstimate is the gas unit price estimated by the node
+	IndexerChainId uint8            // IndexerChainId reported by the indexer, 0 if not checked
+	Checks         []PreflightCheck // Checks in the order they ran
+}
+
+// Ok tells if every check passed or was skipped
+func (report *PreflightReport) Ok() bool {
+	return report.Err() == nil
+}
+
+// Err joins the errors of the failed checks, nil if none failed
+func (report *PreflightReport) Err() error {
+	var errs []error
+	for _, check := range report.Checks {
+		if check.Err != nil {
+			errs = append(errs, fmt.Errorf("preflight %s: %w", check.Name, check.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check returns the check with the name, ok is false if it wasn't run
+func (report *PreflightReport) Check(name string) (check PreflightCheck, ok bool) {
+	for _, check = range report.Checks {
+		if check.Name == name {
+			return check, true
+		}
+	}
+	return PreflightCheck{}, false
+}
+
+// Preflight validates the client at service startup, so misconfiguration fails fast instead of on the first request.
+// It checks connectivity to the fullnode, indexer, and faucet, checks the chain id of the node and indexer against the
+// configured network, and warms the connection, chain id, and the ABIs of the modules given, which are cached for
+// [Client.EntryFunctionFromABI].
+//
+// Every check is run, and the report is returned even when checks fail, with err joining their errors.  ctx limits
+// the whole preflight.
+//
+//	report, err := client.Preflight(ctx, ModuleId{Address: AccountOne, Name: "coin"})
+//	if err != nil {
+//		log.Fatalf("aptos client misconfigured: %v", err)
+//	}
+func (client *Client) Preflight(ctx context.Context, abis ...ModuleId) (report *PreflightReport, err error) {
+	report = &PreflightReport{}
+	nodeClient := client.nodeClient.WithContext(ctx)
+	configuredChainId := client.nodeClient.chainId
+
+	run := func(name string, check func() error) {
+		start := time.Now()
+		err := check()
+		report.Checks = append(report.Checks, PreflightCheck{Name: name, Latency: time.Since(start), Err: err})
+	}
+	skip := func(name string) {
+		report.Checks = append(report.Checks, PreflightCheck{Name: name, Skipped: true})
+	}
+
+	nodeOk := false
+	run(PreflightNode, func() error {
+		info, err := nodeClient.Info()
+		if err != nil {
+			return err
+		}
+		nodeOk = true
+		report.ChainId = info.ChainId
+		report.LedgerVersion = info.LedgerVersion()
+		report.LedgerTime = time.UnixMicro(int64(info.LedgerTimestamp()))
+		return nil
+	})
+	if !nodeOk {
+		// Nothing else on the node can pass
+		skip(PreflightChainId)
+		skip(PreflightGasPrice)
+		for _, module := range abis {
+			skip(preflightAbiName(module))
+		}
+	} else {
+		run(PreflightChainId, func() error {
+			if configuredChainId == 0 {
+				// Cache it for building transactions, a mismatch keeps the configured chain id so it fails loudly
+				client.nodeClient.chainId = report.ChainId
+				return nil
+			}
+			if configuredChainId != report.ChainId {
+				return fmt.Errorf("node chain id %d does not match configured chain id %d", report.ChainId, configuredChainId)
+			}
+			return nil
+		})
+		run(PreflightGasPrice, func() error {
+			info, err := nodeClient.EstimateGasPrice()
+			report.GasEstimate = info.GasEstimate
+			return err
+		})
+		for _, module := range abis {
+			run(preflightAbiName(module), func() error {
+				_, err := nodeClient.ModuleAbi(module)
+				return err
+			})
+		}
+	}
+
+	if client.indexerClient == nil {
+		skip(PreflightIndexer)
+	} else {
+		run(PreflightIndexer, func() error {
+			var q struct {
+				LedgerInfos []struct {
+					ChainId uint64 `graphql:"chain_id"`
+				} `graphql:"ledger_infos"`
+			}
+			err := client.indexerClient.inner.Query(ctx, &q, nil)
+			if err != nil {
+				return err
+			}
+			if len(q.LedgerInfos) == 0 {
+				return errors.New("indexer has no ledger info")
+			}
+			report.IndexerChainId = uint8(q.LedgerInfos[0].ChainId)
+			if nodeOk && report.IndexerChainId != report.ChainId {
+				return fmt.Errorf("indexer chain id %d does not match node chain id %d", report.IndexerChainId, report.ChainId)
+			}
+			return nil
+		})
+	}
+
+	if client.faucetClient == nil {
+		skip(PreflightFaucet)
+	} else {
+		run(PreflightFaucet, func() error {
+			req, err := http.NewRequestWithContext(ctx, "GET", client.faucetClient.url.String(), nil)
+			if err != nil {
+				return err
+			}
+			response, err := client.nodeClient.client.Do(req)
+			if err != nil {
+				return fmt.Errorf("GET %s, %w", client.faucetClient.url.String(), err)
+			}
+			if response.StatusCode >= 400 {
+				return NewHttpError(response)
+			}
+			_, _ = io.Copy(io.Discard, response.Body)
+			return response.Body.Close()
+		})
+	}
+
+	return report, report.Err()
+}
+
+// preflightAbiName names the ABI check of a module
+func preflightAbiName(module ModuleId) string {
+	return fmt.Sprintf("abi %s::%s", module.Address.String(), module.Name)
+}
@@ -0,0 +1,112 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func preflightServer(t *testing.T, nodeChainId uint8, indexerChainId uint8, faucetStatus int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1":
+			json.NewEncoder(w).Encode(map[string]any{"chain_id": nodeChainId, "ledger_version": "100", "ledger_timestamp": "1700000000000000"})
+		case "/v1/estimate_gas_price":
+			json.NewEncoder(w).Encode(map[string]any{"gas_estimate": 150})
+		case "/v1/accounts/0x1/module/coin":
+			json.NewEncoder(w).Encode(map[string]any{"bytecode": "0x00", "abi": map[string]any{"address": "0x1", "name": "coin"}})
+		case "/graphql":
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"ledger_infos": []map[string]any{{"chain_id": indexerChainId}}}})
+		case "/faucet":
+			w.WriteHeader(faucetStatus)
+		default:
+			t.Logf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClient_Preflight(t *testing.T) {
+	mockServer := preflightServer(t, 4, 4, http.StatusOK)
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{
+		ChainId:    4,
+		NodeUrl:    mockServer.URL + "/v1",
+		IndexerUrl: mockServer.URL + "/graphql",
+		FaucetUrl:  mockServer.URL + "/faucet",
+	})
+	assert.NoError(t, err)
+
+	coin := ModuleId{Address: AccountOne, Name: "coin"}
+	report, err := client.Preflight(context.Background(), coin)
+	assert.NoError(t, err)
+	assert.True(t, report.Ok())
+	assert.Equal(t, uint8(4), report.ChainId)
+	assert.Equal(t, uint8(4), report.IndexerChainId)
+	assert.Equal(t, uint64(100), report.LedgerVersion)
+	assert.Equal(t, int64(1700000000), report.LedgerTime.Unix())
+	assert.Equal(t, uint64(150), report.GasEstimate)
+	assert.Len(t, report.Checks, 6)
+	check, ok := report.Check("abi 0x1::coin")
+	assert.True(t, ok)
+	assert.NoError(t, check.Err)
+
+	// The ABI is cached
+	_, ok = client.nodeClient.moduleAbis.Load(coin)
+	assert.True(t, ok)
+}
+
+func TestClient_PreflightFailures(t *testing.T) {
+	mockServer := preflightServer(t, 2, 1, http.StatusInternalServerError)
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{
+		ChainId:    1,
+		NodeUrl:    mockServer.URL + "/v1",
+		IndexerUrl: mockServer.URL + "/graphql",
+		FaucetUrl:  mockServer.URL + "/faucet",
+	})
+	assert.NoError(t, err)
+
+	// Every check runs, and the failures are reported together
+	report, err := client.Preflight(context.Background(), ModuleId{Address: AccountOne, Name: "missing"})
+	assert.Error(t, err)
+	assert.False(t, report.Ok())
+	for _, name := range []string{PreflightChainId, PreflightIndexer, PreflightFaucet, "abi 0x1::missing"} {
+		check, ok := report.Check(name)
+		assert.True(t, ok, name)
+		assert.Error(t, check.Err, name)
+		assert.ErrorContains(t, err, "preflight "+name, name)
+	}
+	check, _ := report.Check(PreflightGasPrice)
+	assert.NoError(t, check.Err)
+
+	// The configured chain id is kept on a mismatch
+	assert.Equal(t, uint8(1), client.nodeClient.chainId)
+}
+
+func TestClient_PreflightNodeDown(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL})
+	assert.NoError(t, err)
+
+	report, err := client.Preflight(context.Background())
+	assert.Error(t, err)
+	check, _ := report.Check(PreflightNode)
+	assert.Error(t, check.Err)
+	for _, name := range []string{PreflightChainId, PreflightGasPrice, PreflightIndexer, PreflightFaucet} {
+		check, ok := report.Check(name)
+		assert.True(t, ok, name)
+		assert.True(t, check.Skipped, name)
+		assert.NoError(t, check.Err, name)
+	}
+}
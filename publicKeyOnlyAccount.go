@@ -0,0 +1,110 @@
+package aptos
+
+import (
+	"errors"
+
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+)
+
+// ErrPublicKeyOnly is returned when signing with a [PublicKeyOnlyAccount]
+var ErrPublicKeyOnly = errors.New("account has only a public key, it can't sign")
+
+// PublicKeyOnlyAccount is an account known by its address and public key, without any private key material.  It can
+// be used anywhere a [TransactionSigner] is needed to build or simulate transactions, so read path services never
+// need to load a private key.  Signing returns [ErrPublicKeyOnly].
+//
+//	sender := NewPublicKeyOnlyAccount(publicKey)
+//	rawTxn, err := client.BuildTransaction(sender.AccountAddress(), payload)
+//	simulated, err := client.SimulateTransaction(rawTxn, sender)
+//
+// Ed25519 and single key public keys simulate with an empty signature.  Other keys, such as multi keys, simulate
+// without an authenticator, which skips the authentication key check.
+//
+// Implements:
+//   - [TransactionSigner]
+type PublicKeyOnlyAccount struct {
+	Address   AccountAddress   // Address of the account, which may differ from the public key's if the key was rotated
+	PublicKey crypto.PublicKey // PublicKey of the account
+}
+
+// NewPublicKeyOnlyAccount creates a [PublicKeyOnlyAccount], with the address derived from the public key unless it's
+// given e.g. for rotated keys
+func NewPublicKeyOnlyAccount(publicKey crypto.PublicKey, address ...AccountAddress) *PublicKeyOnlyAccount {
+	account := &PublicKeyOnlyAccount{PublicKey: publicKey}
+	if len(address) > 0 {
+		account.Address = address[0]
+	} else {
+		account.Address.FromAuthKey(publicKey.AuthKey())
+	}
+	return account
+}
+
+// AccountAddress returns the address of the account
+//
+// Implements:
+//   - [TransactionSigner]
+func (account *PublicKeyOnlyAccount) AccountAddress() AccountAddress {
+	return account.Address
+}
+
+// Sign always fails with [ErrPublicKeyOnly]
+//
+// Implements:
+//   - [crypto.Signer]
+func (account *PublicKeyOnlyAccount) Sign([]byte) (*crypto.AccountAuthenticator, error) {
+	return nil, ErrPublicKeyOnly
+}
+
+// SignMessage always fails with [ErrPublicKeyOnly]
+//
+// Implements:
+//   - [crypto.Signer]
+func (account *PublicKeyOnlyAccount) SignMessage([]byte) (crypto.Signature, error) {
+	return nil, ErrPublicKeyOnly
+}
+
+// SimulationAuthenticator creates an [crypto.AccountAuthenticator] with the public key and an empty signature
+//
+// Implements:
+//   - [crypto.Signer]
+func (account *PublicKeyOnlyAccount) SimulationAuthenticator() *crypto.AccountAuthenticator {
+	switch publicKey := account.PublicKey.(type) {
+	case *crypto.Ed25519PublicKey:
+		return &crypto.AccountAuthenticator{
+			Variant: crypto.AccountAuthenticatorEd25519,
+			Auth:    &crypto.Ed25519Authenticator{PubKey: publicKey, Sig: &crypto.Ed25519Signature{}},
+		}
+	case *crypto.AnyPublicKey:
+		var signature *crypto.AnySignature
+		switch publicKey.Variant {
+		case crypto.AnyPublicKeyVariantEd25519:
+			signature = &crypto.AnySignature{Variant: crypto.AnySignatureVariantEd25519, Signature: &crypto.Ed25519Signature{}}
+		case crypto.AnyPublicKeyVariantSecp256k1:
+			signature = &crypto.AnySignature{Variant: crypto.AnySignatureVariantSecp256k1, Signature: (&crypto.Secp256k1PrivateKey{}).EmptySignature()}
+		default:
+			return crypto.NoAccountAuthenticator()
+		}
+		return &crypto.AccountAuthenticator{
+			Variant: crypto.AccountAuthenticatorSingleSender,
+			Auth:    &crypto.SingleKeyAuthenticator{PubKey: publicKey, Sig: signature},
+		}
+	default:
+		return crypto.NoAccountAuthenticator()
+	}
+}
+
+// AuthKey gives the [crypto.AuthenticationKey] of the public key
+//
+// Implements:
+//   - [crypto.Signer]
+func (account *PublicKeyOnlyAccount) AuthKey() *crypto.AuthenticationKey {
+	return account.PublicKey.AuthKey()
+}
+
+// PubKey returns the public key
+//
+// Implements:
+//   - [crypto.Signer]
+func (account *PublicKeyOnlyAccount) PubKey() crypto.PublicKey {
+	return account.PublicKey
+}
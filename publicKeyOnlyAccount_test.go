@@ -0,0 +1,42 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicKeyOnlyAccount(t *testing.T) {
+	ed25519Account, err := NewEd25519Account()
+	assert.NoError(t, err)
+	secp256k1Account, err := NewSecp256k1Account()
+	assert.NoError(t, err)
+
+	for _, account := range []*Account{ed25519Account, secp256k1Account} {
+		publicOnly := NewPublicKeyOnlyAccount(account.PubKey())
+		assert.Equal(t, account.Address, publicOnly.AccountAddress())
+		assert.Equal(t, account.AuthKey(), publicOnly.AuthKey())
+
+		// Simulates exactly as the private key would
+		expected, err := bcs.Serialize(account.SimulationAuthenticator())
+		assert.NoError(t, err)
+		actual, err := bcs.Serialize(publicOnly.SimulationAuthenticator())
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		_, err = publicOnly.Sign([]byte("hello"))
+		assert.ErrorIs(t, err, ErrPublicKeyOnly)
+		_, err = publicOnly.SignMessage([]byte("hello"))
+		assert.ErrorIs(t, err, ErrPublicKeyOnly)
+	}
+
+	rotated := NewPublicKeyOnlyAccount(ed25519Account.PubKey(), AccountTwo)
+	assert.Equal(t, AccountTwo, rotated.AccountAddress())
+
+	// Keys without a simple empty signature skip authentication
+	multiKey, err := crypto.NewMultiEd25519PublicKey([]*crypto.Ed25519PublicKey{ed25519Account.PubKey().(*crypto.Ed25519PublicKey)}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.AccountAuthenticatorNone, NewPublicKeyOnlyAccount(multiKey).SimulationAuthenticator().Variant)
+}
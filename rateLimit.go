@@ -0,0 +1,88 @@
+package aptos
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit limits the requests of a client with a token bucket, so back-fills stay under a node's rate limits
+// instead of being rejected or banned.  Pass it to [NewClient] to apply it to every node, indexer, and faucet request,
+// each retry by a [RetryPolicy] included.  Requests wait for a token, or until their context is done.
+//
+//	client, err := NewClient(MainnetConfig, RateLimit{RequestsPerSecond: 10, Burst: 20})
+type RateLimit struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second" json:"requests_per_second"` // RequestsPerSecond is the sustained rate, no limit if 0 or less
+	Burst             int     `yaml:"burst,omitempty" json:"burst,omitempty"`         // Burst is the number of requests sent at once before waiting, defaults to 1
+}
+
+// Transport wraps an [http.RoundTripper] to limit the rate of requests, [http.DefaultTransport] if inner is nil.  The
+// limit is shared by every request sent through the returned transport.
+func (limit RateLimit) Transport(inner http.RoundTripper) http.RoundTripper {
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	if limit.RequestsPerSecond <= 0 {
+		return inner
+	}
+	burst := float64(max(limit.Burst, 1))
+	return &rateLimitTransport{
+		inner:   inner,
+		limiter: &tokenBucket{rate: limit.RequestsPerSecond, burst: burst, tokens: burst, last: time.Now()},
+	}
+}
+
+// tokenBucket holds up to burst tokens, refilled at rate per second
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64 // tokens may be negative, for the requests already waiting
+	last   time.Time
+}
+
+// wait takes a token, waiting for one if needed
+func (bucket *tokenBucket) wait(ctx context.Context) error {
+	bucket.mutex.Lock()
+	now := time.Now()
+	bucket.tokens = math.Min(bucket.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*bucket.rate)
+	bucket.last = now
+	bucket.tokens--
+	delay := time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+	bucket.mutex.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the token back for the next request
+		bucket.mutex.Lock()
+		bucket.tokens++
+		bucket.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+// rateLimitTransport waits for a token of the limiter before each request
+type rateLimitTransport struct {
+	inner   http.RoundTripper
+	limiter *tokenBucket
+}
+
+func (t *rateLimitTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(request.Context()); err != nil {
+		// The body must be closed, as the inner transport won't see it
+		if request.Body != nil {
+			_ = request.Body.Close()
+		}
+		return nil, err
+	}
+	return t.inner.RoundTrip(request)
+}
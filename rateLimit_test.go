@@ -0,0 +1,63 @@
+package aptos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_Client(t *testing.T) {
+	requests := atomic.Int32{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"sequence_number": "7", "authentication_key": "0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4}, RateLimit{RequestsPerSecond: 20, Burst: 2})
+	assert.NoError(t, err)
+
+	// The burst goes at once, and the rest at the rate
+	start := time.Now()
+	for range 4 {
+		_, err = client.Account(AccountOne)
+		assert.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	assert.Equal(t, int32(4), requests.Load())
+
+	// A request can stop waiting
+	client, err = NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4}, RateLimit{RequestsPerSecond: 1})
+	assert.NoError(t, err)
+	_, err = client.Account(AccountOne)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = client.WithContext(ctx).Account(AccountOne)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, int32(5), requests.Load())
+}
+
+func TestTokenBucket(t *testing.T) {
+	bucket := &tokenBucket{rate: 1000, burst: 3, tokens: 3, last: time.Now()}
+	for range 3 {
+		assert.NoError(t, bucket.wait(context.Background()))
+	}
+	assert.Less(t, bucket.tokens, 1.0)
+
+	// Refills up to the burst
+	bucket.last = time.Now().Add(-time.Hour)
+	assert.NoError(t, bucket.wait(context.Background()))
+	assert.InDelta(t, 2.0, bucket.tokens, 0.1)
+
+	// No limit without a rate
+	inner := http.DefaultTransport
+	assert.Equal(t, inner, RateLimit{}.Transport(inner))
+}
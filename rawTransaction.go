@@ -1,10 +1,12 @@
 package aptos
 
 import (
+	"errors"
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"golang.org/x/crypto/sha3"
+	"slices"
 	"sync"
 )
 
@@ -156,13 +158,94 @@ type RawTransactionWithDataImpl interface {
 	bcs.Struct
 }
 
-// TODO: make a function to make creating this easier
-
+// RawTransactionWithData is a [RawTransaction] with additional signer data, used for multi-agent and fee payer
+// (sponsored) transactions.  Use [NewMultiAgentRawTransaction] or [NewFeePayerRawTransaction] to create one.
 type RawTransactionWithData struct {
 	Variant RawTransactionWithDataVariant
 	Inner   RawTransactionWithDataImpl
 }
 
+// NewMultiAgentRawTransaction wraps rawTxn so that it must also be signed by the secondarySigners
+func NewMultiAgentRawTransaction(rawTxn *RawTransaction, secondarySigners ...AccountAddress) *RawTransactionWithData {
+	if secondarySigners == nil {
+		secondarySigners = []AccountAddress{}
+	}
+	return &RawTransactionWithData{
+		Variant: MultiAgentRawTransactionWithDataVariant,
+		Inner: &MultiAgentRawTransactionWithData{
+			RawTxn:           rawTxn,
+			SecondarySigners: secondarySigners,
+		},
+	}
+}
+
+// NewFeePayerRawTransaction wraps rawTxn so that gas is paid by feePayer rather than the sender
+//
+// If the fee payer is not known yet, use [AccountZero] and the fee payer can fill itself in with
+// [RawTransactionWithData.SignAsFeePayer]
+func NewFeePayerRawTransaction(rawTxn *RawTransaction, feePayer AccountAddress, secondarySigners ...AccountAddress) *RawTransactionWithData {
+	if secondarySigners == nil {
+		secondarySigners = []AccountAddress{}
+	}
+	return &RawTransactionWithData{
+		Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+		Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+			RawTxn:           rawTxn,
+			SecondarySigners: secondarySigners,
+			FeePayer:         &feePayer,
+		},
+	}
+}
+
+// FeePayer returns the fee payer address, and false if this is not a fee payer transaction
+func (txn *RawTransactionWithData) FeePayer() (AccountAddress, bool) {
+	if txn.Variant != MultiAgentWithFeePayerRawTransactionWithDataVariant {
+		return AccountAddress{}, false
+	}
+	inner := txn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	if inner.FeePayer == nil {
+		return AccountAddress{}, false
+	}
+	return *inner.FeePayer, true
+}
+
+// SignAsSender signs the transaction as the sender or as a secondary signer.  This is the same as
+// [RawTransactionWithData.Sign], but checks that the signer is one of the transaction's signers.
+func (txn *RawTransactionWithData) SignAsSender(signer TransactionSigner) (*crypto.AccountAuthenticator, error) {
+	var rawTxn *RawTransaction
+	var secondarySigners []AccountAddress
+	switch inner := txn.Inner.(type) {
+	case *MultiAgentRawTransactionWithData:
+		rawTxn, secondarySigners = inner.RawTxn, inner.SecondarySigners
+	case *MultiAgentWithFeePayerRawTransactionWithData:
+		rawTxn, secondarySigners = inner.RawTxn, inner.SecondarySigners
+	default:
+		return nil, fmt.Errorf("unknown RawTransactionWithData variant %d", txn.Variant)
+	}
+
+	address := signer.AccountAddress()
+	if rawTxn.Sender != address && !slices.Contains(secondarySigners, address) {
+		return nil, fmt.Errorf("signer %s is not the sender or a secondary signer of the transaction", address.String())
+	}
+	return txn.Sign(signer)
+}
+
+// SignAsFeePayer signs the transaction as the fee payer.  If the fee payer was left as [AccountZero], it is set to the
+// signer's address first, which allows the sender to sign before the sponsor is known.
+func (txn *RawTransactionWithData) SignAsFeePayer(signer TransactionSigner) (*crypto.AccountAuthenticator, error) {
+	feePayer, ok := txn.FeePayer()
+	if !ok {
+		return nil, errors.New("transaction is not a fee payer transaction")
+	}
+	address := signer.AccountAddress()
+	if feePayer == AccountZero {
+		txn.SetFeePayer(address)
+	} else if feePayer != address {
+		return nil, fmt.Errorf("signer %s is not the fee payer %s", address.String(), feePayer.String())
+	}
+	return txn.Sign(signer)
+}
+
 func (txn *RawTransactionWithData) SetFeePayer(
 	feePayer AccountAddress,
 ) bool {
@@ -0,0 +1,112 @@
+package aptos
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// ReloadableClient holds a [Client] whose endpoints, API key, headers, and gas policy can be replaced at runtime, for
+// long-running services that can't restart to move to another fullnode.  Requests started before an update finish on
+// the previous client's settings, and requests started after it use the new ones.
+//
+//	reloadable, err := NewReloadableClient(config)
+//	stop := reloadable.ReloadOnSignal("aptos.yaml", nil)
+//	defer stop()
+//	info, err := reloadable.Client().Info()
+//
+// Take the client with [ReloadableClient.Client] for each unit of work, rather than keeping it, so updates are seen.
+type ReloadableClient struct {
+	current atomic.Pointer[reloadableClientState]
+	options []any // options are passed to [NewClient] on every update
+}
+
+// reloadableClientState is a client and the config it was built from, swapped together
+type reloadableClientState struct {
+	client *Client
+	config ClientConfig
+}
+
+// NewReloadableClient creates a [ReloadableClient] from the config, options are passed to [NewClient] on creation and
+// on every update, e.g. [RequireSuccessfulSimulation]
+func NewReloadableClient(config *ClientConfig, options ...any) (*ReloadableClient, error) {
+	reloadable := &ReloadableClient{options: options}
+	err := reloadable.Update(config)
+	if err != nil {
+		return nil, err
+	}
+	return reloadable, nil
+}
+
+// Client returns the client with the latest settings
+func (reloadable *ReloadableClient) Client() *Client {
+	return reloadable.current.Load().client
+}
+
+// Config returns a copy of the config of the latest settings
+func (reloadable *ReloadableClient) Config() ClientConfig {
+	return reloadable.current.Load().config
+}
+
+// Update builds a client from the config and swaps it in.  If the config is invalid, the previous client is kept and
+// the error is returned.
+func (reloadable *ReloadableClient) Update(config *ClientConfig) error {
+	client, err := config.NewClient(reloadable.options...)
+	if err != nil {
+		return err
+	}
+	reloadable.current.Store(&reloadableClientState{client: client, config: *config})
+	return nil
+}
+
+// UpdateFromFile loads the config file, see [LoadClientConfig], and swaps in a client built from it
+func (reloadable *ReloadableClient) UpdateFromFile(path string) error {
+	config, err := LoadClientConfig(path)
+	if err != nil {
+		return err
+	}
+	return reloadable.Update(config)
+}
+
+// ReloadOnSignal reloads the config file whenever the process receives one of the signals, SIGHUP if none are given.
+// Reload errors go to onError, or are logged if it's nil, and the previous client is kept.  Call stop to stop
+// reloading.
+//
+//	stop := reloadable.ReloadOnSignal("aptos.yaml", func(err error) { log.Printf("config reload failed: %v", err) })
+//	defer stop()
+func (reloadable *ReloadableClient) ReloadOnSignal(path string, onError func(err error), signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+	received := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(received, signals...)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-received:
+				err := reloadable.UpdateFromFile(path)
+				if err == nil {
+					continue
+				}
+				if onError != nil {
+					onError(err)
+				} else {
+					slog.Warn("failed to reload client config", "path", path, "err", err)
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReloadableClient_Update(t *testing.T) {
+	// The old node holds its request until the update is done
+	release := make(chan struct{})
+	started := make(chan struct{})
+	oldServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer old", r.Header.Get("Authorization"))
+		close(started)
+		<-release
+		_ = json.NewEncoder(w).Encode(map[string]any{"chain_id": 4, "ledger_version": "1"})
+	}))
+	defer oldServer.Close()
+	newServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer new", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(map[string]any{"chain_id": 4, "ledger_version": "2"})
+	}))
+	defer newServer.Close()
+
+	reloadable, err := NewReloadableClient(&ClientConfig{ChainId: 4, NodeUrl: oldServer.URL, ApiKey: "old"})
+	assert.NoError(t, err)
+
+	inFlight := make(chan NodeInfo)
+	go func() {
+		info, err := reloadable.Client().Info()
+		assert.NoError(t, err)
+		inFlight <- info
+	}()
+	<-started
+
+	// An invalid config keeps the previous client
+	assert.Error(t, reloadable.Update(&ClientConfig{Network: "nonexistent"}))
+	assert.Equal(t, oldServer.URL, reloadable.Config().NodeUrl)
+
+	assert.NoError(t, reloadable.Update(&ClientConfig{ChainId: 4, NodeUrl: newServer.URL, ApiKey: "new"}))
+	close(release)
+	assert.Equal(t, uint64(1), (<-inFlight).LedgerVersion())
+
+	info, err := reloadable.Client().Info()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), info.LedgerVersion())
+}
+
+func TestReloadableClient_UpdateFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aptos.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("network: localnet\ngas:\n  max_gas_amount: 5000\n  gas_unit_price: 150\n"), 0o600))
+
+	reloadable, err := NewReloadableClient(&ClientConfig{Network: "localnet"})
+	assert.NoError(t, err)
+	assert.NoError(t, reloadable.UpdateFromFile(path))
+
+	// The gas policy applies to built transactions, unless overridden
+	build := func(options ...any) *RawTransaction {
+		options = append(options, SequenceNumber(1), ChainIdOption(4))
+		rawTxn, err := reloadable.Client().BuildTransaction(AccountOne, TransactionPayload{Payload: ObjectTransferCallPayload(AccountTwo, AccountThree)}, options...)
+		assert.NoError(t, err)
+		return rawTxn
+	}
+	rawTxn := build()
+	assert.Equal(t, uint64(5000), rawTxn.MaxGasAmount)
+	assert.Equal(t, uint64(150), rawTxn.GasUnitPrice)
+	rawTxn = build(MaxGasAmount(7000))
+	assert.Equal(t, uint64(7000), rawTxn.MaxGasAmount)
+
+	assert.NoError(t, os.WriteFile(path, []byte("network: localnet\ngas:\n  priority: urgent\n"), 0o600))
+	assert.ErrorContains(t, reloadable.UpdateFromFile(path), "unknown gas priority")
+	assert.Equal(t, uint64(5000), reloadable.Config().Gas.MaxGasAmount)
+}
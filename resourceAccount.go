@@ -0,0 +1,75 @@
+package aptos
+
+import (
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// resourceAccountModule is the 0x1::resource_account module
+var resourceAccountModule = ModuleId{Address: AccountOne, Name: "resource_account"}
+
+// ResourceAccountAddress derives the address of the resource account created by creator with the seed
+//
+//	deployer := ResourceAccountAddress(creator, []byte("my_app"))
+func ResourceAccountAddress(creator AccountAddress, seed []byte) AccountAddress {
+	return creator.ResourceAccount(seed)
+}
+
+// ResourceAccountCreatePayload builds a 0x1::resource_account::create_resource_account of the resource account at
+// [ResourceAccountAddress] of the sender and seed.  The resource account's authentication key is optionalAuthKey, or the
+// sender's if it's empty, so the sender can sign for it until it's retrieved with its signer capability.
+func ResourceAccountCreatePayload(seed []byte, optionalAuthKey []byte) (*EntryFunction, error) {
+	seedBytes, err := bcs.SerializeBytes(seed)
+	if err != nil {
+		return nil, err
+	}
+	authKeyBytes, err := bcs.SerializeBytes(optionalAuthKey)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryFunction{
+		Module:   resourceAccountModule,
+		Function: "create_resource_account",
+		ArgTypes: []TypeTag{},
+		Args:     [][]byte{seedBytes, authKeyBytes},
+	}, nil
+}
+
+// ResourceAccountCreateAndFundPayload builds a 0x1::resource_account::create_resource_account_and_fund, which creates
+// the resource account like [ResourceAccountCreatePayload] and transfers it fundAmount octas from the sender
+func ResourceAccountCreateAndFundPayload(seed []byte, optionalAuthKey []byte, fundAmount uint64) (*EntryFunction, error) {
+	payload, err := ResourceAccountCreatePayload(seed, optionalAuthKey)
+	if err != nil {
+		return nil, err
+	}
+	fundAmountBytes, err := bcs.SerializeU64(fundAmount)
+	if err != nil {
+		return nil, err
+	}
+	payload.Function = "create_resource_account_and_fund"
+	payload.Args = append(payload.Args, fundAmountBytes)
+	return payload, nil
+}
+
+// ResourceAccountCreateAndPublishPackagePayload builds a
+// 0x1::resource_account::create_resource_account_and_publish_package, which creates the resource account at
+// [ResourceAccountAddress] of the sender and seed, and publishes the package to it.  The resource account's
+// authentication key is cleared, so only the package can get its signer, from its init_module.
+//
+// metadata and bytecode are the same as for [PublishPackagePayloadFromJsonFile], compiled with the package's named
+// address set to the resource account address.
+func ResourceAccountCreateAndPublishPackagePayload(seed []byte, metadata []byte, bytecode [][]byte) (*EntryFunction, error) {
+	seedBytes, err := bcs.SerializeBytes(seed)
+	if err != nil {
+		return nil, err
+	}
+	publishArgs, err := publishPackageArgs(metadata, bytecode)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryFunction{
+		Module:   resourceAccountModule,
+		Function: "create_resource_account_and_publish_package",
+		ArgTypes: []TypeTag{},
+		Args:     append([][]byte{seedBytes}, publishArgs...),
+	}, nil
+}
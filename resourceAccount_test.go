@@ -0,0 +1,30 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceAccountAddress(t *testing.T) {
+	address := ResourceAccountAddress(AccountOne, []byte("seed"))
+	assert.Equal(t, "0xf38401f1afe8001e6403d419628d8190fe67f0442d24d106d2592946d205aba4", address.String())
+}
+
+func TestResourceAccountPayloads(t *testing.T) {
+	create, err := ResourceAccountCreatePayload([]byte("seed"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "create_resource_account", create.Function)
+	assert.Equal(t, [][]byte{{4, 's', 'e', 'e', 'd'}, {0}}, create.Args)
+
+	fund, err := ResourceAccountCreateAndFundPayload([]byte("seed"), []byte{1, 2}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, "create_resource_account_and_fund", fund.Function)
+	assert.Equal(t, [][]byte{{4, 's', 'e', 'e', 'd'}, {2, 1, 2}, {100, 0, 0, 0, 0, 0, 0, 0}}, fund.Args)
+
+	publish, err := ResourceAccountCreateAndPublishPackagePayload([]byte("seed"), []byte{0xaa}, [][]byte{{0xbb}, {0xcc, 0xdd}})
+	assert.NoError(t, err)
+	assert.Equal(t, resourceAccountModule, publish.Module)
+	assert.Equal(t, "create_resource_account_and_publish_package", publish.Function)
+	assert.Equal(t, [][]byte{{4, 's', 'e', 'e', 'd'}, {1, 0xaa}, {2, 1, 0xbb, 2, 0xcc, 0xdd}}, publish.Args)
+}
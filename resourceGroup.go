@@ -0,0 +1,77 @@
+package aptos
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// ObjectGroupResourceType is the resource group holding the resources of objects, e.g. 0x1::object::ObjectCore,
+// 0x1::fungible_asset::Metadata, and 0x4::token::Token
+const ObjectGroupResourceType = "0x1::object::ObjectGroup"
+
+// ResourceGroupTypes are the resource groups flattened by [NodeClient.AccountResourcesBCS] and searched by
+// [NodeClient.AccountResourceBCS].  Append to it for resource groups declared by other contracts.
+var ResourceGroupTypes = []string{ObjectGroupResourceType}
+
+// IsResourceGroup checks if the tag is one of [ResourceGroupTypes]
+func IsResourceGroup(tag *StructTag) bool {
+	return slices.Contains(ResourceGroupTypes, tag.String())
+}
+
+// DecodeResourceGroup decodes the BCS of a resource group, a BTreeMap<StructTag, Vec<u8>> of its member resources
+func DecodeResourceGroup(data []byte) ([]AccountResourceRecord, error) {
+	des := bcs.NewDeserializer(data)
+	members := bcs.DeserializeSequence[AccountResourceRecord](des)
+	if des.Error() != nil {
+		return nil, fmt.Errorf("failed to decode resource group: %w", des.Error())
+	}
+	if des.Remaining() != 0 {
+		return nil, fmt.Errorf("failed to decode resource group: %d bytes left over", des.Remaining())
+	}
+	return members, nil
+}
+
+// FlattenResourceGroups replaces each resource group in records with its member resources, so grouped resources can
+// be found the same way as ungrouped ones
+func FlattenResourceGroups(records []AccountResourceRecord) ([]AccountResourceRecord, error) {
+	out := make([]AccountResourceRecord, 0, len(records))
+	for _, record := range records {
+		if !IsResourceGroup(&record.Tag) {
+			out = append(out, record)
+			continue
+		}
+		members, err := DecodeResourceGroup(record.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", record.Tag.String(), err)
+		}
+		out = append(out, members...)
+	}
+	return out, nil
+}
+
+// findInResourceGroups looks for a grouped resource in each of [ResourceGroupTypes], for nodes that don't look inside
+// resource groups when fetching a single resource
+func (rc *NodeClient) findInResourceGroups(address AccountAddress, resourceType string, ledgerVersion []uint64) ([]byte, bool, error) {
+	for _, groupType := range ResourceGroupTypes {
+		group, err := rc.getResourceBCS(address, groupType, ledgerVersion)
+		if HasErrorCode(err, api.ErrorCodeResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		members, err := DecodeResourceGroup(group)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", groupType, err)
+		}
+		for _, member := range members {
+			if member.Tag.String() == resourceType {
+				return member.Data, true, nil
+			}
+		}
+	}
+	return nil, false, nil
+}
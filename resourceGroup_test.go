@@ -0,0 +1,94 @@
+package aptos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func testResourceRecord(t *testing.T, resourceType string, data []byte) AccountResourceRecord {
+	tag, err := ParseTypeTag(resourceType)
+	assert.NoError(t, err)
+	return AccountResourceRecord{Tag: *tag.Value.(*StructTag), Data: data}
+}
+
+func TestFlattenResourceGroups(t *testing.T) {
+	group, err := bcs.SerializeSequenceOnly([]AccountResourceRecord{
+		testResourceRecord(t, ObjectCoreResourceType, []byte{1}),
+		testResourceRecord(t, FungibleAssetMetadataResourceType, []byte{2}),
+	})
+	assert.NoError(t, err)
+
+	flattened, err := FlattenResourceGroups([]AccountResourceRecord{
+		testResourceRecord(t, AccountResourceType, []byte{0}),
+		testResourceRecord(t, ObjectGroupResourceType, group),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, flattened, 3)
+	assert.Equal(t, AccountResourceType, flattened[0].Tag.String())
+	assert.Equal(t, ObjectCoreResourceType, flattened[1].Tag.String())
+	assert.Equal(t, []byte{1}, flattened[1].Data)
+	assert.Equal(t, FungibleAssetMetadataResourceType, flattened[2].Tag.String())
+	assert.Equal(t, []byte{2}, flattened[2].Data)
+
+	_, err = FlattenResourceGroups([]AccountResourceRecord{testResourceRecord(t, ObjectGroupResourceType, []byte{5})})
+	assert.Error(t, err)
+	_, err = DecodeResourceGroup(append(group, 0))
+	assert.Error(t, err)
+}
+
+func TestClient_AccountResourcesBCSGrouped(t *testing.T) {
+	group, err := bcs.SerializeSequenceOnly([]AccountResourceRecord{
+		testResourceRecord(t, ObjectCoreResourceType, []byte{1}),
+		testResourceRecord(t, FungibleAssetMetadataResourceType, []byte{2}),
+	})
+	assert.NoError(t, err)
+	resources, err := bcs.SerializeSequenceOnly([]AccountResourceRecord{
+		testResourceRecord(t, ObjectGroupResourceType, group),
+	})
+	assert.NoError(t, err)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-bcs", r.Header.Get("Accept"))
+		switch r.URL.Path {
+		case "/accounts/0xa/resources":
+			_, _ = w.Write(resources)
+		case "/accounts/0xa/resource/" + ObjectGroupResourceType:
+			_, _ = w.Write(group)
+		case "/accounts/0xa/resource/" + ObjectCoreResourceType:
+			// Nodes which look inside resource groups return the member directly
+			_, _ = w.Write([]byte{1})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found", "error_code": "resource_not_found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+	address := AccountAddress{}
+	assert.NoError(t, address.ParseStringRelaxed("0xa"))
+
+	records, err := client.AccountResourcesBCS(address)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, ObjectCoreResourceType, records[0].Tag.String())
+	assert.Equal(t, FungibleAssetMetadataResourceType, records[1].Tag.String())
+
+	data, err := client.AccountResourceBCS(address, ObjectCoreResourceType)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1}, data)
+
+	// Older nodes only return the group, so the member is found inside it
+	data, err = client.AccountResourceBCS(address, FungibleAssetMetadataResourceType)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2}, data)
+
+	_, err = client.AccountResourceBCS(address, FungibleStoreResourceType)
+	assert.True(t, HasErrorCode(err, api.ErrorCodeResourceNotFound))
+}
@@ -0,0 +1,100 @@
+package aptos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// resourceHistoryPageSize is the number of write set changes fetched per indexer request by
+// [IndexerClient.ResourceHistory]
+const resourceHistoryPageSize = 100
+
+// ResourceVersion is the value of a resource as written by one transaction
+type ResourceVersion struct {
+	Version             uint64          // Version of the transaction that wrote the resource
+	WriteSetChangeIndex uint64          // WriteSetChangeIndex is the index of the change in the transaction's write set
+	IsDeletion          bool            // IsDeletion is true if the transaction deleted the resource
+	Data                json.RawMessage // Data is the JSON value of the resource, empty if deleted
+}
+
+// Into decodes the resource value into out, e.g. a struct matching the resource's fields
+//
+//	var pool struct {
+//		FeeBps string `json:"fee_bps"`
+//	}
+//	err := version.Into(&pool)
+func (rv *ResourceVersion) Into(out any) error {
+	if rv.IsDeletion || len(rv.Data) == 0 {
+		return fmt.Errorf("resource was deleted at version %d", rv.Version)
+	}
+	return json.Unmarshal(rv.Data, out)
+}
+
+// ResourceHistory gets every value a resource had between fromVersion and toVersion inclusive, oldest first, from the
+// write set changes recorded by the indexer.  A resource is only returned at the versions it was written, so the value
+// at any version in the range is the latest one written at or before it.
+//
+//	history, err := client.ResourceHistory(pool, "0xcafe::pool::Config", 0, math.MaxInt64)
+//	for _, version := range history {
+//		var config PoolConfig
+//		if err := version.Into(&config); err == nil {
+//			fmt.Printf("%d: fee %s\n", version.Version, config.FeeBps)
+//		}
+//	}
+func (ic *IndexerClient) ResourceHistory(address AccountAddress, resourceType string, fromVersion uint64, toVersion uint64) ([]ResourceVersion, error) {
+	if fromVersion > toVersion {
+		return nil, fmt.Errorf("from version %d is after to version %d", fromVersion, toVersion)
+	}
+	if resourceType == "" {
+		return nil, errors.New("resource type is required")
+	}
+
+	var out []ResourceVersion
+	for offset := 0; ; offset += resourceHistoryPageSize {
+		var q struct {
+			MoveResources []struct {
+				TransactionVersion  uint64          `graphql:"transaction_version"`
+				WriteSetChangeIndex uint64          `graphql:"write_set_change_index"`
+				IsDeletion          bool            `graphql:"is_deletion"`
+				Data                json.RawMessage `graphql:"data"`
+			} `graphql:"move_resources(where: {address: {_eq: $address}, type: {_eq: $type}, transaction_version: {_gte: $from, _lte: $to}}, order_by: [{transaction_version: asc}, {write_set_change_index: asc}], limit: $limit, offset: $offset)"`
+		}
+		variables := map[string]any{
+			"address": address.StringLong(),
+			"type":    resourceType,
+			"from":    indexerBigint(fromVersion),
+			"to":      indexerBigint(toVersion),
+			"limit":   resourceHistoryPageSize,
+			"offset":  offset,
+		}
+		err := ic.Query(&q, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range q.MoveResources {
+			version := ResourceVersion{
+				Version:             resource.TransactionVersion,
+				WriteSetChangeIndex: resource.WriteSetChangeIndex,
+				IsDeletion:          resource.IsDeletion,
+			}
+			if !resource.IsDeletion && string(resource.Data) != "null" {
+				version.Data = resource.Data
+			}
+			out = append(out, version)
+		}
+		if len(q.MoveResources) < resourceHistoryPageSize {
+			return out, nil
+		}
+	}
+}
+
+// ResourceHistory gets every value a resource had between fromVersion and toVersion inclusive, oldest first.
+// Requires an indexer, or returns [ErrNoIndexer].
+func (client *Client) ResourceHistory(address AccountAddress, resourceType string, fromVersion uint64, toVersion uint64) ([]ResourceVersion, error) {
+	if client.indexerClient == nil {
+		return nil, ErrNoIndexer
+	}
+	return client.indexerClient.ResourceHistory(address, resourceType, fromVersion, toVersion)
+}
@@ -0,0 +1,71 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ResourceHistory(t *testing.T) {
+	pool := AccountAddress{}
+	assert.NoError(t, pool.ParseStringRelaxed("0xcafe"))
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string
+			Variables map[string]any
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Contains(t, request.Query, "$from:bigint!")
+		assert.Equal(t, pool.StringLong(), request.Variables["address"])
+		assert.Equal(t, "0xcafe::pool::Config", request.Variables["type"])
+		assert.Equal(t, float64(10), request.Variables["from"])
+		assert.Equal(t, float64(1000), request.Variables["to"])
+		assert.Equal(t, float64(0), request.Variables["offset"])
+
+		_, _ = w.Write([]byte(`{"data": {"move_resources": [
+			{"transaction_version": 20, "write_set_change_index": 3, "is_deletion": false, "data": {"fee_bps": "30"}},
+			{"transaction_version": 500, "write_set_change_index": 1, "is_deletion": false, "data": {"fee_bps": "25"}},
+			{"transaction_version": 900, "write_set_change_index": 0, "is_deletion": true, "data": null}
+		]}}`))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1", IndexerUrl: mockServer.URL + "/graphql"})
+	assert.NoError(t, err)
+	history, err := client.ResourceHistory(pool, "0xcafe::pool::Config", 10, 1000)
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+
+	type config struct {
+		FeeBps string `json:"fee_bps"`
+	}
+	fees := make([]string, 0, 2)
+	for _, version := range history[:2] {
+		value := config{}
+		assert.NoError(t, version.Into(&value))
+		fees = append(fees, value.FeeBps)
+	}
+	assert.Equal(t, []string{"30", "25"}, fees)
+	assert.Equal(t, uint64(500), history[1].Version)
+	assert.Equal(t, uint64(1), history[1].WriteSetChangeIndex)
+
+	deleted := history[2]
+	assert.True(t, deleted.IsDeletion)
+	assert.Empty(t, deleted.Data)
+	assert.Error(t, deleted.Into(&config{}))
+
+	// Bad ranges are rejected before querying
+	_, err = client.ResourceHistory(pool, "0xcafe::pool::Config", 10, 5)
+	assert.Error(t, err)
+}
+
+func TestClient_ResourceHistoryNoIndexer(t *testing.T) {
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: "http://localhost:8080/v1"})
+	assert.NoError(t, err)
+	_, err = client.ResourceHistory(AccountOne, "0x1::account::Account", 0, 10)
+	assert.ErrorIs(t, err, ErrNoIndexer)
+}
@@ -0,0 +1,163 @@
+package aptos
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryOn is a set of failure classes a [RetryPolicy] retries, combined with |
+type RetryOn uint
+
+const (
+	RetryOnNetworkError       RetryOn = 1 << iota // RetryOnNetworkError retries connection failures e.g. a refused or reset connection
+	RetryOnTimeout                                // RetryOnTimeout retries transport timeouts e.g. waiting for response headers
+	RetryOnTooManyRequests                        // RetryOnTooManyRequests retries 429 responses from rate limits
+	RetryOnServiceUnavailable                     // RetryOnServiceUnavailable retries 503 responses e.g. from an overloaded node
+	RetryOnServerError                            // RetryOnServerError retries 5xx responses other than 503
+
+	// RetryOnAll retries every class, the default of a [RetryPolicy]
+	RetryOnAll = RetryOnNetworkError | RetryOnTimeout | RetryOnTooManyRequests | RetryOnServiceUnavailable | RetryOnServerError
+)
+
+// RetryPolicy retries failed HTTP requests with exponential backoff.  Pass it to [NewClient] to apply it to every node,
+// indexer, and faucet request.  Only requests whose body can be sent again are retried.
+//
+// A POST that may have taken effect before failing e.g. a transaction submission or faucet funding isn't retried unless
+// RetryNonIdempotent is set.  A resubmitted transaction can be rejected as already in the mempool, or as having a too
+// old sequence number once committed, so those callers should check the transaction by hash instead.  Views,
+// simulations, and indexer queries are read-only, and are always retried.
+//
+//	client, err := NewClient(DevnetConfig, RetryPolicy{MaxAttempts: 5, BaseBackoff: 200 * time.Millisecond, Jitter: 0.5})
+type RetryPolicy struct {
+	MaxAttempts int           // MaxAttempts is the total number of tries, including the first, no retries if 1 or less
+	BaseBackoff time.Duration // BaseBackoff is the wait before the first retry, it doubles after each retry
+	MaxBackoff  time.Duration // MaxBackoff caps the wait between retries, if set
+	Jitter      float64       // Jitter is the fraction of each wait that's randomized, from 0 for none to 1
+	RetryOn     RetryOn       // RetryOn are the failures retried, defaults to [RetryOnAll]
+
+	RetryNonIdempotent bool // RetryNonIdempotent retries POSTs other than views, simulations, and indexer queries
+}
+
+// DefaultRetryPolicy is a policy for flaky networks e.g. devnet
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 4, BaseBackoff: 250 * time.Millisecond, MaxBackoff: 5 * time.Second, Jitter: 0.2}
+
+// Transport wraps an [http.RoundTripper] to retry failed requests, [http.DefaultTransport] if inner is nil
+func (policy RetryPolicy) Transport(inner http.RoundTripper) http.RoundTripper {
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	if policy.MaxAttempts <= 1 {
+		return inner
+	}
+	return &retryTransport{inner: inner, policy: policy}
+}
+
+// retryable tells if the policy retries the result of a request
+func (policy RetryPolicy) retryable(response *http.Response, err error) bool {
+	retryOn := policy.RetryOn
+	if retryOn == 0 {
+		retryOn = RetryOnAll
+	}
+	var class RetryOn
+	var netErr net.Error
+	switch {
+	case err != nil && errors.As(err, &netErr) && netErr.Timeout():
+		class = RetryOnTimeout
+	case err != nil:
+		class = RetryOnNetworkError
+	case response.StatusCode == http.StatusTooManyRequests:
+		class = RetryOnTooManyRequests
+	case response.StatusCode == http.StatusServiceUnavailable:
+		class = RetryOnServiceUnavailable
+	case response.StatusCode >= 500:
+		class = RetryOnServerError
+	}
+	return retryOn&class != 0
+}
+
+// wait is the time to wait before the retry after the attempt, at least as long as the server's Retry-After
+func (policy RetryPolicy) wait(attempt int, response *http.Response) time.Duration {
+	wait := policy.BaseBackoff << (attempt - 1)
+	if policy.MaxBackoff > 0 && (wait > policy.MaxBackoff || wait <= 0) {
+		wait = policy.MaxBackoff
+	}
+	if policy.Jitter > 0 {
+		wait -= time.Duration(float64(wait) * min(policy.Jitter, 1) * rand.Float64())
+	}
+	if response != nil {
+		if seconds, err := strconv.ParseUint(response.Header.Get("Retry-After"), 10, 32); err == nil {
+			retryAfter := time.Duration(seconds) * time.Second
+			if policy.MaxBackoff > 0 {
+				retryAfter = min(retryAfter, policy.MaxBackoff)
+			}
+			wait = max(wait, retryAfter)
+		}
+	}
+	return wait
+}
+
+// readOnlyPostSuffixes are the paths of POST requests that don't change anything, so are safe to send again
+var readOnlyPostSuffixes = []string{"/view", "/transactions/simulate", "/transactions/encode_submission", "/graphql"}
+
+// idempotent tells if sending the request again can't have a different effect, by method, or for a read-only POST
+func idempotent(request *http.Request) bool {
+	switch request.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		for _, suffix := range readOnlyPostSuffixes {
+			if strings.HasSuffix(request.URL.Path, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// retryTransport retries requests by a [RetryPolicy]
+type retryTransport struct {
+	inner  http.RoundTripper
+	policy RetryPolicy
+}
+
+func (t *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	// A body that can't be rewound can only be sent once
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+		return t.inner.RoundTrip(request)
+	}
+	if !t.policy.RetryNonIdempotent && !idempotent(request) {
+		return t.inner.RoundTrip(request)
+	}
+
+	for attempt := 1; ; attempt++ {
+		response, err := t.inner.RoundTrip(request)
+		// A cancelled request, or one past the client's timeout, is never retried
+		if request.Context().Err() != nil || attempt >= t.policy.MaxAttempts || !t.policy.retryable(response, err) {
+			return response, err
+		}
+		wait := t.policy.wait(attempt, response)
+		if response != nil {
+			_ = response.Body.Close()
+		}
+
+		select {
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		case <-time.After(wait):
+		}
+
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request = request.Clone(request.Context())
+			request.Body = body
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package aptos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Client(t *testing.T) {
+	requests := atomic.Int32{}
+	status := atomic.Int32{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every other request fails
+		if requests.Add(1)%2 == 1 {
+			w.WriteHeader(int(status.Load()))
+			_, _ = w.Write([]byte(`{"message": "try again", "error_code": "internal_error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"sequence_number": "7", "authentication_key": "0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+	}))
+	defer mockServer.Close()
+
+	httpClient := &http.Client{}
+	policy := RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, RetryOn: RetryOnServiceUnavailable | RetryOnTooManyRequests}
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4}, httpClient, policy)
+	assert.NoError(t, err)
+	assert.Nil(t, httpClient.Transport)
+
+	status.Store(http.StatusServiceUnavailable)
+	account, err := client.Account(AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", account.SequenceNumberStr)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Classes not in the policy aren't retried
+	status.Store(http.StatusInternalServerError)
+	_, err = client.Account(AccountOne)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestRetryPolicy_Wait(t *testing.T) {
+	policy := RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	assert.Equal(t, 100*time.Millisecond, policy.wait(1, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.wait(3, nil))
+	assert.Equal(t, time.Second, policy.wait(10, nil))
+	assert.Equal(t, time.Second, policy.wait(100, nil))
+
+	// The server's Retry-After is honored, up to the cap
+	response := &http.Response{Header: http.Header{"Retry-After": []string{"30"}}}
+	assert.Equal(t, time.Second, policy.wait(1, response))
+	policy.MaxBackoff = 0
+	assert.Equal(t, 30*time.Second, policy.wait(1, response))
+
+	policy.Jitter = 0.5
+	for range 100 {
+		wait := policy.wait(1, nil)
+		assert.GreaterOrEqual(t, wait, 50*time.Millisecond)
+		assert.LessOrEqual(t, wait, 100*time.Millisecond)
+	}
+}
+
+func TestRetryPolicy_Retryable(t *testing.T) {
+	policy := RetryPolicy{}
+	assert.True(t, policy.retryable(&http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+	assert.True(t, policy.retryable(&http.Response{StatusCode: http.StatusBadGateway}, nil))
+	assert.False(t, policy.retryable(&http.Response{StatusCode: http.StatusBadRequest}, nil))
+	assert.False(t, policy.retryable(&http.Response{StatusCode: http.StatusOK}, nil))
+
+	policy.RetryOn = RetryOnTimeout
+	assert.True(t, policy.retryable(nil, &timeoutError{}))
+	assert.False(t, policy.retryable(nil, assert.AnError))
+	assert.False(t, policy.retryable(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil))
+}
+
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string   { return "timeout" }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+
+func TestRetryPolicy_NonIdempotent(t *testing.T) {
+	requests := atomic.Int32{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer mockServer.Close()
+
+	post := func(policy RetryPolicy, path string) int32 {
+		requests.Store(0)
+		httpClient := &http.Client{Transport: policy.Transport(nil)}
+		response, err := httpClient.Post(mockServer.URL+path, "application/json", strings.NewReader("{}"))
+		assert.NoError(t, err)
+		_ = response.Body.Close()
+		return requests.Load()
+	}
+	policy := RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}
+
+	// A submission may have taken effect, so it's only sent once
+	assert.Equal(t, int32(1), post(policy, "/v1/transactions"))
+	assert.Equal(t, int32(1), post(policy, "/mint"))
+	// Read-only POSTs are retried
+	assert.Equal(t, int32(3), post(policy, "/v1/view"))
+	assert.Equal(t, int32(3), post(policy, "/v1/transactions/simulate"))
+
+	policy.RetryNonIdempotent = true
+	assert.Equal(t, int32(3), post(policy, "/v1/transactions"))
+}
@@ -0,0 +1,256 @@
+package aptos
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// MoveBytecodeMagic is the magic number at the start of all compiled Move bytecode
+const MoveBytecodeMagic = uint32(0xa11ceb0b)
+
+// moveSignaturesTable is the table kind for the signature pool in compiled Move bytecode
+const moveSignaturesTable = uint8(5)
+
+// Move signature token types, as serialized in compiled Move bytecode
+const (
+	moveTokenBool             = uint8(0x1)
+	moveTokenU8               = uint8(0x2)
+	moveTokenU64              = uint8(0x3)
+	moveTokenU128             = uint8(0x4)
+	moveTokenAddress          = uint8(0x5)
+	moveTokenReference        = uint8(0x6)
+	moveTokenMutableReference = uint8(0x7)
+	moveTokenStruct           = uint8(0x8)
+	moveTokenTypeParameter    = uint8(0x9)
+	moveTokenVector           = uint8(0xA)
+	moveTokenStructInst       = uint8(0xB)
+	moveTokenSigner           = uint8(0xC)
+	moveTokenU16              = uint8(0xD)
+	moveTokenU32              = uint8(0xE)
+	moveTokenU256             = uint8(0xF)
+)
+
+// ScriptInfo is the information read from the header of compiled Move script bytecode
+type ScriptInfo struct {
+	Version            uint32   // Version of the bytecode format, without the flavor bits
+	TypeParameterCount int      // TypeParameterCount is the number of generic type parameters of the script
+	Parameters         []string // Parameters are the parameter types of the script e.g. &signer, u64, vector<u8>
+}
+
+// SignerCount is the number of leading signer parameters, which are filled in by the transaction's signers
+func (info *ScriptInfo) SignerCount() int {
+	count := 0
+	for _, param := range info.Parameters {
+		if param != "signer" && param != "&signer" {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+// ArgParameters are the parameters which must be provided as [ScriptArgument]
+func (info *ScriptInfo) ArgParameters() []string {
+	return info.Parameters[info.SignerCount():]
+}
+
+// LoadScriptFromFile reads compiled Move script bytecode from a .mv file, and checks that it's a valid script
+func LoadScriptFromFile(path string) (code []byte, info *ScriptInfo, err error) {
+	code, err = os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read script %s: %w", path, err)
+	}
+	info, err = ParseScriptInfo(code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid script %s: %w", path, err)
+	}
+	return code, info, nil
+}
+
+// ParseScriptInfo reads the header of compiled Move script bytecode, to find its type parameters and parameters
+func ParseScriptInfo(code []byte) (*ScriptInfo, error) {
+	if len(code) < 8 {
+		return nil, errors.New("bytecode too short")
+	}
+	if binary.BigEndian.Uint32(code[:4]) != MoveBytecodeMagic {
+		return nil, fmt.Errorf("bad magic %x, not Move bytecode", code[:4])
+	}
+	// The top byte of the version may carry a flavor e.g. Aptos, which isn't part of the version
+	version := binary.LittleEndian.Uint32(code[4:8]) & 0x00FFFFFF
+
+	des := bcs.NewDeserializer(code[8:])
+	tableCount := des.Uleb128()
+	// Offsets and lengths are summed as uint64, so crafted headers can't overflow past the bounds check
+	var signaturesOffset, signaturesEnd uint64
+	haveSignatures := false
+	contentLength := uint64(0)
+	for i := uint32(0); i < tableCount && des.Error() == nil; i++ {
+		kind := des.U8()
+		offset := uint64(des.Uleb128())
+		end := offset + uint64(des.Uleb128())
+		if kind == moveSignaturesTable {
+			signaturesOffset, signaturesEnd, haveSignatures = offset, end, true
+		}
+		contentLength = max(contentLength, end)
+	}
+	if des.Error() != nil {
+		return nil, fmt.Errorf("bad table headers: %w", des.Error())
+	}
+
+	contentStart := uint64(len(code) - des.Remaining())
+	if contentStart+contentLength > uint64(len(code)) {
+		return nil, errors.New("tables extend past end of bytecode")
+	}
+	content := code[contentStart : contentStart+contentLength]
+
+	var signatures [][]string
+	if haveSignatures {
+		sigDes := bcs.NewDeserializer(content[signaturesOffset:signaturesEnd])
+		for sigDes.Remaining() > 0 {
+			signatures = append(signatures, readMoveSignature(sigDes))
+			if sigDes.Error() != nil {
+				return nil, fmt.Errorf("bad signature table: %w", sigDes.Error())
+			}
+		}
+	}
+
+	// The script's entry point follows the tables, modules are not scripts
+	entry := bcs.NewDeserializer(code[contentStart+contentLength:])
+	typeParameterCount := entry.Uleb128()
+	for i := uint32(0); i < typeParameterCount && entry.Error() == nil; i++ {
+		entry.U8() // abilities
+	}
+	parametersIndex := entry.Uleb128()
+	if entry.Error() != nil {
+		return nil, fmt.Errorf("bad script entry, this may be a module instead of a script: %w", entry.Error())
+	}
+	if int(parametersIndex) >= len(signatures) {
+		return nil, fmt.Errorf("script parameters index %d out of range %d", parametersIndex, len(signatures))
+	}
+
+	return &ScriptInfo{
+		Version:            version,
+		TypeParameterCount: int(typeParameterCount),
+		Parameters:         signatures[parametersIndex],
+	}, nil
+}
+
+// readMoveSignature reads a single signature, which is a list of types
+func readMoveSignature(des *bcs.Deserializer) []string {
+	length := des.Uleb128()
+	// Each type is at least a byte, so a bad length can't allocate more than the bytecode
+	out := make([]string, 0, min(length, uint32(des.Remaining())))
+	for i := uint32(0); i < length && des.Error() == nil; i++ {
+		out = append(out, readMoveSignatureToken(des))
+	}
+	return out
+}
+
+// readMoveSignatureToken reads a single type.  Structs are only named by their handle, as resolving them isn't needed to
+// check arguments.
+func readMoveSignatureToken(des *bcs.Deserializer) string {
+	kind := des.U8()
+	switch kind {
+	case moveTokenBool:
+		return "bool"
+	case moveTokenU8:
+		return "u8"
+	case moveTokenU16:
+		return "u16"
+	case moveTokenU32:
+		return "u32"
+	case moveTokenU64:
+		return "u64"
+	case moveTokenU128:
+		return "u128"
+	case moveTokenU256:
+		return "u256"
+	case moveTokenAddress:
+		return "address"
+	case moveTokenSigner:
+		return "signer"
+	case moveTokenReference:
+		return "&" + readMoveSignatureToken(des)
+	case moveTokenMutableReference:
+		return "&mut " + readMoveSignatureToken(des)
+	case moveTokenVector:
+		return "vector<" + readMoveSignatureToken(des) + ">"
+	case moveTokenTypeParameter:
+		return fmt.Sprintf("T%d", des.Uleb128())
+	case moveTokenStruct:
+		return fmt.Sprintf("struct#%d", des.Uleb128())
+	case moveTokenStructInst:
+		name := fmt.Sprintf("struct#%d<", des.Uleb128())
+		count := des.Uleb128()
+		for i := uint32(0); i < count && des.Error() == nil; i++ {
+			if i > 0 {
+				name += ", "
+			}
+			name += readMoveSignatureToken(des)
+		}
+		return name + ">"
+	default:
+		des.SetError(fmt.Errorf("unknown signature token %d", kind))
+		return ""
+	}
+}
+
+// scriptArgumentTypes maps a [ScriptArgumentVariant] to the Move type it must be passed to
+var scriptArgumentTypes = map[ScriptArgumentVariant]string{
+	ScriptArgumentU8:       "u8",
+	ScriptArgumentU16:      "u16",
+	ScriptArgumentU32:      "u32",
+	ScriptArgumentU64:      "u64",
+	ScriptArgumentU128:     "u128",
+	ScriptArgumentU256:     "u256",
+	ScriptArgumentAddress:  "address",
+	ScriptArgumentU8Vector: "vector<u8>",
+	ScriptArgumentBool:     "bool",
+}
+
+// Validate checks the type arguments and arguments against the script's declared parameters
+//
+// [ScriptArgumentSerialized] arguments can't be checked, and are accepted for any parameter
+func (info *ScriptInfo) Validate(typeArgs []TypeTag, args []ScriptArgument) error {
+	if len(typeArgs) != info.TypeParameterCount {
+		return fmt.Errorf("script expects %d type arguments, got %d", info.TypeParameterCount, len(typeArgs))
+	}
+	params := info.ArgParameters()
+	if len(args) != len(params) {
+		return fmt.Errorf("script expects %d arguments %v (after %d signers), got %d", len(params), params, info.SignerCount(), len(args))
+	}
+	for i, arg := range args {
+		if arg.Variant == ScriptArgumentSerialized {
+			continue
+		}
+		expected, ok := scriptArgumentTypes[arg.Variant]
+		if !ok {
+			return fmt.Errorf("script argument %d has unknown variant %d", i, arg.Variant)
+		}
+		if expected != params[i] {
+			return fmt.Errorf("script argument %d is %s, but script expects %s", i, expected, params[i])
+		}
+	}
+	return nil
+}
+
+// NewScriptFromBytecode creates a [Script] payload, validating the arguments against the bytecode before submission
+func NewScriptFromBytecode(code []byte, typeArgs []TypeTag, args []ScriptArgument) (*Script, error) {
+	info, err := ParseScriptInfo(code)
+	if err != nil {
+		return nil, err
+	}
+	err = info.Validate(typeArgs, args)
+	if err != nil {
+		return nil, err
+	}
+	return &Script{
+		Code:     code,
+		ArgTypes: typeArgs,
+		Args:     args,
+	}, nil
+}
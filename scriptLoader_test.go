@@ -0,0 +1,110 @@
+package aptos
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScriptInfo(t *testing.T) {
+	code, err := ParseHex(singleSignerScript)
+	assert.NoError(t, err)
+
+	info, err := ParseScriptInfo(code)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(6), info.Version)
+	assert.Equal(t, 0, info.TypeParameterCount)
+	assert.Equal(t, []string{"&signer", "u64", "address"}, info.Parameters)
+	assert.Equal(t, 1, info.SignerCount())
+	assert.Equal(t, []string{"u64", "address"}, info.ArgParameters())
+
+	// Not bytecode at all
+	_, err = ParseScriptInfo([]byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8})
+	assert.Error(t, err)
+
+	// Truncated
+	_, err = ParseScriptInfo(code[:20])
+	assert.Error(t, err)
+
+	// Table offsets and lengths that overflow 32 bits when summed
+	header := []byte{0xa1, 0x1c, 0xeb, 0x0b, 0x06, 0x00, 0x00, 0x0a}
+	uleb := func(value uint32) []byte {
+		ser := bcs.Serializer{}
+		ser.Uleb128(value)
+		return ser.ToBytes()
+	}
+	table := func(kind uint8, offset uint32, length uint32) []byte {
+		return append(append([]byte{kind}, uleb(offset)...), uleb(length)...)
+	}
+	for _, tables := range [][][]byte{
+		{table(moveSignaturesTable, 0xFFFFFFF0, 0x20)},
+		{table(1, 0, 4), table(moveSignaturesTable, 0xFFFFFFFF, 0xFFFFFFFF)},
+		{table(1, 0xFFFFFFFF, 1), table(moveSignaturesTable, 0, 4)},
+	} {
+		crafted := append(append([]byte{}, header...), uleb(uint32(len(tables)))...)
+		for _, tableBytes := range tables {
+			crafted = append(crafted, tableBytes...)
+		}
+		crafted = append(crafted, 0x1, 0x1, 0x2, 0x0, 0x0)
+		assert.NotPanics(t, func() {
+			_, err = ParseScriptInfo(crafted)
+		})
+		assert.ErrorContains(t, err, "past end of bytecode")
+	}
+
+	// A signature length far past the end of the bytecode
+	crafted := append(append([]byte{}, header...), uleb(1)...)
+	crafted = append(crafted, table(moveSignaturesTable, 0, 6)...)
+	crafted = append(crafted, uleb(0xFFFFFFFF)...)
+	crafted = append(crafted, 0x2)
+	_, err = ParseScriptInfo(crafted)
+	assert.ErrorContains(t, err, "bad signature table")
+}
+
+func TestNewScriptFromBytecode(t *testing.T) {
+	code, err := ParseHex(singleSignerScript)
+	assert.NoError(t, err)
+
+	args := []ScriptArgument{
+		{Variant: ScriptArgumentU64, Value: uint64(100)},
+		{Variant: ScriptArgumentAddress, Value: AccountOne},
+	}
+	script, err := NewScriptFromBytecode(code, []TypeTag{}, args)
+	assert.NoError(t, err)
+	assert.Equal(t, code, script.Code)
+
+	// Wrong number of arguments
+	_, err = NewScriptFromBytecode(code, []TypeTag{}, args[:1])
+	assert.ErrorContains(t, err, "expects 2 arguments")
+
+	// Wrong type of argument
+	_, err = NewScriptFromBytecode(code, []TypeTag{}, []ScriptArgument{
+		{Variant: ScriptArgumentU128, Value: *big.NewInt(100)},
+		{Variant: ScriptArgumentAddress, Value: AccountOne},
+	})
+	assert.ErrorContains(t, err, "script expects u64")
+
+	// Wrong number of type arguments
+	_, err = NewScriptFromBytecode(code, []TypeTag{AptosCoinTypeTag}, args)
+	assert.ErrorContains(t, err, "type arguments")
+}
+
+func TestLoadScriptFromFile(t *testing.T) {
+	code, err := ParseHex(singleSignerScript)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "script.mv")
+	assert.NoError(t, os.WriteFile(path, code, 0o600))
+
+	loaded, info, err := LoadScriptFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, code, loaded)
+	assert.Len(t, info.ArgParameters(), 2)
+
+	_, _, err = LoadScriptFromFile(filepath.Join(t.TempDir(), "missing.mv"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,275 @@
+package aptos
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// SenderIssue is a problem found by [Client.DiagnoseSender]
+type SenderIssue string
+
+const (
+	SenderIssueSequenceGap SenderIssue = "sequence_gap" // SenderIssueSequenceGap is a sequence number with no transaction, so later ones can't commit
+	SenderIssueExpired     SenderIssue = "expired"      // SenderIssueExpired is a transaction that expired without committing
+	SenderIssueGasTooLow   SenderIssue = "gas_too_low"  // SenderIssueGasTooLow is a pending transaction priced below the node's gas estimate
+	SenderIssueDropped     SenderIssue = "dropped"      // SenderIssueDropped is an unexpired transaction the node doesn't know about
+	SenderIssueReplaced    SenderIssue = "replaced"     // SenderIssueReplaced is a transaction whose sequence number was used by another transaction
+	SenderIssueFailed      SenderIssue = "failed"       // SenderIssueFailed is a transaction that committed, but aborted
+)
+
+// SenderRemediation is the action suggested to fix a [SenderFinding]
+type SenderRemediation string
+
+const (
+	SenderRemediationResubmit      SenderRemediation = "resubmit"      // SenderRemediationResubmit submits the transaction again at the same sequence number, re-signing it with a new expiration if it expired
+	SenderRemediationBumpGas       SenderRemediation = "bump_gas"      // SenderRemediationBumpGas re-signs the transaction at the same sequence number with [SenderFinding.GasUnitPrice]
+	SenderRemediationFillGap       SenderRemediation = "fill_gap"      // SenderRemediationFillGap submits any transaction at the missing sequence number
+	SenderRemediationResynchronize SenderRemediation = "resynchronize" // SenderRemediationResynchronize restarts local sequence numbers from the chain, see [AccountSequenceNumberManager.Synchronize]
+	SenderRemediationInspect       SenderRemediation = "inspect"       // SenderRemediationInspect checks the VM status of the transaction, retrying won't help
+)
+
+// SubmissionState is where a [TrackedSubmission] was found by [Client.DiagnoseSender]
+type SubmissionState string
+
+const (
+	SubmissionStatePending   SubmissionState = "pending"   // SubmissionStatePending is in the node's mempool
+	SubmissionStateCommitted SubmissionState = "committed" // SubmissionStateCommitted committed successfully
+	SubmissionStateFailed    SubmissionState = "failed"    // SubmissionStateFailed committed, but aborted
+	SubmissionStateNotFound  SubmissionState = "not_found" // SubmissionStateNotFound is unknown to the node
+)
+
+// TrackedSubmission is a transaction the caller has submitted, and is tracking locally
+type TrackedSubmission struct {
+	Hash                       string // Hash of the signed transaction
+	SequenceNumber             uint64 // SequenceNumber of the transaction
+	GasUnitPrice               uint64 // GasUnitPrice the transaction was signed with
+	ExpirationTimestampSeconds uint64 // ExpirationTimestampSeconds is seconds since Unix epoch
+}
+
+// NewTrackedSubmission records a signed transaction for [Client.DiagnoseSender]
+func NewTrackedSubmission(signedTxn *SignedTransaction) (TrackedSubmission, error) {
+	hash, err := signedTxn.Hash()
+	if err != nil {
+		return TrackedSubmission{}, err
+	}
+	return TrackedSubmission{
+		Hash:                       hash,
+		SequenceNumber:             signedTxn.Transaction.SequenceNumber,
+		GasUnitPrice:               signedTxn.Transaction.GasUnitPrice,
+		ExpirationTimestampSeconds: signedTxn.Transaction.ExpirationTimestampSeconds,
+	}, nil
+}
+
+// SubmissionStatus is a [TrackedSubmission] and where the node has it
+type SubmissionStatus struct {
+	TrackedSubmission
+	State    SubmissionState // State of the transaction on the node
+	VmStatus string          // VmStatus of a committed transaction
+}
+
+// SenderFinding is one problem found by [Client.DiagnoseSender], with the suggested fix
+type SenderFinding struct {
+	Issue          SenderIssue       // Issue found
+	SequenceNumber uint64            // SequenceNumber the issue is at
+	Hash           string            // Hash of the tracked transaction, empty for a [SenderIssueSequenceGap]
+	Detail         string            // Detail explains the issue
+	Remediation    SenderRemediation // Remediation is the suggested fix
+	GasUnitPrice   uint64            // GasUnitPrice to re-sign with, for [SenderRemediationBumpGas]
+}
+
+// String formats the finding e.g. "sequence number 5 expired: ... (resubmit)"
+func (finding SenderFinding) String() string {
+	return fmt.Sprintf("sequence number %d %s: %s (%s)", finding.SequenceNumber, finding.Issue, finding.Detail, finding.Remediation)
+}
+
+// SenderDiagnosis is the result of [Client.DiagnoseSender]
+type SenderDiagnosis struct {
+	Address        AccountAddress     // Address of the sender
+	SequenceNumber uint64             // SequenceNumber is the on-chain sequence number, the next one that can commit
+	LedgerTime     time.Time          // LedgerTime of the node, used to decide what expired
+	GasEstimate    uint64             // GasEstimate is the node's gas unit price estimate
+	Submissions    []SubmissionStatus // Submissions are the tracked submissions, ordered by sequence number
+	Findings       []SenderFinding    // Findings are the problems found, ordered by sequence number
+}
+
+// Healthy tells if no problems were found
+func (diagnosis *SenderDiagnosis) Healthy() bool {
+	return len(diagnosis.Findings) == 0
+}
+
+// Stuck tells if the sender is stuck, and at which sequence number.  A sender is stuck when the transaction at the
+// on-chain sequence number is missing, expired, dropped, or too cheap to be picked up, as nothing after it can commit.
+func (diagnosis *SenderDiagnosis) Stuck() (sequenceNumber uint64, stuck bool) {
+	for _, finding := range diagnosis.Findings {
+		if finding.SequenceNumber != diagnosis.SequenceNumber {
+			continue
+		}
+		switch finding.Issue {
+		case SenderIssueSequenceGap, SenderIssueExpired, SenderIssueGasTooLow, SenderIssueDropped:
+			return diagnosis.SequenceNumber, true
+		default:
+			// Doesn't block the sender
+		}
+	}
+	return 0, false
+}
+
+// DiagnoseSender explains why a sender's transactions aren't committing.  It compares the on-chain sequence number, the
+// node's mempool, and the submissions tracked by the caller, and reports gaps in sequence numbers, expired, dropped,
+// and underpriced transactions, with a suggested fix for each.
+//
+// The node can't list its mempool by sender, so only the tracked submissions are looked up by hash.  Sequence numbers
+// between the on-chain sequence number and the highest tracked submission, which aren't tracked, are reported as gaps.
+//
+//	diagnosis, err := client.DiagnoseSender(sender.Address, submissions...)
+//	if sequenceNumber, stuck := diagnosis.Stuck(); stuck {
+//		log.Printf("sender stuck at %d", sequenceNumber)
+//	}
+//	for _, finding := range diagnosis.Findings {
+//		log.Print(finding.String())
+//	}
+func (client *Client) DiagnoseSender(address AccountAddress, submissions ...TrackedSubmission) (*SenderDiagnosis, error) {
+	diagnosis := &SenderDiagnosis{Address: address}
+
+	info, err := client.Account(address)
+	switch {
+	case errors.Is(err, ErrAccountNotFound):
+		// Nothing has committed yet
+	case err != nil:
+		return nil, fmt.Errorf("failed to fetch sequence number for %s: %w", address.String(), err)
+	default:
+		diagnosis.SequenceNumber, err = info.SequenceNumber()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nodeInfo, err := client.Info()
+	if err != nil {
+		return nil, err
+	}
+	diagnosis.LedgerTime = time.UnixMicro(int64(nodeInfo.LedgerTimestamp()))
+	ledgerSeconds := nodeInfo.LedgerTimestamp() / 1_000_000
+
+	gasInfo, err := client.EstimateGasPrice()
+	if err != nil {
+		return nil, err
+	}
+	diagnosis.GasEstimate = gasInfo.GasEstimate
+
+	submissions = slices.Clone(submissions)
+	slices.SortStableFunc(submissions, func(a, b TrackedSubmission) int {
+		return cmp.Compare(a.SequenceNumber, b.SequenceNumber)
+	})
+
+	tracked := make(map[uint64]bool, len(submissions))
+	var highest uint64
+	outstanding := false
+	for _, submission := range submissions {
+		status, err := client.submissionStatus(submission)
+		if err != nil {
+			return nil, err
+		}
+		diagnosis.Submissions = append(diagnosis.Submissions, status)
+		tracked[submission.SequenceNumber] = true
+		if status.State != SubmissionStateCommitted && status.State != SubmissionStateFailed && submission.SequenceNumber >= diagnosis.SequenceNumber {
+			outstanding = true
+			highest = max(highest, submission.SequenceNumber)
+		}
+
+		if finding, ok := diagnoseSubmission(status, diagnosis.SequenceNumber, ledgerSeconds, gasInfo); ok {
+			diagnosis.Findings = append(diagnosis.Findings, finding)
+		}
+	}
+
+	// Untracked sequence numbers before an outstanding transaction hold it up
+	if outstanding {
+		for sequenceNumber := diagnosis.SequenceNumber; sequenceNumber < highest; sequenceNumber++ {
+			if tracked[sequenceNumber] {
+				continue
+			}
+			diagnosis.Findings = append(diagnosis.Findings, SenderFinding{
+				Issue:          SenderIssueSequenceGap,
+				SequenceNumber: sequenceNumber,
+				Detail:         fmt.Sprintf("no transaction was submitted, so sequence numbers up to %d can't commit", highest),
+				Remediation:    SenderRemediationFillGap,
+			})
+		}
+	}
+	slices.SortStableFunc(diagnosis.Findings, func(a, b SenderFinding) int {
+		return cmp.Compare(a.SequenceNumber, b.SequenceNumber)
+	})
+	return diagnosis, nil
+}
+
+// submissionStatus looks up a tracked submission on the node
+func (client *Client) submissionStatus(submission TrackedSubmission) (SubmissionStatus, error) {
+	status := SubmissionStatus{TrackedSubmission: submission}
+	txn, err := client.TransactionByHash(submission.Hash)
+	if HasErrorCode(err, api.ErrorCodeTransactionNotFound) {
+		status.State = SubmissionStateNotFound
+		return status, nil
+	}
+	if err != nil {
+		return status, err
+	}
+
+	switch txn.Type {
+	case api.TransactionVariantPending:
+		status.State = SubmissionStatePending
+	case api.TransactionVariantUser:
+		userTxn, err := txn.UserTransaction()
+		if err != nil {
+			return status, err
+		}
+		status.VmStatus = userTxn.VmStatus
+		if userTxn.Success {
+			status.State = SubmissionStateCommitted
+		} else {
+			status.State = SubmissionStateFailed
+		}
+	default:
+		return status, fmt.Errorf("transaction %s is a %s, not a user transaction", submission.Hash, txn.Type)
+	}
+	return status, nil
+}
+
+// diagnoseSubmission finds the problem with a tracked submission, if any
+func diagnoseSubmission(status SubmissionStatus, onChain uint64, ledgerSeconds uint64, gasInfo EstimateGasInfo) (SenderFinding, bool) {
+	finding := SenderFinding{SequenceNumber: status.SequenceNumber, Hash: status.Hash}
+	expired := status.ExpirationTimestampSeconds <= ledgerSeconds
+	switch {
+	case status.State == SubmissionStateCommitted:
+		return finding, false
+	case status.State == SubmissionStateFailed:
+		finding.Issue = SenderIssueFailed
+		finding.Detail = fmt.Sprintf("committed but aborted with %s", status.VmStatus)
+		finding.Remediation = SenderRemediationInspect
+	case status.SequenceNumber < onChain:
+		// The node would have the transaction if it had committed, so another one used the sequence number
+		finding.Issue = SenderIssueReplaced
+		finding.Detail = fmt.Sprintf("sequence number was used by another transaction, on-chain is now %d", onChain)
+		finding.Remediation = SenderRemediationResynchronize
+	case expired:
+		finding.Issue = SenderIssueExpired
+		finding.Detail = fmt.Sprintf("expired at %d, ledger is at %d", status.ExpirationTimestampSeconds, ledgerSeconds)
+		finding.Remediation = SenderRemediationResubmit
+	case status.State == SubmissionStateNotFound:
+		finding.Issue = SenderIssueDropped
+		finding.Detail = "not in the node's mempool and not committed"
+		finding.Remediation = SenderRemediationResubmit
+	case status.GasUnitPrice < gasInfo.GasEstimate:
+		finding.Issue = SenderIssueGasTooLow
+		finding.Detail = fmt.Sprintf("gas unit price %d is below the estimate %d", status.GasUnitPrice, gasInfo.GasEstimate)
+		finding.Remediation = SenderRemediationBumpGas
+		finding.GasUnitPrice = gasInfo.Price(GasPriorityPrioritized)
+	default:
+		return finding, false
+	}
+	return finding, true
+}
@@ -0,0 +1,134 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func senderDiagnosisServer(t *testing.T, sequenceNumber string, transactions map[string]map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"chain_id": 4, "ledger_version": "100", "ledger_timestamp": "1700000000000000"})
+		case r.URL.Path == "/v1/estimate_gas_price":
+			_ = json.NewEncoder(w).Encode(map[string]any{"gas_estimate": 150, "prioritized_gas_estimate": 300})
+		case strings.HasPrefix(r.URL.Path, "/v1/accounts/"):
+			if sequenceNumber == "" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "account not found", "error_code": "account_not_found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"sequence_number": sequenceNumber, "authentication_key": "0x01"})
+		case strings.HasPrefix(r.URL.Path, "/v1/transactions/by_hash/"):
+			txn, ok := transactions[strings.TrimPrefix(r.URL.Path, "/v1/transactions/by_hash/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "transaction not found", "error_code": "transaction_not_found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(txn)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClient_DiagnoseSender(t *testing.T) {
+	pending := func(hash string, gasUnitPrice string) map[string]any {
+		return map[string]any{"type": "pending_transaction", "hash": hash, "sequence_number": "0", "max_gas_amount": "1000", "gas_unit_price": gasUnitPrice, "expiration_timestamp_secs": "1700000060"}
+	}
+	committed := func(hash string, success bool, vmStatus string) map[string]any {
+		return map[string]any{"type": "user_transaction", "hash": hash, "version": "90", "success": success, "vm_status": vmStatus, "sequence_number": "0", "gas_used": "10", "max_gas_amount": "1000", "gas_unit_price": "150", "expiration_timestamp_secs": "1700000060", "timestamp": "1699999000000000"}
+	}
+	mockServer := senderDiagnosisServer(t, "5", map[string]map[string]any{
+		"0x3": committed("0x3", true, "Executed successfully"),
+		"0x4": committed("0x4", false, "Move abort: EINSUFFICIENT_BALANCE"),
+		"0x6": pending("0x6", "100"),
+		"0x9": pending("0x9", "150"),
+	})
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1"})
+	assert.NoError(t, err)
+
+	future := uint64(1700000060)
+	diagnosis, err := client.DiagnoseSender(AccountOne,
+		TrackedSubmission{Hash: "0x9", SequenceNumber: 9, GasUnitPrice: 150, ExpirationTimestampSeconds: future},
+		TrackedSubmission{Hash: "0x8", SequenceNumber: 8, GasUnitPrice: 150, ExpirationTimestampSeconds: future},
+		TrackedSubmission{Hash: "0x7", SequenceNumber: 7, GasUnitPrice: 150, ExpirationTimestampSeconds: 1699999999},
+		TrackedSubmission{Hash: "0x6", SequenceNumber: 6, GasUnitPrice: 100, ExpirationTimestampSeconds: future},
+		TrackedSubmission{Hash: "0x4", SequenceNumber: 4, GasUnitPrice: 150, ExpirationTimestampSeconds: future},
+		TrackedSubmission{Hash: "0x3", SequenceNumber: 3, GasUnitPrice: 150, ExpirationTimestampSeconds: future},
+		TrackedSubmission{Hash: "0x2", SequenceNumber: 2, GasUnitPrice: 150, ExpirationTimestampSeconds: future},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), diagnosis.SequenceNumber)
+	assert.Equal(t, int64(1700000000), diagnosis.LedgerTime.Unix())
+	assert.Equal(t, uint64(150), diagnosis.GasEstimate)
+	assert.False(t, diagnosis.Healthy())
+
+	states := make([]SubmissionState, 0, len(diagnosis.Submissions))
+	for _, status := range diagnosis.Submissions {
+		states = append(states, status.State)
+	}
+	assert.Equal(t, []SubmissionState{
+		SubmissionStateNotFound,
+		SubmissionStateCommitted,
+		SubmissionStateFailed,
+		SubmissionStatePending,
+		SubmissionStateNotFound,
+		SubmissionStateNotFound,
+		SubmissionStatePending,
+	}, states)
+
+	issues := make([]SenderIssue, 0, len(diagnosis.Findings))
+	for _, finding := range diagnosis.Findings {
+		issues = append(issues, finding.Issue)
+	}
+	assert.Equal(t, []SenderIssue{
+		SenderIssueReplaced,
+		SenderIssueFailed,
+		SenderIssueSequenceGap,
+		SenderIssueGasTooLow,
+		SenderIssueExpired,
+		SenderIssueDropped,
+	}, issues)
+
+	gap := diagnosis.Findings[2]
+	assert.Equal(t, uint64(5), gap.SequenceNumber)
+	assert.Equal(t, SenderRemediationFillGap, gap.Remediation)
+	assert.Equal(t, "", gap.Hash)
+	gas := diagnosis.Findings[3]
+	assert.Equal(t, SenderRemediationBumpGas, gas.Remediation)
+	assert.Equal(t, uint64(300), gas.GasUnitPrice)
+	assert.Equal(t, SenderRemediationResynchronize, diagnosis.Findings[0].Remediation)
+	assert.Contains(t, diagnosis.Findings[1].String(), "EINSUFFICIENT_BALANCE")
+
+	sequenceNumber, stuck := diagnosis.Stuck()
+	assert.True(t, stuck)
+	assert.Equal(t, uint64(5), sequenceNumber)
+}
+
+func TestClient_DiagnoseSenderHealthy(t *testing.T) {
+	mockServer := senderDiagnosisServer(t, "", map[string]map[string]any{
+		"0x1": {"type": "pending_transaction", "hash": "0x1", "sequence_number": "0", "max_gas_amount": "1000", "gas_unit_price": "150", "expiration_timestamp_secs": "1700000060"},
+	})
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1"})
+	assert.NoError(t, err)
+
+	// An account that doesn't exist yet is at sequence number 0
+	diagnosis, err := client.DiagnoseSender(AccountOne, TrackedSubmission{Hash: "0x1", SequenceNumber: 0, GasUnitPrice: 150, ExpirationTimestampSeconds: 1700000060})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), diagnosis.SequenceNumber)
+	assert.True(t, diagnosis.Healthy())
+	_, stuck := diagnosis.Stuck()
+	assert.False(t, stuck)
+}
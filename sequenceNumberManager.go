@@ -0,0 +1,229 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Defaults for [AccountSequenceNumberManager]
+const (
+	DefaultMaxOutstandingTransactions = 100                    // DefaultMaxOutstandingTransactions is the default cap of in-flight transactions per sender
+	DefaultSequenceNumberPollInterval = 100 * time.Millisecond // DefaultSequenceNumberPollInterval is the default time between on-chain sequence number checks
+	DefaultSequenceNumberMaxWait      = 30 * time.Second       // DefaultSequenceNumberMaxWait is the default time to wait for in-flight transactions to commit
+)
+
+// ErrTooManyOutstandingTransactions is returned by [AccountSequenceNumberManager.NextSequenceNumber] when in-flight
+// transactions did not commit within the max wait
+var ErrTooManyOutstandingTransactions = errors.New("too many outstanding transactions")
+
+// SequenceNumberClient is the subset of [Client] used by [AccountSequenceNumberManager]
+type SequenceNumberClient interface {
+	Account(address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error)
+}
+
+// AccountSequenceNumberManager hands out sequence numbers for a single sender to concurrent goroutines, without fetching
+// the account before every transaction.
+//
+// The on-chain sequence number is fetched once, then each call to [AccountSequenceNumberManager.NextSequenceNumber]
+// returns the next number.  No more than MaxOutstanding numbers are handed out ahead of the chain, once the cap is hit
+// callers wait for transactions to commit.  When a transaction is rejected before reaching the mempool, call
+// [AccountSequenceNumberManager.Release] so its number is handed out again, and on a sequence number error call
+// [AccountSequenceNumberManager.Resync].
+//
+//	manager := NewAccountSequenceNumberManager(client, sender.Address)
+//	sequenceNumber, err := manager.NextSequenceNumber()
+//	rawTxn, err := client.BuildTransaction(sender.Address, payload, SequenceNumber(sequenceNumber))
+type AccountSequenceNumberManager struct {
+	MaxOutstanding uint64        // MaxOutstanding is the number of uncommitted sequence numbers allowed, defaults to [DefaultMaxOutstandingTransactions]
+	PollInterval   time.Duration // PollInterval is the time between checks of the on-chain sequence number while waiting
+	MaxWait        time.Duration // MaxWait is how long to wait for outstanding transactions before giving up
+
+	client  SequenceNumberClient
+	address AccountAddress
+
+	mutex       sync.Mutex
+	initialized bool
+	next        uint64   // next is the next sequence number to hand out
+	onChain     uint64   // onChain is the last sequence number seen on-chain
+	released    []uint64 // released are sequence numbers below next that were given back, sorted, handed out before next
+}
+
+// NewAccountSequenceNumberManager creates an [AccountSequenceNumberManager] with the default limits, the on-chain
+// sequence number is fetched on first use
+func NewAccountSequenceNumberManager(client SequenceNumberClient, address AccountAddress) *AccountSequenceNumberManager {
+	return &AccountSequenceNumberManager{
+		MaxOutstanding: DefaultMaxOutstandingTransactions,
+		PollInterval:   DefaultSequenceNumberPollInterval,
+		MaxWait:        DefaultSequenceNumberMaxWait,
+		client:         client,
+		address:        address,
+	}
+}
+
+// Address is the sender the sequence numbers are for
+func (m *AccountSequenceNumberManager) Address() AccountAddress {
+	return m.address
+}
+
+// NextSequenceNumber returns the next sequence number to use, safe for concurrent use
+//
+// If MaxOutstanding numbers are already ahead of the chain, it waits for them to commit, and returns
+// [ErrTooManyOutstandingTransactions] if they don't within MaxWait.
+func (m *AccountSequenceNumberManager) NextSequenceNumber() (uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !m.initialized {
+		if err := m.refresh(); err != nil {
+			return 0, err
+		}
+		m.next = m.onChain
+		m.initialized = true
+	}
+
+	maxOutstanding := m.MaxOutstanding
+	if maxOutstanding == 0 {
+		maxOutstanding = DefaultMaxOutstandingTransactions
+	}
+	// The chain is ahead if another process or signer used the account, so its numbers can't be handed out
+	m.next = max(m.next, m.onChain)
+	m.dropReleased()
+	if len(m.released) > 0 {
+		// Fill the gaps first, later numbers can't commit until they are
+		sequenceNumber := m.released[0]
+		m.released = m.released[1:]
+		return sequenceNumber, nil
+	}
+	if m.outstanding() >= maxOutstanding {
+		err := m.waitFor(func() bool {
+			m.next = max(m.next, m.onChain)
+			return m.outstanding() < maxOutstanding
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	sequenceNumber := m.next
+	m.next++
+	return sequenceNumber, nil
+}
+
+// Synchronize waits for handed out sequence numbers to commit, then restarts from the on-chain sequence number
+//
+// Call this after a submitted transaction expires, as later sequence numbers can't commit until the gap is filled.  For
+// transactions rejected before reaching the mempool, [AccountSequenceNumberManager.Release] fills the gap without
+// waiting.  If the outstanding transactions don't commit within MaxWait, the manager restarts from the on-chain sequence
+// number anyway, and in-flight transactions may fail with a sequence number error.
+func (m *AccountSequenceNumberManager) Synchronize() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	err := m.waitFor(func() bool { return m.onChain >= m.next })
+	if err != nil && !errors.Is(err, ErrTooManyOutstandingTransactions) {
+		return err
+	}
+	m.next = m.onChain
+	m.released = nil
+	m.initialized = true
+	return nil
+}
+
+// Release gives back a sequence number that was handed out but won't be used, e.g. the transaction failed to build or
+// was rejected by the node, so it's handed out again before any new number.  Unlike
+// [AccountSequenceNumberManager.Synchronize], it doesn't wait for outstanding transactions.
+//
+// Only release numbers that never reached the mempool, a pending transaction with the number would conflict.
+func (m *AccountSequenceNumberManager) Release(sequenceNumber uint64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.initialized || sequenceNumber < m.onChain || sequenceNumber >= m.next {
+		return
+	}
+	i, found := slices.BinarySearch(m.released, sequenceNumber)
+	if !found {
+		m.released = slices.Insert(m.released, i, sequenceNumber)
+	}
+	// Released numbers at the end are handed out next anyway
+	for len(m.released) > 0 && m.released[len(m.released)-1] == m.next-1 {
+		m.released = m.released[:len(m.released)-1]
+		m.next--
+	}
+}
+
+// Resync fetches the on-chain sequence number without waiting for outstanding transactions, and skips the numbers
+// behind it.  Call it when a transaction fails with [ErrSequenceNumberTooOld], e.g. another process used the account.
+func (m *AccountSequenceNumberManager) Resync() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err := m.refresh(); err != nil {
+		return err
+	}
+	if !m.initialized {
+		m.next = m.onChain
+		m.initialized = true
+	}
+	m.next = max(m.next, m.onChain)
+	m.dropReleased()
+	return nil
+}
+
+// Outstanding returns the number of sequence numbers handed out, which were not committed as of the last check
+func (m *AccountSequenceNumberManager) Outstanding() uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.outstanding()
+}
+
+// outstanding is the number of sequence numbers ahead of the chain, must be called with the lock held
+func (m *AccountSequenceNumberManager) outstanding() uint64 {
+	if m.next < m.onChain {
+		return 0
+	}
+	return m.next - m.onChain
+}
+
+// dropReleased removes released numbers the chain has passed, must be called with the lock held
+func (m *AccountSequenceNumberManager) dropReleased() {
+	for len(m.released) > 0 && m.released[0] < m.onChain {
+		m.released = m.released[1:]
+	}
+}
+
+// refresh fetches the on-chain sequence number, must be called with the lock held
+func (m *AccountSequenceNumberManager) refresh() error {
+	info, err := m.client.Account(m.address)
+	if err != nil {
+		return fmt.Errorf("failed to fetch sequence number for %s: %w", m.address.String(), err)
+	}
+	sequenceNumber, err := info.SequenceNumber()
+	if err != nil {
+		return err
+	}
+	m.onChain = sequenceNumber
+	return nil
+}
+
+// waitFor polls the on-chain sequence number until done returns true, must be called with the lock held
+func (m *AccountSequenceNumberManager) waitFor(done func() bool) error {
+	pollInterval := m.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultSequenceNumberPollInterval
+	}
+	deadline := time.Now().Add(m.MaxWait)
+	for {
+		if err := m.refresh(); err != nil {
+			return err
+		}
+		if done() {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: %d uncommitted for %s", ErrTooManyOutstandingTransactions, m.outstanding(), m.address.String())
+		}
+		time.Sleep(pollInterval)
+	}
+}
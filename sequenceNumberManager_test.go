@@ -0,0 +1,146 @@
+package aptos
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSequenceNumberClient struct {
+	sequenceNumber atomic.Uint64
+	requests       atomic.Uint64
+}
+
+func (m *mockSequenceNumberClient) Account(_ AccountAddress, _ ...uint64) (AccountInfo, error) {
+	m.requests.Add(1)
+	return AccountInfo{SequenceNumberStr: strconv.FormatUint(m.sequenceNumber.Load(), 10)}, nil
+}
+
+func TestAccountSequenceNumberManager(t *testing.T) {
+	client := &mockSequenceNumberClient{}
+	client.sequenceNumber.Store(5)
+	manager := NewAccountSequenceNumberManager(client, AccountOne)
+
+	// Concurrent callers each get a unique number, with a single fetch
+	const count = 50
+	results := make(chan uint64, count)
+	wg := sync.WaitGroup{}
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sequenceNumber, err := manager.NextSequenceNumber()
+			assert.NoError(t, err)
+			results <- sequenceNumber
+		}()
+	}
+	wg.Wait()
+	close(results)
+	seen := make(map[uint64]bool)
+	for sequenceNumber := range results {
+		assert.False(t, seen[sequenceNumber])
+		seen[sequenceNumber] = true
+		assert.GreaterOrEqual(t, sequenceNumber, uint64(5))
+		assert.Less(t, sequenceNumber, uint64(5+count))
+	}
+	assert.Equal(t, uint64(1), client.requests.Load())
+	assert.Equal(t, uint64(count), manager.Outstanding())
+
+	// Once everything commits, synchronizing continues from the chain
+	client.sequenceNumber.Store(5 + count)
+	assert.NoError(t, manager.Synchronize())
+	sequenceNumber, err := manager.NextSequenceNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5+count), sequenceNumber)
+}
+
+func TestAccountSequenceNumberManagerMaxOutstanding(t *testing.T) {
+	client := &mockSequenceNumberClient{}
+	manager := NewAccountSequenceNumberManager(client, AccountOne)
+	manager.MaxOutstanding = 2
+	manager.PollInterval = time.Millisecond
+	manager.MaxWait = 20 * time.Millisecond
+
+	for i := uint64(0); i < 2; i++ {
+		sequenceNumber, err := manager.NextSequenceNumber()
+		assert.NoError(t, err)
+		assert.Equal(t, i, sequenceNumber)
+	}
+
+	// Nothing commits, so the cap is hit
+	_, err := manager.NextSequenceNumber()
+	assert.ErrorIs(t, err, ErrTooManyOutstandingTransactions)
+
+	// When the first commits, the next number is handed out
+	client.sequenceNumber.Store(1)
+	sequenceNumber, err := manager.NextSequenceNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), sequenceNumber)
+
+	// A failed transaction leaves a gap, synchronizing hands out the numbers again
+	assert.NoError(t, manager.Synchronize())
+	sequenceNumber, err = manager.NextSequenceNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), sequenceNumber)
+}
+
+func TestAccountSequenceNumberManagerChainAhead(t *testing.T) {
+	client := &mockSequenceNumberClient{}
+	manager := NewAccountSequenceNumberManager(client, AccountOne)
+	manager.MaxOutstanding = 2
+	manager.PollInterval = time.Millisecond
+
+	for i := uint64(0); i < 2; i++ {
+		_, err := manager.NextSequenceNumber()
+		assert.NoError(t, err)
+	}
+
+	// Another process used the account, so the chain is past every number handed out
+	client.sequenceNumber.Store(10)
+	start := time.Now()
+	sequenceNumber, err := manager.NextSequenceNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), sequenceNumber)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, uint64(1), manager.Outstanding())
+
+	sequenceNumber, err = manager.NextSequenceNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(11), sequenceNumber)
+}
+
+func TestAccountSequenceNumberManagerReleaseAndResync(t *testing.T) {
+	client := &mockSequenceNumberClient{}
+	manager := NewAccountSequenceNumberManager(client, AccountOne)
+	for i := uint64(0); i < 4; i++ {
+		_, err := manager.NextSequenceNumber()
+		assert.NoError(t, err)
+	}
+
+	// Released numbers are handed out again, lowest first, before new ones
+	manager.Release(2)
+	manager.Release(1)
+	manager.Release(7)
+	for _, expected := range []uint64{1, 2, 4} {
+		sequenceNumber, err := manager.NextSequenceNumber()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, sequenceNumber)
+	}
+
+	// Releasing the last number handed out rewinds
+	manager.Release(4)
+	assert.Equal(t, uint64(4), manager.Outstanding())
+
+	// Resync skips numbers the chain has passed, without waiting
+	client.sequenceNumber.Store(9)
+	manager.Release(3)
+	assert.NoError(t, manager.Resync())
+	assert.Equal(t, uint64(0), manager.Outstanding())
+	sequenceNumber, err := manager.NextSequenceNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), sequenceNumber)
+}
@@ -2,6 +2,8 @@ package aptos
 
 import (
 	"errors"
+	"fmt"
+
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/crypto"
 )
@@ -32,16 +34,118 @@ type SignedTransaction struct {
 	Authenticator *TransactionAuthenticator // The authenticator for a transaction (can't be be a standalone [crypto.AccountAuthenticator])
 }
 
-// Verify checks a signed transaction's signature
+// Verify checks a signed transaction's signatures, see [VerifySignedTransaction]
 func (txn *SignedTransaction) Verify() error {
-	bytes, err := txn.Transaction.SigningMessage()
+	return VerifySignedTransaction(txn)
+}
+
+// ErrInvalidSignature is returned by [VerifySignedTransaction] when a signature doesn't match the signing message
+var ErrInvalidSignature = errors.New("signature is invalid")
+
+// VerifySignedTransaction checks every signature of a signed transaction locally, against the signing message for its
+// kind of authenticator, so a relayer can reject invalid submissions before forwarding them to a fullnode.
+//
+// Single signer transactions (ed25519, multi-ed25519, single key, and multi-key) are checked against the raw
+// transaction.  Multi-agent transactions check the sender and every secondary signer against the multi-agent message.
+// Fee payer transactions check the fee payer against the message with its address, and the sender and secondary signers
+// against the message with either [AccountZero] or the fee payer address, the same as the chain does.
+//
+// Only signatures are checked.  That the keys match the on-chain authentication keys of the signers, and the sequence
+// number, expiration, and chain id, can only be checked by the chain.  Invalid signatures wrap [ErrInvalidSignature].
+func VerifySignedTransaction(signedTxn *SignedTransaction) error {
+	if signedTxn == nil || signedTxn.Transaction == nil || signedTxn.Authenticator == nil || signedTxn.Authenticator.Auth == nil {
+		return errors.New("signed transaction is missing its transaction or authenticator")
+	}
+	rawTxn := signedTxn.Transaction
+
+	switch auth := signedTxn.Authenticator.Auth.(type) {
+	case *Ed25519TransactionAuthenticator:
+		return verifyRawTransactionSigner("sender", auth.Sender, rawTxn)
+	case *MultiEd25519TransactionAuthenticator:
+		return verifyRawTransactionSigner("sender", auth.Sender, rawTxn)
+	case *SingleSenderTransactionAuthenticator:
+		return verifyRawTransactionSigner("sender", auth.Sender, rawTxn)
+	case *MultiAgentTransactionAuthenticator:
+		if len(auth.SecondarySignerAddresses) != len(auth.SecondarySigners) {
+			return fmt.Errorf("multi-agent transaction has %d secondary signer addresses, but %d secondary signers", len(auth.SecondarySignerAddresses), len(auth.SecondarySigners))
+		}
+		message, err := NewMultiAgentRawTransaction(rawTxn, auth.SecondarySignerAddresses...).SigningMessage()
+		if err != nil {
+			return err
+		}
+		err = verifySigner("sender", auth.Sender, message)
+		if err != nil {
+			return err
+		}
+		for i := range auth.SecondarySigners {
+			err = verifySigner(secondarySignerRole(i, auth.SecondarySignerAddresses[i]), &auth.SecondarySigners[i], message)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case *FeePayerTransactionAuthenticator:
+		if len(auth.SecondarySignerAddresses) != len(auth.SecondarySigners) {
+			return fmt.Errorf("fee payer transaction has %d secondary signer addresses, but %d secondary signers", len(auth.SecondarySignerAddresses), len(auth.SecondarySigners))
+		}
+		if auth.FeePayer == nil {
+			return errors.New("fee payer transaction is missing its fee payer")
+		}
+		feePayerMessage, err := NewFeePayerRawTransaction(rawTxn, *auth.FeePayer, auth.SecondarySignerAddresses...).SigningMessage()
+		if err != nil {
+			return err
+		}
+		// The sender and secondary signers may sign before the fee payer is known
+		noFeePayerMessage, err := NewFeePayerRawTransaction(rawTxn, AccountZero, auth.SecondarySignerAddresses...).SigningMessage()
+		if err != nil {
+			return err
+		}
+		verifyEither := func(role string, signer *crypto.AccountAuthenticator) error {
+			if verifySigner(role, signer, noFeePayerMessage) == nil {
+				return nil
+			}
+			return verifySigner(role, signer, feePayerMessage)
+		}
+
+		err = verifyEither("sender", auth.Sender)
+		if err != nil {
+			return err
+		}
+		for i := range auth.SecondarySigners {
+			err = verifyEither(secondarySignerRole(i, auth.SecondarySignerAddresses[i]), &auth.SecondarySigners[i])
+			if err != nil {
+				return err
+			}
+		}
+		return verifySigner("fee payer "+auth.FeePayer.String(), auth.FeePayerAuthenticator, feePayerMessage)
+	default:
+		return fmt.Errorf("unknown transaction authenticator %T", auth)
+	}
+}
+
+// verifyRawTransactionSigner checks a single signer of a raw transaction
+func verifyRawTransactionSigner(role string, signer *crypto.AccountAuthenticator, rawTxn *RawTransaction) error {
+	message, err := rawTxn.SigningMessage()
 	if err != nil {
 		return err
 	}
-	if txn.Authenticator.Verify(bytes) {
-		return nil
+	return verifySigner(role, signer, message)
+}
+
+// verifySigner checks one authenticator against the message, naming the signer's role in the error
+func verifySigner(role string, signer *crypto.AccountAuthenticator, message []byte) error {
+	if signer == nil || signer.Auth == nil {
+		return fmt.Errorf("%s is missing its authenticator", role)
+	}
+	if !signer.Verify(message) {
+		return fmt.Errorf("%s %w", role, ErrInvalidSignature)
 	}
-	return errors.New("signature is invalid")
+	return nil
+}
+
+// secondarySignerRole names a secondary signer in errors
+func secondarySignerRole(index int, address AccountAddress) string {
+	return fmt.Sprintf("secondary signer %d %s", index, address.String())
 }
 
 // TransactionPrefix is a cached hash prefix for taking transaction hashes
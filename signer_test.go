@@ -50,19 +50,16 @@ func (s *MultiEd25519TestSigner) Sign(msg []byte) (authenticator *crypto.Account
 }
 
 func (s *MultiEd25519TestSigner) SignMessage(msg []byte) (crypto.Signature, error) {
-	signatures := make([]*crypto.Ed25519Signature, s.SignaturesRequired)
+	signatures := make([]crypto.IndexedEd25519Signature, s.SignaturesRequired)
 	for i := 0; i < int(s.SignaturesRequired); i++ {
 		sig, err := s.Keys[i].SignMessage(msg)
 		if err != nil {
 			return nil, err
 		}
-		signatures[i] = sig.(*crypto.Ed25519Signature)
+		signatures[i] = crypto.IndexedEd25519Signature{Index: uint8(i), Signature: sig.(*crypto.Ed25519Signature)}
 	}
 
-	return &crypto.MultiEd25519Signature{
-		Signatures: signatures,
-		Bitmap:     [4]byte{},
-	}, nil
+	return crypto.NewMultiEd25519Signature(signatures)
 }
 
 func (s *MultiEd25519TestSigner) AuthKey() *crypto.AuthenticationKey {
@@ -0,0 +1,123 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+)
+
+// ErrSimulationRejected is returned when a transaction isn't submitted, as it failed the simulation required by
+// [RequireSuccessfulSimulation]
+var ErrSimulationRejected = errors.New("transaction rejected by simulation")
+
+// RequireSuccessfulSimulation is an option to [NewClient], which simulates every transaction before it is submitted,
+// and refuses to submit it if the simulation fails or uses too much gas.  This catches aborts before they cost gas, at
+// the cost of a simulation request per submission.
+//
+// Transactions are simulated without their signatures, so the simulation doesn't depend on the kind of key they were
+// signed with.  Use [Client.WithSimulationGate] to change or skip the check for one call.
+//
+//	client, err := NewClient(MainnetConfig, RequireSuccessfulSimulation{MaxGasUsed: 10_000})
+//	// Skip the check for a transaction known to pass
+//	response, err := client.WithSimulationGate(nil).SubmitTransaction(signedTxn)
+type RequireSuccessfulSimulation struct {
+	MaxGasUsed uint64 // MaxGasUsed rejects transactions using more gas units in simulation, 0 for no limit
+}
+
+// check rejects a simulated transaction that failed or used too much gas
+func (gate *RequireSuccessfulSimulation) check(simulated []*api.UserTransaction) error {
+	if len(simulated) == 0 {
+		return fmt.Errorf("%w: simulation returned no transactions", ErrSimulationRejected)
+	}
+	result := simulated[0]
+	if !result.Success {
+		return fmt.Errorf("%w: simulation failed with %s", ErrSimulationRejected, result.VmStatus)
+	}
+	if gate.MaxGasUsed != 0 && result.GasUsed > gate.MaxGasUsed {
+		return fmt.Errorf("%w: simulation used %d gas, more than the limit of %d", ErrSimulationRejected, result.GasUsed, gate.MaxGasUsed)
+	}
+	return nil
+}
+
+// WithSimulationGate returns a client sharing the connection and settings of this one, which checks transactions with
+// gate before submitting them.  A nil gate submits without simulating.
+//
+//	response, err := client.WithSimulationGate(&RequireSuccessfulSimulation{MaxGasUsed: 50_000}).SubmitTransaction(signedTxn)
+func (client *Client) WithSimulationGate(gate *RequireSuccessfulSimulation) *Client {
+	return &Client{
+		nodeClient:    client.nodeClient.WithSimulationGate(gate),
+		faucetClient:  client.faucetClient,
+		indexerClient: client.indexerClient,
+	}
+}
+
+// WithSimulationGate returns a client sharing the connection and settings of this one, which checks transactions with
+// gate before submitting them.  A nil gate submits without simulating.
+func (rc *NodeClient) WithSimulationGate(gate *RequireSuccessfulSimulation) *NodeClient {
+	return &NodeClient{
+		client:     rc.client,
+		baseUrl:    rc.baseUrl,
+		chainId:    rc.chainId,
+		headers:    rc.headers,
+		moduleAbis: rc.moduleAbis,
+		ctx:        rc.ctx,
+
+		simulationGate: gate,
+		gasDefaults:    rc.gasDefaults,
+		ledgerVersion:  rc.ledgerVersion,
+	}
+}
+
+// checkSimulationGate simulates the transaction, if the client requires it, and rejects it if the simulation fails
+func (rc *NodeClient) checkSimulationGate(signedTxn *SignedTransaction) error {
+	if rc.simulationGate == nil {
+		return nil
+	}
+	simulationTxn, err := simulationSignedTransaction(signedTxn)
+	if err != nil {
+		return err
+	}
+	simulated, err := rc.simulateTransactionInner(simulationTxn)
+	if err != nil {
+		return err
+	}
+	return rc.simulationGate.check(simulated)
+}
+
+// simulationSignedTransaction copies the transaction with every signature removed, as the node refuses to simulate
+// signed transactions
+func simulationSignedTransaction(signedTxn *SignedTransaction) (*SignedTransaction, error) {
+	noSigners := func(count int) []crypto.AccountAuthenticator {
+		out := make([]crypto.AccountAuthenticator, count)
+		for i := range out {
+			out[i] = *crypto.NoAccountAuthenticator()
+		}
+		return out
+	}
+
+	auth := &TransactionAuthenticator{Variant: signedTxn.Authenticator.Variant}
+	switch inner := signedTxn.Authenticator.Auth.(type) {
+	case *Ed25519TransactionAuthenticator, *MultiEd25519TransactionAuthenticator, *SingleSenderTransactionAuthenticator:
+		auth.Variant = TransactionAuthenticatorSingleSender
+		auth.Auth = &SingleSenderTransactionAuthenticator{Sender: crypto.NoAccountAuthenticator()}
+	case *MultiAgentTransactionAuthenticator:
+		auth.Auth = &MultiAgentTransactionAuthenticator{
+			Sender:                   crypto.NoAccountAuthenticator(),
+			SecondarySignerAddresses: inner.SecondarySignerAddresses,
+			SecondarySigners:         noSigners(len(inner.SecondarySigners)),
+		}
+	case *FeePayerTransactionAuthenticator:
+		auth.Auth = &FeePayerTransactionAuthenticator{
+			Sender:                   crypto.NoAccountAuthenticator(),
+			SecondarySignerAddresses: inner.SecondarySignerAddresses,
+			SecondarySigners:         noSigners(len(inner.SecondarySigners)),
+			FeePayer:                 inner.FeePayer,
+			FeePayerAuthenticator:    crypto.NoAccountAuthenticator(),
+		}
+	default:
+		return nil, fmt.Errorf("can't simulate transaction authenticator %T", inner)
+	}
+	return &SignedTransaction{Transaction: signedTxn.Transaction, Authenticator: auth}, nil
+}
@@ -0,0 +1,81 @@
+package aptos
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireSuccessfulSimulation(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+
+	success := true
+	gasUsed := 10
+	simulated := 0
+	submitted := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/transactions/simulate":
+			simulated++
+			// Signatures are removed before simulating
+			body, _ := io.ReadAll(r.Body)
+			assert.True(t, bytes.HasSuffix(body, []byte{byte(TransactionAuthenticatorSingleSender), byte(crypto.AccountAuthenticatorNone)}))
+			_, _ = w.Write([]byte(fmt.Sprintf(`[{"type": "user_transaction", "version": "10", "hash": "0x1234", "sender": "%s", "sequence_number": "0", "max_gas_amount": "100", "gas_unit_price": "100", "expiration_timestamp_secs": "1", "gas_used": "%d", "success": %t, "vm_status": "Move abort", "changes": [], "events": [], "timestamp": "1"}]`, sender.Address.String(), gasUsed, success)))
+		case "/v1/transactions":
+			submitted++
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"hash": "0x1234", "sender": "%s", "sequence_number": "0"}`, sender.Address.String())))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: mockServer.URL + "/v1"}, RequireSuccessfulSimulation{MaxGasUsed: 50})
+	assert.NoError(t, err)
+
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	rawTxn, err := client.BuildTransaction(sender.Address, TransactionPayload{Payload: payload}, SequenceNumber(0), GasUnitPrice(100), MaxGasAmount(100))
+	assert.NoError(t, err)
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+
+	_, err = client.SubmitTransaction(signedTxn)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, simulated)
+	assert.Equal(t, 1, submitted)
+
+	// Failed simulations aren't submitted
+	success = false
+	_, err = client.SubmitTransaction(signedTxn)
+	assert.ErrorIs(t, err, ErrSimulationRejected)
+	assert.ErrorContains(t, err, "Move abort")
+	assert.Equal(t, 1, submitted)
+
+	// Nor are ones over the gas limit
+	success = true
+	gasUsed = 60
+	_, err = client.SubmitTransaction(signedTxn)
+	assert.ErrorIs(t, err, ErrSimulationRejected)
+	_, err = client.BatchSubmitTransaction([]*SignedTransaction{signedTxn})
+	assert.ErrorIs(t, err, ErrSimulationRejected)
+	assert.Equal(t, 1, submitted)
+
+	// The gate can be changed or skipped per call
+	_, err = client.WithSimulationGate(&RequireSuccessfulSimulation{MaxGasUsed: 100}).SubmitTransaction(signedTxn)
+	assert.NoError(t, err)
+	simulated = 0
+	_, err = client.WithSimulationGate(nil).SubmitTransaction(signedTxn)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, simulated)
+	assert.Equal(t, 3, submitted)
+}
@@ -0,0 +1,320 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// TokenStoreResourceType is the resource holding the legacy 0x3::token tokens of an account, see [TokenStoreResource]
+const TokenStoreResourceType = "0x3::token::TokenStore"
+
+// TokenV1DataId identifies a token in the legacy 0x3::token standard, by its creator, collection, and name
+type TokenV1DataId struct {
+	Creator    AccountAddress // Creator of the collection
+	Collection string         // Collection name
+	Name       string         // Name of the token
+}
+
+// TokenV1Id identifies a property version of a token in the legacy 0x3::token standard.  Tokens that haven't had their
+// properties mutated are property version 0.
+type TokenV1Id struct {
+	TokenV1DataId
+	PropertyVersion uint64 // PropertyVersion of the token, 0 unless the properties have been mutated
+}
+
+// MarshalJSON serializes the id as a 0x3::token::TokenId, for looking it up in a TokenStore
+func (id TokenV1Id) MarshalJSON() ([]byte, error) {
+	type tokenDataId struct {
+		Creator    string `json:"creator"`
+		Collection string `json:"collection"`
+		Name       string `json:"name"`
+	}
+	return json.Marshal(struct {
+		TokenDataId     tokenDataId `json:"token_data_id"`
+		PropertyVersion string      `json:"property_version"`
+	}{
+		TokenDataId:     tokenDataId{Creator: id.Creator.StringLong(), Collection: id.Collection, Name: id.Name},
+		PropertyVersion: strconv.FormatUint(id.PropertyVersion, 10),
+	})
+}
+
+// TokenV1Collection configures a collection created by [TokenV1CreateCollectionPayload]
+type TokenV1Collection struct {
+	Name        string // Name is unique across the collections of the creator
+	Description string // Description of the collection
+	Uri         string // Uri of the collection metadata
+	Maximum     uint64 // Maximum number of tokens in the collection, 0 for unlimited
+
+	MutableDescription bool // MutableDescription allows the creator to change the description
+	MutableUri         bool // MutableUri allows the creator to change the uri
+	MutableMaximum     bool // MutableMaximum allows the creator to change the maximum
+}
+
+// TokenV1Token configures a token created by [TokenV1CreateTokenPayload]
+type TokenV1Token struct {
+	Collection  string          // Collection is the name of a collection of the creator
+	Name        string          // Name of the token
+	Description string          // Description of the token
+	Uri         string          // Uri of the token metadata
+	Balance     uint64          // Balance is the amount minted to the creator
+	Maximum     uint64          // Maximum supply of the token, 0 for unlimited
+	Properties  []TokenProperty // Properties are the initial default properties of the token
+
+	RoyaltyPayee             AccountAddress // RoyaltyPayee receives royalties
+	RoyaltyPointsNumerator   uint64         // RoyaltyPointsNumerator of the royalty fraction, 0 for none
+	RoyaltyPointsDenominator uint64         // RoyaltyPointsDenominator of the royalty fraction
+
+	MutableMaximum     bool // MutableMaximum allows the creator to change the maximum
+	MutableUri         bool // MutableUri allows the creator to change the uri
+	MutableRoyalty     bool // MutableRoyalty allows the creator to change the royalty
+	MutableDescription bool // MutableDescription allows the creator to change the description
+	MutableProperties  bool // MutableProperties allows the creator to change the properties
+}
+
+// TokenV1CreateCollectionPayload builds an EntryFunction payload for 0x3::token::create_collection_script
+func TokenV1CreateCollectionPayload(collection TokenV1Collection) (*EntryFunction, error) {
+	name, err := serializeStringArg(collection.Name)
+	if err != nil {
+		return nil, err
+	}
+	description, err := serializeStringArg(collection.Description)
+	if err != nil {
+		return nil, err
+	}
+	uri, err := serializeStringArg(collection.Uri)
+	if err != nil {
+		return nil, err
+	}
+	maximum, err := bcs.SerializeU64(collection.Maximum)
+	if err != nil {
+		return nil, err
+	}
+	mutateSetting, err := serializeBoolsArg(collection.MutableDescription, collection.MutableUri, collection.MutableMaximum)
+	if err != nil {
+		return nil, err
+	}
+	return tokenV1EntryFunction("token", "create_collection_script", [][]byte{name, description, uri, maximum, mutateSetting}), nil
+}
+
+// TokenV1CreateTokenPayload builds an EntryFunction payload for 0x3::token::create_token_script
+func TokenV1CreateTokenPayload(token TokenV1Token) (*EntryFunction, error) {
+	args := make([][]byte, 0, 13)
+	for _, s := range []string{token.Collection, token.Name, token.Description} {
+		arg, err := serializeStringArg(s)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	balance, err := bcs.SerializeU64(token.Balance)
+	if err != nil {
+		return nil, err
+	}
+	maximum, err := bcs.SerializeU64(token.Maximum)
+	if err != nil {
+		return nil, err
+	}
+	uri, err := serializeStringArg(token.Uri)
+	if err != nil {
+		return nil, err
+	}
+	denominator, err := bcs.SerializeU64(token.RoyaltyPointsDenominator)
+	if err != nil {
+		return nil, err
+	}
+	numerator, err := bcs.SerializeU64(token.RoyaltyPointsNumerator)
+	if err != nil {
+		return nil, err
+	}
+	mutateSetting, err := serializeBoolsArg(token.MutableMaximum, token.MutableUri, token.MutableRoyalty, token.MutableDescription, token.MutableProperties)
+	if err != nil {
+		return nil, err
+	}
+	keys, types, values, err := encodeTokenProperties(token.Properties)
+	if err != nil {
+		return nil, err
+	}
+	// Unlike 0x4::aptos_token::mint, the values come before the types
+	args = append(args, balance, maximum, uri, token.RoyaltyPayee[:], denominator, numerator, mutateSetting, keys, values, types)
+	return tokenV1EntryFunction("token", "create_token_script", args), nil
+}
+
+// TokenV1OfferPayload builds an EntryFunction payload for 0x3::token_transfers::offer_script, offering amount of a token
+// to the receiver, who claims it with [TokenV1ClaimPayload]
+func TokenV1OfferPayload(receiver AccountAddress, id TokenV1Id, amount uint64) (*EntryFunction, error) {
+	args, err := tokenV1IdArgs(id)
+	if err != nil {
+		return nil, err
+	}
+	amountBytes, err := bcs.SerializeU64(amount)
+	if err != nil {
+		return nil, err
+	}
+	args = append([][]byte{receiver[:]}, append(args, amountBytes)...)
+	return tokenV1EntryFunction("token_transfers", "offer_script", args), nil
+}
+
+// TokenV1ClaimPayload builds an EntryFunction payload for 0x3::token_transfers::claim_script, claiming a token offered by
+// the sender
+func TokenV1ClaimPayload(sender AccountAddress, id TokenV1Id) (*EntryFunction, error) {
+	args, err := tokenV1IdArgs(id)
+	if err != nil {
+		return nil, err
+	}
+	return tokenV1EntryFunction("token_transfers", "claim_script", append([][]byte{sender[:]}, args...)), nil
+}
+
+// TokenV1CancelOfferPayload builds an EntryFunction payload for 0x3::token_transfers::cancel_offer_script, taking back a
+// token offered to the receiver
+func TokenV1CancelOfferPayload(receiver AccountAddress, id TokenV1Id) (*EntryFunction, error) {
+	args, err := tokenV1IdArgs(id)
+	if err != nil {
+		return nil, err
+	}
+	return tokenV1EntryFunction("token_transfers", "cancel_offer_script", append([][]byte{receiver[:]}, args...)), nil
+}
+
+// TokenV1OptInDirectTransferPayload builds an EntryFunction payload for 0x3::token::opt_in_direct_transfer, allowing
+// tokens to be transferred to the account without an offer and claim
+func TokenV1OptInDirectTransferPayload(optIn bool) (*EntryFunction, error) {
+	optInBytes, err := bcs.SerializeBool(optIn)
+	if err != nil {
+		return nil, err
+	}
+	return tokenV1EntryFunction("token", "opt_in_direct_transfer", [][]byte{optInBytes}), nil
+}
+
+// tokenV1IdArgs serializes the creator, collection, name, and property version arguments shared by the token functions
+func tokenV1IdArgs(id TokenV1Id) ([][]byte, error) {
+	collection, err := serializeStringArg(id.Collection)
+	if err != nil {
+		return nil, err
+	}
+	name, err := serializeStringArg(id.Name)
+	if err != nil {
+		return nil, err
+	}
+	propertyVersion, err := bcs.SerializeU64(id.PropertyVersion)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{id.Creator[:], collection, name, propertyVersion}, nil
+}
+
+func tokenV1EntryFunction(module string, function string, args [][]byte) *EntryFunction {
+	return &EntryFunction{
+		Module: ModuleId{
+			Address: AccountThree,
+			Name:    module,
+		},
+		Function: function,
+		ArgTypes: []TypeTag{},
+		Args:     args,
+	}
+}
+
+func serializeStringArg(s string) ([]byte, error) {
+	return bcs.SerializeSingle(func(ser *bcs.Serializer) { ser.WriteString(s) })
+}
+
+func serializeBoolsArg(values ...bool) ([]byte, error) {
+	return bcs.SerializeSingle(func(ser *bcs.Serializer) {
+		bcs.SerializeSequenceWithFunction(values, ser, func(ser *bcs.Serializer, v bool) { ser.Bool(v) })
+	})
+}
+
+// TokenStoreResource is the 0x3::token::TokenStore resource, see [TokenStoreResourceType]
+type TokenStoreResource struct {
+	Tokens                    string      // Tokens is the handle of the table of tokens owned, by 0x3::token::TokenId
+	DirectTransfer            bool        // DirectTransfer is true if the account accepts tokens without an offer and claim
+	DepositEvents             EventHandle // DepositEvents is the legacy handle for deposits
+	WithdrawEvents            EventHandle // WithdrawEvents is the legacy handle for withdrawals
+	BurnEvents                EventHandle // BurnEvents is the legacy handle for burns
+	MutateTokenPropertyEvents EventHandle // MutateTokenPropertyEvents is the legacy handle for property changes
+}
+
+// UnmarshalJSON deserializes a JSON data blob into a [TokenStoreResource]
+func (o *TokenStoreResource) UnmarshalJSON(b []byte) error {
+	type inner struct {
+		Tokens struct {
+			Handle string `json:"handle"`
+		} `json:"tokens"`
+		DirectTransfer            bool        `json:"direct_transfer"`
+		DepositEvents             EventHandle `json:"deposit_events"`
+		WithdrawEvents            EventHandle `json:"withdraw_events"`
+		BurnEvents                EventHandle `json:"burn_events"`
+		MutateTokenPropertyEvents EventHandle `json:"mutate_token_property_events"`
+	}
+	data := &inner{}
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return err
+	}
+	o.Tokens = data.Tokens.Handle
+	o.DirectTransfer = data.DirectTransfer
+	o.DepositEvents = data.DepositEvents
+	o.WithdrawEvents = data.WithdrawEvents
+	o.BurnEvents = data.BurnEvents
+	o.MutateTokenPropertyEvents = data.MutateTokenPropertyEvents
+	return nil
+}
+
+// TokenV1Balance returns the amount of a legacy 0x3::token token held by the owner, 0 if it has none
+//
+//	id := TokenV1Id{TokenV1DataId: TokenV1DataId{Creator: creator, Collection: "Heroes", Name: "Alice"}}
+//	balance, err := client.TokenV1Balance(owner, id)
+func (client *Client) TokenV1Balance(owner AccountAddress, id TokenV1Id, ledgerVersion ...uint64) (uint64, error) {
+	return client.nodeClient.TokenV1Balance(owner, id, ledgerVersion...)
+}
+
+// TokenV1Balance returns the amount of a legacy 0x3::token token held by the owner, 0 if it has none
+func (rc *NodeClient) TokenV1Balance(owner AccountAddress, id TokenV1Id, ledgerVersion ...uint64) (uint64, error) {
+	store := &TokenStoreResource{}
+	err := rc.AccountResourceInto(owner, TokenStoreResourceType, store, ledgerVersion...)
+	if HasErrorCode(err, api.ErrorCodeResourceNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	token := &struct {
+		Amount api.U64 `json:"amount"`
+	}{}
+	err = rc.tableItemInto(store.Tokens, "0x3::token::TokenId", "0x3::token::Token", id, token, ledgerVersion)
+	if HasErrorCode(err, api.ErrorCodeTableItemNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return token.Amount.ToUint64(), nil
+}
+
+// tableItemInto fetches a table item by its key, and decodes its JSON value into out
+func (rc *NodeClient) tableItemInto(handle string, keyType string, valueType string, key any, out any, ledgerVersion []uint64) error {
+	body, err := json.Marshal(map[string]any{
+		"key_type":   keyType,
+		"value_type": valueType,
+		"key":        key,
+	})
+	if err != nil {
+		return err
+	}
+	au := rc.baseUrl.JoinPath("tables", handle, "item")
+	rc.setLedgerVersion(au, ledgerVersion)
+	data, err := Post[json.RawMessage](rc, au.String(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("get table item api err: %w", err)
+	}
+	err = json.Unmarshal(data, out)
+	if err != nil {
+		return fmt.Errorf("failed to decode table item: %w", err)
+	}
+	return nil
+}
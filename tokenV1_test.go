@@ -0,0 +1,108 @@
+package aptos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenV1Payloads(t *testing.T) {
+	collection, err := TokenV1CreateCollectionPayload(TokenV1Collection{Name: "Heroes", Maximum: 10, MutableUri: true})
+	assert.NoError(t, err)
+	assert.Equal(t, AccountThree, collection.Module.Address)
+	assert.Equal(t, "create_collection_script", collection.Function)
+	assert.Len(t, collection.Args, 5)
+	assert.Equal(t, []byte{3, 0, 1, 0}, collection.Args[4])
+
+	level, err := NewTokenProperty("level", uint8(3))
+	assert.NoError(t, err)
+	token, err := TokenV1CreateTokenPayload(TokenV1Token{Collection: "Heroes", Name: "Alice", Balance: 1, RoyaltyPayee: AccountTwo, Properties: []TokenProperty{level}})
+	assert.NoError(t, err)
+	assert.Equal(t, "create_token_script", token.Function)
+	assert.Len(t, token.Args, 13)
+	assert.Equal(t, AccountTwo[:], token.Args[6])
+	assert.Equal(t, []byte{5, 0, 0, 0, 0, 0}, token.Args[9])
+	assert.Equal(t, []byte{1, 5, 'l', 'e', 'v', 'e', 'l'}, token.Args[10])
+	assert.Equal(t, []byte{1, 1, 3}, token.Args[11])
+	assert.Equal(t, []byte{1, 2, 'u', '8'}, token.Args[12])
+
+	id := TokenV1Id{TokenV1DataId: TokenV1DataId{Creator: AccountTwo, Collection: "Heroes", Name: "Alice"}}
+	offer, err := TokenV1OfferPayload(AccountThree, id, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "token_transfers", offer.Module.Name)
+	assert.Equal(t, "offer_script", offer.Function)
+	assert.Len(t, offer.Args, 6)
+	assert.Equal(t, AccountThree[:], offer.Args[0])
+	assert.Equal(t, AccountTwo[:], offer.Args[1])
+
+	claim, err := TokenV1ClaimPayload(AccountOne, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "claim_script", claim.Function)
+	assert.Len(t, claim.Args, 5)
+	assert.Equal(t, AccountOne[:], claim.Args[0])
+
+	optIn, err := TokenV1OptInDirectTransferPayload(true)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{1}}, optIn.Args)
+}
+
+func TestClient_TokenV1Balance(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/0x1/resource/" + TokenStoreResourceType:
+			_, _ = w.Write([]byte(`{"type": "` + TokenStoreResourceType + `", "data": {"tokens": {"handle": "0xabc"}, "direct_transfer": true, "deposit_events": ` + testEventHandleJson + `, "withdraw_events": ` + testEventHandleJson + `, "burn_events": ` + testEventHandleJson + `, "mutate_token_property_events": ` + testEventHandleJson + `}}`))
+		case "/tables/0xabc/item":
+			var request struct {
+				KeyType   string `json:"key_type"`
+				ValueType string `json:"value_type"`
+				Key       struct {
+					TokenDataId struct {
+						Creator string `json:"creator"`
+						Name    string `json:"name"`
+					} `json:"token_data_id"`
+					PropertyVersion string `json:"property_version"`
+				} `json:"key"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "0x3::token::TokenId", request.KeyType)
+			assert.Equal(t, "0", request.Key.PropertyVersion)
+			assert.Equal(t, AccountTwo.StringLong(), request.Key.TokenDataId.Creator)
+			if request.Key.TokenDataId.Name == "Alice" {
+				_, _ = w.Write([]byte(`{"amount": "3", "token_properties": {"map": {"data": []}}}`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found", "error_code": "table_item_not_found"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found", "error_code": "resource_not_found"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	store := &TokenStoreResource{}
+	assert.NoError(t, client.AccountResourceInto(AccountOne, TokenStoreResourceType, store))
+	assert.Equal(t, "0xabc", store.Tokens)
+	assert.True(t, store.DirectTransfer)
+
+	id := TokenV1Id{TokenV1DataId: TokenV1DataId{Creator: AccountTwo, Collection: "Heroes", Name: "Alice"}}
+	balance, err := client.TokenV1Balance(AccountOne, id)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), balance)
+
+	id.Name = "Bob"
+	balance, err = client.TokenV1Balance(AccountOne, id)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), balance)
+
+	// Accounts without a token store hold nothing
+	balance, err = client.TokenV1Balance(AccountTwo, id)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), balance)
+}
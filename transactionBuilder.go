@@ -0,0 +1,147 @@
+package aptos
+
+import (
+	"errors"
+	"time"
+)
+
+// TransactionBuilderClient builds raw transactions for a [TransactionBuilder], implemented by [Client] and [NodeClient]
+type TransactionBuilderClient interface {
+	BuildTransaction(sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransaction, error)
+	BuildTransactionMultiAgent(sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransactionWithData, error)
+}
+
+// TransactionBuilder builds a raw transaction with chained options, as an alternative to passing options to
+// [Client.BuildTransaction].  Anything not set is fetched or estimated the same way, and defaults to the client's
+// [ClientGasConfig].
+//
+//	rawTxn, err := client.TransactionBuilder().
+//		Sender(sender.AccountAddress()).
+//		Payload(payload).
+//		MaxGas(20_000).
+//		ExpiresIn(time.Minute).
+//		Build()
+//
+// For a sponsored or multi-agent transaction, add the other signers and use [TransactionBuilder.BuildMultiAgent]
+//
+//	rawTxn, err := client.TransactionBuilder().
+//		Sender(sender.AccountAddress()).
+//		Payload(payload).
+//		WithFeePayer(AccountZero).
+//		BuildMultiAgent()
+type TransactionBuilder struct {
+	client            TransactionBuilderClient
+	sender            *AccountAddress
+	payload           *TransactionPayload
+	options           []any
+	feePayer          *AccountAddress
+	additionalSigners []AccountAddress
+}
+
+// NewTransactionBuilder creates a [TransactionBuilder] on the given client
+func NewTransactionBuilder(client TransactionBuilderClient) *TransactionBuilder {
+	return &TransactionBuilder{client: client}
+}
+
+// Sender sets the account sending the transaction, required
+func (b *TransactionBuilder) Sender(sender AccountAddress) *TransactionBuilder {
+	b.sender = &sender
+	return b
+}
+
+// Payload sets what the transaction runs, required
+func (b *TransactionBuilder) Payload(payload TransactionPayload) *TransactionBuilder {
+	b.payload = &payload
+	return b
+}
+
+// MaxGas sets the max gas amount, see [MaxGasAmount]
+func (b *TransactionBuilder) MaxGas(maxGasAmount uint64) *TransactionBuilder {
+	b.options = append(b.options, MaxGasAmount(maxGasAmount))
+	return b
+}
+
+// GasPrice sets the gas unit price, rather than estimating it, see [GasUnitPrice]
+func (b *TransactionBuilder) GasPrice(gasUnitPrice uint64) *TransactionBuilder {
+	b.options = append(b.options, GasUnitPrice(gasUnitPrice))
+	return b
+}
+
+// GasPriority sets which estimate is used when the gas unit price isn't set, see [GasPriority]
+func (b *TransactionBuilder) GasPriority(priority GasPriority) *TransactionBuilder {
+	b.options = append(b.options, priority)
+	return b
+}
+
+// ExpiresIn sets how long after it's built the transaction expires, rounded up to whole seconds, see
+// [ExpirationSeconds]
+func (b *TransactionBuilder) ExpiresIn(expiration time.Duration) *TransactionBuilder {
+	b.options = append(b.options, ExpirationSeconds((expiration+time.Second-1)/time.Second))
+	return b
+}
+
+// SequenceNumber sets the sequence number, rather than fetching the sender's, see [SequenceNumber]
+func (b *TransactionBuilder) SequenceNumber(sequenceNumber uint64) *TransactionBuilder {
+	b.options = append(b.options, SequenceNumber(sequenceNumber))
+	return b
+}
+
+// ChainId sets the chain id, rather than fetching it, see [ChainIdOption]
+func (b *TransactionBuilder) ChainId(chainId uint8) *TransactionBuilder {
+	b.options = append(b.options, ChainIdOption(chainId))
+	return b
+}
+
+// WithFeePayer makes the transaction sponsored by the fee payer.  Use [AccountZero] if the fee payer isn't known yet,
+// and set it when it signs.  Build with [TransactionBuilder.BuildMultiAgent].
+func (b *TransactionBuilder) WithFeePayer(feePayer AccountAddress) *TransactionBuilder {
+	b.feePayer = &feePayer
+	return b
+}
+
+// WithAdditionalSigners adds secondary signers to the transaction, making it multi-agent.  Build with
+// [TransactionBuilder.BuildMultiAgent].
+func (b *TransactionBuilder) WithAdditionalSigners(signers ...AccountAddress) *TransactionBuilder {
+	b.additionalSigners = append(b.additionalSigners, signers...)
+	return b
+}
+
+// Build builds a single signer raw transaction.  It fails if a fee payer or additional signers were set, use
+// [TransactionBuilder.BuildMultiAgent] for those.
+func (b *TransactionBuilder) Build() (*RawTransaction, error) {
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	if b.feePayer != nil || len(b.additionalSigners) > 0 {
+		return nil, errors.New("transaction builder has a fee payer or additional signers, use BuildMultiAgent")
+	}
+	return b.client.BuildTransaction(*b.sender, *b.payload, b.options...)
+}
+
+// BuildMultiAgent builds a raw transaction with a fee payer, additional signers, or both
+func (b *TransactionBuilder) BuildMultiAgent() (*RawTransactionWithData, error) {
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	options := append(make([]any, 0, len(b.options)+2), b.options...)
+	if b.feePayer != nil {
+		options = append(options, FeePayer(b.feePayer))
+	}
+	if len(b.additionalSigners) > 0 {
+		options = append(options, AdditionalSigners(b.additionalSigners))
+	}
+	return b.client.BuildTransactionMultiAgent(*b.sender, *b.payload, options...)
+}
+
+func (b *TransactionBuilder) check() error {
+	if b.client == nil {
+		return errors.New("transaction builder has no client")
+	}
+	if b.sender == nil {
+		return errors.New("transaction builder has no sender")
+	}
+	if b.payload == nil {
+		return errors.New("transaction builder has no payload")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package aptos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionBuilder(t *testing.T) {
+	client, err := NewClient(LocalnetConfig)
+	assert.NoError(t, err)
+	payload := TransactionPayload{Payload: ObjectTransferCallPayload(AccountTwo, AccountThree)}
+
+	now := uint64(time.Now().Unix())
+	rawTxn, err := client.TransactionBuilder().
+		Sender(AccountOne).
+		Payload(payload).
+		MaxGas(5000).
+		GasPrice(150).
+		ExpiresIn(1500 * time.Millisecond).
+		SequenceNumber(7).
+		ChainId(4).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, AccountOne, rawTxn.Sender)
+	assert.Equal(t, uint64(7), rawTxn.SequenceNumber)
+	assert.Equal(t, uint64(5000), rawTxn.MaxGasAmount)
+	assert.Equal(t, uint64(150), rawTxn.GasUnitPrice)
+	assert.Equal(t, uint8(4), rawTxn.ChainId)
+	assert.InDelta(t, now+2, rawTxn.ExpirationTimestampSeconds, 2)
+
+	builder := client.TransactionBuilder().Sender(AccountOne).Payload(payload).GasPrice(150).SequenceNumber(7).ChainId(4).WithFeePayer(AccountZero)
+	_, err = builder.Build()
+	assert.ErrorContains(t, err, "BuildMultiAgent")
+	rawTxnWithData, err := builder.WithAdditionalSigners(AccountTwo).BuildMultiAgent()
+	assert.NoError(t, err)
+	assert.Equal(t, MultiAgentWithFeePayerRawTransactionWithDataVariant, rawTxnWithData.Variant)
+	inner, ok := rawTxnWithData.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	assert.True(t, ok)
+	assert.Equal(t, AccountZero, *inner.FeePayer)
+	assert.Equal(t, []AccountAddress{AccountTwo}, inner.SecondarySigners)
+	assert.Equal(t, uint64(7), inner.RawTxn.SequenceNumber)
+
+	_, err = client.TransactionBuilder().Payload(payload).Build()
+	assert.ErrorContains(t, err, "no sender")
+	_, err = client.TransactionBuilder().Sender(AccountOne).BuildMultiAgent()
+	assert.ErrorContains(t, err, "no payload")
+}
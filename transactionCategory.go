@@ -0,0 +1,352 @@
+package aptos
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// TransactionCategory is a label for what a committed transaction did, see [TransactionClassifier]
+type TransactionCategory string
+
+const (
+	TransactionCategoryTransfer   TransactionCategory = "transfer"   // TransactionCategoryTransfer is a coin, fungible asset, or object transfer
+	TransactionCategorySwap       TransactionCategory = "swap"       // TransactionCategorySwap is an exchange of one asset for another
+	TransactionCategoryStake      TransactionCategory = "stake"      // TransactionCategoryStake is staking, delegating, unlocking, or withdrawing stake
+	TransactionCategoryNftMint    TransactionCategory = "nft_mint"   // TransactionCategoryNftMint is a digital asset (V2) or token (V1) mint
+	TransactionCategoryPublish    TransactionCategory = "publish"    // TransactionCategoryPublish is a Move package publish or upgrade
+	TransactionCategoryGovernance TransactionCategory = "governance" // TransactionCategoryGovernance is an on-chain governance proposal or vote
+	TransactionCategoryUnknown    TransactionCategory = "unknown"    // TransactionCategoryUnknown is when no rule matches
+)
+
+// TransactionCategoryRule labels a transaction with Category when Match returns true
+type TransactionCategoryRule struct {
+	Name     string                              // Name identifies the rule, registering a rule with the same name replaces it
+	Category TransactionCategory                 // Category is the label applied when the rule matches
+	Match    func(txn *api.UserTransaction) bool // Match decides whether the rule applies to the transaction
+}
+
+// TransactionClassifier labels committed transactions with [TransactionCategory]s based on the function called and the
+// events emitted, so wallet history backends don't each need their own heuristics.
+//
+// [NewTransactionClassifier] comes with rules for the framework modules and common swap events, more rules can be added
+// for specific protocols with [TransactionClassifier.Register].  It is safe for concurrent use.
+//
+//	classifier := NewTransactionClassifier()
+//	classifier.Register(TransactionCategoryRule{
+//		Name:     "my_dex",
+//		Category: TransactionCategorySwap,
+//		Match:    MatchEntryFunction("0xcafe::router::swap_exact_in"),
+//	})
+//	categories := classifier.Classify(txn)
+type TransactionClassifier struct {
+	mutex sync.RWMutex
+	rules []TransactionCategoryRule
+}
+
+// NewTransactionClassifier creates a [TransactionClassifier] with [DefaultTransactionCategoryRules]
+func NewTransactionClassifier() *TransactionClassifier {
+	classifier := &TransactionClassifier{}
+	for _, rule := range DefaultTransactionCategoryRules() {
+		classifier.Register(rule)
+	}
+	return classifier
+}
+
+// Register adds a rule, or replaces the rule with the same name.  Rules are evaluated in the order they are first
+// registered.
+func (c *TransactionClassifier) Register(rule TransactionCategoryRule) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i := range c.rules {
+		if c.rules[i].Name == rule.Name {
+			c.rules[i] = rule
+			return
+		}
+	}
+	c.rules = append(c.rules, rule)
+}
+
+// Unregister removes the rule with the given name, returning false if there is none
+func (c *TransactionClassifier) Unregister(name string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i := range c.rules {
+		if c.rules[i].Name == name {
+			c.rules = append(c.rules[:i], c.rules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Classify returns every category with a matching rule, in rule order without duplicates, or
+// [TransactionCategoryUnknown] if none match.
+//
+// Failed transactions are classified by the function they called, as they emit no events.
+func (c *TransactionClassifier) Classify(txn *api.UserTransaction) []TransactionCategory {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var categories []TransactionCategory
+	for _, rule := range c.rules {
+		if rule.Match == nil || !rule.Match(txn) {
+			continue
+		}
+		duplicate := false
+		for _, category := range categories {
+			if category == rule.Category {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			categories = append(categories, rule.Category)
+		}
+	}
+	if len(categories) == 0 {
+		return []TransactionCategory{TransactionCategoryUnknown}
+	}
+	return categories
+}
+
+// ClassifyCommitted classifies a [api.CommittedTransaction], returning nil for anything other than user transactions
+func (c *TransactionClassifier) ClassifyCommitted(txn *api.CommittedTransaction) []TransactionCategory {
+	userTxn, err := txn.UserTransaction()
+	if err != nil {
+		return nil
+	}
+	return c.Classify(userTxn)
+}
+
+// DefaultTransactionCategoryRules are the rules used by [NewTransactionClassifier]
+func DefaultTransactionCategoryRules() []TransactionCategoryRule {
+	return []TransactionCategoryRule{
+		{
+			Name:     "framework_transfer",
+			Category: TransactionCategoryTransfer,
+			Match: MatchEntryFunction(
+				"0x1::aptos_account::transfer",
+				"0x1::aptos_account::transfer_coins",
+				"0x1::aptos_account::batch_transfer",
+				"0x1::aptos_account::batch_transfer_coins",
+				"0x1::aptos_account::transfer_fungible_assets",
+				"0x1::coin::transfer",
+				"0x1::primary_fungible_store::transfer",
+				"0x1::object::transfer",
+				"0x1::object::transfer_call",
+				"0x3::token::direct_transfer_script",
+				"0x3::token_transfers::claim_script",
+			),
+		},
+		{
+			Name:     "swap_events",
+			Category: TransactionCategorySwap,
+			Match:    matchSwapEvent,
+		},
+		{
+			Name:     "swap_assets",
+			Category: TransactionCategorySwap,
+			Match:    matchSenderAssetExchange,
+		},
+		{
+			Name:     "framework_stake",
+			Category: TransactionCategoryStake,
+			Match: MatchAny(
+				MatchEntryFunctionModule("0x1::stake", "0x1::delegation_pool", "0x1::staking_contract", "0x1::staking_proxy", "0x1::vesting"),
+				MatchEvent(
+					"0x1::stake::AddStakeEvent",
+					"0x1::stake::AddStake",
+					"0x1::delegation_pool::AddStakeEvent",
+					"0x1::delegation_pool::UnlockStakeEvent",
+					"0x1::delegation_pool::WithdrawStakeEvent",
+				),
+			),
+		},
+		{
+			Name:     "nft_mint",
+			Category: TransactionCategoryNftMint,
+			Match: MatchAny(
+				MatchEntryFunction("0x4::aptos_token::mint", "0x4::aptos_token::mint_soul_bound", "0x3::token::mint_script"),
+				MatchEvent("0x4::collection::MintEvent", "0x4::collection::Mint", "0x3::token::MintTokenEvent", "0x3::token::Mint"),
+			),
+		},
+		{
+			Name:     "framework_publish",
+			Category: TransactionCategoryPublish,
+			Match: MatchAny(
+				MatchEntryFunction(
+					"0x1::code::publish_package_txn",
+					"0x1::object_code_deployment::publish",
+					"0x1::object_code_deployment::upgrade",
+				),
+				MatchEvent("0x1::code::PublishPackage"),
+				func(txn *api.UserTransaction) bool {
+					return txn.Payload != nil && txn.Payload.Type == api.TransactionPayloadVariantModuleBundle
+				},
+			),
+		},
+		{
+			Name:     "framework_governance",
+			Category: TransactionCategoryGovernance,
+			Match: MatchAny(
+				MatchEntryFunctionModule("0x1::aptos_governance"),
+				MatchEntryFunction(
+					"0x1::delegation_pool::vote",
+					"0x1::delegation_pool::create_proposal",
+					"0x1::delegation_pool::delegate_voting_power",
+				),
+				MatchEvent(
+					"0x1::aptos_governance::VoteEvent",
+					"0x1::aptos_governance::Vote",
+					"0x1::aptos_governance::CreateProposalEvent",
+					"0x1::aptos_governance::CreateProposal",
+				),
+			),
+		},
+	}
+}
+
+//region Match helpers
+
+// MatchAny matches when any of the matchers match
+func MatchAny(matchers ...func(txn *api.UserTransaction) bool) func(txn *api.UserTransaction) bool {
+	return func(txn *api.UserTransaction) bool {
+		for _, matcher := range matchers {
+			if matcher(txn) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchEntryFunction matches transactions calling any of the entry functions e.g. 0x1::aptos_account::transfer,
+// including entry functions run by a multisig account.  Addresses may be short or long.
+func MatchEntryFunction(functions ...string) func(txn *api.UserTransaction) bool {
+	normalized := make(map[string]bool, len(functions))
+	for _, function := range functions {
+		normalized[normalizeMoveId(function)] = true
+	}
+	return func(txn *api.UserTransaction) bool {
+		function, ok := entryFunctionOf(txn)
+		return ok && normalized[normalizeMoveId(function)]
+	}
+}
+
+// MatchEntryFunctionModule matches transactions calling any entry function in the modules e.g. 0x1::stake
+func MatchEntryFunctionModule(modules ...string) func(txn *api.UserTransaction) bool {
+	normalized := make(map[string]bool, len(modules))
+	for _, module := range modules {
+		normalized[normalizeMoveId(module)] = true
+	}
+	return func(txn *api.UserTransaction) bool {
+		function, ok := entryFunctionOf(txn)
+		if !ok {
+			return false
+		}
+		function = normalizeMoveId(function)
+		separator := strings.LastIndex(function, "::")
+		return separator > 0 && normalized[function[:separator]]
+	}
+}
+
+// MatchEvent matches transactions emitting any of the event types e.g. 0x4::collection::Mint.  Type arguments of the
+// emitted events are ignored.
+func MatchEvent(eventTypes ...string) func(txn *api.UserTransaction) bool {
+	normalized := make(map[string]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		normalized[normalizeMoveId(eventType)] = true
+	}
+	return func(txn *api.UserTransaction) bool {
+		for _, event := range txn.Events {
+			if event != nil && normalized[normalizeMoveId(event.Type)] {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// entryFunctionOf returns the entry function called by the transaction, directly or through a multisig account
+func entryFunctionOf(txn *api.UserTransaction) (string, bool) {
+	if txn == nil || txn.Payload == nil {
+		return "", false
+	}
+	payload := txn.Payload
+	if multisig, ok := payload.Inner.(*api.TransactionPayloadMultisig); ok {
+		if multisig.TransactionPayload == nil {
+			return "", false
+		}
+		payload = multisig.TransactionPayload
+	}
+	entryFunction, ok := payload.Inner.(*api.TransactionPayloadEntryFunction)
+	if !ok {
+		return "", false
+	}
+	return entryFunction.Function, true
+}
+
+// normalizeMoveId converts the address of a Move identifier e.g. 0x0001::coin::transfer to its short form, and drops
+// any type arguments
+func normalizeMoveId(id string) string {
+	if generic := strings.IndexByte(id, '<'); generic >= 0 {
+		id = id[:generic]
+	}
+	addressStr, rest, found := strings.Cut(id, "::")
+	address := AccountAddress{}
+	if err := address.ParseStringRelaxed(addressStr); err != nil {
+		return id
+	}
+	if !found {
+		return address.String()
+	}
+	return address.String() + "::" + rest
+}
+
+// matchSwapEvent matches events named like a swap e.g. 0xcafe::pool::SwapEvent, which most DEXes emit
+func matchSwapEvent(txn *api.UserTransaction) bool {
+	for _, event := range txn.Events {
+		if event == nil {
+			continue
+		}
+		eventType := normalizeMoveId(event.Type)
+		name := eventType[strings.LastIndex(eventType, "::")+2:]
+		if strings.HasPrefix(name, "Swap") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSenderAssetExchange matches the sender withdrawing one asset and receiving a different one
+func matchSenderAssetExchange(txn *api.UserTransaction) bool {
+	if !txn.Success || txn.Sender == nil {
+		return false
+	}
+	stores := fungibleStoresFromChanges(txn.Changes)
+	withdrawn := make(map[string]bool)
+	deposited := make(map[string]bool)
+	for _, event := range txn.Events {
+		leg, isWithdraw, ok := transferLegFromEvent(event, stores)
+		if !ok || leg.asset == "" || leg.account != *txn.Sender {
+			continue
+		}
+		if isWithdraw {
+			withdrawn[leg.asset] = true
+		} else {
+			deposited[leg.asset] = true
+		}
+	}
+	for in := range withdrawn {
+		for out := range deposited {
+			if in != out {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//endregion
@@ -0,0 +1,130 @@
+package aptos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCategoryTransaction(t *testing.T, payload string, events []map[string]any) *api.UserTransaction {
+	t.Helper()
+	txn := testTransferTransaction(t, 1, "0x1", events, nil)
+	sender := AccountAddress{}
+	assert.NoError(t, sender.ParseStringRelaxed("0xa"))
+	txn.Sender = &sender
+	if payload != "" {
+		txn.Payload = &api.TransactionPayload{}
+		assert.NoError(t, json.Unmarshal([]byte(payload), txn.Payload))
+	}
+	return txn
+}
+
+func testEntryFunctionPayload(function string) string {
+	return `{"type": "entry_function_payload", "function": "` + function + `", "type_arguments": [], "arguments": []}`
+}
+
+func testCategoryEvent(eventType string, data map[string]any) map[string]any {
+	return map[string]any{"type": eventType, "guid": map[string]any{"creation_number": "0", "account_address": "0x0"}, "sequence_number": "0", "data": data}
+}
+
+func TestTransactionClassifier(t *testing.T) {
+	classifier := NewTransactionClassifier()
+
+	tests := map[string]struct {
+		payload  string
+		events   []map[string]any
+		expected []TransactionCategory
+	}{
+		"transfer": {
+			payload:  testEntryFunctionPayload("0x1::aptos_account::transfer"),
+			expected: []TransactionCategory{TransactionCategoryTransfer},
+		},
+		"long address transfer": {
+			payload:  testEntryFunctionPayload("0x0000000000000000000000000000000000000000000000000000000000000001::coin::transfer"),
+			expected: []TransactionCategory{TransactionCategoryTransfer},
+		},
+		"multisig transfer": {
+			payload:  `{"type": "multisig_payload", "multisig_address": "0xb", "transaction_payload": ` + testEntryFunctionPayload("0x1::primary_fungible_store::transfer") + `}`,
+			expected: []TransactionCategory{TransactionCategoryTransfer},
+		},
+		"swap event": {
+			payload:  testEntryFunctionPayload("0xcafe::router::swap_exact_input"),
+			events:   []map[string]any{testCategoryEvent("0xcafe::pool::SwapEvent<0x1::aptos_coin::AptosCoin, 0xcafe::usd::USD>", map[string]any{})},
+			expected: []TransactionCategory{TransactionCategorySwap},
+		},
+		"swap assets": {
+			payload: testEntryFunctionPayload("0xcafe::router::trade"),
+			events: []map[string]any{
+				testCategoryEvent("0x1::coin::CoinWithdraw", map[string]any{"account": "0xa", "amount": "100", "coin_type": "0x1::aptos_coin::AptosCoin"}),
+				testCategoryEvent("0x1::coin::CoinDeposit", map[string]any{"account": "0xa", "amount": "5", "coin_type": "0xcafe::usd::USD"}),
+			},
+			expected: []TransactionCategory{TransactionCategorySwap},
+		},
+		"stake": {
+			payload:  testEntryFunctionPayload("0x1::delegation_pool::add_stake"),
+			expected: []TransactionCategory{TransactionCategoryStake},
+		},
+		"delegation vote": {
+			payload:  testEntryFunctionPayload("0x1::delegation_pool::vote"),
+			expected: []TransactionCategory{TransactionCategoryStake, TransactionCategoryGovernance},
+		},
+		"nft mint": {
+			payload:  testEntryFunctionPayload("0xcafe::launchpad::mint"),
+			events:   []map[string]any{testCategoryEvent("0x4::collection::Mint", map[string]any{})},
+			expected: []TransactionCategory{TransactionCategoryNftMint},
+		},
+		"publish": {
+			payload:  testEntryFunctionPayload("0x1::code::publish_package_txn"),
+			expected: []TransactionCategory{TransactionCategoryPublish},
+		},
+		"governance": {
+			payload:  testEntryFunctionPayload("0x1::aptos_governance::vote"),
+			expected: []TransactionCategory{TransactionCategoryGovernance},
+		},
+		"unknown": {
+			payload:  testEntryFunctionPayload("0xcafe::game::play"),
+			expected: []TransactionCategory{TransactionCategoryUnknown},
+		},
+		"no payload": {
+			expected: []TransactionCategory{TransactionCategoryUnknown},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			txn := testCategoryTransaction(t, test.payload, test.events)
+			assert.Equal(t, test.expected, classifier.Classify(txn))
+		})
+	}
+}
+
+func TestTransactionClassifierRegister(t *testing.T) {
+	classifier := NewTransactionClassifier()
+	txn := testCategoryTransaction(t, testEntryFunctionPayload("0xcafe::game::play"), nil)
+	assert.Equal(t, []TransactionCategory{TransactionCategoryUnknown}, classifier.Classify(txn))
+
+	classifier.Register(TransactionCategoryRule{
+		Name:     "game",
+		Category: "game",
+		Match:    MatchEntryFunctionModule("0xcafe::game"),
+	})
+	assert.Equal(t, []TransactionCategory{"game"}, classifier.Classify(txn))
+
+	// Replacing a rule keeps its name
+	classifier.Register(TransactionCategoryRule{
+		Name:     "game",
+		Category: "play",
+		Match:    MatchEntryFunction("0xcafe::game::play"),
+	})
+	assert.Equal(t, []TransactionCategory{"play"}, classifier.Classify(txn))
+
+	assert.True(t, classifier.Unregister("game"))
+	assert.False(t, classifier.Unregister("game"))
+	assert.Equal(t, []TransactionCategory{TransactionCategoryUnknown}, classifier.Classify(txn))
+
+	// Default rules can be removed too
+	transfer := testCategoryTransaction(t, testEntryFunctionPayload("0x1::aptos_account::transfer"), nil)
+	assert.True(t, classifier.Unregister("framework_transfer"))
+	assert.Equal(t, []TransactionCategory{TransactionCategoryUnknown}, classifier.Classify(transfer))
+}
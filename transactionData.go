@@ -0,0 +1,366 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// ErrUnsupportedTransactionData is returned decoding BCS of a genesis or validator transaction, which can only be read
+// as JSON, see [NodeClient.TransactionByVersion]
+var ErrUnsupportedTransactionData = errors.New("unsupported BCS transaction variant")
+
+// TransactionDataVariant is the kind of transaction of a [TransactionOnChainData]
+type TransactionDataVariant uint32
+
+const (
+	TransactionDataUser             TransactionDataVariant = 0 // TransactionDataUser is a transaction submitted by a user
+	TransactionDataGenesis          TransactionDataVariant = 1 // TransactionDataGenesis is the genesis transaction
+	TransactionDataBlockMetadata    TransactionDataVariant = 2 // TransactionDataBlockMetadata starts a block
+	TransactionDataStateCheckpoint  TransactionDataVariant = 3 // TransactionDataStateCheckpoint ends a block, in blocks without an epilogue
+	TransactionDataValidator        TransactionDataVariant = 4 // TransactionDataValidator is a validator transaction e.g. a DKG result
+	TransactionDataBlockMetadataExt TransactionDataVariant = 5 // TransactionDataBlockMetadataExt starts a block, with randomness
+	TransactionDataBlockEpilogue    TransactionDataVariant = 6 // TransactionDataBlockEpilogue ends a block
+)
+
+// TransactionOnChainData is a committed transaction as BCS, with its output, see [NodeClient.TransactionByVersionBCS]
+type TransactionOnChainData struct {
+	Version             uint64                 // Version of the transaction
+	Variant             TransactionDataVariant // Variant is the kind of transaction
+	UserTransaction     *SignedTransaction     // UserTransaction is the signed transaction, for [TransactionDataUser]
+	BlockMetadata       *BlockMetadata         // BlockMetadata for [TransactionDataBlockMetadata] and [TransactionDataBlockMetadataExt]
+	Info                TransactionInfo        // Info is the execution result
+	Events              []ContractEvent        // Events emitted by the transaction
+	AccumulatorRootHash []byte                 // AccumulatorRootHash is the root of the transaction accumulator after the transaction
+	Changes             []WriteSetChange       // Changes are the state changes of the transaction
+}
+
+// Hash is the transaction hash, from the transaction info
+func (txn *TransactionOnChainData) Hash() string {
+	return BytesToHex(txn.Info.TransactionHash)
+}
+
+func (txn *TransactionOnChainData) UnmarshalBCS(des *bcs.Deserializer) {
+	txn.Version = des.U64()
+	txn.Variant = TransactionDataVariant(des.Uleb128())
+	switch txn.Variant {
+	case TransactionDataUser:
+		txn.UserTransaction = &SignedTransaction{}
+		txn.UserTransaction.UnmarshalBCS(des)
+	case TransactionDataBlockMetadata:
+		txn.BlockMetadata = &BlockMetadata{}
+		txn.BlockMetadata.UnmarshalBCS(des)
+	case TransactionDataStateCheckpoint:
+		des.ReadBytes()
+	case TransactionDataBlockMetadataExt:
+		txn.BlockMetadata = &BlockMetadata{}
+		txn.BlockMetadata.unmarshalExt(des)
+	case TransactionDataBlockEpilogue:
+		unmarshalBlockEpilogue(des)
+	default:
+		des.SetError(fmt.Errorf("%w %d at version %d", ErrUnsupportedTransactionData, txn.Variant, txn.Version))
+		return
+	}
+	txn.Info.UnmarshalBCS(des)
+	txn.Events = bcs.DeserializeSequence[ContractEvent](des)
+	txn.AccumulatorRootHash = des.ReadBytes()
+	txn.Changes = unmarshalWriteSet(des)
+}
+
+// BlockMetadata starts a block, see [TransactionDataBlockMetadata]
+type BlockMetadata struct {
+	Id                       []byte         // Id is the hash of the block
+	Epoch                    uint64         // Epoch of the block
+	Round                    uint64         // Round of consensus the block was proposed in
+	Proposer                 AccountAddress // Proposer is the validator that proposed the block
+	PreviousBlockVotesBitvec []byte         // PreviousBlockVotesBitvec is a bit per validator that voted for the previous block
+	FailedProposerIndices    []uint32       // FailedProposerIndices are the validators that failed to propose since the last block
+	TimestampUsecs           uint64         // TimestampUsecs is the time of the block in microseconds
+	Randomness               []byte         // Randomness of the block, for [TransactionDataBlockMetadataExt] with randomness
+}
+
+func (o *BlockMetadata) UnmarshalBCS(des *bcs.Deserializer) {
+	o.Id = des.ReadBytes()
+	o.Epoch = des.U64()
+	o.Round = des.U64()
+	o.Proposer.UnmarshalBCS(des)
+	o.PreviousBlockVotesBitvec = des.ReadBytes()
+	o.FailedProposerIndices = bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, out *uint32) {
+		*out = des.U32()
+	})
+	o.TimestampUsecs = des.U64()
+}
+
+// unmarshalExt reads a BlockMetadataExt, which is either plain block metadata or block metadata with randomness
+func (o *BlockMetadata) unmarshalExt(des *bcs.Deserializer) {
+	switch variant := des.Uleb128(); variant {
+	case 0:
+		o.UnmarshalBCS(des)
+	case 1:
+		o.UnmarshalBCS(des)
+		if des.Bool() {
+			// The epoch and round of the randomness are the block's
+			des.U64()
+			des.U64()
+			o.Randomness = des.ReadBytes()
+		}
+	default:
+		des.SetError(fmt.Errorf("unknown block metadata variant %d", variant))
+	}
+}
+
+// unmarshalBlockEpilogue skips a block epilogue payload, which only has block statistics
+func unmarshalBlockEpilogue(des *bcs.Deserializer) {
+	variant := des.Uleb128()
+	if variant > 1 {
+		des.SetError(fmt.Errorf("unknown block epilogue variant %d", variant))
+		return
+	}
+	des.ReadBytes() // Block id
+
+	// Block end info
+	if endVariant := des.Uleb128(); endVariant != 0 {
+		des.SetError(fmt.Errorf("unknown block end info variant %d", endVariant))
+		return
+	}
+	des.Bool()
+	des.Bool()
+	des.U64()
+	des.U64()
+
+	if variant == 1 {
+		// Fee distribution, a map of validator index to amount
+		if feeVariant := des.Uleb128(); feeVariant != 0 {
+			des.SetError(fmt.Errorf("unknown fee distribution variant %d", feeVariant))
+			return
+		}
+		length := des.Uleb128()
+		for i := uint32(0); i < length && des.Error() == nil; i++ {
+			des.U64()
+			des.U64()
+		}
+	}
+}
+
+// ExecutionStatusKind is the outcome of a transaction, see [ExecutionStatus]
+type ExecutionStatusKind uint32
+
+const (
+	ExecutionStatusSuccess            ExecutionStatusKind = 0 // ExecutionStatusSuccess the transaction succeeded
+	ExecutionStatusOutOfGas           ExecutionStatusKind = 1 // ExecutionStatusOutOfGas the transaction ran out of gas
+	ExecutionStatusMoveAbort          ExecutionStatusKind = 2 // ExecutionStatusMoveAbort the transaction aborted with a code
+	ExecutionStatusExecutionFailure   ExecutionStatusKind = 3 // ExecutionStatusExecutionFailure the VM failed in a function e.g. an arithmetic error
+	ExecutionStatusMiscellaneousError ExecutionStatusKind = 4 // ExecutionStatusMiscellaneousError any other failure, with a VM status code
+)
+
+// ExecutionStatus is the outcome of a transaction, and where it failed
+type ExecutionStatus struct {
+	Kind        ExecutionStatusKind // Kind of outcome
+	Location    *ModuleId           // Location is the module that aborted or failed, nil for a script or success
+	Code        uint64              // Code is the abort code, or the VM status code of a miscellaneous error
+	Function    uint16              // Function is the index of the function that failed, for an execution failure
+	CodeOffset  uint16              // CodeOffset is the instruction that failed, for an execution failure
+	ReasonName  string              // ReasonName is the name of the abort code's constant, if known
+	Description string              // Description is the doc comment of the abort code's constant, if known
+}
+
+// Success tells if the transaction succeeded
+func (o *ExecutionStatus) Success() bool {
+	return o.Kind == ExecutionStatusSuccess
+}
+
+func (o *ExecutionStatus) UnmarshalBCS(des *bcs.Deserializer) {
+	o.Kind = ExecutionStatusKind(des.Uleb128())
+	switch o.Kind {
+	case ExecutionStatusSuccess, ExecutionStatusOutOfGas:
+	case ExecutionStatusMoveAbort:
+		o.Location = unmarshalAbortLocation(des)
+		o.Code = des.U64()
+		if des.Bool() {
+			o.ReasonName = des.ReadString()
+			o.Description = des.ReadString()
+		}
+	case ExecutionStatusExecutionFailure:
+		o.Location = unmarshalAbortLocation(des)
+		o.Function = des.U16()
+		o.CodeOffset = des.U16()
+	case ExecutionStatusMiscellaneousError:
+		if des.Bool() {
+			o.Code = des.U64()
+		}
+	default:
+		des.SetError(fmt.Errorf("unknown execution status %d", o.Kind))
+	}
+}
+
+// unmarshalAbortLocation reads where a transaction failed, nil for a script
+func unmarshalAbortLocation(des *bcs.Deserializer) *ModuleId {
+	switch variant := des.Uleb128(); variant {
+	case 0:
+		module := &ModuleId{}
+		module.UnmarshalBCS(des)
+		return module
+	case 1:
+		return nil
+	default:
+		des.SetError(fmt.Errorf("unknown abort location variant %d", variant))
+		return nil
+	}
+}
+
+// TransactionInfo is the execution result of a transaction
+type TransactionInfo struct {
+	GasUsed             uint64          // GasUsed in gas units
+	Status              ExecutionStatus // Status is the outcome of the transaction
+	TransactionHash     []byte          // TransactionHash is the hash of the transaction
+	EventRootHash       []byte          // EventRootHash is the root of the events' accumulator
+	StateChangeHash     []byte          // StateChangeHash is the hash of the write set
+	StateCheckpointHash []byte          // StateCheckpointHash is the state root, only for the last transaction of a block
+	AuxiliaryInfoHash   []byte          // AuxiliaryInfoHash is the hash of the auxiliary info, if any
+}
+
+func (o *TransactionInfo) UnmarshalBCS(des *bcs.Deserializer) {
+	if variant := des.Uleb128(); variant != 0 {
+		des.SetError(fmt.Errorf("unknown transaction info variant %d", variant))
+		return
+	}
+	o.GasUsed = des.U64()
+	o.Status.UnmarshalBCS(des)
+	o.TransactionHash = des.ReadBytes()
+	o.EventRootHash = des.ReadBytes()
+	o.StateChangeHash = des.ReadBytes()
+	o.StateCheckpointHash = readOptionalBytes(des)
+	o.AuxiliaryInfoHash = readOptionalBytes(des)
+}
+
+// ContractEvent is an event emitted by a transaction
+type ContractEvent struct {
+	Handle         *EventHandleId // Handle the event was emitted to, nil for a module event
+	SequenceNumber uint64         // SequenceNumber of the event on its handle, 0 for a module event
+	Type           TypeTag        // Type of the event's data
+	Data           []byte         // Data is the event as the BCS of a Move struct
+}
+
+func (o *ContractEvent) UnmarshalBCS(des *bcs.Deserializer) {
+	switch variant := des.Uleb128(); variant {
+	case 0:
+		o.Handle = &EventHandleId{}
+		o.Handle.CreationNumber = des.U64()
+		o.Handle.Account.UnmarshalBCS(des)
+		o.SequenceNumber = des.U64()
+	case 1:
+	default:
+		des.SetError(fmt.Errorf("unknown contract event variant %d", variant))
+		return
+	}
+	o.Type.UnmarshalBCS(des)
+	o.Data = des.ReadBytes()
+}
+
+// DataInto decodes the event's data with BCS
+func (o *ContractEvent) DataInto(out bcs.Unmarshaler) error {
+	return bcs.Deserialize(out, o.Data)
+}
+
+// StateKeyKind is the kind of state a [StateKey] addresses
+type StateKeyKind uint32
+
+const (
+	StateKeyAccessPath StateKeyKind = 0 // StateKeyAccessPath is a resource, resource group, or module of an account
+	StateKeyTableItem  StateKeyKind = 1 // StateKeyTableItem is an item of a table
+	StateKeyRaw        StateKeyKind = 2 // StateKeyRaw is any other state
+)
+
+// StateKey addresses a state value changed by a [WriteSetChange]
+type StateKey struct {
+	Kind    StateKeyKind   // Kind of state
+	Address AccountAddress // Address of the account, or the table handle of a table item
+	Path    []byte         // Path is the BCS of the access path in the account, the table key, or the raw key
+}
+
+func (o *StateKey) UnmarshalBCS(des *bcs.Deserializer) {
+	o.Kind = StateKeyKind(des.Uleb128())
+	switch o.Kind {
+	case StateKeyAccessPath, StateKeyTableItem:
+		o.Address.UnmarshalBCS(des)
+		o.Path = des.ReadBytes()
+	case StateKeyRaw:
+		o.Path = des.ReadBytes()
+	default:
+		des.SetError(fmt.Errorf("unknown state key variant %d", o.Kind))
+	}
+}
+
+// WriteOpKind is the kind of change of a [WriteSetChange]
+type WriteOpKind uint8
+
+const (
+	WriteOpCreation     WriteOpKind = iota // WriteOpCreation creates the state value
+	WriteOpModification                    // WriteOpModification changes the state value
+	WriteOpDeletion                        // WriteOpDeletion deletes the state value
+)
+
+// WriteSetChange is a change of a state value by a transaction
+type WriteSetChange struct {
+	Key               StateKey    // Key is the state value changed
+	Kind              WriteOpKind // Kind of change
+	Data              []byte      // Data is the new value as BCS, nil for a deletion
+	SlotDeposit       uint64      // SlotDeposit is the storage fee paid for the slot, if known
+	BytesDeposit      uint64      // BytesDeposit is the storage fee paid for the bytes, if known
+	CreationTimeUsecs uint64      // CreationTimeUsecs is the time the slot was created, if known
+}
+
+// unmarshalWriteSet reads the changes of a WriteSet, which is a map of state key to write op
+func unmarshalWriteSet(des *bcs.Deserializer) []WriteSetChange {
+	if variant := des.Uleb128(); variant != 0 {
+		des.SetError(fmt.Errorf("unknown write set variant %d", variant))
+		return nil
+	}
+	entries := bcs.DeserializeMapEntries(des, func(des *bcs.Deserializer, out *StateKey) {
+		out.UnmarshalBCS(des)
+	}, func(des *bcs.Deserializer, out *WriteSetChange) {
+		out.unmarshalWriteOp(des)
+	})
+	changes := make([]WriteSetChange, len(entries))
+	for i, entry := range entries {
+		changes[i] = entry.Value
+		changes[i].Key = entry.Key
+	}
+	return changes
+}
+
+// unmarshalWriteOp reads a write op, with or without the metadata of the state value
+func (o *WriteSetChange) unmarshalWriteOp(des *bcs.Deserializer) {
+	variant := des.Uleb128()
+	if variant > 5 {
+		des.SetError(fmt.Errorf("unknown write op variant %d", variant))
+		return
+	}
+	o.Kind = WriteOpKind(variant % 3)
+	if o.Kind != WriteOpDeletion {
+		o.Data = des.ReadBytes()
+	}
+	if variant < 3 {
+		return
+	}
+	switch metadata := des.Uleb128(); metadata {
+	case 0:
+		o.SlotDeposit = des.U64()
+	case 1:
+		o.SlotDeposit = des.U64()
+		o.BytesDeposit = des.U64()
+	default:
+		des.SetError(fmt.Errorf("unknown state value metadata variant %d", metadata))
+		return
+	}
+	o.CreationTimeUsecs = des.U64()
+}
+
+// readOptionalBytes reads an Option<Vec<u8>>
+func readOptionalBytes(des *bcs.Deserializer) []byte {
+	if !des.Bool() {
+		return nil
+	}
+	return des.ReadBytes()
+}
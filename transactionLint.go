@@ -0,0 +1,193 @@
+package aptos
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// LintRule is a mistake checked by [TransactionLinter]
+type LintRule string
+
+const (
+	LintExpirationTooShort LintRule = "expiration_too_short" // LintExpirationTooShort is a transaction expiring before it's likely to commit, or already expired
+	LintExpirationTooLong  LintRule = "expiration_too_long"  // LintExpirationTooLong is a transaction valid for long enough to be replayed against a changed account, and rejected by mempool
+	LintGasPrice           LintRule = "gas_price"            // LintGasPrice is a gas unit price far from the network's estimate
+	LintZeroAmount         LintRule = "zero_amount"          // LintZeroAmount is a transfer of nothing
+	LintSpecialAddress     LintRule = "special_address"      // LintSpecialAddress is a transfer to a special address e.g. 0x1, which is almost never intended
+	LintArgumentCount      LintRule = "argument_count"       // LintArgumentCount is an entry function called with a different number of arguments or type arguments than its ABI
+	LintUnknownFunction    LintRule = "unknown_function"     // LintUnknownFunction is an entry function missing from its module's ABI
+)
+
+// LintFinding is one likely mistake found by [TransactionLinter.Lint]
+type LintFinding struct {
+	Rule   LintRule // Rule found
+	Detail string   // Detail explains the finding
+}
+
+// String formats the finding e.g. "zero_amount: transfer of 0 with 0x1::aptos_account::transfer"
+func (finding LintFinding) String() string {
+	return fmt.Sprintf("%s: %s", finding.Rule, finding.Detail)
+}
+
+// TransactionLinter inspects a built [RawTransaction] for common mistakes before it's signed or submitted.  Findings are
+// warnings, deciding what to do with them is up to the caller.  Zero valued fields use the defaults.
+//
+//	findings, err := (&TransactionLinter{}).Lint(client, rawTxn)
+//	for _, finding := range findings {
+//		log.Print(finding.String())
+//	}
+type TransactionLinter struct {
+	MinExpiration        time.Duration    // MinExpiration is the shortest expiration from now not flagged, defaults to 10 seconds
+	MaxExpiration        time.Duration    // MaxExpiration is the longest expiration from now not flagged, defaults to 1 hour
+	MaxGasPriceDeviation float64          // MaxGasPriceDeviation is how many times above or below the estimate the gas unit price can be, defaults to 10
+	Now                  func() time.Time // Now is the current time, defaults to [time.Now]
+}
+
+// TransactionLintClient is the subset of the client used by [TransactionLinter], satisfied by both [Client] and
+// [NodeClient]
+type TransactionLintClient interface {
+	EstimateGasPrice() (info EstimateGasInfo, err error)
+	ModuleAbi(module ModuleId) (abi *api.MoveModule, err error)
+}
+
+// lintTransfer is where the receiver and amount are in the arguments of a transfer function
+type lintTransfer struct {
+	receiver int
+	amount   int
+}
+
+// lintTransfers are the framework transfer functions checked for zero amounts and special receivers
+var lintTransfers = map[string]lintTransfer{
+	"0x1::aptos_account::transfer":                 {receiver: 0, amount: 1},
+	"0x1::aptos_account::transfer_coins":           {receiver: 0, amount: 1},
+	"0x1::aptos_account::transfer_fungible_assets": {receiver: 1, amount: 2},
+	"0x1::coin::transfer":                          {receiver: 0, amount: 1},
+	"0x1::primary_fungible_store::transfer":        {receiver: 1, amount: 2},
+}
+
+// Lint checks rawTxn, returning the findings in the order they were checked.  client is used to check the gas unit price
+// and the arguments against the ABI, and may be nil to only run the offline checks.  An error is returned if the gas
+// estimate or ABI couldn't be fetched.
+func (linter *TransactionLinter) Lint(client TransactionLintClient, rawTxn *RawTransaction) ([]LintFinding, error) {
+	findings := linter.lintExpiration(rawTxn)
+	entry, _ := rawTxn.Payload.Payload.(*EntryFunction)
+	if entry != nil {
+		findings = append(findings, lintTransferArgs(entry)...)
+	}
+	if client == nil {
+		return findings, nil
+	}
+
+	gasFinding, err := linter.lintGasPrice(client, rawTxn)
+	if err != nil {
+		return findings, err
+	}
+	findings = append(findings, gasFinding...)
+	if entry != nil {
+		abiFindings, err := lintAbi(client, entry)
+		if err != nil {
+			return findings, err
+		}
+		findings = append(findings, abiFindings...)
+	}
+	return findings, nil
+}
+
+// LintTransaction checks rawTxn for common mistakes with the default [TransactionLinter]
+func (client *Client) LintTransaction(rawTxn *RawTransaction) ([]LintFinding, error) {
+	return (&TransactionLinter{}).Lint(client, rawTxn)
+}
+
+// lintExpiration checks the expiration is neither too soon nor too far from now
+func (linter *TransactionLinter) lintExpiration(rawTxn *RawTransaction) []LintFinding {
+	minExpiration := linter.MinExpiration
+	if minExpiration == 0 {
+		minExpiration = 10 * time.Second
+	}
+	maxExpiration := linter.MaxExpiration
+	if maxExpiration == 0 {
+		maxExpiration = time.Hour
+	}
+	now := time.Now
+	if linter.Now != nil {
+		now = linter.Now
+	}
+
+	remaining := time.Unix(int64(rawTxn.ExpirationTimestampSeconds), 0).Sub(now())
+	switch {
+	case remaining < minExpiration:
+		return []LintFinding{{Rule: LintExpirationTooShort, Detail: fmt.Sprintf("expires in %s, less than %s", remaining.Round(time.Second), minExpiration)}}
+	case remaining > maxExpiration:
+		return []LintFinding{{Rule: LintExpirationTooLong, Detail: fmt.Sprintf("expires in %s, more than %s", remaining.Round(time.Second), maxExpiration)}}
+	default:
+		return nil
+	}
+}
+
+// lintGasPrice checks the gas unit price is within [TransactionLinter.MaxGasPriceDeviation] of the estimate
+func (linter *TransactionLinter) lintGasPrice(client TransactionLintClient, rawTxn *RawTransaction) ([]LintFinding, error) {
+	deviation := linter.MaxGasPriceDeviation
+	if deviation == 0 {
+		deviation = 10
+	}
+	estimate, err := client.EstimateGasPrice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas price: %w", err)
+	}
+	high := float64(estimate.Price(GasPriorityPrioritized)) * deviation
+	low := float64(estimate.Price(GasPriorityDeprioritized)) / deviation
+	price := float64(rawTxn.GasUnitPrice)
+	if price > high || price < low {
+		return []LintFinding{{Rule: LintGasPrice, Detail: fmt.Sprintf("gas unit price %d is more than %gx from the estimate %d", rawTxn.GasUnitPrice, deviation, estimate.GasEstimate)}}, nil
+	}
+	return nil, nil
+}
+
+// lintTransferArgs checks the amount and receiver of framework transfers
+func lintTransferArgs(entry *EntryFunction) []LintFinding {
+	function := fmt.Sprintf("%s::%s::%s", entry.Module.Address.String(), entry.Module.Name, entry.Function)
+	transfer, ok := lintTransfers[function]
+	if !ok || len(entry.Args) <= max(transfer.receiver, transfer.amount) {
+		return nil
+	}
+
+	findings := make([]LintFinding, 0)
+	if bytes.Equal(entry.Args[transfer.amount], make([]byte, 8)) {
+		findings = append(findings, LintFinding{Rule: LintZeroAmount, Detail: "transfer of 0 with " + function})
+	}
+	receiver := AccountAddress{}
+	if len(entry.Args[transfer.receiver]) == len(receiver) {
+		copy(receiver[:], entry.Args[transfer.receiver])
+		if receiver.IsSpecial() {
+			findings = append(findings, LintFinding{Rule: LintSpecialAddress, Detail: fmt.Sprintf("transfer to special address %s with %s", receiver.String(), function)})
+		}
+	}
+	return findings
+}
+
+// lintAbi checks the number of arguments and type arguments against the function's ABI
+func lintAbi(client TransactionLintClient, entry *EntryFunction) ([]LintFinding, error) {
+	abi, err := client.ModuleAbi(entry.Module)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ABI of %s::%s: %w", entry.Module.Address.String(), entry.Module.Name, err)
+	}
+	function := abi.Function(entry.Function)
+	if function == nil || !function.IsEntry {
+		return []LintFinding{{Rule: LintUnknownFunction, Detail: fmt.Sprintf("no entry function %s in module %s::%s", entry.Function, entry.Module.Address.String(), entry.Module.Name)}}, nil
+	}
+
+	// Signers are provided by the transaction, not the arguments
+	params := function.ArgParams()
+	findings := make([]LintFinding, 0)
+	if len(entry.Args) != len(params) {
+		findings = append(findings, LintFinding{Rule: LintArgumentCount, Detail: fmt.Sprintf("%s takes %d arguments (%s), got %d", entry.Function, len(params), strings.Join(params, ", "), len(entry.Args))})
+	}
+	if len(entry.ArgTypes) != len(function.GenericTypeParams) {
+		findings = append(findings, LintFinding{Rule: LintArgumentCount, Detail: fmt.Sprintf("%s takes %d type arguments, got %d", entry.Function, len(function.GenericTypeParams), len(entry.ArgTypes))})
+	}
+	return findings, nil
+}
@@ -0,0 +1,83 @@
+package aptos
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// lintTestClient is a [TransactionLintClient] with a fixed estimate and ABIs
+type lintTestClient struct {
+	estimate EstimateGasInfo
+	abis     map[ModuleId]*api.MoveModule
+}
+
+func (client *lintTestClient) EstimateGasPrice() (EstimateGasInfo, error) {
+	return client.estimate, nil
+}
+
+func (client *lintTestClient) ModuleAbi(module ModuleId) (*api.MoveModule, error) {
+	abi, ok := client.abis[module]
+	if !ok {
+		return nil, errors.New("module not found")
+	}
+	return abi, nil
+}
+
+func TestTransactionLinter(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	linter := &TransactionLinter{Now: func() time.Time { return now }}
+	client := &lintTestClient{
+		estimate: EstimateGasInfo{GasEstimate: 100},
+		abis: map[ModuleId]*api.MoveModule{
+			{Address: AccountOne, Name: "aptos_account"}: {Name: "aptos_account", ExposedFunctions: []*api.MoveFunction{
+				{Name: "transfer", IsEntry: true, Params: []string{"&signer", "address", "u64"}},
+			}},
+		},
+	}
+	rawTxn := func(payload *EntryFunction, gasUnitPrice uint64, expiration time.Duration) *RawTransaction {
+		return &RawTransaction{
+			Payload:                    TransactionPayload{Payload: payload},
+			GasUnitPrice:               gasUnitPrice,
+			ExpirationTimestampSeconds: uint64(now.Add(expiration).Unix()),
+		}
+	}
+
+	receiver, err := NewEd25519Account()
+	assert.NoError(t, err)
+	transfer, err := CoinTransferPayload(nil, receiver.Address, 100)
+	assert.NoError(t, err)
+	findings, err := linter.Lint(client, rawTxn(transfer, 100, time.Minute))
+	assert.NoError(t, err)
+	assert.Empty(t, findings)
+
+	// Everything wrong
+	transfer, err = CoinTransferPayload(nil, AccountOne, 0)
+	assert.NoError(t, err)
+	transfer.Args = append(transfer.Args, []byte{1})
+	findings, err = linter.Lint(client, rawTxn(transfer, 10_000, 2*time.Second))
+	assert.NoError(t, err)
+	rules := make([]LintRule, len(findings))
+	for i, finding := range findings {
+		rules[i] = finding.Rule
+	}
+	assert.Equal(t, []LintRule{LintExpirationTooShort, LintZeroAmount, LintSpecialAddress, LintGasPrice, LintArgumentCount}, rules)
+	assert.Equal(t, "zero_amount: transfer of 0 with 0x1::aptos_account::transfer", findings[1].String())
+
+	// Offline checks only
+	findings, err = linter.Lint(nil, rawTxn(transfer, 10_000, 2*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, LintExpirationTooLong, findings[0].Rule)
+	assert.Len(t, findings, 3)
+
+	unknown := &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: "transfer_all"}
+	findings, err = linter.Lint(client, rawTxn(unknown, 100, time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []LintFinding{{Rule: LintUnknownFunction, Detail: "no entry function transfer_all in module 0x1::aptos_account"}}, findings)
+
+	_, err = linter.Lint(client, rawTxn(&EntryFunction{Module: ModuleId{Address: AccountTwo, Name: "missing"}, Function: "f"}, 100, time.Minute))
+	assert.Error(t, err)
+}
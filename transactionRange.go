@@ -0,0 +1,145 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+const (
+	DefaultTransactionRangePageSize = uint64(100)            // DefaultTransactionRangePageSize is the number of transactions a [TransactionRangeIterator] requests per page
+	DefaultTransactionRangeRetries  = 3                      // DefaultTransactionRangeRetries is how many times a failed page of a [TransactionRangeIterator] is retried
+	DefaultTransactionRangeBackoff  = 200 * time.Millisecond // DefaultTransactionRangeBackoff is the wait before the first retry of a page, it doubles after each
+)
+
+// TransactionRangeIterator pages through the committed transactions of a version range in order, fetching a page at a
+// time as it advances.  Pages are requested with the page size, and the next page starts after the last transaction
+// returned, so a node with a lower per-request limit still yields every transaction.  Failed pages are retried on
+// network errors, 429, and 5xx responses.
+//
+//	it := client.TransactionRange(start, end)
+//	for it.Next() {
+//		txn := it.Transaction()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type TransactionRangeIterator struct {
+	PageSize uint64        // PageSize is the number of transactions requested per page, defaults to [DefaultTransactionRangePageSize]
+	Retries  int           // Retries of a failed page, defaults to [DefaultTransactionRangeRetries], negative for none
+	Backoff  time.Duration // Backoff before the first retry of a page, defaults to [DefaultTransactionRangeBackoff]
+
+	client *NodeClient
+	next   uint64 // next is the version of the first transaction not yet fetched
+	end    uint64 // end of the range, exclusive
+	page   []*api.CommittedTransaction
+	txn    *api.CommittedTransaction
+	err    error
+	done   bool
+}
+
+// TransactionRange creates an iterator over the committed transactions from version start up to, but not including,
+// version end.  The iteration stops early at the end of the ledger.
+func (rc *NodeClient) TransactionRange(start uint64, end uint64) *TransactionRangeIterator {
+	return &TransactionRangeIterator{client: rc, next: start, end: end}
+}
+
+// TransactionsByVersionRange fetches the committed transactions from version start up to, but not including, version
+// end, see [NodeClient.TransactionRange]
+func (rc *NodeClient) TransactionsByVersionRange(start uint64, end uint64) ([]*api.CommittedTransaction, error) {
+	if end < start {
+		return nil, fmt.Errorf("transaction range end %d is before start %d", end, start)
+	}
+	it := rc.TransactionRange(start, end)
+	txns := make([]*api.CommittedTransaction, 0)
+	for it.Next() {
+		txns = append(txns, it.Transaction())
+	}
+	return txns, it.Err()
+}
+
+// Next advances to the next transaction, fetching a page if needed.  It returns false at the end of the range, or on
+// an error, see [TransactionRangeIterator.Err].
+func (it *TransactionRangeIterator) Next() bool {
+	it.txn = nil
+	if it.done {
+		return false
+	}
+	if len(it.page) == 0 {
+		if it.next >= it.end {
+			it.done = true
+			return false
+		}
+		it.page, it.err = it.fetch()
+		if it.err != nil || len(it.page) == 0 {
+			it.done = true
+			return false
+		}
+		it.next = it.page[len(it.page)-1].Version() + 1
+	}
+	it.txn = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Transaction returns the current transaction, nil before the first call to [TransactionRangeIterator.Next] or after
+// the end
+func (it *TransactionRangeIterator) Transaction() *api.CommittedTransaction {
+	return it.txn
+}
+
+// Err returns the error that stopped the iteration, nil if it reached the end
+func (it *TransactionRangeIterator) Err() error {
+	return it.err
+}
+
+// fetch fetches the next page, retrying failures that may be temporary
+func (it *TransactionRangeIterator) fetch() ([]*api.CommittedTransaction, error) {
+	pageSize := it.PageSize
+	if pageSize == 0 {
+		pageSize = DefaultTransactionRangePageSize
+	}
+	retries := it.Retries
+	if retries == 0 {
+		retries = DefaultTransactionRangeRetries
+	}
+	backoff := it.Backoff
+	if backoff <= 0 {
+		backoff = DefaultTransactionRangeBackoff
+	}
+
+	start := it.next
+	limit := min(pageSize, it.end-it.next)
+	for attempt := 0; ; attempt++ {
+		txns, err := it.client.transactionsInner(&start, &limit)
+		if err == nil {
+			// The node may return more than asked for, never past the end of the range
+			for i, txn := range txns {
+				if txn.Version() >= it.end {
+					return txns[:i], nil
+				}
+			}
+			return txns, nil
+		}
+		if attempt >= retries || !transactionRangeRetryable(err) {
+			return nil, err
+		}
+		time.Sleep(backoff << attempt)
+	}
+}
+
+// transactionRangeRetryable tells if a failed page may succeed on a retry
+func transactionRangeRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	httpErr := &HttpError{}
+	if !errors.As(err, &httpErr) {
+		// Network errors
+		return true
+	}
+	return errors.Is(err, ErrRateLimited) || httpErr.StatusCode >= 500
+}
@@ -0,0 +1,80 @@
+package aptos
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionRangeIterator(t *testing.T) {
+	const ledgerEnd = 180
+	requests := atomic.Int32{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/transactions", r.URL.Path)
+		// The second request fails once
+		if requests.Add(1) == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		limit, _ := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+		// The node returns at most 25 transactions per request
+		items := make([]string, 0)
+		for version := start; version < min(start+min(limit, 25), ledgerEnd); version++ {
+			items = append(items, fmt.Sprintf(`{"type": "state_checkpoint_transaction", "version": "%d", "hash": "0x1", "state_change_hash": "0x1", "event_root_hash": "0x1", "accumulator_root_hash": "0x1", "gas_used": "0", "success": true, "vm_status": "Executed successfully", "changes": [], "timestamp": "1"}`, version))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	it := client.TransactionRange(10, 70)
+	it.Backoff = time.Millisecond
+	versions := make([]uint64, 0)
+	for it.Next() {
+		versions = append(versions, it.Transaction().Version())
+	}
+	assert.NoError(t, it.Err())
+	assert.Len(t, versions, 60)
+	for i, version := range versions {
+		assert.Equal(t, uint64(10+i), version)
+	}
+	assert.Nil(t, it.Transaction())
+	assert.False(t, it.Next())
+
+	// The range stops early at the end of the ledger
+	txns, err := client.TransactionsByVersionRange(150, 300)
+	assert.NoError(t, err)
+	assert.Len(t, txns, ledgerEnd-150)
+
+	_, err = client.TransactionsByVersionRange(2, 1)
+	assert.Error(t, err)
+}
+
+func TestTransactionRangeIterator_Errors(t *testing.T) {
+	requests := atomic.Int32{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message": "bad start", "error_code": "invalid_input"}`))
+	}))
+	defer mockServer.Close()
+
+	client, err := NewClient(NetworkConfig{NodeUrl: mockServer.URL, ChainId: 4})
+	assert.NoError(t, err)
+
+	// Client errors aren't retried
+	it := client.TransactionRange(0, 10)
+	assert.False(t, it.Next())
+	assert.ErrorContains(t, it.Err(), "bad start")
+	assert.Equal(t, int32(1), requests.Load())
+}
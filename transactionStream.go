@@ -0,0 +1,446 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// Transaction Stream Service endpoints of the Aptos Labs hosted networks.  An API key from the Aptos developer portal
+// is needed for each.
+const (
+	MainnetTransactionStreamUrl = "https://grpc.mainnet.aptoslabs.com"
+	TestnetTransactionStreamUrl = "https://grpc.testnet.aptoslabs.com"
+	DevnetTransactionStreamUrl  = "https://grpc.devnet.aptoslabs.com"
+)
+
+const (
+	DefaultTransactionStreamReconnects = 5                 // DefaultTransactionStreamReconnects is how many times in a row a broken stream is reconnected
+	DefaultTransactionStreamBackoff    = time.Second       // DefaultTransactionStreamBackoff is the wait before the first reconnect, it doubles after each
+	MaxTransactionStreamMessageSize    = 256 * 1024 * 1024 // MaxTransactionStreamMessageSize is the largest response message accepted from the stream
+)
+
+// transactionStreamMethod is the gRPC method streaming transactions
+const transactionStreamMethod = "/aptos.indexer.v1.RawData/GetTransactions"
+
+// gRPC status codes which reconnecting won't fix
+const (
+	grpcInvalidArgument  = 3
+	grpcPermissionDenied = 7
+	grpcUnauthenticated  = 16
+)
+
+// TransactionStreamError is a gRPC error status returned by the Transaction Stream Service
+type TransactionStreamError struct {
+	Code    int    // Code is the gRPC status code e.g. 16 for unauthenticated
+	Message string // Message is the gRPC status message
+}
+
+func (e *TransactionStreamError) Error() string {
+	return fmt.Sprintf("transaction stream status %d: %s", e.Code, e.Message)
+}
+
+// retryable tells if reconnecting may fix the error
+func (e *TransactionStreamError) retryable() bool {
+	return e.Code != grpcInvalidArgument && e.Code != grpcPermissionDenied && e.Code != grpcUnauthenticated
+}
+
+// StreamTransaction is a transaction from the Transaction Stream Service, decoded from its protobuf form.  The fields
+// shared with the node API are decoded, and Raw has the whole aptos.transaction.v1.Transaction message for anything
+// else, e.g. write set changes or the payload.
+type StreamTransaction struct {
+	Version             uint64                 // Version of the transaction
+	Type                api.TransactionVariant // Type of the transaction, as named by the node API
+	Timestamp           uint64                 // Timestamp of the block in microseconds
+	Epoch               uint64                 // Epoch of the block
+	BlockHeight         uint64                 // BlockHeight of the block
+	Hash                api.Hash               // Hash of the transaction
+	StateChangeHash     api.Hash               // StateChangeHash of the transaction
+	EventRootHash       api.Hash               // EventRootHash of the transaction
+	StateCheckpointHash api.Hash               // StateCheckpointHash of the transaction, if any
+	AccumulatorRootHash api.Hash               // AccumulatorRootHash of the transaction
+	GasUsed             uint64                 // GasUsed in gas units
+	Success             bool                   // Success of the transaction
+	VmStatus            string                 // VmStatus of the transaction, with the error if any
+	Events              []*api.Event           // Events emitted by the transaction
+
+	Sender                  *AccountAddress // Sender of a user transaction
+	SequenceNumber          uint64          // SequenceNumber of a user transaction
+	MaxGasAmount            uint64          // MaxGasAmount of a user transaction
+	GasUnitPrice            uint64          // GasUnitPrice of a user transaction
+	ExpirationTimestampSecs uint64          // ExpirationTimestampSecs of a user transaction
+
+	Raw []byte // Raw is the protobuf encoded aptos.transaction.v1.Transaction
+}
+
+// UserTransaction converts a user transaction to the node API's type.  The payload, signature, and changes aren't
+// converted, decode them from Raw if needed.
+func (txn *StreamTransaction) UserTransaction() (*api.UserTransaction, error) {
+	if txn.Type != api.TransactionVariantUser {
+		return nil, fmt.Errorf("transaction type is %s, not %s", txn.Type, api.TransactionVariantUser)
+	}
+	return &api.UserTransaction{
+		Version:                 txn.Version,
+		Hash:                    txn.Hash,
+		AccumulatorRootHash:     txn.AccumulatorRootHash,
+		StateChangeHash:         txn.StateChangeHash,
+		EventRootHash:           txn.EventRootHash,
+		GasUsed:                 txn.GasUsed,
+		Success:                 txn.Success,
+		VmStatus:                txn.VmStatus,
+		Events:                  txn.Events,
+		Sender:                  txn.Sender,
+		SequenceNumber:          txn.SequenceNumber,
+		MaxGasAmount:            txn.MaxGasAmount,
+		GasUnitPrice:            txn.GasUnitPrice,
+		ExpirationTimestampSecs: txn.ExpirationTimestampSecs,
+		Timestamp:               txn.Timestamp,
+		StateCheckpointHash:     txn.StateCheckpointHash,
+	}, nil
+}
+
+// TransactionStreamClient streams transactions from the Aptos Transaction Stream Service, the gRPC API indexers are
+// built on.  A broken stream is reconnected from the next version, so each transaction is delivered once, in order.
+//
+//	stream := NewTransactionStreamClient(TestnetTransactionStreamUrl, apiKey)
+//	err := stream.Stream(ctx, startVersion, func(txn *StreamTransaction) error {
+//		// index the transaction
+//		return nil
+//	})
+//
+// In a long-running service, Start runs it in the background instead, and Close stops it after the transaction being
+// handled, so [TransactionStreamClient.NextVersion] is exact for resuming.
+//
+//	stream := NewTransactionStreamClient(TestnetTransactionStreamUrl, apiKey).
+//		FromVersion(startVersion).
+//		Handler(indexTransaction)
+//	err := stream.Start(ctx)
+//
+// To run an [EventSubscription] on the stream, use a [StreamEventBackend].
+//
+// gRPC needs HTTP/2, which the default client only negotiates over TLS.  For a plaintext endpoint e.g. a local
+// stream, set HttpClient to one with an h2c transport.
+type TransactionStreamClient struct {
+	Url        string       // Url of the service e.g. [TestnetTransactionStreamUrl]
+	AuthToken  string       // AuthToken is sent as a bearer token, the API key for hosted networks
+	HttpClient *http.Client // HttpClient for the stream, defaults to one without a timeout, as streams are long-lived
+	BatchSize  uint64       // BatchSize is the number of transactions per response, the server's default if not set
+
+	MaxReconnects int           // MaxReconnects in a row without receiving a transaction, defaults to [DefaultTransactionStreamReconnects]
+	Backoff       time.Duration // Backoff before the first reconnect, defaults to [DefaultTransactionStreamBackoff]
+
+	fromVersion uint64
+	nextVersion uint64
+	handler     func(txn *StreamTransaction) error
+
+	life    lifecycle
+	cancel  context.CancelFunc
+	closing bool // closing is set by Close, so the cancellation isn't reported as an error
+}
+
+// NewTransactionStreamClient creates a client for the Transaction Stream Service at url
+func NewTransactionStreamClient(url string, authToken string) *TransactionStreamClient {
+	return &TransactionStreamClient{Url: url, AuthToken: authToken}
+}
+
+// FromVersion sets the version [TransactionStreamClient.Start] streams from, inclusive, 0 by default
+func (c *TransactionStreamClient) FromVersion(version uint64) *TransactionStreamClient {
+	c.fromVersion = version
+	return c
+}
+
+// Handler is called for each transaction by [TransactionStreamClient.Start].  Returning an error stops the stream.
+func (c *TransactionStreamClient) Handler(handler func(txn *StreamTransaction) error) *TransactionStreamClient {
+	c.handler = handler
+	return c
+}
+
+// NextVersion is the first version not yet delivered by [TransactionStreamClient.Start], so a stopped stream can be
+// resumed with [TransactionStreamClient.FromVersion]
+func (c *TransactionStreamClient) NextVersion() uint64 {
+	return c.nextVersion
+}
+
+// Start runs the stream in the background, delivering transactions to the handler, see [TransactionStreamClient.Stream]
+//
+// Implements:
+//   - [Lifecycle]
+func (c *TransactionStreamClient) Start(ctx context.Context) error {
+	if c.handler == nil {
+		return errors.New("transaction stream has no handler")
+	}
+	return c.life.start(func() {
+		ctx, c.cancel = context.WithCancel(ctx)
+		c.nextVersion = c.fromVersion
+		go func() {
+			err := c.run(ctx, &c.nextVersion, c.handler)
+			c.life.mutex.RLock()
+			if c.closing && errors.Is(err, context.Canceled) {
+				err = nil
+			}
+			c.life.mutex.RUnlock()
+			c.life.finish(err)
+		}()
+	})
+}
+
+// Close stops the stream once the transaction being handled is done, and returns the error it stopped with, if it
+// stopped before being closed
+//
+// Implements:
+//   - [Lifecycle]
+func (c *TransactionStreamClient) Close() error {
+	c.life.close(func() {
+		c.closing = true
+		c.cancel()
+	})
+	return c.life.wait()
+}
+
+// Done is closed once the stream has stopped, see [TransactionStreamClient.Err] for why
+//
+// Implements:
+//   - [Lifecycle]
+func (c *TransactionStreamClient) Done() <-chan struct{} {
+	return c.life.doneChan()
+}
+
+// Err is the error a started stream stopped with, nil while it is running or if it was closed
+func (c *TransactionStreamClient) Err() error {
+	select {
+	case <-c.Done():
+		return c.life.err
+	default:
+		return nil
+	}
+}
+
+// Stream delivers transactions starting at fromVersion to handle, until ctx is done or handle returns an error.  It
+// returns ctx.Err() when cancelled.
+//
+// Unlike [TransactionStreamClient.Start], it blocks, and the same client can run several streams at once.
+func (c *TransactionStreamClient) Stream(ctx context.Context, fromVersion uint64, handle func(txn *StreamTransaction) error) error {
+	next := fromVersion
+	return c.run(ctx, &next, handle)
+}
+
+// run streams from next until ctx is done or handle returns an error, advancing next past each delivered transaction
+func (c *TransactionStreamClient) run(ctx context.Context, next *uint64, handle func(txn *StreamTransaction) error) error {
+	maxReconnects := c.MaxReconnects
+	if maxReconnects <= 0 {
+		maxReconnects = DefaultTransactionStreamReconnects
+	}
+	backoff := c.Backoff
+	if backoff <= 0 {
+		backoff = DefaultTransactionStreamBackoff
+	}
+
+	reconnects := 0
+	for {
+		start := *next
+		err := c.stream(ctx, next, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var handlerErr *transactionStreamHandlerError
+		if errors.As(err, &handlerErr) {
+			return handlerErr.err
+		}
+		var statusErr *TransactionStreamError
+		if err != nil && errors.As(err, &statusErr) && !statusErr.retryable() {
+			return err
+		}
+		// Progress resets the reconnects, the stream may have been up for days
+		if *next > start {
+			reconnects = 0
+		}
+		if reconnects >= maxReconnects {
+			return fmt.Errorf("transaction stream failed after %d reconnects at version %d: %w", reconnects, *next, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff << reconnects):
+		}
+		reconnects++
+	}
+}
+
+// transactionStreamHandlerError wraps an error from the handler, so it isn't retried
+type transactionStreamHandlerError struct {
+	err error
+}
+
+func (e *transactionStreamHandlerError) Error() string {
+	return e.err.Error()
+}
+
+// stream runs one connection of the stream, advancing next past each delivered transaction
+func (c *TransactionStreamClient) stream(ctx context.Context, next *uint64, handle func(txn *StreamTransaction) error) error {
+	request := appendProtoVarint(nil, 1, *next)
+	if c.BatchSize > 0 {
+		request = appendProtoVarint(request, 3, c.BatchSize)
+	}
+	body := make([]byte, 5, 5+len(request))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(request)))
+	body = append(body, request...)
+
+	streamUrl, err := url.JoinPath(c.Url, transactionStreamMethod)
+	if err != nil {
+		return fmt.Errorf("failed to parse transaction stream url '%s': %w", c.Url, err)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, streamUrl, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/grpc+proto")
+	httpRequest.Header.Set("TE", "trailers")
+	if c.AuthToken != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+c.AuthToken)
+	}
+
+	httpClient := c.HttpClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	response, err := httpClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("transaction stream http status %d", response.StatusCode)
+	}
+	// A failure before any message is in the headers
+	if err = grpcStatus(response.Header); err != nil {
+		return err
+	}
+
+	header := make([]byte, 5)
+	for {
+		_, err = io.ReadFull(response.Body, header)
+		if errors.Is(err, io.EOF) {
+			// The stream ended, the status is in the trailers
+			if err = grpcStatus(response.Trailer); err != nil {
+				return err
+			}
+			return io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return err
+		}
+		if header[0] != 0 {
+			return errors.New("compressed transaction stream messages are not supported")
+		}
+		length := binary.BigEndian.Uint32(header[1:])
+		if length > MaxTransactionStreamMessageSize {
+			return fmt.Errorf("transaction stream message of %d bytes is too large", length)
+		}
+		message := make([]byte, length)
+		_, err = io.ReadFull(response.Body, message)
+		if err != nil {
+			return err
+		}
+
+		txns, lastVersion, err := decodeTransactionsResponse(message)
+		if err != nil {
+			return err
+		}
+		for _, txn := range txns {
+			// Transactions from before a reconnect are skipped
+			if txn.Version < *next {
+				continue
+			}
+			err = handle(txn)
+			if err != nil {
+				return &transactionStreamHandlerError{err}
+			}
+			*next = txn.Version + 1
+		}
+		// Filtered out transactions are skipped too
+		if lastVersion != nil && *lastVersion+1 > *next {
+			*next = *lastVersion + 1
+		}
+	}
+}
+
+// grpcStatus converts a gRPC status in headers or trailers to an error, nil if it's missing or OK
+func grpcStatus(header http.Header) error {
+	status := header.Get("Grpc-Status")
+	if status == "" || status == "0" {
+		return nil
+	}
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return fmt.Errorf("bad transaction stream status %q", status)
+	}
+	message, err := url.PathUnescape(header.Get("Grpc-Message"))
+	if err != nil {
+		message = header.Get("Grpc-Message")
+	}
+	return &TransactionStreamError{Code: code, Message: strings.TrimSpace(message)}
+}
+
+//region StreamEventBackend
+
+// StreamEventNodeClient is the subset of [Client] used by [StreamEventBackend] for the latest ledger version
+type StreamEventNodeClient interface {
+	Info() (info NodeInfo, err error)
+}
+
+// StreamEventBackend is an [EventSubscriptionBackend] on the Transaction Stream Service, so an [EventSubscription] is
+// pushed transactions rather than polling the node
+//
+//	subscription := client.Subscribe().Backend(&StreamEventBackend{
+//		Stream: NewTransactionStreamClient(TestnetTransactionStreamUrl, apiKey),
+//		Node:   client,
+//	})
+//
+// Write set changes aren't converted, see [StreamTransaction.UserTransaction], so a [ChainScanner] can't find deposits
+// on it.
+type StreamEventBackend struct {
+	Stream *TransactionStreamClient // Stream delivers the transactions
+	Node   StreamEventNodeClient    // Node gives the latest version, when a subscription has no start version
+}
+
+// LatestVersion returns the ledger version of the node
+//
+// Implements:
+//   - [EventSubscriptionBackend]
+func (b *StreamEventBackend) LatestVersion() (uint64, error) {
+	if b.Node == nil {
+		return 0, errors.New("stream event backend has no node to get the latest version from")
+	}
+	info, err := b.Node.Info()
+	if err != nil {
+		return 0, err
+	}
+	return info.LedgerVersion(), nil
+}
+
+// Run streams user transactions starting at fromVersion
+//
+// Implements:
+//   - [EventSubscriptionBackend]
+func (b *StreamEventBackend) Run(ctx context.Context, fromVersion uint64, handle func(txn *api.UserTransaction) error) error {
+	return b.Stream.Stream(ctx, fromVersion, func(txn *StreamTransaction) error {
+		// Events from system transactions e.g. block metadata aren't delivered
+		userTxn, err := txn.UserTransaction()
+		if err != nil {
+			return nil
+		}
+		return handle(userTxn)
+	})
+}
+
+//endregion
@@ -0,0 +1,332 @@
+package aptos
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// Protobuf wire types, see https://protobuf.dev/programming-guides/encoding/
+const (
+	protoVarint = 0
+	protoI64    = 1
+	protoLen    = 2
+	protoI32    = 5
+)
+
+// protoReader reads the fields of a protobuf message, just enough to decode the transaction stream without generated
+// code.  Unknown fields are skipped, so newer protos still decode.
+type protoReader struct {
+	buf    []byte
+	err    error
+	parent *protoReader // parent is the message this one is embedded in, which fails with it
+}
+
+// fail stops reading the message and the messages it's embedded in
+func (r *protoReader) fail(err error) {
+	for ; r != nil; r = r.parent {
+		if r.err == nil {
+			r.err = err
+		}
+	}
+}
+
+// next reads the next field's number and wire type, false at the end of the message or on error
+func (r *protoReader) next() (field uint64, wireType uint64, ok bool) {
+	if r.err != nil || len(r.buf) == 0 {
+		return 0, 0, false
+	}
+	key := r.varint()
+	return key >> 3, key & 7, r.err == nil
+}
+
+// varint reads a varint field value
+func (r *protoReader) varint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	value, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.fail(errors.New("bad protobuf varint"))
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return value
+}
+
+// bytes reads a length delimited field value, without copying
+func (r *protoReader) bytes() []byte {
+	length := r.varint()
+	if r.err != nil {
+		return nil
+	}
+	if length > uint64(len(r.buf)) {
+		r.fail(fmt.Errorf("protobuf field length %d past end of message", length))
+		return nil
+	}
+	value := r.buf[:length]
+	r.buf = r.buf[length:]
+	return value
+}
+
+// message reads a length delimited field value as an embedded message
+func (r *protoReader) message() *protoReader {
+	return &protoReader{buf: r.bytes(), parent: r}
+}
+
+// skip skips a field value of the wire type
+func (r *protoReader) skip(wireType uint64) {
+	switch wireType {
+	case protoVarint:
+		r.varint()
+	case protoI64:
+		r.fixed(8)
+	case protoLen:
+		r.bytes()
+	case protoI32:
+		r.fixed(4)
+	default:
+		r.fail(fmt.Errorf("unsupported protobuf wire type %d", wireType))
+	}
+}
+
+func (r *protoReader) fixed(size int) {
+	if len(r.buf) < size {
+		r.fail(errors.New("protobuf fixed field past end of message"))
+		return
+	}
+	r.buf = r.buf[size:]
+}
+
+// appendProtoVarint appends a varint field to a protobuf message
+func appendProtoVarint(buf []byte, field uint64, value uint64) []byte {
+	buf = binary.AppendUvarint(buf, field<<3|protoVarint)
+	return binary.AppendUvarint(buf, value)
+}
+
+// appendProtoBytes appends a length delimited field to a protobuf message
+func appendProtoBytes(buf []byte, field uint64, value []byte) []byte {
+	buf = binary.AppendUvarint(buf, field<<3|protoLen)
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+// streamTransactionTypes maps aptos.transaction.v1.Transaction.TransactionType to the node API's transaction types
+var streamTransactionTypes = map[uint64]api.TransactionVariant{
+	1:  api.TransactionVariantGenesis,
+	2:  api.TransactionVariantBlockMetadata,
+	3:  api.TransactionVariantStateCheckpoint,
+	4:  api.TransactionVariantUser,
+	20: api.TransactionVariantValidator,
+	21: api.TransactionVariantBlockEpilogue,
+}
+
+// decodeTransactionsResponse decodes an aptos.indexer.v1.TransactionsResponse.  lastVersion is the end of the
+// processed range, which is past the last transaction if the server filtered transactions out.
+func decodeTransactionsResponse(buf []byte) (txns []*StreamTransaction, lastVersion *uint64, err error) {
+	r := &protoReader{buf: buf}
+	for field, wireType, ok := r.next(); ok; field, wireType, ok = r.next() {
+		switch {
+		case field == 1 && wireType == protoLen:
+			raw := r.bytes()
+			if r.err != nil {
+				continue
+			}
+			txn, err := decodeStreamTransaction(raw)
+			if err != nil {
+				return nil, nil, err
+			}
+			txns = append(txns, txn)
+		case field == 3 && wireType == protoLen:
+			// ProcessedRange { first_version = 1, last_version = 2 }
+			processed := r.message()
+			for field, wireType, ok := processed.next(); ok; field, wireType, ok = processed.next() {
+				if field == 2 && wireType == protoVarint {
+					version := processed.varint()
+					lastVersion = &version
+				} else {
+					processed.skip(wireType)
+				}
+			}
+		default:
+			r.skip(wireType)
+		}
+	}
+	if r.err != nil {
+		return nil, nil, fmt.Errorf("failed to decode transactions response: %w", r.err)
+	}
+	return txns, lastVersion, nil
+}
+
+// decodeStreamTransaction decodes an aptos.transaction.v1.Transaction
+func decodeStreamTransaction(raw []byte) (*StreamTransaction, error) {
+	txn := &StreamTransaction{Type: api.TransactionVariantUnknown, Raw: raw}
+	r := &protoReader{buf: raw}
+	for field, wireType, ok := r.next(); ok; field, wireType, ok = r.next() {
+		switch {
+		case field == 1 && wireType == protoLen:
+			seconds, nanos := decodeProtoTimestamp(r.message())
+			txn.Timestamp = seconds*1_000_000 + nanos/1_000
+		case field == 2 && wireType == protoVarint:
+			txn.Version = r.varint()
+		case field == 3 && wireType == protoLen:
+			decodeStreamTransactionInfo(r.message(), txn)
+		case field == 4 && wireType == protoVarint:
+			txn.Epoch = r.varint()
+		case field == 5 && wireType == protoVarint:
+			txn.BlockHeight = r.varint()
+		case field == 6 && wireType == protoVarint:
+			if variant, ok := streamTransactionTypes[r.varint()]; ok {
+				txn.Type = variant
+			}
+		case field == 7 && wireType == protoLen:
+			// BlockMetadataTransaction events are field 3
+			txn.Events = append(txn.Events, decodeStreamEvents(r.message(), 3)...)
+		case (field == 8 || field == 21) && wireType == protoLen:
+			// GenesisTransaction and ValidatorTransaction events are field 2
+			txn.Events = append(txn.Events, decodeStreamEvents(r.message(), 2)...)
+		case field == 10 && wireType == protoLen:
+			decodeStreamUserTransaction(r.message(), txn)
+		default:
+			r.skip(wireType)
+		}
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", r.err)
+	}
+	return txn, nil
+}
+
+// decodeProtoTimestamp decodes an aptos.util.timestamp.Timestamp
+func decodeProtoTimestamp(r *protoReader) (seconds uint64, nanos uint64) {
+	for field, wireType, ok := r.next(); ok; field, wireType, ok = r.next() {
+		switch {
+		case field == 1 && wireType == protoVarint:
+			seconds = r.varint()
+		case field == 2 && wireType == protoVarint:
+			nanos = r.varint()
+		default:
+			r.skip(wireType)
+		}
+	}
+	return seconds, nanos
+}
+
+// decodeStreamTransactionInfo decodes an aptos.transaction.v1.TransactionInfo, write set changes are skipped
+func decodeStreamTransactionInfo(r *protoReader, txn *StreamTransaction) {
+	for field, wireType, ok := r.next(); ok; field, wireType, ok = r.next() {
+		switch {
+		case field == 1 && wireType == protoLen:
+			txn.Hash = BytesToHex(r.bytes())
+		case field == 2 && wireType == protoLen:
+			txn.StateChangeHash = BytesToHex(r.bytes())
+		case field == 3 && wireType == protoLen:
+			txn.EventRootHash = BytesToHex(r.bytes())
+		case field == 4 && wireType == protoLen:
+			txn.StateCheckpointHash = BytesToHex(r.bytes())
+		case field == 5 && wireType == protoVarint:
+			txn.GasUsed = r.varint()
+		case field == 6 && wireType == protoVarint:
+			txn.Success = r.varint() != 0
+		case field == 7 && wireType == protoLen:
+			txn.VmStatus = string(r.bytes())
+		case field == 8 && wireType == protoLen:
+			txn.AccumulatorRootHash = BytesToHex(r.bytes())
+		default:
+			r.skip(wireType)
+		}
+	}
+}
+
+// decodeStreamUserTransaction decodes an aptos.transaction.v1.UserTransaction, the payload and signature are skipped
+func decodeStreamUserTransaction(r *protoReader, txn *StreamTransaction) {
+	for field, wireType, ok := r.next(); ok; field, wireType, ok = r.next() {
+		switch {
+		case field == 1 && wireType == protoLen:
+			request := r.message()
+			for field, wireType, ok := request.next(); ok; field, wireType, ok = request.next() {
+				switch {
+				case field == 1 && wireType == protoLen:
+					sender := &AccountAddress{}
+					if err := sender.ParseStringRelaxed(string(request.bytes())); err != nil {
+						request.fail(err)
+					}
+					txn.Sender = sender
+				case field == 2 && wireType == protoVarint:
+					txn.SequenceNumber = request.varint()
+				case field == 3 && wireType == protoVarint:
+					txn.MaxGasAmount = request.varint()
+				case field == 4 && wireType == protoVarint:
+					txn.GasUnitPrice = request.varint()
+				case field == 5 && wireType == protoLen:
+					txn.ExpirationTimestampSecs, _ = decodeProtoTimestamp(request.message())
+				default:
+					request.skip(wireType)
+				}
+			}
+		case field == 2 && wireType == protoLen:
+			txn.Events = append(txn.Events, decodeStreamEvent(r.message()))
+		default:
+			r.skip(wireType)
+		}
+	}
+}
+
+// decodeStreamEvents decodes the repeated events field of a transaction message
+func decodeStreamEvents(r *protoReader, eventsField uint64) []*api.Event {
+	events := make([]*api.Event, 0)
+	for field, wireType, ok := r.next(); ok; field, wireType, ok = r.next() {
+		if field == eventsField && wireType == protoLen {
+			events = append(events, decodeStreamEvent(r.message()))
+		} else {
+			r.skip(wireType)
+		}
+	}
+	return events
+}
+
+// decodeStreamEvent decodes an aptos.transaction.v1.Event, the type is taken from its string form
+func decodeStreamEvent(r *protoReader) *api.Event {
+	event := &api.Event{}
+	for field, wireType, ok := r.next(); ok; field, wireType, ok = r.next() {
+		switch {
+		case field == 1 && wireType == protoLen:
+			key := r.message()
+			guid := &api.GUID{}
+			for field, wireType, ok := key.next(); ok; field, wireType, ok = key.next() {
+				switch {
+				case field == 1 && wireType == protoVarint:
+					guid.CreationNumber = key.varint()
+				case field == 2 && wireType == protoLen:
+					address := &AccountAddress{}
+					if err := address.ParseStringRelaxed(string(key.bytes())); err == nil {
+						guid.AccountAddress = address
+					}
+				default:
+					key.skip(wireType)
+				}
+			}
+			event.Guid = guid
+		case field == 2 && wireType == protoVarint:
+			event.SequenceNumber = r.varint()
+		case field == 4 && wireType == protoLen:
+			// Data is JSON, as in the node API
+			var data any
+			if err := json.Unmarshal(r.bytes(), &data); err != nil {
+				r.fail(fmt.Errorf("failed to decode event data: %w", err))
+			} else if fields, ok := data.(map[string]any); ok {
+				event.Data = fields
+			} else {
+				event.Data = map[string]any{api.AnyDataName: data}
+			}
+		case field == 5 && wireType == protoLen:
+			event.Type = string(r.bytes())
+		default:
+			r.skip(wireType)
+		}
+	}
+	return event
+}
@@ -0,0 +1,229 @@
+package aptos
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// testStreamTransaction encodes an aptos.transaction.v1.Transaction, a user transaction with one event
+func testStreamTransaction(version uint64) []byte {
+	timestamp := appendProtoVarint(appendProtoVarint(nil, 1, 1700000000), 2, 500_000_000)
+	info := appendProtoBytes(nil, 1, []byte{0xab, byte(version)})
+	info = appendProtoVarint(info, 5, 7)
+	info = appendProtoVarint(info, 6, 1)
+	info = appendProtoBytes(info, 7, []byte("Executed successfully"))
+	request := appendProtoBytes(nil, 1, []byte("0xa"))
+	request = appendProtoVarint(request, 2, version)
+	request = appendProtoBytes(request, 5, appendProtoVarint(nil, 1, 1700000600))
+	eventKey := appendProtoBytes(appendProtoVarint(nil, 1, 2), 2, []byte("0xb"))
+	event := appendProtoBytes(nil, 1, eventKey)
+	event = appendProtoBytes(event, 4, []byte(`{"amount":"100"}`))
+	event = appendProtoBytes(event, 5, []byte("0x1::coin::DepositEvent"))
+	user := appendProtoBytes(appendProtoBytes(nil, 1, request), 2, event)
+
+	txn := appendProtoBytes(nil, 1, timestamp)
+	txn = appendProtoVarint(txn, 2, version)
+	txn = appendProtoBytes(txn, 3, info)
+	txn = appendProtoVarint(txn, 6, 4)
+	// Unknown fields are skipped
+	txn = appendProtoBytes(txn, 22, []byte{1, 2, 3})
+	return appendProtoBytes(txn, 10, user)
+}
+
+// writeTestStreamResponse writes a gRPC framed aptos.indexer.v1.TransactionsResponse
+func writeTestStreamResponse(w http.ResponseWriter, versions []uint64, lastVersion uint64) {
+	response := make([]byte, 0)
+	for _, version := range versions {
+		response = appendProtoBytes(response, 1, testStreamTransaction(version))
+	}
+	response = appendProtoBytes(response, 3, appendProtoVarint(appendProtoVarint(nil, 1, versions[0]), 2, lastVersion))
+	frame := make([]byte, 5)
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(response)))
+	_, _ = w.Write(append(frame, response...))
+	w.(http.Flusher).Flush()
+}
+
+func TestTransactionStreamClient(t *testing.T) {
+	var mutex sync.Mutex
+	var starts []uint64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/aptos.indexer.v1.RawData/GetTransactions", r.URL.Path)
+		assert.Equal(t, "HTTP/2.0", r.Proto)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		reader := &protoReader{buf: body[5:]}
+		_, _, ok := reader.next()
+		assert.True(t, ok)
+		start := reader.varint()
+		mutex.Lock()
+		starts = append(starts, start)
+		mutex.Unlock()
+
+		w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+		w.Header().Set("Content-Type", "application/grpc")
+		if start == 5 {
+			// The first connection breaks after two transactions
+			writeTestStreamResponse(w, []uint64{5, 6}, 6)
+			w.Header().Set("Grpc-Status", "14")
+			w.Header().Set("Grpc-Message", "unavailable")
+			return
+		}
+		// Repeated transactions are skipped, and filtered out versions advance the stream
+		writeTestStreamResponse(w, []uint64{6, 7}, 9)
+		writeTestStreamResponse(w, []uint64{10}, 10)
+		w.Header().Set("Grpc-Status", "0")
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	stream := NewTransactionStreamClient(server.URL, "key")
+	stream.HttpClient = server.Client()
+	stream.Backoff = time.Millisecond
+
+	stop := errors.New("stop")
+	var received []*StreamTransaction
+	err := stream.Stream(context.Background(), 5, func(txn *StreamTransaction) error {
+		received = append(received, txn)
+		if txn.Version == 10 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, []uint64{5, 7}, starts)
+	versions := make([]uint64, len(received))
+	for i, txn := range received {
+		versions[i] = txn.Version
+	}
+	assert.Equal(t, []uint64{5, 6, 7, 10}, versions)
+
+	userTxn, err := received[0].UserTransaction()
+	assert.NoError(t, err)
+	assert.Equal(t, "0xab05", userTxn.Hash)
+	assert.Equal(t, uint64(7), userTxn.GasUsed)
+	assert.True(t, userTxn.Success)
+	assert.Equal(t, uint64(1700000000_500000), userTxn.Timestamp)
+	assert.Equal(t, uint64(1700000600), userTxn.ExpirationTimestampSecs)
+	assert.Equal(t, uint64(5), userTxn.SequenceNumber)
+	assert.Equal(t, "0xa", userTxn.Sender.String())
+	assert.Len(t, userTxn.Events, 1)
+	assert.Equal(t, "0x1::coin::DepositEvent", userTxn.Events[0].Type)
+	assert.Equal(t, uint64(2), userTxn.Events[0].Guid.CreationNumber)
+	assert.Equal(t, "0xb", userTxn.Events[0].Guid.AccountAddress.String())
+	assert.Equal(t, map[string]any{"amount": "100"}, userTxn.Events[0].Data)
+}
+
+func TestTransactionStreamClient_Errors(t *testing.T) {
+	requests := 0
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Grpc-Status", "16")
+		w.Header().Set("Grpc-Message", "bad%20key")
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	stream := &TransactionStreamClient{Url: server.URL, HttpClient: server.Client(), Backoff: time.Millisecond}
+	err := stream.Stream(context.Background(), 0, func(txn *StreamTransaction) error {
+		return nil
+	})
+	// Authentication failures aren't retried
+	statusErr := &TransactionStreamError{}
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, 16, statusErr.Code)
+	assert.Equal(t, "bad key", statusErr.Message)
+	assert.Equal(t, 1, requests)
+
+	_, err = (&StreamTransaction{Type: api.TransactionVariantBlockMetadata}).UserTransaction()
+	assert.Error(t, err)
+
+	_, _, err = decodeTransactionsResponse([]byte{0x0a, 0x05, 0x01})
+	assert.ErrorContains(t, err, "past end of message")
+}
+
+// testStreamServer streams the versions, then holds the stream open until the client goes away
+func testStreamServer(versions []uint64) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+		w.Header().Set("Content-Type", "application/grpc")
+		writeTestStreamResponse(w, versions, versions[len(versions)-1])
+		<-r.Context().Done()
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	return server
+}
+
+func TestTransactionStreamClient_Lifecycle(t *testing.T) {
+	server := testStreamServer([]uint64{5, 6})
+	defer server.Close()
+
+	received := make(chan uint64, 2)
+	stream := NewTransactionStreamClient(server.URL, "key").
+		FromVersion(5).
+		Handler(func(txn *StreamTransaction) error {
+			received <- txn.Version
+			return nil
+		})
+	stream.HttpClient = server.Client()
+	var _ Lifecycle = stream
+
+	assert.NoError(t, stream.Start(context.Background()))
+	assert.ErrorIs(t, stream.Start(context.Background()), ErrAlreadyStarted)
+	assert.Equal(t, uint64(5), <-received)
+	assert.Equal(t, uint64(6), <-received)
+	assert.NoError(t, stream.Err())
+
+	// Closing isn't an error, and the stream can be resumed from the next version
+	assert.NoError(t, stream.Close())
+	<-stream.Done()
+	assert.NoError(t, stream.Err())
+	assert.Equal(t, uint64(7), stream.NextVersion())
+	assert.ErrorIs(t, stream.Start(context.Background()), ErrClosed)
+}
+
+func TestStreamEventBackend(t *testing.T) {
+	server := testStreamServer([]uint64{5, 6})
+	defer server.Close()
+
+	stream := NewTransactionStreamClient(server.URL, "key")
+	stream.HttpClient = server.Client()
+
+	stop := errors.New("stop")
+	var events []SubscribedEvent
+	err := NewEventSubscription(&StreamEventBackend{Stream: stream}).
+		EventType("0x1::coin::DepositEvent").
+		FromVersion(5).
+		Handler(func(event SubscribedEvent) error {
+			events = append(events, event)
+			if event.Version == 6 {
+				return stop
+			}
+			return nil
+		}).
+		Run(context.Background())
+	assert.ErrorIs(t, err, stop)
+	assert.Len(t, events, 2)
+	assert.Equal(t, uint64(5), events[0].Version)
+	assert.Equal(t, "0xa", events[0].Sender.String())
+
+	// Without a start version, the latest version comes from the node
+	err = NewEventSubscription(&StreamEventBackend{Stream: stream}).
+		Handler(func(event SubscribedEvent) error { return nil }).
+		Run(context.Background())
+	assert.ErrorContains(t, err, "no node")
+}
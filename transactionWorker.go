@@ -0,0 +1,345 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// DefaultTransactionWorkerCount is the default number of goroutines building, signing, and submitting in a
+// [TransactionWorker]
+const DefaultTransactionWorkerCount = 20
+
+// TransactionWorkerEventType is the kind of [TransactionWorkerEvent]
+type TransactionWorkerEventType uint8
+
+const (
+	TransactionWorkerEventSubmitted TransactionWorkerEventType = iota // TransactionWorkerEventSubmitted is emitted when a transaction is accepted by the node
+	TransactionWorkerEventCommitted                                   // TransactionWorkerEventCommitted is emitted when a transaction is committed successfully
+	TransactionWorkerEventFailed                                      // TransactionWorkerEventFailed is emitted when a transaction fails to build, sign, submit, or execute
+)
+
+// String returns a readable name for the event type
+func (t TransactionWorkerEventType) String() string {
+	switch t {
+	case TransactionWorkerEventSubmitted:
+		return "submitted"
+	case TransactionWorkerEventCommitted:
+		return "committed"
+	case TransactionWorkerEventFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// TransactionWorkerEvent reports progress of a single payload pushed to a [TransactionWorker]
+//
+// Each payload gets a [TransactionWorkerEventSubmitted] event once accepted by the node, and then exactly one of
+// [TransactionWorkerEventCommitted] or [TransactionWorkerEventFailed].  Payloads that never reach the node only get a
+// [TransactionWorkerEventFailed] event.
+type TransactionWorkerEvent struct {
+	Type           TransactionWorkerEventType // Type of the event
+	Id             uint64                     // Id is the [TransactionBuildPayload.Id] of the payload
+	SequenceNumber uint64                     // SequenceNumber assigned to the transaction
+	Hash           string                     // Hash of the transaction, empty if it wasn't submitted
+	Transaction    *api.UserTransaction       // Transaction is the committed transaction, only for committed and execution failures
+	Err            error                      // Err is the reason for a [TransactionWorkerEventFailed]
+}
+
+// TransactionWorkerClient is the subset of [Client] used by [TransactionWorker]
+type TransactionWorkerClient interface {
+	SequenceNumberClient
+	BuildTransaction(sender AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransaction, err error)
+	SubmitTransaction(signedTransaction *SignedTransaction) (data *api.SubmitTransactionResponse, err error)
+	WaitForTransaction(txnHash string, options ...any) (data *api.UserTransaction, err error)
+}
+
+// TransactionWorker builds, signs, submits, and waits on transactions for a single sender concurrently, for bulk
+// submission e.g. airdrops.
+//
+// Sequence numbers are handed out by an [AccountSequenceNumberManager], so the account is only fetched on start and
+// after sequence number errors.  The numbers of transactions the node rejects are handed out again, so a bad payload
+// doesn't hold back the rest, and the worker restarts from the on-chain sequence number after a transaction expires.
+// Only single signer payloads are supported.
+//
+//	worker := NewTransactionWorker(client, sender)
+//	payloads := make(chan TransactionBuildPayload)
+//	events := worker.Run(payloads)
+//	go func() {
+//		for i, payload := range airdrop {
+//			payloads <- TransactionBuildPayload{Id: uint64(i), Type: TransactionSubmissionTypeSingle, Inner: payload}
+//		}
+//		close(payloads)
+//	}()
+//	for event := range events {
+//		// Record successes and failures
+//	}
+//
+// Payloads the node rejects are retried with a new sequence number up to [TransactionWorker.MaxAttempts] times.
+// Transactions that were submitted aren't retried, as they may still commit.  Payloads that are given up on are sent to
+// [TransactionWorker.DeadLetters], so they can be reconciled and deliberately re-enqueued.
+//
+// For a long-running service, use the [Lifecycle] API instead.  Close submits everything already queued and waits for
+// it to commit, so no transactions are lost on shutdown.  Events must be read while closing.
+//
+//	err := worker.Start(ctx)
+//	go func() {
+//		for event := range worker.Events() {
+//			// Record successes and failures
+//		}
+//	}()
+//	err = worker.Submit(payload)
+//	...
+//	err = worker.Close()
+type TransactionWorker struct {
+	Workers         int                           // Workers is the number of concurrent build, sign, and submit goroutines, defaults to [DefaultTransactionWorkerCount]
+	SequenceNumbers *AccountSequenceNumberManager // SequenceNumbers is the sequence number manager for the sender, it limits the outstanding transactions
+	BuildOptions    []any                         // BuildOptions are passed to [Client.BuildTransaction] e.g. [MaxGasAmount]
+	WaitOptions     []any                         // WaitOptions are passed to [Client.WaitForTransaction] e.g. [PollTimeout]
+	MaxAttempts     int                           // MaxAttempts is the number of times a payload is built and submitted before it is rejected, defaults to 1
+	DeadLetters     DeadLetterSink                // DeadLetters receives the payloads given up on, see [DeadLetter], nil to only report them as events
+
+	client TransactionWorkerClient
+	sender TransactionSigner
+
+	life     lifecycle
+	ctx      context.Context
+	payloads chan TransactionBuildPayload // payloads is the queue for [TransactionWorker.Submit]
+	events   <-chan TransactionWorkerEvent
+}
+
+// NewTransactionWorker creates a [TransactionWorker] for the sender, buildOptions are passed to every
+// [Client.BuildTransaction]
+func NewTransactionWorker(client TransactionWorkerClient, sender TransactionSigner, buildOptions ...any) *TransactionWorker {
+	return &TransactionWorker{
+		Workers:         DefaultTransactionWorkerCount,
+		SequenceNumbers: NewAccountSequenceNumberManager(client, sender.AccountAddress()),
+		BuildOptions:    buildOptions,
+		client:          client,
+		sender:          sender,
+	}
+}
+
+// Run starts processing payloads in the background, and returns the channel of events.  The events channel is closed
+// once payloads is closed and every transaction has committed or failed.
+func (w *TransactionWorker) Run(payloads <-chan TransactionBuildPayload) <-chan TransactionWorkerEvent {
+	return w.run(context.Background(), payloads, func() {})
+}
+
+// Start starts the worker in the background, taking payloads from [TransactionWorker.Submit].  Cancelling ctx fails
+// payloads that haven't been submitted yet, but transactions already submitted are still waited on.
+//
+// Implements:
+//   - [Lifecycle]
+func (w *TransactionWorker) Start(ctx context.Context) error {
+	return w.life.start(func() {
+		w.ctx = ctx
+		w.payloads = make(chan TransactionBuildPayload, max(w.Workers, 1))
+		w.events = w.run(ctx, w.payloads, func() {
+			w.life.finish(nil)
+		})
+	})
+}
+
+// Submit queues a payload on a started worker, blocking while the queue is full.  Returns [ErrNotStarted] before
+// [TransactionWorker.Start], and [ErrClosed] after [TransactionWorker.Close].
+func (w *TransactionWorker) Submit(payload TransactionBuildPayload) error {
+	w.life.mutex.RLock()
+	defer w.life.mutex.RUnlock()
+	err := w.life.running()
+	if err != nil {
+		return err
+	}
+	select {
+	case w.payloads <- payload:
+		return nil
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+}
+
+// Events is the channel of events for a started worker, it is closed once the worker is done.  Nil before
+// [TransactionWorker.Start].
+func (w *TransactionWorker) Events() <-chan TransactionWorkerEvent {
+	w.life.mutex.RLock()
+	defer w.life.mutex.RUnlock()
+	return w.events
+}
+
+// Close stops taking payloads, then waits for every queued payload to be submitted and committed or failed
+//
+// Implements:
+//   - [Lifecycle]
+func (w *TransactionWorker) Close() error {
+	w.life.close(func() {
+		close(w.payloads)
+	})
+	return w.life.wait()
+}
+
+// Done is closed once the worker has stopped, and every event has been sent
+//
+// Implements:
+//   - [Lifecycle]
+func (w *TransactionWorker) Done() <-chan struct{} {
+	return w.life.doneChan()
+}
+
+// run processes payloads until the channel is closed, calling finished after the events channel is closed
+func (w *TransactionWorker) run(ctx context.Context, payloads <-chan TransactionBuildPayload, finished func()) <-chan TransactionWorkerEvent {
+	workers := w.Workers
+	if workers <= 0 {
+		workers = DefaultTransactionWorkerCount
+	}
+	events := make(chan TransactionWorkerEvent, workers)
+
+	// Waiting is tracked separately, so submitting continues while earlier transactions are committing
+	var submitters, waiters sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		submitters.Add(1)
+		go func() {
+			defer submitters.Done()
+			for payload := range payloads {
+				w.process(ctx, payload, events, &waiters)
+			}
+		}()
+	}
+
+	go func() {
+		submitters.Wait()
+		waiters.Wait()
+		close(events)
+		finished()
+	}()
+	return events
+}
+
+// process builds, signs, and submits a single payload, then waits for it in the background
+func (w *TransactionWorker) process(ctx context.Context, payload TransactionBuildPayload, events chan<- TransactionWorkerEvent, waiters *sync.WaitGroup) {
+	if payload.Type != TransactionSubmissionTypeSingle {
+		w.fail(events, DeadLetter{Payload: payload, Reason: DeadLetterRejected, Err: fmt.Errorf("transaction worker only supports single signer payloads")})
+		return
+	}
+
+	maxAttempts := max(w.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		// Once cancelled, nothing new is submitted, but already submitted transactions are still waited on
+		if err := ctx.Err(); err != nil {
+			w.fail(events, DeadLetter{Payload: payload, Reason: DeadLetterCancelled, Attempts: attempt - 1, Err: err})
+			return
+		}
+
+		sequenceNumber, hash, err := w.submit(payload)
+		if err == nil {
+			events <- TransactionWorkerEvent{Type: TransactionWorkerEventSubmitted, Id: payload.Id, SequenceNumber: sequenceNumber, Hash: hash}
+			waiters.Add(1)
+			go func() {
+				defer waiters.Done()
+				w.wait(payload, attempt, sequenceNumber, hash, events)
+			}()
+			return
+		}
+		if attempt >= maxAttempts {
+			w.fail(events, DeadLetter{Payload: payload, Reason: DeadLetterRejected, Attempts: attempt, SequenceNumber: sequenceNumber, Err: err})
+			return
+		}
+		slog.Debug("transaction worker retrying payload", "id", payload.Id, "attempt", attempt, "err", err)
+	}
+}
+
+// submit builds, signs, and submits the payload with the next sequence number
+func (w *TransactionWorker) submit(payload TransactionBuildPayload) (sequenceNumber uint64, hash string, err error) {
+	sequenceNumber, err = w.SequenceNumbers.NextSequenceNumber()
+	if err != nil {
+		return 0, "", err
+	}
+
+	signedTxn, err := w.buildAndSign(payload, sequenceNumber)
+	if err != nil {
+		// The transaction never reached the node, so hand out the number again rather than leave a gap
+		w.SequenceNumbers.Release(sequenceNumber)
+		return sequenceNumber, "", err
+	}
+	response, err := w.client.SubmitTransaction(signedTxn)
+	if err == nil {
+		return sequenceNumber, response.Hash, nil
+	}
+
+	httpErr := &HttpError{}
+	switch {
+	case errors.Is(err, ErrSequenceNumberTooOld):
+		// Another process used the account, so continue from the chain
+		w.resync()
+	case errors.As(err, &httpErr) && httpErr.StatusCode >= 400 && httpErr.StatusCode < 500:
+		// The node rejected the transaction, so hand out the number again rather than leave a gap
+		w.SequenceNumbers.Release(sequenceNumber)
+	default:
+		// The node may have the transaction anyway e.g. on a timeout, so the number can't be signed for another payload
+		w.resync()
+	}
+	return sequenceNumber, "", err
+}
+
+// resync continues from the on-chain sequence number, keeping the outstanding numbers
+func (w *TransactionWorker) resync() {
+	if err := w.SequenceNumbers.Resync(); err != nil {
+		slog.Warn("transaction worker failed to resynchronize sequence number", "sender", w.SequenceNumbers.address.String(), "err", err)
+	}
+}
+
+func (w *TransactionWorker) buildAndSign(payload TransactionBuildPayload, sequenceNumber uint64) (*SignedTransaction, error) {
+	options := make([]any, 0, len(w.BuildOptions)+len(payload.Options)+1)
+	options = append(options, w.BuildOptions...)
+	options = append(options, payload.Options...)
+	options = append(options, SequenceNumber(sequenceNumber))
+	rawTxn, err := w.client.BuildTransaction(w.sender.AccountAddress(), payload.Inner, options...)
+	if err != nil {
+		return nil, err
+	}
+	return rawTxn.SignedTransaction(w.sender)
+}
+
+// wait waits for a submitted transaction, and reports whether it committed
+func (w *TransactionWorker) wait(payload TransactionBuildPayload, attempts int, sequenceNumber uint64, hash string, events chan<- TransactionWorkerEvent) {
+	txn, err := w.client.WaitForTransaction(hash, w.WaitOptions...)
+	letter := DeadLetter{Payload: payload, Attempts: attempts, SequenceNumber: sequenceNumber, Hash: hash}
+	switch {
+	case err != nil:
+		letter.Reason = DeadLetterExpired
+		letter.Err = err
+		w.fail(events, letter)
+		// Later transactions can't commit until the gap is filled, so restart from the on-chain sequence number
+		if syncErr := w.SequenceNumbers.Synchronize(); syncErr != nil {
+			slog.Warn("transaction worker failed to synchronize sequence number", "sender", w.SequenceNumbers.address.String(), "err", syncErr)
+		}
+	case !txn.Success:
+		letter.Reason = DeadLetterExecutionFailed
+		letter.Transaction = txn
+		letter.Err = fmt.Errorf("transaction %s failed: %s", hash, txn.VmStatus)
+		w.fail(events, letter)
+	default:
+		events <- TransactionWorkerEvent{Type: TransactionWorkerEventCommitted, Id: payload.Id, SequenceNumber: sequenceNumber, Hash: hash, Transaction: txn}
+	}
+}
+
+// fail sends a payload that was given up on to the dead letter sink, and reports it as failed
+func (w *TransactionWorker) fail(events chan<- TransactionWorkerEvent, letter DeadLetter) {
+	if w.DeadLetters != nil {
+		if err := w.DeadLetters.DeadLetter(letter); err != nil {
+			slog.Warn("transaction worker failed to record dead letter", "id", letter.Payload.Id, "reason", letter.Reason, "err", err)
+		}
+	}
+	events <- TransactionWorkerEvent{
+		Type:           TransactionWorkerEventFailed,
+		Id:             letter.Payload.Id,
+		SequenceNumber: letter.SequenceNumber,
+		Hash:           letter.Hash,
+		Transaction:    letter.Transaction,
+		Err:            letter.Err,
+	}
+}
@@ -0,0 +1,409 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTransactionWorkerClient struct {
+	mutex     sync.Mutex
+	onChain   uint64
+	submitted map[string]*RawTransaction
+	rejects   map[string]int // rejects is the number of times to reject submitting each function
+	timeouts  map[string]int // timeouts is the number of times submitting each function fails without a response
+}
+
+func (m *mockTransactionWorkerClient) Account(_ AccountAddress, _ ...uint64) (AccountInfo, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return AccountInfo{SequenceNumberStr: strconv.FormatUint(m.onChain, 10)}, nil
+}
+
+func (m *mockTransactionWorkerClient) BuildTransaction(sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransaction, error) {
+	rawTxn := &RawTransaction{Sender: sender, Payload: payload, MaxGasAmount: DefaultMaxGasAmount, GasUnitPrice: DefaultGasUnitPrice, ChainId: 4}
+	for _, option := range options {
+		if sequenceNumber, ok := option.(SequenceNumber); ok {
+			rawTxn.SequenceNumber = uint64(sequenceNumber)
+		}
+	}
+	return rawTxn, nil
+}
+
+func (m *mockTransactionWorkerClient) SubmitTransaction(signedTxn *SignedTransaction) (*api.SubmitTransactionResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	rawTxn := signedTxn.Transaction
+	function := rawTxn.Payload.Payload.(*EntryFunction).Function
+	if m.timeouts[function] > 0 {
+		m.timeouts[function]--
+		return nil, errors.New("timeout")
+	}
+	if m.rejects[function] > 0 {
+		m.rejects[function]--
+		return nil, &HttpError{Status: "400 Bad Request", StatusCode: 400, Message: "rejected"}
+	}
+	// Sequence numbers are reused after an expiry, so hashes are by submission
+	hash := "0x" + strconv.FormatUint(uint64(len(m.submitted)), 16)
+	m.submitted[hash] = rawTxn
+	return &api.SubmitTransactionResponse{Hash: hash}, nil
+}
+
+func (m *mockTransactionWorkerClient) WaitForTransaction(txnHash string, _ ...any) (*api.UserTransaction, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	rawTxn := m.submitted[txnHash]
+	if rawTxn.Payload.Payload.(*EntryFunction).Function == "expire" {
+		return nil, errors.New("transaction expired")
+	}
+	if rawTxn.SequenceNumber+1 > m.onChain {
+		m.onChain = rawTxn.SequenceNumber + 1
+	}
+	success := rawTxn.Payload.Payload.(*EntryFunction).Function != "abort"
+	return &api.UserTransaction{Hash: txnHash, Success: success, SequenceNumber: rawTxn.SequenceNumber, VmStatus: "Move abort"}, nil
+}
+
+func TestTransactionWorker(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	client := &mockTransactionWorkerClient{onChain: 3, submitted: make(map[string]*RawTransaction)}
+
+	worker := NewTransactionWorker(client, sender)
+	worker.Workers = 4
+	worker.SequenceNumbers.MaxOutstanding = 5
+
+	const count = 30
+	payloads := make(chan TransactionBuildPayload)
+	events := worker.Run(payloads)
+	go func() {
+		for i := uint64(0); i < count; i++ {
+			function := "transfer"
+			if i == 7 {
+				function = "abort"
+			}
+			payloads <- TransactionBuildPayload{
+				Id:    i,
+				Type:  TransactionSubmissionTypeSingle,
+				Inner: TransactionPayload{Payload: &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: function}},
+			}
+		}
+		payloads <- TransactionBuildPayload{Id: count, Type: TransactionSubmissionTypeMultiAgent}
+		close(payloads)
+	}()
+
+	submitted := make(map[uint64]bool)
+	committed := make(map[uint64]bool)
+	failed := make(map[uint64]error)
+	sequenceNumbers := make(map[uint64]bool)
+	for event := range events {
+		switch event.Type {
+		case TransactionWorkerEventSubmitted:
+			submitted[event.Id] = true
+			assert.False(t, sequenceNumbers[event.SequenceNumber])
+			sequenceNumbers[event.SequenceNumber] = true
+		case TransactionWorkerEventCommitted:
+			assert.True(t, submitted[event.Id])
+			committed[event.Id] = true
+		case TransactionWorkerEventFailed:
+			failed[event.Id] = event.Err
+		}
+	}
+
+	assert.Len(t, submitted, count)
+	assert.Len(t, committed, count-1)
+	assert.Len(t, failed, 2)
+	assert.Error(t, failed[7])
+	assert.Error(t, failed[count])
+	// Sequence numbers are handed out without gaps, starting from the chain
+	for i := uint64(3); i < 3+count; i++ {
+		assert.True(t, sequenceNumbers[i])
+	}
+}
+
+func TestTransactionWorkerLifecycle(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	client := &mockTransactionWorkerClient{submitted: make(map[string]*RawTransaction)}
+	worker := NewTransactionWorker(client, sender)
+	worker.Workers = 2
+	var _ Lifecycle = worker
+
+	payload := func(id uint64) TransactionBuildPayload {
+		return TransactionBuildPayload{
+			Id:    id,
+			Type:  TransactionSubmissionTypeSingle,
+			Inner: TransactionPayload{Payload: &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: "transfer"}},
+		}
+	}
+	assert.ErrorIs(t, worker.Submit(payload(0)), ErrNotStarted)
+	assert.NoError(t, worker.Start(context.Background()))
+	assert.ErrorIs(t, worker.Start(context.Background()), ErrAlreadyStarted)
+
+	committed := make(map[uint64]bool)
+	read := make(chan struct{})
+	go func() {
+		defer close(read)
+		for event := range worker.Events() {
+			if event.Type == TransactionWorkerEventCommitted {
+				committed[event.Id] = true
+			}
+		}
+	}()
+
+	// Everything queued before closing is committed
+	const count = 10
+	for i := uint64(0); i < count; i++ {
+		assert.NoError(t, worker.Submit(payload(i)))
+	}
+	assert.NoError(t, worker.Close())
+	<-worker.Done()
+	<-read
+	assert.Len(t, committed, count)
+	assert.ErrorIs(t, worker.Submit(payload(count)), ErrClosed)
+	assert.NoError(t, worker.Close())
+
+	// Once cancelled, queued payloads fail instead of being submitted
+	ctx, cancel := context.WithCancel(context.Background())
+	worker = NewTransactionWorker(client, sender)
+	assert.NoError(t, worker.Start(ctx))
+	cancel()
+	err = worker.Submit(payload(0))
+	if err != nil {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+	go func() {
+		assert.NoError(t, worker.Close())
+	}()
+	for event := range worker.Events() {
+		assert.Equal(t, TransactionWorkerEventFailed, event.Type)
+		assert.ErrorIs(t, event.Err, context.Canceled)
+	}
+	<-worker.Done()
+}
+
+func TestTransactionWorkerDeadLetters(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	client := &mockTransactionWorkerClient{
+		submitted: make(map[string]*RawTransaction),
+		rejects:   map[string]int{"flaky": 1, "invalid": 100},
+	}
+	worker := NewTransactionWorker(client, sender)
+	worker.Workers = 1
+	worker.MaxAttempts = 3
+	worker.SequenceNumbers.PollInterval = time.Millisecond
+	worker.SequenceNumbers.MaxWait = 10 * time.Millisecond
+	journal := &bytes.Buffer{}
+	journalSink := NewDeadLetterJournal(journal)
+	letters := make(chan DeadLetter, 10)
+	worker.DeadLetters = DeadLetterFunc(func(letter DeadLetter) error {
+		letters <- letter
+		return journalSink.DeadLetter(letter)
+	})
+
+	payloads := make(chan TransactionBuildPayload)
+	events := worker.Run(payloads)
+	go func() {
+		for i, function := range []string{"transfer", "flaky", "invalid", "abort", "expire"} {
+			payloads <- TransactionBuildPayload{
+				Id:    uint64(i),
+				Type:  TransactionSubmissionTypeSingle,
+				Inner: TransactionPayload{Payload: &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: function, ArgTypes: []TypeTag{}, Args: [][]byte{}}},
+			}
+		}
+		close(payloads)
+	}()
+	committed := make(map[uint64]bool)
+	for event := range events {
+		if event.Type == TransactionWorkerEventCommitted {
+			committed[event.Id] = true
+		}
+	}
+	close(letters)
+
+	// The flaky payload succeeds on retry
+	assert.Equal(t, map[uint64]bool{0: true, 1: true}, committed)
+	received := make(map[uint64]DeadLetter)
+	for letter := range letters {
+		received[letter.Payload.Id] = letter
+	}
+	assert.Len(t, received, 3)
+	assert.Equal(t, DeadLetterRejected, received[2].Reason)
+	assert.Equal(t, 3, received[2].Attempts)
+	assert.Empty(t, received[2].Hash)
+	assert.Equal(t, DeadLetterExecutionFailed, received[3].Reason)
+	assert.Equal(t, 1, received[3].Attempts)
+	assert.NotEmpty(t, received[3].Hash)
+	assert.Equal(t, DeadLetterExpired, received[4].Reason)
+	assert.Equal(t, 1, received[4].Attempts)
+	assert.NotEmpty(t, received[4].Hash)
+	assert.Nil(t, received[4].Transaction)
+
+	// The journal has enough to re-enqueue the payloads
+	journaled, err := ReadDeadLetterJournal(journal)
+	assert.NoError(t, err)
+	assert.Len(t, journaled, 3)
+	for _, letter := range journaled {
+		assert.Equal(t, received[letter.Payload.Id].Reason, letter.Reason)
+		assert.Equal(t, received[letter.Payload.Id].Payload.Inner, letter.Payload.Inner)
+		assert.EqualError(t, letter.Err, received[letter.Payload.Id].Err.Error())
+	}
+}
+
+type blockingTransactionWorkerClient struct {
+	*mockTransactionWorkerClient
+	commit chan struct{}
+}
+
+func (m *blockingTransactionWorkerClient) WaitForTransaction(txnHash string, options ...any) (*api.UserTransaction, error) {
+	<-m.commit
+	return m.mockTransactionWorkerClient.WaitForTransaction(txnHash, options...)
+}
+
+func TestTransactionWorkerRejectedWhileOutstanding(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	client := &blockingTransactionWorkerClient{
+		mockTransactionWorkerClient: &mockTransactionWorkerClient{submitted: make(map[string]*RawTransaction), rejects: map[string]int{"invalid": 1}, timeouts: map[string]int{"timeout": 1}},
+		commit:                      make(chan struct{}),
+	}
+	worker := NewTransactionWorker(client, sender)
+	worker.Workers = 1
+
+	payloads := make(chan TransactionBuildPayload)
+	events := worker.Run(payloads)
+	payload := func(id uint64, function string) TransactionBuildPayload {
+		return TransactionBuildPayload{Id: id, Type: TransactionSubmissionTypeSingle, Inner: TransactionPayload{Payload: &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: function}}}
+	}
+
+	// Nothing commits while the rejected payload fails, and it doesn't wait on the outstanding transaction
+	payloads <- payload(0, "transfer")
+	event := <-events
+	assert.Equal(t, TransactionWorkerEventSubmitted, event.Type)
+	assert.Equal(t, uint64(0), event.SequenceNumber)
+	start := time.Now()
+	payloads <- payload(1, "invalid")
+	event = <-events
+	assert.Equal(t, TransactionWorkerEventFailed, event.Type)
+	assert.Equal(t, uint64(1), event.Id)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The rejected sequence number is handed out again, so there's no gap
+	payloads <- payload(2, "transfer")
+	event = <-events
+	assert.Equal(t, TransactionWorkerEventSubmitted, event.Type)
+	assert.Equal(t, uint64(1), event.SequenceNumber)
+
+	// A submission without a response may have reached the node, so its number isn't handed out again
+	payloads <- payload(3, "timeout")
+	event = <-events
+	assert.Equal(t, TransactionWorkerEventFailed, event.Type)
+	assert.Equal(t, uint64(3), event.Id)
+	assert.Equal(t, uint64(2), event.SequenceNumber)
+	payloads <- payload(4, "transfer")
+	event = <-events
+	assert.Equal(t, TransactionWorkerEventSubmitted, event.Type)
+	assert.Equal(t, uint64(3), event.SequenceNumber)
+
+	close(payloads)
+	close(client.commit)
+	for range events {
+	}
+}
+
+// sequentialTransactionWorkerClient commits transactions in sequence number order, so a transaction that expires holds
+// back the ones after it until its sequence number is used again
+type sequentialTransactionWorkerClient struct {
+	*mockTransactionWorkerClient
+	committed map[string]bool
+	expired   map[string]bool
+}
+
+func (m *sequentialTransactionWorkerClient) WaitForTransaction(txnHash string, _ ...any) (*api.UserTransaction, error) {
+	for start := time.Now(); time.Since(start) < 20*time.Millisecond; time.Sleep(time.Millisecond) {
+		m.mutex.Lock()
+		m.commit()
+		committed := m.committed[txnHash]
+		m.mutex.Unlock()
+		if committed {
+			return &api.UserTransaction{Hash: txnHash, Success: true}, nil
+		}
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.expired[txnHash] = true
+	return nil, errors.New("transaction expired")
+}
+
+// commit commits the pending transactions at the on-chain sequence number, must be called with the lock held
+func (m *sequentialTransactionWorkerClient) commit() {
+	for {
+		next := ""
+		for hash, rawTxn := range m.submitted {
+			if rawTxn.SequenceNumber == m.onChain && !m.expired[hash] && !m.committed[hash] {
+				next = hash
+			}
+		}
+		if next == "" || m.submitted[next].Payload.Payload.(*EntryFunction).Function == "expire" {
+			return
+		}
+		m.committed[next] = true
+		m.onChain++
+	}
+}
+
+func TestTransactionWorkerExpiredGap(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	client := &sequentialTransactionWorkerClient{
+		mockTransactionWorkerClient: &mockTransactionWorkerClient{submitted: make(map[string]*RawTransaction)},
+		committed:                   make(map[string]bool),
+		expired:                     make(map[string]bool),
+	}
+	worker := NewTransactionWorker(client, sender)
+	worker.Workers = 1
+	worker.MaxAttempts = 3
+	worker.SequenceNumbers.MaxOutstanding = 2
+	worker.SequenceNumbers.PollInterval = time.Millisecond
+	worker.SequenceNumbers.MaxWait = 100 * time.Millisecond
+	letters := make(chan DeadLetter, 10)
+	worker.DeadLetters = DeadLetterFunc(func(letter DeadLetter) error {
+		letters <- letter
+		return nil
+	})
+
+	payloads := make(chan TransactionBuildPayload)
+	events := worker.Run(payloads)
+	go func() {
+		for i, function := range []string{"transfer", "expire", "transfer", "transfer", "transfer", "transfer"} {
+			payloads <- TransactionBuildPayload{
+				Id:    uint64(i),
+				Type:  TransactionSubmissionTypeSingle,
+				Inner: TransactionPayload{Payload: &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: function}},
+			}
+		}
+		close(payloads)
+	}()
+	committed := make(map[uint64]uint64)
+	for event := range events {
+		if event.Type == TransactionWorkerEventCommitted {
+			committed[event.Id] = event.SequenceNumber
+		}
+	}
+	close(letters)
+
+	// The expired transaction and the one waiting behind it are dead lettered, and the rest fill the gap
+	reasons := make(map[uint64]DeadLetterReason)
+	for letter := range letters {
+		reasons[letter.Payload.Id] = letter.Reason
+	}
+	assert.Equal(t, map[uint64]DeadLetterReason{1: DeadLetterExpired, 2: DeadLetterExpired}, reasons)
+	assert.Equal(t, map[uint64]uint64{0: 0, 3: 1, 4: 2, 5: 3}, committed)
+}
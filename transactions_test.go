@@ -2,6 +2,7 @@ package aptos
 
 import (
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -58,3 +59,188 @@ func TestTPMarshal(t *testing.T) {
 	// without a payload, it should fail
 	assert.Error(t, ser.Error())
 }
+
+func TestFeePayerRawTransaction(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	sponsor, err := NewEd25519Account()
+	assert.NoError(t, err)
+	other, err := NewEd25519Account()
+	assert.NoError(t, err)
+
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     sender.Address,
+		SequenceNumber:             1,
+		Payload:                    TransactionPayload{Payload: payload},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1714158778,
+		ChainId:                    4,
+	}
+
+	// The sender signs before knowing the sponsor
+	txn := NewFeePayerRawTransaction(rawTxn, AccountZero)
+	senderAuth, err := txn.SignAsSender(sender)
+	assert.NoError(t, err)
+
+	// Only the sender can sign as the sender
+	_, err = txn.SignAsSender(other)
+	assert.Error(t, err)
+
+	// The sponsor fills itself in
+	sponsorAuth, err := txn.SignAsFeePayer(sponsor)
+	assert.NoError(t, err)
+	feePayer, ok := txn.FeePayer()
+	assert.True(t, ok)
+	assert.Equal(t, sponsor.Address, feePayer)
+
+	// Now that the fee payer is set, no one else can sign as it
+	_, err = txn.SignAsFeePayer(other)
+	assert.Error(t, err)
+
+	message, err := txn.SigningMessage()
+	assert.NoError(t, err)
+	assert.True(t, sponsorAuth.Verify(message))
+
+	signedTxn, ok := txn.ToFeePayerSignedTransaction(senderAuth, sponsorAuth, []crypto.AccountAuthenticator{})
+	assert.True(t, ok)
+	assert.Equal(t, TransactionAuthenticatorFeePayer, signedTxn.Authenticator.Variant)
+
+	// Multi-agent transactions are not fee payer transactions
+	multiAgent := NewMultiAgentRawTransaction(rawTxn, other.Address)
+	_, ok = multiAgent.FeePayer()
+	assert.False(t, ok)
+	_, err = multiAgent.SignAsFeePayer(sponsor)
+	assert.Error(t, err)
+	_, err = multiAgent.SignAsSender(other)
+	assert.NoError(t, err)
+}
+
+func TestMultiEd25519RawTransactionSign(t *testing.T) {
+	keys := make([]*crypto.Ed25519PrivateKey, 3)
+	pubKeys := make([]*crypto.Ed25519PublicKey, 3)
+	for i := range keys {
+		key, err := crypto.GenerateEd25519PrivateKey()
+		assert.NoError(t, err)
+		keys[i] = key
+		pubKeys[i] = key.PubKey().(*crypto.Ed25519PublicKey)
+	}
+	publicKey, err := crypto.NewMultiEd25519PublicKey(pubKeys, 2)
+	assert.NoError(t, err)
+	signer, err := crypto.NewMultiEd25519Signer(publicKey, keys[1], keys[2])
+	assert.NoError(t, err)
+	sender, err := NewAccountFromSigner(signer)
+	assert.NoError(t, err)
+
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	txn := RawTransaction{
+		Sender:                     sender.Address,
+		SequenceNumber:             1,
+		Payload:                    TransactionPayload{Payload: payload},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1714158778,
+		ChainId:                    4,
+	}
+	signedTxn, err := txn.SignedTransaction(sender)
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionAuthenticatorMultiEd25519, signedTxn.Authenticator.Variant)
+	assert.NoError(t, signedTxn.Verify())
+
+	// The authenticator round trips through BCS without an account authenticator variant
+	txnBytes, err := bcs.Serialize(signedTxn)
+	assert.NoError(t, err)
+	signedTxn2 := &SignedTransaction{}
+	assert.NoError(t, bcs.Deserialize(signedTxn2, txnBytes))
+	assert.NoError(t, signedTxn2.Verify())
+	txnBytes2, err := bcs.Serialize(signedTxn2)
+	assert.NoError(t, err)
+	assert.Equal(t, txnBytes, txnBytes2)
+}
+
+func TestVerifySignedTransaction(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	secondary, err := NewSecp256k1Account()
+	assert.NoError(t, err)
+	sponsor, err := NewEd25519SingleSenderAccount()
+	assert.NoError(t, err)
+
+	payload, err := CoinTransferPayload(nil, AccountOne, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     sender.Address,
+		SequenceNumber:             1,
+		Payload:                    TransactionPayload{Payload: payload},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1714158778,
+		ChainId:                    4,
+	}
+
+	// Multi-agent signers sign the multi-agent message, not the raw transaction
+	multiAgent := NewMultiAgentRawTransaction(rawTxn, secondary.Address)
+	senderAuth, err := multiAgent.SignAsSender(sender)
+	assert.NoError(t, err)
+	secondaryAuth, err := multiAgent.SignAsSender(secondary)
+	assert.NoError(t, err)
+	signedTxn, ok := multiAgent.ToMultiAgentSignedTransaction(senderAuth, []crypto.AccountAuthenticator{*secondaryAuth})
+	assert.True(t, ok)
+	assert.NoError(t, VerifySignedTransaction(signedTxn))
+	assert.NoError(t, signedTxn.Verify())
+
+	// A secondary signer signing the wrong message is caught
+	wrongAuth, err := rawTxn.Sign(secondary)
+	assert.NoError(t, err)
+	signedTxn, _ = multiAgent.ToMultiAgentSignedTransaction(senderAuth, []crypto.AccountAuthenticator{*wrongAuth})
+	err = VerifySignedTransaction(signedTxn)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+	assert.ErrorContains(t, err, "secondary signer 0")
+
+	// Missing secondary signers are caught
+	signedTxn, _ = multiAgent.ToMultiAgentSignedTransaction(senderAuth, []crypto.AccountAuthenticator{})
+	assert.Error(t, VerifySignedTransaction(signedTxn))
+
+	// The sender may sign a fee payer transaction before the fee payer is known
+	feePayerTxn := NewFeePayerRawTransaction(rawTxn, AccountZero, secondary.Address)
+	senderAuth, err = feePayerTxn.SignAsSender(sender)
+	assert.NoError(t, err)
+	sponsorAuth, err := feePayerTxn.SignAsFeePayer(sponsor)
+	assert.NoError(t, err)
+	// Or after
+	secondaryAuth, err = feePayerTxn.SignAsSender(secondary)
+	assert.NoError(t, err)
+	signedTxn, ok = feePayerTxn.ToFeePayerSignedTransaction(senderAuth, sponsorAuth, []crypto.AccountAuthenticator{*secondaryAuth})
+	assert.True(t, ok)
+	assert.NoError(t, VerifySignedTransaction(signedTxn))
+
+	// The fee payer must sign over its own address
+	unsetFeePayerTxn := NewFeePayerRawTransaction(rawTxn, AccountZero, secondary.Address)
+	wrongSponsorAuth, err := unsetFeePayerTxn.Sign(sponsor)
+	assert.NoError(t, err)
+	signedTxn, _ = feePayerTxn.ToFeePayerSignedTransaction(senderAuth, wrongSponsorAuth, []crypto.AccountAuthenticator{*secondaryAuth})
+	err = VerifySignedTransaction(signedTxn)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+	assert.ErrorContains(t, err, "fee payer")
+
+	// Tampering with the transaction invalidates every signature
+	signedTxn, _ = feePayerTxn.ToFeePayerSignedTransaction(senderAuth, sponsorAuth, []crypto.AccountAuthenticator{*secondaryAuth})
+	tampered := *rawTxn
+	tampered.MaxGasAmount = 1_000_000
+	signedTxn.Transaction = &tampered
+	err = VerifySignedTransaction(signedTxn)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+	assert.ErrorContains(t, err, "sender")
+
+	// Single signer transactions sign the raw transaction
+	signedTxn, err = rawTxn.SignedTransaction(sponsor)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifySignedTransaction(signedTxn))
+	signedTxn.Transaction = &tampered
+	assert.ErrorIs(t, VerifySignedTransaction(signedTxn), ErrInvalidSignature)
+
+	assert.Error(t, VerifySignedTransaction(&SignedTransaction{}))
+}
@@ -0,0 +1,421 @@
+package aptos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// TransferEdge is a single movement of funds between two accounts, derived from the withdraw and deposit events of a
+// transaction
+type TransferEdge struct {
+	From            AccountAddress `json:"from"`             // From is the account funds were withdrawn from
+	To              AccountAddress `json:"to"`               // To is the account funds were deposited to
+	Asset           string         `json:"asset"`            // Asset is the coin type, or the fungible asset metadata address.  Empty if it couldn't be determined
+	Amount          uint64         `json:"amount,string"`    // Amount is the amount moved in the smallest unit of the asset
+	Version         uint64         `json:"version,string"`   // Version is the ledger version of the transaction
+	TransactionHash string         `json:"transaction_hash"` // TransactionHash is the hash of the transaction
+}
+
+// TransferNode is an account in a [TransferGraph]
+type TransferNode struct {
+	Address AccountAddress `json:"address"` // Address of the account
+	Hop     int            `json:"hop"`     // Hop is the number of hops from the start of the trace, starting accounts are 0
+}
+
+// TransferGraph is a graph of fund flows between accounts.  It can be exported with [TransferGraph.WriteDOT] or as JSON
+// with [TransferGraph.WriteJSON] or [json.Marshal].
+type TransferGraph struct {
+	Nodes []TransferNode `json:"nodes"`
+	Edges []TransferEdge `json:"edges"`
+
+	nodeIndex map[AccountAddress]int
+}
+
+// AddNode adds an account to the graph, keeping the lowest hop if it already exists
+func (g *TransferGraph) AddNode(address AccountAddress, hop int) {
+	if g.nodeIndex == nil {
+		g.nodeIndex = make(map[AccountAddress]int)
+		for i, node := range g.Nodes {
+			g.nodeIndex[node.Address] = i
+		}
+	}
+	if i, ok := g.nodeIndex[address]; ok {
+		if hop < g.Nodes[i].Hop {
+			g.Nodes[i].Hop = hop
+		}
+		return
+	}
+	g.nodeIndex[address] = len(g.Nodes)
+	g.Nodes = append(g.Nodes, TransferNode{Address: address, Hop: hop})
+}
+
+// WriteJSON writes the graph as indented JSON
+func (g *TransferGraph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g)
+}
+
+// WriteDOT writes the graph in the Graphviz DOT format, e.g. for rendering with `dot -Tsvg`
+func (g *TransferGraph) WriteDOT(w io.Writer) error {
+	b := &strings.Builder{}
+	b.WriteString("digraph transfers {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(b, "  %q [label=%q];\n", node.Address.String(), fmt.Sprintf("%s\nhop %d", node.Address.String(), node.Hop))
+	}
+	for _, edge := range g.Edges {
+		label := fmt.Sprintf("%d %s\nv%d", edge.Amount, edge.Asset, edge.Version)
+		fmt.Fprintf(b, "  %q -> %q [label=%q];\n", edge.From.String(), edge.To.String(), label)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// TransferTracerClient is the subset of [Client] used by [TransferTracer]
+type TransferTracerClient interface {
+	AccountTransactions(address AccountAddress, start *uint64, limit *uint64) (data []*api.CommittedTransaction, err error)
+	TransactionByHash(txnHash string) (data *api.Transaction, err error)
+}
+
+// TransferTracer follows outgoing transfers across accounts to build a [TransferGraph]
+//
+// Only transactions sent by each account are scanned, as the node API does not index incoming transfers.  Funds
+// deposited into an account by a transaction it didn't send will show up as an edge from the sender's side only.
+type TransferTracer struct {
+	Client           TransferTracerClient // Client used to fetch transactions
+	Hops             int                  // Hops is the number of transfers to follow from the start, defaults to 1
+	TransactionLimit uint64               // TransactionLimit is the number of transactions fetched per account, 0 uses the node default
+}
+
+// FromAddress traces outgoing transfers starting with the transactions sent by address
+func (t *TransferTracer) FromAddress(address AccountAddress) (*TransferGraph, error) {
+	graph := &TransferGraph{}
+	graph.AddNode(address, 0)
+	err := t.trace(graph, []AccountAddress{address}, 0, map[uint64]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// FromTransaction traces outgoing transfers starting with the transfers in the transaction with the given hash
+func (t *TransferTracer) FromTransaction(txnHash string) (*TransferGraph, error) {
+	txn, err := t.Client.TransactionByHash(txnHash)
+	if err != nil {
+		return nil, err
+	}
+	userTxn, err := txn.UserTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &TransferGraph{}
+	seen := map[uint64]bool{userTxn.Version: true}
+	var frontier []AccountAddress
+	for _, edge := range TransfersFromTransaction(userTxn) {
+		graph.AddNode(edge.From, 0)
+		graph.AddNode(edge.To, 1)
+		graph.Edges = append(graph.Edges, edge)
+		frontier = append(frontier, edge.To)
+	}
+	err = t.trace(graph, frontier, 1, seen)
+	if err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// trace does a breadth first walk from frontier, which is at the given hop
+func (t *TransferTracer) trace(graph *TransferGraph, frontier []AccountAddress, hop int, seen map[uint64]bool) error {
+	if t.Client == nil {
+		return errors.New("transfer tracer has no client")
+	}
+	hops := t.Hops
+	if hops <= 0 {
+		hops = 1
+	}
+	var limit *uint64
+	if t.TransactionLimit > 0 {
+		limit = &t.TransactionLimit
+	}
+
+	visited := make(map[AccountAddress]bool)
+	for ; hop < hops && len(frontier) > 0; hop++ {
+		var next []AccountAddress
+		for _, address := range frontier {
+			if visited[address] {
+				continue
+			}
+			visited[address] = true
+
+			txns, err := t.Client.AccountTransactions(address, nil, limit)
+			if err != nil {
+				return fmt.Errorf("failed to fetch transactions for %s: %w", address.String(), err)
+			}
+			for _, txn := range txns {
+				userTxn, err := txn.UserTransaction()
+				if err != nil {
+					// Only user transactions move funds between accounts
+					continue
+				}
+				if seen[userTxn.Version] {
+					continue
+				}
+				seen[userTxn.Version] = true
+				for _, edge := range TransfersFromTransaction(userTxn) {
+					if edge.From != address {
+						continue
+					}
+					graph.AddNode(edge.To, hop+1)
+					graph.Edges = append(graph.Edges, edge)
+					next = append(next, edge.To)
+				}
+			}
+		}
+		frontier = next
+	}
+	return nil
+}
+
+// transferLeg is one side of a transfer, either a withdrawal or a deposit
+type transferLeg struct {
+	account AccountAddress
+	asset   string
+	amount  uint64
+}
+
+// TransfersFromTransaction derives [TransferEdge]s from the withdraw and deposit events of a successful transaction
+//
+// Coin events (0x1::coin::WithdrawEvent, 0x1::coin::CoinWithdraw and their deposit counterparts) and fungible asset
+// events (0x1::fungible_asset::Withdraw and Deposit) are supported.  Fungible store addresses are resolved to their
+// owner and metadata from the transaction's write set when possible.
+//
+// Events only record one side of each transfer, so they are paired per asset: a single withdrawal fans out to every
+// deposit, many withdrawals converge on a single deposit, and otherwise withdrawals and deposits of equal amounts are
+// matched.  Transfers that can't be paired unambiguously are left out.
+func TransfersFromTransaction(txn *api.UserTransaction) []TransferEdge {
+	if txn == nil || !txn.Success {
+		return nil
+	}
+	stores := fungibleStoresFromChanges(txn.Changes)
+
+	var withdrawals, deposits []transferLeg
+	var assets []string
+	addAsset := func(asset string) {
+		for _, a := range assets {
+			if a == asset {
+				return
+			}
+		}
+		assets = append(assets, asset)
+	}
+	for _, event := range txn.Events {
+		leg, isWithdraw, ok := transferLegFromEvent(event, stores)
+		if !ok {
+			continue
+		}
+		if isWithdraw {
+			withdrawals = append(withdrawals, leg)
+		} else {
+			deposits = append(deposits, leg)
+		}
+	}
+
+	// V1 coin events and stores missing from the write set have no asset, if only one asset moved, it must be that one
+	for _, leg := range append(withdrawals, deposits...) {
+		if leg.asset != "" {
+			addAsset(leg.asset)
+		}
+	}
+	if len(assets) <= 1 {
+		known := ""
+		if len(assets) == 1 {
+			known = assets[0]
+		}
+		for i := range withdrawals {
+			withdrawals[i].asset = known
+		}
+		for i := range deposits {
+			deposits[i].asset = known
+		}
+		assets = []string{known}
+	} else {
+		addAsset("")
+	}
+
+	var edges []TransferEdge
+	for _, asset := range assets {
+		for _, pair := range pairTransferLegs(filterLegs(withdrawals, asset), filterLegs(deposits, asset)) {
+			if pair.from == pair.to {
+				continue
+			}
+			edges = append(edges, TransferEdge{
+				From:            pair.from,
+				To:              pair.to,
+				Asset:           asset,
+				Amount:          pair.amount,
+				Version:         txn.Version,
+				TransactionHash: txn.Hash,
+			})
+		}
+	}
+	return edges
+}
+
+type transferPair struct {
+	from   AccountAddress
+	to     AccountAddress
+	amount uint64
+}
+
+func filterLegs(legs []transferLeg, asset string) []transferLeg {
+	var out []transferLeg
+	for _, leg := range legs {
+		if leg.asset == asset {
+			out = append(out, leg)
+		}
+	}
+	return out
+}
+
+func pairTransferLegs(withdrawals []transferLeg, deposits []transferLeg) []transferPair {
+	var pairs []transferPair
+	switch {
+	case len(withdrawals) == 0 || len(deposits) == 0:
+		return nil
+	case len(withdrawals) == 1:
+		for _, deposit := range deposits {
+			pairs = append(pairs, transferPair{withdrawals[0].account, deposit.account, deposit.amount})
+		}
+	case len(deposits) == 1:
+		for _, withdrawal := range withdrawals {
+			pairs = append(pairs, transferPair{withdrawal.account, deposits[0].account, withdrawal.amount})
+		}
+	default:
+		used := make([]bool, len(deposits))
+		for _, withdrawal := range withdrawals {
+			for i, deposit := range deposits {
+				if !used[i] && deposit.amount == withdrawal.amount {
+					used[i] = true
+					pairs = append(pairs, transferPair{withdrawal.account, deposit.account, deposit.amount})
+					break
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+// fungibleStoreInfo is the owner and metadata of a fungible store, as found in a write set
+type fungibleStoreInfo struct {
+	owner    *AccountAddress
+	metadata string
+}
+
+func fungibleStoresFromChanges(changes []*api.WriteSetChange) map[AccountAddress]*fungibleStoreInfo {
+	stores := make(map[AccountAddress]*fungibleStoreInfo)
+	for _, change := range changes {
+		if change == nil || change.Type != api.WriteSetChangeVariantWriteResource {
+			continue
+		}
+		write, ok := change.Inner.(*api.WriteSetChangeWriteResource)
+		if !ok || write.Address == nil || write.Data == nil {
+			continue
+		}
+		info := stores[*write.Address]
+		if info == nil {
+			info = &fungibleStoreInfo{}
+		}
+		switch write.Data.Type {
+		case ObjectCoreResourceType:
+			owner, ok := write.Data.Data["owner"].(string)
+			if !ok {
+				continue
+			}
+			address := &AccountAddress{}
+			if address.ParseStringRelaxed(owner) != nil {
+				continue
+			}
+			info.owner = address
+		case FungibleStoreResourceType:
+			metadata, ok := write.Data.Data["metadata"].(map[string]any)
+			if !ok {
+				continue
+			}
+			inner, ok := metadata["inner"].(string)
+			if !ok {
+				continue
+			}
+			metadataAddress := AccountAddress{}
+			if metadataAddress.ParseStringRelaxed(inner) != nil {
+				continue
+			}
+			info.metadata = metadataAddress.String()
+		default:
+			continue
+		}
+		stores[*write.Address] = info
+	}
+	return stores
+}
+
+// transferLegFromEvent parses a withdraw or deposit event, returning false if the event isn't one
+func transferLegFromEvent(event *api.Event, stores map[AccountAddress]*fungibleStoreInfo) (leg transferLeg, isWithdraw bool, ok bool) {
+	if event == nil {
+		return
+	}
+	amountStr, ok := event.Data["amount"].(string)
+	if !ok {
+		return
+	}
+	amount, err := StrToUint64(amountStr)
+	if err != nil {
+		return leg, false, false
+	}
+	leg.amount = amount
+
+	parseAddress := func(field string) bool {
+		str, ok := event.Data[field].(string)
+		if !ok {
+			return false
+		}
+		return leg.account.ParseStringRelaxed(str) == nil
+	}
+
+	switch {
+	case event.Type == "0x1::coin::WithdrawEvent" || event.Type == "0x1::coin::DepositEvent":
+		// V1 events are emitted on the account's event handle, and don't carry the coin type
+		if event.Guid == nil || event.Guid.AccountAddress == nil {
+			return leg, false, false
+		}
+		leg.account = *event.Guid.AccountAddress
+		isWithdraw = event.Type == "0x1::coin::WithdrawEvent"
+	case event.Type == "0x1::coin::CoinWithdraw" || event.Type == "0x1::coin::CoinDeposit":
+		if !parseAddress("account") {
+			return leg, false, false
+		}
+		leg.asset, _ = event.Data["coin_type"].(string)
+		isWithdraw = event.Type == "0x1::coin::CoinWithdraw"
+	case event.Type == "0x1::fungible_asset::Withdraw" || event.Type == "0x1::fungible_asset::Deposit":
+		if !parseAddress("store") {
+			return leg, false, false
+		}
+		if info, found := stores[leg.account]; found {
+			leg.asset = info.metadata
+			if info.owner != nil {
+				leg.account = *info.owner
+			}
+		}
+		isWithdraw = event.Type == "0x1::fungible_asset::Withdraw"
+	default:
+		return leg, false, false
+	}
+	return leg, isWithdraw, true
+}
@@ -0,0 +1,120 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTransferTracerClient struct {
+	byAccount map[AccountAddress][]*api.CommittedTransaction
+	byHash    map[string]*api.Transaction
+}
+
+func (m *mockTransferTracerClient) AccountTransactions(address AccountAddress, _ *uint64, _ *uint64) ([]*api.CommittedTransaction, error) {
+	return m.byAccount[address], nil
+}
+
+func (m *mockTransferTracerClient) TransactionByHash(txnHash string) (*api.Transaction, error) {
+	return m.byHash[txnHash], nil
+}
+
+func testTransferTransaction(t *testing.T, version uint64, hash string, events []map[string]any, changes []map[string]any) *api.UserTransaction {
+	t.Helper()
+	txn := &api.UserTransaction{Version: version, Hash: hash, Success: true}
+	eventJson, err := json.Marshal(events)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(eventJson, &txn.Events))
+	changeJson, err := json.Marshal(changes)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(changeJson, &txn.Changes))
+	return txn
+}
+
+func TestTransfersFromTransaction(t *testing.T) {
+	aliceStore := "0xa1"
+	bobStore := "0xb1"
+	txn := testTransferTransaction(t, 10, "0x10", []map[string]any{
+		{"type": "0x1::fungible_asset::Withdraw", "guid": map[string]any{"creation_number": "0", "account_address": "0x0"}, "sequence_number": "0", "data": map[string]any{"store": aliceStore, "amount": "100"}},
+		{"type": "0x1::fungible_asset::Deposit", "guid": map[string]any{"creation_number": "0", "account_address": "0x0"}, "sequence_number": "0", "data": map[string]any{"store": bobStore, "amount": "100"}},
+		{"type": "0x1::transaction_fee::FeeStatement", "guid": map[string]any{"creation_number": "0", "account_address": "0x0"}, "sequence_number": "0", "data": map[string]any{"total_charge_gas_units": "5"}},
+	}, []map[string]any{
+		{"type": "write_resource", "address": aliceStore, "state_key_hash": "0x0", "data": map[string]any{"type": "0x1::object::ObjectCore", "data": map[string]any{"owner": "0xa"}}},
+		{"type": "write_resource", "address": aliceStore, "state_key_hash": "0x0", "data": map[string]any{"type": "0x1::fungible_asset::FungibleStore", "data": map[string]any{"metadata": map[string]any{"inner": "0xa"}}}},
+		{"type": "write_resource", "address": bobStore, "state_key_hash": "0x0", "data": map[string]any{"type": "0x1::object::ObjectCore", "data": map[string]any{"owner": "0xb"}}},
+	})
+
+	edges := TransfersFromTransaction(txn)
+	assert.Len(t, edges, 1)
+	alice := AccountAddress{}
+	assert.NoError(t, alice.ParseStringRelaxed("0xa"))
+	bob := AccountAddress{}
+	assert.NoError(t, bob.ParseStringRelaxed("0xb"))
+	assert.Equal(t, TransferEdge{From: alice, To: bob, Asset: "0xa", Amount: 100, Version: 10, TransactionHash: "0x10"}, edges[0])
+
+	// Failed transactions don't move funds
+	txn.Success = false
+	assert.Empty(t, TransfersFromTransaction(txn))
+}
+
+func TestTransferTracer(t *testing.T) {
+	alice := AccountAddress{}
+	assert.NoError(t, alice.ParseStringRelaxed("0xa"))
+	bob := AccountAddress{}
+	assert.NoError(t, bob.ParseStringRelaxed("0xb"))
+	carol := AccountAddress{}
+	assert.NoError(t, carol.ParseStringRelaxed("0xc"))
+
+	coinEvents := func(from, to string, amount string) []map[string]any {
+		return []map[string]any{
+			{"type": "0x1::coin::CoinWithdraw", "guid": map[string]any{"creation_number": "0", "account_address": "0x0"}, "sequence_number": "0", "data": map[string]any{"account": from, "amount": amount, "coin_type": "0x1::aptos_coin::AptosCoin"}},
+			{"type": "0x1::coin::CoinDeposit", "guid": map[string]any{"creation_number": "0", "account_address": "0x0"}, "sequence_number": "0", "data": map[string]any{"account": to, "amount": amount, "coin_type": "0x1::aptos_coin::AptosCoin"}},
+		}
+	}
+	aliceToBob := testTransferTransaction(t, 1, "0x1", coinEvents("0xa", "0xb", "50"), nil)
+	bobToCarol := testTransferTransaction(t, 2, "0x2", coinEvents("0xb", "0xc", "20"), nil)
+
+	client := &mockTransferTracerClient{
+		byAccount: map[AccountAddress][]*api.CommittedTransaction{
+			alice: {{Type: api.TransactionVariantUser, Inner: aliceToBob}},
+			bob:   {{Type: api.TransactionVariantUser, Inner: bobToCarol}},
+		},
+		byHash: map[string]*api.Transaction{
+			"0x1": {Type: api.TransactionVariantUser, Inner: aliceToBob},
+		},
+	}
+
+	// One hop only sees alice's transfer
+	tracer := &TransferTracer{Client: client, Hops: 1}
+	graph, err := tracer.FromAddress(alice)
+	assert.NoError(t, err)
+	assert.Len(t, graph.Edges, 1)
+	assert.Equal(t, []TransferNode{{alice, 0}, {bob, 1}}, graph.Nodes)
+
+	// Two hops follow the funds to carol
+	tracer.Hops = 2
+	graph, err = tracer.FromAddress(alice)
+	assert.NoError(t, err)
+	assert.Len(t, graph.Edges, 2)
+	assert.Equal(t, []TransferNode{{alice, 0}, {bob, 1}, {carol, 2}}, graph.Nodes)
+
+	graph, err = tracer.FromTransaction("0x1")
+	assert.NoError(t, err)
+	assert.Len(t, graph.Edges, 2)
+	assert.Equal(t, []TransferNode{{alice, 0}, {bob, 1}, {carol, 2}}, graph.Nodes)
+
+	dot := &bytes.Buffer{}
+	assert.NoError(t, graph.WriteDOT(dot))
+	assert.Contains(t, dot.String(), "\"0xa\" -> \"0xb\"")
+	assert.Contains(t, dot.String(), "\"0xb\" -> \"0xc\"")
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, graph.WriteJSON(out))
+	decoded := &TransferGraph{}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), decoded))
+	assert.Equal(t, graph.Edges, decoded.Edges)
+	assert.Equal(t, graph.Nodes, decoded.Nodes)
+}
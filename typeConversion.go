@@ -1,9 +1,13 @@
 package aptos
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/api"
+	"math"
 	"math/big"
+	"reflect"
 	"strconv"
 
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
@@ -54,30 +58,19 @@ func EntryFunctionFromAbi(abi any, moduleAddress AccountAddress, moduleName stri
 
 	// Convert string types to actual types
 	argTypes := make([]TypeTag, 0)
+	leadingSigners := true
 	for _, typeStr := range function.Params {
 		typeArg, err := ParseTypeTag(typeStr)
 		if err != nil {
 			return nil, err
 		}
 
-		// If it's `signer` or `&signer` need to skip
-		// TODO: only skip at the beginning
-		switch typeArg.Value.(type) {
-		case *SignerTag:
-			// Skip
+		// If it's `signer` or `&signer` at the beginning, it's filled in by the transaction signers, so skip it
+		if leadingSigners && isSignerTypeTag(typeArg) {
 			continue
-		case *ReferenceTag:
-			innerArg := typeArg.Value.(*ReferenceTag)
-			switch innerArg.TypeParam.Value.(type) {
-			case *SignerTag:
-				// Skip
-				continue
-			default:
-				argTypes = append(argTypes, *typeArg)
-			}
-		default:
-			argTypes = append(argTypes, *typeArg)
 		}
+		leadingSigners = false
+		argTypes = append(argTypes, *typeArg)
 	}
 
 	// Check args length matches
@@ -87,9 +80,9 @@ func EntryFunctionFromAbi(abi any, moduleAddress AccountAddress, moduleName stri
 
 	convertedArgs := make([][]byte, len(args))
 	for i, arg := range args {
-		b, err := ConvertArg(argTypes[i], arg, argTypes)
+		b, err := ConvertArg(argTypes[i], arg, convertedTypeArgs)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("entry function %s argument %d: %w", functionName, i, err)
 		}
 		convertedArgs[i] = b
 	}
@@ -107,6 +100,84 @@ func EntryFunctionFromAbi(abi any, moduleAddress AccountAddress, moduleName stri
 	return entry, err
 }
 
+// isSignerTypeTag tells if the type is `signer` or `&signer`
+func isSignerTypeTag(typeArg *TypeTag) bool {
+	switch inner := typeArg.Value.(type) {
+	case *SignerTag:
+		return true
+	case *ReferenceTag:
+		_, ok := inner.TypeParam.Value.(*SignerTag)
+		return ok
+	default:
+		return false
+	}
+}
+
+// NewEntryFunction builds an [EntryFunction] from Go native arguments, encoding each with BCS according to the declared
+// parameter types e.g. "address", "u64", "vector<u8>", "0x1::string::String".  This is the same as
+// [EntryFunctionFromAbi], but without needing to fetch or write the full ABI.
+//
+// Leading signer parameters may be included or left out.  Type arguments may be [TypeTag], *[TypeTag], or string.
+//
+//	entry, err := NewEntryFunction(ModuleId{Address: AccountOne, Name: "aptos_account"}, "transfer",
+//		nil, []string{"address", "u64"}, []any{receiver, uint64(100)})
+func NewEntryFunction(module ModuleId, function string, typeArgs []any, paramTypes []string, args []any) (*EntryFunction, error) {
+	genericTypeParams := make([]*api.GenericTypeParam, len(typeArgs))
+	for i := range genericTypeParams {
+		genericTypeParams[i] = &api.GenericTypeParam{}
+	}
+	abi := &api.MoveFunction{
+		Name:              function,
+		IsEntry:           true,
+		GenericTypeParams: genericTypeParams,
+		Params:            paramTypes,
+	}
+	return EntryFunctionFromAbi(abi, module.Address, module.Name, function, typeArgs, args)
+}
+
+// decodeJsonArg decodes a [json.RawMessage] argument, keeping numbers as [json.Number] so large integers are exact.
+// Already decoded float64 numbers are converted to [json.Number] only if they are exact integers.
+func decodeJsonArg(arg any) (any, error) {
+	switch value := arg.(type) {
+	case json.RawMessage:
+		decoder := json.NewDecoder(bytes.NewReader(value))
+		decoder.UseNumber()
+		var out any
+		err := decoder.Decode(&out)
+		if err != nil {
+			return nil, err
+		}
+		return out, nil
+	case float64:
+		if value != math.Trunc(value) || math.Abs(value) > 1<<53 {
+			return nil, fmt.Errorf("cannot convert %v to an integer exactly, use a string instead", value)
+		}
+		return json.Number(strconv.FormatFloat(value, 'f', -1, 64)), nil
+	case []any:
+		out := make([]any, len(value))
+		for i, item := range value {
+			converted, err := decodeJsonArg(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = converted
+		}
+		return out, nil
+	case map[string]any:
+		out := make(map[string]any, len(value))
+		for key, item := range value {
+			converted, err := decodeJsonArg(item)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = converted
+		}
+		return out, nil
+	default:
+		return arg, nil
+	}
+}
+
 func ConvertTypeTag(typeArg any) (*TypeTag, error) {
 	switch typeArg.(type) {
 	case TypeTag:
@@ -126,186 +197,128 @@ func ConvertTypeTag(typeArg any) (*TypeTag, error) {
 	}
 }
 
-func ConvertToU8(arg any) (*uint8, error) {
-	var num uint8
-	switch arg.(type) {
+// convertToUint converts any Go integer, [big.Int], or decimal string to an unsigned integer of the given bits, erroring
+// on negative numbers or overflow rather than silently truncating
+func convertToUint(arg any, bits int, typeName string) (uint64, error) {
+	num, err := convertToBigUint(arg, bits, typeName)
+	if err != nil {
+		return 0, err
+	}
+	return num.Uint64(), nil
+}
+
+// convertToBigUint converts any Go integer, [big.Int], [bcs.Uint128], [bcs.Uint256], or decimal string to an unsigned [big.Int] that fits in the given bits
+func convertToBigUint(arg any, bits int, typeName string) (*big.Int, error) {
+	var num *big.Int
+	switch value := arg.(type) {
 	case int:
-		num = uint8(arg.(int))
+		num = big.NewInt(int64(value))
+	case int8:
+		num = big.NewInt(int64(value))
+	case int16:
+		num = big.NewInt(int64(value))
+	case int32:
+		num = big.NewInt(int64(value))
+	case int64:
+		num = big.NewInt(value)
 	case uint:
-		num = uint8(arg.(uint))
+		num = new(big.Int).SetUint64(uint64(value))
 	case uint8:
-		num = arg.(uint8)
+		num = new(big.Int).SetUint64(uint64(value))
+	case uint16:
+		num = new(big.Int).SetUint64(uint64(value))
+	case uint32:
+		num = new(big.Int).SetUint64(uint64(value))
+	case uint64:
+		num = new(big.Int).SetUint64(value)
 	case big.Int:
-		b := arg.(big.Int)
-		num = uint8(b.Uint64())
+		num = &value
 	case *big.Int:
-		b := arg.(*big.Int)
-		if b == nil {
-			return nil, fmt.Errorf("cannot convert to uint8, input is nil")
-		}
-		num = uint8(b.Uint64())
+		if value == nil {
+			return nil, fmt.Errorf("cannot convert to %s, input is nil", typeName)
+		}
+		num = value
+	case bcs.Uint128:
+		num = value.BigInt()
+	case *bcs.Uint128:
+		if value == nil {
+			return nil, fmt.Errorf("cannot convert to %s, input is nil", typeName)
+		}
+		num = value.BigInt()
+	case bcs.Uint256:
+		num = value.BigInt()
+	case *bcs.Uint256:
+		if value == nil {
+			return nil, fmt.Errorf("cannot convert to %s, input is nil", typeName)
+		}
+		num = value.BigInt()
 	case string:
-		// Convert the number
-		u64, err := strconv.ParseUint(arg.(string), 10, 8)
+		parsed, err := util.StrToBigInt(value)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("cannot convert %#v to %s: %w", value, typeName, err)
+		}
+		num = parsed
+	case json.Number:
+		parsed, err := util.StrToBigInt(value.String())
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %s to %s: %w", value.String(), typeName, err)
 		}
-		num = uint8(u64)
+		num = parsed
 	default:
-		return nil, fmt.Errorf("invalid input type for uint8")
+		return nil, fmt.Errorf("invalid input type %T for %s", arg, typeName)
 	}
 
+	if num.Sign() < 0 {
+		return nil, fmt.Errorf("cannot convert %s to %s, it is negative", num.String(), typeName)
+	}
+	if num.BitLen() > bits {
+		return nil, fmt.Errorf("cannot convert %s to %s, it overflows", num.String(), typeName)
+	}
+	return num, nil
+}
+
+func ConvertToU8(arg any) (*uint8, error) {
+	value, err := convertToUint(arg, 8, "uint8")
+	if err != nil {
+		return nil, err
+	}
+	num := uint8(value)
 	return &num, nil
 }
 
 func ConvertToU16(arg any) (*uint16, error) {
-	var num uint16
-	switch arg.(type) {
-	case int:
-		num = uint16(arg.(int))
-	case uint:
-		num = uint16(arg.(uint))
-	case uint16:
-		num = arg.(uint16)
-	case big.Int:
-		b := arg.(big.Int)
-		num = uint16(b.Uint64())
-	case *big.Int:
-		b := arg.(*big.Int)
-		if b == nil {
-			return nil, fmt.Errorf("cannot convert to uint16, input is nil")
-		}
-		num = uint16(b.Uint64())
-	case string:
-		// Convert the number
-		u64, err := strconv.ParseUint(arg.(string), 10, 16)
-		if err != nil {
-			return nil, err
-		}
-		num = uint16(u64)
-	default:
-		return nil, fmt.Errorf("invalid input type for uint16")
+	value, err := convertToUint(arg, 16, "uint16")
+	if err != nil {
+		return nil, err
 	}
-
+	num := uint16(value)
 	return &num, nil
 }
 
 func ConvertToU32(arg any) (*uint32, error) {
-	var num uint32
-	switch arg.(type) {
-	case int:
-		num = uint32(arg.(int))
-	case uint:
-		num = uint32(arg.(uint))
-	case uint32:
-		num = arg.(uint32)
-	case big.Int:
-		b := arg.(big.Int)
-		num = uint32(b.Uint64())
-	case *big.Int:
-		b := arg.(*big.Int)
-		if b == nil {
-			return nil, fmt.Errorf("cannot convert to uint32, input is nil")
-		}
-		num = uint32(b.Uint64())
-	case string:
-		// Convert the number
-		u64, err := strconv.ParseUint(arg.(string), 10, 32)
-		if err != nil {
-			return nil, err
-		}
-		num = uint32(u64)
-	default:
-		return nil, fmt.Errorf("invalid input type for uint32")
+	value, err := convertToUint(arg, 32, "uint32")
+	if err != nil {
+		return nil, err
 	}
-
+	num := uint32(value)
 	return &num, nil
 }
 
 func ConvertToU64(arg any) (*uint64, error) {
-	var num uint64
-	switch arg.(type) {
-	case int:
-		num = uint64(arg.(int))
-	case uint:
-		num = uint64(arg.(uint))
-	case uint64:
-		num = arg.(uint64)
-	case big.Int:
-		b := arg.(big.Int)
-		num = b.Uint64()
-	case *big.Int:
-		b := arg.(*big.Int)
-		if b == nil {
-			return nil, fmt.Errorf("cannot convert to uint64, input is nil")
-		}
-		num = b.Uint64()
-	case string:
-		// Convert the number
-		u64, err := strconv.ParseUint(arg.(string), 10, 64)
-		if err != nil {
-			return nil, err
-		}
-		num = u64
-	default:
-		return nil, fmt.Errorf("invalid input type for uint64")
+	value, err := convertToUint(arg, 64, "uint64")
+	if err != nil {
+		return nil, err
 	}
-
+	num := uint64(value)
 	return &num, nil
 }
 
 func ConvertToU128(arg any) (num *big.Int, err error) {
-	switch arg.(type) {
-	case int:
-		num = big.NewInt(int64(arg.(int)))
-	case uint:
-		num = big.NewInt(int64(arg.(uint)))
-	case big.Int:
-		b := arg.(big.Int)
-		num = &b
-	case *big.Int:
-		num = arg.(*big.Int)
-		if num == nil {
-			return nil, fmt.Errorf("cannot convert to uint128, input is nil")
-		}
-	case string:
-		// Convert the number
-		num, err = util.StrToBigInt(arg.(string))
-		if err != nil {
-			return nil, err
-		}
-	default:
-		return nil, fmt.Errorf("invalid input type for uint128")
-	}
-
-	return num, nil
+	return convertToBigUint(arg, 128, "uint128")
 }
 
 func ConvertToU256(arg any) (num *big.Int, err error) {
-	switch arg.(type) {
-	case int:
-		num = big.NewInt(int64(arg.(int)))
-	case uint:
-		num = big.NewInt(int64(arg.(uint)))
-	case big.Int:
-		b := arg.(big.Int)
-		num = &b
-	case *big.Int:
-		num = arg.(*big.Int)
-		if num == nil {
-			return nil, fmt.Errorf("cannot convert to uint256, input is nil")
-		}
-	case string:
-		// Convert the number
-		num, err = util.StrToBigInt(arg.(string))
-		if err != nil {
-			return nil, err
-		}
-	default:
-		return nil, fmt.Errorf("invalid input type for uint256")
-	}
-
-	return num, nil
+	return convertToBigUint(arg, 256, "uint256")
 }
 
 func ConvertToBool(arg any) (b bool, err error) {
@@ -616,12 +629,33 @@ func ConvertToVector(typeArg TypeTag, arg any, generics []TypeTag) (out []byte,
 		return ConvertToVectorGeneric(typeArg, arg, generics)
 	case *ReferenceTag:
 		return ConvertToVectorReference(typeArg, arg, generics)
-	// TODO: Handle structs
 	default:
-		return nil, fmt.Errorf("%s is currently not supported as an input type", typeArg.String())
+		// Structs and nested vectors convert each element on its own e.g. vector<0x1::string::String> from []string
+		return convertToVectorAny(typeArg, arg, generics)
 	}
 }
 
+// convertToVectorAny returns the BCS encoded vector of any slice, converting each element as the inner type
+func convertToVectorAny(innerType TypeTag, arg any, generics []TypeTag) (b []byte, err error) {
+	value := reflect.ValueOf(arg)
+	if arg == nil || (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) {
+		return nil, fmt.Errorf("invalid input type %T for vector<%s>", arg, innerType.String())
+	}
+
+	b, err = bcs.SerializeUleb128(uint32(value.Len()))
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < value.Len(); i++ {
+		item, err := ConvertArg(innerType, value.Index(i).Interface(), generics)
+		if err != nil {
+			return nil, fmt.Errorf("vector<%s> item %d: %w", innerType.String(), i, err)
+		}
+		b = append(b, item...)
+	}
+	return b, nil
+}
+
 func ConvertArg(typeArg TypeTag, arg any, generics []TypeTag) (b []byte, err error) {
 	switch typeArg.Value.(type) {
 	case *U8Tag:
@@ -691,6 +725,10 @@ func ConvertArg(typeArg TypeTag, arg any, generics []TypeTag) (b []byte, err err
 		// 1. Hex strings are allowed for vector<u8>
 		// 2. Otherwise, everything is just parsed as an array of the inner type
 		vecTag := typeArg.Value.(*VectorTag)
+		if items, ok := arg.([]any); ok && items != nil {
+			// JSON arrays decode to []any, so convert each item on its own
+			return convertToVectorAny(vecTag.TypeParam, items, generics)
+		}
 		switch vecTag.TypeParam.Value.(type) {
 		case *U8Tag:
 			return ConvertToVectorU8(arg)
@@ -732,6 +770,18 @@ func ConvertArg(typeArg TypeTag, arg any, generics []TypeTag) (b []byte, err err
 					// Get inner type
 					typeParam := structTag.TypeParams[0]
 
+					// Unwrap the API's JSON form of an option e.g. {"vec": ["1"]}
+					if wrapped, ok := arg.(map[string]any); ok {
+						vec, ok := wrapped["vec"].([]any)
+						if !ok || len(wrapped) != 1 || len(vec) > 1 {
+							return nil, fmt.Errorf("invalid input for option, expected {\"vec\": []} with at most one item")
+						}
+						arg = nil
+						if len(vec) == 1 {
+							arg = vec[0]
+						}
+					}
+
 					// Handle special case of "none", it's a single 0 byte
 					if arg == nil {
 						return bcs.SerializeU8(0)
@@ -681,3 +681,40 @@ func TestConvertToVectorU8(t *testing.T) {
 }
 
 // ... existing code ...
+
+func TestNewEntryFunction(t *testing.T) {
+	receiver := AccountTwo
+	entry, err := NewEntryFunction(ModuleId{Address: AccountOne, Name: "aptos_account"}, "transfer",
+		nil, []string{"&signer", "address", "u64"}, []any{receiver, 100})
+	assert.NoError(t, err)
+
+	expected, err := CoinTransferPayload(nil, receiver, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Args, entry.Args)
+
+	// Generics are resolved from the type arguments
+	entry, err = NewEntryFunction(ModuleId{Address: AccountOne, Name: "test"}, "generic",
+		[]any{"u16"}, []string{"T0", "vector<T0>"}, []any{uint16(1), []any{uint16(2), uint16(3)}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x01, 0x00}, {0x02, 0x02, 0x00, 0x03, 0x00}}, entry.Args)
+	assert.Equal(t, "u16", entry.ArgTypes[0].String())
+
+	// Vectors of structs take native slices
+	entry, err = NewEntryFunction(ModuleId{Address: AccountOne, Name: "test"}, "strings",
+		nil, []string{"vector<0x1::string::String>"}, []any{[]string{"a", "bc"}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x02, 0x01, 'a', 0x02, 'b', 'c'}}, entry.Args)
+
+	// Wrong number of args
+	_, err = NewEntryFunction(ModuleId{Address: AccountOne, Name: "aptos_account"}, "transfer",
+		nil, []string{"address", "u64"}, []any{receiver})
+	assert.Error(t, err)
+
+	// Out of range numbers are rejected rather than truncated
+	_, err = NewEntryFunction(ModuleId{Address: AccountOne, Name: "test"}, "small",
+		nil, []string{"u8"}, []any{256})
+	assert.ErrorContains(t, err, "overflows")
+	_, err = NewEntryFunction(ModuleId{Address: AccountOne, Name: "test"}, "negative",
+		nil, []string{"u64"}, []any{-1})
+	assert.ErrorContains(t, err, "negative")
+}
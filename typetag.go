@@ -54,6 +54,10 @@ type parseInfo struct {
 	str           string
 }
 
+// ParseTypeTag parses a Move type string e.g. "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>" into a [TypeTag], including
+// nested generics, vectors, references, and generic type parameters e.g. T0.
+//
+// The result round-trips with [TypeTag.String], which outputs the canonical form without whitespace.
 func ParseTypeTag(inputStr string) (*TypeTag, error) {
 	inputRunes := []rune(inputStr)
 	// Represents the stack of types currently being processed
@@ -158,9 +162,8 @@ func ParseTypeTag(inputStr string) (*TypeTag, error) {
 				cur += 1
 			}
 
-			// Next char must be a comma or a closing > if something was parsed before it
-			nextChar := inputRunes[cur]
-			if cur < len(inputRunes) && parsedTypeTag && nextChar != ',' && nextChar != '>' {
+			// Next char must be a comma or a closing > if something was parsed before it, trailing whitespace is fine
+			if cur < len(inputRunes) && parsedTypeTag && inputRunes[cur] != ',' && inputRunes[cur] != '>' {
 				return nil, fmt.Errorf("unexpected character at top level type")
 			}
 
@@ -249,6 +252,14 @@ func ParseTypeTagInner(input string, types []TypeTag) (*TypeTag, error) {
 		}
 		return &TypeTag{Value: &VectorTag{TypeParam: types[0]}}, nil
 	default:
+		// If it's a registered extension
+		if extension, ok := typeTagExtensionByName(str); ok {
+			if len(types) > 0 {
+				return nil, fmt.Errorf("invalid type tag, primitive with generics")
+			}
+			return &TypeTag{Value: extension}, nil
+		}
+
 		// If it's a reference
 		if strings.HasPrefix(str, "&") {
 			actualType, _ := strings.CutPrefix(str, "&")
@@ -352,8 +363,12 @@ func (tt *TypeTag) UnmarshalBCS(des *bcs.Deserializer) {
 	case TypeTagStruct:
 		tt.Value = &StructTag{}
 	default:
-		des.SetError(fmt.Errorf("unknown TypeTag enum %d", variant))
-		return
+		extension, ok := typeTagExtensionByVariant(variant)
+		if !ok {
+			des.SetError(fmt.Errorf("unknown TypeTag enum %d", variant))
+			return
+		}
+		tt.Value = extension
 	}
 	des.Struct(tt.Value)
 }
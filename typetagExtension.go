@@ -0,0 +1,108 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// TypeTagExtension describes a [TypeTag] variant that isn't built into the SDK, e.g. a custom primitive type on another
+// network, or a new variant the SDK doesn't support yet.  Register it with [RegisterTypeTagExtension].
+type TypeTagExtension struct {
+	Variant TypeTagVariant     // Variant is the BCS enum index of the type tag
+	Name    string             // Name is the Move name of a type without type parameters e.g. "i64", so [ParseTypeTag] can parse it, optional
+	New     func() TypeTagImpl // New creates an empty [TypeTagImpl] to deserialize the variant into, its GetType must return Variant
+}
+
+// typeTagExtensions holds the registered [TypeTagExtension]s, by variant and by name
+var typeTagExtensions = struct {
+	sync.RWMutex
+	byVariant map[TypeTagVariant]TypeTagExtension
+	byName    map[string]TypeTagExtension
+}{
+	byVariant: make(map[TypeTagVariant]TypeTagExtension),
+	byName:    make(map[string]TypeTagExtension),
+}
+
+// builtinTypeTagNames are the names [ParseTypeTagInner] handles itself
+var builtinTypeTagNames = map[string]bool{
+	"bool": true, "u8": true, "u16": true, "u32": true, "u64": true, "u128": true, "u256": true,
+	"address": true, "signer": true, "vector": true,
+}
+
+// RegisterTypeTagExtension opts in to deserializing and parsing an additional [TypeTag] variant.  Without it, unknown
+// variants fail deserialization.  Built-in variants can't be replaced, and each variant and name can only be
+// registered once.  It is safe for concurrent use, but is meant to be called at init time.
+//
+//	err := RegisterTypeTagExtension(TypeTagExtension{
+//		Variant: 11,
+//		Name:    "i64",
+//		New:     func() TypeTagImpl { return &I64Tag{} },
+//	})
+func RegisterTypeTagExtension(extension TypeTagExtension) error {
+	if extension.New == nil {
+		return errors.New("type tag extension must have a New function")
+	}
+	if isBuiltinTypeTagVariant(extension.Variant) {
+		return fmt.Errorf("type tag variant %d is built in", extension.Variant)
+	}
+	if got := extension.New().GetType(); got != extension.Variant {
+		return fmt.Errorf("type tag extension for variant %d creates variant %d", extension.Variant, got)
+	}
+	if builtinTypeTagNames[extension.Name] {
+		return fmt.Errorf("type tag name %s is built in", extension.Name)
+	}
+
+	typeTagExtensions.Lock()
+	defer typeTagExtensions.Unlock()
+	if _, ok := typeTagExtensions.byVariant[extension.Variant]; ok {
+		return fmt.Errorf("type tag variant %d is already registered", extension.Variant)
+	}
+	if _, ok := typeTagExtensions.byName[extension.Name]; ok && extension.Name != "" {
+		return fmt.Errorf("type tag name %s is already registered", extension.Name)
+	}
+	typeTagExtensions.byVariant[extension.Variant] = extension
+	if extension.Name != "" {
+		typeTagExtensions.byName[extension.Name] = extension
+	}
+	return nil
+}
+
+// UnregisterTypeTagExtension removes a variant registered with [RegisterTypeTagExtension], mostly for tests
+func UnregisterTypeTagExtension(variant TypeTagVariant) {
+	typeTagExtensions.Lock()
+	defer typeTagExtensions.Unlock()
+	extension, ok := typeTagExtensions.byVariant[variant]
+	if !ok {
+		return
+	}
+	delete(typeTagExtensions.byVariant, variant)
+	delete(typeTagExtensions.byName, extension.Name)
+}
+
+// isBuiltinTypeTagVariant checks if the variant is handled by [TypeTag.UnmarshalBCS] itself
+func isBuiltinTypeTagVariant(variant TypeTagVariant) bool {
+	return variant <= TypeTagU256 || variant == TypeTagGeneric || variant == TypeTagReference
+}
+
+// typeTagExtensionByVariant creates an empty [TypeTagImpl] for a registered variant
+func typeTagExtensionByVariant(variant TypeTagVariant) (TypeTagImpl, bool) {
+	typeTagExtensions.RLock()
+	defer typeTagExtensions.RUnlock()
+	extension, ok := typeTagExtensions.byVariant[variant]
+	if !ok {
+		return nil, false
+	}
+	return extension.New(), true
+}
+
+// typeTagExtensionByName creates an empty [TypeTagImpl] for a registered name
+func typeTagExtensionByName(name string) (TypeTagImpl, bool) {
+	typeTagExtensions.RLock()
+	defer typeTagExtensions.RUnlock()
+	extension, ok := typeTagExtensions.byName[name]
+	if !ok {
+		return nil, false
+	}
+	return extension.New(), true
+}
@@ -0,0 +1,77 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+const testI64TagVariant = TypeTagVariant(11)
+const testBoxTagVariant = TypeTagVariant(12)
+
+// testI64Tag is a custom primitive type tag with no payload
+type testI64Tag struct{}
+
+func (xt *testI64Tag) String() string                   { return "i64" }
+func (xt *testI64Tag) GetType() TypeTagVariant          { return testI64TagVariant }
+func (xt *testI64Tag) MarshalBCS(_ *bcs.Serializer)     {}
+func (xt *testI64Tag) UnmarshalBCS(_ *bcs.Deserializer) {}
+
+// testBoxTag is a custom type tag with a nested type tag payload
+type testBoxTag struct {
+	Inner TypeTag
+}
+
+func (xt *testBoxTag) String() string                     { return "box<" + xt.Inner.String() + ">" }
+func (xt *testBoxTag) GetType() TypeTagVariant            { return testBoxTagVariant }
+func (xt *testBoxTag) MarshalBCS(ser *bcs.Serializer)     { ser.Struct(&xt.Inner) }
+func (xt *testBoxTag) UnmarshalBCS(des *bcs.Deserializer) { des.Struct(&xt.Inner) }
+
+func TestTypeTagExtension(t *testing.T) {
+	i64Tag := TypeTag{Value: &testI64Tag{}}
+	boxTag := TypeTag{Value: &testBoxTag{Inner: NewTypeTag(NewVectorTag(&testI64Tag{}))}}
+	i64Bytes, err := bcs.Serialize(&i64Tag)
+	assert.NoError(t, err)
+	boxBytes, err := bcs.Serialize(&boxTag)
+	assert.NoError(t, err)
+
+	// Unknown variants fail until registered
+	assert.Error(t, bcs.Deserialize(&TypeTag{}, i64Bytes))
+	_, err = ParseTypeTag("vector<i64>")
+	assert.Error(t, err)
+
+	assert.NoError(t, RegisterTypeTagExtension(TypeTagExtension{Variant: testI64TagVariant, Name: "i64", New: func() TypeTagImpl { return &testI64Tag{} }}))
+	defer UnregisterTypeTagExtension(testI64TagVariant)
+	assert.NoError(t, RegisterTypeTagExtension(TypeTagExtension{Variant: testBoxTagVariant, New: func() TypeTagImpl { return &testBoxTag{} }}))
+	defer UnregisterTypeTagExtension(testBoxTagVariant)
+
+	deserialized := TypeTag{}
+	assert.NoError(t, bcs.Deserialize(&deserialized, i64Bytes))
+	assert.Equal(t, i64Tag, deserialized)
+	deserialized = TypeTag{}
+	assert.NoError(t, bcs.Deserialize(&deserialized, boxBytes))
+	assert.Equal(t, boxTag, deserialized)
+	assert.Equal(t, "box<vector<i64>>", deserialized.String())
+
+	parsed, err := ParseTypeTag("0x1::table::Table<i64, vector<i64>>")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1::table::Table<i64,vector<i64>>", parsed.String())
+	_, err = ParseTypeTag("i64<u8>")
+	assert.Error(t, err)
+
+	// Built in and already registered variants and names can't be registered
+	newI64 := func() TypeTagImpl { return &testI64Tag{} }
+	assert.Error(t, RegisterTypeTagExtension(TypeTagExtension{Variant: testI64TagVariant, New: newI64}))
+	assert.Error(t, RegisterTypeTagExtension(TypeTagExtension{Variant: TypeTagU8, New: func() TypeTagImpl { return &U8Tag{} }}))
+	assert.Error(t, RegisterTypeTagExtension(TypeTagExtension{Variant: testBoxTagVariant + 1, Name: "u8", New: newI64}))
+	assert.Error(t, RegisterTypeTagExtension(TypeTagExtension{Variant: testBoxTagVariant + 1}))
+
+	// New must create the registered variant
+	assert.Error(t, RegisterTypeTagExtension(TypeTagExtension{Variant: testBoxTagVariant + 1, New: newI64}))
+
+	UnregisterTypeTagExtension(testI64TagVariant)
+	assert.Error(t, bcs.Deserialize(&TypeTag{}, i64Bytes))
+	_, err = ParseTypeTag("i64")
+	assert.Error(t, err)
+}
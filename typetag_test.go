@@ -209,3 +209,29 @@ func TestParseTypeTag(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTypeTagRoundTrip(t *testing.T) {
+	inputs := []string{
+		"0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+		"vector<vector<u8>>",
+		"0x1::object::Object<0x1::fungible_asset::Metadata>",
+		"0x1::option::Option<vector<0x1::string::String>>",
+		"0x1::pool::Pool<u8,0x1::a::B<T0,address>>",
+		"&signer",
+		"T1",
+	}
+	for _, input := range inputs {
+		tag, err := ParseTypeTag(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, input, tag.String())
+
+		again, err := ParseTypeTag(tag.String())
+		assert.NoError(t, err)
+		assert.Equal(t, tag, again)
+	}
+
+	// Whitespace is accepted but not output
+	tag, err := ParseTypeTag("0x1::pool::Pool<u8, 0x1::a::B<T0, address> > ")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1::pool::Pool<u8,0x1::a::B<T0,address>>", tag.String())
+}
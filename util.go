@@ -2,6 +2,7 @@ package aptos
 
 import (
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+	"io"
 	"math/big"
 )
 
@@ -13,6 +14,23 @@ func ParseHex(hexStr string) ([]byte, error) {
 	return util.ParseHex(hexStr)
 }
 
+// NewHexDecoder returns a reader that decodes hex from r, with or without a leading 0x.  Use this rather than [ParseHex]
+// for large blobs e.g. module bytecode, to avoid holding the hex string in memory.
+func NewHexDecoder(r io.Reader) io.Reader {
+	return util.NewHexDecoder(r)
+}
+
+// EncodeHex streams the 0x prefixed hex encoding of r to w, returning the number of bytes read from r
+func EncodeHex(w io.Writer, r io.Reader) (int64, error) {
+	return util.EncodeHex(w, r)
+}
+
+// DecodeHex streams the decoded bytes of hex from r, with or without a leading 0x, to w, returning the number of bytes
+// written to w
+func DecodeHex(w io.Writer, r io.Reader) (int64, error) {
+	return util.DecodeHex(w, r)
+}
+
 // Sha3256Hash takes a hash of the given sets of bytes
 func Sha3256Hash(bytes [][]byte) (output []byte) {
 	return util.Sha3256Hash(bytes)
@@ -1,8 +1,11 @@
 package aptos
 
 import (
+	"bytes"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -73,3 +76,31 @@ func TestStrToBigIntError(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestStreamingHex(t *testing.T) {
+	blob := make([]byte, 100_000)
+	for i := range blob {
+		blob[i] = byte(i)
+	}
+
+	encoded := &bytes.Buffer{}
+	n, err := EncodeHex(encoded, bytes.NewReader(blob))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(blob)), n)
+	assert.Equal(t, BytesToHex(blob), encoded.String())
+
+	// With and without the prefix
+	for _, input := range []string{encoded.String(), encoded.String()[2:]} {
+		decoded := &bytes.Buffer{}
+		n, err = DecodeHex(decoded, strings.NewReader(input))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(blob)), n)
+		assert.Equal(t, blob, decoded.Bytes())
+	}
+
+	decoded, err := io.ReadAll(NewHexDecoder(strings.NewReader("0x")))
+	assert.NoError(t, err)
+	assert.Empty(t, decoded)
+	_, err = io.ReadAll(NewHexDecoder(strings.NewReader("0xzz")))
+	assert.Error(t, err)
+}
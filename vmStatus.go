@@ -0,0 +1,166 @@
+package aptos
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AbortReason is the name and doc comment of the constant of an abort code e.g. ECOIN_STORE_NOT_PUBLISHED
+type AbortReason struct {
+	Name        string // Name of the error constant
+	Description string // Description is the doc comment of the constant
+}
+
+// ErrorMap is the abort reasons of Move modules, by module e.g. 0x1::coin or 0xcafe::vault, then by the reason of the
+// abort code, its low 16 bits
+type ErrorMap map[string]map[uint64]AbortReason
+
+// VmStatus is a vm_status of a transaction, parsed by [ParseVmStatus]
+type VmStatus struct {
+	Kind        ExecutionStatusKind // Kind of outcome
+	Location    *ModuleId           // Location is the module that aborted or failed, nil for a script or success
+	Function    string              // Function is the name of the function that failed, for an execution failure
+	Code        uint64              // Code is the abort code
+	CodeOffset  uint16              // CodeOffset is the instruction that failed, for an execution failure
+	ReasonName  string              // ReasonName is the name of the abort code's constant, if known
+	Description string              // Description is the doc comment of the abort code's constant, if known
+	Raw         string              // Raw is the vm_status as given
+}
+
+var (
+	moveAbortPattern       = regexp.MustCompile(`(?s)^Move abort in (\S+): (?:([A-Za-z_][A-Za-z0-9_]*)\((0x[0-9a-fA-F]+)\)(?:: (.*))?|(0x[0-9a-fA-F]+|[0-9]+))$`)
+	executionFailedPattern = regexp.MustCompile(`^Execution failed in (\S+) at code offset ([0-9]+)$`)
+)
+
+// ParseVmStatus parses the vm_status of a transaction, e.g. from a simulation, into its outcome.  A Move abort without a
+// reason name is looked up in the errorMaps, if given.  Anything not understood is a
+// [ExecutionStatusMiscellaneousError] with only Raw set.
+//
+//	status := aptos.ParseVmStatus("Move abort in 0x1::coin: ECOIN_STORE_NOT_PUBLISHED(0x60005): Account hasn't registered `CoinStore` for `CoinType`")
+//	status.ReasonName // ECOIN_STORE_NOT_PUBLISHED
+//	status.Message()  // 0x1::coin aborted with ECOIN_STORE_NOT_PUBLISHED: Account hasn't registered `CoinStore` for `CoinType`
+func ParseVmStatus(vmStatus string, errorMaps ...ErrorMap) VmStatus {
+	status := VmStatus{Kind: ExecutionStatusMiscellaneousError, Raw: vmStatus}
+	switch {
+	case vmStatus == "Executed successfully":
+		status.Kind = ExecutionStatusSuccess
+	case vmStatus == "Out of gas":
+		status.Kind = ExecutionStatusOutOfGas
+	case strings.HasPrefix(vmStatus, "Move abort in "):
+		match := moveAbortPattern.FindStringSubmatch(vmStatus)
+		if match == nil {
+			return status
+		}
+		codeStr := match[3]
+		if codeStr == "" {
+			codeStr = match[5]
+		}
+		code, err := strconv.ParseUint(codeStr, 0, 64)
+		if err != nil {
+			return status
+		}
+		status.Kind = ExecutionStatusMoveAbort
+		status.Location = parseAbortLocation(match[1])
+		status.Code = code
+		status.ReasonName = match[2]
+		status.Description = match[4]
+		if status.ReasonName == "" && status.Location != nil {
+			status.lookupReason(errorMaps)
+		}
+	case strings.HasPrefix(vmStatus, "Execution failed in "):
+		match := executionFailedPattern.FindStringSubmatch(vmStatus)
+		if match == nil {
+			return status
+		}
+		codeOffset, err := strconv.ParseUint(match[2], 10, 16)
+		if err != nil {
+			return status
+		}
+		status.Kind = ExecutionStatusExecutionFailure
+		status.CodeOffset = uint16(codeOffset)
+		location, function := match[1], ""
+		if i := strings.LastIndex(location, "::"); i >= 0 {
+			location, function = location[:i], location[i+2:]
+		}
+		status.Location = parseAbortLocation(location)
+		status.Function = function
+	}
+	return status
+}
+
+// lookupReason sets the reason of the abort code from the first error map that has it
+func (status *VmStatus) lookupReason(errorMaps []ErrorMap) {
+	location := status.Location.String()
+	for _, errorMap := range errorMaps {
+		for module, reasons := range errorMap {
+			if normalizeMoveId(module) != location {
+				continue
+			}
+			if reason, ok := reasons[status.Reason()]; ok {
+				status.ReasonName = reason.Name
+				status.Description = reason.Description
+				return
+			}
+		}
+	}
+}
+
+// parseAbortLocation parses a module e.g. 0x1::coin, nil for a script or anything else
+func parseAbortLocation(location string) *ModuleId {
+	addressStr, name, found := strings.Cut(location, "::")
+	if !found || name == "" || strings.Contains(name, "::") {
+		return nil
+	}
+	module := &ModuleId{Name: name}
+	if err := module.Address.ParseStringRelaxed(addressStr); err != nil {
+		return nil
+	}
+	return module
+}
+
+// Success tells if the transaction succeeded
+func (status VmStatus) Success() bool {
+	return status.Kind == ExecutionStatusSuccess
+}
+
+// Category is the category of an abort code in the std::error convention e.g. 0x6 for NOT_FOUND
+func (status VmStatus) Category() uint64 {
+	return (status.Code >> 16) & 0xff
+}
+
+// Reason is the module specific reason of an abort code in the std::error convention, its low 16 bits
+func (status VmStatus) Reason() uint64 {
+	return status.Code & 0xffff
+}
+
+// Message is a message for users e.g. 0x1::coin aborted with EINSUFFICIENT_BALANCE: Not enough coins to complete
+// transaction
+func (status VmStatus) Message() string {
+	location := "script"
+	if status.Location != nil {
+		location = status.Location.String()
+	}
+	switch status.Kind {
+	case ExecutionStatusSuccess:
+		return "Executed successfully"
+	case ExecutionStatusOutOfGas:
+		return "Out of gas"
+	case ExecutionStatusMoveAbort:
+		switch {
+		case status.ReasonName != "" && status.Description != "":
+			return fmt.Sprintf("%s aborted with %s: %s", location, status.ReasonName, status.Description)
+		case status.ReasonName != "":
+			return fmt.Sprintf("%s aborted with %s (%#x)", location, status.ReasonName, status.Code)
+		default:
+			return fmt.Sprintf("%s aborted with code %#x", location, status.Code)
+		}
+	case ExecutionStatusExecutionFailure:
+		if status.Function != "" {
+			location += "::" + status.Function
+		}
+		return fmt.Sprintf("%s failed at code offset %d", location, status.CodeOffset)
+	}
+	return status.Raw
+}
@@ -0,0 +1,49 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVmStatus(t *testing.T) {
+	status := ParseVmStatus("Move abort in 0x1::coin: ECOIN_STORE_NOT_PUBLISHED(0x60005): Account hasn't registered `CoinStore` for `CoinType`")
+	assert.Equal(t, ExecutionStatusMoveAbort, status.Kind)
+	assert.False(t, status.Success())
+	assert.Equal(t, &ModuleId{Address: AccountOne, Name: "coin"}, status.Location)
+	assert.Equal(t, uint64(0x60005), status.Code)
+	assert.Equal(t, uint64(6), status.Category())
+	assert.Equal(t, uint64(5), status.Reason())
+	assert.Equal(t, "ECOIN_STORE_NOT_PUBLISHED", status.ReasonName)
+	assert.Equal(t, "Account hasn't registered `CoinStore` for `CoinType`", status.Description)
+	assert.Equal(t, "0x1::coin aborted with ECOIN_STORE_NOT_PUBLISHED: Account hasn't registered `CoinStore` for `CoinType`", status.Message())
+
+	// Reasons missing from the status come from the error map
+	errorMap := ErrorMap{"0xcafe::vault": {3: {Name: "EVAULT_LOCKED", Description: "The vault is locked"}}}
+	status = ParseVmStatus("Move abort in 0xcafe::vault: 0x50003", errorMap)
+	assert.Equal(t, uint64(0x50003), status.Code)
+	assert.Equal(t, "EVAULT_LOCKED", status.ReasonName)
+	assert.Equal(t, status.Location.String()+" aborted with EVAULT_LOCKED: The vault is locked", status.Message())
+	status = ParseVmStatus("Move abort in 0xcafe::other: 0x50003", errorMap)
+	assert.Equal(t, "", status.ReasonName)
+	assert.Equal(t, status.Location.String()+" aborted with code 0x50003", status.Message())
+
+	status = ParseVmStatus("Move abort in script: 0x10")
+	assert.Equal(t, ExecutionStatusMoveAbort, status.Kind)
+	assert.Nil(t, status.Location)
+	assert.Equal(t, "script aborted with code 0x10", status.Message())
+
+	status = ParseVmStatus("Execution failed in 0x1::coin::transfer at code offset 12")
+	assert.Equal(t, ExecutionStatusExecutionFailure, status.Kind)
+	assert.Equal(t, "coin", status.Location.Name)
+	assert.Equal(t, "transfer", status.Function)
+	assert.Equal(t, uint16(12), status.CodeOffset)
+	assert.Equal(t, "0x1::coin::transfer failed at code offset 12", status.Message())
+
+	assert.True(t, ParseVmStatus("Executed successfully").Success())
+	assert.Equal(t, ExecutionStatusOutOfGas, ParseVmStatus("Out of gas").Kind)
+	status = ParseVmStatus("Transaction Executed and Committed with Error LOOKUP_FAILED")
+	assert.Equal(t, ExecutionStatusMiscellaneousError, status.Kind)
+	assert.Equal(t, "Transaction Executed and Committed with Error LOOKUP_FAILED", status.Message())
+	assert.Equal(t, ExecutionStatusMiscellaneousError, ParseVmStatus("Move abort in 0x1::coin: garbage").Kind)
+}
@@ -0,0 +1,67 @@
+package aptos
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// DefaultWaitConcurrency is the default number of transactions [NodeClient.WaitForTransactions] waits on at once
+const DefaultWaitConcurrency = 8
+
+// WaitConcurrency is an option to WaitForTransactions, the number of transactions waited on at once
+type WaitConcurrency int
+
+// TransactionWaitResult is the outcome of waiting on one transaction with [NodeClient.WaitForTransactions]
+type TransactionWaitResult struct {
+	Hash        string               // Hash of the transaction waited on
+	Transaction *api.UserTransaction // Transaction is the committed transaction, nil if it didn't commit in time
+	Err         error                // Err is the error waiting on the transaction, see [NodeClient.WaitForTransaction]
+}
+
+// WaitForTransactions waits on many transactions in parallel, and returns the result of each, in the same order as
+// hashes.  One transaction failing or timing out doesn't stop the others being waited on.
+//
+// Accepts the options of [NodeClient.WaitForTransaction], applied to each transaction, and:
+//   - WaitConcurrency: int, how many transactions to wait on at once. Default [DefaultWaitConcurrency].
+func (rc *NodeClient) WaitForTransactions(hashes []string, options ...any) ([]TransactionWaitResult, error) {
+	concurrency := DefaultWaitConcurrency
+	waitOptions := make([]any, 0, len(options))
+	for _, option := range options {
+		if value, ok := option.(WaitConcurrency); ok {
+			if value < 1 {
+				return nil, fmt.Errorf("WaitConcurrency must be at least 1, got %d", value)
+			}
+			concurrency = int(value)
+			continue
+		}
+		waitOptions = append(waitOptions, option)
+	}
+	// Check the options once, rather than failing every transaction
+	_, err := getTransactionPollOptions(100*time.Millisecond, 10*time.Second, waitOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]TransactionWaitResult, len(hashes))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(hashes)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				txn, err := rc.WaitForTransaction(hashes[i], waitOptions...)
+				results[i] = TransactionWaitResult{Hash: hashes[i], Transaction: txn, Err: err}
+			}
+		}()
+	}
+	for i := range hashes {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	return results, nil
+}